@@ -1,3 +1,8 @@
+//go:build ignore
+
+// Every file in this folder is a standalone program (go run 02_ocp.go), so the
+// ignore tag keeps `go build ./...` from compiling them as one package.
+
 package main
 
 import (
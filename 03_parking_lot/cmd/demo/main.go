@@ -0,0 +1,8 @@
+// Command demo runs the parkinglot walkthrough: go run ./03_parking_lot/cmd/demo
+package main
+
+import parkinglot "github.com/ayushgupta5/GoLLD/03_parking_lot"
+
+func main() {
+	parkinglot.RunDemo()
+}
@@ -0,0 +1,655 @@
+package parkinglot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ratelimiter "github.com/ayushgupta5/GoLLD/09_rate_limiter"
+)
+
+// ============================================================
+// SECTION 16: MAIN FUNCTION - DEMO
+// ============================================================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("=================================================")
+	fmt.Println("     PARKING LOT SYSTEM - LOW LEVEL DESIGN DEMO")
+	fmt.Println("=================================================")
+	fmt.Println()
+
+	// ----- Step 1: Create the Parking Lot -----
+	// Configuration: 2 floors
+	// Each floor has: 5 small spots, 10 medium spots, 3 large spots
+	parkingLotConfig := []FloorConfig{
+		{5, 10, 3}, // Floor 1: 5 small, 10 medium, 3 large
+		{5, 10, 3}, // Floor 2: 5 small, 10 medium, 3 large
+	}
+
+	parkingLot := NewParkingLot("City Center Parking", parkingLotConfig)
+
+	// Show initial state
+	fmt.Println(">>> Initial Parking Lot State:")
+	parkingLot.DisplayAvailability()
+
+	// ----- Step 2: Park Some Vehicles -----
+	fmt.Println("\n>>> Parking Vehicles...")
+
+	motorcycle1 := NewMotorcycle("BIKE-001")
+	if _, err := parkingLot.ParkVehicle(motorcycle1); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	car1 := NewCar("CAR-1234")
+	if _, err := parkingLot.ParkVehicle(car1); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	car2 := NewCar("CAR-5678")
+	if _, err := parkingLot.ParkVehicle(car2); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	truck1 := NewTruck("TRUCK-01")
+	if _, err := parkingLot.ParkVehicle(truck1); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	// Show state after parking
+	fmt.Println("\n>>> After Parking 4 Vehicles:")
+	parkingLot.DisplayAvailability()
+
+	// ----- Step 3: Try Parking Same Vehicle Again (Error Case) -----
+	fmt.Println("\n>>> Testing: Try to park same vehicle again...")
+	_, err := parkingLot.ParkVehicle(car1)
+	if err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	// ----- Step 4: Exit Some Vehicles (Process Payments) -----
+	fmt.Println("\n>>> Vehicles Exiting...")
+
+	// Car exits with cash payment
+	if _, err := parkingLot.UnparkVehicle("CAR-1234", &CashPayment{}); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	// Truck exits with card payment
+	if _, err := parkingLot.UnparkVehicle("TRUCK-01", NewCardPayment("4111222233334444")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	// Show state after exits
+	fmt.Println("\n>>> After 2 Vehicles Exited:")
+	parkingLot.DisplayAvailability()
+
+	// ----- Step 5: More Vehicles Arrive -----
+	fmt.Println("\n>>> More Vehicles Arriving...")
+
+	if _, err := parkingLot.ParkVehicle(NewCar("CAR-9999")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	if _, err := parkingLot.ParkVehicle(NewMotorcycle("BIKE-002")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	if _, err := parkingLot.ParkVehicle(NewTruck("TRUCK-02")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	// Show final state
+	fmt.Println("\n>>> Final Parking Lot State:")
+	parkingLot.DisplayAvailability()
+
+	// ----- Step 6: Maintenance Closures -----
+	fmt.Println("\n>>> Scheduling Maintenance Closures...")
+
+	now := time.Now()
+
+	// Floor 1 is resurfaced starting now; anything parked there must move
+	floorClosure, err := parkingLot.ScheduleFloorClosure(1, "Floor resurfacing", now, now.Add(4*time.Hour))
+	if err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	// Two truck bays on floor 2 are reserved for an event tomorrow (not active yet)
+	tomorrow := now.Add(24 * time.Hour)
+	if _, err := parkingLot.ScheduleSpotClosure([]string{"F2-S17", "F2-S18"}, "Food truck event",
+		tomorrow, tomorrow.Add(6*time.Hour)); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	fmt.Println("\n>>> Vehicles Flagged for Relocation:")
+	for _, ticket := range parkingLot.GetVehiclesToRelocate() {
+		fmt.Printf("  [FLAGGED] %s in closed Spot %s\n", ticket.vehiclePlate, ticket.GetSpotLabel())
+		if _, err := parkingLot.RelocateVehicle(ticket.vehiclePlate); err != nil {
+			fmt.Printf("  [ERROR] %v\n", err)
+		}
+	}
+
+	fmt.Println("\n>>> New Arrival During Closure:")
+	if _, err := parkingLot.ParkVehicle(NewCar("CAR-7777")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	parkingLot.DisplayAvailability()
+
+	fmt.Println("\n>>> Resurfacing Finished Early:")
+	if err := parkingLot.CancelClosure(floorClosure.GetID()); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	parkingLot.DisplayAvailability()
+
+	// ----- Step 7: Find My Car + Lot Map -----
+	fmt.Println("\n>>> Finding Vehicles by License Plate:")
+	for _, licensePlate := range []string{"CAR-5678", "NOPE-000"} {
+		location, err := parkingLot.FindVehicle(licensePlate)
+		if err != nil {
+			fmt.Printf("  [ERROR] %v\n", err)
+			continue
+		}
+		fmt.Printf("  [FOUND] %s is on Floor %d, Spot %s (ticket %s)\n",
+			location.LicensePlate, location.FloorNumber, location.SpotID, location.TicketID)
+	}
+
+	// Close one spot so the map shows every status
+	if _, err := parkingLot.ScheduleSpotClosure([]string{"F1-S10"}, "Broken light", now, now.Add(time.Hour)); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	fmt.Println("\n>>> Lot Map (ASCII):")
+	lotMap := parkingLot.BuildLotMap(6)
+	var renderer MapRenderer = &ASCIIMapRenderer{}
+	if asciiMap, err := renderer.Render(lotMap); err == nil {
+		fmt.Print(asciiMap)
+	}
+	renderer = &JSONMapRenderer{}
+	if jsonMap, err := renderer.Render(lotMap); err == nil {
+		fmt.Printf("\n>>> Lot Map (JSON, %d bytes):\n  %s...\n", len(jsonMap), jsonMap[:120])
+	}
+
+	// ----- Step 8: UPI & Wallet Payments, Receipts, Refunds -----
+	fmt.Println("\n>>> UPI and Wallet Payments:")
+
+	bikeTicket, err := parkingLot.UnparkVehicle("BIKE-002", NewUPIPayment("alice@okbank"))
+	if err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	// An empty wallet is declined, so the vehicle stays parked
+	if _, err := parkingLot.UnparkVehicle("TRUCK-02", NewWalletPayment("W-EMPTY", 0)); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	wallet := NewWalletPayment("W-1001", 20)
+	truckTicket, err := parkingLot.UnparkVehicle("TRUCK-02", wallet)
+	if err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	if _, err := parkingLot.UnparkVehicle("CAR-9999", NewUPIPayment("no-handle")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	fmt.Println("\n>>> Receipts:")
+	bikeTicket.GetReceipt().Print()
+	truckTicket.GetReceipt().Print()
+
+	fmt.Println("\n>>> Fee Disputes:")
+	// The truck driver shows a validation stamp: the fee should have been $1.50
+	if receipt, err := parkingLot.DisputeFee(truckTicket.GetID(), 1.50); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	} else {
+		receipt.Print()
+	}
+	if _, err := parkingLot.DisputeFee(truckTicket.GetID(), 1.00); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	if _, err := parkingLot.DisputeFee(bikeTicket.GetID(), 5.00); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	// Disputes are only accepted shortly after payment
+	parkingLot.SetDisputeWindow(0)
+	if _, err := parkingLot.DisputeFee(bikeTicket.GetID(), 0.50); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	parkingLot.SetDisputeWindow(DefaultDisputeWindow)
+
+	// ----- Step 9: Occupancy Statistics and Revenue Reports -----
+	fmt.Println("\n>>> Activity Reports:")
+	fmt.Printf("  Live activity log: %d events recorded today\n", len(parkingLot.GetActivityLog().GetEvents()))
+
+	// Import a morning of gate history for a small two-floor lot (7 spots per floor)
+	reportLot := NewParkingLot("Mall Parking", []FloorConfig{{2, 4, 1}, {2, 4, 1}})
+	reportDay := time.Date(2024, time.March, 11, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return reportDay.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	history := []struct {
+		plate       string
+		vehicleType VehicleType
+		floor       int
+		entry, exit time.Time
+		fee         float64
+	}{
+		{"MH-01", VehicleTypeCar, 1, at(8, 0), at(10, 30), 4},
+		{"MH-02", VehicleTypeCar, 1, at(8, 15), at(12, 0), 6},
+		{"MH-03", VehicleTypeMotorcycle, 1, at(9, 0), at(11, 0), 2},
+		{"MH-04", VehicleTypeCar, 1, at(9, 30), at(12, 30), 6},
+		{"MH-05", VehicleTypeTruck, 2, at(9, 45), at(10, 45), 3},
+		{"MH-06", VehicleTypeCar, 2, at(10, 0), at(11, 30), 2},
+		{"MH-07", VehicleTypeCar, 1, at(10, 10), at(11, 10), 2},
+		{"MH-08", VehicleTypeMotorcycle, 2, at(10, 20), at(13, 0), 2},
+	}
+	for index, visit := range history {
+		ticketID := fmt.Sprintf("HIST-%d", index+1)
+		reportLot.GetActivityLog().Record(ParkingEvent{Type: ParkingEventEntry, Time: visit.entry, TicketID: ticketID,
+			LicensePlate: visit.plate, VehicleType: visit.vehicleType, FloorNumber: visit.floor})
+		reportLot.GetActivityLog().Record(ParkingEvent{Type: ParkingEventExit, Time: visit.exit, TicketID: ticketID,
+			LicensePlate: visit.plate, VehicleType: visit.vehicleType, FloorNumber: visit.floor, Amount: visit.fee})
+	}
+	reportLot.GetActivityLog().Record(ParkingEvent{Type: ParkingEventRefund, Time: at(12, 45), TicketID: "HIST-2",
+		LicensePlate: "MH-02", VehicleType: VehicleTypeCar, FloorNumber: 1, Amount: 2})
+
+	activity := reportLot.GetActivityLog()
+	fmt.Println("  Floor 1 occupancy by hour:")
+	for _, point := range activity.OccupancySeries(at(8, 0), at(13, 0)) {
+		if point.FloorNumber == 1 {
+			fmt.Printf("    %s  %5.1f%%  (%.2f of %d spots)\n",
+				point.Hour.Format("15:04"), point.Percent, point.AvgOccupied, point.Capacity)
+		}
+	}
+
+	fmt.Println("  Peak hours:")
+	for _, peak := range activity.PeakHours(at(8, 0), at(13, 0), 2) {
+		fmt.Printf("    %02d:00  %5.1f%% lot-wide, %d entries\n", peak.HourOfDay, peak.Percent, peak.Entries)
+	}
+
+	averageStay, visits := activity.AverageStay(reportDay, reportDay.AddDate(0, 0, 1))
+	fmt.Printf("  Average stay: %v over %d visits\n", averageStay, visits)
+
+	revenueCSV, err := RevenueCSV(activity.DailyRevenue(reportDay, reportDay.AddDate(0, 0, 1)))
+	if err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	} else {
+		fmt.Println("  Revenue CSV:")
+		for _, row := range strings.Split(strings.TrimSpace(revenueCSV), "\n") {
+			fmt.Println("    " + row)
+		}
+	}
+
+	// ----- Step 10: Overnight, Overstay and Lost-Vehicle Alerts -----
+	fmt.Println("\n>>> Long-Stay Monitoring:")
+
+	// A simulated clock lets the demo skip ahead days at a time
+	simulatedNow := time.Date(2024, time.March, 15, 9, 0, 0, 0, time.Local)
+	advance := func(duration time.Duration) {
+		simulatedNow = simulatedNow.Add(duration)
+		fmt.Printf("  -- %s --\n", simulatedNow.Format("Mon Jan 02 15:04"))
+	}
+	longTermLot := NewParkingLot("Airport Long-Term", []FloorConfig{{2, 4, 1}})
+	longTermLot.SetClock(func() time.Time { return simulatedNow })
+	longTermLot.AddAlertNotifier(&ConsoleAlertNotifier{})
+
+	// A lost-vehicle threshold shorter than the overstay limit is rejected
+	if err := longTermLot.SetOverstayPolicy(OverstayPolicy{MaxDuration: 48 * time.Hour, LostAfter: time.Hour}); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	for _, vehicle := range []Vehicle{NewCar("TRIP-777"), NewTruck("HAUL-42")} {
+		if _, err := longTermLot.ParkVehicle(vehicle); err != nil {
+			fmt.Printf("  [ERROR] %v\n", err)
+		}
+	}
+	advance(6 * time.Hour)
+	if _, err := longTermLot.ParkVehicle(NewCar("DAY-100")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	advance(2 * time.Hour)
+	if _, err := longTermLot.UnparkVehicle("DAY-100", &CashPayment{}); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	advance(15 * time.Hour) // Next morning: both long-stay vehicles are now overnighters
+	longTermLot.CheckOverstays()
+	longTermLot.CheckOverstays() // Already alerted at this level, so nothing new
+
+	advance(28 * time.Hour) // 51 hours in: past the 48-hour limit
+	longTermLot.CheckOverstays()
+
+	fmt.Println("  Long-stay report (24h+):")
+	for _, entry := range longTermLot.LongStayReport(24 * time.Hour) {
+		fmt.Printf("    %-9s %-10s since %s  %5.0fh  %-9s penalty $%.2f\n",
+			entry.LicensePlate, entry.SpotID, entry.ParkedSince.Format("Jan 02 15:04"),
+			entry.ParkedFor.Hours(), entry.Level, entry.PenaltyDue)
+	}
+
+	tripTicket, err := longTermLot.UnparkVehicle("TRIP-777", NewCardPayment("4111111111111111"))
+	if err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	} else {
+		fmt.Printf("  TRIP-777 paid $%.2f for %d hours (includes $%.2f overstay penalty)\n",
+			tripTicket.GetReceipt().GetNetAmount(), tripTicket.GetParkingDurationHours(),
+			DefaultOverstayPolicy().PenaltyFor(tripTicket.ParkedFor(simulatedNow)))
+	}
+
+	advance(5 * 24 * time.Hour) // The truck is still there a week later
+	longTermLot.CheckOverstays()
+
+	// ----- Step 11: EV Scooters and Oversized Vehicles -----
+	fmt.Println("\n>>> EV Scooters and Buses (two adjacent large spots):")
+
+	// Floor 1 has large spots F1-S6, F1-S7 and F1-S8; floor 2 has F2-S6 and F2-S7
+	depot := NewParkingLot("Transit Depot", []FloorConfig{{2, 3, 3}, {2, 3, 2}})
+	for _, vehicle := range []Vehicle{NewEVScooter("EV-01"), NewBus("BUS-100"), NewTruck("TRUCK-77")} {
+		if _, err := depot.ParkVehicle(vehicle); err != nil {
+			fmt.Printf("  [ERROR] %v\n", err)
+		}
+	}
+
+	// Floor 1 has one large spot left, so the next bus goes to floor 2
+	if _, err := depot.ParkVehicle(NewBus("BUS-200")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	// No two adjacent large spots left anywhere
+	if _, err := depot.ParkVehicle(NewBus("BUS-300")); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	if depotMap, err := (&ASCIIMapRenderer{}).Render(depot.BuildLotMap(8)); err == nil {
+		fmt.Print(depotMap)
+	}
+
+	// Closing one bay of the bus's pair means the whole bus has to move
+	if _, err := depot.ScheduleSpotClosure([]string{"F2-S7"}, "Drain repair", time.Now(), time.Now().Add(time.Hour)); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	for _, ticket := range depot.GetVehiclesToRelocate() {
+		fmt.Printf("  [FLAGGED] %s in closed Spot %s\n", ticket.vehiclePlate, ticket.GetSpotLabel())
+	}
+	if _, err := depot.RelocateVehicle("BUS-200"); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err) // Only F1-S8 is free: not enough on its own
+	}
+	if _, err := depot.UnparkVehicle("BUS-100", &CashPayment{}); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	if _, err := depot.RelocateVehicle("BUS-200"); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+	for _, licensePlate := range []string{"BUS-200", "TRUCK-77", "EV-01"} {
+		if _, err := depot.UnparkVehicle(licensePlate, &CashPayment{}); err != nil {
+			fmt.Printf("  [ERROR] %v\n", err)
+		}
+	}
+
+	// ----- Step 12: Capacity Planning Simulation -----
+	fmt.Println("\n>>> Capacity Planning (simulated weekday traffic):")
+	weekdayTraffic := TrafficPattern{
+		ArrivalsPerHour: 12,
+		RushHours: []RushHour{
+			{StartHour: 8, EndHour: 10, Multiplier: 4},  // Morning commute
+			{StartHour: 17, EndHour: 19, Multiplier: 3}, // Evening shopping
+		},
+		VehicleMix: map[VehicleType]float64{
+			VehicleTypeCar:        70,
+			VehicleTypeMotorcycle: 15,
+			VehicleTypeEVScooter:  8,
+			VehicleTypeTruck:      6,
+			VehicleTypeBus:        1,
+		},
+		MeanStay: 3 * time.Hour,
+		MinStay:  15 * time.Minute,
+	}
+	simulationStart := time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local) // A Monday
+	candidateLayouts := []struct {
+		label  string
+		layout []FloorConfig
+	}{
+		{"Current", parkingLotConfig},
+		{"More medium", []FloorConfig{{5, 25, 3}, {5, 25, 3}}},
+		{"Balanced", []FloorConfig{{8, 30, 4}, {8, 30, 4}}},
+	}
+	for _, candidate := range candidateLayouts {
+		report, err := RunSimulation(candidate.layout, SimulationConfig{
+			Traffic:  weekdayTraffic,
+			Start:    simulationStart,
+			Duration: 24 * time.Hour,
+			Seed:     42,
+		})
+		if err != nil {
+			fmt.Printf("  Simulation failed: %v\n", err)
+			continue
+		}
+		report.Print(candidate.label)
+	}
+
+	if _, err := RunSimulation(parkingLotConfig, SimulationConfig{Traffic: TrafficPattern{}, Duration: time.Hour}); err != nil {
+		fmt.Printf("  Invalid traffic pattern rejected: %v\n", err)
+	}
+
+	// ----- Step 13: Corporate Parking Contracts -----
+	fmt.Println("\n>>> Corporate Contracts (reserved block during working hours):")
+
+	// Floor 1 is public; Acme rents all three medium spots on floor 2
+	officeNow := time.Date(2024, 3, 4, 9, 30, 0, 0, time.Local) // A Monday morning
+	officeLot := NewParkingLot("Office Tower", []FloorConfig{{0, 2, 0}, {0, 3, 0}})
+	officeLot.SetClock(func() time.Time { return officeNow })
+
+	acme, err := officeLot.CreateCorporateContract("Acme Corp", 2, 3, SpotSizeMedium, WeekdayHours(9, 18),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	} else {
+		_ = officeLot.AddContractVehicle(acme.GetID(), "ACME-01")
+		_ = officeLot.AddContractVehicle(acme.GetID(), "ACME-02")
+
+		// 09:30: visitors fill floor 1 and cannot use Acme's block
+		for _, licensePlate := range []string{"VISITOR-1", "VISITOR-2", "VISITOR-3", "ACME-01"} {
+			if _, err := officeLot.ParkVehicle(NewCar(licensePlate)); err != nil {
+				fmt.Printf("  [ERROR] %v\n", err)
+			}
+		}
+
+		// 17:30: Acme's car leaves free of charge
+		officeNow = time.Date(2024, 3, 4, 17, 30, 0, 0, time.Local)
+		if ticket, err := officeLot.UnparkVehicle("ACME-01", nil); err == nil {
+			fmt.Printf("  ACME-01 settled via %s\n", ticket.GetReceipt().method.GetName())
+		}
+
+		// 18:30: contract hours are over, so the block is public again
+		officeNow = time.Date(2024, 3, 4, 18, 30, 0, 0, time.Local)
+		if _, err := officeLot.ParkVehicle(NewCar("VISITOR-3")); err != nil {
+			fmt.Printf("  [ERROR] %v\n", err)
+		}
+		officeNow = time.Date(2024, 3, 4, 20, 30, 0, 0, time.Local)
+		for _, licensePlate := range []string{"VISITOR-1", "VISITOR-2", "VISITOR-3"} {
+			_, _ = officeLot.UnparkVehicle(licensePlate, &CashPayment{})
+		}
+
+		// The rest of the week: both company cars commute every day
+		officeLot.SetQuiet(true)
+		for day := 1; day <= 4; day++ {
+			for _, licensePlate := range []string{"ACME-01", "ACME-02"} {
+				officeNow = time.Date(2024, 3, 4+day, 9, 0, 0, 0, time.Local)
+				_, _ = officeLot.ParkVehicle(NewCar(licensePlate))
+			}
+			officeNow = time.Date(2024, 3, 4+day, 17, 0, 0, 0, time.Local)
+			_, _ = officeLot.UnparkVehicle("ACME-01", nil)
+			_, _ = officeLot.UnparkVehicle("ACME-02", nil)
+		}
+		officeLot.SetQuiet(false)
+
+		if usage, err := officeLot.ContractUsageReport(acme.GetID(), officeNow); err == nil {
+			usage.Print()
+		}
+	}
+
+	// ----- Step 14: Accessible Parking and Permits -----
+	fmt.Println("\n>>> Accessible Parking (permit holders only):")
+
+	clinicNow := time.Date(2024, 6, 10, 10, 0, 0, 0, time.Local)
+	clinicLot := NewParkingLot("City Clinic", []FloorConfig{{0, 4, 0}})
+	clinicLot.SetClock(func() time.Time { return clinicNow })
+	if err := clinicLot.DesignateAccessibleSpots("F1-S1", "F1-S2"); err != nil {
+		fmt.Printf("  [ERROR] %v\n", err)
+	}
+
+	permits := []AccessibilityPermit{
+		{Number: "AP-1001", Holder: "Maria Lopez", LicensePlates: []string{"ACC-100"},
+			ValidFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), ValidUntil: time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)},
+		{Number: "AP-0876", Holder: "Ken Ito", LicensePlates: []string{"ACC-200"},
+			ValidFrom: time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local), ValidUntil: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)},
+		{Number: "AP-1002", Holder: "Duplicate", LicensePlates: []string{"ACC-100"},
+			ValidFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), ValidUntil: time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, permit := range permits {
+		if err := clinicLot.RegisterPermit(permit); err != nil {
+			fmt.Printf("  [ERROR] %v\n", err)
+		}
+	}
+	clinicLot.DisplayAvailability()
+
+	// A valid permit gets an accessible spot; an expired one parks like everyone else
+	for _, licensePlate := range []string{"ACC-100", "ACC-200", "REG-1", "REG-2"} {
+		if _, err := clinicLot.ParkVehicle(NewCar(licensePlate)); err != nil {
+			fmt.Printf("  [ERROR] %s: %v (accessible spots are permit only)\n", licensePlate, err)
+		}
+	}
+	clinicLot.DisplayAvailability()
+
+	// The permit is revoked mid-stay: the next patrol flags the car once
+	clinicNow = clinicNow.Add(time.Hour)
+	_ = clinicLot.RevokePermit("AP-1001")
+	clinicLot.PatrolAccessibleSpots()
+	clinicNow = clinicNow.Add(time.Hour)
+	fmt.Printf("  Second patrol: %d new exception(s)\n", len(clinicLot.PatrolAccessibleSpots()))
+
+	fmt.Println("  Enforcement log:")
+	for _, exception := range clinicLot.GetEnforcementLog() {
+		fmt.Printf("    - %s\n", exception)
+	}
+
+	// ----- Step 15: Gate API Protection -----
+	fmt.Println("\n>>> Gate API (rate limits + ANPR duplicate reads):")
+
+	gateNow := time.Date(2024, 7, 1, 8, 0, 0, 0, time.Local)
+	gateLot := NewParkingLot("Station Garage", []FloorConfig{{2, 6, 1}})
+	gateLot.SetClock(func() time.Time { return gateNow })
+	// The limiters run on real time; the demo barrier cycles every 300ms
+	gateLimiter := ratelimiter.NewTokenBucketRateLimiter(3, 1, 300*time.Millisecond)
+	plateLimiter := ratelimiter.NewTokenBucketRateLimiter(2, 1, 5*time.Minute) // No plate enters/exits twice a minute
+	gateAPI := NewGateAPI(gateLot, gateLimiter, plateLimiter)
+
+	// gateEvent prints what happened to one camera read
+	gateEvent := func(label string, err error) {
+		var duplicate *DuplicateReadError
+		var throttled *GateRateLimitError
+		switch {
+		case errors.As(err, &duplicate):
+			fmt.Printf("  [DEDUP] %s: dropped (%v)\n", label, err)
+		case errors.As(err, &throttled):
+			fmt.Printf("  [429] %s: %v\n", label, err)
+		case err != nil:
+			fmt.Printf("  [ERROR] %s: %v\n", label, err)
+		}
+	}
+
+	// A faulty camera at G1 reads the same car five times in one second
+	for read := 0; read < 5; read++ {
+		_, err := gateAPI.Enter("G1", NewCar("KA-01-AB-1234"))
+		gateEvent("G1 read of KA-01-AB-1234", err)
+		gateNow = gateNow.Add(200 * time.Millisecond)
+	}
+	_, err = gateAPI.Enter("G1", NewCar("ka 01 ab 1234"))
+	gateEvent("G1 read as \"ka 01 ab 1234\"", err)
+
+	// Real traffic at G1 arriving faster than the barrier can cycle
+	for index, licensePlate := range []string{"MH-12-1", "MH-12-2", "MH-12-3"} {
+		_, err := gateAPI.Enter("G1", NewCar(licensePlate))
+		gateEvent(fmt.Sprintf("G1 car %d", index+1), err)
+	}
+	time.Sleep(400 * time.Millisecond)
+	gateNow = gateNow.Add(400 * time.Millisecond)
+	_, err = gateAPI.Enter("G1", NewCar("MH-12-3"))
+	gateEvent("G1 car 3 retried after a barrier cycle", err)
+
+	// The car leaves, but misaligned cameras at G2 and G3 also catch its plate
+	// in the exit lane: the per-plate limit cuts the loop
+	gateNow = gateNow.Add(time.Hour)
+	_, err = gateAPI.Exit("X1", "KA-01-AB-1234", &CashPayment{})
+	gateEvent("X1 exit", err)
+	_, err = gateAPI.Exit("X1", "KA-01-AB-1234", &CashPayment{})
+	gateEvent("X1 exit read again", err)
+	for _, gateID := range []string{"G2", "G3"} {
+		if _, err := gateAPI.Enter(gateID, NewCar("KA-01-AB-1234")); err != nil {
+			gateEvent(gateID+" phantom entry", err)
+		} else {
+			fmt.Printf("  [WARN] %s phantom entry got through\n", gateID)
+		}
+	}
+
+	for _, gateID := range []string{"G1", "G2", "G3", "X1"} {
+		stats := gateAPI.GetGateStats(gateID)
+		fmt.Printf("  Gate %s: %d accepted, %d duplicates, %d gate-throttled, %d plate-throttled\n",
+			gateID, stats.Accepted, stats.Duplicates, stats.GateThrottled, stats.PlateThrottled)
+	}
+	// The throttled phantom reads were never charged to their gates
+	fmt.Printf("  G2 gate tokens left after its phantom read: %d/3\n", gateLimiter.Check("gate:G2").Remaining)
+
+	// Full buckets are the same as no bucket, so idle gates are forgotten
+	time.Sleep(time.Second)
+	fmt.Printf("  Compacted %d idle limiter key(s); %d gate(s) still tracked\n",
+		gateAPI.Compact(), gateLimiter.GetTrackedUsers())
+
+	// ----- Summary of Design Decisions -----
+	fmt.Println()
+	fmt.Println("=================================================")
+	fmt.Println("  KEY DESIGN PATTERNS & PRINCIPLES USED:")
+	fmt.Println("=================================================")
+	fmt.Println("  1. Interface (Vehicle) - Open/Closed Principle")
+	fmt.Println("     -> Easy to add new vehicle types without changes")
+	fmt.Println()
+	fmt.Println("  2. Strategy Pattern (FeeCalculator)")
+	fmt.Println("     -> Flexible fee calculation algorithms")
+	fmt.Println()
+	fmt.Println("  3. Strategy Pattern (PaymentMethod)")
+	fmt.Println("     -> Cash, Card, UPI, Wallet; refunds go back the same way")
+	fmt.Println()
+	fmt.Println("  4. Single Responsibility Principle")
+	fmt.Println("     -> Each struct has one well-defined job")
+	fmt.Println()
+	fmt.Println("  5. Composition over Inheritance")
+	fmt.Println("     -> ParkingLot contains Floors contains Spots")
+	fmt.Println()
+	fmt.Println("  6. Time-windowed Closures attached to Spots")
+	fmt.Println("     -> Closed spots excluded from counts and allocation")
+	fmt.Println()
+	fmt.Println("  7. Strategy Pattern (MapRenderer)")
+	fmt.Println("     -> One lot snapshot, rendered as ASCII or JSON")
+	fmt.Println()
+	fmt.Println("  8. Receipts attached to Tickets")
+	fmt.Println("     -> Overcharges refunded within a dispute window")
+	fmt.Println()
+	fmt.Println("  9. Event log as the source of truth for reports")
+	fmt.Println("     -> Occupancy, peak hours, stays and revenue derived on demand")
+	fmt.Println()
+	fmt.Println("  10. Decorator over FeeCalculator + pluggable AlertNotifier")
+	fmt.Println("     -> Overstay penalty and overnight/overstay/lost alerts")
+	fmt.Println()
+	fmt.Println("  11. Vehicles declare how many spots they need")
+	fmt.Println("     -> Buses take two adjacent large spots, parked and freed together")
+	fmt.Println()
+	fmt.Println("  12. Simulation drives the real lot through an injected clock")
+	fmt.Println("     -> Poisson/rush-hour traffic gives rejection rates and utilization per layout")
+	fmt.Println()
+	fmt.Println("  13. Spot filters for corporate contracts")
+	fmt.Println("     -> Contracted blocks hidden from the public in contract hours; covered stays skip fees")
+	fmt.Println()
+	fmt.Println("  14. Permit registry checked at entry and on patrol")
+	fmt.Println("     -> Accessible spots only for valid permits; every exception goes to an enforcement log")
+	fmt.Println()
+	fmt.Println("  15. Gate API in front of the lot (09_rate_limiter Token Bucket)")
+	fmt.Println("     -> Duplicate ANPR reads dropped first, then per-gate and per-plate limits")
+	fmt.Println("=================================================")
+}
@@ -0,0 +1,3255 @@
+// Package parkinglot models a multi-level parking lot: spots, tickets, fees,
+// gates and the background jobs around them.
+package parkinglot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ratelimiter "github.com/ayushgupta5/GoLLD/09_rate_limiter"
+	"github.com/ayushgupta5/GoLLD/pkg/idgen"
+	"github.com/ayushgupta5/GoLLD/pkg/money"
+	"github.com/ayushgupta5/GoLLD/pkg/periodic"
+)
+
+// ============================================================
+// PARKING LOT SYSTEM - LOW LEVEL DESIGN DEMO
+// ============================================================
+//
+// This file demonstrates a complete parking lot management system.
+// It covers key design patterns and SOLID principles.
+//
+// KEY CONCEPTS COVERED:
+// - Interface-based design (Vehicle interface)
+// - Strategy Pattern (FeeCalculator, PaymentMethod)
+// - Single Responsibility Principle (each struct has one job)
+// - Composition (ParkingLot contains Floors, Floor contains Spots)
+// - Scheduled maintenance closures of floors or individual spots
+// - License-plate search and a lot map with ASCII/JSON renderers
+// - UPI and wallet payments, payment receipts and overcharge refunds
+// - Entry/exit activity log with occupancy, peak-hour and revenue reports
+// - Overnight, overstay and lost-vehicle alerts with an overstay penalty
+// - EV scooters, and oversized vehicles (buses) that take two adjacent large spots
+// - Capacity planning simulator (Poisson arrivals, rush hours, rejection/utilization)
+// - Corporate contracts reserving a block of spots during working hours
+// - Accessible spots for permit holders, with permit validation and an enforcement log
+// - Gate API with per-gate/per-plate rate limits and ANPR duplicate-read suppression
+//
+// Run: go run .
+// ============================================================
+
+// ============================================================
+// SECTION 1: VEHICLE TYPES AND SPOT SIZES
+// ============================================================
+
+// VehicleType represents the type of vehicle (Motorcycle, Car, Truck, ...)
+// Using iota for automatic enumeration (0, 1, 2, ...)
+// New types are appended so existing values never change
+type VehicleType int
+
+const (
+	VehicleTypeMotorcycle VehicleType = iota // 0 - Smallest vehicle
+	VehicleTypeCar                           // 1 - Medium vehicle
+	VehicleTypeTruck                         // 2 - Largest single-spot vehicle
+	VehicleTypeEVScooter                     // 3 - Electric two-wheeler
+	VehicleTypeBus                           // 4 - Oversized, spans two large spots
+)
+
+// String converts VehicleType to a human-readable string
+func (vehicleType VehicleType) String() string {
+	switch vehicleType {
+	case VehicleTypeMotorcycle:
+		return "Motorcycle"
+	case VehicleTypeCar:
+		return "Car"
+	case VehicleTypeTruck:
+		return "Truck"
+	case VehicleTypeEVScooter:
+		return "EV Scooter"
+	case VehicleTypeBus:
+		return "Bus"
+	default:
+		return "Unknown"
+	}
+}
+
+// SpotSize represents the size of a parking spot
+// Larger spots can accommodate smaller vehicles
+type SpotSize int
+
+const (
+	SpotSizeSmall  SpotSize = iota // 0 - For motorcycles and EV scooters
+	SpotSizeMedium                 // 1 - For cars
+	SpotSizeLarge                  // 2 - For trucks (two adjacent ones for a bus)
+)
+
+// String converts SpotSize to a human-readable string
+func (spotSize SpotSize) String() string {
+	switch spotSize {
+	case SpotSizeSmall:
+		return "Small"
+	case SpotSizeMedium:
+		return "Medium"
+	case SpotSizeLarge:
+		return "Large"
+	default:
+		return "Unknown"
+	}
+}
+
+// CanFit checks if this spot size can accommodate a vehicle of given size
+// Rule: A larger spot can fit a smaller vehicle (e.g., truck spot can fit a car)
+func (spotSize SpotSize) CanFit(requiredSize SpotSize) bool {
+	return spotSize >= requiredSize
+}
+
+// ============================================================
+// SECTION 2: VEHICLE INTERFACE AND IMPLEMENTATIONS
+// ============================================================
+
+// Vehicle is the interface that all vehicle types must implement
+// This allows the parking lot to work with any type of vehicle
+type Vehicle interface {
+	GetType() VehicleType          // Returns the type of vehicle
+	GetLicensePlate() string       // Returns the unique license plate
+	GetRequiredSpotSize() SpotSize // Returns the minimum spot size needed
+	GetSpotsRequired() int         // Returns how many adjacent spots it takes
+}
+
+// -------------------- Motorcycle --------------------
+
+// Motorcycle represents a two-wheeler vehicle
+type Motorcycle struct {
+	licensePlate string // Unique identifier for the motorcycle
+}
+
+// NewMotorcycle creates a new Motorcycle instance
+func NewMotorcycle(licensePlate string) *Motorcycle {
+	return &Motorcycle{licensePlate: licensePlate}
+}
+
+// GetType returns VehicleTypeMotorcycle
+func (motorcycle *Motorcycle) GetType() VehicleType {
+	return VehicleTypeMotorcycle
+}
+
+// GetLicensePlate returns the motorcycle's license plate
+func (motorcycle *Motorcycle) GetLicensePlate() string {
+	return motorcycle.licensePlate
+}
+
+// GetRequiredSpotSize returns SpotSizeSmall (motorcycles need small spots)
+func (motorcycle *Motorcycle) GetRequiredSpotSize() SpotSize {
+	return SpotSizeSmall
+}
+
+// GetSpotsRequired returns 1 (a motorcycle takes a single spot)
+func (motorcycle *Motorcycle) GetSpotsRequired() int {
+	return 1
+}
+
+// -------------------- Car --------------------
+
+// Car represents a standard four-wheeler vehicle
+type Car struct {
+	licensePlate string // Unique identifier for the car
+}
+
+// NewCar creates a new Car instance
+func NewCar(licensePlate string) *Car {
+	return &Car{licensePlate: licensePlate}
+}
+
+// GetType returns VehicleTypeCar
+func (car *Car) GetType() VehicleType {
+	return VehicleTypeCar
+}
+
+// GetLicensePlate returns the car's license plate
+func (car *Car) GetLicensePlate() string {
+	return car.licensePlate
+}
+
+// GetRequiredSpotSize returns SpotSizeMedium (cars need medium spots)
+func (car *Car) GetRequiredSpotSize() SpotSize {
+	return SpotSizeMedium
+}
+
+// GetSpotsRequired returns 1 (a car takes a single spot)
+func (car *Car) GetSpotsRequired() int {
+	return 1
+}
+
+// -------------------- Truck --------------------
+
+// Truck represents a large commercial vehicle
+type Truck struct {
+	licensePlate string // Unique identifier for the truck
+}
+
+// NewTruck creates a new Truck instance
+func NewTruck(licensePlate string) *Truck {
+	return &Truck{licensePlate: licensePlate}
+}
+
+// GetType returns VehicleTypeTruck
+func (truck *Truck) GetType() VehicleType {
+	return VehicleTypeTruck
+}
+
+// GetLicensePlate returns the truck's license plate
+func (truck *Truck) GetLicensePlate() string {
+	return truck.licensePlate
+}
+
+// GetRequiredSpotSize returns SpotSizeLarge (trucks need large spots)
+func (truck *Truck) GetRequiredSpotSize() SpotSize {
+	return SpotSizeLarge
+}
+
+// GetSpotsRequired returns 1 (a truck fits in one large spot)
+func (truck *Truck) GetSpotsRequired() int {
+	return 1
+}
+
+// -------------------- EV Scooter --------------------
+
+// EVScooter represents an electric two-wheeler
+type EVScooter struct {
+	licensePlate string // Unique identifier for the scooter
+}
+
+// NewEVScooter creates a new EVScooter instance
+func NewEVScooter(licensePlate string) *EVScooter {
+	return &EVScooter{licensePlate: licensePlate}
+}
+
+// GetType returns VehicleTypeEVScooter
+func (scooter *EVScooter) GetType() VehicleType {
+	return VehicleTypeEVScooter
+}
+
+// GetLicensePlate returns the scooter's license plate
+func (scooter *EVScooter) GetLicensePlate() string {
+	return scooter.licensePlate
+}
+
+// GetRequiredSpotSize returns SpotSizeSmall (scooters need small spots)
+func (scooter *EVScooter) GetRequiredSpotSize() SpotSize {
+	return SpotSizeSmall
+}
+
+// GetSpotsRequired returns 1 (a scooter takes a single spot)
+func (scooter *EVScooter) GetSpotsRequired() int {
+	return 1
+}
+
+// -------------------- Bus --------------------
+
+// BusSpotsRequired is how many adjacent large spots a bus occupies
+const BusSpotsRequired = 2
+
+// Bus represents an oversized vehicle (coach, bus) longer than one bay
+type Bus struct {
+	licensePlate string // Unique identifier for the bus
+}
+
+// NewBus creates a new Bus instance
+func NewBus(licensePlate string) *Bus {
+	return &Bus{licensePlate: licensePlate}
+}
+
+// GetType returns VehicleTypeBus
+func (bus *Bus) GetType() VehicleType {
+	return VehicleTypeBus
+}
+
+// GetLicensePlate returns the bus's license plate
+func (bus *Bus) GetLicensePlate() string {
+	return bus.licensePlate
+}
+
+// GetRequiredSpotSize returns SpotSizeLarge (every spot it spans must be large)
+func (bus *Bus) GetRequiredSpotSize() SpotSize {
+	return SpotSizeLarge
+}
+
+// GetSpotsRequired returns BusSpotsRequired (two adjacent large spots)
+func (bus *Bus) GetSpotsRequired() int {
+	return BusSpotsRequired
+}
+
+// ============================================================
+// SECTION 3: PARKING SPOT
+// ============================================================
+
+// ParkingSpot represents a single parking space in the lot
+type ParkingSpot struct {
+	spotID        string             // Unique ID like "F1-S1" (Floor 1, Spot 1)
+	floorNumber   int                // Which floor this spot is on
+	spotNumber    int                // Spot number on this floor
+	size          SpotSize           // Size of this spot (small/medium/large)
+	parkedVehicle Vehicle            // Currently parked vehicle (nil if empty)
+	closures      []*Closure         // Scheduled maintenance/event closures
+	contract      *CorporateContract // Corporate contract reserving this spot (nil if public)
+	accessible    bool               // Reserved for vehicles with a valid accessibility permit
+}
+
+// NewParkingSpot creates a new parking spot with given parameters
+func NewParkingSpot(floorNumber, spotNumber int, size SpotSize) *ParkingSpot {
+	return &ParkingSpot{
+		spotID:        fmt.Sprintf("F%d-S%d", floorNumber, spotNumber),
+		floorNumber:   floorNumber,
+		spotNumber:    spotNumber,
+		size:          size,
+		parkedVehicle: nil, // Initially empty
+	}
+}
+
+// GetID returns the unique spot identifier
+func (spot *ParkingSpot) GetID() string {
+	return spot.spotID
+}
+
+// GetFloorNumber returns which floor this spot is on
+func (spot *ParkingSpot) GetFloorNumber() int {
+	return spot.floorNumber
+}
+
+// GetSize returns the size of this parking spot
+func (spot *ParkingSpot) GetSize() SpotSize {
+	return spot.size
+}
+
+// IsAvailable checks if the spot is empty and not closed right now
+func (spot *ParkingSpot) IsAvailable() bool {
+	return spot.parkedVehicle == nil && !spot.IsClosedAt(time.Now())
+}
+
+// IsClosedAt checks if any scheduled closure covers the given time
+func (spot *ParkingSpot) IsClosedAt(moment time.Time) bool {
+	for _, closure := range spot.closures {
+		if closure.IsActiveAt(moment) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetVehicle returns the currently parked vehicle (nil if empty)
+func (spot *ParkingSpot) GetVehicle() Vehicle {
+	return spot.parkedVehicle
+}
+
+// CanPark checks if a given vehicle can park in this spot
+// Conditions: spot must be empty, open, AND spot size must fit the vehicle
+func (spot *ParkingSpot) CanPark(vehicle Vehicle) bool {
+	isSpotAvailable := spot.IsAvailable()
+	canFitVehicle := spot.size.CanFit(vehicle.GetRequiredSpotSize())
+	return isSpotAvailable && canFitVehicle
+}
+
+// Park places a vehicle in this spot
+// Returns an error if the vehicle cannot be parked here
+func (spot *ParkingSpot) Park(vehicle Vehicle) error {
+	if !spot.CanPark(vehicle) {
+		return fmt.Errorf("cannot park vehicle in spot %s: spot is occupied, closed or too small", spot.spotID)
+	}
+	spot.parkedVehicle = vehicle
+	return nil
+}
+
+// Unpark removes and returns the vehicle from this spot
+// Returns the vehicle that was parked (nil if spot was already empty)
+func (spot *ParkingSpot) Unpark() Vehicle {
+	removedVehicle := spot.parkedVehicle
+	spot.parkedVehicle = nil
+	return removedVehicle
+}
+
+// removeClosure detaches a closure from this spot
+func (spot *ParkingSpot) removeClosure(closureID string) {
+	remaining := make([]*Closure, 0, len(spot.closures))
+	for _, closure := range spot.closures {
+		if closure.closureID != closureID {
+			remaining = append(remaining, closure)
+		}
+	}
+	spot.closures = remaining
+}
+
+// -------------------- Closure --------------------
+
+// Closure takes a set of spots out of service for a time window
+// (e.g., resurfacing a floor, reserving a zone for an event)
+type Closure struct {
+	closureID string         // Unique ID like "CLS-1"
+	reason    string         // Why the spots are closed
+	startTime time.Time      // When the closure begins
+	endTime   time.Time      // When the spots reopen
+	spots     []*ParkingSpot // Spots covered by this closure
+}
+
+// closureIDGen generates unique closure IDs
+var closureIDGen = idgen.NewSequence("CLS")
+
+// NewClosure creates a closure covering the given spots
+func NewClosure(reason string, startTime, endTime time.Time, spots []*ParkingSpot) *Closure {
+	return &Closure{
+		closureID: closureIDGen.NextID(),
+		reason:    reason,
+		startTime: startTime,
+		endTime:   endTime,
+		spots:     spots,
+	}
+}
+
+// GetID returns the unique closure identifier
+func (closure *Closure) GetID() string {
+	return closure.closureID
+}
+
+// GetReason returns why the spots are closed
+func (closure *Closure) GetReason() string {
+	return closure.reason
+}
+
+// IsActiveAt checks if the closure window covers the given time
+// The window is [startTime, endTime)
+func (closure *Closure) IsActiveAt(moment time.Time) bool {
+	return !moment.Before(closure.startTime) && moment.Before(closure.endTime)
+}
+
+// ============================================================
+// SECTION 4: FLOOR
+// ============================================================
+
+// Floor represents one level of the parking lot
+// Each floor contains multiple parking spots of different sizes
+type Floor struct {
+	floorNumber int            // Floor number (1, 2, 3, etc.)
+	spots       []*ParkingSpot // All parking spots on this floor
+}
+
+// NewFloor creates a new floor with specified number of spots for each size
+// Parameters:
+//   - floorNumber: which floor (1, 2, 3, etc.)
+//   - smallSpotCount: number of small spots (for motorcycles)
+//   - mediumSpotCount: number of medium spots (for cars)
+//   - largeSpotCount: number of large spots (for trucks)
+func NewFloor(floorNumber, smallSpotCount, mediumSpotCount, largeSpotCount int) *Floor {
+	floor := &Floor{
+		floorNumber: floorNumber,
+		spots:       make([]*ParkingSpot, 0),
+	}
+
+	currentSpotNumber := 1
+
+	// Create small spots first
+	for i := 0; i < smallSpotCount; i++ {
+		newSpot := NewParkingSpot(floorNumber, currentSpotNumber, SpotSizeSmall)
+		floor.spots = append(floor.spots, newSpot)
+		currentSpotNumber++
+	}
+
+	// Create medium spots
+	for i := 0; i < mediumSpotCount; i++ {
+		newSpot := NewParkingSpot(floorNumber, currentSpotNumber, SpotSizeMedium)
+		floor.spots = append(floor.spots, newSpot)
+		currentSpotNumber++
+	}
+
+	// Create large spots
+	for i := 0; i < largeSpotCount; i++ {
+		newSpot := NewParkingSpot(floorNumber, currentSpotNumber, SpotSizeLarge)
+		floor.spots = append(floor.spots, newSpot)
+		currentSpotNumber++
+	}
+
+	return floor
+}
+
+// FindAvailableSpot finds a suitable parking spot for the given vehicle
+// Strategy: First try to find exact size match, then try larger spots
+// This optimization prevents wasting large spots on small vehicles
+func (floor *Floor) FindAvailableSpot(vehicle Vehicle) *ParkingSpot {
+	return floor.findAvailableSpot(vehicle, anySpot)
+}
+
+// FindAvailableSpots finds the spot(s) for the given vehicle
+// Single-spot vehicles use FindAvailableSpot; oversized vehicles need a run
+// of adjacent spots (consecutive spot numbers) that are all free and big enough
+func (floor *Floor) FindAvailableSpots(vehicle Vehicle) []*ParkingSpot {
+	return floor.findAvailableSpots(vehicle, anySpot)
+}
+
+// anySpot is the spot filter that allows every spot
+func anySpot(spot *ParkingSpot) bool { return true }
+
+// findAvailableSpot is FindAvailableSpot restricted to spots the filter allows
+func (floor *Floor) findAvailableSpot(vehicle Vehicle, allowed func(*ParkingSpot) bool) *ParkingSpot {
+	requiredSize := vehicle.GetRequiredSpotSize()
+
+	// First pass: Look for exact size match (best fit)
+	for _, spot := range floor.spots {
+		if allowed(spot) && spot.CanPark(vehicle) && spot.GetSize() == requiredSize {
+			return spot
+		}
+	}
+
+	// Second pass: Look for any spot that can fit (larger spot is okay)
+	for _, spot := range floor.spots {
+		if allowed(spot) && spot.CanPark(vehicle) {
+			return spot
+		}
+	}
+
+	// No suitable spot found on this floor
+	return nil
+}
+
+// findAvailableSpots is FindAvailableSpots restricted to spots the filter allows
+func (floor *Floor) findAvailableSpots(vehicle Vehicle, allowed func(*ParkingSpot) bool) []*ParkingSpot {
+	spotsRequired := vehicle.GetSpotsRequired()
+	if spotsRequired <= 1 {
+		if spot := floor.findAvailableSpot(vehicle, allowed); spot != nil {
+			return []*ParkingSpot{spot}
+		}
+		return nil
+	}
+
+	// Slide a window over the floor; restart it at any spot that cannot take
+	// the vehicle or is not next to the previous one
+	run := make([]*ParkingSpot, 0, spotsRequired)
+	for _, spot := range floor.spots {
+		isAdjacent := len(run) > 0 && spot.spotNumber == run[len(run)-1].spotNumber+1
+		if !allowed(spot) || !spot.CanPark(vehicle) {
+			run = run[:0]
+			continue
+		}
+		if !isAdjacent {
+			run = run[:0]
+		}
+		run = append(run, spot)
+		if len(run) == spotsRequired {
+			return run
+		}
+	}
+
+	// No run of adjacent spots found on this floor
+	return nil
+}
+
+// GetClosedSpotCount returns how many spots on this floor are closed right now
+func (floor *Floor) GetClosedSpotCount() int {
+	closedCount := 0
+	now := time.Now()
+	for _, spot := range floor.spots {
+		if spot.IsClosedAt(now) {
+			closedCount++
+		}
+	}
+	return closedCount
+}
+
+// GetAvailableSpotCount returns the count of available spots of a specific size
+// Closed spots are never counted as available, and accessible spots are
+// counted separately (see GetAccessibleSpotCounts)
+func (floor *Floor) GetAvailableSpotCount(spotSize SpotSize) int {
+	availableCount := 0
+	for _, spot := range floor.spots {
+		if spot.IsAvailable() && !spot.accessible && spot.GetSize() == spotSize {
+			availableCount++
+		}
+	}
+	return availableCount
+}
+
+// ============================================================
+// SECTION 5: PARKING TICKET
+// ============================================================
+
+// Ticket represents a parking ticket issued when a vehicle enters
+type Ticket struct {
+	ticketID     string             // Unique ticket ID like "TKT-1"
+	vehiclePlate string             // License plate of the parked vehicle
+	vehicleType  VehicleType        // Type of vehicle
+	assignedSpot *ParkingSpot       // Which spot the vehicle is parked in (first one if several)
+	spots        []*ParkingSpot     // Every spot the vehicle occupies (oversized vehicles take more than one)
+	entryTime    time.Time          // When the vehicle entered
+	exitTime     time.Time          // When the vehicle exited (zero if still parked)
+	amountPaid   float64            // Amount paid (0 if not paid yet)
+	isPaid       bool               // Whether payment has been made
+	receipt      *PaymentReceipt    // Proof of payment (nil if not paid yet)
+	alertLevel   OverstayLevel      // Highest overstay alert raised so far
+	contract     *CorporateContract // Contract covering this stay (nil = pay per hour)
+}
+
+// ticketIDGen generates unique ticket IDs
+var ticketIDGen = idgen.NewSequence("TKT")
+
+// NewTicket creates a new parking ticket for a vehicle entering at entryTime
+// spots are the spots the vehicle occupies; the first is its assigned spot
+func NewTicket(vehicle Vehicle, spots []*ParkingSpot, entryTime time.Time) *Ticket {
+	return &Ticket{
+		ticketID:     ticketIDGen.NextID(),
+		vehiclePlate: vehicle.GetLicensePlate(),
+		vehicleType:  vehicle.GetType(),
+		assignedSpot: spots[0],
+		spots:        spots,
+		entryTime:    entryTime,
+		// exitTime, amountPaid, isPaid are zero/false by default
+	}
+}
+
+// GetParkingDurationHours calculates how long the vehicle has been parked
+// Returns at least 1 hour (minimum billing)
+func (ticket *Ticket) GetParkingDurationHours() int {
+	// Convert to hours (minimum 1 hour billing)
+	hours := int(ticket.ParkedFor(time.Now()).Hours())
+	if hours < 1 {
+		hours = 1 // Minimum charge is 1 hour
+	}
+	return hours
+}
+
+// ParkedFor returns how long the vehicle was parked
+// If it hasn't exited yet, the duration runs until now
+func (ticket *Ticket) ParkedFor(now time.Time) time.Duration {
+	if ticket.exitTime.IsZero() {
+		return now.Sub(ticket.entryTime)
+	}
+	// Vehicle has exited, use the recorded exit time
+	return ticket.exitTime.Sub(ticket.entryTime)
+}
+
+// RecordExit marks the exit time when vehicle leaves
+func (ticket *Ticket) RecordExit(exitTime time.Time) {
+	ticket.exitTime = exitTime
+}
+
+// RecordPayment marks the ticket as paid and attaches the receipt
+func (ticket *Ticket) RecordPayment(receipt *PaymentReceipt) {
+	ticket.amountPaid = receipt.amount
+	ticket.isPaid = true
+	ticket.receipt = receipt
+}
+
+// GetID returns the ticket ID
+func (ticket *Ticket) GetID() string {
+	return ticket.ticketID
+}
+
+// GetReceipt returns the payment receipt (nil if not paid yet)
+func (ticket *Ticket) GetReceipt() *PaymentReceipt {
+	return ticket.receipt
+}
+
+// GetSpotLabel returns the spot ID, or "F1-S17+F1-S18" for a vehicle spanning spots
+func (ticket *Ticket) GetSpotLabel() string {
+	spotIDs := make([]string, 0, len(ticket.spots))
+	for _, spot := range ticket.spots {
+		spotIDs = append(spotIDs, spot.GetID())
+	}
+	return strings.Join(spotIDs, "+")
+}
+
+// isInClosedSpot checks if any spot the vehicle occupies is closed at the given time
+func (ticket *Ticket) isInClosedSpot(moment time.Time) bool {
+	for _, spot := range ticket.spots {
+		if spot.IsClosedAt(moment) {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================================================
+// SECTION 6: FEE CALCULATOR (Strategy Pattern)
+// ============================================================
+// The Strategy Pattern allows us to define different fee calculation
+// algorithms and switch between them at runtime. This makes the system
+// flexible - we can easily add new pricing strategies without changing
+// existing code.
+
+// FeeCalculator is the interface for fee calculation strategies
+type FeeCalculator interface {
+	CalculateFee(ticket *Ticket) float64
+}
+
+// HourlyRateCalculator calculates fee based on hourly rates per vehicle type
+type HourlyRateCalculator struct {
+	hourlyRates map[VehicleType]float64 // Rate per hour for each vehicle type
+}
+
+// NewHourlyRateCalculator creates a calculator with default hourly rates
+// Rates: EV Scooter=$0.50/hr, Motorcycle=$1/hr, Car=$2/hr, Truck=$3/hr, Bus=$6/hr
+func NewHourlyRateCalculator() *HourlyRateCalculator {
+	return &HourlyRateCalculator{
+		hourlyRates: map[VehicleType]float64{
+			VehicleTypeMotorcycle: 1.0, // $1 per hour
+			VehicleTypeCar:        2.0, // $2 per hour
+			VehicleTypeTruck:      3.0, // $3 per hour
+			VehicleTypeEVScooter:  0.5, // $0.50 per hour (discount for zero-emission)
+			VehicleTypeBus:        6.0, // $6 per hour (two large spots)
+		},
+	}
+}
+
+// CalculateFee calculates the total fee based on duration and vehicle type
+func (calculator *HourlyRateCalculator) CalculateFee(ticket *Ticket) float64 {
+	parkingHours := ticket.GetParkingDurationHours()
+	hourlyRate := calculator.hourlyRates[ticket.vehicleType]
+	totalFee := float64(parkingHours) * hourlyRate
+	return totalFee
+}
+
+// ============================================================
+// SECTION 7: PAYMENT METHOD (Strategy Pattern)
+// ============================================================
+// Another use of Strategy Pattern for handling different payment methods.
+// This allows easy addition of new payment options (UPI, Wallet, etc.)
+// Every method can also send money back, so a disputed fee can be refunded
+// the same way it was paid.
+
+// PaymentMethod is the interface for different payment options
+type PaymentMethod interface {
+	ProcessPayment(amount float64) error
+	Refund(amount float64) error
+	GetName() string // Shown on receipts, e.g. "UPI (alice@okbank)"
+}
+
+// CashPayment handles cash payments
+type CashPayment struct{}
+
+// ProcessPayment processes a cash payment
+func (payment *CashPayment) ProcessPayment(amount float64) error {
+	fmt.Printf("  [Cash Payment] Amount received: $%.2f\n", amount)
+	return nil
+}
+
+// Refund hands cash back at the booth
+func (payment *CashPayment) Refund(amount float64) error {
+	fmt.Printf("  [Cash Refund] Amount returned: $%.2f\n", amount)
+	return nil
+}
+
+// GetName returns the method name for receipts
+func (payment *CashPayment) GetName() string {
+	return "Cash"
+}
+
+// CardPayment handles credit/debit card payments
+type CardPayment struct {
+	cardNumber string // Full card number (would be encrypted in production)
+}
+
+// NewCardPayment creates a new card payment with the given card number
+func NewCardPayment(cardNumber string) *CardPayment {
+	return &CardPayment{cardNumber: cardNumber}
+}
+
+// ProcessPayment processes a card payment
+// Shows only last 4 digits for security
+func (payment *CardPayment) ProcessPayment(amount float64) error {
+	// Validate card number length to avoid panic
+	if len(payment.cardNumber) < 4 {
+		return fmt.Errorf("invalid card number")
+	}
+
+	// Show only last 4 digits for security
+	lastFourDigits := payment.cardNumber[len(payment.cardNumber)-4:]
+	fmt.Printf("  [Card Payment] Amount charged: $%.2f (Card: ****%s)\n", amount, lastFourDigits)
+	return nil
+}
+
+// Refund credits the amount back to the card
+func (payment *CardPayment) Refund(amount float64) error {
+	fmt.Printf("  [Card Refund] Amount credited: $%.2f (%s)\n", amount, payment.GetName())
+	return nil
+}
+
+// GetName returns the method name with the masked card number
+func (payment *CardPayment) GetName() string {
+	if len(payment.cardNumber) < 4 {
+		return "Card"
+	}
+	return "Card ****" + payment.cardNumber[len(payment.cardNumber)-4:]
+}
+
+// UPIPayment handles payments through a UPI virtual payment address
+type UPIPayment struct {
+	upiID string // Virtual payment address like "alice@okbank"
+}
+
+// NewUPIPayment creates a new UPI payment for the given UPI ID
+func NewUPIPayment(upiID string) *UPIPayment {
+	return &UPIPayment{upiID: upiID}
+}
+
+// ProcessPayment sends a collect request to the UPI ID
+// A UPI ID must look like "handle@provider"
+func (payment *UPIPayment) ProcessPayment(amount float64) error {
+	handle, provider, found := strings.Cut(payment.upiID, "@")
+	if !found || handle == "" || provider == "" {
+		return fmt.Errorf("invalid UPI ID %q", payment.upiID)
+	}
+	fmt.Printf("  [UPI Payment] Amount collected: $%.2f (UPI: %s)\n", amount, payment.upiID)
+	return nil
+}
+
+// Refund pushes the amount back to the UPI ID
+func (payment *UPIPayment) Refund(amount float64) error {
+	fmt.Printf("  [UPI Refund] Amount sent: $%.2f (UPI: %s)\n", amount, payment.upiID)
+	return nil
+}
+
+// GetName returns the method name with the UPI ID
+func (payment *UPIPayment) GetName() string {
+	return "UPI (" + payment.upiID + ")"
+}
+
+// WalletPayment handles payments from a prepaid parking wallet
+type WalletPayment struct {
+	walletID string  // Wallet account ID
+	balance  float64 // Prepaid balance available
+}
+
+// NewWalletPayment creates a wallet with the given prepaid balance
+func NewWalletPayment(walletID string, balance float64) *WalletPayment {
+	return &WalletPayment{walletID: walletID, balance: balance}
+}
+
+// ProcessPayment debits the wallet
+// Fails if the balance cannot cover the amount
+func (payment *WalletPayment) ProcessPayment(amount float64) error {
+	if amount > payment.balance {
+		return fmt.Errorf("insufficient wallet balance: have $%.2f, need $%.2f", payment.balance, amount)
+	}
+	payment.balance -= amount
+	fmt.Printf("  [Wallet Payment] Amount debited: $%.2f (Wallet: %s, balance $%.2f)\n",
+		amount, payment.walletID, payment.balance)
+	return nil
+}
+
+// Refund credits the amount back to the wallet balance
+func (payment *WalletPayment) Refund(amount float64) error {
+	payment.balance += amount
+	fmt.Printf("  [Wallet Refund] Amount credited: $%.2f (Wallet: %s, balance $%.2f)\n",
+		amount, payment.walletID, payment.balance)
+	return nil
+}
+
+// GetName returns the method name with the wallet ID
+func (payment *WalletPayment) GetName() string {
+	return "Wallet (" + payment.walletID + ")"
+}
+
+// GetBalance returns the remaining wallet balance
+func (payment *WalletPayment) GetBalance() float64 {
+	return payment.balance
+}
+
+// PaymentReceipt is the proof of payment attached to a ticket
+type PaymentReceipt struct {
+	transactionID  string        // Unique transaction ID like "TXN-1"
+	ticketID       string        // Ticket this payment settled
+	method         PaymentMethod // Method used (refunds go back through it)
+	amount         float64       // Amount charged
+	refundedAmount float64       // Amount returned after a dispute
+	paidAt         time.Time     // When the payment was made
+	refundedAt     time.Time     // When the refund was made (zero if none)
+}
+
+// transactionIDGen generates unique transaction IDs
+var transactionIDGen = idgen.NewSequence("TXN")
+
+// NewPaymentReceipt creates a receipt for a successful payment
+func NewPaymentReceipt(ticketID string, method PaymentMethod, amount float64) *PaymentReceipt {
+	return &PaymentReceipt{
+		transactionID: transactionIDGen.NextID(),
+		ticketID:      ticketID,
+		method:        method,
+		amount:        amount,
+		paidAt:        time.Now(),
+	}
+}
+
+// GetTransactionID returns the transaction ID
+func (receipt *PaymentReceipt) GetTransactionID() string {
+	return receipt.transactionID
+}
+
+// GetNetAmount returns the amount kept after any refund
+func (receipt *PaymentReceipt) GetNetAmount() float64 {
+	return money.Round(receipt.amount - receipt.refundedAmount)
+}
+
+// Print displays the receipt
+func (receipt *PaymentReceipt) Print() {
+	fmt.Printf("  [RECEIPT] %s | Ticket %s | %s | %s | %s\n",
+		receipt.transactionID, receipt.ticketID, receipt.method.GetName(),
+		money.Format(receipt.amount), receipt.paidAt.Format("2006-01-02 15:04:05"))
+	if receipt.refundedAmount > 0 {
+		fmt.Printf("            Refunded %s on %s -> Net %s\n",
+			money.Format(receipt.refundedAmount), receipt.refundedAt.Format("2006-01-02 15:04:05"),
+			money.Format(receipt.GetNetAmount()))
+	}
+}
+
+// ============================================================
+// SECTION 8: PARKING LOT (Main Controller)
+// ============================================================
+
+// ParkingLot is the main class that manages the entire parking system
+type ParkingLot struct {
+	name          string              // Name of the parking lot
+	floors        []*Floor            // All floors in the parking lot
+	activeTickets map[string]*Ticket  // Maps license plate -> active ticket
+	feeCalculator FeeCalculator       // Strategy for calculating fees
+	closures      map[string]*Closure // Scheduled closures by closure ID
+	paidTickets   map[string]*Ticket  // Completed tickets by ticket ID (for disputes)
+	disputeWindow time.Duration       // How long after payment a fee can be disputed
+	activityLog   *ActivityLog        // Entry/exit/refund events for reporting
+	clock         func() time.Time    // Source of entry/exit times (time.Now unless replaced)
+	overstay      OverstayPolicy      // Limits for overnight, overstay and lost-vehicle alerts
+	notifiers     []AlertNotifier     // Where overstay alerts are sent
+	quiet         bool                // Suppresses per-vehicle console output (simulations)
+
+	contracts        map[string]*CorporateContract // Corporate contracts by contract ID
+	contractVehicles map[string]*CorporateContract // Maps license plate -> contract it belongs to
+
+	permits        map[string]*AccessibilityPermit // Accessibility permits by permit number
+	permitsByPlate map[string]*AccessibilityPermit // Maps license plate -> permit covering it
+	enforcementLog []EnforcementException          // Permit rejections and unpermitted use of accessible spots
+	flaggedTickets map[string]bool                 // Tickets already flagged by a patrol
+}
+
+// DefaultDisputeWindow is how long after payment a fee can be disputed
+const DefaultDisputeWindow = 7 * 24 * time.Hour
+
+// FloorConfig defines the configuration for one floor
+// [0] = small spots, [1] = medium spots, [2] = large spots
+type FloorConfig [3]int
+
+// NewParkingLot creates a new parking lot with the given configuration
+// Parameters:
+//   - name: Name of the parking lot
+//   - floorsConfig: Array of FloorConfig, one for each floor
+//     Each FloorConfig is [smallSpots, mediumSpots, largeSpots]
+func NewParkingLot(name string, floorsConfig []FloorConfig) *ParkingLot {
+	parkingLot := &ParkingLot{
+		name:          name,
+		floors:        make([]*Floor, 0),
+		activeTickets: make(map[string]*Ticket),
+		closures:      make(map[string]*Closure),
+		paidTickets:   make(map[string]*Ticket),
+		disputeWindow: DefaultDisputeWindow,
+		clock:         time.Now,
+		overstay:      DefaultOverstayPolicy(),
+		notifiers:     make([]AlertNotifier, 0),
+
+		contracts:        make(map[string]*CorporateContract),
+		contractVehicles: make(map[string]*CorporateContract),
+
+		permits:        make(map[string]*AccessibilityPermit),
+		permitsByPlate: make(map[string]*AccessibilityPermit),
+		enforcementLog: make([]EnforcementException, 0),
+		flaggedTickets: make(map[string]bool),
+	}
+	// Default fee calculator: hourly rates plus the overstay penalty
+	parkingLot.feeCalculator = NewOverstayFeeCalculator(NewHourlyRateCalculator(), parkingLot.overstay)
+
+	// Create floors based on configuration
+	floorCapacity := make(map[int]int)
+	for floorIndex, config := range floorsConfig {
+		floorNumber := floorIndex + 1 // Floors are 1-indexed
+		smallSpots := config[0]
+		mediumSpots := config[1]
+		largeSpots := config[2]
+
+		newFloor := NewFloor(floorNumber, smallSpots, mediumSpots, largeSpots)
+		parkingLot.floors = append(parkingLot.floors, newFloor)
+		floorCapacity[floorNumber] = len(newFloor.spots)
+	}
+	parkingLot.activityLog = NewActivityLog(floorCapacity)
+
+	return parkingLot
+}
+
+// ParkVehicle parks a vehicle and returns a ticket
+// Returns an error if:
+//   - Vehicle is already parked
+//   - No suitable spot is available
+func (lot *ParkingLot) ParkVehicle(vehicle Vehicle) (*Ticket, error) {
+	licensePlate := vehicle.GetLicensePlate()
+
+	// Check if this vehicle is already parked
+	if _, alreadyParked := lot.activeTickets[licensePlate]; alreadyParked {
+		return nil, fmt.Errorf("vehicle %s is already parked in the lot", licensePlate)
+	}
+
+	// Find available spot(s) across all floors
+	lot.checkPermitAtEntry(licensePlate, lot.clock())
+	availableSpots := lot.findSpots(vehicle)
+
+	// No spot found
+	if availableSpots == nil {
+		if vehicle.GetSpotsRequired() > 1 {
+			return nil, fmt.Errorf("no %d adjacent %s spots available for %s",
+				vehicle.GetSpotsRequired(), vehicle.GetRequiredSpotSize(), vehicle.GetType())
+		}
+		return nil, fmt.Errorf("no parking spot available for %s", vehicle.GetType())
+	}
+
+	// Park the vehicle in the found spot(s)
+	if err := parkInSpots(vehicle, availableSpots); err != nil {
+		return nil, err
+	}
+
+	// Create and store the ticket
+	ticket := NewTicket(vehicle, availableSpots, lot.clock())
+	if contract := lot.contractVehicles[licensePlate]; contract != nil && availableSpots[0].contract == contract {
+		ticket.contract = contract // Parked in its own block: covered by the contract
+	}
+	lot.activeTickets[licensePlate] = ticket
+	lot.activityLog.Record(ParkingEvent{
+		Type:         ParkingEventEntry,
+		Time:         ticket.entryTime,
+		TicketID:     ticket.ticketID,
+		LicensePlate: licensePlate,
+		VehicleType:  ticket.vehicleType,
+		FloorNumber:  ticket.assignedSpot.GetFloorNumber(),
+		Spots:        len(availableSpots),
+	})
+
+	if !lot.quiet {
+		fmt.Printf("  [PARKED] %s (%s) -> Spot %s\n",
+			licensePlate, vehicle.GetType(), ticket.GetSpotLabel())
+	}
+
+	return ticket, nil
+}
+
+// findSpots returns the first floor's spot(s) that can take the vehicle (nil if none)
+// During contract hours a contracted vehicle tries its own block first, and
+// contracted spots are off-limits to everyone else
+// A vehicle with a valid accessibility permit tries accessible spots next;
+// accessible spots are off-limits to vehicles without one
+func (lot *ParkingLot) findSpots(vehicle Vehicle) []*ParkingSpot {
+	now := lot.clock()
+	if contract := lot.contractVehicles[vehicle.GetLicensePlate()]; contract != nil && contract.IsActiveAt(now) {
+		ownBlock := func(spot *ParkingSpot) bool { return spot.contract == contract }
+		for _, floor := range lot.floors {
+			if spots := floor.findAvailableSpots(vehicle, ownBlock); spots != nil {
+				return spots
+			}
+		}
+	}
+
+	if lot.hasValidPermit(vehicle.GetLicensePlate(), now) {
+		accessible := func(spot *ParkingSpot) bool { return spot.accessible }
+		for _, floor := range lot.floors {
+			if spots := floor.findAvailableSpots(vehicle, accessible); spots != nil {
+				return spots
+			}
+		}
+	}
+
+	public := func(spot *ParkingSpot) bool { return !spot.isContractedAt(now) && !spot.accessible }
+	for _, floor := range lot.floors {
+		if spots := floor.findAvailableSpots(vehicle, public); spots != nil {
+			return spots
+		}
+	}
+	return nil
+}
+
+// parkInSpots parks the vehicle in every given spot, or in none of them
+func parkInSpots(vehicle Vehicle, spots []*ParkingSpot) error {
+	for index, spot := range spots {
+		if err := spot.Park(vehicle); err != nil {
+			for _, parkedSpot := range spots[:index] {
+				parkedSpot.Unpark()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// UnparkVehicle removes a vehicle, calculates fee, processes payment
+// Stays covered by a corporate contract skip fee calculation and are
+// settled against the contract, so paymentMethod may be nil for them
+// Returns the completed ticket or an error if vehicle not found
+func (lot *ParkingLot) UnparkVehicle(licensePlate string, paymentMethod PaymentMethod) (*Ticket, error) {
+	// Find the ticket for this vehicle
+	ticket, exists := lot.activeTickets[licensePlate]
+	if !exists {
+		return nil, fmt.Errorf("vehicle %s is not found in the parking lot", licensePlate)
+	}
+
+	// Record exit time and calculate fee
+	ticket.RecordExit(lot.clock())
+	parkingFee := 0.0
+	if ticket.contract != nil {
+		paymentMethod = &contractPayment{contract: ticket.contract}
+	} else {
+		parkingFee = lot.feeCalculator.CalculateFee(ticket)
+	}
+
+	// Process payment
+	if err := paymentMethod.ProcessPayment(parkingFee); err != nil {
+		return nil, fmt.Errorf("payment failed: %v", err)
+	}
+	ticket.RecordPayment(NewPaymentReceipt(ticket.ticketID, paymentMethod, parkingFee))
+
+	// Free up the parking spot(s)
+	for _, spot := range ticket.spots {
+		spot.Unpark()
+	}
+
+	// Move from active tickets to paid tickets
+	delete(lot.activeTickets, licensePlate)
+	lot.paidTickets[ticket.ticketID] = ticket
+	if ticket.contract != nil {
+		ticket.contract.recordVisit(ticket)
+	}
+	lot.activityLog.Record(ParkingEvent{
+		Type:         ParkingEventExit,
+		Time:         ticket.exitTime,
+		TicketID:     ticket.ticketID,
+		LicensePlate: licensePlate,
+		VehicleType:  ticket.vehicleType,
+		FloorNumber:  ticket.assignedSpot.GetFloorNumber(),
+		Amount:       parkingFee,
+	})
+
+	if !lot.quiet {
+		fmt.Printf("  [EXITED] %s - Total Paid: %s (%s)\n",
+			licensePlate, money.Format(parkingFee), ticket.receipt.transactionID)
+	}
+
+	return ticket, nil
+}
+
+// SetDisputeWindow changes how long after payment a fee can be disputed
+func (lot *ParkingLot) SetDisputeWindow(window time.Duration) {
+	lot.disputeWindow = window
+}
+
+// DisputeFee refunds an overcharge on a paid ticket
+// correctFee is the fee the customer should have paid; the difference is
+// refunded through the original payment method.
+// Returns an error if:
+//   - Ticket is not found or not paid
+//   - The dispute window has passed
+//   - The ticket was already refunded
+//   - correctFee is not lower than the amount paid
+func (lot *ParkingLot) DisputeFee(ticketID string, correctFee float64) (*PaymentReceipt, error) {
+	ticket, exists := lot.paidTickets[ticketID]
+	if !exists || ticket.receipt == nil {
+		return nil, fmt.Errorf("no paid ticket %s found", ticketID)
+	}
+	receipt := ticket.receipt
+
+	if time.Since(receipt.paidAt) > lot.disputeWindow {
+		return nil, fmt.Errorf("dispute window for ticket %s closed on %s",
+			ticketID, receipt.paidAt.Add(lot.disputeWindow).Format("2006-01-02 15:04"))
+	}
+	if receipt.refundedAmount > 0 {
+		return nil, fmt.Errorf("ticket %s was already refunded $%.2f", ticketID, receipt.refundedAmount)
+	}
+	if correctFee < 0 || correctFee >= receipt.amount {
+		return nil, fmt.Errorf("no overcharge on ticket %s: paid $%.2f, disputed fee $%.2f",
+			ticketID, receipt.amount, correctFee)
+	}
+
+	refundAmount := money.Round(receipt.amount - correctFee)
+	if err := receipt.method.Refund(refundAmount); err != nil {
+		return nil, fmt.Errorf("refund failed: %v", err)
+	}
+	receipt.refundedAmount = refundAmount
+	receipt.refundedAt = time.Now()
+	ticket.amountPaid = correctFee
+	lot.activityLog.Record(ParkingEvent{
+		Type:         ParkingEventRefund,
+		Time:         receipt.refundedAt,
+		TicketID:     ticket.ticketID,
+		LicensePlate: ticket.vehiclePlate,
+		VehicleType:  ticket.vehicleType,
+		FloorNumber:  ticket.assignedSpot.GetFloorNumber(),
+		Amount:       refundAmount,
+	})
+
+	return receipt, nil
+}
+
+// ScheduleFloorClosure closes every spot on a floor between startTime and endTime
+func (lot *ParkingLot) ScheduleFloorClosure(floorNumber int, reason string, startTime, endTime time.Time) (*Closure, error) {
+	if floorNumber < 1 || floorNumber > len(lot.floors) {
+		return nil, fmt.Errorf("floor %d does not exist", floorNumber)
+	}
+	return lot.scheduleClosure(lot.floors[floorNumber-1].spots, reason, startTime, endTime)
+}
+
+// ScheduleSpotClosure closes a specific set of spots (a zone) between startTime and endTime
+func (lot *ParkingLot) ScheduleSpotClosure(spotIDs []string, reason string, startTime, endTime time.Time) (*Closure, error) {
+	closedSpots, err := lot.spotsByID(spotIDs)
+	if err != nil {
+		return nil, err
+	}
+	return lot.scheduleClosure(closedSpots, reason, startTime, endTime)
+}
+
+// spotsByID resolves spot IDs like "F1-S3" to spots, failing on the first unknown ID
+func (lot *ParkingLot) spotsByID(spotIDs []string) ([]*ParkingSpot, error) {
+	spotsByID := make(map[string]*ParkingSpot)
+	for _, floor := range lot.floors {
+		for _, spot := range floor.spots {
+			spotsByID[spot.GetID()] = spot
+		}
+	}
+
+	spots := make([]*ParkingSpot, 0, len(spotIDs))
+	for _, spotID := range spotIDs {
+		spot, exists := spotsByID[spotID]
+		if !exists {
+			return nil, fmt.Errorf("spot %s does not exist", spotID)
+		}
+		spots = append(spots, spot)
+	}
+	return spots, nil
+}
+
+// scheduleClosure validates the window and attaches the closure to each spot
+func (lot *ParkingLot) scheduleClosure(spots []*ParkingSpot, reason string, startTime, endTime time.Time) (*Closure, error) {
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("closure must end after it starts")
+	}
+	if len(spots) == 0 {
+		return nil, fmt.Errorf("closure must cover at least one spot")
+	}
+
+	closure := NewClosure(reason, startTime, endTime, spots)
+	for _, spot := range spots {
+		spot.closures = append(spot.closures, closure)
+	}
+	lot.closures[closure.GetID()] = closure
+
+	fmt.Printf("  [CLOSURE] %s: %d spots closed %s - %s (%s)\n",
+		closure.GetID(), len(spots), startTime.Format("Jan 02 15:04"), endTime.Format("Jan 02 15:04"), reason)
+	return closure, nil
+}
+
+// CancelClosure reopens the spots covered by a closure
+func (lot *ParkingLot) CancelClosure(closureID string) error {
+	closure, exists := lot.closures[closureID]
+	if !exists {
+		return fmt.Errorf("closure %s not found", closureID)
+	}
+
+	for _, spot := range closure.spots {
+		spot.removeClosure(closureID)
+	}
+	delete(lot.closures, closureID)
+	return nil
+}
+
+// GetVehiclesToRelocate returns tickets of vehicles parked in spots that are closed right now
+// These vehicles must be moved before work in the closed area can start
+func (lot *ParkingLot) GetVehiclesToRelocate() []*Ticket {
+	now := time.Now()
+	flaggedTickets := make([]*Ticket, 0)
+	for _, ticket := range lot.activeTickets {
+		if ticket.isInClosedSpot(now) {
+			flaggedTickets = append(flaggedTickets, ticket)
+		}
+	}
+	return flaggedTickets
+}
+
+// RelocateVehicle moves a vehicle from a closed spot to an open one
+// The original ticket (and its entry time) is kept so billing is unaffected
+// Returns the vehicle's new assigned spot (the first one if it spans several)
+func (lot *ParkingLot) RelocateVehicle(licensePlate string) (*ParkingSpot, error) {
+	ticket, exists := lot.activeTickets[licensePlate]
+	if !exists {
+		return nil, fmt.Errorf("vehicle %s is not found in the parking lot", licensePlate)
+	}
+
+	oldLabel := ticket.GetSpotLabel()
+	oldSpots := ticket.spots
+	vehicle := ticket.assignedSpot.GetVehicle()
+
+	// Find open spot(s) across all floors
+	newSpots := lot.findSpots(vehicle)
+	if newSpots == nil {
+		return nil, fmt.Errorf("no open spot available to relocate %s", licensePlate)
+	}
+
+	if err := parkInSpots(vehicle, newSpots); err != nil {
+		return nil, err
+	}
+	for _, oldSpot := range oldSpots {
+		oldSpot.Unpark()
+	}
+	ticket.assignedSpot = newSpots[0]
+	ticket.spots = newSpots
+
+	fmt.Printf("  [RELOCATED] %s: Spot %s -> Spot %s\n", licensePlate, oldLabel, ticket.GetSpotLabel())
+	return ticket.assignedSpot, nil
+}
+
+// DisplayAvailability shows the current availability of parking spots
+func (lot *ParkingLot) DisplayAvailability() {
+	fmt.Println()
+	fmt.Println("+----------------------------------------------------+")
+	fmt.Printf("|  %-48s  |\n", lot.name+" - AVAILABILITY")
+	fmt.Println("+----------------------------------------------------+")
+
+	for _, floor := range lot.floors {
+		smallAvailable := floor.GetAvailableSpotCount(SpotSizeSmall)
+		mediumAvailable := floor.GetAvailableSpotCount(SpotSizeMedium)
+		largeAvailable := floor.GetAvailableSpotCount(SpotSizeLarge)
+
+		fmt.Printf("|  Floor %d: Motorcycle: %2d  Car: %2d  Truck: %2d       |\n",
+			floor.floorNumber, smallAvailable, mediumAvailable, largeAvailable)
+
+		if accessibleAvailable, accessibleTotal := floor.GetAccessibleSpotCounts(); accessibleTotal > 0 {
+			fmt.Printf("|           Accessible: %2d of %2d free (permit only)  |\n", accessibleAvailable, accessibleTotal)
+		}
+		if closedCount := floor.GetClosedSpotCount(); closedCount > 0 {
+			fmt.Printf("|           (%2d spots closed for maintenance)        |\n", closedCount)
+		}
+	}
+	fmt.Println("+----------------------------------------------------+")
+}
+
+// ============================================================
+// SECTION 9: VEHICLE SEARCH AND LOT MAP
+// ============================================================
+
+// VehicleLocation tells a driver (or attendant) where a vehicle is parked
+type VehicleLocation struct {
+	LicensePlate string
+	FloorNumber  int
+	SpotID       string // "F1-S17+F1-S18" if the vehicle spans several spots
+	TicketID     string
+	ParkedSince  time.Time
+}
+
+// FindVehicle looks up where a vehicle is parked by its license plate
+func (lot *ParkingLot) FindVehicle(licensePlate string) (*VehicleLocation, error) {
+	ticket, exists := lot.activeTickets[licensePlate]
+	if !exists {
+		return nil, fmt.Errorf("vehicle %s is not found in the parking lot", licensePlate)
+	}
+
+	return &VehicleLocation{
+		LicensePlate: licensePlate,
+		FloorNumber:  ticket.assignedSpot.GetFloorNumber(),
+		SpotID:       ticket.GetSpotLabel(),
+		TicketID:     ticket.ticketID,
+		ParkedSince:  ticket.entryTime,
+	}, nil
+}
+
+// SpotStatus is what a UI needs to know to color a spot on the map
+type SpotStatus int
+
+const (
+	SpotStatusFree     SpotStatus = iota // 0 - Empty and open
+	SpotStatusOccupied                   // 1 - A vehicle is parked here
+	SpotStatusClosed                     // 2 - Closed for maintenance/events
+)
+
+// String returns a human-readable name for the spot status
+func (status SpotStatus) String() string {
+	switch status {
+	case SpotStatusFree:
+		return "free"
+	case SpotStatusOccupied:
+		return "occupied"
+	case SpotStatusClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// SpotCell is one cell in the lot map grid
+type SpotCell struct {
+	SpotID       string `json:"spot_id"`
+	Size         string `json:"size"`
+	Status       string `json:"status"`
+	VehicleType  string `json:"vehicle_type,omitempty"`
+	LicensePlate string `json:"license_plate,omitempty"`
+}
+
+// FloorMap is a grid of spot cells for one floor, plus its occupancy rate
+type FloorMap struct {
+	FloorNumber int          `json:"floor"`
+	Occupancy   float64      `json:"occupancy"` // Occupied spots / total spots (0.0 - 1.0)
+	Rows        [][]SpotCell `json:"rows"`
+}
+
+// LotMap is a point-in-time snapshot of the whole lot layout
+type LotMap struct {
+	Name        string     `json:"name"`
+	GeneratedAt time.Time  `json:"generated_at"`
+	Floors      []FloorMap `json:"floors"`
+}
+
+// BuildLotMap snapshots every spot into a grid with the given number of columns
+// A closed spot that still has a vehicle in it is reported as closed,
+// so the UI can highlight vehicles that need to be relocated
+func (lot *ParkingLot) BuildLotMap(columns int) *LotMap {
+	if columns < 1 {
+		columns = 1
+	}
+
+	now := time.Now()
+	lotMap := &LotMap{
+		Name:        lot.name,
+		GeneratedAt: now,
+		Floors:      make([]FloorMap, 0, len(lot.floors)),
+	}
+
+	for _, floor := range lot.floors {
+		floorMap := FloorMap{FloorNumber: floor.floorNumber, Rows: make([][]SpotCell, 0)}
+		occupiedCount := 0
+
+		for index, spot := range floor.spots {
+			if index%columns == 0 {
+				floorMap.Rows = append(floorMap.Rows, make([]SpotCell, 0, columns))
+			}
+
+			cell := SpotCell{SpotID: spot.GetID(), Size: spot.GetSize().String()}
+			status := SpotStatusFree
+			if vehicle := spot.GetVehicle(); vehicle != nil {
+				status = SpotStatusOccupied
+				cell.VehicleType = vehicle.GetType().String()
+				cell.LicensePlate = vehicle.GetLicensePlate()
+				occupiedCount++
+			}
+			if spot.IsClosedAt(now) {
+				status = SpotStatusClosed
+			}
+			cell.Status = status.String()
+
+			lastRow := len(floorMap.Rows) - 1
+			floorMap.Rows[lastRow] = append(floorMap.Rows[lastRow], cell)
+		}
+
+		if len(floor.spots) > 0 {
+			floorMap.Occupancy = float64(occupiedCount) / float64(len(floor.spots))
+		}
+		lotMap.Floors = append(lotMap.Floors, floorMap)
+	}
+
+	return lotMap
+}
+
+// MapRenderer turns a lot map into something a UI can display (Strategy Pattern)
+type MapRenderer interface {
+	Render(lotMap *LotMap) (string, error)
+}
+
+// ASCIIMapRenderer draws the lot as a text grid for terminals and kiosks
+type ASCIIMapRenderer struct{}
+
+// Render draws one grid per floor
+// Legend: . free, M/C/T/E/B occupied by motorcycle/car/truck/EV scooter/bus, # closed
+func (renderer *ASCIIMapRenderer) Render(lotMap *LotMap) (string, error) {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s - LOT MAP (%s)\n", lotMap.Name, lotMap.GeneratedAt.Format("15:04:05"))
+
+	for _, floorMap := range lotMap.Floors {
+		fmt.Fprintf(&builder, "  Floor %d  [%3.0f%% occupied]\n", floorMap.FloorNumber, floorMap.Occupancy*100)
+		for _, row := range floorMap.Rows {
+			symbols := make([]string, 0, len(row))
+			for _, cell := range row {
+				symbols = append(symbols, cellSymbol(cell))
+			}
+			builder.WriteString("    " + strings.Join(symbols, " ") + "\n")
+		}
+	}
+
+	builder.WriteString("  Legend: . free  M/C/T/E/B motorcycle/car/truck/EV scooter/bus  # closed\n")
+	return builder.String(), nil
+}
+
+// cellSymbol picks the single character used for a cell in the ASCII map
+func cellSymbol(cell SpotCell) string {
+	switch cell.Status {
+	case SpotStatusClosed.String():
+		return "#"
+	case SpotStatusOccupied.String():
+		return cell.VehicleType[:1]
+	default:
+		return "."
+	}
+}
+
+// JSONMapRenderer serializes the lot map for web/mobile UIs
+type JSONMapRenderer struct {
+	Indent bool // Pretty-print for debugging; compact for the wire
+}
+
+// Render serializes the lot map to JSON
+func (renderer *JSONMapRenderer) Render(lotMap *LotMap) (string, error) {
+	var encoded []byte
+	var err error
+	if renderer.Indent {
+		encoded, err = json.MarshalIndent(lotMap, "", "  ")
+	} else {
+		encoded, err = json.Marshal(lotMap)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to render lot map: %v", err)
+	}
+	return string(encoded), nil
+}
+
+// ============================================================
+// SECTION 10: OCCUPANCY STATISTICS AND REVENUE REPORTING
+// ============================================================
+
+// ParkingEventType is the kind of activity recorded at the gates
+type ParkingEventType int
+
+const (
+	ParkingEventEntry  ParkingEventType = iota // 0 - Vehicle entered and got a spot
+	ParkingEventExit                           // 1 - Vehicle left and paid
+	ParkingEventRefund                         // 2 - Overcharge refunded after a dispute
+)
+
+// String returns a human-readable name for the event type
+func (eventType ParkingEventType) String() string {
+	switch eventType {
+	case ParkingEventEntry:
+		return "Entry"
+	case ParkingEventExit:
+		return "Exit"
+	case ParkingEventRefund:
+		return "Refund"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParkingEvent is one entry in the lot's activity log
+type ParkingEvent struct {
+	Type         ParkingEventType
+	Time         time.Time
+	TicketID     string
+	LicensePlate string
+	VehicleType  VehicleType
+	FloorNumber  int     // Floor the vehicle was parked on at entry
+	Spots        int     // Spots taken at entry (0 is read as 1, e.g. imported history)
+	Amount       float64 // Fee paid (Exit) or amount refunded (Refund); 0 for Entry
+}
+
+// ActivityLog records entry/exit events and answers reporting queries
+// Reports are computed from the log on demand, so they can cover any
+// time range, including history imported from another system
+type ActivityLog struct {
+	events   []ParkingEvent // All events in the order they were recorded
+	capacity map[int]int    // Floor number -> number of spots on that floor
+}
+
+// NewActivityLog creates an empty log for floors with the given capacities
+func NewActivityLog(capacity map[int]int) *ActivityLog {
+	return &ActivityLog{
+		events:   make([]ParkingEvent, 0),
+		capacity: capacity,
+	}
+}
+
+// Record appends an event to the log
+// The lot records events itself; call this directly to import history
+func (activityLog *ActivityLog) Record(event ParkingEvent) {
+	activityLog.events = append(activityLog.events, event)
+}
+
+// GetEvents returns a copy of all recorded events
+func (activityLog *ActivityLog) GetEvents() []ParkingEvent {
+	events := make([]ParkingEvent, len(activityLog.events))
+	copy(events, activityLog.events)
+	return events
+}
+
+// stay is one vehicle visit, built by pairing an entry with its exit
+type stay struct {
+	floorNumber int
+	vehicleType VehicleType
+	spots       int // Spots occupied (2 for a bus)
+	entryTime   time.Time
+	exitTime    time.Time // Zero while the vehicle is still parked
+}
+
+// stays pairs entry and exit events by ticket ID
+func (activityLog *ActivityLog) stays() []*stay {
+	byTicket := make(map[string]*stay)
+	allStays := make([]*stay, 0)
+	for _, event := range activityLog.events {
+		switch event.Type {
+		case ParkingEventEntry:
+			visit := &stay{floorNumber: event.FloorNumber, vehicleType: event.VehicleType,
+				spots: max(1, event.Spots), entryTime: event.Time}
+			byTicket[event.TicketID] = visit
+			allStays = append(allStays, visit)
+		case ParkingEventExit:
+			if visit, exists := byTicket[event.TicketID]; exists {
+				visit.exitTime = event.Time
+			}
+		}
+	}
+	return allStays
+}
+
+// occupiedHours returns how many spot-hours the stay used within [from, to)
+// A stay that has not ended yet is counted as lasting until "to"
+func (visit *stay) occupiedHours(from, to time.Time) float64 {
+	end := visit.exitTime
+	if end.IsZero() || end.After(to) {
+		end = to
+	}
+	start := visit.entryTime
+	if start.Before(from) {
+		start = from
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start).Hours() * float64(visit.spots)
+}
+
+// OccupancyPoint is the average occupancy of one floor during one hour
+type OccupancyPoint struct {
+	Hour        time.Time // Start of the hour
+	FloorNumber int
+	Capacity    int
+	AvgOccupied float64 // Average number of occupied spots over the hour
+	Percent     float64 // AvgOccupied / Capacity * 100
+}
+
+// OccupancySeries returns hourly occupancy for every floor between from and to
+// Points are ordered by hour, then by floor
+func (activityLog *ActivityLog) OccupancySeries(from, to time.Time) []OccupancyPoint {
+	allStays := activityLog.stays()
+	floorNumbers := make([]int, 0, len(activityLog.capacity))
+	for floorNumber := range activityLog.capacity {
+		floorNumbers = append(floorNumbers, floorNumber)
+	}
+	sort.Ints(floorNumbers)
+
+	points := make([]OccupancyPoint, 0)
+	for hour := from.Truncate(time.Hour); hour.Before(to); hour = hour.Add(time.Hour) {
+		hourEnd := hour.Add(time.Hour)
+		for _, floorNumber := range floorNumbers {
+			usedHours := 0.0
+			for _, visit := range allStays {
+				if visit.floorNumber == floorNumber {
+					usedHours += visit.occupiedHours(hour, hourEnd)
+				}
+			}
+
+			point := OccupancyPoint{
+				Hour:        hour,
+				FloorNumber: floorNumber,
+				Capacity:    activityLog.capacity[floorNumber],
+				AvgOccupied: usedHours,
+			}
+			if point.Capacity > 0 {
+				point.Percent = usedHours / float64(point.Capacity) * 100
+			}
+			points = append(points, point)
+		}
+	}
+	return points
+}
+
+// PeakHour summarizes one hour of the day across the reporting range
+type PeakHour struct {
+	HourOfDay int     // 0-23
+	Percent   float64 // Average lot-wide occupancy during this hour
+	Entries   int     // Vehicles that entered during this hour
+}
+
+// PeakHours returns the busiest hours of the day between from and to,
+// ranked by average lot-wide occupancy (ties broken by entries)
+func (activityLog *ActivityLog) PeakHours(from, to time.Time, top int) []PeakHour {
+	totalCapacity := 0
+	for _, spots := range activityLog.capacity {
+		totalCapacity += spots
+	}
+
+	var usedHours, hourCount [24]float64
+	for _, point := range activityLog.OccupancySeries(from, to) {
+		usedHours[point.Hour.Hour()] += point.AvgOccupied
+	}
+	for hour := from.Truncate(time.Hour); hour.Before(to); hour = hour.Add(time.Hour) {
+		hourCount[hour.Hour()]++
+	}
+
+	var entries [24]int
+	for _, event := range activityLog.events {
+		if event.Type == ParkingEventEntry && !event.Time.Before(from) && event.Time.Before(to) {
+			entries[event.Time.Hour()]++
+		}
+	}
+
+	peaks := make([]PeakHour, 0, 24)
+	for hourOfDay := 0; hourOfDay < 24; hourOfDay++ {
+		if hourCount[hourOfDay] == 0 || totalCapacity == 0 {
+			continue
+		}
+		peaks = append(peaks, PeakHour{
+			HourOfDay: hourOfDay,
+			Percent:   usedHours[hourOfDay] / (hourCount[hourOfDay] * float64(totalCapacity)) * 100,
+			Entries:   entries[hourOfDay],
+		})
+	}
+	sort.SliceStable(peaks, func(i, j int) bool {
+		if peaks[i].Percent != peaks[j].Percent {
+			return peaks[i].Percent > peaks[j].Percent
+		}
+		return peaks[i].Entries > peaks[j].Entries
+	})
+
+	if top > 0 && top < len(peaks) {
+		peaks = peaks[:top]
+	}
+	return peaks
+}
+
+// AverageStay returns the mean duration of visits that ended between
+// from and to, and how many visits that covers
+func (activityLog *ActivityLog) AverageStay(from, to time.Time) (time.Duration, int) {
+	var totalDuration time.Duration
+	completedCount := 0
+	for _, visit := range activityLog.stays() {
+		if visit.exitTime.IsZero() || visit.exitTime.Before(from) || !visit.exitTime.Before(to) {
+			continue
+		}
+		totalDuration += visit.exitTime.Sub(visit.entryTime)
+		completedCount++
+	}
+
+	if completedCount == 0 {
+		return 0, 0
+	}
+	return totalDuration / time.Duration(completedCount), completedCount
+}
+
+// RevenueLine is the revenue from one vehicle type on one day
+type RevenueLine struct {
+	Date        string // "2006-01-02"
+	VehicleType VehicleType
+	Exits       int     // Paid exits
+	Gross       float64 // Fees collected
+	Refunds     float64 // Overcharges refunded that day
+	Net         float64 // Gross - Refunds
+}
+
+// DailyRevenue returns revenue per day and vehicle type between from and to
+// Refunds count against the day they were issued, not the day of the visit
+func (activityLog *ActivityLog) DailyRevenue(from, to time.Time) []RevenueLine {
+	type revenueKey struct {
+		date        string
+		vehicleType VehicleType
+	}
+	lines := make(map[revenueKey]*RevenueLine)
+
+	for _, event := range activityLog.events {
+		if event.Type == ParkingEventEntry || event.Time.Before(from) || !event.Time.Before(to) {
+			continue
+		}
+		key := revenueKey{date: event.Time.Format("2006-01-02"), vehicleType: event.VehicleType}
+		line, exists := lines[key]
+		if !exists {
+			line = &RevenueLine{Date: key.date, VehicleType: key.vehicleType}
+			lines[key] = line
+		}
+
+		if event.Type == ParkingEventExit {
+			line.Exits++
+			line.Gross += event.Amount
+		} else {
+			line.Refunds += event.Amount
+		}
+		line.Net = line.Gross - line.Refunds
+	}
+
+	report := make([]RevenueLine, 0, len(lines))
+	for _, line := range lines {
+		report = append(report, *line)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Date != report[j].Date {
+			return report[i].Date < report[j].Date
+		}
+		return report[i].VehicleType < report[j].VehicleType
+	})
+	return report
+}
+
+// OccupancyCSV exports an occupancy series as CSV with a header row
+func OccupancyCSV(points []OccupancyPoint) (string, error) {
+	rows := [][]string{{"hour", "floor", "capacity", "avg_occupied", "percent"}}
+	for _, point := range points {
+		rows = append(rows, []string{
+			point.Hour.Format("2006-01-02 15:04"),
+			strconv.Itoa(point.FloorNumber),
+			strconv.Itoa(point.Capacity),
+			strconv.FormatFloat(point.AvgOccupied, 'f', 2, 64),
+			strconv.FormatFloat(point.Percent, 'f', 1, 64),
+		})
+	}
+	return writeCSV(rows)
+}
+
+// RevenueCSV exports a revenue report as CSV with a header row
+func RevenueCSV(lines []RevenueLine) (string, error) {
+	rows := [][]string{{"date", "vehicle_type", "exits", "gross", "refunds", "net"}}
+	for _, line := range lines {
+		rows = append(rows, []string{
+			line.Date,
+			line.VehicleType.String(),
+			strconv.Itoa(line.Exits),
+			strconv.FormatFloat(line.Gross, 'f', 2, 64),
+			strconv.FormatFloat(line.Refunds, 'f', 2, 64),
+			strconv.FormatFloat(line.Net, 'f', 2, 64),
+		})
+	}
+	return writeCSV(rows)
+}
+
+// writeCSV encodes rows as CSV text
+func writeCSV(rows [][]string) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	if err := writer.WriteAll(rows); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %v", err)
+	}
+	return builder.String(), nil
+}
+
+// GetActivityLog returns the lot's entry/exit log for reporting
+func (lot *ParkingLot) GetActivityLog() *ActivityLog {
+	return lot.activityLog
+}
+
+// ============================================================
+// SECTION 11: OVERNIGHT, OVERSTAY AND LOST-VEHICLE ALERTS
+// ============================================================
+// Each active ticket is checked against an OverstayPolicy and
+// climbs through three alert levels:
+//   Overnight -> still parked after midnight (informational)
+//   Overstay  -> parked longer than MaxDuration, penalty applies
+//   Lost      -> parked longer than LostAfter, likely abandoned
+// An alert is raised once per level per ticket and sent to every
+// registered AlertNotifier. The penalty itself is added by the
+// OverstayFeeCalculator, which wraps the normal fee strategy.
+
+// OverstayLevel is how far past the limits a parked vehicle is
+type OverstayLevel int
+
+const (
+	OverstayNone      OverstayLevel = iota // 0 - Within limits
+	OverstayOvernight                      // 1 - Parked across midnight
+	OverstayExceeded                       // 2 - Beyond the maximum duration
+	OverstayLost                           // 3 - Beyond the lost-vehicle threshold
+)
+
+// String returns a human-readable name for the overstay level
+func (level OverstayLevel) String() string {
+	switch level {
+	case OverstayNone:
+		return "OK"
+	case OverstayOvernight:
+		return "Overnight"
+	case OverstayExceeded:
+		return "Overstay"
+	case OverstayLost:
+		return "Lost"
+	default:
+		return "Unknown"
+	}
+}
+
+// OverstayPolicy defines the long-stay limits and the penalty for exceeding them
+type OverstayPolicy struct {
+	MaxDuration  time.Duration // Longest allowed stay before the penalty applies
+	LostAfter    time.Duration // Stay after which the vehicle is reported as lost
+	FlatPenalty  float64       // One-off charge once MaxDuration is exceeded
+	DailyPenalty float64       // Extra charge per started day beyond MaxDuration
+}
+
+// DefaultOverstayPolicy allows 48 hours and reports vehicles left for a week
+func DefaultOverstayPolicy() OverstayPolicy {
+	return OverstayPolicy{
+		MaxDuration:  48 * time.Hour,
+		LostAfter:    7 * 24 * time.Hour,
+		FlatPenalty:  25.0,
+		DailyPenalty: 10.0,
+	}
+}
+
+// Validate checks that the limits are positive and in order
+func (policy OverstayPolicy) Validate() error {
+	if policy.MaxDuration <= 0 {
+		return fmt.Errorf("maximum parking duration must be positive")
+	}
+	if policy.LostAfter < policy.MaxDuration {
+		return fmt.Errorf("lost-vehicle threshold (%v) cannot be shorter than the maximum duration (%v)",
+			policy.LostAfter, policy.MaxDuration)
+	}
+	if policy.FlatPenalty < 0 || policy.DailyPenalty < 0 {
+		return fmt.Errorf("overstay penalties cannot be negative")
+	}
+	return nil
+}
+
+// LevelFor returns the alert level of a vehicle that entered at entryTime
+func (policy OverstayPolicy) LevelFor(entryTime, now time.Time) OverstayLevel {
+	parked := now.Sub(entryTime)
+	switch {
+	case parked > policy.LostAfter:
+		return OverstayLost
+	case parked > policy.MaxDuration:
+		return OverstayExceeded
+	case !sameDay(entryTime, now):
+		return OverstayOvernight
+	default:
+		return OverstayNone
+	}
+}
+
+// PenaltyFor returns the overstay penalty for a stay of the given length
+func (policy OverstayPolicy) PenaltyFor(parked time.Duration) float64 {
+	if parked <= policy.MaxDuration {
+		return 0
+	}
+	extraDays := math.Ceil((parked - policy.MaxDuration).Hours() / 24)
+	return policy.FlatPenalty + extraDays*policy.DailyPenalty
+}
+
+// sameDay reports whether two times fall on the same calendar day
+func sameDay(first, second time.Time) bool {
+	firstYear, firstMonth, firstDay := first.Date()
+	secondYear, secondMonth, secondDay := second.Date()
+	return firstYear == secondYear && firstMonth == secondMonth && firstDay == secondDay
+}
+
+// OverstayFeeCalculator adds the overstay penalty on top of another fee strategy
+// (Decorator over the FeeCalculator strategy)
+type OverstayFeeCalculator struct {
+	base   FeeCalculator  // Strategy for the normal parking fee
+	policy OverstayPolicy // Limits and penalty rates
+}
+
+// NewOverstayFeeCalculator wraps a fee calculator with an overstay penalty
+func NewOverstayFeeCalculator(base FeeCalculator, policy OverstayPolicy) *OverstayFeeCalculator {
+	return &OverstayFeeCalculator{base: base, policy: policy}
+}
+
+// CalculateFee returns the normal fee plus any overstay penalty
+func (calculator *OverstayFeeCalculator) CalculateFee(ticket *Ticket) float64 {
+	penalty := calculator.policy.PenaltyFor(ticket.ParkedFor(time.Now()))
+	return calculator.base.CalculateFee(ticket) + penalty
+}
+
+// OverstayAlert describes a vehicle that reached a new overstay level
+type OverstayAlert struct {
+	Level        OverstayLevel
+	TicketID     string
+	LicensePlate string
+	VehicleType  VehicleType
+	SpotID       string
+	ParkedSince  time.Time
+	ParkedFor    time.Duration
+	PenaltyDue   float64 // Penalty the driver would pay if they left now
+}
+
+// AlertNotifier receives overstay alerts (e.g. attendant pager, security desk)
+type AlertNotifier interface {
+	Notify(alert OverstayAlert)
+}
+
+// ConsoleAlertNotifier prints alerts to stdout
+type ConsoleAlertNotifier struct{}
+
+// Notify prints the alert
+func (notifier *ConsoleAlertNotifier) Notify(alert OverstayAlert) {
+	fmt.Printf("  [ALERT:%s] %s (%s) at %s for %.0fh, penalty due $%.2f\n",
+		alert.Level, alert.LicensePlate, alert.VehicleType, alert.SpotID,
+		alert.ParkedFor.Hours(), alert.PenaltyDue)
+}
+
+// SetClock replaces the time source used for entries, exits and overstay checks
+// Tests and simulations use it to move time forward without waiting
+func (lot *ParkingLot) SetClock(clock func() time.Time) {
+	lot.clock = clock
+}
+
+// SetQuiet turns the per-vehicle [PARKED]/[EXITED] lines off (true) or on
+func (lot *ParkingLot) SetQuiet(quiet bool) {
+	lot.quiet = quiet
+}
+
+// SetOverstayPolicy changes the long-stay limits and re-wraps the fee calculator
+func (lot *ParkingLot) SetOverstayPolicy(policy OverstayPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	lot.overstay = policy
+
+	baseCalculator := lot.feeCalculator
+	if wrapped, isWrapped := baseCalculator.(*OverstayFeeCalculator); isWrapped {
+		baseCalculator = wrapped.base
+	}
+	lot.feeCalculator = NewOverstayFeeCalculator(baseCalculator, policy)
+	return nil
+}
+
+// AddAlertNotifier registers a receiver for overstay alerts
+func (lot *ParkingLot) AddAlertNotifier(notifier AlertNotifier) {
+	lot.notifiers = append(lot.notifiers, notifier)
+}
+
+// overstayAlertFor builds the alert for a ticket at the given level
+func (lot *ParkingLot) overstayAlertFor(ticket *Ticket, level OverstayLevel, now time.Time) OverstayAlert {
+	parked := ticket.ParkedFor(now)
+	return OverstayAlert{
+		Level:        level,
+		TicketID:     ticket.ticketID,
+		LicensePlate: ticket.vehiclePlate,
+		VehicleType:  ticket.vehicleType,
+		SpotID:       ticket.GetSpotLabel(),
+		ParkedSince:  ticket.entryTime,
+		ParkedFor:    parked,
+		PenaltyDue:   lot.overstay.PenaltyFor(parked),
+	}
+}
+
+// CheckOverstays raises alerts for vehicles that reached a new overstay level
+// since the last check, and returns them (oldest entry first)
+// Run it periodically, e.g. from an hourly job
+func (lot *ParkingLot) CheckOverstays() []OverstayAlert {
+	now := lot.clock()
+	alerts := make([]OverstayAlert, 0)
+	for _, ticket := range lot.activeTickets {
+		level := lot.overstay.LevelFor(ticket.entryTime, now)
+		if level <= ticket.alertLevel {
+			continue
+		}
+		ticket.alertLevel = level
+		alerts = append(alerts, lot.overstayAlertFor(ticket, level, now))
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].ParkedSince.Equal(alerts[j].ParkedSince) {
+			return alerts[i].ParkedSince.Before(alerts[j].ParkedSince)
+		}
+		return alerts[i].TicketID < alerts[j].TicketID
+	})
+	for _, alert := range alerts {
+		for _, notifier := range lot.notifiers {
+			notifier.Notify(alert)
+		}
+	}
+	return alerts
+}
+
+// LongStayReport lists every vehicle parked at least minDuration, longest first
+// It is a read-only admin view and does not raise alerts
+func (lot *ParkingLot) LongStayReport(minDuration time.Duration) []OverstayAlert {
+	now := lot.clock()
+	report := make([]OverstayAlert, 0)
+	for _, ticket := range lot.activeTickets {
+		if ticket.ParkedFor(now) < minDuration {
+			continue
+		}
+		level := lot.overstay.LevelFor(ticket.entryTime, now)
+		report = append(report, lot.overstayAlertFor(ticket, level, now))
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].ParkedFor != report[j].ParkedFor {
+			return report[i].ParkedFor > report[j].ParkedFor
+		}
+		return report[i].TicketID < report[j].TicketID
+	})
+	return report
+}
+
+// ============================================================
+// SECTION 12: CAPACITY PLANNING SIMULATOR
+// ============================================================
+// Answers "how many spots of each size do we need?" by running
+// synthetic traffic against a candidate lot layout:
+//   - Arrivals follow a Poisson process whose rate changes by
+//     hour of day, so rush-hour peaks can be modelled
+//   - Each arrival's vehicle type is drawn from a weighted mix
+//   - Stay lengths are exponential around a mean, with a minimum
+// The traffic runs through the real ParkVehicle/UnparkVehicle
+// code on a fresh, quiet lot with a simulated clock, so spot
+// fitting, bus adjacency and fees behave exactly as in production.
+// The report gives rejection rates per vehicle type and the
+// time-weighted utilization of each spot size.
+
+// RushHour raises the arrival rate between StartHour and EndHour (exclusive)
+type RushHour struct {
+	StartHour  int     // First hour of the peak (0-23)
+	EndHour    int     // Hour the peak ends (1-24)
+	Multiplier float64 // Arrival rate multiplier during the peak
+}
+
+// TrafficPattern describes the synthetic traffic fed to the simulator
+type TrafficPattern struct {
+	ArrivalsPerHour float64                 // Base Poisson arrival rate outside rush hours
+	RushHours       []RushHour              // Peaks layered on top of the base rate
+	VehicleMix      map[VehicleType]float64 // Relative weight of each vehicle type
+	MeanStay        time.Duration           // Mean of the exponential stay length
+	MinStay         time.Duration           // Shortest stay (drop-offs are not modelled)
+}
+
+// Validate checks that the pattern can generate traffic
+func (pattern TrafficPattern) Validate() error {
+	if pattern.ArrivalsPerHour <= 0 {
+		return fmt.Errorf("arrival rate must be positive")
+	}
+	for _, rush := range pattern.RushHours {
+		if rush.StartHour < 0 || rush.EndHour > 24 || rush.StartHour >= rush.EndHour {
+			return fmt.Errorf("invalid rush hour %02d:00-%02d:00", rush.StartHour, rush.EndHour)
+		}
+		if rush.Multiplier <= 0 {
+			return fmt.Errorf("rush hour multiplier must be positive")
+		}
+	}
+	totalWeight := 0.0
+	for vehicleType, weight := range pattern.VehicleMix {
+		if weight < 0 {
+			return fmt.Errorf("negative weight for %s", vehicleType)
+		}
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return fmt.Errorf("vehicle mix must have at least one positive weight")
+	}
+	if pattern.MeanStay <= 0 || pattern.MinStay < 0 {
+		return fmt.Errorf("stay lengths must be positive")
+	}
+	return nil
+}
+
+// RateAt returns the arrival rate (vehicles per hour) at the given moment
+// Overlapping rush hours use the highest multiplier
+func (pattern TrafficPattern) RateAt(moment time.Time) float64 {
+	multiplier := 1.0
+	for _, rush := range pattern.RushHours {
+		if moment.Hour() >= rush.StartHour && moment.Hour() < rush.EndHour {
+			multiplier = math.Max(multiplier, rush.Multiplier)
+		}
+	}
+	return pattern.ArrivalsPerHour * multiplier
+}
+
+// peakRate returns the highest arrival rate of the day
+func (pattern TrafficPattern) peakRate() float64 {
+	multiplier := 1.0
+	for _, rush := range pattern.RushHours {
+		multiplier = math.Max(multiplier, rush.Multiplier)
+	}
+	return pattern.ArrivalsPerHour * multiplier
+}
+
+// SimulationConfig controls one simulation run
+type SimulationConfig struct {
+	Traffic  TrafficPattern // What arrives and how long it stays
+	Start    time.Time      // Simulated start time
+	Duration time.Duration  // How long to simulate
+	Seed     int64          // Random seed; the same seed gives the same run
+}
+
+// VehicleTypeOutcome counts what happened to arrivals of one vehicle type
+type VehicleTypeOutcome struct {
+	Type     VehicleType
+	Arrivals int // Vehicles that tried to enter
+	Parked   int // Vehicles that found a spot
+	Rejected int // Vehicles turned away because the lot was full for them
+}
+
+// RejectionRate returns the share of arrivals that were turned away
+func (outcome VehicleTypeOutcome) RejectionRate() float64 {
+	if outcome.Arrivals == 0 {
+		return 0
+	}
+	return float64(outcome.Rejected) / float64(outcome.Arrivals)
+}
+
+// SpotSizeUsage reports how busy the spots of one size were
+type SpotSizeUsage struct {
+	Size            SpotSize
+	Spots           int     // Spots of this size in the layout
+	AverageOccupied float64 // Time-weighted mean number of occupied spots
+	PeakOccupied    int     // Most spots occupied at once
+	Utilization     float64 // AverageOccupied / Spots (0..1)
+}
+
+// SimulationReport is the result of one simulation run
+type SimulationReport struct {
+	Layout      []FloorConfig        // The lot layout that was simulated
+	Duration    time.Duration        // Simulated time
+	Outcomes    []VehicleTypeOutcome // Per vehicle type, in VehicleType order
+	Usage       []SpotSizeUsage      // Per spot size, small to large
+	Revenue     float64              // Fees collected from vehicles that left
+	StillParked int                  // Vehicles in the lot when the run ended
+}
+
+// RejectionRate returns the share of all arrivals that were turned away
+func (report *SimulationReport) RejectionRate() float64 {
+	total := VehicleTypeOutcome{}
+	for _, outcome := range report.Outcomes {
+		total.Arrivals += outcome.Arrivals
+		total.Rejected += outcome.Rejected
+	}
+	return total.RejectionRate()
+}
+
+// simulatedPayment settles fees silently during a simulation
+type simulatedPayment struct{}
+
+func (payment *simulatedPayment) ProcessPayment(amount float64) error { return nil }
+func (payment *simulatedPayment) Refund(amount float64) error         { return nil }
+func (payment *simulatedPayment) GetName() string                     { return "Simulated" }
+
+// newVehicleOfType builds a vehicle of the given type
+func newVehicleOfType(vehicleType VehicleType, licensePlate string) (Vehicle, error) {
+	switch vehicleType {
+	case VehicleTypeMotorcycle:
+		return NewMotorcycle(licensePlate), nil
+	case VehicleTypeCar:
+		return NewCar(licensePlate), nil
+	case VehicleTypeTruck:
+		return NewTruck(licensePlate), nil
+	case VehicleTypeEVScooter:
+		return NewEVScooter(licensePlate), nil
+	case VehicleTypeBus:
+		return NewBus(licensePlate), nil
+	default:
+		return nil, fmt.Errorf("unknown vehicle type %d", vehicleType)
+	}
+}
+
+// simulatedArrival is one generated vehicle: when it comes and how long it would stay
+type simulatedArrival struct {
+	at          time.Time
+	vehicleType VehicleType
+	stay        time.Duration
+}
+
+// generateArrivals draws the traffic for a run from the seed
+// The stream depends only on the config, so every layout simulated
+// with the same config sees exactly the same vehicles
+func generateArrivals(config SimulationConfig) []simulatedArrival {
+	random := rand.New(rand.NewSource(config.Seed))
+	end := config.Start.Add(config.Duration)
+
+	// Sample vehicle types in a fixed order so a seed always gives the same run
+	vehicleTypes := make([]VehicleType, 0, len(config.Traffic.VehicleMix))
+	totalWeight := 0.0
+	for vehicleType, weight := range config.Traffic.VehicleMix {
+		if weight > 0 {
+			vehicleTypes = append(vehicleTypes, vehicleType)
+			totalWeight += weight
+		}
+	}
+	sort.Slice(vehicleTypes, func(i, j int) bool { return vehicleTypes[i] < vehicleTypes[j] })
+	pickVehicleType := func() VehicleType {
+		target := random.Float64() * totalWeight
+		for _, vehicleType := range vehicleTypes {
+			target -= config.Traffic.VehicleMix[vehicleType]
+			if target < 0 {
+				return vehicleType
+			}
+		}
+		return vehicleTypes[len(vehicleTypes)-1]
+	}
+
+	// Non-homogeneous Poisson arrivals by thinning: draw candidates at the
+	// peak rate and keep each one with probability rate(t) / peak rate
+	arrivals := make([]simulatedArrival, 0)
+	peakRate := config.Traffic.peakRate()
+	moment := config.Start
+	for {
+		gapHours := random.ExpFloat64() / peakRate
+		moment = moment.Add(time.Duration(gapHours * float64(time.Hour)))
+		if !moment.Before(end) {
+			return arrivals
+		}
+		if random.Float64()*peakRate > config.Traffic.RateAt(moment) {
+			continue
+		}
+
+		stay := time.Duration(random.ExpFloat64() * float64(config.Traffic.MeanStay))
+		if stay < config.Traffic.MinStay {
+			stay = config.Traffic.MinStay
+		}
+		arrivals = append(arrivals, simulatedArrival{at: moment, vehicleType: pickVehicleType(), stay: stay})
+	}
+}
+
+// simulatedDeparture is a parked vehicle's scheduled exit
+type simulatedDeparture struct {
+	licensePlate string
+	at           time.Time
+}
+
+// spotUsageTracker integrates occupied spots per size over time
+type spotUsageTracker struct {
+	occupied   map[SpotSize]int
+	peak       map[SpotSize]int
+	spotHours  map[SpotSize]float64
+	lastChange time.Time
+}
+
+// advance adds the occupancy since the last change, up to now
+func (tracker *spotUsageTracker) advance(now time.Time) {
+	hours := now.Sub(tracker.lastChange).Hours()
+	for size, count := range tracker.occupied {
+		tracker.spotHours[size] += float64(count) * hours
+	}
+	tracker.lastChange = now
+}
+
+// change adjusts the occupied count of every spot's size by delta
+func (tracker *spotUsageTracker) change(spots []*ParkingSpot, delta int) {
+	for _, spot := range spots {
+		tracker.occupied[spot.GetSize()] += delta
+		tracker.peak[spot.GetSize()] = max(tracker.peak[spot.GetSize()], tracker.occupied[spot.GetSize()])
+	}
+}
+
+// RunSimulation runs synthetic traffic against a lot built from layout
+func RunSimulation(layout []FloorConfig, config SimulationConfig) (*SimulationReport, error) {
+	if err := config.Traffic.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Duration <= 0 {
+		return nil, fmt.Errorf("simulation duration must be positive")
+	}
+
+	// A fresh, quiet lot whose clock is driven by the simulation
+	now := config.Start
+	lot := NewParkingLot("Simulation", layout)
+	lot.SetQuiet(true)
+	lot.SetClock(func() time.Time { return now })
+
+	payment := &simulatedPayment{}
+	end := config.Start.Add(config.Duration)
+
+	outcomes := make(map[VehicleType]*VehicleTypeOutcome)
+	tracker := &spotUsageTracker{
+		occupied:   make(map[SpotSize]int),
+		peak:       make(map[SpotSize]int),
+		spotHours:  make(map[SpotSize]float64),
+		lastChange: config.Start,
+	}
+	departures := make([]simulatedDeparture, 0) // Sorted by exit time
+	revenue := 0.0
+
+	// departUntil lets every vehicle due to leave by `until` exit the lot
+	departUntil := func(until time.Time) error {
+		for len(departures) > 0 && !departures[0].at.After(until) {
+			departure := departures[0]
+			departures = departures[1:]
+			now = departure.at
+			tracker.advance(now)
+
+			ticket, err := lot.UnparkVehicle(departure.licensePlate, payment)
+			if err != nil {
+				return err
+			}
+			tracker.change(ticket.spots, -1)
+			revenue += ticket.GetReceipt().GetNetAmount()
+		}
+		return nil
+	}
+
+	for index, arrival := range generateArrivals(config) {
+		if err := departUntil(arrival.at); err != nil {
+			return nil, err
+		}
+		now = arrival.at
+		tracker.advance(now)
+
+		vehicle, err := newVehicleOfType(arrival.vehicleType, fmt.Sprintf("SIM-%05d", index+1))
+		if err != nil {
+			return nil, err
+		}
+
+		outcome, seen := outcomes[arrival.vehicleType]
+		if !seen {
+			outcome = &VehicleTypeOutcome{Type: arrival.vehicleType}
+			outcomes[arrival.vehicleType] = outcome
+		}
+		outcome.Arrivals++
+		ticket, err := lot.ParkVehicle(vehicle)
+		if err != nil {
+			outcome.Rejected++
+			continue
+		}
+		outcome.Parked++
+		tracker.change(ticket.spots, +1)
+
+		departure := simulatedDeparture{licensePlate: vehicle.GetLicensePlate(), at: arrival.at.Add(arrival.stay)}
+		position := sort.Search(len(departures), func(i int) bool { return departures[i].at.After(departure.at) })
+		departures = append(departures, simulatedDeparture{})
+		copy(departures[position+1:], departures[position:])
+		departures[position] = departure
+	}
+
+	// Let everyone due before the end leave, then close the books
+	if err := departUntil(end); err != nil {
+		return nil, err
+	}
+	tracker.advance(end)
+
+	report := &SimulationReport{
+		Layout:      append([]FloorConfig{}, layout...),
+		Duration:    config.Duration,
+		Revenue:     revenue,
+		StillParked: len(departures),
+	}
+	for _, outcome := range outcomes {
+		report.Outcomes = append(report.Outcomes, *outcome)
+	}
+	sort.Slice(report.Outcomes, func(i, j int) bool { return report.Outcomes[i].Type < report.Outcomes[j].Type })
+
+	spotCounts := make(map[SpotSize]int)
+	for _, floor := range lot.floors {
+		for _, spot := range floor.spots {
+			spotCounts[spot.GetSize()]++
+		}
+	}
+	for _, size := range []SpotSize{SpotSizeSmall, SpotSizeMedium, SpotSizeLarge} {
+		usage := SpotSizeUsage{
+			Size:            size,
+			Spots:           spotCounts[size],
+			AverageOccupied: tracker.spotHours[size] / config.Duration.Hours(),
+			PeakOccupied:    tracker.peak[size],
+		}
+		if usage.Spots > 0 {
+			usage.Utilization = usage.AverageOccupied / float64(usage.Spots)
+		}
+		report.Usage = append(report.Usage, usage)
+	}
+	return report, nil
+}
+
+// Print writes a short summary of the report
+func (report *SimulationReport) Print(label string) {
+	fmt.Printf("  %s: layout %v, %v simulated\n", label, report.Layout, report.Duration)
+	for _, outcome := range report.Outcomes {
+		fmt.Printf("    %-10s arrivals %4d  parked %4d  rejected %4d (%5.1f%%)\n",
+			outcome.Type, outcome.Arrivals, outcome.Parked, outcome.Rejected, outcome.RejectionRate()*100)
+	}
+	for _, usage := range report.Usage {
+		fmt.Printf("    %-6s spots %3d  avg occupied %6.1f  peak %3d  utilization %5.1f%%\n",
+			usage.Size, usage.Spots, usage.AverageOccupied, usage.PeakOccupied, usage.Utilization*100)
+	}
+	fmt.Printf("    Overall rejection %.1f%%, revenue $%.2f, %d still parked at end\n",
+		report.RejectionRate()*100, report.Revenue, report.StillParked)
+}
+
+// ============================================================
+// SECTION 13: CORPORATE PARKING CONTRACTS
+// ============================================================
+// A company can rent a block of spots on one floor for its employees'
+// working hours (e.g. Mon-Fri 09:00-18:00):
+//   - During contract hours the block is invisible to everyone else;
+//     outside them the spots are ordinary public spots
+//   - Registered company vehicles are steered into their block first and
+//     pay nothing there (the company is billed for the contract instead);
+//     if the block is full they park publicly and pay the normal fee
+//   - Every covered stay is logged on the contract for monthly usage reports
+// A public vehicle that parked in the block after hours is not moved when
+// contract hours begin; the attendant handles that like any other overstay.
+
+// ContractHours is the recurring weekly window a contract reserves its spots
+type ContractHours struct {
+	StartHour int            // First reserved hour of the day (0-23)
+	EndHour   int            // Reserved until this hour (exclusive, 1-24)
+	Days      []time.Weekday // Days the window applies
+}
+
+// WeekdayHours returns a Monday-Friday window between the given hours
+func WeekdayHours(startHour, endHour int) ContractHours {
+	return ContractHours{
+		StartHour: startHour,
+		EndHour:   endHour,
+		Days:      []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	}
+}
+
+// Validate checks the window is a non-empty range within one day
+func (hours ContractHours) Validate() error {
+	if hours.StartHour < 0 || hours.EndHour > 24 || hours.StartHour >= hours.EndHour {
+		return fmt.Errorf("contract hours %02d:00-%02d:00 are not a valid daily window", hours.StartHour, hours.EndHour)
+	}
+	if len(hours.Days) == 0 {
+		return fmt.Errorf("contract hours must apply on at least one day")
+	}
+	return nil
+}
+
+// appliesOn checks whether the window applies on the moment's weekday
+func (hours ContractHours) appliesOn(moment time.Time) bool {
+	for _, day := range hours.Days {
+		if moment.Weekday() == day {
+			return true
+		}
+	}
+	return false
+}
+
+// covers checks whether the moment falls inside the weekly window
+func (hours ContractHours) covers(moment time.Time) bool {
+	return hours.appliesOn(moment) && moment.Hour() >= hours.StartHour && moment.Hour() < hours.EndHour
+}
+
+// ContractVisit is one stay of a company vehicle in its contracted block
+type ContractVisit struct {
+	TicketID     string
+	LicensePlate string
+	EntryTime    time.Time
+	ExitTime     time.Time
+}
+
+// CorporateContract reserves a block of spots for a company's vehicles
+type CorporateContract struct {
+	contractID  string          // Unique ID like "CTR-1"
+	company     string          // Company the block is rented to
+	floorNumber int             // Floor the block is on
+	spots       []*ParkingSpot  // Spots in the block
+	hours       ContractHours   // When the block is reserved
+	validFrom   time.Time       // Contract start
+	validUntil  time.Time       // Contract end (exclusive)
+	vehicles    map[string]bool // Registered company license plates
+	visits      []ContractVisit // Completed stays covered by the contract
+}
+
+// contractIDGen generates unique contract IDs
+var contractIDGen = idgen.NewSequence("CTR")
+
+// GetID returns the unique contract identifier
+func (contract *CorporateContract) GetID() string {
+	return contract.contractID
+}
+
+// GetCompany returns the company the block is rented to
+func (contract *CorporateContract) GetCompany() string {
+	return contract.company
+}
+
+// GetSpotIDs returns the IDs of the spots in the block
+func (contract *CorporateContract) GetSpotIDs() []string {
+	spotIDs := make([]string, 0, len(contract.spots))
+	for _, spot := range contract.spots {
+		spotIDs = append(spotIDs, spot.GetID())
+	}
+	return spotIDs
+}
+
+// IsActiveAt checks if the block is reserved at the given time
+func (contract *CorporateContract) IsActiveAt(moment time.Time) bool {
+	return !moment.Before(contract.validFrom) && moment.Before(contract.validUntil) && contract.hours.covers(moment)
+}
+
+// recordVisit logs a completed stay covered by the contract
+func (contract *CorporateContract) recordVisit(ticket *Ticket) {
+	contract.visits = append(contract.visits, ContractVisit{
+		TicketID:     ticket.ticketID,
+		LicensePlate: ticket.vehiclePlate,
+		EntryTime:    ticket.entryTime,
+		ExitTime:     ticket.exitTime,
+	})
+}
+
+// reservedHoursIn returns the spot-hours the block is reserved within [from, to)
+func (contract *CorporateContract) reservedHoursIn(from, to time.Time) float64 {
+	from = laterOf(from, contract.validFrom)
+	to = earlierOf(to, contract.validUntil)
+
+	total := 0.0
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !contract.hours.appliesOn(day) {
+			continue
+		}
+		windowStart := laterOf(day.Add(time.Duration(contract.hours.StartHour)*time.Hour), from)
+		windowEnd := earlierOf(day.Add(time.Duration(contract.hours.EndHour)*time.Hour), to)
+		if windowEnd.After(windowStart) {
+			total += windowEnd.Sub(windowStart).Hours()
+		}
+	}
+	return total * float64(len(contract.spots))
+}
+
+// laterOf returns the later of two times
+func laterOf(first, second time.Time) time.Time {
+	if first.After(second) {
+		return first
+	}
+	return second
+}
+
+// earlierOf returns the earlier of two times
+func earlierOf(first, second time.Time) time.Time {
+	if first.Before(second) {
+		return first
+	}
+	return second
+}
+
+// contractPayment settles a covered stay against the company's contract
+type contractPayment struct {
+	contract *CorporateContract
+}
+
+func (payment *contractPayment) ProcessPayment(amount float64) error { return nil }
+func (payment *contractPayment) Refund(amount float64) error         { return nil }
+func (payment *contractPayment) GetName() string {
+	return "Contract " + payment.contract.contractID + " (" + payment.contract.company + ")"
+}
+
+// ContractUsage summarizes one month of a contract for billing and review
+type ContractUsage struct {
+	ContractID    string
+	Company       string
+	Month         time.Time // First day of the month
+	Visits        int       // Covered stays that overlapped the month
+	Vehicles      int       // Distinct company vehicles that used the block
+	HoursParked   float64   // Covered hours parked within the month
+	ReservedHours float64   // Spot-hours the block was held for the company
+	Utilization   float64   // HoursParked / ReservedHours (0-1)
+}
+
+// Print shows the usage report
+func (usage *ContractUsage) Print() {
+	fmt.Printf("  Contract %s (%s) - %s\n", usage.ContractID, usage.Company, usage.Month.Format("January 2006"))
+	fmt.Printf("    Visits: %d by %d vehicle(s)\n", usage.Visits, usage.Vehicles)
+	fmt.Printf("    Hours parked: %.1f of %.1f reserved spot-hours (%.1f%% utilization)\n",
+		usage.HoursParked, usage.ReservedHours, usage.Utilization*100)
+}
+
+// CreateCorporateContract reserves spotCount spots of the given size on a floor
+// for a company between validFrom and validUntil, during the given hours
+// Spots already in another contract are skipped
+func (lot *ParkingLot) CreateCorporateContract(company string, floorNumber, spotCount int, size SpotSize,
+	hours ContractHours, validFrom, validUntil time.Time) (*CorporateContract, error) {
+	if floorNumber < 1 || floorNumber > len(lot.floors) {
+		return nil, fmt.Errorf("floor %d does not exist", floorNumber)
+	}
+	if spotCount < 1 {
+		return nil, fmt.Errorf("contract must reserve at least one spot")
+	}
+	if !validUntil.After(validFrom) {
+		return nil, fmt.Errorf("contract must end after it starts")
+	}
+	if err := hours.Validate(); err != nil {
+		return nil, err
+	}
+
+	block := make([]*ParkingSpot, 0, spotCount)
+	for _, spot := range lot.floors[floorNumber-1].spots {
+		if spot.contract == nil && !spot.accessible && spot.GetSize() == size {
+			block = append(block, spot)
+			if len(block) == spotCount {
+				break
+			}
+		}
+	}
+	if len(block) < spotCount {
+		return nil, fmt.Errorf("floor %d has only %d uncontracted, non-accessible %s spots, %d requested",
+			floorNumber, len(block), size, spotCount)
+	}
+
+	contract := &CorporateContract{
+		contractID:  contractIDGen.NextID(),
+		company:     company,
+		floorNumber: floorNumber,
+		spots:       block,
+		hours:       hours,
+		validFrom:   validFrom,
+		validUntil:  validUntil,
+		vehicles:    make(map[string]bool),
+		visits:      make([]ContractVisit, 0),
+	}
+	for _, spot := range block {
+		spot.contract = contract
+	}
+	lot.contracts[contract.contractID] = contract
+
+	if !lot.quiet {
+		fmt.Printf("  [CONTRACT] %s: %s reserves %s on floor %d, %02d:00-%02d:00\n",
+			contract.contractID, company, strings.Join(contract.GetSpotIDs(), ", "),
+			floorNumber, hours.StartHour, hours.EndHour)
+	}
+	return contract, nil
+}
+
+// AddContractVehicle registers a company vehicle on a contract
+// A vehicle can belong to one contract at a time
+func (lot *ParkingLot) AddContractVehicle(contractID, licensePlate string) error {
+	contract, exists := lot.contracts[contractID]
+	if !exists {
+		return fmt.Errorf("contract %s not found", contractID)
+	}
+	if existing, registered := lot.contractVehicles[licensePlate]; registered && existing != contract {
+		return fmt.Errorf("vehicle %s is already on contract %s", licensePlate, existing.contractID)
+	}
+	contract.vehicles[licensePlate] = true
+	lot.contractVehicles[licensePlate] = contract
+	return nil
+}
+
+// ContractUsageReport summarizes a contract's covered stays for the month containing `month`
+// Stays spanning the month boundary count only their hours inside the month
+func (lot *ParkingLot) ContractUsageReport(contractID string, month time.Time) (*ContractUsage, error) {
+	contract, exists := lot.contracts[contractID]
+	if !exists {
+		return nil, fmt.Errorf("contract %s not found", contractID)
+	}
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	to := from.AddDate(0, 1, 0)
+	usage := &ContractUsage{
+		ContractID:    contract.contractID,
+		Company:       contract.company,
+		Month:         from,
+		ReservedHours: contract.reservedHoursIn(from, to),
+	}
+
+	vehicles := make(map[string]bool)
+	for _, visit := range contract.visits {
+		start := laterOf(visit.EntryTime, from)
+		end := earlierOf(visit.ExitTime, to)
+		if !end.After(start) {
+			continue
+		}
+		usage.Visits++
+		usage.HoursParked += end.Sub(start).Hours()
+		vehicles[visit.LicensePlate] = true
+	}
+	usage.Vehicles = len(vehicles)
+	if usage.ReservedHours > 0 {
+		usage.Utilization = usage.HoursParked / usage.ReservedHours
+	}
+	return usage, nil
+}
+
+// isContractedAt checks if a contract reserves this spot at the given time
+func (spot *ParkingSpot) isContractedAt(moment time.Time) bool {
+	return spot.contract != nil && spot.contract.IsActiveAt(moment)
+}
+
+// ============================================================
+// SECTION 14: ACCESSIBLE PARKING AND PERMITS
+// ============================================================
+// Some spots are designated accessible. Only vehicles covered by a valid
+// accessibility permit may use them:
+//   - Permits are registered with the lot: a permit number, the holder,
+//     the license plates it covers and a validity window
+//   - At entry a permitted vehicle is steered to an accessible spot first
+//     and falls back to ordinary spots if none is free; every other
+//     vehicle is kept out of accessible spots entirely
+//   - A plate whose permit is expired, not yet valid or revoked parks like
+//     any other vehicle, and the rejected permit is logged
+//   - A patrol sweep flags vehicles sitting in accessible spots without a
+//     valid permit (e.g. the permit expired or was revoked mid-stay)
+// Every rejection and flag is kept in an enforcement log for the attendant.
+// Availability displays count accessible spots separately, since the
+// general public cannot use them.
+
+// AccessibilityPermit is an accessible parking permit registered with the lot
+type AccessibilityPermit struct {
+	Number        string    // Permit number printed on the placard
+	Holder        string    // Person the permit was issued to
+	LicensePlates []string  // Vehicles the permit covers
+	ValidFrom     time.Time // First moment the permit is valid
+	ValidUntil    time.Time // Permit expiry (exclusive)
+	Revoked       bool      // Withdrawn by the issuing authority
+}
+
+// Validate checks the permit's fields before registration
+func (permit AccessibilityPermit) Validate() error {
+	if permit.Number == "" {
+		return fmt.Errorf("permit number is required")
+	}
+	if len(permit.LicensePlates) == 0 {
+		return fmt.Errorf("permit %s must cover at least one vehicle", permit.Number)
+	}
+	if !permit.ValidUntil.After(permit.ValidFrom) {
+		return fmt.Errorf("permit %s must expire after it becomes valid", permit.Number)
+	}
+	return nil
+}
+
+// CheckAt returns why the permit cannot be used at the given time (nil if it can)
+func (permit *AccessibilityPermit) CheckAt(moment time.Time) error {
+	switch {
+	case permit.Revoked:
+		return fmt.Errorf("permit %s has been revoked", permit.Number)
+	case moment.Before(permit.ValidFrom):
+		return fmt.Errorf("permit %s is not valid until %s", permit.Number, permit.ValidFrom.Format("2006-01-02"))
+	case !moment.Before(permit.ValidUntil):
+		return fmt.Errorf("permit %s expired on %s", permit.Number, permit.ValidUntil.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// EnforcementReason says why an enforcement exception was logged
+type EnforcementReason int
+
+const (
+	EnforcementPermitRejected     EnforcementReason = iota // Invalid permit presented at entry
+	EnforcementUnpermittedVehicle                          // Accessible spot used without a valid permit
+)
+
+// String returns a human-readable name for the reason
+func (reason EnforcementReason) String() string {
+	switch reason {
+	case EnforcementPermitRejected:
+		return "Permit Rejected"
+	case EnforcementUnpermittedVehicle:
+		return "Unpermitted Vehicle"
+	default:
+		return "Unknown"
+	}
+}
+
+// EnforcementException is one entry in the accessible parking enforcement log
+type EnforcementException struct {
+	Time         time.Time         // When the exception was found
+	Reason       EnforcementReason // What went wrong
+	LicensePlate string            // Vehicle involved
+	PermitNumber string            // Permit involved (empty if the vehicle has none)
+	SpotID       string            // Accessible spot involved (empty for entry rejections)
+	Detail       string            // Human-readable explanation
+}
+
+// String returns a one-line description of the exception
+func (exception EnforcementException) String() string {
+	location := ""
+	if exception.SpotID != "" {
+		location = " in " + exception.SpotID
+	}
+	return fmt.Sprintf("%s %s: %s%s - %s", exception.Time.Format("2006-01-02 15:04"),
+		exception.Reason, exception.LicensePlate, location, exception.Detail)
+}
+
+// IsAccessible checks if this spot is reserved for permit holders
+func (spot *ParkingSpot) IsAccessible() bool {
+	return spot.accessible
+}
+
+// GetAccessibleSpotCounts returns how many accessible spots on this floor
+// are available right now, and how many there are in total
+func (floor *Floor) GetAccessibleSpotCounts() (available, total int) {
+	for _, spot := range floor.spots {
+		if !spot.accessible {
+			continue
+		}
+		total++
+		if spot.IsAvailable() {
+			available++
+		}
+	}
+	return available, total
+}
+
+// DesignateAccessibleSpots reserves the given spots for permit holders
+// Spots rented out under a corporate contract cannot be designated
+func (lot *ParkingLot) DesignateAccessibleSpots(spotIDs ...string) error {
+	spots, err := lot.spotsByID(spotIDs)
+	if err != nil {
+		return err
+	}
+	for _, spot := range spots {
+		if spot.contract != nil {
+			return fmt.Errorf("spot %s is reserved by contract %s", spot.GetID(), spot.contract.contractID)
+		}
+	}
+	for _, spot := range spots {
+		spot.accessible = true
+	}
+	return nil
+}
+
+// RegisterPermit records an accessibility permit for its vehicles
+// A vehicle can be covered by one permit at a time
+func (lot *ParkingLot) RegisterPermit(permit AccessibilityPermit) error {
+	if err := permit.Validate(); err != nil {
+		return err
+	}
+	if _, exists := lot.permits[permit.Number]; exists {
+		return fmt.Errorf("permit %s is already registered", permit.Number)
+	}
+	for _, licensePlate := range permit.LicensePlates {
+		if existing, covered := lot.permitsByPlate[licensePlate]; covered {
+			return fmt.Errorf("vehicle %s is already covered by permit %s", licensePlate, existing.Number)
+		}
+	}
+
+	registered := permit
+	registered.LicensePlates = append([]string{}, permit.LicensePlates...)
+	lot.permits[registered.Number] = &registered
+	for _, licensePlate := range registered.LicensePlates {
+		lot.permitsByPlate[licensePlate] = &registered
+	}
+	return nil
+}
+
+// RevokePermit withdraws a permit; its vehicles lose access to accessible spots
+func (lot *ParkingLot) RevokePermit(permitNumber string) error {
+	permit, exists := lot.permits[permitNumber]
+	if !exists {
+		return fmt.Errorf("permit %s not found", permitNumber)
+	}
+	permit.Revoked = true
+	return nil
+}
+
+// ValidatePermit returns the vehicle's permit if it may use accessible spots
+// at the given time, or an error saying why not
+func (lot *ParkingLot) ValidatePermit(licensePlate string, moment time.Time) (*AccessibilityPermit, error) {
+	permit, covered := lot.permitsByPlate[licensePlate]
+	if !covered {
+		return nil, fmt.Errorf("vehicle %s has no accessibility permit", licensePlate)
+	}
+	if err := permit.CheckAt(moment); err != nil {
+		return nil, err
+	}
+	return permit, nil
+}
+
+// hasValidPermit checks if the vehicle may use accessible spots at the given time
+func (lot *ParkingLot) hasValidPermit(licensePlate string, moment time.Time) bool {
+	_, err := lot.ValidatePermit(licensePlate, moment)
+	return err == nil
+}
+
+// checkPermitAtEntry logs an exception if the vehicle presents a permit
+// that cannot be used right now
+func (lot *ParkingLot) checkPermitAtEntry(licensePlate string, now time.Time) {
+	permit, covered := lot.permitsByPlate[licensePlate]
+	if !covered {
+		return
+	}
+	if err := permit.CheckAt(now); err != nil {
+		lot.logEnforcement(EnforcementException{
+			Time:         now,
+			Reason:       EnforcementPermitRejected,
+			LicensePlate: licensePlate,
+			PermitNumber: permit.Number,
+			Detail:       err.Error() + "; parked in an ordinary spot",
+		})
+	}
+}
+
+// PatrolAccessibleSpots flags every vehicle in an accessible spot without a
+// valid permit. A stay is flagged once, however many patrols find it
+// Returns the exceptions logged by this patrol
+func (lot *ParkingLot) PatrolAccessibleSpots() []EnforcementException {
+	now := lot.clock()
+	found := make([]EnforcementException, 0)
+	for _, floor := range lot.floors {
+		for _, spot := range floor.spots {
+			vehicle := spot.GetVehicle()
+			if !spot.accessible || vehicle == nil {
+				continue
+			}
+			licensePlate := vehicle.GetLicensePlate()
+			_, err := lot.ValidatePermit(licensePlate, now)
+			if err == nil {
+				continue
+			}
+			ticket := lot.activeTickets[licensePlate]
+			if ticket == nil || lot.flaggedTickets[ticket.ticketID] {
+				continue
+			}
+			lot.flaggedTickets[ticket.ticketID] = true
+
+			exception := EnforcementException{
+				Time:         now,
+				Reason:       EnforcementUnpermittedVehicle,
+				LicensePlate: licensePlate,
+				SpotID:       spot.GetID(),
+				Detail:       err.Error(),
+			}
+			if permit, covered := lot.permitsByPlate[licensePlate]; covered {
+				exception.PermitNumber = permit.Number
+			}
+			lot.logEnforcement(exception)
+			found = append(found, exception)
+		}
+	}
+	return found
+}
+
+// logEnforcement appends an exception to the enforcement log
+func (lot *ParkingLot) logEnforcement(exception EnforcementException) {
+	lot.enforcementLog = append(lot.enforcementLog, exception)
+	if !lot.quiet {
+		fmt.Printf("  [ENFORCEMENT] %s\n", exception)
+	}
+}
+
+// GetEnforcementLog returns every enforcement exception logged so far
+func (lot *ParkingLot) GetEnforcementLog() []EnforcementException {
+	return append([]EnforcementException{}, lot.enforcementLog...)
+}
+
+// ============================================================
+// SECTION 15: GATE API PROTECTION (Rate Limiting + ANPR Dedup)
+// ============================================================
+// Entry and exit gates call the lot through a GateAPI, driven by ANPR
+// (automatic number-plate recognition) cameras. A malfunctioning camera
+// can fire the same plate read many times a second, so every gate event
+// passes three checks before it reaches ParkVehicle/UnparkVehicle:
+//   1. Dedup: an identical read (same gate, direction and plate) within
+//      the dedup window of an accepted one is dropped as a
+//      DuplicateReadError. Each repeat restarts the window, so a stuck
+//      camera stays suppressed
+//   2. Per-gate limit: a gate can't push more events than a real barrier
+//      could let through, whatever plates it reads
+//   3. Per-plate limit: one plate can't enter/exit in a tight loop across
+//      gates (a mirrored camera, or a misread plate on every car)
+// Duplicates are dropped before the limiters so they don't eat the
+// gate's budget. Throttled events fail with a GateRateLimitError.
+//
+// An event is only charged once both limits have room, so a throttled
+// plate doesn't spend the gate's budget. Any limiter from 09_rate_limiter
+// can be plugged in; Compact (or StartCompaction) drops the limiter state
+// of gates and plates that have gone idle.
+//
+// Plates are normalized (upper case, no spaces or dashes) for dedup and
+// limiting, since cameras disagree on formatting.
+
+// DefaultDedupWindow is how long an identical plate read counts as a duplicate
+const DefaultDedupWindow = 5 * time.Second
+
+// compactable is implemented by limiters that can drop the state of idle keys
+type compactable interface {
+	Compact() int
+}
+
+// GateDirection says whether a gate event is an entry or an exit
+type GateDirection int
+
+const (
+	GateDirectionEntry GateDirection = iota
+	GateDirectionExit
+)
+
+// String returns the direction name
+func (direction GateDirection) String() string {
+	if direction == GateDirectionExit {
+		return "exit"
+	}
+	return "entry"
+}
+
+// DuplicateReadError is returned for a plate read already seen within the dedup window
+type DuplicateReadError struct {
+	GateID       string
+	Direction    GateDirection
+	LicensePlate string
+	FirstSeen    time.Time // When the read that was acted on arrived
+}
+
+func (err *DuplicateReadError) Error() string {
+	return fmt.Sprintf("duplicate %s read of %s at gate %s (first seen %s)",
+		err.Direction, err.LicensePlate, err.GateID, err.FirstSeen.Format("15:04:05"))
+}
+
+// GateRateLimitError is returned when a gate or plate has exhausted its quota,
+// so an HTTP layer can map it to a 429 response
+type GateRateLimitError struct {
+	Key string // The limiter key that was throttled (e.g., "gate:G1", "plate:KA01AB1234")
+}
+
+func (err *GateRateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s", err.Key)
+}
+
+// GateStats counts what happened to one gate's events
+type GateStats struct {
+	Accepted       int // Events passed on to the lot (whatever the lot answered)
+	Duplicates     int // Identical reads dropped inside the dedup window
+	GateThrottled  int // Events rejected by the per-gate limit
+	PlateThrottled int // Events rejected by the per-plate limit
+}
+
+// plateRead remembers when a (gate, direction, plate) read was first acted on and last seen
+type plateRead struct {
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// GateAPI is the entry point gates and cameras use in front of the ParkingLot
+// The lot itself is not safe for concurrent use, so the API serializes calls
+type GateAPI struct {
+	lot          *ParkingLot
+	gateLimiter  ratelimiter.RateLimiter // Keyed by gate ID
+	plateLimiter ratelimiter.RateLimiter // Keyed by normalized plate
+	dedupWindow  time.Duration           // How long an identical read is a duplicate
+	recentReads  map[string]*plateRead   // Dedup key -> last read
+	stats        map[string]*GateStats   // Gate ID -> counters
+	compaction   periodic.Job            // Drops idle limiter state in the background
+	mutex        sync.Mutex              // Serializes gate events
+}
+
+// NewGateAPI wires the lot with a per-gate and a per-plate limiter
+func NewGateAPI(lot *ParkingLot, gateLimiter, plateLimiter ratelimiter.RateLimiter) *GateAPI {
+	return &GateAPI{
+		lot:          lot,
+		gateLimiter:  gateLimiter,
+		plateLimiter: plateLimiter,
+		dedupWindow:  DefaultDedupWindow,
+		recentReads:  make(map[string]*plateRead),
+		stats:        make(map[string]*GateStats),
+	}
+}
+
+// SetDedupWindow changes how long an identical plate read is treated as a duplicate
+func (api *GateAPI) SetDedupWindow(window time.Duration) error {
+	if window < 0 {
+		return fmt.Errorf("dedup window cannot be negative")
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	api.dedupWindow = window
+	return nil
+}
+
+// normalizePlate makes plate reads comparable across cameras ("ka-01 ab" -> "KA01AB")
+func normalizePlate(licensePlate string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(licensePlate))
+}
+
+// admit runs dedup and both limiters for one gate event
+// Caller must hold api.mutex
+func (api *GateAPI) admit(gateID string, direction GateDirection, licensePlate string) error {
+	now := api.lot.clock()
+	stats := api.stats[gateID]
+	if stats == nil {
+		stats = &GateStats{}
+		api.stats[gateID] = stats
+	}
+
+	// Forget reads that have gone quiet for a whole window
+	for key, read := range api.recentReads {
+		if now.Sub(read.lastSeen) >= api.dedupWindow {
+			delete(api.recentReads, key)
+		}
+	}
+
+	plate := normalizePlate(licensePlate)
+	dedupKey := gateID + "|" + direction.String() + "|" + plate
+	if read, seen := api.recentReads[dedupKey]; seen {
+		read.lastSeen = now
+		stats.Duplicates++
+		return &DuplicateReadError{GateID: gateID, Direction: direction, LicensePlate: plate, FirstSeen: read.firstSeen}
+	}
+
+	// Both limits are checked before either is charged; events are
+	// serialized, so the quotas can't change in between
+	gateKey, plateKey := "gate:"+gateID, "plate:"+plate
+	if api.gateLimiter.Check(gateKey).Remaining < 1 {
+		stats.GateThrottled++
+		return &GateRateLimitError{Key: gateKey}
+	}
+	if api.plateLimiter.Check(plateKey).Remaining < 1 {
+		stats.PlateThrottled++
+		return &GateRateLimitError{Key: plateKey}
+	}
+	api.gateLimiter.Allow(gateKey)
+	api.plateLimiter.Allow(plateKey)
+
+	// Only reads that get through count for dedup, so a throttled read can be retried
+	api.recentReads[dedupKey] = &plateRead{firstSeen: now, lastSeen: now}
+	stats.Accepted++
+	return nil
+}
+
+// Enter handles a camera read at an entry gate and parks the vehicle
+func (api *GateAPI) Enter(gateID string, vehicle Vehicle) (*Ticket, error) {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	if err := api.admit(gateID, GateDirectionEntry, vehicle.GetLicensePlate()); err != nil {
+		return nil, err
+	}
+	return api.lot.ParkVehicle(vehicle)
+}
+
+// Exit handles a camera read at an exit gate and unparks the vehicle
+func (api *GateAPI) Exit(gateID, licensePlate string, paymentMethod PaymentMethod) (*Ticket, error) {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	if err := api.admit(gateID, GateDirectionExit, licensePlate); err != nil {
+		return nil, err
+	}
+	return api.lot.UnparkVehicle(licensePlate, paymentMethod)
+}
+
+// Compact drops the limiter state of idle gates and plates and returns how
+// many keys were dropped
+func (api *GateAPI) Compact() int {
+	dropped := 0
+	for _, limiter := range []ratelimiter.RateLimiter{api.gateLimiter, api.plateLimiter} {
+		if compacting, ok := limiter.(compactable); ok {
+			dropped += compacting.Compact()
+		}
+	}
+	return dropped
+}
+
+// StartCompaction runs Compact in the background every interval
+// Calling it while compaction is running has no effect
+func (api *GateAPI) StartCompaction(interval time.Duration) {
+	api.compaction.Start(interval, func(time.Time) {
+		api.Compact()
+	})
+}
+
+// StopCompaction stops the background compaction and waits for a run in
+// progress to finish
+func (api *GateAPI) StopCompaction() {
+	api.compaction.Stop()
+}
+
+// GetGateStats returns a copy of the counters for a gate
+func (api *GateAPI) GetGateStats(gateID string) GateStats {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	if stats := api.stats[gateID]; stats != nil {
+		return *stats
+	}
+	return GateStats{}
+}
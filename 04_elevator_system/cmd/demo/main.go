@@ -0,0 +1,8 @@
+// Command demo runs the elevator walkthrough: go run ./04_elevator_system/cmd/demo
+package main
+
+import elevator "github.com/ayushgupta5/GoLLD/04_elevator_system"
+
+func main() {
+	elevator.RunDemo()
+}
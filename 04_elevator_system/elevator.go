@@ -1,4 +1,6 @@
-package main
+// Package elevator dispatches a bank of elevators across a building's floors,
+// serving hall calls and in-car requests through pluggable strategies.
+package elevator
 
 import (
 	"fmt"
@@ -553,78 +555,3 @@ func absoluteValue(x int) int {
 	}
 	return x
 }
-
-// ============================================================
-// MAIN FUNCTION - Demonstrates the elevator system
-// ============================================================
-
-func main() {
-	fmt.Println("═══════════════════════════════════════════")
-	fmt.Println("       ELEVATOR SYSTEM - LLD DEMO")
-	fmt.Println("═══════════════════════════════════════════")
-
-	// Create a building with:
-	// - Floors 0 (ground) to 10
-	// - 3 elevators
-	// - Each elevator can hold 10 people
-	building := NewBuilding("Tech Tower", 0, 10, 3, 10)
-
-	// Display initial status
-	fmt.Println(building.GetStatus())
-
-	// ========== SCENARIO 1: Multiple floor requests ==========
-	fmt.Println("\n📌 SCENARIO 1: Multiple floor requests")
-	fmt.Println("─────────────────────────────────────────")
-
-	// Person on floor 5 wants to go down
-	_, _ = building.CallElevator(5, DirectionDown)
-	time.Sleep(100 * time.Millisecond)
-
-	// Person on floor 8 wants to go down
-	_, _ = building.CallElevator(8, DirectionDown)
-	time.Sleep(100 * time.Millisecond)
-
-	// Person on floor 2 wants to go up
-	_, _ = building.CallElevator(2, DirectionUp)
-	time.Sleep(100 * time.Millisecond)
-
-	// Wait for elevators to process requests
-	time.Sleep(2 * time.Second)
-	fmt.Println(building.GetStatus())
-
-	// ========== SCENARIO 2: Internal floor selection ==========
-	fmt.Println("\n📌 SCENARIO 2: Internal floor selection")
-	fmt.Println("─────────────────────────────────────────")
-
-	// Person inside elevator 1 presses button for floor 10
-	_ = building.SelectFloor(1, 10)
-
-	// Person inside elevator 2 presses button for floor 0 (ground)
-	_ = building.SelectFloor(2, 0)
-
-	time.Sleep(3 * time.Second)
-	fmt.Println(building.GetStatus())
-
-	// ========== SCENARIO 3: Rush hour simulation ==========
-	fmt.Println("\n📌 SCENARIO 3: Rush hour simulation")
-	fmt.Println("─────────────────────────────────────────")
-
-	// Multiple people calling elevators from different floors
-	for floor := 1; floor <= 5; floor++ {
-		_, _ = building.CallElevator(floor, DirectionUp)
-		time.Sleep(50 * time.Millisecond)
-	}
-
-	time.Sleep(4 * time.Second)
-	fmt.Println(building.GetStatus())
-
-	// ========== Summary of Design Patterns ==========
-	fmt.Println("\n═══════════════════════════════════════════")
-	fmt.Println("  KEY DESIGN PATTERNS USED:")
-	fmt.Println("═══════════════════════════════════════════")
-	fmt.Println("  1. State Pattern  - Elevator states (Idle, Moving, Stopped, Maintenance)")
-	fmt.Println("  2. Strategy Pattern - Scheduling algorithms (Nearest, RoundRobin)")
-	fmt.Println("  3. Facade Pattern - Building provides simple interface")
-	fmt.Println("  4. SCAN Algorithm - Efficient floor serving (elevator algorithm)")
-	fmt.Println("═══════════════════════════════════════════")
-}
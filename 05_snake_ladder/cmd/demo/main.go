@@ -0,0 +1,8 @@
+// Command demo runs the snakeladder walkthrough: go run ./05_snake_ladder/cmd/demo
+package main
+
+import snakeladder "github.com/ayushgupta5/GoLLD/05_snake_ladder"
+
+func main() {
+	snakeladder.RunDemo()
+}
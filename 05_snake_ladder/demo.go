@@ -0,0 +1,110 @@
+package snakeladder
+
+import "fmt"
+
+// ========== MAIN ==========
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	// Note: In Go 1.20+, random number generation is automatically seeded
+	// No need to call rand.Seed() anymore - it's deprecated
+
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("       SNAKE AND LADDER - LLD DEMO")
+	fmt.Println("═══════════════════════════════════════════")
+
+	// Define game configuration
+	// - BoardSize: Standard 100-square board
+	// - Snakes: Each pair is [head, tail] - landing on head drops you to tail
+	// - Ladders: Each pair is [start, end] - landing on start lifts you to end
+	config := GameConfig{
+		BoardSize: 100,
+		Snakes: [][2]int{
+			{99, 54}, // Near win - cruel snake!
+			{70, 55},
+			{52, 42},
+			{25, 2},
+			{95, 72},
+		},
+		Ladders: [][2]int{
+			{6, 25},
+			{11, 40},
+			{60, 85},
+			{46, 90},
+			{17, 69},
+		},
+		PlayerNames: []string{"Alice", "Bob", "Charlie"},
+		Dice:        NewStandardDice(), // Can swap with NewDoubleDice() or NewBiasedDice()
+	}
+
+	// Create and play game
+	game, err := NewGame(config)
+	if err != nil {
+		fmt.Printf("Failed to create game: %v\n", err)
+		return
+	}
+
+	// Play complete game
+	winner := game.PlayGame()
+
+	if winner != nil {
+		fmt.Println("\n═══════════════════════════════════════════")
+		fmt.Printf("  🎊 Congratulations %s! 🎊\n", winner.GetName())
+		fmt.Println("═══════════════════════════════════════════")
+	}
+
+	// Show per-player statistics derived from the replay
+	fmt.Println("\n📊 Player Statistics:")
+	fmt.Println("─────────────────────────────────────────")
+	for _, stats := range game.GetStatistics() {
+		fmt.Printf("  %-8s rolls: %2d | sixes: %d | snake bites: %d | ladders: %d\n",
+			stats.PlayerName, stats.TotalRolls, stats.SixesRolled, stats.SnakeBites, stats.LaddersClimbed)
+	}
+
+	// Save the replay as JSON, load it back and step through the first turns
+	replayJSON, err := game.GetReplay().ToJSON()
+	if err != nil {
+		fmt.Printf("Failed to save replay: %v\n", err)
+		return
+	}
+	fmt.Printf("\n💾 Replay saved (%d bytes of JSON)\n", len(replayJSON))
+
+	loadedReplay, err := ReplayFromJSON(replayJSON)
+	if err != nil {
+		fmt.Printf("Failed to load replay: %v\n", err)
+		return
+	}
+
+	fmt.Println("\n⏯️  Replaying first 5 turns:")
+	replayPlayer := NewReplayPlayer(loadedReplay)
+	for step := 0; step < 5; step++ {
+		turn, ok := replayPlayer.Step()
+		if !ok {
+			break
+		}
+		name := loadedReplay.PlayerNames[turn.PlayerID-1]
+		fmt.Printf("  Turn %d: %s rolled %d, %d → %d %s\n",
+			turn.Turn, name, turn.DiceValue, turn.FromPosition,
+			replayPlayer.GetPosition(turn.PlayerID), turn.Event)
+	}
+
+	// A tampered file is rejected when loaded, before anything indexes by player ID
+	tampered := *loadedReplay
+	tampered.Turns = append([]TurnRecord(nil), loadedReplay.Turns...)
+	tampered.Turns[0].PlayerID = 7
+	if tamperedJSON, err := tampered.ToJSON(); err == nil {
+		if _, err := ReplayFromJSON(tamperedJSON); err != nil {
+			fmt.Printf("\n❌ Tampered replay: %v\n", err)
+		}
+	}
+
+	fmt.Println("\n═══════════════════════════════════════════")
+	fmt.Println("  KEY DESIGN DECISIONS:")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  1. Dice interface - Strategy pattern")
+	fmt.Println("  2. Board encapsulates snake/ladder logic")
+	fmt.Println("  3. Game orchestrates the flow")
+	fmt.Println("  4. Easy to extend (power-ups, etc.)")
+	fmt.Println("  5. Replay recorder - statistics derived from turns")
+	fmt.Println("═══════════════════════════════════════════")
+}
@@ -1,6 +1,9 @@
-package main
+// Package snakeladder plays Snake and Ladder: boards, dice, players, turn
+// history and replays.
+package snakeladder
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 )
@@ -329,6 +332,189 @@ func (b *Board) PrintBoard() {
 	}
 }
 
+// ========== REPLAY & STATISTICS ==========
+//
+// Every turn is recorded as a TurnRecord inside a Replay. The replay holds the
+// board layout too, so it can be saved as JSON and stepped through later
+// without the original Game object. Per-player statistics are derived from
+// the recorded turns rather than tracked separately, so they always agree.
+
+// TurnEvent describes what happened after the dice moved the player
+type TurnEvent string
+
+const (
+	TurnEventNone      TurnEvent = ""          // Landed on a plain square
+	TurnEventSnake     TurnEvent = "snake"     // Bitten by a snake
+	TurnEventLadder    TurnEvent = "ladder"    // Climbed a ladder
+	TurnEventOvershoot TurnEvent = "overshoot" // Roll too high, player stayed put
+)
+
+// TurnRecord captures one dice roll and the resulting position change
+type TurnRecord struct {
+	Turn           int       `json:"turn"`            // 1-based turn number
+	PlayerID       int       `json:"player_id"`       // Player who rolled
+	DiceValue      int       `json:"dice_value"`      // Value rolled
+	FromPosition   int       `json:"from_position"`   // Position before the roll
+	LandedPosition int       `json:"landed_position"` // Position after moving by the roll
+	FinalPosition  int       `json:"final_position"`  // Position after snake/ladder
+	Event          TurnEvent `json:"event,omitempty"` // Snake, ladder, overshoot or none
+}
+
+// Replay is a serializable recording of a whole game
+type Replay struct {
+	BoardSize   int          `json:"board_size"`
+	Snakes      [][2]int     `json:"snakes"`
+	Ladders     [][2]int     `json:"ladders"`
+	PlayerNames []string     `json:"player_names"` // Index i belongs to player ID i+1
+	Turns       []TurnRecord `json:"turns"`
+	WinnerID    int          `json:"winner_id,omitempty"` // 0 if the game didn't finish
+}
+
+// ToJSON serializes the replay
+func (r *Replay) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// ReplayFromJSON restores a replay saved with ToJSON
+// The replay is checked against the rules, so a corrupted or hand-edited
+// file is rejected here instead of breaking Statistics or a ReplayPlayer
+func ReplayFromJSON(data []byte) (*Replay, error) {
+	var replay Replay
+	if err := json.Unmarshal(data, &replay); err != nil {
+		return nil, fmt.Errorf("invalid replay: %w", err)
+	}
+	if err := replay.validate(); err != nil {
+		return nil, fmt.Errorf("invalid replay: %w", err)
+	}
+	return &replay, nil
+}
+
+// validate replays every turn on the recorded board and checks that players
+// take turns in order, each roll starts where the player last stood and
+// moves them the way the board says
+func (r *Replay) validate() error {
+	if len(r.PlayerNames) == 0 {
+		return fmt.Errorf("at least one player is required")
+	}
+	board, err := newBoardFromLayout(r.BoardSize, r.Snakes, r.Ladders)
+	if err != nil {
+		return err
+	}
+
+	positions := make([]int, len(r.PlayerNames)) // Index i is player ID i+1
+	winnerID := 0
+	for index, turn := range r.Turns {
+		if winnerID != 0 {
+			return fmt.Errorf("turn %d: played after player %d won", turn.Turn, winnerID)
+		}
+		if turn.Turn != index+1 {
+			return fmt.Errorf("turn %d: recorded as turn %d", index+1, turn.Turn)
+		}
+		if expectedID := index%len(positions) + 1; turn.PlayerID != expectedID {
+			return fmt.Errorf("turn %d: player %d rolled, but it was player %d's turn", turn.Turn, turn.PlayerID, expectedID)
+		}
+		if turn.DiceValue < 1 {
+			return fmt.Errorf("turn %d: invalid dice value %d", turn.Turn, turn.DiceValue)
+		}
+		if turn.FromPosition != positions[turn.PlayerID-1] {
+			return fmt.Errorf("turn %d: player %d starts at %d, but stood at %d",
+				turn.Turn, turn.PlayerID, turn.FromPosition, positions[turn.PlayerID-1])
+		}
+
+		landed, final, event := turn.FromPosition, turn.FromPosition, TurnEventOvershoot
+		if turn.FromPosition+turn.DiceValue <= board.GetSize() {
+			landed = turn.FromPosition + turn.DiceValue
+			final, _ = board.GetNewPosition(landed)
+			event = TurnEventNone
+			if final < landed {
+				event = TurnEventSnake
+			} else if final > landed {
+				event = TurnEventLadder
+			}
+		}
+		if turn.LandedPosition != landed || turn.FinalPosition != final || turn.Event != event {
+			return fmt.Errorf("turn %d: rolling %d from %d ends at %d (%q), not %d (%q)",
+				turn.Turn, turn.DiceValue, turn.FromPosition, final, event, turn.FinalPosition, turn.Event)
+		}
+
+		positions[turn.PlayerID-1] = final
+		if board.IsWinningPosition(final) {
+			winnerID = turn.PlayerID
+		}
+	}
+
+	if r.WinnerID != winnerID {
+		return fmt.Errorf("winner is recorded as player %d, but the turns give player %d", r.WinnerID, winnerID)
+	}
+	return nil
+}
+
+// PlayerStats summarizes one player's game
+type PlayerStats struct {
+	PlayerName     string
+	TotalRolls     int
+	SixesRolled    int
+	SnakeBites     int
+	LaddersClimbed int
+}
+
+// Statistics computes per-player statistics from the recorded turns
+// Results are ordered by player ID
+func (r *Replay) Statistics() []*PlayerStats {
+	stats := make([]*PlayerStats, len(r.PlayerNames))
+	for index, name := range r.PlayerNames {
+		stats[index] = &PlayerStats{PlayerName: name}
+	}
+
+	for _, turn := range r.Turns {
+		playerStats := stats[turn.PlayerID-1]
+		playerStats.TotalRolls++
+		if turn.DiceValue == 6 {
+			playerStats.SixesRolled++
+		}
+		switch turn.Event {
+		case TurnEventSnake:
+			playerStats.SnakeBites++
+		case TurnEventLadder:
+			playerStats.LaddersClimbed++
+		}
+	}
+	return stats
+}
+
+// ReplayPlayer steps through a replay one turn at a time
+type ReplayPlayer struct {
+	replay    *Replay
+	nextTurn  int         // Index of the next turn to apply
+	positions map[int]int // Player ID -> position after the applied turns
+}
+
+// NewReplayPlayer creates a player positioned before the first turn
+func NewReplayPlayer(replay *Replay) *ReplayPlayer {
+	positions := make(map[int]int)
+	for index := range replay.PlayerNames {
+		positions[index+1] = 0
+	}
+	return &ReplayPlayer{replay: replay, positions: positions}
+}
+
+// Step applies the next recorded turn
+// Returns the turn and true, or nil and false when the replay is finished
+func (rp *ReplayPlayer) Step() (*TurnRecord, bool) {
+	if rp.nextTurn >= len(rp.replay.Turns) {
+		return nil, false
+	}
+	turn := &rp.replay.Turns[rp.nextTurn]
+	rp.positions[turn.PlayerID] = turn.FinalPosition
+	rp.nextTurn++
+	return turn, true
+}
+
+// GetPosition returns a player's position at the current step of the replay
+func (rp *ReplayPlayer) GetPosition(playerID int) int {
+	return rp.positions[playerID]
+}
+
 // ========== GAME ==========
 
 // GameState represents the current state of the game
@@ -350,6 +536,7 @@ type Game struct {
 	currentTurn int       // Index of the player whose turn it is
 	state       GameState // Current state of the game
 	winner      *Player   // The winning player (nil until game ends)
+	replay      *Replay   // Recording of every turn played
 }
 
 // GameConfig holds all the configuration options for creating a new game
@@ -362,25 +549,19 @@ type GameConfig struct {
 	Dice        Dice     // Optional: Custom dice (defaults to StandardDice)
 }
 
-// NewGame creates a new game with the given configuration
-// Returns an error if the configuration is invalid (e.g., invalid snake/ladder positions)
-func NewGame(config GameConfig) (*Game, error) {
-	// Validate that we have at least one player
-	if len(config.PlayerNames) == 0 {
-		return nil, fmt.Errorf("at least one player is required")
-	}
-
+// newBoardFromLayout builds a board and checks its snakes and ladders
+func newBoardFromLayout(size int, snakes, ladders [][2]int) (*Board, error) {
 	// Validate board size
-	if config.BoardSize < 10 {
+	if size < 10 {
 		return nil, fmt.Errorf("board size must be at least 10")
 	}
 
 	// Create the game board with the specified size
-	board := NewBoard(config.BoardSize)
+	board := NewBoard(size)
 
 	// Add all snakes to the board
 	// Each snake is defined as [head, tail] where head > tail
-	for _, snakeConfig := range config.Snakes {
+	for _, snakeConfig := range snakes {
 		if err := board.AddSnake(snakeConfig[0], snakeConfig[1]); err != nil {
 			return nil, fmt.Errorf("failed to add snake: %w", err)
 		}
@@ -388,11 +569,27 @@ func NewGame(config GameConfig) (*Game, error) {
 
 	// Add all ladders to the board
 	// Each ladder is defined as [start, end] where start < end
-	for _, ladderConfig := range config.Ladders {
+	for _, ladderConfig := range ladders {
 		if err := board.AddLadder(ladderConfig[0], ladderConfig[1]); err != nil {
 			return nil, fmt.Errorf("failed to add ladder: %w", err)
 		}
 	}
+	return board, nil
+}
+
+// NewGame creates a new game with the given configuration
+// Returns an error if the configuration is invalid (e.g., invalid snake/ladder positions)
+func NewGame(config GameConfig) (*Game, error) {
+	// Validate that we have at least one player
+	if len(config.PlayerNames) == 0 {
+		return nil, fmt.Errorf("at least one player is required")
+	}
+
+	// Create the game board with its snakes and ladders
+	board, err := newBoardFromLayout(config.BoardSize, config.Snakes, config.Ladders)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create player objects with unique IDs starting from 1
 	players := make([]*Player, len(config.PlayerNames))
@@ -413,6 +610,13 @@ func NewGame(config GameConfig) (*Game, error) {
 		currentTurn: 0, // First player (index 0) starts
 		state:       GameStateNotStarted,
 		winner:      nil,
+		replay: &Replay{
+			BoardSize:   config.BoardSize,
+			Snakes:      config.Snakes,
+			Ladders:     config.Ladders,
+			PlayerNames: config.PlayerNames,
+			Turns:       make([]TurnRecord, 0),
+		},
 	}, nil
 }
 
@@ -449,11 +653,22 @@ func (g *Game) PlayTurn() bool {
 	currentPosition := currentPlayer.GetPosition()
 	newPosition := currentPosition + diceValue
 
+	record := TurnRecord{
+		Turn:           len(g.replay.Turns) + 1,
+		PlayerID:       currentPlayer.GetID(),
+		DiceValue:      diceValue,
+		FromPosition:   currentPosition,
+		LandedPosition: currentPosition,
+		FinalPosition:  currentPosition,
+	}
+
 	// Step 3: Check if the roll would exceed the board size
 	// In Snake and Ladder, you need EXACT roll to reach the winning position
 	if newPosition > g.board.GetSize() {
 		fmt.Printf("   %s stays at %d (rolled too high, need exact roll to win)\n",
 			currentPlayer.GetName(), currentPosition)
+		record.Event = TurnEventOvershoot
+		g.replay.Turns = append(g.replay.Turns, record)
 	} else {
 		// Step 4: Move the player to the new position
 		currentPlayer.SetPosition(newPosition)
@@ -466,10 +681,20 @@ func (g *Game) PlayTurn() bool {
 			currentPlayer.SetPosition(finalPosition)
 		}
 
+		record.LandedPosition = newPosition
+		record.FinalPosition = finalPosition
+		if finalPosition < newPosition {
+			record.Event = TurnEventSnake
+		} else if finalPosition > newPosition {
+			record.Event = TurnEventLadder
+		}
+		g.replay.Turns = append(g.replay.Turns, record)
+
 		// Step 6: Check if player has won (reached exactly position 100)
 		if g.board.IsWinningPosition(currentPlayer.GetPosition()) {
 			g.state = GameStateFinished
 			g.winner = currentPlayer
+			g.replay.WinnerID = currentPlayer.GetID()
 			fmt.Printf("\n🏆 %s WINS! 🎉\n", currentPlayer.GetName())
 			return true
 		}
@@ -507,6 +732,16 @@ func (g *Game) PlayGame() *Player {
 	return g.winner
 }
 
+// GetReplay returns the recording of all turns played so far
+func (g *Game) GetReplay() *Replay {
+	return g.replay
+}
+
+// GetStatistics returns per-player statistics (typically called at game end)
+func (g *Game) GetStatistics() []*PlayerStats {
+	return g.replay.Statistics()
+}
+
 // GetStatus returns current game status
 func (g *Game) GetStatus() string {
 	status := "\n╔══════════════════════════════════════╗\n"
@@ -524,63 +759,3 @@ func (g *Game) GetStatus() string {
 	status += "╚══════════════════════════════════════╝\n"
 	return status
 }
-
-// ========== MAIN ==========
-
-func main() {
-	// Note: In Go 1.20+, random number generation is automatically seeded
-	// No need to call rand.Seed() anymore - it's deprecated
-
-	fmt.Println("═══════════════════════════════════════════")
-	fmt.Println("       SNAKE AND LADDER - LLD DEMO")
-	fmt.Println("═══════════════════════════════════════════")
-
-	// Define game configuration
-	// - BoardSize: Standard 100-square board
-	// - Snakes: Each pair is [head, tail] - landing on head drops you to tail
-	// - Ladders: Each pair is [start, end] - landing on start lifts you to end
-	config := GameConfig{
-		BoardSize: 100,
-		Snakes: [][2]int{
-			{99, 54}, // Near win - cruel snake!
-			{70, 55},
-			{52, 42},
-			{25, 2},
-			{95, 72},
-		},
-		Ladders: [][2]int{
-			{6, 25},
-			{11, 40},
-			{60, 85},
-			{46, 90},
-			{17, 69},
-		},
-		PlayerNames: []string{"Alice", "Bob", "Charlie"},
-		Dice:        NewStandardDice(), // Can swap with NewDoubleDice() or NewBiasedDice()
-	}
-
-	// Create and play game
-	game, err := NewGame(config)
-	if err != nil {
-		fmt.Printf("Failed to create game: %v\n", err)
-		return
-	}
-
-	// Play complete game
-	winner := game.PlayGame()
-
-	if winner != nil {
-		fmt.Println("\n═══════════════════════════════════════════")
-		fmt.Printf("  🎊 Congratulations %s! 🎊\n", winner.GetName())
-		fmt.Println("═══════════════════════════════════════════")
-	}
-
-	fmt.Println("\n═══════════════════════════════════════════")
-	fmt.Println("  KEY DESIGN DECISIONS:")
-	fmt.Println("═══════════════════════════════════════════")
-	fmt.Println("  1. Dice interface - Strategy pattern")
-	fmt.Println("  2. Board encapsulates snake/ladder logic")
-	fmt.Println("  3. Game orchestrates the flow")
-	fmt.Println("  4. Easy to extend (power-ups, etc.)")
-	fmt.Println("═══════════════════════════════════════════")
-}
@@ -0,0 +1,8 @@
+// Command demo runs the lrucache walkthrough: go run ./06_lru_cache/cmd/demo
+package main
+
+import lrucache "github.com/ayushgupta5/GoLLD/06_lru_cache"
+
+func main() {
+	lrucache.RunDemo()
+}
@@ -1,4 +1,6 @@
-package main
+// Package lrucache is a thread-safe least-recently-used cache with O(1) get
+// and put, in both a string-keyed and a generic form.
+package lrucache
 
 import (
 	"fmt"
@@ -486,112 +488,3 @@ func (cache *GenericLRUCache[K, V]) Size() int {
 	defer cache.mutex.RUnlock()
 	return len(cache.cache)
 }
-
-// ================================== MAIN =====================================
-
-func main() {
-	fmt.Println("╔═══════════════════════════════════════════════════════════════╗")
-	fmt.Println("║              LRU CACHE - Low Level Design Demo                ║")
-	fmt.Println("╚═══════════════════════════════════════════════════════════════╝")
-
-	// Create an LRU cache that can hold maximum 3 items
-	cache := NewLRUCache(3)
-
-	fmt.Println("\n📋 DEMONSTRATION OF LRU CACHE OPERATIONS")
-	fmt.Println("─────────────────────────────────────────────────────────────────")
-
-	// Operation 1: Add items to the cache
-	fmt.Println("\n▶ Step 1: Adding items to cache")
-	fmt.Println("   cache.Put(1, 100)")
-	cache.Put(1, 100)
-	cache.PrintCache()
-
-	fmt.Println("   cache.Put(2, 200)")
-	cache.Put(2, 200)
-	cache.PrintCache()
-
-	fmt.Println("   cache.Put(3, 300)")
-	cache.Put(3, 300)
-	cache.PrintCache()
-
-	// Operation 2: Access an item (moves it to front)
-	fmt.Println("\n▶ Step 2: Accessing key 1 (moves it to front as MRU)")
-	fmt.Println("   cache.Get(1)")
-	if value, found := cache.Get(1); found {
-		fmt.Printf("   → Found value: %d\n", value)
-	}
-	cache.PrintCache()
-	fmt.Println("   Notice: Key 1 is now at the front (most recently used)")
-
-	// Operation 3: Add new item when cache is full (triggers eviction)
-	fmt.Println("\n▶ Step 3: Adding key 4 when cache is full")
-	fmt.Println("   cache.Put(4, 400)")
-	fmt.Println("   → Cache is full, so LRU item (key 2) will be evicted")
-	cache.Put(4, 400)
-	cache.PrintCache()
-
-	// Operation 4: Try to access evicted item
-	fmt.Println("\n▶ Step 4: Trying to access evicted key 2")
-	fmt.Println("   cache.Get(2)")
-	if _, found := cache.Get(2); !found {
-		fmt.Println("   → Key 2 not found (was evicted) ✓")
-	}
-
-	// Operation 5: Update existing key
-	fmt.Println("\n▶ Step 5: Updating existing key 3")
-	fmt.Println("   cache.Put(3, 333)")
-	cache.Put(3, 333)
-	cache.PrintCache()
-	fmt.Println("   Notice: Key 3's value updated and moved to front")
-
-	// Operation 6: Another eviction
-	fmt.Println("\n▶ Step 6: Adding key 5 (triggers another eviction)")
-	fmt.Println("   cache.Put(5, 500)")
-	cache.Put(5, 500)
-	cache.PrintCache()
-
-	// Operation 7: Delete a key
-	fmt.Println("\n▶ Step 7: Deleting key 3")
-	fmt.Println("   cache.Delete(3)")
-	deleted := cache.Delete(3)
-	fmt.Printf("   → Deleted: %v\n", deleted)
-	cache.PrintCache()
-
-	// ========== GENERIC CACHE DEMO ==========
-	fmt.Println("\n╔═══════════════════════════════════════════════════════════════╗")
-	fmt.Println("║            GENERIC LRU CACHE (String Keys Demo)               ║")
-	fmt.Println("╚═══════════════════════════════════════════════════════════════╝")
-
-	// Create a string->string cache with capacity 2
-	stringCache := NewGenericLRUCache[string, string](2)
-
-	fmt.Println("\n   stringCache.Put(\"name\", \"Alice\")")
-	stringCache.Put("name", "Alice")
-
-	fmt.Println("   stringCache.Put(\"city\", \"New York\")")
-	stringCache.Put("city", "New York")
-
-	if value, found := stringCache.Get("name"); found {
-		fmt.Printf("   stringCache.Get(\"name\") → \"%s\"\n", value)
-	}
-
-	fmt.Println("\n   stringCache.Put(\"country\", \"USA\")  // This evicts \"city\"")
-	stringCache.Put("country", "USA")
-
-	if _, found := stringCache.Get("city"); !found {
-		fmt.Println("   stringCache.Get(\"city\") → Not found (was evicted) ✓")
-	}
-
-	// ========== KEY DESIGN SUMMARY ==========
-	fmt.Println("\n╔═══════════════════════════════════════════════════════════════╗")
-	fmt.Println("║                    KEY DESIGN DECISIONS                       ║")
-	fmt.Println("╠═══════════════════════════════════════════════════════════════╣")
-	fmt.Println("║  1. HashMap (map)       → O(1) key lookup                     ║")
-	fmt.Println("║  2. Doubly Linked List  → O(1) reordering/eviction            ║")
-	fmt.Println("║  3. Dummy head/tail     → Simplifies edge cases               ║")
-	fmt.Println("║  4. sync.RWMutex        → Thread-safe concurrent access       ║")
-	fmt.Println("║  5. Key stored in node  → Enables HashMap cleanup on eviction ║")
-	fmt.Println("╚═══════════════════════════════════════════════════════════════╝")
-
-	fmt.Println("\n✅ LRU Cache demonstration complete!")
-}
@@ -1,4 +1,6 @@
-package main
+// Package bookmyshow books movie tickets: theatres, screens, shows and
+// seats held safely under concurrent bookings.
+package bookmyshow
 
 import (
 	"fmt"
@@ -645,106 +647,3 @@ func (service *BookingService) GetBooking(bookingID string) (*Booking, error) {
 	}
 	return booking, nil
 }
-
-// ========== MAIN ==========
-
-func main() {
-	fmt.Println("═══════════════════════════════════════════")
-	fmt.Println("    🎬 BOOKMYSHOW - Ticket Booking System")
-	fmt.Println("═══════════════════════════════════════════")
-
-	// Initialize service
-	service := NewBookingService()
-
-	// Add movies
-	movie1 := NewMovie("M1", "Avengers: Endgame", "Action", "English", 180)
-	movie2 := NewMovie("M2", "Inception", "Sci-Fi", "English", 150)
-	service.AddMovie(movie1)
-	service.AddMovie(movie2)
-
-	// Create theatre with screens
-	theatre := NewTheatre("T1", "PVR Cinemas", "Mumbai", "Phoenix Mall")
-	screen1 := NewScreen("S1", "Screen 1", []string{"A", "B", "C", "D", "E", "F"}, 10)
-	screen2 := NewScreen("S2", "Screen 2", []string{"A", "B", "C", "D"}, 8)
-	theatre.AddScreen(screen1)
-	theatre.AddScreen(screen2)
-
-	// Add shows
-	today := time.Now()
-	show1 := NewShow("SH1", movie1, screen1, time.Date(today.Year(), today.Month(), today.Day(), 14, 0, 0, 0, time.Local))
-	show2 := NewShow("SH2", movie1, screen1, time.Date(today.Year(), today.Month(), today.Day(), 18, 0, 0, 0, time.Local))
-	show3 := NewShow("SH3", movie2, screen2, time.Date(today.Year(), today.Month(), today.Day(), 16, 0, 0, 0, time.Local))
-	theatre.AddShow(show1)
-	theatre.AddShow(show2)
-	theatre.AddShow(show3)
-
-	service.AddTheatre(theatre)
-
-	// Display available shows
-	fmt.Println("\n📽️  Now Showing in Mumbai:")
-	fmt.Println("─────────────────────────────────────────")
-	for _, show := range theatre.GetShows() {
-		fmt.Printf("  • %s\n", show)
-	}
-
-	// Create user
-	user := NewUser("U1", "John Doe", "john@email.com", "9876543210")
-
-	// Book tickets
-	fmt.Println("\n🎫 Booking Tickets...")
-	fmt.Println("─────────────────────────────────────────")
-
-	booking1, err := service.BookTickets(user, show1, []string{"A1", "A2", "A3"})
-	if err != nil {
-		fmt.Printf("Booking failed: %v\n", err)
-	} else {
-		fmt.Printf("✅ Booking created: %s\n", booking1.GetID())
-
-		// Simulate payment and confirm
-		if confirmErr := service.ConfirmBooking(booking1.GetID()); confirmErr != nil {
-			fmt.Printf("Failed to confirm booking: %v\n", confirmErr)
-		}
-		booking1.PrintTicket()
-	}
-
-	// Show updated availability
-	fmt.Println("\n📊 Updated Availability:")
-	fmt.Printf("  %s\n", show1)
-
-	// Try to book same seats again (should fail)
-	fmt.Println("\n⚠️  Trying to book same seats again...")
-	_, err = service.BookTickets(user, show1, []string{"A1", "A2"})
-	if err != nil {
-		fmt.Printf("  ❌ Expected error: %v\n", err)
-	}
-
-	// Book different seats
-	fmt.Println("\n🎫 Booking different seats...")
-	booking2, err := service.BookTickets(user, show1, []string{"B5", "B6"})
-	if err != nil {
-		fmt.Printf("Booking failed: %v\n", err)
-	} else {
-		if confirmErr := service.ConfirmBooking(booking2.GetID()); confirmErr != nil {
-			fmt.Printf("Failed to confirm booking: %v\n", confirmErr)
-		}
-		booking2.PrintTicket()
-	}
-
-	// Cancel a booking
-	fmt.Println("\n❌ Cancelling first booking...")
-	if cancelErr := service.CancelBooking(booking1.GetID()); cancelErr != nil {
-		fmt.Printf("Failed to cancel booking: %v\n", cancelErr)
-	} else {
-		fmt.Printf("  Booking %s cancelled\n", booking1.GetID())
-	}
-	fmt.Printf("  Updated: %s\n", show1)
-
-	fmt.Println("\n═══════════════════════════════════════════")
-	fmt.Println("  KEY DESIGN DECISIONS:")
-	fmt.Println("═══════════════════════════════════════════")
-	fmt.Println("  1. Show owns seat booking state")
-	fmt.Println("  2. Mutex for concurrent booking safety")
-	fmt.Println("  3. Booking status flow: Pending→Confirmed")
-	fmt.Println("  4. Seat release on cancellation")
-	fmt.Println("═══════════════════════════════════════════")
-}
@@ -0,0 +1,8 @@
+// Command demo runs the bookmyshow walkthrough: go run ./07_bookmyshow/cmd/demo
+package main
+
+import bookmyshow "github.com/ayushgupta5/GoLLD/07_bookmyshow"
+
+func main() {
+	bookmyshow.RunDemo()
+}
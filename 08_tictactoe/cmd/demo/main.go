@@ -0,0 +1,8 @@
+// Command demo runs the tictactoe walkthrough: go run ./08_tictactoe/cmd/demo
+package main
+
+import tictactoe "github.com/ayushgupta5/GoLLD/08_tictactoe"
+
+func main() {
+	tictactoe.RunDemo()
+}
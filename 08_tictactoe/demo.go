@@ -0,0 +1,23 @@
+package tictactoe
+
+// ============================================================
+// SECTION 8: MAIN ENTRY POINT
+// ============================================================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	// Run the demo scenarios to show the game works
+	runWinDemo()
+	runDrawDemo()
+
+	// Print design summary for learning
+	printDesignSummary()
+
+	// ─────────────────────────────────────────────────────────
+	// INTERACTIVE MODE (uncomment the lines below to play!)
+	// ─────────────────────────────────────────────────────────
+	// fmt.Println("\n🎮 Starting Interactive Game...")
+	// game := NewGame(3, "Player 1", "Player 2")
+	// controller := NewGameController(game)
+	// controller.StartInteractiveGame()
+}
@@ -1,4 +1,6 @@
-package main
+// Package tictactoe plays Tic Tac Toe on an NxN board, detecting wins and
+// draws.
+package tictactoe
 
 import (
 	"bufio"
@@ -592,24 +594,3 @@ func printDesignSummary() {
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════")
 }
-
-// ============================================================
-// SECTION 8: MAIN ENTRY POINT
-// ============================================================
-
-func main() {
-	// Run the demo scenarios to show the game works
-	runWinDemo()
-	runDrawDemo()
-
-	// Print design summary for learning
-	printDesignSummary()
-
-	// ─────────────────────────────────────────────────────────
-	// INTERACTIVE MODE (uncomment the lines below to play!)
-	// ─────────────────────────────────────────────────────────
-	// fmt.Println("\n🎮 Starting Interactive Game...")
-	// game := NewGame(3, "Player 1", "Player 2")
-	// controller := NewGameController(game)
-	// controller.StartInteractiveGame()
-}
@@ -0,0 +1,8 @@
+// Command demo runs the ratelimiter walkthrough: go run ./09_rate_limiter/cmd/demo
+package main
+
+import ratelimiter "github.com/ayushgupta5/GoLLD/09_rate_limiter"
+
+func main() {
+	ratelimiter.RunDemo()
+}
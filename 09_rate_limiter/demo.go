@@ -0,0 +1,703 @@
+package ratelimiter
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================================================
+// SECTION 16: MAIN FUNCTION (Demo)
+// ============================================================================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	printSeparator()
+	fmt.Println("        RATE LIMITER - Low Level Design Demo")
+	printSeparator()
+
+	// ----------------------------------------
+	// Demo 1: Token Bucket Rate Limiter
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 1: TOKEN BUCKET LIMITER")
+	fmt.Println("   Configuration: 5 tokens capacity, refill 2 tokens per second")
+	fmt.Println("   Allows burst traffic up to bucket capacity")
+	printLine()
+
+	tokenBucketLimiter := NewTokenBucketRateLimiter(
+		5,           // maxCapacity: bucket can hold 5 tokens
+		2,           // tokensPerRefill: add 2 tokens per interval
+		time.Second, // refillInterval: refill every 1 second
+	)
+	gateway1 := NewAPIGateway(tokenBucketLimiter)
+
+	// Simulate burst of 7 rapid requests (only 5 should succeed)
+	fmt.Println("\n   Sending burst of 7 rapid requests...")
+	for i := 1; i <= 7; i++ {
+		gateway1.HandleRequest(NewUserRequest("user1", fmt.Sprintf("/api/resource/%d", i)))
+	}
+
+	// Wait for tokens to refill
+	fmt.Println("\n   ⏳ Waiting 2 seconds for tokens to refill...")
+	time.Sleep(2 * time.Second)
+
+	// Try more requests after refill
+	fmt.Println("\n   Sending 3 more requests after refill...")
+	for i := 1; i <= 3; i++ {
+		gateway1.HandleRequest(NewUserRequest("user1", fmt.Sprintf("/api/resource/%d", i)))
+	}
+
+	// Fractional refill: 2 tokens/sec means one token every 500ms, not
+	// two tokens at the end of each full second
+	fmt.Println("\n   Fractional refill (2 tokens/sec, bucket drained):")
+	fractionalBucket := NewTokenBucket(2, 2, time.Second)
+	fractionalBucket.TryConsume()
+	fractionalBucket.TryConsume()
+	for _, wait := range []time.Duration{300 * time.Millisecond, 300 * time.Millisecond} {
+		time.Sleep(wait)
+		level := fractionalBucket.GetTokenLevel()
+		fmt.Printf("   after +%v: %.1f tokens → allowed=%v\n",
+			wait, level, fractionalBucket.TryConsume())
+	}
+
+	// ----------------------------------------
+	// Demo 2: Sliding Window Rate Limiter
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 2: SLIDING WINDOW LIMITER")
+	fmt.Println("   Configuration: 3 requests per 2 seconds")
+	fmt.Println("   Smooth limiting with no boundary issues")
+	printLine()
+
+	slidingWindowLimiter := NewSlidingWindowRateLimiter(
+		3,             // maxRequests: allow 3 requests
+		2*time.Second, // windowDuration: within 2 seconds
+	)
+	gateway2 := NewAPIGateway(slidingWindowLimiter)
+
+	// Send requests with small delays
+	fmt.Println("\n   Sending 5 requests with 300ms delays...")
+	for i := 1; i <= 5; i++ {
+		gateway2.HandleRequest(NewUserRequest("user2", fmt.Sprintf("/api/data/%d", i)))
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	// ----------------------------------------
+	// Demo 3: Fixed Window Rate Limiter
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 3: FIXED WINDOW LIMITER")
+	fmt.Println("   Configuration: 4 requests per 3-second window")
+	fmt.Println("   Simple but has boundary spike vulnerability")
+	printLine()
+
+	fixedWindowLimiter := NewFixedWindowRateLimiter(
+		4,             // maxRequests: allow 4 requests
+		3*time.Second, // windowDuration: per 3-second window
+	)
+	gateway3 := NewAPIGateway(fixedWindowLimiter)
+
+	// Send rapid burst of requests
+	fmt.Println("\n   Sending 6 rapid requests...")
+	for i := 1; i <= 6; i++ {
+		gateway3.HandleRequest(NewUserRequest("user3", fmt.Sprintf("/api/item/%d", i)))
+	}
+
+	// ----------------------------------------
+	// Demo 4: Leaky Bucket Rate Limiter
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 4: LEAKY BUCKET LIMITER")
+	fmt.Println("   Configuration: 3 capacity, leak 1 request per 500ms")
+	fmt.Println("   Smooths traffic to constant rate")
+	printLine()
+
+	leakyBucketLimiter := NewLeakyBucketRateLimiter(
+		3,                    // maxCapacity: bucket holds 3 requests
+		500*time.Millisecond, // leakInterval: process 1 request every 500ms
+	)
+	gateway4 := NewAPIGateway(leakyBucketLimiter)
+
+	// Send rapid burst of requests
+	fmt.Println("\n   Sending 5 rapid requests...")
+	for i := 1; i <= 5; i++ {
+		gateway4.HandleRequest(NewUserRequest("user4", fmt.Sprintf("/api/stream/%d", i)))
+	}
+
+	// Wait for requests to leak out
+	fmt.Println("\n   ⏳ Waiting 1 second for requests to leak out...")
+	time.Sleep(1 * time.Second)
+
+	// Try more requests after some leaked out
+	fmt.Println("\n   Sending 3 more requests after leak...")
+	for i := 1; i <= 3; i++ {
+		gateway4.HandleRequest(NewUserRequest("user4", fmt.Sprintf("/api/stream/%d", i)))
+	}
+
+	// ----------------------------------------
+	// Demo 5: Quota API (Check without consuming)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 5: QUOTA API")
+	fmt.Println("   Check() reports remaining quota and reset time without consuming")
+	printLine()
+
+	limiters := []RateLimiter{
+		NewTokenBucketRateLimiter(5, 1, time.Second),
+		NewSlidingWindowRateLimiter(5, 10*time.Second),
+		NewFixedWindowRateLimiter(5, 10*time.Second),
+		NewLeakyBucketRateLimiter(5, time.Second),
+	}
+	for _, limiter := range limiters {
+		for i := 0; i < 3; i++ {
+			limiter.Allow("user5")
+		}
+		limiter.Check("user5") // Checking twice must not change anything
+		quota := limiter.Check("user5")
+		fmt.Printf("   %-15s limit=%d remaining=%d resets in %v\n",
+			limiter.GetName(), quota.Limit, quota.Remaining,
+			time.Until(quota.ResetAt).Round(time.Second))
+	}
+
+	// Only the 429 carries Retry-After: the time until one token is back
+	headerRequest := NewUserRequest("user1", "/api/resource/1")
+	for _, wantRejected := range []bool{false, true} {
+		rejected := !wantRejected
+		for rejected != wantRejected {
+			rejected = gateway1.ServeRequest(context.Background(), headerRequest, func() {}) != nil
+		}
+		fmt.Printf("\n   Gateway response headers for user1 (Token Bucket, rejected=%v):\n", rejected)
+		headers, _ := gateway1.RateLimitHeaders(headerRequest, rejected)
+		for _, name := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"} {
+			if value, present := headers[name]; present {
+				fmt.Printf("   %s: %s\n", name, value)
+			}
+		}
+	}
+	fmt.Printf("\n   Check on an unseen key: remaining=%d (no record is created)\n", limiters[0].Check("nobody").Remaining)
+
+	// ----------------------------------------
+	// Demo 6: Compact Sliding Window vs plain Sliding Window
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 6: COMPACT SLIDING WINDOW")
+	fmt.Println("   Configuration: 100 requests per minute, 1-second buckets")
+	fmt.Println("   Ring buffer per user with a hard cap, no allocation per request")
+	printLine()
+
+	const benchmarkUsers = 2000
+	const benchmarkLimit = 100
+	newPlain := func() RateLimiter { return NewSlidingWindowRateLimiter(benchmarkLimit, time.Minute) }
+	newCompact := func() RateLimiter {
+		return NewCompactSlidingWindowRateLimiter(benchmarkLimit, time.Minute, time.Second)
+	}
+
+	compactLimiter := newCompact().(*CompactSlidingWindowRateLimiter)
+	fmt.Printf("\n   Ring capacity per user: %d buckets\n", compactLimiter.GetRingCapacity())
+
+	fmt.Printf("\n   %-15s %14s\n", "Algorithm", "bytes/user")
+	for _, factory := range []func() RateLimiter{newPlain, newCompact} {
+		bytesPerUser := retainedBytesPerUser(factory, benchmarkUsers, benchmarkLimit)
+		fmt.Printf("   %-15s %14d\n", factory().GetName(), bytesPerUser)
+	}
+	fmt.Println("   Allow cost per call: go test -bench SlidingWindow ./09_rate_limiter")
+
+	// ----------------------------------------
+	// Demo 7: Concurrency Limiter (max in-flight requests)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 7: CONCURRENCY LIMITER")
+	fmt.Println("   Configuration: /api/report max 2 in flight (default 10)")
+	fmt.Println("   5 concurrent 200ms requests, each waits at most 300ms for a slot")
+	printLine()
+
+	concurrencyLimiter := NewConcurrencyLimiter(10, map[string]int{"/api/report": 2})
+	gateway7 := NewAPIGateway(NewTokenBucketRateLimiter(100, 100, time.Second))
+	gateway7.SetConcurrencyLimiter(concurrencyLimiter)
+
+	var (
+		waitGroup     sync.WaitGroup
+		resultMutex   sync.Mutex
+		peakInFlight  int
+		servedCount   int
+		rejectedCount int
+	)
+	for i := 1; i <= 5; i++ {
+		waitGroup.Add(1)
+		go func(userID string) {
+			defer waitGroup.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+			defer cancel()
+
+			err := gateway7.ServeRequest(ctx, NewUserRequest(userID, "/api/report"), func() {
+				resultMutex.Lock()
+				peakInFlight = max(peakInFlight, concurrencyLimiter.GetInFlight("/api/report"))
+				resultMutex.Unlock()
+				time.Sleep(200 * time.Millisecond) // Slow report generation
+			})
+
+			resultMutex.Lock()
+			defer resultMutex.Unlock()
+			if err != nil {
+				rejectedCount++
+				fmt.Printf("   ❌ %s rejected: %v\n", userID, err)
+			} else {
+				servedCount++
+			}
+		}("user" + strconv.Itoa(70+i))
+	}
+	waitGroup.Wait()
+	fmt.Printf("   Served %d, rejected %d, peak in flight %d (limit %d)\n",
+		servedCount, rejectedCount, peakInFlight, concurrencyLimiter.GetLimit("/api/report"))
+
+	fmt.Println("\n   TryAcquire never waits:")
+	fmt.Printf("   1st: %v, 2nd: %v, 3rd: %v\n",
+		concurrencyLimiter.TryAcquire("/api/report"),
+		concurrencyLimiter.TryAcquire("/api/report"),
+		concurrencyLimiter.TryAcquire("/api/report"))
+	concurrencyLimiter.Release("/api/report")
+	concurrencyLimiter.Release("/api/report")
+	if err := concurrencyLimiter.Release("/api/report"); err != nil {
+		fmt.Printf("   %v\n", err)
+	}
+
+	// ----------------------------------------
+	// Demo 8: Queueing Limiter (batch job submission)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 8: QUEUEING LIMITER")
+	fmt.Println("   Configuration: Fixed window of 2 jobs per 100ms, queue of 5, max wait 250ms")
+	fmt.Println("   8 jobs submitted at once by batch-runner")
+	printLine()
+
+	queueingLimiter := NewQueueingLimiter(NewFixedWindowRateLimiter(2, 100*time.Millisecond), 5, 250*time.Millisecond)
+
+	var (
+		jobWaitGroup sync.WaitGroup
+		jobMutex     sync.Mutex
+		jobResults   = make([]string, 8)
+	)
+	submittedAt := time.Now()
+	for i := 0; i < 8; i++ {
+		jobWaitGroup.Add(1)
+		go func(jobIndex int) {
+			defer jobWaitGroup.Done()
+			err := queueingLimiter.Wait(context.Background(), "batch-runner")
+
+			jobMutex.Lock()
+			defer jobMutex.Unlock()
+			switch {
+			case err == nil:
+				jobResults[jobIndex] = fmt.Sprintf("✅ started after ~%v", time.Since(submittedAt).Round(50*time.Millisecond))
+			case errors.Is(err, ErrQueueFull):
+				jobResults[jobIndex] = "❌ rejected: " + err.Error()
+			default:
+				jobResults[jobIndex] = "⏱️  " + err.Error()
+			}
+		}(i)
+		time.Sleep(time.Millisecond) // Keep submission order deterministic
+	}
+	jobWaitGroup.Wait()
+	for i, result := range jobResults {
+		fmt.Printf("   Job %d: %s\n", i+1, result)
+	}
+
+	queueStats := queueingLimiter.GetStats()
+	fmt.Printf("   Immediate %d, granted after waiting %d, timed out %d, queue full %d\n",
+		queueStats.Immediate, queueStats.Granted, queueStats.TimedOut, queueStats.QueueFull)
+
+	fmt.Println("\n   A cancelled context leaves the queue early:")
+	queueingLimiter.Wait(context.Background(), "nightly-export")
+	queueingLimiter.Wait(context.Background(), "nightly-export")
+	cancelCtx, cancelWait := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	if err := queueingLimiter.Wait(cancelCtx, "nightly-export"); err != nil {
+		fmt.Printf("   %v (queue length now %d)\n", err, queueingLimiter.GetQueueLength("nightly-export"))
+	}
+	cancelWait()
+
+	// ----------------------------------------
+	// Demo 9: Key extractors (who is the request counted against?)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 9: KEY EXTRACTORS")
+	fmt.Println("   Fixed window, 2 requests per minute per key")
+	printLine()
+
+	fmt.Println("\n   Per client IP (behind a trusted proxy):")
+	ipGateway := NewAPIGateway(NewFixedWindowRateLimiter(2, time.Minute))
+	ipGateway.SetKeyExtractor(IPKeyExtractor{TrustForwardedFor: true})
+	for _, forwardedFor := range []string{"203.0.113.7", "203.0.113.7, 10.0.0.2", "203.0.113.7", "198.51.100.4"} {
+		ipGateway.HandleRequest(&Request{
+			RemoteAddr: "10.0.0.2:51234",
+			Endpoint:   "/api/search",
+			Headers:    map[string]string{"X-Forwarded-For": forwardedFor},
+		})
+	}
+
+	fmt.Println("\n   Per API key owner (two keys, one client):")
+	apiKeys := NewAPIKeyExtractor("")
+	apiKeys.RegisterKey("key-live-1", "acme")
+	apiKeys.RegisterKey("key-live-2", "acme")
+	apiKeyGateway := NewAPIGateway(NewFixedWindowRateLimiter(2, time.Minute))
+	apiKeyGateway.SetKeyExtractor(apiKeys)
+	for _, apiKey := range []string{"key-live-1", "key-live-2", "key-live-1", "key-stolen"} {
+		apiKeyGateway.HandleRequest(&Request{Endpoint: "/v1/charges", Headers: map[string]string{"X-Api-Key": apiKey}})
+	}
+
+	fmt.Println("\n   JWT subject, falling back to IP for anonymous traffic:")
+	jwtSecret := []byte("demo-signing-secret")
+	aliceToken := signTestJWT(jwtSecret, "alice", time.Now().Add(time.Hour))
+	forgedToken := signTestJWT([]byte("attacker-secret"), "alice", time.Now().Add(time.Hour))
+	jwtGateway := NewAPIGateway(NewFixedWindowRateLimiter(2, time.Minute))
+	jwtGateway.SetKeyExtractor(NewFirstKeyExtractor(NewJWTSubjectExtractor(jwtSecret), IPKeyExtractor{}))
+	for _, token := range []string{aliceToken, aliceToken, forgedToken, ""} {
+		headers := map[string]string{}
+		if token != "" {
+			headers["Authorization"] = "Bearer " + token
+		}
+		jwtGateway.HandleRequest(&Request{RemoteAddr: "192.0.2.10:443", Endpoint: "/api/feed", Headers: headers})
+	}
+
+	fmt.Println("\n   Composite user + endpoint (separate budget per endpoint):")
+	compositeGateway := NewAPIGateway(NewFixedWindowRateLimiter(2, time.Minute))
+	compositeGateway.SetKeyExtractor(NewCompositeKeyExtractor(UserKeyExtractor{}, EndpointKeyExtractor{}))
+	for _, endpoint := range []string{"/api/search", "/api/search", "/api/search", "/api/profile"} {
+		compositeGateway.HandleRequest(NewUserRequest("bob", endpoint))
+	}
+	compositeGateway.HandleRequest(&Request{Endpoint: "/api/search"}) // Anonymous
+
+	// ----------------------------------------
+	// Demo 10: Multi-window policy (burst AND sustained limits)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 10: MULTI-WINDOW POLICY")
+	fmt.Println("   Configuration: burst 3 per 200ms AND sustained 5 per 2s, per user")
+	printLine()
+
+	if _, err := NewMultiWindowRateLimiter(WindowRule{Name: "broken", Limit: 0, Window: time.Second}); err != nil {
+		fmt.Printf("\n   Invalid policy rejected: %v\n", err)
+	}
+	multiWindowLimiter, err := NewMultiWindowRateLimiter(
+		WindowRule{Name: "burst", Limit: 3, Window: 200 * time.Millisecond},
+		WindowRule{Name: "sustained", Limit: 5, Window: 2 * time.Second},
+	)
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+	} else {
+		sendMultiWindow := func(count int) {
+			for i := 0; i < count; i++ {
+				decision := multiWindowLimiter.Evaluate("carol")
+				if decision.Allowed {
+					fmt.Println("   ✅ allowed")
+					continue
+				}
+				for _, rule := range decision.Violated {
+					fmt.Printf("   ❌ rejected by %s, retry in ~%v\n", rule, time.Until(decision.RetryAt).Round(10*time.Millisecond))
+				}
+			}
+		}
+		fmt.Println("\n   4 rapid requests (the burst rule trips first):")
+		sendMultiWindow(4)
+		time.Sleep(250 * time.Millisecond)
+		fmt.Println("\n   After 250ms, 3 more (now the sustained rule trips):")
+		sendMultiWindow(3)
+
+		fmt.Println("\n   Per-rule quotas (rejected requests used no budget):")
+		for _, ruleQuota := range multiWindowLimiter.CheckRules("carol") {
+			fmt.Printf("   %-28s remaining %d/%d\n", ruleQuota.Rule, ruleQuota.Quota.Remaining, ruleQuota.Quota.Limit)
+		}
+		tightest := multiWindowLimiter.Check("carol")
+		fmt.Printf("   Check (used for rate-limit headers) reports the tightest rule: %d/%d, full again in ~%v\n",
+			tightest.Remaining, tightest.Limit, time.Until(tightest.ResetAt).Round(10*time.Millisecond))
+	}
+
+	// ----------------------------------------
+	// Demo 11: Jittered resets (thundering herd smoothing)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 11: JITTERED RESETS")
+	fmt.Println("   Simulation: 1000 clients arrive together, 10 requests per 1s window,")
+	fmt.Println("   100ms ticks for 6s (first window not measured), with and without")
+	fmt.Println("   up to 1s of per-key jitter")
+	printLine()
+
+	alignedHerd := simulateResetHerd(1000, 10, time.Second, 0, 100*time.Millisecond, 60)
+	jitteredHerd := simulateResetHerd(1000, 10, time.Second, time.Second, 100*time.Millisecond, 60)
+	for _, run := range []struct {
+		label      string
+		simulation HerdSimulation
+	}{{"No jitter", alignedHerd}, {"1s jitter", jitteredHerd}} {
+		fmt.Printf("\n   %-10s total %5d | peak %5d/tick | avg %6.1f/tick | peak/avg %4.1fx | idle ticks %d\n",
+			run.label, run.simulation.Total, run.simulation.PeakPerTick, run.simulation.AveragePerTick,
+			run.simulation.GetPeakToAverage(), run.simulation.IdleTicks)
+	}
+	fmt.Println("\n   Same clients, same limits: jitter trades the square wave for a steady load")
+
+	jitteredBuckets := NewTokenBucketRateLimiter(2, 2, time.Second)
+	jitteredBuckets.SetRefillJitter(time.Second)
+	fmt.Println("\n   Token bucket with 1s refill jitter (each key drained, first refill staggered):")
+	for _, clientID := range []string{"client-a", "client-b", "client-c"} {
+		jitteredBuckets.Allow(clientID)
+		jitteredBuckets.Allow(clientID)
+		fmt.Printf("   %s refill delayed by %v, full again in ~%v\n", clientID,
+			resetJitterFor(clientID, time.Second).Round(time.Millisecond),
+			time.Until(jitteredBuckets.Check(clientID).ResetAt).Round(10*time.Millisecond))
+	}
+
+	// ----------------------------------------
+	// Demo 12: Distributed limits (shared Store)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 12: DISTRIBUTED LIMITS (Shared Store)")
+	fmt.Println("   Two gateway instances share one store: 5 requests per minute for")
+	fmt.Println("   the user in total, not 5 per instance")
+	printLine()
+
+	sharedStore := NewMemoryStore()
+	for _, algorithm := range []struct {
+		name     string
+		instance func() RateLimiter
+	}{
+		{"Fixed window", func() RateLimiter {
+			return NewDistributedFixedWindowLimiter(sharedStore, "rl:api", 5, time.Minute)
+		}},
+		{"Sliding window", func() RateLimiter {
+			return NewDistributedSlidingWindowLimiter(sharedStore, "rl:api", 5, time.Minute)
+		}},
+		{"Token bucket", func() RateLimiter {
+			return NewDistributedTokenBucketLimiter(sharedStore, "rl:api", 5, 5, time.Minute)
+		}},
+	} {
+		gateways := []RateLimiter{algorithm.instance(), algorithm.instance()}
+		outcomes := make([]string, 0, 8)
+		allowed := 0
+		for requestNumber := 0; requestNumber < 8; requestNumber++ {
+			gatewayIndex := requestNumber % len(gateways) // Load balancer round-robin
+			if gateways[gatewayIndex].Allow("dave") {
+				allowed++
+				outcomes = append(outcomes, fmt.Sprintf("gw%d ✓", gatewayIndex+1))
+			} else {
+				outcomes = append(outcomes, fmt.Sprintf("gw%d ✗", gatewayIndex+1))
+			}
+		}
+		fmt.Printf("   %-15s %s → %d/8 allowed, remaining %d\n", algorithm.name,
+			strings.Join(outcomes, " "), allowed, gateways[0].Check("dave").Remaining)
+	}
+	fmt.Printf("   Shared keys in the store: %d\n", sharedStore.Len())
+
+	contendedBucket := NewDistributedTokenBucketLimiter(sharedStore, "rl:burst", 50, 50, time.Minute)
+	var burstWaitGroup sync.WaitGroup
+	var burstAllowed atomic.Int64
+	for worker := 0; worker < 100; worker++ {
+		burstWaitGroup.Add(1)
+		go func() {
+			defer burstWaitGroup.Done()
+			if contendedBucket.Allow("eve") {
+				burstAllowed.Add(1)
+			}
+		}()
+	}
+	burstWaitGroup.Wait()
+	fmt.Printf("\n   100 concurrent requests on a 50-token shared bucket: %d allowed, %d CAS retries\n",
+		burstAllowed.Load(), contendedBucket.GetConflicts())
+
+	unreachable := NewRedisStore("127.0.0.1:1", 20*time.Millisecond) // Nothing listens on port 1
+	failOpen := NewDistributedFixedWindowLimiter(unreachable, "rl:api", 5, time.Minute)
+	failClosed := NewDistributedFixedWindowLimiter(unreachable, "rl:api", 5, time.Minute)
+	failClosed.SetFailClosed(true)
+	fmt.Printf("\n   Store down: fail-open allows=%v, fail-closed allows=%v (store errors: %d)\n",
+		failOpen.Allow("dave"), failClosed.Allow("dave"), failOpen.GetStoreErrors()+failClosed.GetStoreErrors())
+
+	if redisAddress := os.Getenv("REDIS_ADDR"); redisAddress != "" {
+		redisStore := NewRedisStore(redisAddress, time.Second)
+		redisLimiter := NewDistributedFixedWindowLimiter(redisStore, fmt.Sprintf("rl:demo:%d", time.Now().UnixNano()), 3, time.Minute)
+		redisResults := make([]bool, 0, 5)
+		for requestNumber := 0; requestNumber < 5; requestNumber++ {
+			redisResults = append(redisResults, redisLimiter.Allow("dave"))
+		}
+		fmt.Printf("   Redis at %s: %v (store errors: %d)\n", redisAddress, redisResults, redisLimiter.GetStoreErrors())
+		redisStore.Close()
+	} else {
+		fmt.Println("   Redis: set REDIS_ADDR=host:port to run the same limiter against Redis")
+	}
+
+	// ----------------------------------------
+	// Demo 13: Shadow mode (observe before enforcing)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 13: SHADOW MODE (Safe Rollout)")
+	fmt.Println("   New limit: 3 requests per minute. Shadowed by default, already")
+	fmt.Println("   enforced for API keys")
+	printLine()
+
+	shadowLimiter := NewShadowLimiter(NewFixedWindowRateLimiter(3, time.Minute), ModeShadow)
+	_ = shadowLimiter.SetRuleMode("apikey:*", ModeEnforce)
+	shadowLog := make([]string, 0)
+	shadowLimiter.SetObserver(func(decision ShadowDecision) {
+		shadowLog = append(shadowLog, fmt.Sprintf("%s/%s/%s", decision.Key, decision.Rule, decision.Mode))
+	})
+
+	rolloutTraffic := []struct {
+		key      string
+		requests int
+	}{{"user:alice", 5}, {"user:bob", 2}, {"apikey:partner-7", 5}, {"user:mallory|endpoint:/api/search", 8}}
+	for _, traffic := range rolloutTraffic {
+		outcomes := make([]string, 0, traffic.requests)
+		for requestNumber := 0; requestNumber < traffic.requests; requestNumber++ {
+			if shadowLimiter.Allow(traffic.key) {
+				outcomes = append(outcomes, "✓")
+			} else {
+				outcomes = append(outcomes, "✗")
+			}
+		}
+		fmt.Printf("   %-35s [%-7s] %s\n", traffic.key, shadowLimiter.GetMode(traffic.key), strings.Join(outcomes, ""))
+	}
+
+	shadowStats := shadowLimiter.GetStats()
+	fmt.Printf("\n   Totals: %d evaluated, %d allowed, %d rejected, %d would-be rejected\n",
+		shadowStats.Evaluated, shadowStats.Allowed, shadowStats.Rejected, shadowStats.WouldReject)
+	for _, rule := range []string{"apikey:*", defaultShadowRule} {
+		stats := shadowLimiter.GetRuleStats()[rule]
+		fmt.Printf("   Rule %-10s %d evaluated, %d rejected, %d would-be rejected\n",
+			rule, stats.Evaluated, stats.Rejected, stats.WouldReject)
+	}
+	fmt.Println("   Keys a 3/min limit would hurt most:")
+	for _, keyCount := range shadowLimiter.GetTopShadowedKeys(3) {
+		fmt.Printf("     %-35s %d\n", keyCount.Key, keyCount.Count)
+	}
+	fmt.Printf("   Observer saw %d limited requests, e.g. %s\n", len(shadowLog), shadowLog[0])
+
+	// Numbers look right: enforce for users too
+	_ = shadowLimiter.SetRuleMode("user:*", ModeEnforce)
+	fmt.Printf("\n   After SetRuleMode(\"user:*\", enforce): alice allowed=%v\n", shadowLimiter.Allow("user:alice"))
+
+	// ----------------------------------------
+	// Demo 14: Sliding window memory (log cap vs counter)
+	// ----------------------------------------
+	fmt.Println("\n📊 Demo 14: SLIDING WINDOW MEMORY")
+	fmt.Println("   Configuration: 100 requests per minute")
+	fmt.Println("   Log (exact), log capped at 16 timestamps, two-bucket counter")
+	printLine()
+
+	newCappedLog := func() RateLimiter {
+		limiter := NewSlidingWindowRateLimiter(benchmarkLimit, time.Minute)
+		_ = limiter.SetMaxLogEntries(16)
+		return limiter
+	}
+	newCounter := func() RateLimiter { return NewSlidingWindowCounterRateLimiter(benchmarkLimit, time.Minute) }
+
+	// One client sending 4 requests/sec for 3 simulated minutes (240/min
+	// against a 100/min limit), measured after the first minute
+	simulatedStart := time.Now().Truncate(time.Minute)
+	simulateClient := func(allowAt func(time.Time) bool) int {
+		allowedAfterWarmup := 0
+		for tick := 0; tick < 3*60*4; tick++ {
+			if allowAt(simulatedStart.Add(time.Duration(tick)*250*time.Millisecond)) && tick >= 60*4 {
+				allowedAfterWarmup++
+			}
+		}
+		return allowedAfterWarmup
+	}
+
+	fmt.Printf("\n   %-22s %14s %22s\n", "Variant", "bytes/user", "allowed in minutes 2-3")
+	for _, variant := range []struct {
+		label      string
+		newLimiter func() RateLimiter
+	}{{"Log (exact)", newPlain}, {"Log, cap 16", newCappedLog}, {"Counter (2 buckets)", newCounter}} {
+		simulated := variant.newLimiter()
+		var allowed int
+		switch limiter := simulated.(type) {
+		case *SlidingWindowRateLimiter:
+			allowed = simulateClient(func(at time.Time) bool { return limiter.allowAt("client", at) })
+		case *SlidingWindowCounterRateLimiter:
+			allowed = simulateClient(func(at time.Time) bool { return limiter.allowAt("client", at) })
+		}
+		fmt.Printf("   %-22s %14d %16d (limit 200)\n", variant.label,
+			retainedBytesPerUser(variant.newLimiter, benchmarkUsers, benchmarkLimit), allowed)
+	}
+
+	shortLivedLimiter := NewSlidingWindowRateLimiter(5, 50*time.Millisecond)
+	for i := 0; i < 1000; i++ {
+		shortLivedLimiter.Allow("visitor" + strconv.Itoa(i))
+	}
+	time.Sleep(60 * time.Millisecond)
+	trackedBefore := shortLivedLimiter.GetTrackedUsers()
+	fmt.Printf("\n   Compact() after the window passed: dropped %d of %d idle users\n",
+		shortLivedLimiter.Compact(), trackedBefore)
+
+	// ----------------------------------------
+	// Summary: Algorithm Comparison
+	// ----------------------------------------
+	printSeparator()
+	fmt.Println("        ALGORITHM COMPARISON SUMMARY")
+	printSeparator()
+	fmt.Println()
+	fmt.Println("  ┌─────────────────┬──────────────────────────────────────────┐")
+	fmt.Println("  │ Algorithm       │ Characteristics                          │")
+	fmt.Println("  ├─────────────────┼──────────────────────────────────────────┤")
+	fmt.Println("  │ Token Bucket    │ Allows bursts, smooth refill, most used  │")
+	fmt.Println("  │ Sliding Window  │ Smooth limiting, no boundary issues      │")
+	fmt.Println("  │ Fixed Window    │ Simple & fast, but has boundary problem  │")
+	fmt.Println("  │ Leaky Bucket    │ Constant output rate, smooths traffic    │")
+	fmt.Println("  │ Compact Window  │ Sliding window with bounded memory/user  │")
+	fmt.Println("  │ Window Counter  │ Weighted 2-bucket estimate, O(1) memory  │")
+	fmt.Println("  │ Concurrency     │ Caps in-flight requests per endpoint     │")
+	fmt.Println("  │ Queueing        │ Bounded FIFO wait for capacity per key   │")
+	fmt.Println("  │ Multi Window    │ All of several windows (burst+sustained) │")
+	fmt.Println("  │ Reset Jitter    │ Staggers per-key resets, no herd bursts  │")
+	fmt.Println("  │ Distributed     │ Shared Store (Redis) across gateways     │")
+	fmt.Println("  │ Shadow Mode     │ Records would-be rejections, no blocking │")
+	fmt.Println("  └─────────────────┴──────────────────────────────────────────┘")
+	fmt.Println()
+	printSeparator()
+}
+
+// ============================================================================
+// SECTION 17: HELPER FUNCTIONS
+// ============================================================================
+
+// retainedBytesPerUser fills `users` users up to their limit and reports the
+// heap kept alive per user once garbage is collected.
+func retainedBytesPerUser(newLimiter func() RateLimiter, users, requestsPerUser int) int {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	limiter := newLimiter()
+	for i := 0; i < users; i++ {
+		userID := "user" + strconv.Itoa(i)
+		for j := 0; j < requestsPerUser; j++ {
+			limiter.Allow(userID)
+		}
+	}
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(limiter)
+
+	if after.HeapAlloc < before.HeapAlloc {
+		return 0
+	}
+	return int(after.HeapAlloc-before.HeapAlloc) / users
+}
+
+// printSeparator prints a visual separator line for better readability.
+func printSeparator() {
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+}
+
+// printLine prints a thin line for section separation.
+func printLine() {
+	fmt.Println("───────────────────────────────────────────────────────────────")
+}
+
+// signTestJWT issues an HS256 token for the demo. Real tokens come from the
+// identity provider; the gateway only verifies them.
+func signTestJWT(secret []byte, subject string, expiresAt time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims, _ := json.Marshal(jwtClaims{Subject: subject, ExpiresAt: expiresAt.Unix()})
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(header + "." + payload))
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,3397 @@
+// Package ratelimiter implements rate limiting algorithms (token bucket,
+// sliding window, fixed window, leaky bucket and variants) behind one
+// RateLimiter interface, plus an API gateway that uses them.
+package ratelimiter
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================================================
+// RATE LIMITER - Low Level Design Implementation
+// ============================================================================
+//
+// What is a Rate Limiter?
+// -----------------------
+// A rate limiter controls the number of requests a user/client can make to a
+// service within a specific time window. It helps:
+// - Prevent abuse and denial-of-service attacks
+// - Ensure fair resource usage among users
+// - Protect servers from being overwhelmed
+//
+// This file implements 4 popular rate limiting algorithms:
+// 1. Token Bucket     - Allows burst traffic, most widely used
+// 2. Sliding Window   - Smooth limiting, no boundary issues
+// 3. Fixed Window     - Simple, but has boundary problems
+// 4. Leaky Bucket     - Processes requests at constant rate
+//
+// Plus a Sliding Window Counter (two weighted fixed windows, O(1) memory
+// per user), a memory-bounded Sliding Window variant (ring buffer of
+// coarse-grained timestamp buckets) for large user counts, and a
+// Concurrency Limiter that caps in-flight requests per endpoint, and a
+// Queueing Limiter that lets rate-limited jobs wait in a bounded FIFO, and
+// a Multi-Window Limiter that enforces several windows per key at once
+// (e.g. 10/min AND 100/hour).
+// Requests are keyed by pluggable Key Extractors (user, IP, API key, JWT
+// subject, or composites such as user + endpoint).
+// Token Bucket and Fixed Window can jitter refills/window resets per key,
+// so thousands of clients that arrive together don't all burst together.
+// Token Bucket, Sliding Window and Fixed Window also come in distributed
+// variants that keep their state in a pluggable Store (in-memory or Redis),
+// so every instance of a gateway enforces one shared limit.
+// Any limiter can run in Shadow Mode, recording would-be rejections without
+// blocking, so new limits can be tuned on real traffic before enforcing.
+//
+// Design Pattern Used: Strategy Pattern
+// - RateLimiter interface defines the contract
+// - Each algorithm implements this interface
+// - API Gateway uses any limiter via the interface
+//
+// ============================================================================
+
+// ============================================================================
+// SECTION 1: RATE LIMITER INTERFACE (Strategy Pattern)
+// ============================================================================
+
+// RateLimiter defines the contract for all rate limiting algorithms.
+// Any rate limiter must implement these methods.
+type RateLimiter interface {
+	// Allow checks if a request from the given userID should be permitted.
+	// Returns true if allowed, false if rate limited.
+	Allow(userID string) bool
+
+	// Check reports the user's current quota WITHOUT consuming it, and
+	// without creating state for users it has never seen.
+	// Gateways use this for rate-limit headers and dashboards.
+	Check(userID string) Quota
+
+	// GetName returns the name of the algorithm for logging purposes.
+	GetName() string
+}
+
+// Quota is a read-only snapshot of a user's rate limit state.
+type Quota struct {
+	Limit     int       // Maximum requests allowed (bucket capacity or window limit)
+	Remaining int       // Requests that would be allowed right now
+	ResetAt   time.Time // When Remaining will be back to Limit
+	RetryAt   time.Time // When the next request would be allowed (zero if it would be now)
+}
+
+// fullQuota is the quota of a key the limiter holds no state for.
+func fullQuota(limit int) Quota {
+	return Quota{Limit: limit, Remaining: limit, ResetAt: time.Now()}
+}
+
+// counterRetryAt returns when a two-window weighted estimate
+// (previous × (1 − elapsed fraction) + current) leaves room for one more
+// request, or the zero time if there is room now. The previous window's
+// weight fades over the current window; the current window's count starts
+// fading one window later. Used by both sliding window counters.
+func counterRetryAt(windowStart time.Time, window time.Duration, currentTime time.Time, previous, current, limit int) time.Time {
+	elapsed := float64(currentTime.Sub(windowStart)) / float64(window)
+	if float64(previous)*(1-elapsed)+float64(current)+1 <= float64(limit) {
+		return time.Time{}
+	}
+	if current+1 <= limit {
+		// Wait for the previous window's weight to drop far enough
+		fraction := 1 - float64(limit-1-current)/float64(previous)
+		return windowStart.Add(time.Duration(fraction * float64(window)))
+	}
+	// The current window alone is full: wait for it to become the previous one
+	fraction := min(1, max(0, 1-float64(limit-1)/float64(current)))
+	return windowStart.Add(window + time.Duration(fraction*float64(window)))
+}
+
+// untilFull returns when `missing` units are restored, given that `perStep`
+// units come back every `step` starting from `from`.
+// Used by the bucket algorithms to compute Quota.ResetAt.
+func untilFull(from time.Time, missing, perStep int, step time.Duration) time.Time {
+	if missing <= 0 {
+		return time.Now()
+	}
+	stepsNeeded := (missing + perStep - 1) / perStep // Ceiling division
+	return from.Add(time.Duration(stepsNeeded) * step)
+}
+
+// ============================================================================
+// SECTION 2: TOKEN BUCKET ALGORITHM
+// ============================================================================
+//
+// How Token Bucket Works:
+// -----------------------
+// Imagine a bucket that holds tokens. Each request costs 1 token.
+// - Bucket has a maximum capacity (e.g., 10 tokens)
+// - Tokens are added at a fixed rate (e.g., 2 tokens per second)
+// - When a request arrives, if there's a token, consume it and allow
+// - If bucket is empty, reject the request
+// - Allows burst traffic (up to bucket capacity) followed by steady rate
+//
+// Example: capacity=5, refillRate=2/sec
+// - User can make 5 quick requests (burst)
+// - Then must wait for tokens to refill (2 per second)
+//
+// Refill is continuous: tokens accrue fractionally (2/sec = 0.2 tokens
+// after 100ms), so a request is allowed as soon as one whole token has
+// built up instead of waiting for the next full interval, and no partial
+// progress is thrown away between refills. Elapsed time is measured on
+// the monotonic clock, so wall-clock jumps (NTP, DST) can't mint or
+// steal tokens.
+//
+// Pros: Allows controlled bursts, smooth refill
+// Cons: Slightly more complex than fixed window
+//
+// ============================================================================
+
+// monotonicEpoch anchors monotonicNow. time.Since reads the monotonic clock
+// carried by time.Now(), so the result only ever moves forward.
+var monotonicEpoch = time.Now()
+
+// monotonicNow returns the time elapsed since process start on the
+// monotonic clock. Durations between two calls are immune to wall-clock changes.
+func monotonicNow() time.Duration {
+	return time.Since(monotonicEpoch)
+}
+
+// TokenBucket represents a single user's token bucket.
+type TokenBucket struct {
+	maxCapacity   int           // Maximum tokens the bucket can hold
+	currentTokens float64       // Tokens available, including a partial one
+	refillRate    float64       // Tokens added per second (tokensPerRefill / refillInterval)
+	lastRefill    time.Duration // Monotonic time of the last refill (see monotonicNow)
+	retired       bool          // Dropped by Compact; callers must fetch a fresh bucket
+	mutex         sync.Mutex    // Protects concurrent access to this bucket
+}
+
+// NewTokenBucket creates a new token bucket with the specified configuration.
+// The bucket refills continuously at tokensPerRefill per refillInterval.
+func NewTokenBucket(maxCapacity, tokensPerRefill int, refillInterval time.Duration) *TokenBucket {
+	return &TokenBucket{
+		maxCapacity:   maxCapacity,
+		currentTokens: float64(maxCapacity), // Start with a full bucket
+		refillRate:    float64(tokensPerRefill) / refillInterval.Seconds(),
+		lastRefill:    monotonicNow(),
+	}
+}
+
+// refillTokens adds the (possibly fractional) tokens earned since the last refill.
+// This is called internally before checking/consuming tokens.
+// Caller must hold bucket.mutex.
+func (bucket *TokenBucket) refillTokens() {
+	now := monotonicNow()
+	elapsed := now - bucket.lastRefill
+	if elapsed <= 0 {
+		return
+	}
+
+	// Add tokens but don't exceed capacity
+	bucket.currentTokens = min(float64(bucket.maxCapacity), bucket.currentTokens+elapsed.Seconds()*bucket.refillRate)
+	bucket.lastRefill = now
+}
+
+// TryConsume attempts to consume one token. Returns true if successful.
+func (bucket *TokenBucket) TryConsume() bool {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	// First, refill tokens based on elapsed time
+	bucket.refillTokens()
+
+	// A request needs one whole token
+	if bucket.currentTokens >= 1 {
+		bucket.currentTokens--
+		return true
+	}
+	return false
+}
+
+// GetAvailableTokens returns the number of whole tokens available.
+func (bucket *TokenBucket) GetAvailableTokens() int {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	bucket.refillTokens()
+	return int(bucket.currentTokens)
+}
+
+// GetTokenLevel returns the exact token level, including the partial token.
+func (bucket *TokenBucket) GetTokenLevel() float64 {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	bucket.refillTokens()
+	return bucket.currentTokens
+}
+
+// Snapshot returns the bucket's quota without consuming a token.
+func (bucket *TokenBucket) Snapshot() Quota {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	bucket.refillTokens()
+
+	// ResetAt is reported on the wall clock for HTTP headers
+	missingTokens := float64(bucket.maxCapacity) - bucket.currentTokens
+	untilRefilled := time.Duration(missingTokens / bucket.refillRate * float64(time.Second))
+	if missingTokens > 0 {
+		// A jittered bucket may not have started refilling yet
+		untilRefilled += max(0, bucket.lastRefill-monotonicNow())
+	}
+	quota := Quota{
+		Limit:     bucket.maxCapacity,
+		Remaining: int(bucket.currentTokens),
+		ResetAt:   time.Now().Add(untilRefilled),
+	}
+	if bucket.currentTokens < 1 {
+		untilToken := time.Duration((1 - bucket.currentTokens) / bucket.refillRate * float64(time.Second))
+		quota.RetryAt = time.Now().Add(untilToken + max(0, bucket.lastRefill-monotonicNow()))
+	}
+	return quota
+}
+
+// TokenBucketRateLimiter manages token buckets for multiple users.
+type TokenBucketRateLimiter struct {
+	userBuckets     map[string]*TokenBucket // Map of userID -> their bucket
+	maxCapacity     int                     // Bucket capacity for new users
+	tokensPerRefill int                     // Refill rate for new users
+	refillInterval  time.Duration           // Refill interval for new users
+	refillJitter    time.Duration           // Max per-key delay before refill starts (0 = off)
+	mutex           sync.RWMutex            // Protects the userBuckets map
+}
+
+// NewTokenBucketRateLimiter creates a new token bucket rate limiter.
+func NewTokenBucketRateLimiter(maxCapacity, tokensPerRefill int, refillInterval time.Duration) *TokenBucketRateLimiter {
+	return &TokenBucketRateLimiter{
+		userBuckets:     make(map[string]*TokenBucket),
+		maxCapacity:     maxCapacity,
+		tokensPerRefill: tokensPerRefill,
+		refillInterval:  refillInterval,
+	}
+}
+
+// getOrCreateBucket retrieves an existing bucket or creates a new one for the user.
+// Uses double-checked locking pattern for thread safety and efficiency.
+func (limiter *TokenBucketRateLimiter) getOrCreateBucket(userID string) *TokenBucket {
+	// First, try to read with a read lock (allows concurrent reads)
+	limiter.mutex.RLock()
+	bucket, exists := limiter.userBuckets[userID]
+	limiter.mutex.RUnlock()
+
+	if exists {
+		return bucket
+	}
+
+	// Bucket doesn't exist, need to create it with write lock
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Double-check: another goroutine might have created it while we waited
+	if bucket, exists = limiter.userBuckets[userID]; exists {
+		return bucket
+	}
+
+	// Create new bucket for this user
+	bucket = NewTokenBucket(limiter.maxCapacity, limiter.tokensPerRefill, limiter.refillInterval)
+	bucket.lastRefill += resetJitterFor(userID, limiter.refillJitter)
+	limiter.userBuckets[userID] = bucket
+	return bucket
+}
+
+// Allow checks if a request from userID should be permitted.
+// Implements the RateLimiter interface.
+func (limiter *TokenBucketRateLimiter) Allow(userID string) bool {
+	for {
+		bucket := limiter.getOrCreateBucket(userID)
+		bucket.mutex.Lock()
+		if bucket.retired {
+			// Compact dropped it after the lookup; a fresh bucket is
+			// just as full, so start over with that one
+			bucket.mutex.Unlock()
+			continue
+		}
+		bucket.refillTokens()
+		allowed := bucket.currentTokens >= 1
+		if allowed {
+			bucket.currentTokens--
+		}
+		bucket.mutex.Unlock()
+		return allowed
+	}
+}
+
+// Compact drops the buckets of users that have refilled to capacity and
+// returns how many were dropped. A full bucket is what a new user starts
+// with, so nothing is forgotten. Without it, every user ever seen (e.g.
+// every client IP) keeps a bucket; call it periodically.
+func (limiter *TokenBucketRateLimiter) Compact() int {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	dropped := 0
+	for userID, bucket := range limiter.userBuckets {
+		bucket.mutex.Lock()
+		bucket.refillTokens()
+		if bucket.currentTokens >= float64(bucket.maxCapacity) {
+			bucket.retired = true
+			delete(limiter.userBuckets, userID)
+			dropped++
+		}
+		bucket.mutex.Unlock()
+	}
+	return dropped
+}
+
+// GetTrackedUsers returns how many users currently hold a bucket.
+func (limiter *TokenBucketRateLimiter) GetTrackedUsers() int {
+	limiter.mutex.RLock()
+	defer limiter.mutex.RUnlock()
+	return len(limiter.userBuckets)
+}
+
+// Check returns the user's quota without consuming a token.
+func (limiter *TokenBucketRateLimiter) Check(userID string) Quota {
+	limiter.mutex.RLock()
+	bucket, exists := limiter.userBuckets[userID]
+	limiter.mutex.RUnlock()
+
+	if !exists {
+		return fullQuota(limiter.maxCapacity)
+	}
+	return bucket.Snapshot()
+}
+
+// GetName returns the algorithm name.
+func (limiter *TokenBucketRateLimiter) GetName() string {
+	return "Token Bucket"
+}
+
+// ============================================================================
+// SECTION 3: SLIDING WINDOW ALGORITHM
+// ============================================================================
+//
+// How Sliding Window Works:
+// -------------------------
+// Track the timestamp of each request within a sliding time window.
+// - Window slides with current time (not fixed boundaries)
+// - Count requests in the last N seconds (where N is window size)
+// - If count < limit, allow request; otherwise reject
+//
+// Example: 3 requests per 2 seconds
+// - At time 0s: Request 1 ✅ (count: 1)
+// - At time 0.5s: Request 2 ✅ (count: 2)
+// - At time 1s: Request 3 ✅ (count: 3)
+// - At time 1.5s: Request 4 ❌ (count: 3, limit reached)
+// - At time 2.1s: Request 5 ✅ (Request 1 is now outside window)
+//
+// Pros: Smooth rate limiting, no boundary spike issues
+// Cons: Higher memory usage (stores all request timestamps)
+//
+// Keeping memory in check:
+// - SetMaxLogEntries caps the timestamps per user; the oldest ones are
+//   compacted into a single count (see SlidingWindowCounter in Section 15
+//   for O(1) state per user)
+// - Compact drops users whose window is empty
+//
+// ============================================================================
+
+// SlidingWindowRecord stores request timestamps for one user.
+// With a log cap, the oldest timestamps are compacted into one overflow
+// entry: a count plus the newest compacted timestamp.
+type SlidingWindowRecord struct {
+	requestTimestamps []time.Time // List of timestamps of recent requests, oldest first
+	overflowCount     int         // Requests compacted out of the log (still in the window)
+	overflowNewest    time.Time   // Newest timestamp among the compacted requests
+	retired           bool        // Removed by Compact; callers must fetch a new record
+	mutex             sync.Mutex  // Protects concurrent access
+}
+
+// requestCount returns the number of requests in the window.
+// Caller must hold window.mutex.
+func (window *SlidingWindowRecord) requestCount() int {
+	return len(window.requestTimestamps) + window.overflowCount
+}
+
+// SlidingWindowRateLimiter implements sliding window rate limiting.
+type SlidingWindowRateLimiter struct {
+	userWindows    map[string]*SlidingWindowRecord // Map of userID -> their record
+	maxRequests    int                             // Maximum requests allowed per window
+	windowDuration time.Duration                   // Size of the sliding window
+	maxLogEntries  int                             // Timestamps kept per user before compaction (0 = no cap)
+	mutex          sync.RWMutex                    // Protects the userWindows map
+}
+
+// NewSlidingWindowRateLimiter creates a new sliding window rate limiter.
+func NewSlidingWindowRateLimiter(maxRequests int, windowDuration time.Duration) *SlidingWindowRateLimiter {
+	return &SlidingWindowRateLimiter{
+		userWindows:    make(map[string]*SlidingWindowRecord),
+		maxRequests:    maxRequests,
+		windowDuration: windowDuration,
+	}
+}
+
+// SetMaxLogEntries caps the timestamps kept per user (0 = no cap).
+// When a log is full, its oldest half is compacted into a single overflow
+// entry that is treated as if every compacted request happened at the
+// newest compacted time. Those requests leave the window late, so the
+// limiter may reject slightly early but never admits more than maxRequests.
+// Call before the limiter serves traffic.
+func (limiter *SlidingWindowRateLimiter) SetMaxLogEntries(maxLogEntries int) error {
+	if maxLogEntries < 0 || maxLogEntries == 1 {
+		return fmt.Errorf("log cap must be 0 (no cap) or at least 2, got %d", maxLogEntries)
+	}
+	limiter.maxLogEntries = maxLogEntries
+	return nil
+}
+
+// lockWindow returns the user's record, locked. A record that Compact
+// retired between lookup and locking is replaced by a fresh one.
+func (limiter *SlidingWindowRateLimiter) lockWindow(userID string) *SlidingWindowRecord {
+	for {
+		window := limiter.getOrCreateWindow(userID)
+		window.mutex.Lock()
+		if !window.retired {
+			return window
+		}
+		window.mutex.Unlock()
+	}
+}
+
+// lockExistingWindow is lockWindow for Check: it returns nil instead of
+// creating a record for a user with no requests.
+func (limiter *SlidingWindowRateLimiter) lockExistingWindow(userID string) *SlidingWindowRecord {
+	for {
+		limiter.mutex.RLock()
+		window, exists := limiter.userWindows[userID]
+		limiter.mutex.RUnlock()
+		if !exists {
+			return nil
+		}
+		window.mutex.Lock()
+		if !window.retired {
+			return window
+		}
+		window.mutex.Unlock()
+	}
+}
+
+// getOrCreateWindow retrieves or creates a sliding window record for a user.
+func (limiter *SlidingWindowRateLimiter) getOrCreateWindow(userID string) *SlidingWindowRecord {
+	limiter.mutex.RLock()
+	window, exists := limiter.userWindows[userID]
+	limiter.mutex.RUnlock()
+
+	if exists {
+		return window
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Double-check after acquiring write lock
+	if window, exists = limiter.userWindows[userID]; exists {
+		return window
+	}
+
+	window = &SlidingWindowRecord{
+		requestTimestamps: make([]time.Time, 0),
+	}
+	limiter.userWindows[userID] = window
+	return window
+}
+
+// evictExpired removes timestamps that are outside the current window.
+// Timestamps are sorted, so the valid ones are shifted to the front of the
+// same slice instead of being copied to a new one.
+// Caller must hold window.mutex.
+func (limiter *SlidingWindowRateLimiter) evictExpired(window *SlidingWindowRecord, currentTime time.Time) {
+	windowStartTime := currentTime.Add(-limiter.windowDuration)
+
+	if window.overflowCount > 0 && !window.overflowNewest.After(windowStartTime) {
+		window.overflowCount = 0
+	}
+	firstValid := sort.Search(len(window.requestTimestamps), func(index int) bool {
+		return window.requestTimestamps[index].After(windowStartTime)
+	})
+	if firstValid > 0 {
+		validCount := copy(window.requestTimestamps, window.requestTimestamps[firstValid:])
+		window.requestTimestamps = window.requestTimestamps[:validCount]
+	}
+}
+
+// compact folds the oldest half of a full log into the overflow entry.
+// Caller must hold window.mutex.
+func (limiter *SlidingWindowRateLimiter) compact(window *SlidingWindowRecord) {
+	if limiter.maxLogEntries == 0 || len(window.requestTimestamps) < limiter.maxLogEntries {
+		return
+	}
+	folded := len(window.requestTimestamps) / 2
+	window.overflowCount += folded
+	window.overflowNewest = window.requestTimestamps[folded-1]
+	keptCount := copy(window.requestTimestamps, window.requestTimestamps[folded:])
+	window.requestTimestamps = window.requestTimestamps[:keptCount]
+}
+
+// Allow checks if a request from userID should be permitted.
+func (limiter *SlidingWindowRateLimiter) Allow(userID string) bool {
+	return limiter.allowAt(userID, time.Now())
+}
+
+// allowAt is Allow evaluated at currentTime. The compaction demo drives it
+// with a simulated clock.
+func (limiter *SlidingWindowRateLimiter) allowAt(userID string, currentTime time.Time) bool {
+	window := limiter.lockWindow(userID)
+	defer window.mutex.Unlock()
+
+	// Remove timestamps that are outside the current window (expired requests)
+	limiter.evictExpired(window, currentTime)
+
+	// Check if we're under the limit
+	if window.requestCount() < limiter.maxRequests {
+		limiter.compact(window)
+		window.requestTimestamps = append(window.requestTimestamps, currentTime)
+		return true
+	}
+	return false
+}
+
+// Check returns the user's quota without recording a request.
+// The full limit is back once the newest request slides out of the window.
+func (limiter *SlidingWindowRateLimiter) Check(userID string) Quota {
+	window := limiter.lockExistingWindow(userID)
+	if window == nil {
+		return fullQuota(limiter.maxRequests)
+	}
+	defer window.mutex.Unlock()
+
+	currentTime := time.Now()
+	limiter.evictExpired(window, currentTime)
+
+	resetAt := currentTime
+	if count := len(window.requestTimestamps); count > 0 {
+		resetAt = window.requestTimestamps[count-1].Add(limiter.windowDuration)
+	} else if window.overflowCount > 0 {
+		resetAt = window.overflowNewest.Add(limiter.windowDuration)
+	}
+
+	quota := Quota{
+		Limit:     limiter.maxRequests,
+		Remaining: max(0, limiter.maxRequests-window.requestCount()),
+		ResetAt:   resetAt,
+	}
+	// Room returns once the oldest excess requests slide out; compacted
+	// requests all count as made at overflowNewest and leave first
+	if excess := window.requestCount() - limiter.maxRequests + 1; excess > 0 {
+		if excess <= window.overflowCount {
+			quota.RetryAt = window.overflowNewest.Add(limiter.windowDuration)
+		} else {
+			quota.RetryAt = window.requestTimestamps[excess-window.overflowCount-1].Add(limiter.windowDuration)
+		}
+	}
+	return quota
+}
+
+// GetName returns the algorithm name.
+func (limiter *SlidingWindowRateLimiter) GetName() string {
+	return "Sliding Window"
+}
+
+// Compact drops the records of users with no requests left in the window
+// and returns how many were dropped. Without it, every user ever seen keeps
+// a record; call it periodically on long-running gateways.
+func (limiter *SlidingWindowRateLimiter) Compact() int {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	currentTime := time.Now()
+	dropped := 0
+	for userID, window := range limiter.userWindows {
+		window.mutex.Lock()
+		limiter.evictExpired(window, currentTime)
+		if window.requestCount() == 0 {
+			window.retired = true
+			delete(limiter.userWindows, userID)
+			dropped++
+		}
+		window.mutex.Unlock()
+	}
+	return dropped
+}
+
+// GetTrackedUsers returns how many users currently have a record.
+func (limiter *SlidingWindowRateLimiter) GetTrackedUsers() int {
+	limiter.mutex.RLock()
+	defer limiter.mutex.RUnlock()
+	return len(limiter.userWindows)
+}
+
+// ============================================================================
+// SECTION 4: FIXED WINDOW ALGORITHM
+// ============================================================================
+//
+// How Fixed Window Works:
+// -----------------------
+// Divide time into fixed windows (e.g., every minute) and count requests.
+// - Reset counter at the start of each new window
+// - Simple to implement with low memory overhead
+//
+// Example: 4 requests per 3-second window
+// - Window 1 (0s-3s): Can make up to 4 requests
+// - Window 2 (3s-6s): Counter resets, another 4 requests allowed
+//
+// IMPORTANT: Boundary Problem!
+// - User makes 4 requests at 2.9s (end of window 1)
+// - User makes 4 requests at 3.1s (start of window 2)
+// - Result: 8 requests in 0.2 seconds! This exceeds the intended rate.
+//
+// Pros: Very simple, low memory usage
+// Cons: Vulnerable to boundary spikes (traffic bursts at window edges)
+//
+// ============================================================================
+
+// FixedWindowRecord stores request count for one user's current window.
+type FixedWindowRecord struct {
+	requestCount    int        // Number of requests in current window
+	windowStartTime time.Time  // When the current window started
+	retired         bool       // Set by Compact once the record leaves the map
+	mutex           sync.Mutex // Protects concurrent access
+}
+
+// FixedWindowRateLimiter implements fixed window rate limiting.
+type FixedWindowRateLimiter struct {
+	userWindows    map[string]*FixedWindowRecord // Map of userID -> their record
+	maxRequests    int                           // Maximum requests per window
+	windowDuration time.Duration                 // Duration of each window
+	resetJitter    time.Duration                 // Max per-key stretch of the first window (0 = off)
+	mutex          sync.RWMutex                  // Protects the userWindows map
+}
+
+// NewFixedWindowRateLimiter creates a new fixed window rate limiter.
+func NewFixedWindowRateLimiter(maxRequests int, windowDuration time.Duration) *FixedWindowRateLimiter {
+	return &FixedWindowRateLimiter{
+		userWindows:    make(map[string]*FixedWindowRecord),
+		maxRequests:    maxRequests,
+		windowDuration: windowDuration,
+	}
+}
+
+// getOrCreateWindow retrieves or creates a fixed window record for a user.
+// A new record's window opens at currentTime.
+func (limiter *FixedWindowRateLimiter) getOrCreateWindow(userID string, currentTime time.Time) *FixedWindowRecord {
+	limiter.mutex.RLock()
+	window, exists := limiter.userWindows[userID]
+	limiter.mutex.RUnlock()
+
+	if exists {
+		return window
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Double-check after acquiring write lock
+	if window, exists = limiter.userWindows[userID]; exists {
+		return window
+	}
+
+	// With jitter the first window runs a little longer, which shifts
+	// every later reset of this key by the same offset
+	window = &FixedWindowRecord{
+		windowStartTime: currentTime.Add(resetJitterFor(userID, limiter.resetJitter)),
+	}
+	limiter.userWindows[userID] = window
+	return window
+}
+
+// Allow checks if a request from userID should be permitted.
+func (limiter *FixedWindowRateLimiter) Allow(userID string) bool {
+	return limiter.allowAt(userID, time.Now())
+}
+
+// allowAt is Allow evaluated at currentTime. The jitter simulation drives
+// it with a virtual clock.
+func (limiter *FixedWindowRateLimiter) allowAt(userID string, currentTime time.Time) bool {
+	window := limiter.getOrCreateWindow(userID, currentTime)
+	window.mutex.Lock()
+	for window.retired {
+		// Compact dropped this record after we looked it up
+		window.mutex.Unlock()
+		window = limiter.getOrCreateWindow(userID, currentTime)
+		window.mutex.Lock()
+	}
+	defer window.mutex.Unlock()
+
+	// Check if we've moved to a new window
+	timeSinceWindowStart := currentTime.Sub(window.windowStartTime)
+	if timeSinceWindowStart >= limiter.windowDuration {
+		// Start a new window: reset counter and update start time
+		window.requestCount = 0
+		window.windowStartTime = currentTime
+	}
+
+	// Check if we're under the limit
+	if window.requestCount < limiter.maxRequests {
+		window.requestCount++
+		return true
+	}
+	return false
+}
+
+// Check returns the user's quota without counting a request.
+// If the current window has already expired, the full limit is available now.
+func (limiter *FixedWindowRateLimiter) Check(userID string) Quota {
+	limiter.mutex.RLock()
+	window, exists := limiter.userWindows[userID]
+	limiter.mutex.RUnlock()
+	if !exists {
+		return fullQuota(limiter.maxRequests)
+	}
+
+	currentTime := time.Now()
+	window.mutex.Lock()
+	defer window.mutex.Unlock()
+
+	windowEndTime := window.windowStartTime.Add(limiter.windowDuration)
+
+	if !currentTime.Before(windowEndTime) {
+		return Quota{Limit: limiter.maxRequests, Remaining: limiter.maxRequests, ResetAt: currentTime}
+	}
+
+	quota := Quota{
+		Limit:     limiter.maxRequests,
+		Remaining: limiter.maxRequests - window.requestCount,
+		ResetAt:   windowEndTime,
+	}
+	if quota.Remaining <= 0 {
+		quota.RetryAt = windowEndTime
+	}
+	return quota
+}
+
+// GetName returns the algorithm name.
+func (limiter *FixedWindowRateLimiter) GetName() string {
+	return "Fixed Window"
+}
+
+// Compact drops the records whose window has ended and returns how many
+// were dropped. Such a record would be reset on its next request anyway.
+// Without it, every key ever seen keeps a record; call it periodically.
+func (limiter *FixedWindowRateLimiter) Compact() int {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	currentTime := time.Now()
+	dropped := 0
+	for userID, window := range limiter.userWindows {
+		window.mutex.Lock()
+		if currentTime.Sub(window.windowStartTime) >= limiter.windowDuration {
+			window.retired = true
+			delete(limiter.userWindows, userID)
+			dropped++
+		}
+		window.mutex.Unlock()
+	}
+	return dropped
+}
+
+// GetTrackedUsers returns how many users currently have a record.
+func (limiter *FixedWindowRateLimiter) GetTrackedUsers() int {
+	limiter.mutex.RLock()
+	defer limiter.mutex.RUnlock()
+	return len(limiter.userWindows)
+}
+
+// ============================================================================
+// SECTION 5: LEAKY BUCKET ALGORITHM
+// ============================================================================
+//
+// How Leaky Bucket Works:
+// -----------------------
+// Imagine a bucket with a hole at the bottom. Water (requests) drip out at
+// a constant rate.
+// - Incoming requests fill the bucket
+// - Requests "leak" (are processed) at a constant rate
+// - If bucket overflows, new requests are rejected
+//
+// Key Difference from Token Bucket:
+// - Token Bucket: Tokens accumulate, allowing bursts
+// - Leaky Bucket: Requests leak at constant rate, smoothing traffic
+//
+// Example: capacity=3, leakRate=1 per 500ms
+// - Requests fill the bucket (up to 3)
+// - Every 500ms, one "leaks out" (processed/removed)
+// - If bucket is full, reject new requests until some leak out
+//
+// Pros: Smooths traffic, predictable output rate
+// Cons: No bursting allowed, may delay requests
+//
+// ============================================================================
+
+// LeakyBucketRecord represents one user's leaky bucket.
+type LeakyBucketRecord struct {
+	currentQueueSize int           // Current number of requests in the bucket
+	maxCapacity      int           // Maximum requests the bucket can hold
+	lastLeakTime     time.Time     // When requests last "leaked" out
+	leakInterval     time.Duration // Time between each leak
+	mutex            sync.Mutex    // Protects concurrent access
+}
+
+// LeakyBucketRateLimiter implements leaky bucket rate limiting.
+type LeakyBucketRateLimiter struct {
+	userBuckets  map[string]*LeakyBucketRecord // Map of userID -> their bucket
+	maxCapacity  int                           // Bucket capacity for new users
+	leakInterval time.Duration                 // Leak interval for new users
+	mutex        sync.RWMutex                  // Protects the userBuckets map
+}
+
+// NewLeakyBucketRateLimiter creates a new leaky bucket rate limiter.
+func NewLeakyBucketRateLimiter(maxCapacity int, leakInterval time.Duration) *LeakyBucketRateLimiter {
+	return &LeakyBucketRateLimiter{
+		userBuckets:  make(map[string]*LeakyBucketRecord),
+		maxCapacity:  maxCapacity,
+		leakInterval: leakInterval,
+	}
+}
+
+// getOrCreateBucket retrieves or creates a leaky bucket for a user.
+func (limiter *LeakyBucketRateLimiter) getOrCreateBucket(userID string) *LeakyBucketRecord {
+	limiter.mutex.RLock()
+	bucket, exists := limiter.userBuckets[userID]
+	limiter.mutex.RUnlock()
+
+	if exists {
+		return bucket
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Double-check after acquiring write lock
+	if bucket, exists = limiter.userBuckets[userID]; exists {
+		return bucket
+	}
+
+	bucket = &LeakyBucketRecord{
+		maxCapacity:  limiter.maxCapacity,
+		leakInterval: limiter.leakInterval,
+		lastLeakTime: time.Now(),
+	}
+	limiter.userBuckets[userID] = bucket
+	return bucket
+}
+
+// leakedState returns the queue size and last leak time the bucket would
+// have at currentTime, without changing it.
+// Caller must hold bucket.mutex.
+func (bucket *LeakyBucketRecord) leakedState(currentTime time.Time) (int, time.Time) {
+	timeSinceLastLeak := currentTime.Sub(bucket.lastLeakTime)
+	leakedCount := int(timeSinceLastLeak / bucket.leakInterval)
+	if leakedCount <= 0 {
+		return bucket.currentQueueSize, bucket.lastLeakTime
+	}
+
+	// Remove leaked requests from the queue (but don't go below 0). The leak
+	// clock moves by whole intervals, so the unfinished part of the current
+	// interval still counts towards the next leak.
+	queueSize := max(0, bucket.currentQueueSize-leakedCount)
+	return queueSize, bucket.lastLeakTime.Add(time.Duration(leakedCount) * bucket.leakInterval)
+}
+
+// leak removes requests that have "leaked" out since the last check.
+// Caller must hold bucket.mutex.
+func (bucket *LeakyBucketRecord) leak(currentTime time.Time) {
+	bucket.currentQueueSize, bucket.lastLeakTime = bucket.leakedState(currentTime)
+}
+
+// Allow checks if a request from userID should be permitted.
+func (limiter *LeakyBucketRateLimiter) Allow(userID string) bool {
+	bucket := limiter.getOrCreateBucket(userID)
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	// Calculate how many requests have "leaked" out since last check
+	bucket.leak(time.Now())
+
+	// Try to add the new request to the bucket
+	if bucket.currentQueueSize < bucket.maxCapacity {
+		bucket.currentQueueSize++
+		return true
+	}
+	return false
+}
+
+// Check returns the user's quota without adding a request to the bucket.
+// The bucket is empty again once every queued request has leaked out.
+func (limiter *LeakyBucketRateLimiter) Check(userID string) Quota {
+	limiter.mutex.RLock()
+	bucket, exists := limiter.userBuckets[userID]
+	limiter.mutex.RUnlock()
+	if !exists {
+		return fullQuota(limiter.maxCapacity)
+	}
+
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	// Leak on local copies: a Check must not change what Allow sees later
+	queueSize, lastLeakTime := bucket.leakedState(time.Now())
+
+	quota := Quota{
+		Limit:     bucket.maxCapacity,
+		Remaining: bucket.maxCapacity - queueSize,
+		ResetAt:   untilFull(lastLeakTime, queueSize, 1, bucket.leakInterval),
+	}
+	if quota.Remaining <= 0 {
+		quota.RetryAt = lastLeakTime.Add(bucket.leakInterval) // Next request leaks out
+	}
+	return quota
+}
+
+// GetName returns the algorithm name.
+func (limiter *LeakyBucketRateLimiter) GetName() string {
+	return "Leaky Bucket"
+}
+
+// ============================================================================
+// SECTION 6: COMPACT SLIDING WINDOW (Ring Buffer + Timestamp Buckets)
+// ============================================================================
+//
+// Why another Sliding Window?
+// ---------------------------
+// The Sliding Window above keeps one time.Time (24 bytes) per request and
+// rebuilds the slice on every call, so each Allow allocates and memory per
+// user grows with the limit.
+//
+// This variant makes two changes:
+// 1. Timestamp bucketing: time is cut into buckets of `granularity`, and all
+//    requests in the same bucket share one {slot, count} entry (16 bytes).
+// 2. Ring buffer: each user gets a fixed-size ring, allocated once. A user
+//    can never hold more than min(maxRequests, window/granularity + 2)
+//    entries, so memory per user has a hard cap and Allow never allocates.
+//
+// Trade-off: a request is treated as if it happened at the END of its
+// bucket, so it leaves the window up to one granularity late. The limiter
+// may reject slightly early, but never admits more than maxRequests in any
+// real window.
+//
+// Example: 100 requests per minute, granularity 1 second
+// - Plain sliding window: up to 100 timestamps  = 2400 bytes per user
+// - Compact window:       up to 62 buckets      =  992 bytes per user
+//
+// ============================================================================
+
+// DefaultWindowBuckets is how many buckets a window is split into when no
+// granularity is given.
+const DefaultWindowBuckets = 100
+
+// windowBucket counts the requests made within one granularity slot.
+type windowBucket struct {
+	slot  int64  // Bucket number: unix nanoseconds / granularity
+	count uint32 // Requests recorded in this bucket
+}
+
+// CompactWindowRecord is one user's ring buffer of request buckets.
+type CompactWindowRecord struct {
+	buckets []windowBucket // Fixed-size ring, allocated once
+	head    int            // Index of the oldest live bucket
+	size    int            // Number of live buckets
+	total   int            // Sum of counts across live buckets
+	mutex   sync.Mutex     // Protects concurrent access
+}
+
+// CompactSlidingWindowRateLimiter implements sliding window rate limiting
+// with bounded memory per user.
+type CompactSlidingWindowRateLimiter struct {
+	userWindows    map[string]*CompactWindowRecord // Map of userID -> their record
+	maxRequests    int                             // Maximum requests allowed per window
+	windowDuration time.Duration                   // Size of the sliding window
+	granularity    time.Duration                   // Width of one timestamp bucket
+	ringCapacity   int                             // Buckets per user (hard cap)
+	mutex          sync.RWMutex                    // Protects the userWindows map
+}
+
+// NewCompactSlidingWindowRateLimiter creates a compact sliding window limiter.
+// A granularity <= 0 (or larger than the window) falls back to
+// windowDuration / DefaultWindowBuckets.
+func NewCompactSlidingWindowRateLimiter(maxRequests int, windowDuration, granularity time.Duration) *CompactSlidingWindowRateLimiter {
+	if granularity <= 0 || granularity > windowDuration {
+		granularity = max(time.Nanosecond, windowDuration/DefaultWindowBuckets)
+	}
+
+	// Live buckets span at most window/granularity + 2 slots, and each live
+	// bucket holds at least one request, so neither bound can be exceeded
+	slotsPerWindow := int(windowDuration/granularity) + 2
+	ringCapacity := max(1, min(maxRequests, slotsPerWindow))
+
+	return &CompactSlidingWindowRateLimiter{
+		userWindows:    make(map[string]*CompactWindowRecord),
+		maxRequests:    maxRequests,
+		windowDuration: windowDuration,
+		granularity:    granularity,
+		ringCapacity:   ringCapacity,
+	}
+}
+
+// getOrCreateWindow retrieves or creates a compact window record for a user.
+func (limiter *CompactSlidingWindowRateLimiter) getOrCreateWindow(userID string) *CompactWindowRecord {
+	limiter.mutex.RLock()
+	window, exists := limiter.userWindows[userID]
+	limiter.mutex.RUnlock()
+
+	if exists {
+		return window
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Double-check after acquiring write lock
+	if window, exists = limiter.userWindows[userID]; exists {
+		return window
+	}
+
+	window = &CompactWindowRecord{
+		buckets: make([]windowBucket, limiter.ringCapacity),
+	}
+	limiter.userWindows[userID] = window
+	return window
+}
+
+// slotOf returns the bucket number that contains the given time.
+func (limiter *CompactSlidingWindowRateLimiter) slotOf(moment time.Time) int64 {
+	return moment.UnixNano() / int64(limiter.granularity)
+}
+
+// bucketEnd returns the end of a bucket, used as the time of its requests.
+func (limiter *CompactSlidingWindowRateLimiter) bucketEnd(slot int64) time.Time {
+	return time.Unix(0, (slot+1)*int64(limiter.granularity))
+}
+
+// evictExpired drops buckets whose requests have all left the window.
+// Caller must hold window.mutex.
+func (limiter *CompactSlidingWindowRateLimiter) evictExpired(window *CompactWindowRecord, currentTime time.Time) {
+	windowStartTime := currentTime.Add(-limiter.windowDuration)
+
+	for window.size > 0 {
+		oldest := window.buckets[window.head]
+		if limiter.bucketEnd(oldest.slot).After(windowStartTime) {
+			return
+		}
+		window.total -= int(oldest.count)
+		window.head = (window.head + 1) % len(window.buckets)
+		window.size--
+	}
+}
+
+// Allow checks if a request from userID should be permitted.
+func (limiter *CompactSlidingWindowRateLimiter) Allow(userID string) bool {
+	window := limiter.getOrCreateWindow(userID)
+	window.mutex.Lock()
+	defer window.mutex.Unlock()
+
+	currentTime := time.Now()
+	limiter.evictExpired(window, currentTime)
+
+	if window.total >= limiter.maxRequests {
+		return false
+	}
+
+	// Same bucket as the newest request: just bump its count
+	slot := limiter.slotOf(currentTime)
+	if window.size > 0 {
+		newest := &window.buckets[(window.head+window.size-1)%len(window.buckets)]
+		if newest.slot == slot {
+			newest.count++
+			window.total++
+			return true
+		}
+	}
+
+	// Ring is full: reject rather than grow (cannot happen with the
+	// capacity chosen in the constructor, but the cap is enforced anyway)
+	if window.size == len(window.buckets) {
+		return false
+	}
+
+	tail := (window.head + window.size) % len(window.buckets)
+	window.buckets[tail] = windowBucket{slot: slot, count: 1}
+	window.size++
+	window.total++
+	return true
+}
+
+// Check returns the user's quota without recording a request.
+// The full limit is back once the newest bucket slides out of the window.
+func (limiter *CompactSlidingWindowRateLimiter) Check(userID string) Quota {
+	limiter.mutex.RLock()
+	window, exists := limiter.userWindows[userID]
+	limiter.mutex.RUnlock()
+	if !exists {
+		return fullQuota(limiter.maxRequests)
+	}
+
+	window.mutex.Lock()
+	defer window.mutex.Unlock()
+
+	currentTime := time.Now()
+	limiter.evictExpired(window, currentTime)
+
+	resetAt := currentTime
+	if window.size > 0 {
+		newest := window.buckets[(window.head+window.size-1)%len(window.buckets)]
+		resetAt = limiter.bucketEnd(newest.slot).Add(limiter.windowDuration)
+	}
+
+	quota := Quota{
+		Limit:     limiter.maxRequests,
+		Remaining: max(0, limiter.maxRequests-window.total),
+		ResetAt:   resetAt,
+	}
+	// Room returns once enough of the oldest buckets slide out
+	excess := window.total - limiter.maxRequests + 1
+	for i := 0; excess > 0 && i < window.size; i++ {
+		bucket := window.buckets[(window.head+i)%len(window.buckets)]
+		excess -= int(bucket.count)
+		quota.RetryAt = limiter.bucketEnd(bucket.slot).Add(limiter.windowDuration)
+	}
+	return quota
+}
+
+// GetRingCapacity returns the hard cap on buckets stored per user.
+func (limiter *CompactSlidingWindowRateLimiter) GetRingCapacity() int {
+	return limiter.ringCapacity
+}
+
+// GetName returns the algorithm name.
+func (limiter *CompactSlidingWindowRateLimiter) GetName() string {
+	return "Compact Window"
+}
+
+// ============================================================================
+// SECTION 7: CONCURRENCY LIMITER (Max In-Flight Requests)
+// ============================================================================
+//
+// Why a concurrency limiter?
+// --------------------------
+// The algorithms above limit how OFTEN requests start. A slow endpoint can
+// still pile up: 10 requests/second that each take 5 seconds means 50
+// requests running at once. A concurrency limiter caps how many requests
+// are IN FLIGHT at the same time, whatever the rate.
+//
+// How it works:
+// - Every key (usually an endpoint) gets a semaphore: a buffered channel
+//   with one slot per allowed in-flight request
+// - Acquire puts a token in the channel, blocking while it is full; the
+//   wait ends early when the caller's context is cancelled or times out
+// - Release takes a token out, letting the next waiter in
+//
+// The two kinds of limiter work together: the rate limiter rejects clients
+// that send too much, the concurrency limiter protects the backend from
+// work that takes too long.
+//
+// ============================================================================
+
+// concurrencySlots is one key's semaphore.
+type concurrencySlots struct {
+	slots chan struct{} // Buffered channel; len = in flight, cap = limit
+}
+
+// ConcurrencyLimiter caps simultaneous in-flight requests per key.
+type ConcurrencyLimiter struct {
+	keySlots       map[string]*concurrencySlots // Map of key -> its semaphore
+	defaultLimit   int                          // Limit for keys without an override
+	endpointLimits map[string]int               // Per-key overrides (e.g. slow endpoints)
+	mutex          sync.RWMutex                 // Protects the keySlots map
+}
+
+// NewConcurrencyLimiter creates a concurrency limiter.
+// endpointLimits overrides defaultLimit for specific keys and may be nil.
+func NewConcurrencyLimiter(defaultLimit int, endpointLimits map[string]int) *ConcurrencyLimiter {
+	overrides := make(map[string]int, len(endpointLimits))
+	for key, limit := range endpointLimits {
+		overrides[key] = max(1, limit)
+	}
+	return &ConcurrencyLimiter{
+		keySlots:       make(map[string]*concurrencySlots),
+		defaultLimit:   max(1, defaultLimit),
+		endpointLimits: overrides,
+	}
+}
+
+// getOrCreateSlots retrieves or creates the semaphore for a key.
+func (limiter *ConcurrencyLimiter) getOrCreateSlots(key string) *concurrencySlots {
+	limiter.mutex.RLock()
+	semaphore, exists := limiter.keySlots[key]
+	limiter.mutex.RUnlock()
+
+	if exists {
+		return semaphore
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Double-check after acquiring write lock
+	if semaphore, exists = limiter.keySlots[key]; exists {
+		return semaphore
+	}
+
+	semaphore = &concurrencySlots{slots: make(chan struct{}, limiter.GetLimit(key))}
+	limiter.keySlots[key] = semaphore
+	return semaphore
+}
+
+// Acquire takes an in-flight slot for the key, waiting while all are busy.
+// Returns the context's error if it is done before a slot frees up.
+// Every successful Acquire must be paired with a Release.
+func (limiter *ConcurrencyLimiter) Acquire(ctx context.Context, key string) error {
+	semaphore := limiter.getOrCreateSlots(key)
+	select {
+	case semaphore.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for a free slot on %s: %w", key, ctx.Err())
+	}
+}
+
+// TryAcquire takes an in-flight slot only if one is free right now.
+func (limiter *ConcurrencyLimiter) TryAcquire(key string) bool {
+	semaphore := limiter.getOrCreateSlots(key)
+	select {
+	case semaphore.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release gives back a slot taken by Acquire or TryAcquire.
+// Returns an error if the key has nothing in flight.
+func (limiter *ConcurrencyLimiter) Release(key string) error {
+	semaphore := limiter.getOrCreateSlots(key)
+	select {
+	case <-semaphore.slots:
+		return nil
+	default:
+		return fmt.Errorf("release on %s without a matching acquire", key)
+	}
+}
+
+// GetInFlight returns how many requests currently hold a slot for the key.
+func (limiter *ConcurrencyLimiter) GetInFlight(key string) int {
+	return len(limiter.getOrCreateSlots(key).slots)
+}
+
+// GetLimit returns the maximum in-flight requests allowed for the key.
+func (limiter *ConcurrencyLimiter) GetLimit(key string) int {
+	if limit, hasOverride := limiter.endpointLimits[key]; hasOverride {
+		return limit
+	}
+	return limiter.defaultLimit
+}
+
+// GetName returns the limiter name.
+func (limiter *ConcurrencyLimiter) GetName() string {
+	return "Concurrency"
+}
+
+// ============================================================================
+// SECTION 8: QUEUEING LIMITER (Wait In Line Instead of Failing)
+// ============================================================================
+//
+// Why a queueing limiter?
+// -----------------------
+// For interactive traffic, rejecting right away is the right answer: the
+// client retries or shows an error. For batch jobs the caller would rather
+// WAIT a little for capacity than handle a rejection and build its own
+// retry loop.
+//
+// How it works:
+// - Wraps any RateLimiter; requests that would be rejected join a FIFO
+//   queue for their key instead
+// - Each key's queue is bounded; a full queue rejects at once (ErrQueueFull)
+// - A per-key dispatcher goroutine retries the wrapped limiter and grants
+//   capacity to the HEAD of the queue, so waiters are served in arrival order
+// - A waiter gives up after maxWait (ErrQueueTimeout) or when its context
+//   is done, and leaves the queue
+//
+// Fairness:
+// - New requests never jump the queue: while a key has waiters, even a
+//   request that would fit goes to the back of the line
+// - Keys have separate queues, so one busy tenant cannot delay another
+//
+// ============================================================================
+
+// ErrQueueFull is returned when a key's wait queue has no room left.
+var ErrQueueFull = errors.New("wait queue is full")
+
+// ErrQueueTimeout is returned when a request waited maxWait without a grant.
+var ErrQueueTimeout = errors.New("timed out waiting in queue")
+
+// queuePollInterval caps how long a dispatcher sleeps before retrying the
+// wrapped limiter. Quota.ResetAt is when the quota is FULL again, which can
+// be much later than the next free slot.
+const queuePollInterval = 5 * time.Millisecond
+
+// queuedRequest is one waiter in a key's queue.
+type queuedRequest struct {
+	granted chan struct{} // Closed by the dispatcher when the request may proceed
+}
+
+// requestQueue is one key's FIFO of waiters.
+type requestQueue struct {
+	waiters           []*queuedRequest // Oldest first
+	dispatcherRunning bool             // True while a dispatcher goroutine serves this key
+}
+
+// QueueingStats counts how requests through a QueueingLimiter ended.
+type QueueingStats struct {
+	Immediate int // Allowed without waiting
+	Granted   int // Allowed after waiting in the queue
+	TimedOut  int // Gave up after maxWait
+	Cancelled int // Caller's context was done first
+	QueueFull int // Rejected because the queue had no room
+}
+
+// QueueingLimiter lets rate-limited requests wait in a bounded FIFO per key.
+type QueueingLimiter struct {
+	limiter        RateLimiter              // The wrapped rate limiting strategy
+	maxQueueLength int                      // Waiters allowed per key
+	maxWait        time.Duration            // Longest a waiter stays in the queue
+	queues         map[string]*requestQueue // Map of key -> its wait queue
+	stats          QueueingStats            // Outcome counters
+	mutex          sync.Mutex               // Protects queues and stats
+}
+
+// NewQueueingLimiter wraps limiter so rejected requests can wait up to
+// maxWait in a queue of at most maxQueueLength waiters per key.
+func NewQueueingLimiter(limiter RateLimiter, maxQueueLength int, maxWait time.Duration) *QueueingLimiter {
+	return &QueueingLimiter{
+		limiter:        limiter,
+		maxQueueLength: max(0, maxQueueLength),
+		maxWait:        maxWait,
+		queues:         make(map[string]*requestQueue),
+	}
+}
+
+// getOrCreateQueue retrieves or creates the wait queue for a key.
+// Caller must hold limiter.mutex.
+func (limiter *QueueingLimiter) getOrCreateQueue(key string) *requestQueue {
+	queue, exists := limiter.queues[key]
+	if !exists {
+		queue = &requestQueue{}
+		limiter.queues[key] = queue
+	}
+	return queue
+}
+
+// Allow permits a request only if it can run right now without waiting.
+// It never jumps ahead of requests already queued for the key.
+func (limiter *QueueingLimiter) Allow(key string) bool {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if queue, exists := limiter.queues[key]; exists && len(queue.waiters) > 0 {
+		return false
+	}
+	if !limiter.limiter.Allow(key) {
+		return false
+	}
+	limiter.stats.Immediate++
+	return true
+}
+
+// Check reports the wrapped limiter's quota for the key.
+func (limiter *QueueingLimiter) Check(key string) Quota {
+	return limiter.limiter.Check(key)
+}
+
+// GetName returns the limiter name, including the wrapped algorithm.
+func (limiter *QueueingLimiter) GetName() string {
+	return "Queueing(" + limiter.limiter.GetName() + ")"
+}
+
+// Wait blocks until the request for key is allowed.
+// Returns nil once granted, ErrQueueFull if the key's queue has no room,
+// ErrQueueTimeout after maxWait, or the context's error if ctx is done first.
+func (limiter *QueueingLimiter) Wait(ctx context.Context, key string) error {
+	limiter.mutex.Lock()
+	queue := limiter.getOrCreateQueue(key)
+
+	// Fast path: nobody is waiting and the wrapped limiter has room
+	if len(queue.waiters) == 0 && limiter.limiter.Allow(key) {
+		limiter.stats.Immediate++
+		limiter.mutex.Unlock()
+		return nil
+	}
+
+	if len(queue.waiters) >= limiter.maxQueueLength {
+		waiting := len(queue.waiters)
+		limiter.stats.QueueFull++
+		limiter.mutex.Unlock()
+		return fmt.Errorf("%s: %w (%d waiting)", key, ErrQueueFull, waiting)
+	}
+
+	request := &queuedRequest{granted: make(chan struct{})}
+	queue.waiters = append(queue.waiters, request)
+	if !queue.dispatcherRunning {
+		queue.dispatcherRunning = true
+		go limiter.dispatch(key, queue)
+	}
+	limiter.mutex.Unlock()
+
+	timer := time.NewTimer(limiter.maxWait)
+	defer timer.Stop()
+
+	select {
+	case <-request.granted:
+		return nil
+	case <-timer.C:
+		if limiter.leaveQueue(queue, request, &limiter.stats.TimedOut) {
+			return fmt.Errorf("%s: %w after %v", key, ErrQueueTimeout, limiter.maxWait)
+		}
+	case <-ctx.Done():
+		if limiter.leaveQueue(queue, request, &limiter.stats.Cancelled) {
+			return fmt.Errorf("waiting in queue for %s: %w", key, ctx.Err())
+		}
+	}
+	// The grant raced the timeout; the capacity is already ours
+	return nil
+}
+
+// leaveQueue removes a waiter that gave up and bumps the matching counter.
+// Returns false if the dispatcher granted the request first.
+func (limiter *QueueingLimiter) leaveQueue(queue *requestQueue, request *queuedRequest, counter *int) bool {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	for i, waiter := range queue.waiters {
+		if waiter == request {
+			queue.waiters = append(queue.waiters[:i], queue.waiters[i+1:]...)
+			*counter++
+			return true
+		}
+	}
+	return false
+}
+
+// dispatch grants capacity to the key's waiters in FIFO order.
+// Runs until the queue is empty.
+func (limiter *QueueingLimiter) dispatch(key string, queue *requestQueue) {
+	for {
+		limiter.mutex.Lock()
+		if len(queue.waiters) == 0 {
+			queue.dispatcherRunning = false
+			limiter.mutex.Unlock()
+			return
+		}
+
+		if limiter.limiter.Allow(key) {
+			head := queue.waiters[0]
+			queue.waiters = queue.waiters[1:]
+			limiter.stats.Granted++
+			close(head.granted)
+			limiter.mutex.Unlock()
+			continue
+		}
+		limiter.mutex.Unlock()
+
+		// Sleep until the quota resets, but re-check at least every poll interval
+		sleep := min(time.Until(limiter.limiter.Check(key).ResetAt), queuePollInterval)
+		time.Sleep(max(sleep, time.Millisecond))
+	}
+}
+
+// GetQueueLength returns how many requests are waiting for the key.
+func (limiter *QueueingLimiter) GetQueueLength(key string) int {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if queue, exists := limiter.queues[key]; exists {
+		return len(queue.waiters)
+	}
+	return 0
+}
+
+// GetStats returns a snapshot of the outcome counters.
+func (limiter *QueueingLimiter) GetStats() QueueingStats {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	return limiter.stats
+}
+
+// ============================================================================
+// SECTION 9: MULTI-WINDOW POLICIES (e.g. 10/min AND 100/hour)
+// ============================================================================
+//
+// Why several windows?
+// --------------------
+// One window can't express both "no bursts" and "no sustained abuse":
+// - 10 per minute alone still allows 14,400 per day
+// - 100 per hour alone lets a client spend the whole hour in one second
+// A MultiWindowRateLimiter attaches several rules to every key, and a
+// request is allowed only if EVERY rule has room.
+//
+// How it works:
+// - One sliding-window log per key, kept for the LONGEST rule's window
+// - Each rule counts the timestamps inside its own window (binary search
+//   on the sorted log), so all rules see exactly the same history
+// - All rules are evaluated under the key's lock and the request is
+//   recorded only if none of them is exceeded: a rejected request never
+//   uses up another rule's budget
+// - Evaluate reports which rules were violated and when the request would
+//   succeed, so clients get a meaningful Retry-After
+//
+// ============================================================================
+
+// WindowRule is one limit in a multi-window policy.
+type WindowRule struct {
+	Name   string        // Label reported on violation (e.g. "burst")
+	Limit  int           // Maximum requests inside the window
+	Window time.Duration // Length of the sliding window
+}
+
+// String formats the rule as "burst (10 per 1m0s)".
+func (rule WindowRule) String() string {
+	return fmt.Sprintf("%s (%d per %v)", rule.Name, rule.Limit, rule.Window)
+}
+
+// MultiWindowDecision is the outcome of evaluating every rule for a request.
+type MultiWindowDecision struct {
+	Allowed  bool         // True if every rule had room (the request was recorded)
+	Violated []WindowRule // Rules that rejected the request, in policy order
+	RetryAt  time.Time    // When all violated rules have room again (zero if allowed)
+}
+
+// RuleQuota is one rule's quota for a key.
+type RuleQuota struct {
+	Rule  WindowRule
+	Quota Quota
+}
+
+// multiWindowRecord stores one key's request log.
+type multiWindowRecord struct {
+	requestTimestamps []time.Time // Sorted oldest first, trimmed to the longest window
+	mutex             sync.Mutex  // Makes evaluating all rules atomic
+}
+
+// MultiWindowRateLimiter enforces several sliding-window rules per key.
+type MultiWindowRateLimiter struct {
+	rules         []WindowRule                  // Policy, in the order given
+	longestWindow time.Duration                 // How much history a log must keep
+	records       map[string]*multiWindowRecord // Map of key -> its request log
+	mutex         sync.RWMutex                  // Protects the records map
+}
+
+// NewMultiWindowRateLimiter creates a limiter that enforces all the rules.
+// Rules without a name are named after their limit and window.
+func NewMultiWindowRateLimiter(rules ...WindowRule) (*MultiWindowRateLimiter, error) {
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("multi-window policy needs at least one rule")
+	}
+
+	limiter := &MultiWindowRateLimiter{
+		rules:   make([]WindowRule, 0, len(rules)),
+		records: make(map[string]*multiWindowRecord),
+	}
+	for _, rule := range rules {
+		if rule.Limit <= 0 || rule.Window <= 0 {
+			return nil, fmt.Errorf("rule %s: limit and window must be positive", rule)
+		}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("%d/%v", rule.Limit, rule.Window)
+		}
+		limiter.rules = append(limiter.rules, rule)
+		limiter.longestWindow = max(limiter.longestWindow, rule.Window)
+	}
+	return limiter, nil
+}
+
+// getOrCreateRecord retrieves or creates the request log for a key.
+func (limiter *MultiWindowRateLimiter) getOrCreateRecord(key string) *multiWindowRecord {
+	limiter.mutex.RLock()
+	record, exists := limiter.records[key]
+	limiter.mutex.RUnlock()
+
+	if exists {
+		return record
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Double-check after acquiring write lock
+	if record, exists = limiter.records[key]; exists {
+		return record
+	}
+
+	record = &multiWindowRecord{requestTimestamps: make([]time.Time, 0)}
+	limiter.records[key] = record
+	return record
+}
+
+// evictExpired drops timestamps older than the longest window.
+// Caller must hold record.mutex.
+func (limiter *MultiWindowRateLimiter) evictExpired(record *multiWindowRecord, currentTime time.Time) {
+	firstKept := limiter.firstInWindow(record, currentTime, limiter.longestWindow)
+	record.requestTimestamps = append(record.requestTimestamps[:0], record.requestTimestamps[firstKept:]...)
+}
+
+// firstInWindow returns the index of the oldest timestamp inside the window
+// ending at currentTime. Caller must hold record.mutex.
+func (limiter *MultiWindowRateLimiter) firstInWindow(record *multiWindowRecord, currentTime time.Time, window time.Duration) int {
+	windowStartTime := currentTime.Add(-window)
+	return sort.Search(len(record.requestTimestamps), func(i int) bool {
+		return record.requestTimestamps[i].After(windowStartTime)
+	})
+}
+
+// Evaluate checks every rule and records the request only if all allow it.
+func (limiter *MultiWindowRateLimiter) Evaluate(key string) MultiWindowDecision {
+	record := limiter.getOrCreateRecord(key)
+	record.mutex.Lock()
+	defer record.mutex.Unlock()
+
+	currentTime := time.Now()
+	limiter.evictExpired(record, currentTime)
+
+	decision := MultiWindowDecision{Violated: make([]WindowRule, 0)}
+	for _, rule := range limiter.rules {
+		first := limiter.firstInWindow(record, currentTime, rule.Window)
+		count := len(record.requestTimestamps) - first
+		if count < rule.Limit {
+			continue
+		}
+		// Room returns when enough of the oldest requests slide out
+		freedAt := record.requestTimestamps[first+count-rule.Limit].Add(rule.Window)
+		decision.Violated = append(decision.Violated, rule)
+		if freedAt.After(decision.RetryAt) {
+			decision.RetryAt = freedAt
+		}
+	}
+
+	if len(decision.Violated) == 0 {
+		record.requestTimestamps = append(record.requestTimestamps, currentTime)
+		decision.Allowed = true
+	}
+	return decision
+}
+
+// Allow checks if a request from key should be permitted under every rule.
+func (limiter *MultiWindowRateLimiter) Allow(key string) bool {
+	return limiter.Evaluate(key).Allowed
+}
+
+// CheckRules returns every rule's quota for the key without recording a request.
+func (limiter *MultiWindowRateLimiter) CheckRules(key string) []RuleQuota {
+	limiter.mutex.RLock()
+	record, exists := limiter.records[key]
+	limiter.mutex.RUnlock()
+
+	quotas := make([]RuleQuota, 0, len(limiter.rules))
+	if !exists {
+		for _, rule := range limiter.rules {
+			quotas = append(quotas, RuleQuota{Rule: rule, Quota: fullQuota(rule.Limit)})
+		}
+		return quotas
+	}
+
+	record.mutex.Lock()
+	defer record.mutex.Unlock()
+
+	currentTime := time.Now()
+	limiter.evictExpired(record, currentTime)
+
+	for _, rule := range limiter.rules {
+		first := limiter.firstInWindow(record, currentTime, rule.Window)
+		count := len(record.requestTimestamps) - first
+		quota := Quota{Limit: rule.Limit, Remaining: max(0, rule.Limit-count), ResetAt: currentTime}
+		if count > 0 {
+			quota.ResetAt = record.requestTimestamps[len(record.requestTimestamps)-1].Add(rule.Window)
+		}
+		if count >= rule.Limit {
+			// Same as Evaluate: room returns when enough of the oldest requests slide out
+			quota.RetryAt = record.requestTimestamps[first+count-rule.Limit].Add(rule.Window)
+		}
+		quotas = append(quotas, RuleQuota{Rule: rule, Quota: quota})
+	}
+	return quotas
+}
+
+// Check returns the quota of the most restrictive rule (fewest requests
+// remaining; the later reset on a tie), which is what a client can rely on.
+// RetryAt is the latest of the exhausted rules' RetryAt, since every rule
+// must have room before a request is allowed.
+func (limiter *MultiWindowRateLimiter) Check(key string) Quota {
+	quotas := limiter.CheckRules(key)
+	tightest := quotas[0].Quota
+	retryAt := tightest.RetryAt
+	for _, ruleQuota := range quotas[1:] {
+		quota := ruleQuota.Quota
+		if quota.Remaining < tightest.Remaining ||
+			(quota.Remaining == tightest.Remaining && quota.ResetAt.After(tightest.ResetAt)) {
+			tightest = quota
+		}
+		if quota.RetryAt.After(retryAt) {
+			retryAt = quota.RetryAt
+		}
+	}
+	tightest.RetryAt = retryAt
+	return tightest
+}
+
+// GetName returns the algorithm name with its rules.
+func (limiter *MultiWindowRateLimiter) GetName() string {
+	names := make([]string, 0, len(limiter.rules))
+	for _, rule := range limiter.rules {
+		names = append(names, rule.Name)
+	}
+	return "Multi Window (" + strings.Join(names, " + ") + ")"
+}
+
+// ============================================================================
+// SECTION 10: REQUEST IDENTITY (Key Extractors)
+// ============================================================================
+//
+// A limiter counts requests per key, but what the key should be depends on
+// the API: anonymous traffic is limited per client IP, partner APIs per API
+// key, logged-in users per JWT subject. A KeyExtractor turns an incoming
+// Request into that key (Strategy Pattern again), so the same limiters serve
+// every identification scheme.
+//
+// Keys are prefixed with their scheme ("ip:", "user:", "apikey:", ...) so
+// one limiter can be shared by several extractors without collisions.
+// CompositeKeyExtractor joins several keys, e.g. user + endpoint to give
+// every user a separate budget per endpoint.
+//
+// ============================================================================
+
+// ErrNoRateLimitKey is returned when a request carries no usable identity.
+var ErrNoRateLimitKey = errors.New("request has no rate limit key")
+
+// Request is the part of an incoming HTTP request the gateway looks at.
+type Request struct {
+	RemoteAddr string            // "ip:port" of the TCP peer
+	Endpoint   string            // Request path, e.g. "/api/orders"
+	Headers    map[string]string // Canonical header names, e.g. "X-Api-Key"
+	UserID     string            // Set by an upstream auth layer ("" if anonymous)
+}
+
+// NewUserRequest creates a request from an already authenticated user.
+func NewUserRequest(userID string, endpoint string) *Request {
+	return &Request{Endpoint: endpoint, Headers: map[string]string{}, UserID: userID}
+}
+
+// GetHeader returns a header value ("" if absent).
+func (request *Request) GetHeader(name string) string {
+	return request.Headers[name]
+}
+
+// KeyExtractor resolves the rate limit key of a request.
+type KeyExtractor interface {
+	// ExtractKey returns the key, or an error wrapping ErrNoRateLimitKey
+	// when the request carries no usable identity.
+	ExtractKey(request *Request) (string, error)
+
+	// GetName returns the scheme name for logging purposes.
+	GetName() string
+}
+
+// ----------------------------------------------------------------------------
+// User ID (already authenticated upstream)
+// ----------------------------------------------------------------------------
+
+// UserKeyExtractor keys requests by Request.UserID. It is the gateway default.
+type UserKeyExtractor struct{}
+
+// ExtractKey returns "user:<id>".
+func (extractor UserKeyExtractor) ExtractKey(request *Request) (string, error) {
+	if request.UserID == "" {
+		return "", fmt.Errorf("%w: anonymous request", ErrNoRateLimitKey)
+	}
+	return "user:" + request.UserID, nil
+}
+
+// GetName returns the scheme name.
+func (extractor UserKeyExtractor) GetName() string { return "User" }
+
+// ----------------------------------------------------------------------------
+// Client IP
+// ----------------------------------------------------------------------------
+
+// IPKeyExtractor keys requests by client IP.
+// X-Forwarded-For is only honoured behind a trusted proxy: anyone can send
+// the header, so trusting it blindly lets clients pick their own key.
+type IPKeyExtractor struct {
+	TrustForwardedFor bool // Use the first X-Forwarded-For hop if present
+}
+
+// ExtractKey returns "ip:<address>".
+func (extractor IPKeyExtractor) ExtractKey(request *Request) (string, error) {
+	if extractor.TrustForwardedFor {
+		if forwarded := request.GetHeader("X-Forwarded-For"); forwarded != "" {
+			client := strings.TrimSpace(strings.Split(forwarded, ",")[0])
+			if net.ParseIP(client) != nil {
+				return "ip:" + client, nil
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr // No port
+	}
+	if net.ParseIP(host) == nil {
+		return "", fmt.Errorf("%w: invalid remote address %q", ErrNoRateLimitKey, request.RemoteAddr)
+	}
+	return "ip:" + host, nil
+}
+
+// GetName returns the scheme name.
+func (extractor IPKeyExtractor) GetName() string { return "IP" }
+
+// ----------------------------------------------------------------------------
+// Arbitrary header (tenant ID, device ID, ...)
+// ----------------------------------------------------------------------------
+
+// HeaderKeyExtractor keys requests by the value of one header.
+type HeaderKeyExtractor struct {
+	Header string
+}
+
+// ExtractKey returns "header:<name>=<value>".
+func (extractor HeaderKeyExtractor) ExtractKey(request *Request) (string, error) {
+	value := request.GetHeader(extractor.Header)
+	if value == "" {
+		return "", fmt.Errorf("%w: missing %s header", ErrNoRateLimitKey, extractor.Header)
+	}
+	return "header:" + extractor.Header + "=" + value, nil
+}
+
+// GetName returns the scheme name.
+func (extractor HeaderKeyExtractor) GetName() string { return "Header(" + extractor.Header + ")" }
+
+// ----------------------------------------------------------------------------
+// API key
+// ----------------------------------------------------------------------------
+
+// APIKeyExtractor resolves an API key header to the client that owns it,
+// so a client with several keys shares one budget. Unknown keys are rejected.
+type APIKeyExtractor struct {
+	header  string
+	clients map[string]string // API key -> client ID
+	mutex   sync.RWMutex
+}
+
+// NewAPIKeyExtractor reads keys from header ("X-Api-Key" if empty).
+func NewAPIKeyExtractor(header string) *APIKeyExtractor {
+	if header == "" {
+		header = "X-Api-Key"
+	}
+	return &APIKeyExtractor{header: header, clients: make(map[string]string)}
+}
+
+// RegisterKey assigns an API key to a client.
+func (extractor *APIKeyExtractor) RegisterKey(apiKey, clientID string) {
+	extractor.mutex.Lock()
+	defer extractor.mutex.Unlock()
+	extractor.clients[apiKey] = clientID
+}
+
+// RevokeKey removes an API key.
+func (extractor *APIKeyExtractor) RevokeKey(apiKey string) {
+	extractor.mutex.Lock()
+	defer extractor.mutex.Unlock()
+	delete(extractor.clients, apiKey)
+}
+
+// ExtractKey returns "apikey:<client ID>".
+func (extractor *APIKeyExtractor) ExtractKey(request *Request) (string, error) {
+	apiKey := request.GetHeader(extractor.header)
+	if apiKey == "" {
+		return "", fmt.Errorf("%w: missing %s header", ErrNoRateLimitKey, extractor.header)
+	}
+
+	extractor.mutex.RLock()
+	clientID, known := extractor.clients[apiKey]
+	extractor.mutex.RUnlock()
+	if !known {
+		return "", fmt.Errorf("%w: unknown API key", ErrNoRateLimitKey)
+	}
+	return "apikey:" + clientID, nil
+}
+
+// GetName returns the scheme name.
+func (extractor *APIKeyExtractor) GetName() string { return "APIKey" }
+
+// ----------------------------------------------------------------------------
+// JWT subject
+// ----------------------------------------------------------------------------
+
+// JWTSubjectExtractor keys requests by the "sub" claim of an HS256 bearer
+// token. The signature and expiry are verified first; otherwise a client
+// could mint tokens with any subject and dodge its limit.
+type JWTSubjectExtractor struct {
+	secret []byte
+	now    func() time.Time // Clock for expiry checks
+}
+
+// NewJWTSubjectExtractor verifies tokens with the shared HS256 secret.
+func NewJWTSubjectExtractor(secret []byte) *JWTSubjectExtractor {
+	return &JWTSubjectExtractor{secret: secret, now: time.Now}
+}
+
+// jwtClaims are the claims the extractor reads.
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp,omitempty"` // Unix seconds (0 = no expiry)
+}
+
+// ExtractKey returns "jwt:<subject>".
+func (extractor *JWTSubjectExtractor) ExtractKey(request *Request) (string, error) {
+	authorization := request.GetHeader("Authorization")
+	token, isBearer := strings.CutPrefix(authorization, "Bearer ")
+	if !isBearer || token == "" {
+		return "", fmt.Errorf("%w: missing bearer token", ErrNoRateLimitKey)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%w: malformed JWT", ErrNoRateLimitKey)
+	}
+	mac := hmac.New(sha256.New, extractor.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", fmt.Errorf("%w: invalid JWT signature", ErrNoRateLimitKey)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed JWT payload", ErrNoRateLimitKey)
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("%w: malformed JWT claims", ErrNoRateLimitKey)
+	}
+	if claims.ExpiresAt != 0 && extractor.now().Unix() >= claims.ExpiresAt {
+		return "", fmt.Errorf("%w: JWT expired", ErrNoRateLimitKey)
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: JWT has no subject", ErrNoRateLimitKey)
+	}
+	return "jwt:" + claims.Subject, nil
+}
+
+// GetName returns the scheme name.
+func (extractor *JWTSubjectExtractor) GetName() string { return "JWT" }
+
+// ----------------------------------------------------------------------------
+// Endpoint and composite keys
+// ----------------------------------------------------------------------------
+
+// EndpointKeyExtractor keys requests by endpoint. Alone it gives each
+// endpoint one global budget; in a composite it splits another key by endpoint.
+type EndpointKeyExtractor struct{}
+
+// ExtractKey returns "endpoint:<path>".
+func (extractor EndpointKeyExtractor) ExtractKey(request *Request) (string, error) {
+	if request.Endpoint == "" {
+		return "", fmt.Errorf("%w: missing endpoint", ErrNoRateLimitKey)
+	}
+	return "endpoint:" + request.Endpoint, nil
+}
+
+// GetName returns the scheme name.
+func (extractor EndpointKeyExtractor) GetName() string { return "Endpoint" }
+
+// CompositeKeyExtractor joins the keys of several extractors with "|",
+// e.g. "user:alice|endpoint:/api/search". Every part must resolve.
+type CompositeKeyExtractor struct {
+	parts []KeyExtractor
+}
+
+// NewCompositeKeyExtractor combines extractors in order.
+func NewCompositeKeyExtractor(parts ...KeyExtractor) *CompositeKeyExtractor {
+	return &CompositeKeyExtractor{parts: parts}
+}
+
+// ExtractKey returns the joined key.
+func (extractor *CompositeKeyExtractor) ExtractKey(request *Request) (string, error) {
+	keys := make([]string, 0, len(extractor.parts))
+	for _, part := range extractor.parts {
+		key, err := part.ExtractKey(request)
+		if err != nil {
+			return "", err
+		}
+		keys = append(keys, key)
+	}
+	return strings.Join(keys, "|"), nil
+}
+
+// GetName returns the scheme names joined with "+".
+func (extractor *CompositeKeyExtractor) GetName() string {
+	names := make([]string, 0, len(extractor.parts))
+	for _, part := range extractor.parts {
+		names = append(names, part.GetName())
+	}
+	return strings.Join(names, "+")
+}
+
+// FirstKeyExtractor tries extractors in order and uses the first that
+// resolves, e.g. JWT subject for logged-in users, else the client IP.
+type FirstKeyExtractor struct {
+	candidates []KeyExtractor
+}
+
+// NewFirstKeyExtractor tries candidates in order.
+func NewFirstKeyExtractor(candidates ...KeyExtractor) *FirstKeyExtractor {
+	return &FirstKeyExtractor{candidates: candidates}
+}
+
+// ExtractKey returns the first key that resolves, or the last error.
+func (extractor *FirstKeyExtractor) ExtractKey(request *Request) (string, error) {
+	lastErr := fmt.Errorf("%w: no extractors configured", ErrNoRateLimitKey)
+	for _, candidate := range extractor.candidates {
+		key, err := candidate.ExtractKey(request)
+		if err == nil {
+			return key, nil
+		}
+		lastErr = err
+	}
+	return "", lastErr
+}
+
+// GetName returns the scheme names joined with "|".
+func (extractor *FirstKeyExtractor) GetName() string {
+	names := make([]string, 0, len(extractor.candidates))
+	for _, candidate := range extractor.candidates {
+		names = append(names, candidate.GetName())
+	}
+	return strings.Join(names, "|")
+}
+
+// ============================================================================
+// SECTION 11: API GATEWAY (Client that uses Rate Limiter)
+// ============================================================================
+//
+// The API Gateway is a common component that sits between clients and backend
+// services. It uses a rate limiter to protect the backend.
+//
+// This demonstrates the Strategy Pattern:
+// - APIGateway depends on the RateLimiter interface, not concrete implementations
+// - We can swap different rate limiting algorithms without changing APIGateway
+//
+// The gateway asks its KeyExtractor whom a request belongs to (user ID by
+// default) and rate limits that key. Requests without a usable identity are
+// rejected.
+//
+// Optionally, a ConcurrencyLimiter caps in-flight requests per endpoint.
+// ServeRequest applies the rate limit first (cheap rejection of noisy
+// clients), then waits for a free slot on the endpoint.
+//
+// ============================================================================
+
+// APIGateway handles incoming requests and applies rate limiting.
+type APIGateway struct {
+	rateLimiter        RateLimiter         // The rate limiting strategy (can be any algorithm)
+	keyExtractor       KeyExtractor        // Decides whom a request is counted against
+	concurrencyLimiter *ConcurrencyLimiter // Optional cap on in-flight requests per endpoint
+}
+
+// NewAPIGateway creates a new API Gateway with the specified rate limiter.
+// Requests are keyed by user ID until SetKeyExtractor says otherwise.
+func NewAPIGateway(rateLimiter RateLimiter) *APIGateway {
+	return &APIGateway{
+		rateLimiter:  rateLimiter,
+		keyExtractor: UserKeyExtractor{},
+	}
+}
+
+// SetKeyExtractor changes how requests are identified.
+func (gateway *APIGateway) SetKeyExtractor(keyExtractor KeyExtractor) {
+	gateway.keyExtractor = keyExtractor
+}
+
+// HandleRequest processes an incoming request.
+// It resolves the request's key, then checks if the rate limiter allows it.
+func (gateway *APIGateway) HandleRequest(request *Request) {
+	key, err := gateway.keyExtractor.ExtractKey(request)
+	if err != nil {
+		fmt.Printf("⛔ [%s] Request REJECTED: %s (%v)\n",
+			gateway.keyExtractor.GetName(), request.Endpoint, err)
+		return
+	}
+
+	if gateway.rateLimiter.Allow(key) {
+		fmt.Printf("✅ [%s] Request ALLOWED for %s: %s\n",
+			gateway.rateLimiter.GetName(), key, request.Endpoint)
+	} else {
+		fmt.Printf("❌ [%s] Request REJECTED for %s: %s (rate limited)\n",
+			gateway.rateLimiter.GetName(), key, request.Endpoint)
+	}
+}
+
+// SetConcurrencyLimiter enables (or, with nil, disables) the in-flight cap.
+func (gateway *APIGateway) SetConcurrencyLimiter(concurrencyLimiter *ConcurrencyLimiter) {
+	gateway.concurrencyLimiter = concurrencyLimiter
+}
+
+// ServeRequest runs handler for a request.
+// The request is rejected if it has no key, if its key is rate limited, or
+// if no in-flight slot for the endpoint frees up before ctx is done.
+func (gateway *APIGateway) ServeRequest(ctx context.Context, request *Request, handler func()) error {
+	key, err := gateway.keyExtractor.ExtractKey(request)
+	if err != nil {
+		return err
+	}
+	if !gateway.rateLimiter.Allow(key) {
+		return fmt.Errorf("%s rate limited by %s", key, gateway.rateLimiter.GetName())
+	}
+
+	if gateway.concurrencyLimiter != nil {
+		if err := gateway.concurrencyLimiter.Acquire(ctx, request.Endpoint); err != nil {
+			return err
+		}
+		defer gateway.concurrencyLimiter.Release(request.Endpoint)
+	}
+
+	handler()
+	return nil
+}
+
+// RateLimitHeaders returns the standard rate-limit response headers for a
+// request's key. Uses Check, so reading the headers never costs a request.
+// Retry-After is only set when rejected is true (the 429 response), as the
+// whole seconds until the next request would be allowed.
+func (gateway *APIGateway) RateLimitHeaders(request *Request, rejected bool) (map[string]string, error) {
+	key, err := gateway.keyExtractor.ExtractKey(request)
+	if err != nil {
+		return nil, err
+	}
+	quota := gateway.rateLimiter.Check(key)
+
+	headers := map[string]string{
+		"X-RateLimit-Limit":     strconv.Itoa(quota.Limit),
+		"X-RateLimit-Remaining": strconv.Itoa(quota.Remaining),
+		"X-RateLimit-Reset":     strconv.FormatInt(quota.ResetAt.Unix(), 10),
+	}
+	if rejected {
+		// Round up: retrying a fraction of a second early would be rejected again
+		retryAfter := max(0, time.Until(quota.RetryAt))
+		headers["Retry-After"] = strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+	}
+	return headers, nil
+}
+
+// ============================================================================
+// SECTION 12: JITTERED RESETS (Thundering Herd Smoothing)
+// ============================================================================
+//
+// The Problem:
+// ------------
+// After a deploy or an outage, thousands of clients reconnect in the same
+// second. Their windows open together, they all hit the limit together, and
+// they all get a fresh quota at the same instant one window later. The
+// backend sees a spike of clients*limit requests, then silence, then another
+// spike - aggregate throughput is a square wave even though every single
+// client is within its limit.
+//
+// The Fix:
+// --------
+// Give every key a fixed offset in [0, maxJitter) derived from a hash of the
+// key, and shift that key's refills/window resets by it:
+// - Fixed Window: the key's first window is stretched by the offset, so all
+//   later resets happen offset later than they otherwise would
+// - Token Bucket: the key's bucket starts refilling offset later, so drained
+//   buckets earn their next token at staggered instants
+//
+// The offset is deterministic, so a key keeps the same phase across restarts
+// and replicas, and only the first window/refill is delayed - the long-run
+// rate per key is unchanged. A maxJitter of about one window spreads resets
+// evenly over the whole window.
+//
+// ============================================================================
+
+// resetJitterFor returns the key's offset in [0, maxJitter), or 0 when
+// jitter is off. FNV-1a keeps it stable across processes.
+func resetJitterFor(key string, maxJitter time.Duration) time.Duration {
+	if maxJitter <= 0 {
+		return 0
+	}
+	hasher := fnv.New64a()
+	hasher.Write([]byte(key))
+	return time.Duration(hasher.Sum64() % uint64(maxJitter))
+}
+
+// SetRefillJitter delays the first refill of each new bucket by a per-key
+// offset in [0, maxJitter). Buckets created earlier keep their phase.
+func (limiter *TokenBucketRateLimiter) SetRefillJitter(maxJitter time.Duration) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.refillJitter = max(0, maxJitter)
+}
+
+// SetResetJitter stretches the first window of each new key by a per-key
+// offset in [0, maxJitter). Windows opened earlier keep their phase.
+func (limiter *FixedWindowRateLimiter) SetResetJitter(maxJitter time.Duration) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.resetJitter = max(0, maxJitter)
+}
+
+// HerdSimulation summarises aggregate throughput of a simulated herd once
+// the first window has passed. The arrival burst itself is the same with or
+// without jitter (every client spends its first quota), so it is left out.
+type HerdSimulation struct {
+	Ticks          int     // Number of measured ticks
+	Total          int     // Requests allowed across all clients in measured ticks
+	PeakPerTick    int     // Most requests allowed in any one measured tick
+	AveragePerTick float64 // Total / Ticks
+	IdleTicks      int     // Measured ticks in which no request was allowed
+}
+
+// GetPeakToAverage returns how spiky the traffic is; 1.0 is perfectly flat.
+func (simulation HerdSimulation) GetPeakToAverage() float64 {
+	if simulation.AveragePerTick == 0 {
+		return 0
+	}
+	return float64(simulation.PeakPerTick) / simulation.AveragePerTick
+}
+
+// simulateResetHerd runs `clients` greedy clients that all show up at the
+// same instant against a fixed window limiter, on a virtual clock so the
+// result is deterministic. Every tick each client sends requests until one
+// is rejected; the allowed requests per tick are the backend's load.
+// Ticks inside the first window are simulated but not measured.
+func simulateResetHerd(clients, maxRequests int, window, maxJitter, tick time.Duration, ticks int) HerdSimulation {
+	limiter := NewFixedWindowRateLimiter(maxRequests, window)
+	limiter.SetResetJitter(maxJitter)
+
+	clientIDs := make([]string, clients)
+	for i := range clientIDs {
+		clientIDs[i] = "client" + strconv.Itoa(i)
+	}
+
+	firstMeasured := int(window / tick)
+	simulation := HerdSimulation{Ticks: max(1, ticks-firstMeasured)}
+	start := time.Unix(0, 0)
+	for t := 0; t < ticks; t++ {
+		currentTime := start.Add(time.Duration(t) * tick)
+		allowed := 0
+		for _, clientID := range clientIDs {
+			for limiter.allowAt(clientID, currentTime) {
+				allowed++
+			}
+		}
+		if t < firstMeasured {
+			continue
+		}
+		simulation.Total += allowed
+		simulation.PeakPerTick = max(simulation.PeakPerTick, allowed)
+		if allowed == 0 {
+			simulation.IdleTicks++
+		}
+	}
+	simulation.AveragePerTick = float64(simulation.Total) / float64(simulation.Ticks)
+	return simulation
+}
+
+// ============================================================================
+// SECTION 13: DISTRIBUTED STORAGE (Shared State Across Gateway Instances)
+// ============================================================================
+//
+// The limiters above keep state in process memory, so N gateway instances
+// behind a load balancer each allow the full limit: N times too much. The
+// distributed limiters below keep their state in a Store shared by every
+// instance instead:
+//
+//   gateway-1 ──┐
+//   gateway-2 ──┼──► Store (Redis in production, MemoryStore in tests)
+//   gateway-3 ──┘
+//
+// Store is deliberately small (Get/Set/Incr with TTL, plus CompareAndSet)
+// so any key-value backend with atomic increments can implement it:
+// - Fixed Window:   one counter per key per window (INCR, expires with the window)
+// - Sliding Window: weighted sum of the current and previous window counters,
+//                   O(1) state per key instead of a timestamp log
+// - Token Bucket:   "tokens|last refill" string updated with CompareAndSet,
+//                   retried when another instance updated it first
+//
+// Instances must share a wall clock (NTP); the in-process token bucket's
+// monotonic clock cannot be compared across machines.
+//
+// When the store is unreachable the limiters FAIL OPEN by default: an outage
+// of the rate-limit store should not become an outage of the API. Call
+// SetFailClosed(true) for endpoints where over-admitting is worse.
+
+// Store is shared key-value state for distributed rate limiting.
+// Every method must be atomic with respect to other instances.
+type Store interface {
+	// Get returns the value of key; found is false if it is missing or expired.
+	Get(ctx context.Context, key string) (value string, found bool, err error)
+
+	// Set stores value under key for ttl (0 = no expiry).
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+
+	// Incr adds 1 to the integer at key and returns the new value. A missing
+	// key starts at 0 and expires after ttl (0 = no expiry).
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+
+	// CompareAndSet stores value only if key currently holds expected
+	// ("" = key must be missing). Reports whether the value was stored.
+	CompareAndSet(ctx context.Context, key, expected, value string, ttl time.Duration) (bool, error)
+}
+
+// storeTimeout bounds each store round trip made by a distributed limiter.
+const storeTimeout = 50 * time.Millisecond
+
+// maxCompareAndSetAttempts bounds token bucket retries under contention.
+const maxCompareAndSetAttempts = 5
+
+// ----------------------------------------------------------------------------
+// MemoryStore: in-process default (single instance, tests, demos)
+// ----------------------------------------------------------------------------
+
+// memoryEntry is one value with its expiry (zero = never).
+type memoryEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// MemoryStore implements Store with a map. Instances in the same process
+// that share one MemoryStore share their limits.
+type MemoryStore struct {
+	entries map[string]memoryEntry
+	mutex   sync.Mutex
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]memoryEntry)}
+}
+
+// getLocked returns the live entry for key, dropping it if expired.
+// Caller must hold store.mutex.
+func (store *MemoryStore) getLocked(key string) (memoryEntry, bool) {
+	entry, exists := store.entries[key]
+	if exists && !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		delete(store.entries, key)
+		return memoryEntry{}, false
+	}
+	return entry, exists
+}
+
+// expiryFor converts a TTL to an absolute expiry (zero = never).
+func expiryFor(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+// Get returns the value of key.
+func (store *MemoryStore) Get(ctx context.Context, key string) (string, bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	entry, found := store.getLocked(key)
+	return entry.value, found, nil
+}
+
+// Set stores value under key for ttl.
+func (store *MemoryStore) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.entries[key] = memoryEntry{value: value, expiresAt: expiryFor(ttl)}
+	return nil
+}
+
+// Incr adds 1 to the integer at key; a new key expires after ttl.
+func (store *MemoryStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entry, found := store.getLocked(key)
+	if !found {
+		entry = memoryEntry{value: "0", expiresAt: expiryFor(ttl)}
+	}
+	count, err := strconv.ParseInt(entry.value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value at %q is not an integer", key)
+	}
+	count++
+	entry.value = strconv.FormatInt(count, 10)
+	store.entries[key] = entry
+	return count, nil
+}
+
+// CompareAndSet stores value only if key holds expected ("" = missing).
+func (store *MemoryStore) CompareAndSet(ctx context.Context, key, expected, value string, ttl time.Duration) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entry, found := store.getLocked(key)
+	if (expected == "" && found) || (expected != "" && (!found || entry.value != expected)) {
+		return false, nil
+	}
+	store.entries[key] = memoryEntry{value: value, expiresAt: expiryFor(ttl)}
+	return true, nil
+}
+
+// Len returns the number of live keys (for demos and tests).
+func (store *MemoryStore) Len() int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	live := 0
+	for key := range store.entries {
+		if _, found := store.getLocked(key); found {
+			live++
+		}
+	}
+	return live
+}
+
+// ----------------------------------------------------------------------------
+// RedisStore: shared state on a Redis server
+// ----------------------------------------------------------------------------
+// A minimal RESP2 client over one TCP connection, enough for the four Store
+// operations. Incr and CompareAndSet run as Lua scripts (EVAL), so the
+// read-modify-write and the TTL are applied atomically on the server.
+// A production gateway would use a pooled client library; the commands and
+// scripts stay the same.
+
+// redisIncrScript increments and sets the TTL only when the key is new.
+const redisIncrScript = `local n = redis.call('INCR', KEYS[1])
+if n == 1 and tonumber(ARGV[1]) > 0 then redis.call('PEXPIRE', KEYS[1], ARGV[1]) end
+return n`
+
+// redisCompareAndSetScript replaces the value only if it still matches.
+const redisCompareAndSetScript = `local current = redis.call('GET', KEYS[1])
+if (current == false and ARGV[1] == '') or current == ARGV[1] then
+  if tonumber(ARGV[3]) > 0 then redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+  else redis.call('SET', KEYS[1], ARGV[2]) end
+  return 1
+end
+return 0`
+
+// RedisStore implements Store on a Redis server.
+type RedisStore struct {
+	address     string
+	dialTimeout time.Duration
+	conn        net.Conn      // nil until first use, or after a failed round trip
+	reader      *bufio.Reader // Reads replies from conn
+	mutex       sync.Mutex    // One request/reply in flight on the connection
+}
+
+// NewRedisStore creates a store for the Redis server at address ("host:port").
+// The connection is opened on first use and reopened after errors.
+func NewRedisStore(address string, dialTimeout time.Duration) *RedisStore {
+	return &RedisStore{address: address, dialTimeout: dialTimeout}
+}
+
+// Close closes the connection to the server.
+func (store *RedisStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if store.conn == nil {
+		return nil
+	}
+	err := store.conn.Close()
+	store.conn = nil
+	return err
+}
+
+// do sends one command and reads its reply: string, int64, nil (null bulk)
+// or an error for "-ERR" replies.
+func (store *RedisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.conn == nil {
+		dialer := net.Dialer{Timeout: store.dialTimeout}
+		conn, err := dialer.DialContext(ctx, "tcp", store.address)
+		if err != nil {
+			return nil, fmt.Errorf("redis %s: %w", store.address, err)
+		}
+		store.conn = conn
+		store.reader = bufio.NewReader(conn)
+	}
+	// Always set the deadline: with no ctx deadline the zero time clears
+	// one left on the connection by an earlier call
+	deadline, _ := ctx.Deadline()
+	_ = store.conn.SetDeadline(deadline)
+	// Cancellation interrupts a blocked write or read by moving the deadline
+	// into the past; the next call sets a fresh one
+	conn := store.conn
+	stopWatching := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+	defer stopWatching()
+
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := store.conn.Write([]byte(command.String())); err != nil {
+		store.dropConnLocked()
+		return nil, store.wrapError(ctx, err)
+	}
+	reply, err := readRESPReply(store.reader)
+	var serverErr redisError
+	if err != nil && !errors.As(err, &serverErr) {
+		store.dropConnLocked() // Broken stream: the next reply would be misread
+		return nil, store.wrapError(ctx, err)
+	}
+	return reply, err
+}
+
+// wrapError reports a failed round trip, as the context's error when the
+// call was cancelled or timed out rather than the I/O timeout it caused.
+func (store *RedisStore) wrapError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = ctxErr
+	}
+	return fmt.Errorf("redis %s: %w", store.address, err)
+}
+
+// dropConnLocked discards a connection whose stream can no longer be trusted.
+// Caller must hold store.mutex.
+func (store *RedisStore) dropConnLocked() {
+	_ = store.conn.Close()
+	store.conn = nil
+}
+
+// redisError is an error reply sent by the server ("-ERR ...").
+type redisError string
+
+func (err redisError) Error() string { return "redis: " + string(err) }
+
+// readRESPReply reads one RESP2 reply of the types the store uses.
+func readRESPReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+': // Simple string, e.g. "+OK"
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$': // Bulk string; "$-1" is nil
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length < 0 {
+			return nil, err
+		}
+		payload := make([]byte, length+2) // Value plus trailing CRLF
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return nil, err
+		}
+		return string(payload[:length]), nil
+	}
+	return nil, fmt.Errorf("redis: unsupported reply %q", line)
+}
+
+// ttlMillis formats a TTL for PX/PEXPIRE ("0" = no expiry).
+func ttlMillis(ttl time.Duration) string {
+	if ttl <= 0 {
+		return "0"
+	}
+	return strconv.FormatInt(ttl.Milliseconds()+1, 10) // Round up: never expire early
+}
+
+// Get returns the value of key.
+func (store *RedisStore) Get(ctx context.Context, key string) (string, bool, error) {
+	reply, err := store.do(ctx, "GET", key)
+	if err != nil || reply == nil {
+		return "", false, err
+	}
+	value, _ := reply.(string)
+	return value, true, nil
+}
+
+// Set stores value under key for ttl.
+func (store *RedisStore) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	args := []string{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", ttlMillis(ttl))
+	}
+	_, err := store.do(ctx, args...)
+	return err
+}
+
+// Incr adds 1 to the integer at key; a new key expires after ttl.
+func (store *RedisStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	reply, err := store.do(ctx, "EVAL", redisIncrScript, "1", key, ttlMillis(ttl))
+	if err != nil {
+		return 0, err
+	}
+	count, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected INCR reply %v", reply)
+	}
+	return count, nil
+}
+
+// CompareAndSet stores value only if key holds expected ("" = missing).
+func (store *RedisStore) CompareAndSet(ctx context.Context, key, expected, value string, ttl time.Duration) (bool, error) {
+	reply, err := store.do(ctx, "EVAL", redisCompareAndSetScript, "1", key, expected, value, ttlMillis(ttl))
+	if err != nil {
+		return false, err
+	}
+	return reply == int64(1), nil
+}
+
+// ----------------------------------------------------------------------------
+// Store-backed limiters
+// ----------------------------------------------------------------------------
+
+// storeGuard holds what every distributed limiter shares: the store, a key
+// prefix naming the policy, and what to do when the store fails.
+type storeGuard struct {
+	store       Store
+	prefix      string       // e.g. "rl:login"; keys are prefix:algorithm:user[:window]
+	failClosed  bool         // Reject instead of allow when the store fails
+	storeErrors atomic.Int64 // Store calls that failed
+}
+
+// SetFailClosed makes store errors reject requests instead of allowing them.
+func (guard *storeGuard) SetFailClosed(failClosed bool) {
+	guard.failClosed = failClosed
+}
+
+// GetStoreErrors returns how many store calls failed.
+func (guard *storeGuard) GetStoreErrors() int64 {
+	return guard.storeErrors.Load()
+}
+
+// onStoreError counts a failure and returns the fail-open/closed decision.
+func (guard *storeGuard) onStoreError() bool {
+	guard.storeErrors.Add(1)
+	return !guard.failClosed
+}
+
+// key builds a store key for one user and optional window index.
+func (guard *storeGuard) key(algorithm, userID string, window ...int64) string {
+	key := guard.prefix + ":" + algorithm + ":" + userID
+	for _, index := range window {
+		key += ":" + strconv.FormatInt(index, 10)
+	}
+	return key
+}
+
+// DistributedFixedWindowLimiter is the fixed window algorithm on a Store:
+// one counter per user per window, shared by every instance.
+type DistributedFixedWindowLimiter struct {
+	storeGuard
+	maxRequests    int
+	windowDuration time.Duration
+}
+
+// NewDistributedFixedWindowLimiter creates a fixed window limiter whose
+// counts live in store under prefix.
+func NewDistributedFixedWindowLimiter(store Store, prefix string, maxRequests int, windowDuration time.Duration) *DistributedFixedWindowLimiter {
+	return &DistributedFixedWindowLimiter{
+		storeGuard:     storeGuard{store: store, prefix: prefix},
+		maxRequests:    maxRequests,
+		windowDuration: windowDuration,
+	}
+}
+
+// Allow counts the request in the shared window and checks the limit.
+func (limiter *DistributedFixedWindowLimiter) Allow(userID string) bool {
+	return limiter.allowAt(userID, time.Now())
+}
+
+// allowAt is Allow evaluated at currentTime. Windows are aligned to the
+// epoch so every instance agrees on which window a moment belongs to.
+func (limiter *DistributedFixedWindowLimiter) allowAt(userID string, currentTime time.Time) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	window := currentTime.UnixNano() / int64(limiter.windowDuration)
+	count, err := limiter.store.Incr(ctx, limiter.key("fw", userID, window), limiter.windowDuration)
+	if err != nil {
+		return limiter.onStoreError()
+	}
+	return count <= int64(limiter.maxRequests)
+}
+
+// Check returns the user's quota in the current window without counting.
+func (limiter *DistributedFixedWindowLimiter) Check(userID string) Quota {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	currentTime := time.Now()
+	window := currentTime.UnixNano() / int64(limiter.windowDuration)
+	windowEnd := time.Unix(0, (window+1)*int64(limiter.windowDuration))
+	count := limiter.readCount(ctx, limiter.key("fw", userID, window))
+	quota := Quota{
+		Limit:     limiter.maxRequests,
+		Remaining: max(0, limiter.maxRequests-int(count)),
+		ResetAt:   windowEnd,
+	}
+	if quota.Remaining == 0 {
+		quota.RetryAt = windowEnd
+	}
+	return quota
+}
+
+// GetName returns the algorithm name.
+func (limiter *DistributedFixedWindowLimiter) GetName() string {
+	return "Distributed Fixed Window"
+}
+
+// readCount returns the counter at key (0 if missing or unreadable).
+func (guard *storeGuard) readCount(ctx context.Context, key string) int64 {
+	value, found, err := guard.store.Get(ctx, key)
+	if err != nil {
+		guard.storeErrors.Add(1)
+		return 0
+	}
+	if !found {
+		return 0
+	}
+	count, _ := strconv.ParseInt(value, 10, 64)
+	return count
+}
+
+// DistributedSlidingWindowLimiter approximates a sliding window with two
+// fixed-window counters: the previous window's count weighted by how much
+// of it still overlaps the sliding window, plus the current count.
+// Rejected requests are counted too, so a client hammering the API stays
+// limited instead of getting a slot every time the estimate dips.
+type DistributedSlidingWindowLimiter struct {
+	storeGuard
+	maxRequests    int
+	windowDuration time.Duration
+}
+
+// NewDistributedSlidingWindowLimiter creates a sliding window limiter whose
+// counters live in store under prefix.
+func NewDistributedSlidingWindowLimiter(store Store, prefix string, maxRequests int, windowDuration time.Duration) *DistributedSlidingWindowLimiter {
+	return &DistributedSlidingWindowLimiter{
+		storeGuard:     storeGuard{store: store, prefix: prefix},
+		maxRequests:    maxRequests,
+		windowDuration: windowDuration,
+	}
+}
+
+// Allow counts the request and checks the weighted estimate.
+func (limiter *DistributedSlidingWindowLimiter) Allow(userID string) bool {
+	return limiter.allowAt(userID, time.Now())
+}
+
+// estimate returns the weighted request count for the sliding window ending
+// at currentTime, given the current window's count.
+func (limiter *DistributedSlidingWindowLimiter) estimate(ctx context.Context, userID string, currentTime time.Time, current int64) float64 {
+	window := currentTime.UnixNano() / int64(limiter.windowDuration)
+	elapsed := float64(currentTime.UnixNano()%int64(limiter.windowDuration)) / float64(limiter.windowDuration)
+	previous := limiter.readCount(ctx, limiter.key("sw", userID, window-1))
+	return float64(previous)*(1-elapsed) + float64(current)
+}
+
+// allowAt is Allow evaluated at currentTime.
+func (limiter *DistributedSlidingWindowLimiter) allowAt(userID string, currentTime time.Time) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	window := currentTime.UnixNano() / int64(limiter.windowDuration)
+	// The counter must outlive its window: it is the "previous" one next
+	current, err := limiter.store.Incr(ctx, limiter.key("sw", userID, window), 2*limiter.windowDuration)
+	if err != nil {
+		return limiter.onStoreError()
+	}
+	return limiter.estimate(ctx, userID, currentTime, current) <= float64(limiter.maxRequests)
+}
+
+// Check returns the user's estimated quota without counting.
+// ResetAt is when the previous window stops contributing.
+func (limiter *DistributedSlidingWindowLimiter) Check(userID string) Quota {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	currentTime := time.Now()
+	window := currentTime.UnixNano() / int64(limiter.windowDuration)
+	current := limiter.readCount(ctx, limiter.key("sw", userID, window))
+	previous := limiter.readCount(ctx, limiter.key("sw", userID, window-1))
+	used := int(math.Ceil(limiter.estimate(ctx, userID, currentTime, current)))
+	return Quota{
+		Limit:     limiter.maxRequests,
+		Remaining: max(0, limiter.maxRequests-used),
+		ResetAt:   time.Unix(0, (window+2)*int64(limiter.windowDuration)),
+		RetryAt: counterRetryAt(time.Unix(0, window*int64(limiter.windowDuration)), limiter.windowDuration,
+			currentTime, int(previous), int(current), limiter.maxRequests),
+	}
+}
+
+// GetName returns the algorithm name.
+func (limiter *DistributedSlidingWindowLimiter) GetName() string {
+	return "Distributed Sliding Window"
+}
+
+// DistributedTokenBucketLimiter is the token bucket algorithm on a Store.
+// The bucket is stored as "tokens|last refill unix nanos" and updated with
+// CompareAndSet, so two instances spending the same token race and exactly
+// one of them wins; the loser re-reads and tries again.
+type DistributedTokenBucketLimiter struct {
+	storeGuard
+	maxCapacity     int
+	tokensPerRefill int
+	refillInterval  time.Duration
+	casConflicts    atomic.Int64 // CompareAndSet attempts lost to another instance
+}
+
+// NewDistributedTokenBucketLimiter creates a token bucket limiter whose
+// buckets live in store under prefix.
+func NewDistributedTokenBucketLimiter(store Store, prefix string, maxCapacity, tokensPerRefill int, refillInterval time.Duration) *DistributedTokenBucketLimiter {
+	return &DistributedTokenBucketLimiter{
+		storeGuard:      storeGuard{store: store, prefix: prefix},
+		maxCapacity:     maxCapacity,
+		tokensPerRefill: tokensPerRefill,
+		refillInterval:  refillInterval,
+	}
+}
+
+// GetConflicts returns how many updates lost a race and were retried.
+func (limiter *DistributedTokenBucketLimiter) GetConflicts() int64 {
+	return limiter.casConflicts.Load()
+}
+
+// bucketState decodes a stored bucket refilled up to currentTime.
+// A missing or malformed value is a full bucket.
+func (limiter *DistributedTokenBucketLimiter) bucketState(value string, found bool, currentTime time.Time) float64 {
+	if !found {
+		return float64(limiter.maxCapacity)
+	}
+	tokensText, lastText, ok := strings.Cut(value, "|")
+	tokens, tokensErr := strconv.ParseFloat(tokensText, 64)
+	lastRefill, lastErr := strconv.ParseInt(lastText, 10, 64)
+	if !ok || tokensErr != nil || lastErr != nil {
+		return float64(limiter.maxCapacity)
+	}
+	elapsed := currentTime.Sub(time.Unix(0, lastRefill))
+	if elapsed > 0 {
+		tokens += float64(limiter.tokensPerRefill) * float64(elapsed) / float64(limiter.refillInterval)
+	}
+	return math.Min(tokens, float64(limiter.maxCapacity))
+}
+
+// Allow takes one token from the shared bucket if one is available.
+func (limiter *DistributedTokenBucketLimiter) Allow(userID string) bool {
+	return limiter.allowAt(userID, time.Now())
+}
+
+// allowAt is Allow evaluated at currentTime.
+func (limiter *DistributedTokenBucketLimiter) allowAt(userID string, currentTime time.Time) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	key := limiter.key("tb", userID)
+	// An untouched bucket refills completely within this TTL, so expiring
+	// it then is the same as keeping a full bucket
+	ttl := time.Duration(math.Ceil(float64(limiter.maxCapacity)/float64(limiter.tokensPerRefill))) * limiter.refillInterval
+	for attempt := 0; attempt < maxCompareAndSetAttempts; attempt++ {
+		value, found, err := limiter.store.Get(ctx, key)
+		if err != nil {
+			return limiter.onStoreError()
+		}
+		tokens := limiter.bucketState(value, found, currentTime)
+		if tokens < 1 {
+			return false
+		}
+		if !found {
+			value = ""
+		}
+		next := strconv.FormatFloat(tokens-1, 'f', 6, 64) + "|" + strconv.FormatInt(currentTime.UnixNano(), 10)
+		stored, err := limiter.store.CompareAndSet(ctx, key, value, next, ttl)
+		if err != nil {
+			return limiter.onStoreError()
+		}
+		if stored {
+			return true
+		}
+		limiter.casConflicts.Add(1)
+	}
+	return limiter.onStoreError() // Contention beyond the retry budget
+}
+
+// Check returns the user's shared bucket level without taking a token.
+func (limiter *DistributedTokenBucketLimiter) Check(userID string) Quota {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	currentTime := time.Now()
+	value, found, err := limiter.store.Get(ctx, limiter.key("tb", userID))
+	if err != nil {
+		limiter.storeErrors.Add(1)
+		found = false
+	}
+	tokens := limiter.bucketState(value, found, currentTime)
+	missing := float64(limiter.maxCapacity) - tokens
+	refillTime := time.Duration(missing / float64(limiter.tokensPerRefill) * float64(limiter.refillInterval))
+	quota := Quota{
+		Limit:     limiter.maxCapacity,
+		Remaining: int(tokens),
+		ResetAt:   currentTime.Add(refillTime),
+	}
+	if tokens < 1 {
+		untilToken := time.Duration((1 - tokens) / float64(limiter.tokensPerRefill) * float64(limiter.refillInterval))
+		quota.RetryAt = currentTime.Add(untilToken)
+	}
+	return quota
+}
+
+// GetName returns the algorithm name.
+func (limiter *DistributedTokenBucketLimiter) GetName() string {
+	return "Distributed Token Bucket"
+}
+
+// ============================================================================
+// SECTION 14: SHADOW MODE (Observe Before Enforcing)
+// ============================================================================
+//
+// Turning on a new limit is risky: set it too low and paying customers get
+// 429s. Shadow mode rolls it out in two steps:
+//
+//   1. SHADOW:  the limiter runs on real traffic and counts the requests it
+//               WOULD reject, but lets them through
+//   2. ENFORCE: once the numbers look right, rejections become real
+//
+// ShadowLimiter wraps any RateLimiter (Decorator Pattern). The mode is set
+// per limiter (the default) and per key pattern, so a rollout can enforce
+// for "apikey:*" while "user:*" is still observed. Patterns are globs where
+// '*' matches any run of characters, including the '/' and '|' found in
+// endpoint and composite keys. The first matching rule wins.
+//
+// Metrics:
+// - Per-limiter and per-rule counters (evaluated, allowed, rejected,
+//   would-reject) for dashboards
+// - The keys that would be rejected most often: who a limit would hurt
+// - An optional observer called on every limited decision, to emit log
+//   lines or metrics to an external system
+//
+// The wrapped limiter still counts shadowed requests, so its state matches
+// what enforcement would see: the would-reject numbers are exact, not
+// estimated.
+//
+// ============================================================================
+
+// maxShadowedKeys bounds how many distinct keys are tracked for
+// GetTopShadowedKeys; keys beyond it still count in the aggregate stats.
+const maxShadowedKeys = 10000
+
+// defaultShadowRule labels decisions that matched no pattern rule.
+const defaultShadowRule = "(default)"
+
+// EnforcementMode is whether a limiter's rejections are real.
+type EnforcementMode int
+
+const (
+	ModeEnforce EnforcementMode = iota // Rejections block the request
+	ModeShadow                         // Rejections are recorded, request proceeds
+)
+
+func (mode EnforcementMode) String() string {
+	if mode == ModeShadow {
+		return "shadow"
+	}
+	return "enforce"
+}
+
+// ShadowRule sets the enforcement mode for keys matching Pattern.
+type ShadowRule struct {
+	Pattern string
+	Mode    EnforcementMode
+}
+
+// ShadowStats counts decisions made by a ShadowLimiter.
+type ShadowStats struct {
+	Evaluated   int // Requests checked against the wrapped limiter
+	Allowed     int // Within the limit
+	Rejected    int // Over the limit and blocked (enforce mode)
+	WouldReject int // Over the limit but let through (shadow mode)
+}
+
+// ShadowDecision describes one request that went over the limit.
+type ShadowDecision struct {
+	Key     string
+	Limiter string          // Wrapped limiter name
+	Rule    string          // Matching pattern, or "(default)"
+	Mode    EnforcementMode // ModeShadow means the request was let through
+}
+
+// KeyCount is a key with the number of would-be rejections it caused.
+type KeyCount struct {
+	Key   string
+	Count int
+}
+
+// ShadowLimiter evaluates a wrapped limiter and enforces or only records
+// its rejections, depending on the mode configured for the key.
+type ShadowLimiter struct {
+	limiter      RateLimiter             // The wrapped rate limiting strategy
+	defaultMode  EnforcementMode         // Mode for keys no rule matches
+	rules        []ShadowRule            // Checked in order; first match wins
+	stats        ShadowStats             // Totals across all keys
+	ruleStats    map[string]*ShadowStats // Rule pattern -> counters
+	shadowedKeys map[string]int          // Key -> would-be rejections
+	observer     func(ShadowDecision)    // Called on every limited request (nil = none)
+	mutex        sync.Mutex              // Protects everything above
+}
+
+// NewShadowLimiter wraps limiter. defaultMode applies until rules are added.
+func NewShadowLimiter(limiter RateLimiter, defaultMode EnforcementMode) *ShadowLimiter {
+	return &ShadowLimiter{
+		limiter:      limiter,
+		defaultMode:  defaultMode,
+		ruleStats:    make(map[string]*ShadowStats),
+		shadowedKeys: make(map[string]int),
+	}
+}
+
+// SetDefaultMode changes the mode for keys that match no rule.
+func (limiter *ShadowLimiter) SetDefaultMode(mode EnforcementMode) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.defaultMode = mode
+}
+
+// SetRuleMode sets the mode for keys matching pattern. An existing rule for
+// the same pattern is updated in place; new rules go after existing ones.
+func (limiter *ShadowLimiter) SetRuleMode(pattern string, mode EnforcementMode) error {
+	if pattern == "" {
+		return fmt.Errorf("shadow rule pattern cannot be empty")
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	for index := range limiter.rules {
+		if limiter.rules[index].Pattern == pattern {
+			limiter.rules[index].Mode = mode
+			return nil
+		}
+	}
+	limiter.rules = append(limiter.rules, ShadowRule{Pattern: pattern, Mode: mode})
+	return nil
+}
+
+// SetObserver registers a function called on every limited request, in
+// either mode. It runs on the request path, so it must be fast.
+func (limiter *ShadowLimiter) SetObserver(observer func(ShadowDecision)) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.observer = observer
+}
+
+// modeForLocked returns the mode for key and the rule that chose it.
+// Caller must hold limiter.mutex.
+func (limiter *ShadowLimiter) modeForLocked(key string) (EnforcementMode, string) {
+	for _, rule := range limiter.rules {
+		if matchKeyPattern(rule.Pattern, key) {
+			return rule.Mode, rule.Pattern
+		}
+	}
+	return limiter.defaultMode, defaultShadowRule
+}
+
+// GetMode returns the enforcement mode that applies to key.
+func (limiter *ShadowLimiter) GetMode(key string) EnforcementMode {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	mode, _ := limiter.modeForLocked(key)
+	return mode
+}
+
+// Allow evaluates the wrapped limiter. Over-limit requests are rejected in
+// enforce mode and allowed (but recorded) in shadow mode.
+func (limiter *ShadowLimiter) Allow(key string) bool {
+	allowed := limiter.limiter.Allow(key)
+
+	limiter.mutex.Lock()
+	mode, rule := limiter.modeForLocked(key)
+	ruleStats, exists := limiter.ruleStats[rule]
+	if !exists {
+		ruleStats = &ShadowStats{}
+		limiter.ruleStats[rule] = ruleStats
+	}
+	for _, stats := range []*ShadowStats{&limiter.stats, ruleStats} {
+		stats.Evaluated++
+		switch {
+		case allowed:
+			stats.Allowed++
+		case mode == ModeShadow:
+			stats.WouldReject++
+		default:
+			stats.Rejected++
+		}
+	}
+	if !allowed && mode == ModeShadow {
+		if _, tracked := limiter.shadowedKeys[key]; tracked || len(limiter.shadowedKeys) < maxShadowedKeys {
+			limiter.shadowedKeys[key]++
+		}
+	}
+	observer := limiter.observer
+	limiter.mutex.Unlock()
+
+	if !allowed && observer != nil {
+		observer(ShadowDecision{Key: key, Limiter: limiter.limiter.GetName(), Rule: rule, Mode: mode})
+	}
+	return allowed || mode == ModeShadow
+}
+
+// Check reports the wrapped limiter's quota, so rate-limit headers show
+// what enforcement would look like even while the key is shadowed.
+func (limiter *ShadowLimiter) Check(key string) Quota {
+	return limiter.limiter.Check(key)
+}
+
+// GetName returns the limiter name, including the wrapped algorithm.
+func (limiter *ShadowLimiter) GetName() string {
+	return "Shadow(" + limiter.limiter.GetName() + ")"
+}
+
+// GetStats returns a snapshot of the totals across all keys.
+func (limiter *ShadowLimiter) GetStats() ShadowStats {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	return limiter.stats
+}
+
+// GetRuleStats returns a snapshot of the counters per rule pattern.
+// Keys that matched no rule are reported under "(default)".
+func (limiter *ShadowLimiter) GetRuleStats() map[string]ShadowStats {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	snapshot := make(map[string]ShadowStats, len(limiter.ruleStats))
+	for rule, stats := range limiter.ruleStats {
+		snapshot[rule] = *stats
+	}
+	return snapshot
+}
+
+// GetTopShadowedKeys returns up to limit keys with the most would-be
+// rejections, most first.
+func (limiter *ShadowLimiter) GetTopShadowedKeys(limit int) []KeyCount {
+	limiter.mutex.Lock()
+	keyCounts := make([]KeyCount, 0, len(limiter.shadowedKeys))
+	for key, count := range limiter.shadowedKeys {
+		keyCounts = append(keyCounts, KeyCount{Key: key, Count: count})
+	}
+	limiter.mutex.Unlock()
+
+	sort.Slice(keyCounts, func(i, j int) bool {
+		if keyCounts[i].Count != keyCounts[j].Count {
+			return keyCounts[i].Count > keyCounts[j].Count
+		}
+		return keyCounts[i].Key < keyCounts[j].Key
+	})
+	return keyCounts[:min(limit, len(keyCounts))]
+}
+
+// ResetStats clears all counters, e.g. after changing a limit.
+func (limiter *ShadowLimiter) ResetStats() {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.stats = ShadowStats{}
+	limiter.ruleStats = make(map[string]*ShadowStats)
+	limiter.shadowedKeys = make(map[string]int)
+}
+
+// matchKeyPattern reports whether key matches a glob where '*' matches any
+// run of characters (including none) and every other character is literal.
+func matchKeyPattern(pattern, key string) bool {
+	patternIndex, keyIndex := 0, 0
+	starIndex, starKeyIndex := -1, 0
+	for keyIndex < len(key) {
+		switch {
+		case patternIndex < len(pattern) && pattern[patternIndex] == '*':
+			starIndex, starKeyIndex = patternIndex, keyIndex
+			patternIndex++
+		case patternIndex < len(pattern) && pattern[patternIndex] == key[keyIndex]:
+			patternIndex++
+			keyIndex++
+		case starIndex >= 0:
+			// Let the last '*' swallow one more character and retry
+			starKeyIndex++
+			patternIndex, keyIndex = starIndex+1, starKeyIndex
+		default:
+			return false
+		}
+	}
+	for patternIndex < len(pattern) && pattern[patternIndex] == '*' {
+		patternIndex++
+	}
+	return patternIndex == len(pattern)
+}
+
+// ============================================================================
+// SECTION 15: SLIDING WINDOW COUNTER (Weighted Two-Bucket Approximation)
+// ============================================================================
+//
+// The Sliding Window log keeps one timestamp per request, so memory grows
+// with the limit: 10,000 requests/minute means up to 10,000 timestamps per
+// user. The Sliding Window Counter keeps two counters instead:
+//
+//   previous window      current window
+//   [ 80 requests ]      [ 30 requests so far ]
+//              |<------ sliding window ------>|
+//                 ^ 25% into the current window
+//
+//   estimate = previous × (1 − elapsed fraction) + current
+//            = 80 × 0.75 + 30 = 90
+//
+// The estimate assumes the previous window's requests were spread evenly.
+// That is exact for steady traffic and slightly off for bursty traffic,
+// which is the trade for O(1) memory per user whatever the limit.
+//
+// Windows are aligned to multiples of the window size, so two limiters with
+// the same settings agree on window boundaries. Only allowed requests are
+// counted. The distributed variant in Section 13 uses the same formula on a
+// shared Store.
+//
+// ============================================================================
+
+// SlidingWindowCounterRecord holds one user's two window counters.
+type SlidingWindowCounterRecord struct {
+	windowStart   time.Time  // Start of the current window
+	currentCount  int        // Allowed requests in the current window
+	previousCount int        // Allowed requests in the window before it
+	mutex         sync.Mutex // Protects concurrent access
+}
+
+// SlidingWindowCounterRateLimiter approximates a sliding window with two
+// fixed-window counters per user.
+type SlidingWindowCounterRateLimiter struct {
+	userWindows    map[string]*SlidingWindowCounterRecord // userID -> counters
+	maxRequests    int                                    // Maximum requests per sliding window
+	windowDuration time.Duration                          // Size of the sliding window
+	mutex          sync.RWMutex                           // Protects the userWindows map
+}
+
+// NewSlidingWindowCounterRateLimiter creates a sliding window counter limiter.
+func NewSlidingWindowCounterRateLimiter(maxRequests int, windowDuration time.Duration) *SlidingWindowCounterRateLimiter {
+	return &SlidingWindowCounterRateLimiter{
+		userWindows:    make(map[string]*SlidingWindowCounterRecord),
+		maxRequests:    maxRequests,
+		windowDuration: windowDuration,
+	}
+}
+
+// getOrCreateWindow retrieves or creates the counters for a user.
+func (limiter *SlidingWindowCounterRateLimiter) getOrCreateWindow(userID string) *SlidingWindowCounterRecord {
+	limiter.mutex.RLock()
+	window, exists := limiter.userWindows[userID]
+	limiter.mutex.RUnlock()
+
+	if exists {
+		return window
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Double-check after acquiring write lock
+	if window, exists = limiter.userWindows[userID]; exists {
+		return window
+	}
+
+	window = &SlidingWindowCounterRecord{}
+	limiter.userWindows[userID] = window
+	return window
+}
+
+// advance rolls the counters forward to the window containing currentTime
+// and returns the weighted request count of the sliding window ending there.
+// Caller must hold window.mutex.
+func (limiter *SlidingWindowCounterRateLimiter) advance(window *SlidingWindowCounterRecord, currentTime time.Time) float64 {
+	windowStart := currentTime.Truncate(limiter.windowDuration)
+	switch {
+	case windowStart.Equal(window.windowStart):
+		// Still in the same window
+	case windowStart.Equal(window.windowStart.Add(limiter.windowDuration)):
+		window.previousCount, window.currentCount = window.currentCount, 0
+	default:
+		// More than a full window idle: nothing left to weigh
+		window.previousCount, window.currentCount = 0, 0
+	}
+	window.windowStart = windowStart
+
+	elapsed := float64(currentTime.Sub(windowStart)) / float64(limiter.windowDuration)
+	return float64(window.previousCount)*(1-elapsed) + float64(window.currentCount)
+}
+
+// Allow checks if a request from userID should be permitted.
+func (limiter *SlidingWindowCounterRateLimiter) Allow(userID string) bool {
+	return limiter.allowAt(userID, time.Now())
+}
+
+// allowAt is Allow evaluated at currentTime.
+func (limiter *SlidingWindowCounterRateLimiter) allowAt(userID string, currentTime time.Time) bool {
+	window := limiter.getOrCreateWindow(userID)
+	window.mutex.Lock()
+	defer window.mutex.Unlock()
+
+	if limiter.advance(window, currentTime)+1 > float64(limiter.maxRequests) {
+		return false
+	}
+	window.currentCount++
+	return true
+}
+
+// Check returns the user's estimated quota without recording a request.
+// The full limit is back once the current window's requests stop counting,
+// one window after the current one ends.
+func (limiter *SlidingWindowCounterRateLimiter) Check(userID string) Quota {
+	limiter.mutex.RLock()
+	window, exists := limiter.userWindows[userID]
+	limiter.mutex.RUnlock()
+	if !exists {
+		return fullQuota(limiter.maxRequests)
+	}
+
+	window.mutex.Lock()
+	defer window.mutex.Unlock()
+
+	currentTime := time.Now()
+	used := int(math.Ceil(limiter.advance(window, currentTime)))
+
+	resetAt := currentTime
+	if window.currentCount > 0 {
+		resetAt = window.windowStart.Add(2 * limiter.windowDuration)
+	} else if window.previousCount > 0 {
+		resetAt = window.windowStart.Add(limiter.windowDuration)
+	}
+
+	return Quota{
+		Limit:     limiter.maxRequests,
+		Remaining: max(0, limiter.maxRequests-used),
+		ResetAt:   resetAt,
+		RetryAt: counterRetryAt(window.windowStart, limiter.windowDuration, currentTime,
+			window.previousCount, window.currentCount, limiter.maxRequests),
+	}
+}
+
+// GetName returns the algorithm name.
+func (limiter *SlidingWindowCounterRateLimiter) GetName() string {
+	return "Window Counter"
+}
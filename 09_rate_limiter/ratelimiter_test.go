@@ -0,0 +1,59 @@
+package ratelimiter
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	benchmarkUsers = 2000
+	benchmarkLimit = 100
+)
+
+// benchmarkAllow measures Allow on a limiter whose users are already at
+// their limit, the steady state of a busy gateway.
+func benchmarkAllow(b *testing.B, limiter RateLimiter) {
+	userIDs := make([]string, benchmarkUsers)
+	for i := range userIDs {
+		userIDs[i] = "user" + strconv.Itoa(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		limiter.Allow(userIDs[i%benchmarkUsers])
+	}
+}
+
+func BenchmarkSlidingWindow(b *testing.B) {
+	benchmarkAllow(b, NewSlidingWindowRateLimiter(benchmarkLimit, time.Minute))
+}
+
+func BenchmarkCompactSlidingWindow(b *testing.B) {
+	benchmarkAllow(b, NewCompactSlidingWindowRateLimiter(benchmarkLimit, time.Minute, time.Second))
+}
+
+// TestResetJitterSmoothsHerd checks that jittered resets spread a herd of
+// clients that arrived together: the load is flatter and the backend is
+// no longer left idle between synchronized resets.
+func TestResetJitterSmoothsHerd(t *testing.T) {
+	const (
+		clients     = 1000
+		maxRequests = 10
+		window      = time.Second
+		tick        = 100 * time.Millisecond
+		ticks       = 60
+	)
+	aligned := simulateResetHerd(clients, maxRequests, window, 0, tick, ticks)
+	jittered := simulateResetHerd(clients, maxRequests, window, window, tick, ticks)
+
+	if jittered.GetPeakToAverage() > aligned.GetPeakToAverage()/2 {
+		t.Errorf("peak/average with jitter = %.2f, want at most half of %.2f without",
+			jittered.GetPeakToAverage(), aligned.GetPeakToAverage())
+	}
+	if jittered.IdleTicks >= aligned.IdleTicks {
+		t.Errorf("idle ticks with jitter = %d, want fewer than %d without",
+			jittered.IdleTicks, aligned.IdleTicks)
+	}
+}
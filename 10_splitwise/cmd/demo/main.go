@@ -0,0 +1,8 @@
+// Command demo runs the splitwise walkthrough: go run ./10_splitwise/cmd/demo
+package main
+
+import splitwise "github.com/ayushgupta5/GoLLD/10_splitwise"
+
+func main() {
+	splitwise.RunDemo()
+}
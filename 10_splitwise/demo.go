@@ -0,0 +1,152 @@
+package splitwise
+
+import "fmt"
+
+// ==================== MAIN FUNCTION ====================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("============================================")
+	fmt.Println("       SPLITWISE - Expense Sharing")
+	fmt.Println("============================================")
+
+	// Step 1: Create the expense manager (our main controller)
+	expenseManager := NewExpenseManager()
+
+	// Step 2: Create some users
+	alice := NewUser("U1", "Alice", "alice@email.com", "1111111111")
+	bob := NewUser("U2", "Bob", "bob@email.com", "2222222222")
+	charlie := NewUser("U3", "Charlie", "charlie@email.com", "3333333333")
+	diana := NewUser("U4", "Diana", "diana@email.com", "4444444444")
+
+	// Step 3: Register users with the expense manager
+	expenseManager.AddUser(alice)
+	expenseManager.AddUser(bob)
+	expenseManager.AddUser(charlie)
+	expenseManager.AddUser(diana)
+
+	fmt.Println("\nUsers registered: Alice, Bob, Charlie, Diana")
+
+	// ============================================
+	// EXAMPLE 1: Equal Split
+	// ============================================
+	// Alice pays $100 for dinner, split equally among 4 friends
+	// Each person's share: $100 / 4 = $25
+	fmt.Println("\n--------------------------------------------")
+	fmt.Println("EXPENSE 1: Dinner - $100 paid by Alice")
+	fmt.Println("Split Type: EQUAL (divided equally among all)")
+
+	dinnerExpense := NewExpense(
+		100.0,    // Total amount
+		"Dinner", // Description
+		alice,    // Paid by Alice
+		[]Split{ // Participants
+			NewEqualSplit("U1"), // Alice
+			NewEqualSplit("U2"), // Bob
+			NewEqualSplit("U3"), // Charlie
+			NewEqualSplit("U4"), // Diana
+		},
+		SplitTypeEqual, // Split type
+	)
+
+	if err := expenseManager.AddExpense(dinnerExpense); err != nil {
+		fmt.Printf("Error adding expense: %v\n", err)
+	} else {
+		fmt.Println("Result: Each person owes $25")
+		fmt.Println("  - Bob, Charlie, Diana each owe Alice $25")
+	}
+
+	expenseManager.PrintAllBalances()
+
+	// ============================================
+	// EXAMPLE 2: Exact Split
+	// ============================================
+	// Bob pays $50 for a movie, with specific amounts for each person
+	fmt.Println("\n--------------------------------------------")
+	fmt.Println("EXPENSE 2: Movie - $50 paid by Bob")
+	fmt.Println("Split Type: EXACT (specific amounts)")
+
+	movieExpense := NewExpense(
+		50.0,    // Total amount
+		"Movie", // Description
+		bob,     // Paid by Bob
+		[]Split{ // Participants with exact amounts
+			NewExactSplit("U1", 20.0), // Alice pays $20
+			NewExactSplit("U2", 10.0), // Bob pays $10 (himself)
+			NewExactSplit("U3", 20.0), // Charlie pays $20
+		},
+		SplitTypeExact, // Split type
+	)
+
+	if err := expenseManager.AddExpense(movieExpense); err != nil {
+		fmt.Printf("Error adding expense: %v\n", err)
+	} else {
+		fmt.Println("Result: Alice: $20, Bob: $10, Charlie: $20")
+		fmt.Println("  - Alice owes Bob $20, Charlie owes Bob $20")
+	}
+
+	expenseManager.PrintAllBalances()
+
+	// ============================================
+	// EXAMPLE 3: Percentage Split
+	// ============================================
+	// Charlie pays $200 for groceries, split by percentage
+	fmt.Println("\n--------------------------------------------")
+	fmt.Println("EXPENSE 3: Groceries - $200 paid by Charlie")
+	fmt.Println("Split Type: PERCENT (by percentage of total)")
+
+	groceriesExpense := NewExpense(
+		200.0,       // Total amount
+		"Groceries", // Description
+		charlie,     // Paid by Charlie
+		[]Split{ // Participants with percentages
+			NewPercentSplit("U1", 40.0), // Alice: 40% = $80
+			NewPercentSplit("U2", 30.0), // Bob: 30% = $60
+			NewPercentSplit("U3", 20.0), // Charlie: 20% = $40 (himself)
+			NewPercentSplit("U4", 10.0), // Diana: 10% = $20
+		},
+		SplitTypePercent, // Split type
+	)
+
+	if err := expenseManager.AddExpense(groceriesExpense); err != nil {
+		fmt.Printf("Error adding expense: %v\n", err)
+	} else {
+		fmt.Println("Result:")
+		fmt.Println("  - Alice: 40% = $80")
+		fmt.Println("  - Bob: 30% = $60")
+		fmt.Println("  - Charlie: 20% = $40 (paid himself)")
+		fmt.Println("  - Diana: 10% = $20")
+	}
+
+	expenseManager.PrintAllBalances()
+
+	// ============================================
+	// Display Individual User Balances
+	// ============================================
+	fmt.Println("\n--------------------------------------------")
+	fmt.Println("INDIVIDUAL BALANCES:")
+	expenseManager.PrintBalancesForUser("U1") // Alice
+	expenseManager.PrintBalancesForUser("U2") // Bob
+	expenseManager.PrintBalancesForUser("U3") // Charlie
+	expenseManager.PrintBalancesForUser("U4") // Diana
+
+	// ============================================
+	// Summary of Key Design Patterns Used
+	// ============================================
+	fmt.Println("\n============================================")
+	fmt.Println("KEY DESIGN PATTERNS & CONCEPTS:")
+	fmt.Println("============================================")
+	fmt.Println("1. Strategy Pattern: Different split types")
+	fmt.Println("   (EqualSplit, ExactSplit, PercentSplit)")
+	fmt.Println("   all implement the same Split interface")
+	fmt.Println("")
+	fmt.Println("2. Facade Pattern: ExpenseManager provides")
+	fmt.Println("   a simple interface to complex subsystems")
+	fmt.Println("")
+	fmt.Println("3. Thread Safety: Mutex locks protect shared")
+	fmt.Println("   data for concurrent access")
+	fmt.Println("")
+	fmt.Println("4. Bidirectional Balance Tracking: Debts are")
+	fmt.Println("   recorded both ways for easy lookup")
+	fmt.Println("============================================")
+}
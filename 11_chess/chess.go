@@ -0,0 +1,3506 @@
+// Package chess plays chess: pieces, legal move generation, check and
+// checkmate, FEN and PGN, and simple engines.
+package chess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ayushgupta5/GoLLD/11_chess/chesshash"
+)
+
+// ============================================================
+// CHESS GAME - Low Level Design
+// ============================================================
+//
+// This implementation demonstrates:
+// - Polymorphism: Each piece type implements the Piece interface
+// - Encapsulation: Board manages piece placement, Game manages rules
+// - Single Responsibility: Each struct has a clear, focused purpose
+// - Strategy: pluggable engines and tournament pairing systems
+// - Variants: standard and Chess960 setups chosen through GameConfig
+// - Perft: node counts checked against published values (FEN positions)
+// - Termination: resignation, draw offers, timeouts; outcome exported as PGN tags
+// - Rendering: Unicode (themed), ASCII and JSON board renderers chosen per game
+// - Opening book: text/JSON lines name the opening and feed a book engine
+// - Move hints: legal destinations for one square, for UIs to highlight
+// - Teaching setups: handicap (odds) games and custom FEN starting positions
+//
+// Board Layout (0-indexed):
+// Row 0: Black's back rank (Rook, Knight, Bishop, Queen, King, ...)
+// Row 1: Black's pawns
+// Row 6: White's pawns
+// Row 7: White's back rank
+//
+// Columns: 0-7 correspond to files a-h
+// ============================================================
+
+// ========== COLOR ENUM ==========
+// Represents the two players in chess: White and Black
+
+type Color int
+
+const (
+	White Color = iota // White = 0, moves first
+	Black              // Black = 1
+)
+
+// String returns a human-readable representation of the color
+func (c Color) String() string {
+	if c == White {
+		return "White"
+	}
+	return "Black"
+}
+
+// Opponent returns the opposing color
+func (c Color) Opponent() Color {
+	if c == White {
+		return Black
+	}
+	return White
+}
+
+// ========== PIECE TYPE ENUM ==========
+// Represents the six different types of chess pieces
+
+type PieceType int
+
+const (
+	TypeKing   PieceType = iota // The most important piece - game ends if checkmated
+	TypeQueen                   // Most powerful piece - moves like Rook + Bishop
+	TypeRook                    // Moves horizontally or vertically
+	TypeBishop                  // Moves diagonally
+	TypeKnight                  // Moves in L-shape, can jump over pieces
+	TypePawn                    // Moves forward, captures diagonally
+)
+
+// pieceTypeNames maps each piece type to its name (used for display and saved games)
+var pieceTypeNames = [...]string{"King", "Queen", "Rook", "Bishop", "Knight", "Pawn"}
+
+// String returns a human-readable name for the piece type
+func (pt PieceType) String() string {
+	if pt >= 0 && int(pt) < len(pieceTypeNames) {
+		return pieceTypeNames[pt]
+	}
+	return "Unknown"
+}
+
+// ========== POSITION ==========
+// Represents a square on the chess board using row and column indices
+
+type Position struct {
+	Row int // 0-7, where 0 is Black's back rank and 7 is White's back rank
+	Col int // 0-7, where 0 is column 'a' and 7 is column 'h'
+}
+
+// NewPosition creates a new Position with the given row and column
+func NewPosition(row, col int) Position {
+	return Position{Row: row, Col: col}
+}
+
+// IsValid checks if the position is within the 8x8 board boundaries
+func (p Position) IsValid() bool {
+	return p.Row >= 0 && p.Row < 8 && p.Col >= 0 && p.Col < 8
+}
+
+// String converts position to chess notation (e.g., "e4", "a1")
+func (p Position) String() string {
+	return fmt.Sprintf("%c%d", 'a'+p.Col, 8-p.Row)
+}
+
+// ParsePosition converts chess notation (e.g., "e4") back to a Position
+func ParsePosition(square string) (Position, error) {
+	if len(square) != 2 || square[0] < 'a' || square[0] > 'h' || square[1] < '1' || square[1] > '8' {
+		return Position{}, fmt.Errorf("invalid square %q", square)
+	}
+	return NewPosition(8-int(square[1]-'0'), int(square[0]-'a')), nil
+}
+
+// ========== PIECE INTERFACE ==========
+// Piece defines the contract that all chess pieces must implement
+// This enables polymorphism - we can treat all pieces uniformly
+
+type Piece interface {
+	GetColor() Color                              // Returns which player owns this piece
+	GetType() PieceType                           // Returns the type of piece (King, Queen, etc.)
+	GetSymbol() string                            // Returns Unicode symbol for display
+	CanMove(from, to Position, board *Board) bool // Validates if this piece can make the move
+	Copy() Piece                                  // Creates a deep copy of the piece
+}
+
+// ========== BASE PIECE ==========
+// BasePiece contains common fields shared by all piece types
+// This is embedded in each specific piece type (King, Queen, etc.)
+
+type BasePiece struct {
+	color     Color     // Which player owns this piece
+	pieceType PieceType // The type of piece
+	hasMoved  bool      // Tracks if piece has moved (important for castling and pawn double-move)
+}
+
+// Common getter methods for all pieces
+func (bp *BasePiece) GetColor() Color    { return bp.color }
+func (bp *BasePiece) GetType() PieceType { return bp.pieceType }
+func (bp *BasePiece) SetMoved()          { bp.hasMoved = true }
+func (bp *BasePiece) HasMoved() bool     { return bp.hasMoved }
+
+// ========== KING ==========
+// The King is the most important piece - if checkmated, the game is over
+// Movement: One square in any direction (horizontal, vertical, or diagonal)
+
+type King struct {
+	BasePiece
+}
+
+// NewKing creates a new King piece of the specified color
+func NewKing(color Color) *King {
+	return &King{BasePiece{color: color, pieceType: TypeKing}}
+}
+
+// GetSymbol returns the Unicode chess symbol for display
+func (k *King) GetSymbol() string {
+	if k.color == White {
+		return "♔"
+	}
+	return "♚"
+}
+
+// CanMove validates King movement: one square in any direction
+func (k *King) CanMove(from, to Position, _ *Board) bool {
+	rowDiff := abs(to.Row - from.Row)
+	colDiff := abs(to.Col - from.Col)
+
+	// King can move exactly one square in any direction
+	// rowDiff+colDiff > 0 ensures the king actually moves somewhere
+	return rowDiff <= 1 && colDiff <= 1 && (rowDiff+colDiff > 0)
+}
+
+// Copy creates a deep copy of this King piece
+func (k *King) Copy() Piece {
+	return &King{BasePiece{color: k.color, pieceType: k.pieceType, hasMoved: k.hasMoved}}
+}
+
+// ========== QUEEN ==========
+// The Queen is the most powerful piece
+// Movement: Any number of squares horizontally, vertically, or diagonally (combines Rook + Bishop)
+
+type Queen struct {
+	BasePiece
+}
+
+// NewQueen creates a new Queen piece of the specified color
+func NewQueen(color Color) *Queen {
+	return &Queen{BasePiece{color: color, pieceType: TypeQueen}}
+}
+
+// GetSymbol returns the Unicode chess symbol for display
+func (q *Queen) GetSymbol() string {
+	if q.color == White {
+		return "♕"
+	}
+	return "♛"
+}
+
+// CanMove validates Queen movement: combines Rook and Bishop movement patterns
+func (q *Queen) CanMove(from, to Position, _ *Board) bool {
+	// Queen can move like a Rook OR like a Bishop
+	return canMoveAsRook(from, to) || canMoveAsBishop(from, to)
+}
+
+// Copy creates a deep copy of this Queen piece
+func (q *Queen) Copy() Piece {
+	return &Queen{BasePiece{color: q.color, pieceType: q.pieceType, hasMoved: q.hasMoved}}
+}
+
+// ========== ROOK ==========
+// The Rook moves horizontally or vertically any number of squares
+// Also participates in castling with the King
+
+type Rook struct {
+	BasePiece
+}
+
+// NewRook creates a new Rook piece of the specified color
+func NewRook(color Color) *Rook {
+	return &Rook{BasePiece{color: color, pieceType: TypeRook}}
+}
+
+// GetSymbol returns the Unicode chess symbol for display
+func (r *Rook) GetSymbol() string {
+	if r.color == White {
+		return "♖"
+	}
+	return "♜"
+}
+
+// CanMove validates Rook movement: horizontal or vertical lines
+func (r *Rook) CanMove(from, to Position, _ *Board) bool {
+	return canMoveAsRook(from, to)
+}
+
+// Copy creates a deep copy of this Rook piece
+func (r *Rook) Copy() Piece {
+	return &Rook{BasePiece{color: r.color, pieceType: r.pieceType, hasMoved: r.hasMoved}}
+}
+
+// ========== BISHOP ==========
+// The Bishop moves diagonally any number of squares
+// Each Bishop is restricted to squares of one color throughout the game
+
+type Bishop struct {
+	BasePiece
+}
+
+// NewBishop creates a new Bishop piece of the specified color
+func NewBishop(color Color) *Bishop {
+	return &Bishop{BasePiece{color: color, pieceType: TypeBishop}}
+}
+
+// GetSymbol returns the Unicode chess symbol for display
+func (b *Bishop) GetSymbol() string {
+	if b.color == White {
+		return "♗"
+	}
+	return "♝"
+}
+
+// CanMove validates Bishop movement: diagonal lines
+func (b *Bishop) CanMove(from, to Position, _ *Board) bool {
+	return canMoveAsBishop(from, to)
+}
+
+// Copy creates a deep copy of this Bishop piece
+func (b *Bishop) Copy() Piece {
+	return &Bishop{BasePiece{color: b.color, pieceType: b.pieceType, hasMoved: b.hasMoved}}
+}
+
+// ========== KNIGHT ==========
+// The Knight moves in an "L" shape: 2 squares in one direction, then 1 square perpendicular
+// Special ability: Can jump over other pieces
+
+type Knight struct {
+	BasePiece
+}
+
+// NewKnight creates a new Knight piece of the specified color
+func NewKnight(color Color) *Knight {
+	return &Knight{BasePiece{color: color, pieceType: TypeKnight}}
+}
+
+// GetSymbol returns the Unicode chess symbol for display
+func (n *Knight) GetSymbol() string {
+	if n.color == White {
+		return "♘"
+	}
+	return "♞"
+}
+
+// CanMove validates Knight movement: L-shape (2+1 or 1+2 squares)
+func (n *Knight) CanMove(from, to Position, _ *Board) bool {
+	rowDiff := abs(to.Row - from.Row)
+	colDiff := abs(to.Col - from.Col)
+
+	// Knight moves in L-shape: 2 squares one way, 1 square perpendicular
+	// This creates 8 possible target squares from any position
+	return (rowDiff == 2 && colDiff == 1) || (rowDiff == 1 && colDiff == 2)
+}
+
+// Copy creates a deep copy of this Knight piece
+func (n *Knight) Copy() Piece {
+	return &Knight{BasePiece{color: n.color, pieceType: n.pieceType, hasMoved: n.hasMoved}}
+}
+
+// ========== PAWN ==========
+// The Pawn is the most numerous piece (8 per player)
+// Movement: Forward one square (or two from starting position)
+// Capture: Diagonally one square
+// Special: Can be promoted when reaching the opposite end of the board
+
+type Pawn struct {
+	BasePiece
+}
+
+// NewPawn creates a new Pawn piece of the specified color
+func NewPawn(color Color) *Pawn {
+	return &Pawn{BasePiece{color: color, pieceType: TypePawn}}
+}
+
+// GetSymbol returns the Unicode chess symbol for display
+func (p *Pawn) GetSymbol() string {
+	if p.color == White {
+		return "♙"
+	}
+	return "♟"
+}
+
+// CanMove validates Pawn movement:
+// - One square forward (two from starting position)
+// - Capture diagonally one square
+func (p *Pawn) CanMove(from, to Position, board *Board) bool {
+	// Direction: White moves UP (row decreases: -1), Black moves DOWN (row increases: +1)
+	direction := 1 // Black moves down (increasing row numbers)
+	if p.color == White {
+		direction = -1 // White moves up (decreasing row numbers)
+	}
+
+	rowDiff := to.Row - from.Row
+	colDiff := abs(to.Col - from.Col)
+
+	// Case 1: Forward move (no capture allowed)
+	if colDiff == 0 {
+		// One square forward
+		if rowDiff == direction && board.GetPiece(to) == nil {
+			return true
+		}
+
+		// Two squares forward from starting position (first move only)
+		// White pawns start at row 6, Black pawns start at row 1
+		startRow := 1 // Black's starting row
+		if p.color == White {
+			startRow = 6 // White's starting row
+		}
+
+		if from.Row == startRow && rowDiff == 2*direction {
+			// Check both the middle square and destination are empty
+			middlePos := NewPosition(from.Row+direction, from.Col)
+			return board.GetPiece(middlePos) == nil && board.GetPiece(to) == nil
+		}
+	}
+
+	// Case 2: Diagonal capture (must capture an opponent's piece)
+	if colDiff == 1 && rowDiff == direction {
+		targetPiece := board.GetPiece(to)
+		return targetPiece != nil && targetPiece.GetColor() != p.color
+	}
+
+	return false
+}
+
+// Copy creates a deep copy of this Pawn piece
+func (p *Pawn) Copy() Piece {
+	return &Pawn{BasePiece{color: p.color, pieceType: p.pieceType, hasMoved: p.hasMoved}}
+}
+
+// ========== HELPER FUNCTIONS ==========
+// Movement pattern helpers used by multiple pieces
+
+// canMoveAsRook checks if the move is along a row or column (horizontal/vertical)
+func canMoveAsRook(from, to Position) bool {
+	return from.Row == to.Row || from.Col == to.Col
+}
+
+// canMoveAsBishop checks if the move is along a diagonal
+func canMoveAsBishop(from, to Position) bool {
+	return abs(to.Row-from.Row) == abs(to.Col-from.Col)
+}
+
+// abs returns the absolute value of an integer
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// sign returns -1, 0, or 1 based on the sign of x
+func sign(x int) int {
+	if x > 0 {
+		return 1
+	} else if x < 0 {
+		return -1
+	}
+	return 0
+}
+
+// ========== ZOBRIST HASHING ==========
+// Positions are hashed with the chesshash package: the board keeps the
+// piece-placement hash up to date on every SetPiece, and Game.PositionHash
+// adds the side to move and the castling rights still held.
+
+// zobrist is shared by all boards so hashes are comparable between games
+var zobrist = chesshash.NewTable(20240601)
+
+// pieceKey returns the Zobrist key for a piece on a square
+func pieceKey(piece Piece, pos Position) uint64 {
+	return zobrist.PieceKey(int(piece.GetColor()), int(piece.GetType()), pos.Row*8+pos.Col)
+}
+
+// ========== BOARD ==========
+// Board represents the 8x8 chess board and manages piece placement
+// It provides methods for piece manipulation and position checking
+
+type Board struct {
+	cells    [8][8]Piece // 2D array storing pieces at each position
+	hash     uint64      // Zobrist hash of piece placement, updated on every SetPiece
+	backRank string      // Starting arrangement, files a-h (e.g. "RNBQKBNR")
+}
+
+// NewBoard creates a new board with pieces in standard starting positions
+func NewBoard() *Board {
+	return NewBoardFromBackRank(StandardBackRank)
+}
+
+// NewBoardFromBackRank creates a board whose back ranks follow the given
+// arrangement (see ValidateBackRank); both sides mirror it
+func NewBoardFromBackRank(backRank string) *Board {
+	board := &Board{backRank: backRank}
+	board.setupPieces()
+	board.hash = board.hashPieces()
+	return board
+}
+
+// setupPieces places all pieces on their starting squares
+func (b *Board) setupPieces() {
+	for col := 0; col < 8; col++ {
+		pieceType := backRankPieces[b.backRank[col]]
+
+		// Black pieces (row 0) - opponent's back rank, pawns on row 1
+		b.cells[0][col] = newPiece(pieceType, Black)
+		b.cells[1][col] = NewPawn(Black)
+
+		// White pieces (row 7) - player's back rank, pawns on row 6
+		b.cells[6][col] = NewPawn(White)
+		b.cells[7][col] = newPiece(pieceType, White)
+	}
+}
+
+// castlingFiles returns the starting columns of the king and of the
+// queenside (lower file) and kingside (higher file) rooks
+func (b *Board) castlingFiles() (kingCol, queenRookCol, kingRookCol int) {
+	backRank := b.backRank
+	if backRank == "" {
+		backRank = StandardBackRank
+	}
+	queenRookCol = -1
+	for col := 0; col < 8; col++ {
+		switch backRank[col] {
+		case 'K':
+			kingCol = col
+		case 'R':
+			if queenRookCol < 0 {
+				queenRookCol = col
+			} else {
+				kingRookCol = col
+			}
+		}
+	}
+	return kingCol, queenRookCol, kingRookCol
+}
+
+// GetPiece returns the piece at the given position, or nil if empty/invalid
+func (b *Board) GetPiece(pos Position) Piece {
+	if !pos.IsValid() {
+		return nil
+	}
+	return b.cells[pos.Row][pos.Col]
+}
+
+// SetPiece places a piece at the given position
+// The Zobrist hash is updated incrementally: the old occupant is XORed out
+// and the new one XORed in
+func (b *Board) SetPiece(pos Position, piece Piece) {
+	if !pos.IsValid() {
+		return
+	}
+	if existing := b.cells[pos.Row][pos.Col]; existing != nil {
+		b.hash ^= pieceKey(existing, pos)
+	}
+	if piece != nil {
+		b.hash ^= pieceKey(piece, pos)
+	}
+	b.cells[pos.Row][pos.Col] = piece
+}
+
+// Hash returns the Zobrist hash of the current piece placement
+func (b *Board) Hash() uint64 {
+	return b.hash
+}
+
+// hashPieces computes the piece-placement hash from scratch
+// Used to initialize a board and to verify incremental updates
+func (b *Board) hashPieces() uint64 {
+	var hash uint64
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			if piece := b.cells[row][col]; piece != nil {
+				hash ^= pieceKey(piece, NewPosition(row, col))
+			}
+		}
+	}
+	return hash
+}
+
+// castlingHash XORs together the keys of the castling rights still held
+func (b *Board) castlingHash() uint64 {
+	var hash uint64
+	for _, wing := range b.castlingWings() {
+		if wing.available {
+			hash ^= zobrist.CastlingKey(int(wing.color), wing.side)
+		}
+	}
+	return hash
+}
+
+// hashPosition computes the full position hash from scratch:
+// pieces, side to move and castling rights
+func (b *Board) hashPosition(toMove Color) uint64 {
+	hash := b.hashPieces() ^ b.castlingHash()
+	if toMove == Black {
+		hash ^= zobrist.SideKey()
+	}
+	return hash
+}
+
+// MovePiece moves a piece from one position to another
+// Returns the captured piece (if any), or nil
+// The hash stays in sync because both squares go through SetPiece
+func (b *Board) MovePiece(from, to Position) Piece {
+	piece := b.GetPiece(from)
+	capturedPiece := b.GetPiece(to)
+
+	// Move the piece to the new position
+	b.SetPiece(to, piece)
+	b.SetPiece(from, nil)
+
+	// Mark the piece as having moved (important for castling and pawn double-move)
+	if king, ok := piece.(*King); ok {
+		king.SetMoved()
+	} else if rook, ok := piece.(*Rook); ok {
+		rook.SetMoved()
+	} else if pawn, ok := piece.(*Pawn); ok {
+		pawn.SetMoved()
+	}
+
+	return capturedPiece
+}
+
+// IsPathClear checks if the path between two positions is clear (for Rook, Bishop, Queen)
+// This ensures pieces cannot jump over other pieces (except Knights)
+func (b *Board) IsPathClear(from, to Position) bool {
+	rowDirection := sign(to.Row - from.Row)
+	colDirection := sign(to.Col - from.Col)
+
+	// Start from the square next to 'from' and check each square until 'to'
+	current := NewPosition(from.Row+rowDirection, from.Col+colDirection)
+	for current != to {
+		if b.GetPiece(current) != nil {
+			return false // Path is blocked
+		}
+		current.Row += rowDirection
+		current.Col += colDirection
+	}
+	return true
+}
+
+// FindKing locates the King of the specified color on the board
+func (b *Board) FindKing(color Color) Position {
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			piece := b.cells[row][col]
+			if piece != nil && piece.GetType() == TypeKing && piece.GetColor() == color {
+				return NewPosition(row, col)
+			}
+		}
+	}
+	// Return invalid position if king not found (should never happen in a valid game)
+	return Position{Row: -1, Col: -1}
+}
+
+// IsSquareUnderAttack checks if a square is under attack by any piece of the given color
+func (b *Board) IsSquareUnderAttack(pos Position, byColor Color) bool {
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			piece := b.cells[row][col]
+			if piece != nil && piece.GetColor() == byColor {
+				attackerPos := NewPosition(row, col)
+				if piece.CanMove(attackerPos, pos, b) {
+					// For sliding pieces (Rook, Bishop, Queen), also verify path is clear
+					if piece.GetType() != TypeKnight && piece.GetType() != TypePawn && piece.GetType() != TypeKing {
+						if !b.IsPathClear(attackerPos, pos) {
+							continue // Path blocked, this piece can't attack the square
+						}
+					}
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Copy creates a deep copy of the board for move simulation
+func (b *Board) Copy() *Board {
+	newBoard := &Board{hash: b.hash, backRank: b.backRank}
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			if b.cells[row][col] != nil {
+				newBoard.cells[row][col] = b.cells[row][col].Copy()
+			}
+		}
+	}
+	return newBoard
+}
+
+// Print displays the board with pieces and coordinates in the classic theme
+func (b *Board) Print() {
+	fmt.Print((&UnicodeRenderer{Theme: ThemeClassic}).renderBoard(b))
+}
+
+// ========== PLAYER ==========
+// Player represents a chess player with a name and assigned color
+
+type Player struct {
+	name  string // Player's display name
+	color Color  // The color pieces this player controls
+}
+
+// NewPlayer creates a new player with the given name and color
+func NewPlayer(name string, color Color) *Player {
+	return &Player{name: name, color: color}
+}
+
+// GetName returns the player's name
+func (p *Player) GetName() string {
+	return p.name
+}
+
+// GetColor returns the player's piece color
+func (p *Player) GetColor() Color {
+	return p.color
+}
+
+// ========== GAME STATUS ==========
+// GameStatus represents the current state of the chess game
+
+type GameStatus int
+
+const (
+	StatusOngoing    GameStatus = iota // Game is in progress
+	StatusCheck                        // Current player's king is in check
+	StatusCheckmate                    // Current player is checkmated (game over)
+	StatusStalemate                    // Current player has no legal moves but is not in check (draw)
+	StatusRepetition                   // Same position occurred three times (draw)
+	StatusResigned                     // A player resigned (game over)
+	StatusDrawAgreed                   // Players agreed to a draw (game over)
+	StatusTimeout                      // A player ran out of time (game over)
+)
+
+// String returns a human-readable description of the game status
+func (gs GameStatus) String() string {
+	switch gs {
+	case StatusOngoing:
+		return "Ongoing"
+	case StatusCheck:
+		return "Check"
+	case StatusCheckmate:
+		return "Checkmate"
+	case StatusStalemate:
+		return "Stalemate"
+	case StatusRepetition:
+		return "Threefold Repetition"
+	case StatusResigned:
+		return "Resigned"
+	case StatusDrawAgreed:
+		return "Draw Agreed"
+	case StatusTimeout:
+		return "Timeout"
+	default:
+		return "Unknown"
+	}
+}
+
+// ========== GAME ==========
+// Game manages the chess game state, rules, and turn-based play
+// It orchestrates interactions between the board and players
+
+type Game struct {
+	board       *Board      // The chess board with all pieces
+	players     [2]*Player  // Array of two players [White, Black]
+	currentTurn Color       // Which player's turn it is
+	status      GameStatus  // Current game status (ongoing, check, checkmate, stalemate)
+	moveHistory []string    // Record of all moves made in the game
+	quiet       bool        // Suppresses move-by-move output (e.g. tournament games)
+	variant     Variant     // Rule set (standard or Chess960)
+	outcome     GameOutcome // Result and termination reason (ResultPending while playing)
+	drawOfferBy Color       // Who offered the pending draw (valid if hasDrawOffer)
+
+	hasDrawOffer   bool           // A draw offer is waiting for an answer
+	positionCounts map[uint64]int // Zobrist position hash -> times the position occurred
+	renderer       BoardRenderer  // How PrintBoard draws the board (nil = default Unicode)
+	openingBook    *OpeningBook   // Names the opening as it is played (nil = off)
+	opening        *OpeningLine   // Last named opening reached (nil if none yet)
+	startFEN       string         // Handicap or custom starting position ("" = normal setup)
+}
+
+// NewGame creates a new standard chess game with two players
+// White player always moves first
+func NewGame(whitePlayerName, blackPlayerName string) *Game {
+	// A standard setup cannot fail validation
+	game, _ := NewGameWithConfig(GameConfig{WhitePlayer: whitePlayerName, BlackPlayer: blackPlayerName})
+	return game
+}
+
+// GetCurrentPlayer returns the player whose turn it is
+func (g *Game) GetCurrentPlayer() *Player {
+	if g.currentTurn == White {
+		return g.players[0]
+	}
+	return g.players[1]
+}
+
+// SetQuiet turns move-by-move console output off or on
+func (g *Game) SetQuiet(quiet bool) {
+	g.quiet = quiet
+}
+
+// announce prints a game event unless the game is quiet
+func (g *Game) announce(format string, args ...interface{}) {
+	if !g.quiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// GetCurrentTurn returns the color to move
+func (g *Game) GetCurrentTurn() Color {
+	return g.currentTurn
+}
+
+// GetStatus returns the current game status
+func (g *Game) GetStatus() GameStatus {
+	return g.status
+}
+
+// IsOver reports whether the game has ended
+func (g *Game) IsOver() bool {
+	switch g.status {
+	case StatusCheckmate, StatusStalemate, StatusRepetition, StatusResigned, StatusDrawAgreed, StatusTimeout:
+		return true
+	}
+	return false
+}
+
+// PositionHash returns the Zobrist hash of the current position
+// (pieces + side to move + castling rights)
+// Two positions that differ only in castling rights are different positions
+// for threefold repetition, so the rights are part of the hash
+func (g *Game) PositionHash() uint64 {
+	hash := g.board.Hash() ^ g.board.castlingHash()
+	if g.currentTurn == Black {
+		hash ^= zobrist.SideKey()
+	}
+	return hash
+}
+
+// GetRepetitionCount returns how many times the current position has occurred
+func (g *Game) GetRepetitionCount() int {
+	return g.positionCounts[g.PositionHash()]
+}
+
+// IsValidMove checks if a move is valid according to chess rules
+// Returns (true, "") if valid, or (false, reason) if invalid
+func (g *Game) IsValidMove(from, to Position) (bool, string) {
+	// Check 1: There must be a piece at the source position
+	piece := g.board.GetPiece(from)
+	if piece == nil {
+		return false, "No piece at source position"
+	}
+
+	// Check 2: The piece must belong to the current player
+	if piece.GetColor() != g.currentTurn {
+		return false, "Not your turn"
+	}
+
+	// Check 3: Cannot capture your own piece
+	targetPiece := g.board.GetPiece(to)
+	if targetPiece != nil && targetPiece.GetColor() == g.currentTurn {
+		return false, "Cannot capture your own piece"
+	}
+
+	// Check 4: The piece must be able to make this move
+	if !piece.CanMove(from, to, g.board) {
+		return false, "Invalid move for this piece"
+	}
+
+	// Check 5: Path must be clear (except for knights which can jump)
+	if piece.GetType() != TypeKnight && piece.GetType() != TypePawn && piece.GetType() != TypeKing {
+		if !g.board.IsPathClear(from, to) {
+			return false, "Path is blocked"
+		}
+	}
+
+	// Check 6: Move must not leave own king in check
+	if g.wouldLeaveKingInCheck(from, to) {
+		return false, "Move would leave your king in check"
+	}
+
+	return true, ""
+}
+
+// wouldLeaveKingInCheck simulates a move and checks if it would leave the king in check
+func (g *Game) wouldLeaveKingInCheck(from, to Position) bool {
+	// Create a copy of the board to simulate the move
+	simulatedBoard := g.board.Copy()
+
+	// Simulate the move
+	simulatedBoard.MovePiece(from, to)
+
+	// Find where our king is after the move
+	kingPos := simulatedBoard.FindKing(g.currentTurn)
+
+	// Check if the king would be under attack
+	return simulatedBoard.IsSquareUnderAttack(kingPos, g.currentTurn.Opponent())
+}
+
+// hasAnyLegalMove checks if the player with the given color has any legal moves
+func (g *Game) hasAnyLegalMove(color Color) bool {
+	for fromRow := 0; fromRow < 8; fromRow++ {
+		for fromCol := 0; fromCol < 8; fromCol++ {
+			fromPos := NewPosition(fromRow, fromCol)
+			piece := g.board.GetPiece(fromPos)
+
+			// Skip empty squares and opponent's pieces
+			if piece == nil || piece.GetColor() != color {
+				continue
+			}
+
+			// Try all possible destination squares
+			for toRow := 0; toRow < 8; toRow++ {
+				for toCol := 0; toCol < 8; toCol++ {
+					toPos := NewPosition(toRow, toCol)
+
+					// Skip same square
+					if fromPos == toPos {
+						continue
+					}
+
+					// Temporarily save current turn to check moves
+					originalTurn := g.currentTurn
+					g.currentTurn = color
+
+					// Check if this move is valid
+					valid, _ := g.IsValidMove(fromPos, toPos)
+
+					// Restore original turn
+					g.currentTurn = originalTurn
+
+					if valid {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// LegalMoves returns every legal [from, to] move for the player to move
+func (g *Game) LegalMoves() [][2]Position {
+	moves := make([][2]Position, 0)
+	for fromRow := 0; fromRow < 8; fromRow++ {
+		for fromCol := 0; fromCol < 8; fromCol++ {
+			fromPos := NewPosition(fromRow, fromCol)
+			for _, toPos := range g.LegalMovesFrom(fromPos) {
+				moves = append(moves, [2]Position{fromPos, toPos})
+			}
+		}
+	}
+	return moves
+}
+
+// LegalMovesFrom returns every square the piece at pos can legally move to,
+// in board order (a8 to h1), so a UI can highlight destinations
+// Moves that would leave the own king in check are excluded
+// Returns an empty list for an empty or off-board square, a piece of the
+// side not to move, or a finished game
+func (g *Game) LegalMovesFrom(pos Position) []Position {
+	destinations := make([]Position, 0)
+	if g.IsOver() || !pos.IsValid() {
+		return destinations
+	}
+	piece := g.board.GetPiece(pos)
+	if piece == nil || piece.GetColor() != g.currentTurn {
+		return destinations
+	}
+	for toRow := 0; toRow < 8; toRow++ {
+		for toCol := 0; toCol < 8; toCol++ {
+			toPos := NewPosition(toRow, toCol)
+			if toPos == pos {
+				continue
+			}
+			if valid, _ := g.IsValidMove(pos, toPos); valid {
+				destinations = append(destinations, toPos)
+			}
+		}
+	}
+	return destinations
+}
+
+// LegalMovesFromSquare is LegalMovesFrom for clients that speak algebraic
+// squares (e.g. "g1" -> ["f3", "h3"]), such as a web board
+// Returns an error only if the square cannot be parsed
+func (g *Game) LegalMovesFromSquare(square string) ([]string, error) {
+	pos, err := ParsePosition(square)
+	if err != nil {
+		return nil, err
+	}
+	destinations := g.LegalMovesFrom(pos)
+	squares := make([]string, len(destinations))
+	for i, destination := range destinations {
+		squares[i] = destination.String()
+	}
+	return squares, nil
+}
+
+// Move executes a move if it's valid
+// Returns an error if the move is invalid
+func (g *Game) Move(from, to Position) error {
+	// Validate the move
+	if g.IsOver() {
+		return fmt.Errorf("game is over (%s)", g.status)
+	}
+
+	valid, reason := g.IsValidMove(from, to)
+	if !valid {
+		return errors.New(reason)
+	}
+
+	// Get piece info before moving (for recording the move)
+	piece := g.board.GetPiece(from)
+
+	// Execute the move
+	captured := g.board.MovePiece(from, to)
+
+	// Record the move in history
+	moveStr := fmt.Sprintf("%s: %s %s→%s", g.currentTurn, piece.GetSymbol(), from, to)
+	if captured != nil {
+		moveStr += fmt.Sprintf(" (captured %s)", captured.GetSymbol())
+	}
+	g.moveHistory = append(g.moveHistory, moveStr)
+	g.announce("✅ %s\n", moveStr)
+
+	// Moving instead of answering a draw offer declines it
+	if g.hasDrawOffer && g.drawOfferBy != g.currentTurn {
+		g.hasDrawOffer = false
+	}
+
+	// Switch to the other player's turn
+	g.currentTurn = g.currentTurn.Opponent()
+
+	// Record the resulting position for repetition detection
+	g.positionCounts[g.PositionHash()]++
+	g.trackOpening()
+
+	// Update game status (check for check, checkmate, stalemate)
+	g.updateGameStatus()
+
+	return nil
+}
+
+// updateGameStatus checks and updates the game status after each move
+func (g *Game) updateGameStatus() {
+	// Find the current player's king
+	kingPos := g.board.FindKing(g.currentTurn)
+	opponentColor := g.currentTurn.Opponent()
+
+	// Check if the current player's king is under attack
+	isInCheck := g.board.IsSquareUnderAttack(kingPos, opponentColor)
+
+	// Check if the current player has any legal moves
+	hasLegalMoves := g.hasAnyLegalMove(g.currentTurn)
+
+	if isInCheck {
+		if hasLegalMoves {
+			g.status = StatusCheck
+			g.announce("⚠️  %s King is in CHECK!\n", g.currentTurn)
+		} else {
+			g.finish(StatusCheckmate, winFor(opponentColor), ReasonCheckmate)
+			g.announce("🏆 CHECKMATE! %s wins!\n", opponentColor)
+		}
+	} else {
+		if hasLegalMoves {
+			g.status = StatusOngoing
+		} else {
+			g.finish(StatusStalemate, ResultDraw, ReasonStalemate)
+			g.announce("🤝 STALEMATE! The game is a draw.\n")
+		}
+	}
+
+	// Threefold repetition is a draw unless the position is already checkmate
+	if g.status != StatusCheckmate && g.GetRepetitionCount() >= 3 {
+		g.finish(StatusRepetition, ResultDraw, ReasonRepetition)
+		g.announce("🤝 THREEFOLD REPETITION! The game is a draw.\n")
+	}
+}
+
+// PrintBoard displays the current board state with the game's renderer
+func (g *Game) PrintBoard() {
+	output, err := g.RenderBoard()
+	if err != nil {
+		fmt.Printf("❌ Cannot render board with %s: %v\n", g.GetRenderer().Name(), err)
+		return
+	}
+	fmt.Print(output)
+}
+
+// GetMoveHistory returns the list of all moves made in the game
+func (g *Game) GetMoveHistory() []string {
+	return g.moveHistory
+}
+
+// ========== GAME TERMINATION ==========
+// A game ends on the board (checkmate, stalemate, repetition) or by a
+// player's decision: resigning, agreeing to a draw, or losing on time.
+// Every ending is stored as a GameOutcome: the result in PGN notation plus
+// the reason, which is also exported as PGN tags.
+//
+// Draw offers follow over-the-board etiquette: a player offers, the
+// opponent accepts or declines, and making a move instead of answering
+// declines the offer. Only one offer can be pending at a time.
+
+// TerminationReason says why a game ended
+type TerminationReason int
+
+const (
+	ReasonNone        TerminationReason = iota // Game still in progress
+	ReasonCheckmate                            // King checkmated
+	ReasonStalemate                            // No legal move, not in check (draw)
+	ReasonRepetition                           // Threefold repetition (draw)
+	ReasonResignation                          // A player resigned
+	ReasonAgreement                            // Draw offer accepted
+	ReasonTimeout                              // A player ran out of time
+)
+
+// String returns a human-readable name for the termination reason
+func (r TerminationReason) String() string {
+	names := [...]string{"in progress", "checkmate", "stalemate", "threefold repetition",
+		"resignation", "agreement", "timeout"}
+	if r >= 0 && int(r) < len(names) {
+		return names[r]
+	}
+	return "unknown"
+}
+
+// pgnTermination maps a reason to the standard PGN Termination tag value
+func (r TerminationReason) pgnTermination() string {
+	switch r {
+	case ReasonNone:
+		return "unterminated"
+	case ReasonTimeout:
+		return "time forfeit"
+	default:
+		return "normal"
+	}
+}
+
+// GameOutcome is how a game ended (Result is ResultPending while it is on)
+type GameOutcome struct {
+	Result GameResult
+	Reason TerminationReason
+}
+
+// String describes the outcome, e.g. "0-1 (White resigns)"
+func (o GameOutcome) String() string {
+	var loser Color = White
+	if o.Result == ResultWhiteWins {
+		loser = Black
+	}
+	switch o.Reason {
+	case ReasonNone:
+		return o.Result.String()
+	case ReasonCheckmate:
+		return fmt.Sprintf("%s (%s checkmated)", o.Result, loser)
+	case ReasonResignation:
+		return fmt.Sprintf("%s (%s resigns)", o.Result, loser)
+	case ReasonTimeout:
+		return fmt.Sprintf("%s (%s lost on time)", o.Result, loser)
+	case ReasonAgreement:
+		return fmt.Sprintf("%s (draw by agreement)", o.Result)
+	default:
+		return fmt.Sprintf("%s (%s)", o.Result, o.Reason)
+	}
+}
+
+// winFor returns the result in which the given color wins
+func winFor(color Color) GameResult {
+	if color == White {
+		return ResultWhiteWins
+	}
+	return ResultBlackWins
+}
+
+// GetOutcome returns how the game ended (ResultPending while it is on)
+func (g *Game) GetOutcome() GameOutcome {
+	return g.outcome
+}
+
+// finish ends the game with the given status and outcome
+func (g *Game) finish(status GameStatus, result GameResult, reason TerminationReason) {
+	g.status = status
+	g.outcome = GameOutcome{Result: result, Reason: reason}
+	g.hasDrawOffer = false
+}
+
+// checkCanEnd returns an error if the game is already over or color is not a player
+func (g *Game) checkCanEnd(color Color) error {
+	if g.IsOver() {
+		return fmt.Errorf("game is already over: %s", g.outcome)
+	}
+	if color != White && color != Black {
+		return fmt.Errorf("invalid color %d", color)
+	}
+	return nil
+}
+
+// Resign ends the game as a loss for the given color
+// A player may resign at any time, not only on their own turn
+func (g *Game) Resign(color Color) error {
+	if err := g.checkCanEnd(color); err != nil {
+		return err
+	}
+	g.finish(StatusResigned, winFor(color.Opponent()), ReasonResignation)
+	g.announce("🏳️  %s resigns. %s wins!\n", color, color.Opponent())
+	return nil
+}
+
+// OfferDraw records a draw offer from the given color to its opponent
+func (g *Game) OfferDraw(color Color) error {
+	if err := g.checkCanEnd(color); err != nil {
+		return err
+	}
+	if g.hasDrawOffer {
+		return fmt.Errorf("%s already has a draw offer pending", g.drawOfferBy)
+	}
+	g.drawOfferBy, g.hasDrawOffer = color, true
+	g.announce("🤝 %s offers a draw\n", color)
+	return nil
+}
+
+// GetDrawOffer returns which color has a draw offer pending, if any
+func (g *Game) GetDrawOffer() (Color, bool) {
+	return g.drawOfferBy, g.hasDrawOffer
+}
+
+// AcceptDraw accepts the pending draw offer on behalf of the opponent
+// of the player who made it, ending the game as a draw
+func (g *Game) AcceptDraw() error {
+	if !g.hasDrawOffer {
+		return fmt.Errorf("no draw offer to accept")
+	}
+	offeredBy := g.drawOfferBy
+	g.finish(StatusDrawAgreed, ResultDraw, ReasonAgreement)
+	g.announce("🤝 %s accepts %s's draw offer. The game is a draw.\n", offeredBy.Opponent(), offeredBy)
+	return nil
+}
+
+// DeclineDraw withdraws the pending draw offer; the game goes on
+func (g *Game) DeclineDraw() error {
+	if !g.hasDrawOffer {
+		return fmt.Errorf("no draw offer to decline")
+	}
+	g.hasDrawOffer = false
+	g.announce("✋ %s declines the draw offer\n", g.drawOfferBy.Opponent())
+	return nil
+}
+
+// LoseOnTime ends the game as a loss for a player whose clock ran out
+// The game keeps no clocks itself; the caller (UI, server) reports the flag
+// This model has no insufficient-material detection, so the opponent
+// always wins (over the board it would be a draw if they cannot mate)
+func (g *Game) LoseOnTime(color Color) error {
+	if err := g.checkCanEnd(color); err != nil {
+		return err
+	}
+	g.finish(StatusTimeout, winFor(color.Opponent()), ReasonTimeout)
+	g.announce("⏱️  %s ran out of time. %s wins!\n", color, color.Opponent())
+	return nil
+}
+
+// PGNTag is one [Name "Value"] header of a PGN game
+type PGNTag struct {
+	Name  string
+	Value string
+}
+
+// String formats the tag as it appears in a PGN file
+func (t PGNTag) String() string {
+	return fmt.Sprintf("[%s %q]", t.Name, t.Value)
+}
+
+// PGNTags returns the Seven Tag Roster plus Termination (and Variant for
+// Chess960, ECO and Opening once a book has named the opening), in the
+// standard order. Unknown values use PGN's "?"
+func (g *Game) PGNTags() []PGNTag {
+	tags := []PGNTag{
+		{"Event", "Casual Game"},
+		{"Site", "?"},
+		{"Date", "????.??.??"},
+		{"Round", "-"},
+		{"White", g.players[0].GetName()},
+		{"Black", g.players[1].GetName()},
+		{"Result", g.pgnResult()},
+	}
+	if g.variant != VariantStandard {
+		tags = append(tags, PGNTag{"Variant", g.variant.String()})
+	}
+	if g.startFEN != "" {
+		tags = append(tags, PGNTag{"SetUp", "1"}, PGNTag{"FEN", g.startFEN})
+	}
+	if opening, found := g.GetOpening(); found {
+		if opening.ECO != "" {
+			tags = append(tags, PGNTag{"ECO", opening.ECO})
+		}
+		tags = append(tags, PGNTag{"Opening", opening.Name})
+	}
+	tags = append(tags, PGNTag{"Termination", g.outcome.Reason.pgnTermination()})
+	return tags
+}
+
+// pgnResult returns the PGN Result tag value (PGN spells a draw "1/2-1/2")
+func (g *Game) pgnResult() string {
+	if g.outcome.Result == ResultDraw {
+		return "1/2-1/2"
+	}
+	return g.outcome.Result.String()
+}
+
+// ========== VARIANTS & GAME CONFIG ==========
+// Chess960 (Fischer Random) shuffles the back rank; pawns and the rest of
+// the rules are unchanged. A legal arrangement has:
+// - bishops on opposite-colored squares
+// - the king somewhere between the two rooks
+// Black mirrors White's arrangement. There are exactly 960 such ranks,
+// numbered 0-959 (Scharnagl numbering); standard chess is #518.
+//
+// Castling rights follow the rooks' actual starting files, written in
+// Shredder-FEN ("HAha" = rooks on h and a) for Chess960 games.
+
+// Variant selects the rule set a game is played with
+type Variant int
+
+const (
+	VariantStandard Variant = iota // Classical starting position
+	VariantChess960                // Fischer Random starting position
+)
+
+// variantNames maps each variant to its name (used for display and saved games)
+var variantNames = [...]string{"Standard", "Chess960"}
+
+// String returns a human-readable name for the variant
+func (v Variant) String() string {
+	if v >= 0 && int(v) < len(variantNames) {
+		return variantNames[v]
+	}
+	return "Unknown"
+}
+
+// parseVariant converts a variant name back to a Variant ("" = Standard)
+func parseVariant(name string) (Variant, error) {
+	if name == "" {
+		return VariantStandard, nil
+	}
+	for index, variantName := range variantNames {
+		if variantName == name {
+			return Variant(index), nil
+		}
+	}
+	return VariantStandard, fmt.Errorf("invalid variant %q", name)
+}
+
+// StandardBackRank is the classical arrangement, files a-h
+const StandardBackRank = "RNBQKBNR"
+
+// Chess960StandardID is the Chess960 number of the classical arrangement
+const Chess960StandardID = 518
+
+// chess960KnightPairs lists, for each remainder 0-9, which two of the five
+// squares left after placing bishops and queen receive the knights
+var chess960KnightPairs = [10][2]int{
+	{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4},
+}
+
+// Chess960BackRank returns the back rank for a Chess960 position number (0-959)
+func Chess960BackRank(id int) (string, error) {
+	if id < 0 || id >= 960 {
+		return "", fmt.Errorf("chess960 position must be 0-959, got %d", id)
+	}
+
+	var rank [8]byte
+	rank[2*(id%4)+1] = 'B' // Light-squared bishop on b, d, f or h
+	id /= 4
+	rank[2*(id%4)] = 'B' // Dark-squared bishop on a, c, e or g
+	id /= 4
+
+	// placeOnEmpty puts a piece on the n-th empty square (0-based)
+	placeOnEmpty := func(n int, piece byte) {
+		for col := 0; col < 8; col++ {
+			if rank[col] != 0 {
+				continue
+			}
+			if n == 0 {
+				rank[col] = piece
+				return
+			}
+			n--
+		}
+	}
+
+	placeOnEmpty(id%6, 'Q')
+	id /= 6
+	knights := chess960KnightPairs[id]
+	placeOnEmpty(knights[1], 'N') // Higher index first so the lower index is unaffected
+	placeOnEmpty(knights[0], 'N')
+
+	// The last three empty squares get rook, king, rook in that order
+	placeOnEmpty(0, 'R')
+	placeOnEmpty(0, 'K')
+	placeOnEmpty(0, 'R')
+	return string(rank[:]), nil
+}
+
+// backRankPieces maps back-rank letters to piece types
+var backRankPieces = map[byte]PieceType{
+	'K': TypeKing, 'Q': TypeQueen, 'R': TypeRook, 'B': TypeBishop, 'N': TypeKnight,
+}
+
+// ValidateBackRank checks that a back rank is a legal Chess960 arrangement
+func ValidateBackRank(rank string) error {
+	if len(rank) != 8 {
+		return fmt.Errorf("back rank %q must have 8 pieces", rank)
+	}
+
+	counts := map[byte]int{}
+	var bishopCols, rookCols []int
+	kingCol := -1
+	for col := 0; col < 8; col++ {
+		letter := rank[col]
+		if _, ok := backRankPieces[letter]; !ok {
+			return fmt.Errorf("back rank %q has invalid piece %q", rank, letter)
+		}
+		counts[letter]++
+		switch letter {
+		case 'B':
+			bishopCols = append(bishopCols, col)
+		case 'R':
+			rookCols = append(rookCols, col)
+		case 'K':
+			kingCol = col
+		}
+	}
+
+	if counts['K'] != 1 || counts['Q'] != 1 || counts['R'] != 2 || counts['B'] != 2 || counts['N'] != 2 {
+		return fmt.Errorf("back rank %q must have 1 king, 1 queen, 2 rooks, 2 bishops and 2 knights", rank)
+	}
+	if bishopCols[0]%2 == bishopCols[1]%2 {
+		return fmt.Errorf("back rank %q has both bishops on the same color", rank)
+	}
+	if kingCol < rookCols[0] || kingCol > rookCols[1] {
+		return fmt.Errorf("back rank %q does not place the king between the rooks", rank)
+	}
+	return nil
+}
+
+// GameConfig describes how to set up a new game
+type GameConfig struct {
+	WhitePlayer string
+	BlackPlayer string
+	Variant     Variant
+	BackRank    string        // Chess960 only: arrangement to use (e.g. "BBQNNRKR"); empty = random
+	Seed        int64         // Chess960 only: seeds the random arrangement (0 = unseeded)
+	Renderer    BoardRenderer // How the board is drawn (nil = Unicode, classic theme)
+	Handicaps   []Handicap    // Material taken off before play (odds games)
+	Setup       string        // Custom starting position as FEN; replaces the variant's setup
+}
+
+// NewGameWithConfig creates a game for any variant
+// Standard games ignore BackRank; Chess960 games validate or generate one
+// A Setup replaces the variant's starting position; Handicaps then remove
+// material from whichever setup is used, and the result is validated
+func NewGameWithConfig(config GameConfig) (*Game, error) {
+	if config.Setup != "" {
+		if config.Variant != VariantStandard {
+			return nil, fmt.Errorf("custom setups are only supported for %s games", VariantStandard)
+		}
+		game, err := NewGameFromFEN(config.Setup)
+		if err != nil {
+			return nil, err
+		}
+		game.players = [2]*Player{NewPlayer(config.WhitePlayer, White), NewPlayer(config.BlackPlayer, Black)}
+		game.renderer = config.Renderer
+		if err := game.applyHandicaps(config.Handicaps); err != nil {
+			return nil, err
+		}
+		return game, nil
+	}
+
+	backRank := StandardBackRank
+	switch config.Variant {
+	case VariantStandard:
+	case VariantChess960:
+		backRank = config.BackRank
+		if backRank == "" {
+			id := rand.Intn(960)
+			if config.Seed != 0 {
+				id = rand.New(rand.NewSource(config.Seed)).Intn(960)
+			}
+			backRank, _ = Chess960BackRank(id)
+		}
+		if err := ValidateBackRank(backRank); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported variant %s", config.Variant)
+	}
+
+	game := &Game{
+		board: NewBoardFromBackRank(backRank),
+		players: [2]*Player{
+			NewPlayer(config.WhitePlayer, White),
+			NewPlayer(config.BlackPlayer, Black),
+		},
+		currentTurn: White, // White moves first
+		status:      StatusOngoing,
+		moveHistory: make([]string, 0),
+		variant:     config.Variant,
+		renderer:    config.Renderer,
+	}
+	game.positionCounts = map[uint64]int{game.PositionHash(): 1}
+	if len(config.Handicaps) > 0 {
+		if err := game.applyHandicaps(config.Handicaps); err != nil {
+			return nil, err
+		}
+	}
+	return game, nil
+}
+
+// GetVariant returns the rule set the game is played with
+func (g *Game) GetVariant() Variant {
+	return g.variant
+}
+
+// GetBackRank returns the game's starting arrangement (e.g. "RNBQKBNR")
+func (g *Game) GetBackRank() string {
+	return g.board.backRank
+}
+
+// ========== HANDICAPS & CUSTOM SETUPS ==========
+// Teaching games often start from something other than two full armies:
+// - Handicap (odds) games take material off one side before play, e.g.
+//   knight odds removes White's queenside knight
+// - Custom setups place any material from a FEN string, e.g. king and rook
+//   against king to practise the basic mate
+// Every such starting position is checked before play: one king per side,
+// no pawns on the first or last rank, at most 16 men and 8 pawns per side,
+// and the side that is not to move must not already be in check.
+// Castling rights follow from the material left: a removed rook (or a king
+// or rook placed off its home square) gives up castling on that wing.
+
+// Handicap removes one piece from one side's starting position
+// Pieces come off the lowest file first (the queenside knight for knight
+// odds); pawn odds removes the f-pawn, the classical "pawn and move" odds
+type Handicap struct {
+	Color Color
+	Piece PieceType
+}
+
+// String describes the handicap (e.g. "White gives Knight odds")
+func (h Handicap) String() string {
+	return fmt.Sprintf("%s gives %s odds", h.Color, h.Piece)
+}
+
+// handicapOddsPawnCol is the file of the pawn removed for pawn odds (f-file)
+const handicapOddsPawnCol = 5
+
+// applyHandicap takes the handicapped piece off the board
+func (b *Board) applyHandicap(handicap Handicap) error {
+	row := 7 // White's back rank
+	if handicap.Color == Black {
+		row = 0
+	}
+	switch handicap.Piece {
+	case TypeKing:
+		return fmt.Errorf("cannot give king odds")
+	case TypePawn:
+		pawnRow := row - 1
+		if handicap.Color == Black {
+			pawnRow = row + 1
+		}
+		pos := NewPosition(pawnRow, handicapOddsPawnCol)
+		piece := b.GetPiece(pos)
+		if piece == nil || piece.GetType() != TypePawn || piece.GetColor() != handicap.Color {
+			return fmt.Errorf("%s: no pawn left on %s", handicap, pos)
+		}
+		b.SetPiece(pos, nil)
+		return nil
+	}
+	for col := 0; col < 8; col++ {
+		pos := NewPosition(row, col)
+		piece := b.GetPiece(pos)
+		if piece != nil && piece.GetType() == handicap.Piece && piece.GetColor() == handicap.Color {
+			b.SetPiece(pos, nil)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: no %s left on the back rank", handicap, handicap.Piece)
+}
+
+// ValidateStartingPosition checks that a position is a legal place to start
+// a game with the given side to move
+func (b *Board) ValidateStartingPosition(toMove Color) error {
+	for _, color := range []Color{White, Black} {
+		kings, pawns, men := 0, 0, 0
+		for row := 0; row < 8; row++ {
+			for col := 0; col < 8; col++ {
+				piece := b.cells[row][col]
+				if piece == nil || piece.GetColor() != color {
+					continue
+				}
+				men++
+				switch piece.GetType() {
+				case TypeKing:
+					kings++
+				case TypePawn:
+					pawns++
+					if row == 0 || row == 7 {
+						return fmt.Errorf("%s pawn on %s: pawns cannot stand on the first or last rank",
+							color, NewPosition(row, col))
+					}
+				}
+			}
+		}
+		if kings != 1 {
+			return fmt.Errorf("%s must have exactly one king, found %d", color, kings)
+		}
+		if pawns > 8 || men > 16 {
+			return fmt.Errorf("%s has %d men and %d pawns (at most 16 and 8)", color, men, pawns)
+		}
+	}
+	waiting := toMove.Opponent()
+	if b.IsSquareUnderAttack(b.FindKing(waiting), toMove) {
+		return fmt.Errorf("%s is in check but it is %s's move", waiting, toMove)
+	}
+	return nil
+}
+
+// PlacementFEN returns the piece placement field of FEN (rank 8 first)
+func (b *Board) PlacementFEN() string {
+	var placement strings.Builder
+	for row := 0; row < 8; row++ {
+		empty := 0
+		for col := 0; col < 8; col++ {
+			piece := b.cells[row][col]
+			if piece == nil {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				placement.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			placement.WriteString(pieceLetter(piece)) // Lowercase for Black
+		}
+		if empty > 0 {
+			placement.WriteString(strconv.Itoa(empty))
+		}
+		if row < 7 {
+			placement.WriteByte('/')
+		}
+	}
+	return placement.String()
+}
+
+// startingFEN describes a game's current position as a FEN string
+// (en passant and move counters are not tracked and written as "- 0 1")
+func (g *Game) startingFEN() string {
+	side := "w"
+	if g.currentTurn == Black {
+		side = "b"
+	}
+	return fmt.Sprintf("%s %s %s - 0 1", g.board.PlacementFEN(), side, g.board.CastlingRights())
+}
+
+// applyHandicaps removes handicap material before the first move, then
+// revalidates the position and records it as the game's starting FEN
+func (g *Game) applyHandicaps(handicaps []Handicap) error {
+	for _, handicap := range handicaps {
+		if err := g.board.applyHandicap(handicap); err != nil {
+			return err
+		}
+	}
+	if err := g.board.ValidateStartingPosition(g.currentTurn); err != nil {
+		return err
+	}
+	g.startFEN = g.startingFEN()
+	g.positionCounts = map[uint64]int{g.PositionHash(): 1}
+	return nil
+}
+
+// GetStartingFEN returns the FEN of a handicap or custom starting position
+// ("" when the game began from its variant's normal setup)
+func (g *Game) GetStartingFEN() string {
+	return g.startFEN
+}
+
+// ========== SAVE / LOAD ==========
+// Full game serialization to JSON so an in-progress game can be persisted
+// and resumed exactly where it left off.
+//
+// Unlike FEN/PGN, the saved state keeps engine-internal details:
+// - every piece's hasMoved flag (castling and pawn double-move eligibility)
+// - the repetition table (Zobrist hash -> occurrences)
+// - the move history as recorded by the game, and the game status
+// - the outcome of a finished game (result and termination reason)
+//
+// Castling rights are also written in FEN style ("KQkq") for readability,
+// but on load they are derived from the pieces' hasMoved flags.
+// Chess960 games also store the variant and starting back rank; older
+// saves without them load as standard games.
+// This model has no clocks, so none are saved.
+
+// saveFormatVersion is bumped whenever the JSON layout changes incompatibly
+const saveFormatVersion = 1
+
+// SavedPiece is one occupied square in a saved game
+type SavedPiece struct {
+	Square   string `json:"square"`
+	Color    string `json:"color"`
+	Type     string `json:"type"`
+	HasMoved bool   `json:"has_moved"`
+}
+
+// SavedGame is the JSON document written by Game.SaveJSON
+type SavedGame struct {
+	Version        int            `json:"version"`
+	WhitePlayer    string         `json:"white_player"`
+	BlackPlayer    string         `json:"black_player"`
+	CurrentTurn    string         `json:"current_turn"`
+	Status         string         `json:"status"`
+	Variant        string         `json:"variant,omitempty"`
+	BackRank       string         `json:"back_rank,omitempty"`
+	StartFEN       string         `json:"start_fen,omitempty"` // Handicap or custom starting position
+	CastlingRights string         `json:"castling_rights"`
+	Pieces         []SavedPiece   `json:"pieces"`
+	MoveHistory    []string       `json:"move_history"`
+	PositionCounts map[string]int `json:"position_counts"`       // Hex Zobrist hash -> count
+	Result         string         `json:"result,omitempty"`      // "1-0", "0-1" or "½-½" once over
+	Termination    string         `json:"termination,omitempty"` // Termination reason once over
+}
+
+// movedTracker is implemented by every piece through the embedded BasePiece
+type movedTracker interface {
+	HasMoved() bool
+	SetMoved()
+}
+
+// newPiece creates a piece of the given type and color
+func newPiece(pieceType PieceType, color Color) Piece {
+	switch pieceType {
+	case TypeKing:
+		return NewKing(color)
+	case TypeQueen:
+		return NewQueen(color)
+	case TypeRook:
+		return NewRook(color)
+	case TypeBishop:
+		return NewBishop(color)
+	case TypeKnight:
+		return NewKnight(color)
+	case TypePawn:
+		return NewPawn(color)
+	}
+	return nil
+}
+
+// parseColor converts "White"/"Black" back to a Color
+func parseColor(name string) (Color, error) {
+	switch name {
+	case White.String():
+		return White, nil
+	case Black.String():
+		return Black, nil
+	}
+	return White, fmt.Errorf("invalid color %q", name)
+}
+
+// parsePieceType converts a piece type name back to a PieceType
+func parsePieceType(name string) (PieceType, error) {
+	for index, typeName := range pieceTypeNames {
+		if typeName == name {
+			return PieceType(index), nil
+		}
+	}
+	return TypePawn, fmt.Errorf("invalid piece type %q", name)
+}
+
+// parseGameStatus converts a status name back to a GameStatus
+func parseGameStatus(name string) (GameStatus, error) {
+	for status := StatusOngoing; status <= StatusTimeout; status++ {
+		if status.String() == name {
+			return status, nil
+		}
+	}
+	return StatusOngoing, fmt.Errorf("invalid game status %q", name)
+}
+
+// parseOutcome converts a saved result and termination reason back to a GameOutcome
+func parseOutcome(result, reason string) (GameOutcome, error) {
+	outcome := GameOutcome{Result: -1, Reason: -1}
+	for candidate := ResultPending; candidate <= ResultDraw; candidate++ {
+		if candidate.String() == result {
+			outcome.Result = candidate
+		}
+	}
+	for candidate := ReasonNone; candidate <= ReasonTimeout; candidate++ {
+		if candidate.String() == reason {
+			outcome.Reason = candidate
+		}
+	}
+	if outcome.Result < 0 || outcome.Reason < 0 {
+		return GameOutcome{}, fmt.Errorf("invalid outcome %q (%s)", result, reason)
+	}
+	return outcome, nil
+}
+
+// outcomeFromStatus rebuilds the outcome of a board-decided game from its
+// status (saves made before outcomes were stored only have the status)
+func outcomeFromStatus(status GameStatus, toMove Color) (GameOutcome, error) {
+	switch status {
+	case StatusOngoing, StatusCheck:
+		return GameOutcome{}, nil
+	case StatusCheckmate:
+		return GameOutcome{Result: winFor(toMove.Opponent()), Reason: ReasonCheckmate}, nil
+	case StatusStalemate:
+		return GameOutcome{Result: ResultDraw, Reason: ReasonStalemate}, nil
+	case StatusRepetition:
+		return GameOutcome{Result: ResultDraw, Reason: ReasonRepetition}, nil
+	}
+	return GameOutcome{}, fmt.Errorf("status %s needs a saved result", status)
+}
+
+// hasUnmovedPiece checks for an unmoved piece of the given type and color on a square
+func (b *Board) hasUnmovedPiece(pos Position, pieceType PieceType, color Color) bool {
+	piece := b.GetPiece(pos)
+	if piece == nil || piece.GetType() != pieceType || piece.GetColor() != color {
+		return false
+	}
+	tracker, ok := piece.(movedTracker)
+	return ok && !tracker.HasMoved()
+}
+
+// castlingWing is one castling right: a color castling toward one rook
+type castlingWing struct {
+	color     Color
+	side      int    // chesshash.KingSide or chesshash.QueenSide
+	symbol    string // FEN letter, e.g. "K" or "h"
+	available bool   // King and this wing's rook have never moved
+}
+
+// castlingWings reports every castling right in FEN order (white kingside,
+// white queenside, black kingside, black queenside)
+// Non-standard (Chess960) setups name the rook files instead (Shredder-FEN, e.g. "HBhb")
+func (b *Board) castlingWings() []castlingWing {
+	kingCol, queenRookCol, kingRookCol := b.castlingFiles()
+	kingSymbol, queenSymbol := "K", "Q"
+	if b.backRank != "" && b.backRank != StandardBackRank {
+		kingSymbol, queenSymbol = string(rune('A'+kingRookCol)), string(rune('A'+queenRookCol))
+	}
+
+	wings := []struct {
+		color   Color
+		row     int
+		rookCol int
+		side    int
+		symbol  string
+	}{
+		{White, 7, kingRookCol, chesshash.KingSide, kingSymbol}, {White, 7, queenRookCol, chesshash.QueenSide, queenSymbol},
+		{Black, 0, kingRookCol, chesshash.KingSide, strings.ToLower(kingSymbol)}, {Black, 0, queenRookCol, chesshash.QueenSide, strings.ToLower(queenSymbol)},
+	}
+	rights := make([]castlingWing, 0, len(wings))
+	for _, wing := range wings {
+		rights = append(rights, castlingWing{
+			color:  wing.color,
+			side:   wing.side,
+			symbol: wing.symbol,
+			available: b.hasUnmovedPiece(NewPosition(wing.row, kingCol), TypeKing, wing.color) &&
+				b.hasUnmovedPiece(NewPosition(wing.row, wing.rookCol), TypeRook, wing.color),
+		})
+	}
+	return rights
+}
+
+// CastlingRights returns castling availability in FEN notation ("KQkq", "-" if none)
+// A side may still castle on a wing if its king and that wing's rook have never moved
+func (b *Board) CastlingRights() string {
+	rights := ""
+	for _, wing := range b.castlingWings() {
+		if wing.available {
+			rights += wing.symbol
+		}
+	}
+	if rights == "" {
+		return "-"
+	}
+	return rights
+}
+
+// SaveJSON serializes the complete game state to JSON
+func (g *Game) SaveJSON() ([]byte, error) {
+	saved := SavedGame{
+		Version:        saveFormatVersion,
+		WhitePlayer:    g.players[0].GetName(),
+		BlackPlayer:    g.players[1].GetName(),
+		CurrentTurn:    g.currentTurn.String(),
+		Status:         g.status.String(),
+		CastlingRights: g.board.CastlingRights(),
+		Pieces:         make([]SavedPiece, 0, 32),
+		MoveHistory:    append([]string{}, g.moveHistory...),
+		PositionCounts: make(map[string]int, len(g.positionCounts)),
+		StartFEN:       g.startFEN,
+	}
+	if g.variant != VariantStandard {
+		saved.Variant = g.variant.String()
+		saved.BackRank = g.board.backRank
+	}
+	if g.IsOver() {
+		saved.Result = g.outcome.Result.String()
+		saved.Termination = g.outcome.Reason.String()
+	}
+
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			piece := g.board.cells[row][col]
+			if piece == nil {
+				continue
+			}
+			savedPiece := SavedPiece{
+				Square: NewPosition(row, col).String(),
+				Color:  piece.GetColor().String(),
+				Type:   piece.GetType().String(),
+			}
+			if tracker, ok := piece.(movedTracker); ok {
+				savedPiece.HasMoved = tracker.HasMoved()
+			}
+			saved.Pieces = append(saved.Pieces, savedPiece)
+		}
+	}
+
+	for hash, count := range g.positionCounts {
+		saved.PositionCounts[strconv.FormatUint(hash, 16)] = count
+	}
+
+	return json.MarshalIndent(saved, "", "  ")
+}
+
+// LoadGameJSON restores a game saved with SaveJSON
+// The document is fully validated before a Game is returned
+func LoadGameJSON(data []byte) (*Game, error) {
+	var saved SavedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("invalid saved game: %v", err)
+	}
+	if saved.Version != saveFormatVersion {
+		return nil, fmt.Errorf("unsupported save format version %d (expected %d)", saved.Version, saveFormatVersion)
+	}
+
+	currentTurn, err := parseColor(saved.CurrentTurn)
+	if err != nil {
+		return nil, err
+	}
+	status, err := parseGameStatus(saved.Status)
+	if err != nil {
+		return nil, err
+	}
+	outcome, err := outcomeFromStatus(status, currentTurn)
+	if saved.Result != "" {
+		outcome, err = parseOutcome(saved.Result, saved.Termination)
+	}
+	if err != nil {
+		return nil, err
+	}
+	variant, err := parseVariant(saved.Variant)
+	if err != nil {
+		return nil, err
+	}
+	backRank := StandardBackRank
+	if variant == VariantChess960 {
+		if err := ValidateBackRank(saved.BackRank); err != nil {
+			return nil, err
+		}
+		backRank = saved.BackRank
+	}
+
+	// Rebuild the board square by square
+	board := &Board{backRank: backRank}
+	kingCounts := map[Color]int{}
+	for _, savedPiece := range saved.Pieces {
+		pos, err := ParsePosition(savedPiece.Square)
+		if err != nil {
+			return nil, err
+		}
+		if board.GetPiece(pos) != nil {
+			return nil, fmt.Errorf("square %s is occupied twice", savedPiece.Square)
+		}
+		color, err := parseColor(savedPiece.Color)
+		if err != nil {
+			return nil, err
+		}
+		pieceType, err := parsePieceType(savedPiece.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		piece := newPiece(pieceType, color)
+		if savedPiece.HasMoved {
+			piece.(movedTracker).SetMoved()
+		}
+		board.cells[pos.Row][pos.Col] = piece
+		if pieceType == TypeKing {
+			kingCounts[color]++
+		}
+	}
+	if kingCounts[White] != 1 || kingCounts[Black] != 1 {
+		return nil, fmt.Errorf("saved game must have exactly one king per side")
+	}
+	board.hash = board.hashPieces()
+
+	game := &Game{
+		board: board,
+		players: [2]*Player{
+			NewPlayer(saved.WhitePlayer, White),
+			NewPlayer(saved.BlackPlayer, Black),
+		},
+		currentTurn:    currentTurn,
+		status:         status,
+		moveHistory:    append([]string{}, saved.MoveHistory...),
+		positionCounts: make(map[uint64]int, len(saved.PositionCounts)),
+		variant:        variant,
+		outcome:        outcome,
+		startFEN:       saved.StartFEN,
+	}
+
+	for hexHash, count := range saved.PositionCounts {
+		hash, err := strconv.ParseUint(hexHash, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid position hash %q", hexHash)
+		}
+		game.positionCounts[hash] = count
+	}
+
+	// The current position must have been recorded at least once
+	if game.positionCounts[game.PositionHash()] == 0 {
+		return nil, fmt.Errorf("repetition table does not match the saved position")
+	}
+
+	return game, nil
+}
+
+// ========== ENGINES ==========
+// Engine picks moves for a computer player (Strategy Pattern), so tournament
+// games can be played automatically. These engines are deliberately simple:
+// they exist to exercise the tournament, not to play good chess.
+
+type Engine interface {
+	Name() string
+	// ChooseMove returns the move to play, or false if there is none
+	ChooseMove(g *Game) (from, to Position, ok bool)
+}
+
+// pieceValues is the classic material value of each piece type (King unused)
+var pieceValues = [...]int{0, 9, 5, 3, 3, 1}
+
+// RandomEngine plays a uniformly random legal move
+type RandomEngine struct {
+	rng *rand.Rand
+}
+
+// NewRandomEngine creates a random mover; the seed makes games reproducible
+func NewRandomEngine(seed int64) *RandomEngine {
+	return &RandomEngine{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Name returns the engine's display name
+func (e *RandomEngine) Name() string { return "Random" }
+
+// ChooseMove picks any legal move
+func (e *RandomEngine) ChooseMove(g *Game) (Position, Position, bool) {
+	moves := g.LegalMoves()
+	if len(moves) == 0 {
+		return Position{}, Position{}, false
+	}
+	move := moves[e.rng.Intn(len(moves))]
+	return move[0], move[1], true
+}
+
+// GreedyEngine delivers mate when it can, otherwise grabs the most valuable
+// capture, breaking ties randomly
+type GreedyEngine struct {
+	rng *rand.Rand
+}
+
+// NewGreedyEngine creates a greedy capturer; the seed makes games reproducible
+func NewGreedyEngine(seed int64) *GreedyEngine {
+	return &GreedyEngine{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Name returns the engine's display name
+func (e *GreedyEngine) Name() string { return "Greedy" }
+
+// ChooseMove scores every legal move and plays one of the best
+func (e *GreedyEngine) ChooseMove(g *Game) (Position, Position, bool) {
+	moves := g.LegalMoves()
+	if len(moves) == 0 {
+		return Position{}, Position{}, false
+	}
+
+	bestScore := -1
+	best := make([][2]Position, 0)
+	for _, move := range moves {
+		score := 0
+		if target := g.board.GetPiece(move[1]); target != nil {
+			score = pieceValues[target.GetType()]
+		}
+		if e.isMate(g, move) {
+			score = 100
+		}
+		if score > bestScore {
+			bestScore = score
+			best = best[:0]
+		}
+		if score == bestScore {
+			best = append(best, move)
+		}
+	}
+	move := best[e.rng.Intn(len(best))]
+	return move[0], move[1], true
+}
+
+// isMate reports whether the move checkmates the opponent
+func (e *GreedyEngine) isMate(g *Game, move [2]Position) bool {
+	simulated := &Game{board: g.board.Copy(), currentTurn: g.currentTurn.Opponent()}
+	simulated.board.MovePiece(move[0], move[1])
+	kingPos := simulated.board.FindKing(simulated.currentTurn)
+	if !simulated.board.IsSquareUnderAttack(kingPos, g.currentTurn) {
+		return false
+	}
+	return !simulated.hasAnyLegalMove(simulated.currentTurn)
+}
+
+// ========== TOURNAMENT ==========
+// Tournament runs many games among N players:
+// - A PairingSystem (Strategy Pattern) decides who plays whom each round:
+//   round robin (everyone meets everyone) or Swiss (players with similar
+//   scores meet, no rematches).
+// - Games between two engines are played automatically on the existing Game
+//   type; games involving a human are left pending until RecordResult.
+// - Standings are sorted by points, then by the configured tie-breaks.
+//
+// Scores are kept in half-points (win = 2, draw = 1) so no floating point
+// rounding creeps into tie-breaks.
+
+// GameResult is the outcome of one tournament game
+type GameResult int
+
+const (
+	ResultPending   GameResult = iota // Not played yet
+	ResultWhiteWins                   // 1-0
+	ResultBlackWins                   // 0-1
+	ResultDraw                        // ½-½
+)
+
+var gameResultNames = [...]string{"*", "1-0", "0-1", "½-½"}
+
+// String returns the result in PGN-style notation
+func (r GameResult) String() string {
+	if r >= 0 && int(r) < len(gameResultNames) {
+		return gameResultNames[r]
+	}
+	return "?"
+}
+
+// halfPoints returns the half-points earned by White and Black
+func (r GameResult) halfPoints() (white, black int) {
+	switch r {
+	case ResultWhiteWins:
+		return 2, 0
+	case ResultBlackWins:
+		return 0, 2
+	case ResultDraw:
+		return 1, 1
+	}
+	return 0, 0
+}
+
+// MaxTournamentPlies caps engine games; a game still going is adjudicated a draw
+// (this model has no fifty-move rule or insufficient-material detection)
+const MaxTournamentPlies = 200
+
+// TournamentPlayer is one registered entrant
+type TournamentPlayer struct {
+	Name   string
+	Engine Engine // nil for a human, whose results are recorded manually
+}
+
+// Pairing is one board in one round. A bye has an empty Black.
+type Pairing struct {
+	Round  int
+	Board  int
+	White  string
+	Black  string
+	Result GameResult
+	Plies  int   // Half-moves played (engine games)
+	Game   *Game // The played game (engine games)
+}
+
+// IsBye reports whether this pairing is a bye (a free point, no opponent)
+func (p *Pairing) IsBye() bool {
+	return p.Black == ""
+}
+
+// PairingSystem produces the pairings for the next round
+type PairingSystem interface {
+	Name() string
+	// TotalRounds returns how many rounds the system schedules for n players
+	TotalRounds(playerCount int) int
+	PairRound(t *Tournament, round int) []*Pairing
+}
+
+// RoundRobinPairing schedules everyone against everyone using the circle method
+type RoundRobinPairing struct{}
+
+// Name returns the system's display name
+func (rr *RoundRobinPairing) Name() string { return "Round Robin" }
+
+// TotalRounds is n-1 for even n, n for odd n (one bye per round)
+func (rr *RoundRobinPairing) TotalRounds(playerCount int) int {
+	if playerCount%2 == 1 {
+		return playerCount
+	}
+	return playerCount - 1
+}
+
+// PairRound fixes the first seat and rotates the others one step per round
+func (rr *RoundRobinPairing) PairRound(t *Tournament, round int) []*Pairing {
+	seats := t.playerNames()
+	if len(seats)%2 == 1 {
+		seats = append(seats, "") // Empty seat = bye
+	}
+	n := len(seats)
+
+	rotated := []string{seats[0]}
+	for i := 0; i < n-1; i++ {
+		rotated = append(rotated, seats[1+(i+round-1)%(n-1)])
+	}
+
+	pairings := make([]*Pairing, 0, n/2)
+	for board := 0; board < n/2; board++ {
+		white, black := rotated[board], rotated[n-1-board]
+		// Alternate colors so the fixed seat doesn't always play White
+		if (board == 0 && round%2 == 0) || (board > 0 && board%2 == 1) {
+			white, black = black, white
+		}
+		if white == "" {
+			white, black = black, white
+		}
+		pairings = append(pairings, &Pairing{Round: round, White: white, Black: black})
+	}
+	return pairings
+}
+
+// SwissPairing pairs players with equal or close scores who haven't met yet
+type SwissPairing struct {
+	Rounds int
+}
+
+// Name returns the system's display name
+func (sw *SwissPairing) Name() string { return "Swiss" }
+
+// TotalRounds is fixed by the organizer
+func (sw *SwissPairing) TotalRounds(_ int) int { return sw.Rounds }
+
+// PairRound sorts by current standings, gives the bye to the lowest-ranked
+// player without one, then pairs top-down, backtracking to avoid rematches
+func (sw *SwissPairing) PairRound(t *Tournament, round int) []*Pairing {
+	ranked := make([]string, 0)
+	for _, standing := range t.Standings() {
+		ranked = append(ranked, standing.Name)
+	}
+
+	pairings := make([]*Pairing, 0)
+	if len(ranked)%2 == 1 {
+		byeIndex := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if !t.hadBye(ranked[i]) {
+				byeIndex = i
+				break
+			}
+		}
+		pairings = append(pairings, &Pairing{Round: round, White: ranked[byeIndex]})
+		ranked = append(ranked[:byeIndex:byeIndex], ranked[byeIndex+1:]...)
+	}
+
+	pairs, ok := sw.pairWithoutRematch(t, ranked)
+	if !ok {
+		// Every arrangement repeats a game: fall back to plain top-down pairs
+		pairs = nil
+		for i := 0; i+1 < len(ranked); i += 2 {
+			pairs = append(pairs, [2]string{ranked[i], ranked[i+1]})
+		}
+	}
+	for _, pair := range pairs {
+		white, black := pair[0], pair[1]
+		// The player who has had White less often gets White
+		if t.colorBalance(white) > t.colorBalance(black) {
+			white, black = black, white
+		}
+		pairings = append(pairings, &Pairing{Round: round, White: white, Black: black})
+	}
+
+	// Byes go on the last board
+	sort.SliceStable(pairings, func(i, j int) bool { return !pairings[i].IsBye() && pairings[j].IsBye() })
+	return pairings
+}
+
+// pairWithoutRematch pairs the first player with the closest-ranked opponent
+// they haven't met, recursing on the rest
+func (sw *SwissPairing) pairWithoutRematch(t *Tournament, ranked []string) ([][2]string, bool) {
+	if len(ranked) == 0 {
+		return nil, true
+	}
+	top := ranked[0]
+	for i := 1; i < len(ranked); i++ {
+		if t.havePlayed(top, ranked[i]) {
+			continue
+		}
+		rest := make([]string, 0, len(ranked)-2)
+		rest = append(rest, ranked[1:i]...)
+		rest = append(rest, ranked[i+1:]...)
+		if pairs, ok := sw.pairWithoutRematch(t, rest); ok {
+			return append([][2]string{{top, ranked[i]}}, pairs...), true
+		}
+	}
+	return nil, false
+}
+
+// TieBreak orders players who finish on equal points
+type TieBreak int
+
+const (
+	TieBreakSonnebornBerger TieBreak = iota // Sum of beaten opponents' scores + half of drawn opponents'
+	TieBreakBuchholz                        // Sum of all opponents' scores
+	TieBreakWins                            // Number of games won
+	TieBreakDirectEncounter                 // Result between the tied players
+)
+
+var tieBreakNames = [...]string{"SB", "Buch", "Wins", "DE"}
+
+// String returns the short column name of the tie-break
+func (tb TieBreak) String() string {
+	if tb >= 0 && int(tb) < len(tieBreakNames) {
+		return tieBreakNames[tb]
+	}
+	return "?"
+}
+
+// Standing is one row of the tournament table
+type Standing struct {
+	Rank       int
+	Name       string
+	HalfPoints int
+	Played     int
+	Wins       int
+	Draws      int
+	Losses     int
+	TieBreaks  []int // Half-point values, in the tournament's tie-break order
+}
+
+// Points returns the score as a float (e.g., 2.5)
+func (s Standing) Points() float64 {
+	return float64(s.HalfPoints) / 2
+}
+
+// Tournament schedules rounds, runs engine games and keeps standings
+type Tournament struct {
+	name      string
+	system    PairingSystem
+	players   []*TournamentPlayer
+	rounds    [][]*Pairing
+	tieBreaks []TieBreak
+}
+
+// NewTournament creates a tournament with the given pairing system
+// Tie-breaks default to Sonneborn-Berger for round robin and Buchholz for Swiss
+func NewTournament(name string, system PairingSystem) *Tournament {
+	tieBreaks := []TieBreak{TieBreakDirectEncounter, TieBreakSonnebornBerger, TieBreakWins}
+	if _, isSwiss := system.(*SwissPairing); isSwiss {
+		tieBreaks = []TieBreak{TieBreakBuchholz, TieBreakSonnebornBerger, TieBreakWins}
+	}
+	return &Tournament{
+		name:      name,
+		system:    system,
+		players:   make([]*TournamentPlayer, 0),
+		rounds:    make([][]*Pairing, 0),
+		tieBreaks: tieBreaks,
+	}
+}
+
+// SetTieBreaks replaces the tie-break order
+func (t *Tournament) SetTieBreaks(tieBreaks ...TieBreak) {
+	t.tieBreaks = tieBreaks
+}
+
+// AddPlayer registers a player (engine may be nil for a human)
+// Registration closes when the first round is paired
+func (t *Tournament) AddPlayer(name string, engine Engine) error {
+	if len(t.rounds) > 0 {
+		return fmt.Errorf("registration is closed")
+	}
+	if name == "" {
+		return fmt.Errorf("player name is required")
+	}
+	if t.findPlayer(name) != nil {
+		return fmt.Errorf("player %q is already registered", name)
+	}
+	t.players = append(t.players, &TournamentPlayer{Name: name, Engine: engine})
+	return nil
+}
+
+// findPlayer looks a player up by name
+func (t *Tournament) findPlayer(name string) *TournamentPlayer {
+	for _, player := range t.players {
+		if player.Name == name {
+			return player
+		}
+	}
+	return nil
+}
+
+// playerNames returns names in registration (seeding) order
+func (t *Tournament) playerNames() []string {
+	names := make([]string, len(t.players))
+	for i, player := range t.players {
+		names[i] = player.Name
+	}
+	return names
+}
+
+// TotalRounds returns how many rounds the tournament will have
+func (t *Tournament) TotalRounds() int {
+	return t.system.TotalRounds(len(t.players))
+}
+
+// IsFinished reports whether every round has been paired and played
+func (t *Tournament) IsFinished() bool {
+	return len(t.rounds) == t.TotalRounds() && t.currentRoundComplete()
+}
+
+// currentRoundComplete reports whether every game of the latest round has a result
+func (t *Tournament) currentRoundComplete() bool {
+	if len(t.rounds) == 0 {
+		return true
+	}
+	for _, pairing := range t.rounds[len(t.rounds)-1] {
+		if pairing.Result == ResultPending {
+			return false
+		}
+	}
+	return true
+}
+
+// PairNextRound creates the next round's pairings
+// Returns an error if the previous round has unfinished games or the
+// tournament is over
+func (t *Tournament) PairNextRound() ([]*Pairing, error) {
+	if len(t.players) < 2 {
+		return nil, fmt.Errorf("at least 2 players are required")
+	}
+	if !t.currentRoundComplete() {
+		return nil, fmt.Errorf("round %d still has unfinished games", len(t.rounds))
+	}
+	if len(t.rounds) >= t.TotalRounds() {
+		return nil, fmt.Errorf("all %d rounds have been played", t.TotalRounds())
+	}
+
+	round := len(t.rounds) + 1
+	pairings := t.system.PairRound(t, round)
+	for board, pairing := range pairings {
+		pairing.Board = board + 1
+		if pairing.IsBye() {
+			pairing.Result = ResultWhiteWins // A bye scores a full point
+		}
+	}
+	t.rounds = append(t.rounds, pairings)
+	return pairings, nil
+}
+
+// PlayRound plays every pending engine-vs-engine game of the current round
+// Games involving a human stay pending until RecordResult is called
+func (t *Tournament) PlayRound() error {
+	if len(t.rounds) == 0 {
+		return fmt.Errorf("no round has been paired")
+	}
+	for _, pairing := range t.rounds[len(t.rounds)-1] {
+		if pairing.Result != ResultPending {
+			continue
+		}
+		white, black := t.findPlayer(pairing.White), t.findPlayer(pairing.Black)
+		if white.Engine == nil || black.Engine == nil {
+			continue
+		}
+		t.playGame(pairing, [2]Engine{white.Engine, black.Engine})
+	}
+	return nil
+}
+
+// playGame runs one engine game to completion (or the ply cap)
+func (t *Tournament) playGame(pairing *Pairing, engines [2]Engine) {
+	game := NewGame(pairing.White, pairing.Black)
+	game.SetQuiet(true)
+	pairing.Game = game
+
+	for plies := 0; plies < MaxTournamentPlies && !game.IsOver(); plies++ {
+		from, to, ok := engines[game.GetCurrentTurn()].ChooseMove(game)
+		if !ok || game.Move(from, to) != nil {
+			break
+		}
+		pairing.Plies++
+	}
+
+	if game.IsOver() {
+		pairing.Result = game.GetOutcome().Result
+		return
+	}
+	pairing.Result = ResultDraw // Adjudicated at the ply cap
+}
+
+// RecordResult stores the result of a game played over the board
+func (t *Tournament) RecordResult(round, board int, result GameResult) error {
+	if round < 1 || round > len(t.rounds) {
+		return fmt.Errorf("round %d does not exist", round)
+	}
+	pairings := t.rounds[round-1]
+	if board < 1 || board > len(pairings) {
+		return fmt.Errorf("round %d has no board %d", round, board)
+	}
+	if result == ResultPending {
+		return fmt.Errorf("a result must be a win or a draw")
+	}
+	pairing := pairings[board-1]
+	if pairing.IsBye() {
+		return fmt.Errorf("board %d is a bye", board)
+	}
+	if pairing.Result != ResultPending {
+		return fmt.Errorf("board %d of round %d already has result %s", board, round, pairing.Result)
+	}
+	pairing.Result = result
+	return nil
+}
+
+// GetRound returns the pairings of a round (1-based)
+func (t *Tournament) GetRound(round int) []*Pairing {
+	if round < 1 || round > len(t.rounds) {
+		return nil
+	}
+	return t.rounds[round-1]
+}
+
+// playedGames calls visit for every finished game that is not a bye
+func (t *Tournament) playedGames(visit func(pairing *Pairing)) {
+	for _, round := range t.rounds {
+		for _, pairing := range round {
+			if pairing.Result != ResultPending && !pairing.IsBye() {
+				visit(pairing)
+			}
+		}
+	}
+}
+
+// havePlayed reports whether two players have already been paired
+func (t *Tournament) havePlayed(a, b string) bool {
+	for _, round := range t.rounds {
+		for _, pairing := range round {
+			if (pairing.White == a && pairing.Black == b) || (pairing.White == b && pairing.Black == a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hadBye reports whether a player has already received a bye
+func (t *Tournament) hadBye(name string) bool {
+	for _, round := range t.rounds {
+		for _, pairing := range round {
+			if pairing.IsBye() && pairing.White == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// colorBalance returns games as White minus games as Black
+func (t *Tournament) colorBalance(name string) int {
+	balance := 0
+	t.playedGames(func(pairing *Pairing) {
+		if pairing.White == name {
+			balance++
+		} else if pairing.Black == name {
+			balance--
+		}
+	})
+	return balance
+}
+
+// scores returns each player's half-points, including byes
+func (t *Tournament) scores() map[string]int {
+	scores := make(map[string]int, len(t.players))
+	for _, round := range t.rounds {
+		for _, pairing := range round {
+			white, black := pairing.Result.halfPoints()
+			scores[pairing.White] += white
+			if !pairing.IsBye() {
+				scores[pairing.Black] += black
+			}
+		}
+	}
+	return scores
+}
+
+// Standings returns the table sorted by points, then tie-breaks, then seed
+func (t *Tournament) Standings() []Standing {
+	scores := t.scores()
+	rows := make(map[string]*Standing, len(t.players))
+	for _, name := range t.playerNames() {
+		rows[name] = &Standing{Name: name, HalfPoints: scores[name]}
+	}
+
+	// Opponent-based tie-breaks; byes count as a win but add no opponent score
+	sonnebornBerger := make(map[string]int)
+	buchholz := make(map[string]int)
+	t.playedGames(func(pairing *Pairing) {
+		white, black := pairing.Result.halfPoints()
+		for _, side := range [2]struct {
+			name, opponent string
+			earned         int
+		}{{pairing.White, pairing.Black, white}, {pairing.Black, pairing.White, black}} {
+			row := rows[side.name]
+			row.Played++
+			switch side.earned {
+			case 2:
+				row.Wins++
+			case 1:
+				row.Draws++
+			default:
+				row.Losses++
+			}
+			buchholz[side.name] += scores[side.opponent]
+			sonnebornBerger[side.name] += scores[side.opponent] * side.earned / 2
+		}
+	})
+
+	seed := make(map[string]int, len(t.players))
+	standings := make([]Standing, 0, len(t.players))
+	for index, name := range t.playerNames() {
+		seed[name] = index
+		row := rows[name]
+		for _, tieBreak := range t.tieBreaks {
+			switch tieBreak {
+			case TieBreakSonnebornBerger:
+				row.TieBreaks = append(row.TieBreaks, sonnebornBerger[name])
+			case TieBreakBuchholz:
+				row.TieBreaks = append(row.TieBreaks, buchholz[name])
+			case TieBreakWins:
+				row.TieBreaks = append(row.TieBreaks, row.Wins*2)
+			case TieBreakDirectEncounter:
+				row.TieBreaks = append(row.TieBreaks, 0) // Filled in below, per tied group
+			}
+		}
+		standings = append(standings, *row)
+	}
+	t.applyDirectEncounter(standings)
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.HalfPoints != b.HalfPoints {
+			return a.HalfPoints > b.HalfPoints
+		}
+		for k := range a.TieBreaks {
+			if a.TieBreaks[k] != b.TieBreaks[k] {
+				return a.TieBreaks[k] > b.TieBreaks[k]
+			}
+		}
+		return seed[a.Name] < seed[b.Name]
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
+
+// applyDirectEncounter scores, for each group of players tied on points,
+// the half-points each earned against the others in the group
+func (t *Tournament) applyDirectEncounter(standings []Standing) {
+	column := -1
+	for k, tieBreak := range t.tieBreaks {
+		if tieBreak == TieBreakDirectEncounter {
+			column = k
+		}
+	}
+	if column < 0 {
+		return
+	}
+
+	scores := t.scores()
+	earned := make(map[string]int)
+	t.playedGames(func(pairing *Pairing) {
+		if scores[pairing.White] != scores[pairing.Black] {
+			return
+		}
+		white, black := pairing.Result.halfPoints()
+		earned[pairing.White] += white
+		earned[pairing.Black] += black
+	})
+	for i := range standings {
+		standings[i].TieBreaks[column] = earned[standings[i].Name]
+	}
+}
+
+// PrintStandings displays the table with one column per tie-break
+func (t *Tournament) PrintStandings() {
+	fmt.Printf("\n🏆 %s — %s, after round %d of %d\n", t.name, t.system.Name(), len(t.rounds), t.TotalRounds())
+	header := fmt.Sprintf("  %-3s %-10s %5s %3s %3s %3s", "#", "Player", "Pts", "W", "D", "L")
+	for _, tieBreak := range t.tieBreaks {
+		header += fmt.Sprintf(" %5s", tieBreak)
+	}
+	fmt.Println(header)
+	for _, row := range t.Standings() {
+		line := fmt.Sprintf("  %-3d %-10s %5.1f %3d %3d %3d", row.Rank, row.Name, row.Points(), row.Wins, row.Draws, row.Losses)
+		for _, value := range row.TieBreaks {
+			line += fmt.Sprintf(" %5.1f", float64(value)/2)
+		}
+		fmt.Println(line)
+	}
+}
+
+// CrossTable renders results between every pair of players, in standings
+// order. Cells show the row player's score against the column player
+// ("1", "½", "0"), "·" if they haven't met and "X" on the diagonal.
+// Repeated meetings (possible in Swiss fallbacks) are concatenated.
+func (t *Tournament) CrossTable() string {
+	standings := t.Standings()
+	cells := make(map[[2]string]string)
+	t.playedGames(func(pairing *Pairing) {
+		white, black := pairing.Result.halfPoints()
+		cells[[2]string{pairing.White, pairing.Black}] += halfPointSymbol(white)
+		cells[[2]string{pairing.Black, pairing.White}] += halfPointSymbol(black)
+	})
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("  %-3s %-10s", "#", "Player"))
+	for _, column := range standings {
+		builder.WriteString(fmt.Sprintf(" %3d", column.Rank))
+	}
+	builder.WriteString("   Pts\n")
+	for _, row := range standings {
+		builder.WriteString(fmt.Sprintf("  %-3d %-10s", row.Rank, row.Name))
+		for _, column := range standings {
+			cell := "·"
+			if row.Name == column.Name {
+				cell = "X"
+			} else if result, met := cells[[2]string{row.Name, column.Name}]; met {
+				cell = result
+			}
+			builder.WriteString(fmt.Sprintf(" %3s", cell))
+		}
+		builder.WriteString(fmt.Sprintf(" %5.1f\n", row.Points()))
+	}
+	return builder.String()
+}
+
+// halfPointSymbol converts 2/1/0 half-points to "1"/"½"/"0"
+func halfPointSymbol(halfPoints int) string {
+	switch halfPoints {
+	case 2:
+		return "1"
+	case 1:
+		return "½"
+	}
+	return "0"
+}
+
+// PrintRound displays one round's pairings and results
+func (t *Tournament) PrintRound(round int) {
+	fmt.Printf("  Round %d:\n", round)
+	for _, pairing := range t.GetRound(round) {
+		if pairing.IsBye() {
+			fmt.Printf("    Board %d: %-10s bye (+1)\n", pairing.Board, pairing.White)
+			continue
+		}
+		detail := ""
+		if pairing.Game != nil {
+			ending := pairing.Game.GetStatus().String()
+			if !pairing.Game.IsOver() {
+				ending = "adjudicated"
+			}
+			detail = fmt.Sprintf("  (%d plies, %s)", pairing.Plies, ending)
+		}
+		fmt.Printf("    Board %d: %-10s - %-10s %s%s\n", pairing.Board, pairing.White, pairing.Black, pairing.Result, detail)
+	}
+}
+
+// ========== PERFT ==========
+// Perft ("performance test") walks the legal-move tree to a fixed depth and
+// counts the leaf nodes. The counts for well-known positions are published,
+// so any difference points to a move-generation or validation bug.
+// PerftDivide splits the count by root move to narrow a mismatch down.
+//
+// The suite marks which special moves each case needs. Cases that need a
+// move the generator does not play yet (castling, en passant, promotion)
+// are reported as Pending instead of Fail; add the feature to
+// SupportedSpecialMoves when it lands and the case starts counting.
+
+// SpecialMove is a bit set of rules beyond ordinary piece movement
+type SpecialMove int
+
+const (
+	SpecialCastling SpecialMove = 1 << iota
+	SpecialEnPassant
+	SpecialPromotion
+)
+
+// String lists the special moves in the set (e.g. "castling+promotion")
+func (s SpecialMove) String() string {
+	names := []string{}
+	for flag, name := range map[SpecialMove]string{
+		SpecialCastling: "castling", SpecialEnPassant: "en passant", SpecialPromotion: "promotion",
+	} {
+		if s&flag != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, "+")
+}
+
+// SupportedSpecialMoves is what the move generator currently plays
+var SupportedSpecialMoves SpecialMove = 0
+
+// Perft counts the leaf nodes of the legal-move tree depth plies deep
+// The game itself is not modified
+func (g *Game) Perft(depth int) int {
+	return perft(g.board, g.currentTurn, depth)
+}
+
+// PerftDivide returns the perft count below each legal root move,
+// keyed by "e2e4"-style move text
+func (g *Game) PerftDivide(depth int) map[string]int {
+	divide := map[string]int{}
+	if depth < 1 {
+		return divide
+	}
+	for _, move := range legalMovesFor(g.board, g.currentTurn) {
+		next := g.board.Copy()
+		next.MovePiece(move[0], move[1])
+		divide[move[0].String()+move[1].String()] = perft(next, g.currentTurn.Opponent(), depth-1)
+	}
+	return divide
+}
+
+// perft is the recursive node counter behind Perft
+func perft(board *Board, toMove Color, depth int) int {
+	if depth == 0 {
+		return 1
+	}
+	moves := legalMovesFor(board, toMove)
+	if depth == 1 {
+		return len(moves) // Bulk counting: leaves need not be played
+	}
+	nodes := 0
+	for _, move := range moves {
+		next := board.Copy()
+		next.MovePiece(move[0], move[1])
+		nodes += perft(next, toMove.Opponent(), depth-1)
+	}
+	return nodes
+}
+
+// legalMovesFor generates legal moves for a bare position, reusing the game's
+// validation rules through a scratch Game (no history, never "over")
+func legalMovesFor(board *Board, toMove Color) [][2]Position {
+	scratch := &Game{board: board, currentTurn: toMove, status: StatusOngoing, quiet: true}
+	return scratch.LegalMoves()
+}
+
+// NewGameFromFEN creates a game from the first fields of a FEN string:
+// piece placement, side to move and castling availability
+// Castling rights only decide which kings and rooks count as unmoved;
+// the en passant square and move counters are accepted but not used
+// The position must pass ValidateStartingPosition
+func NewGameFromFEN(fen string) (*Game, error) {
+	fields := strings.Fields(fen)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("FEN needs at least placement and side to move: %q", fen)
+	}
+
+	ranks := strings.Split(fields[0], "/")
+	if len(ranks) != 8 {
+		return nil, fmt.Errorf("FEN placement must have 8 ranks, got %d", len(ranks))
+	}
+	board := &Board{backRank: StandardBackRank}
+	for row, rank := range ranks {
+		col := 0
+		for _, symbol := range rank {
+			if symbol >= '1' && symbol <= '8' {
+				col += int(symbol - '0')
+				continue
+			}
+			pieceType, known := backRankPieces[byte(unicode.ToUpper(symbol))]
+			if symbol == 'P' || symbol == 'p' {
+				pieceType, known = TypePawn, true
+			}
+			if !known || col > 7 {
+				return nil, fmt.Errorf("invalid FEN rank %q", rank)
+			}
+			color := Black
+			if unicode.IsUpper(symbol) {
+				color = White
+			}
+			piece := newPiece(pieceType, color)
+			if pieceType == TypeKing || pieceType == TypeRook {
+				piece.(movedTracker).SetMoved() // Unmoved again below if castling allows
+			}
+			board.cells[row][col] = piece
+			col++
+		}
+		if col != 8 {
+			return nil, fmt.Errorf("FEN rank %q does not cover 8 files", rank)
+		}
+	}
+	var currentTurn Color
+	switch fields[1] {
+	case "w":
+		currentTurn = White
+	case "b":
+		currentTurn = Black
+	default:
+		return nil, fmt.Errorf("invalid side to move %q", fields[1])
+	}
+
+	if len(fields) > 2 && fields[2] != "-" {
+		kingCol, queenRookCol, kingRookCol := board.castlingFiles()
+		for _, right := range fields[2] {
+			row, color := 7, White
+			if unicode.IsLower(right) {
+				row, color = 0, Black
+			}
+			rookCol := kingRookCol
+			switch unicode.ToUpper(right) {
+			case 'K':
+			case 'Q':
+				rookCol = queenRookCol
+			default:
+				return nil, fmt.Errorf("invalid castling availability %q", fields[2])
+			}
+			for _, col := range []int{kingCol, rookCol} {
+				piece := board.cells[row][col]
+				if piece == nil || piece.GetColor() != color ||
+					(piece.GetType() != TypeKing && piece.GetType() != TypeRook) {
+					return nil, fmt.Errorf("castling right %q has no king and rook on their starting squares", right)
+				}
+				board.cells[row][col] = newPiece(piece.GetType(), color) // Fresh piece: unmoved
+			}
+		}
+	}
+	board.hash = board.hashPieces()
+	if err := board.ValidateStartingPosition(currentTurn); err != nil {
+		return nil, err
+	}
+
+	game := &Game{
+		board:       board,
+		players:     [2]*Player{NewPlayer("White", White), NewPlayer("Black", Black)},
+		currentTurn: currentTurn,
+		status:      StatusOngoing,
+		moveHistory: make([]string, 0),
+		variant:     VariantStandard,
+	}
+	game.positionCounts = map[uint64]int{game.PositionHash(): 1}
+	return game, nil
+}
+
+// PerftCase is a position with a published node count
+type PerftCase struct {
+	Name     string
+	FEN      string
+	Depth    int
+	Expected int
+	Needs    SpecialMove // Special moves that occur within Depth plies
+}
+
+// StandardPerftSuite lists reference counts from the Chess Programming Wiki
+var StandardPerftSuite = []PerftCase{
+	{"Start", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 1, 20, 0},
+	{"Start", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 2, 400, 0},
+	{"Start", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 3, 8902, 0},
+	{"Start", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 4, 197281, 0},
+	{"Kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", 1, 48, SpecialCastling},
+	{"Kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", 2, 2039, SpecialCastling | SpecialEnPassant},
+	{"Position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", 1, 14, 0},
+	{"Position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", 2, 191, 0},
+	{"Position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", 3, 2812, SpecialEnPassant},
+	{"Position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", 1, 6, 0},
+	{"Position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", 2, 264, SpecialCastling | SpecialPromotion},
+	{"Position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", 1, 44, SpecialCastling | SpecialPromotion},
+	{"Position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", 1, 46, 0},
+	{"Position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", 2, 2079, 0},
+	{"Position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", 3, 89890, 0},
+}
+
+// PerftStatus is the outcome of one perft case
+type PerftStatus int
+
+const (
+	PerftPass    PerftStatus = iota // Count matches the reference
+	PerftFail                       // Count differs: move generation bug
+	PerftPending                    // Needs special moves not implemented yet
+	PerftError                      // Position could not be loaded
+)
+
+// String returns a human-readable name for the perft status
+func (s PerftStatus) String() string {
+	names := [...]string{"PASS", "FAIL", "PENDING", "ERROR"}
+	if int(s) < len(names) {
+		return names[s]
+	}
+	return "UNKNOWN"
+}
+
+// PerftResult is the outcome of running one case
+type PerftResult struct {
+	Case    PerftCase
+	Nodes   int
+	Status  PerftStatus
+	Elapsed time.Duration
+	Err     error
+}
+
+// RunPerftSuite runs every case up to maxDepth (0 = no limit)
+// Pending cases are still counted, so progress on a feature is visible
+func RunPerftSuite(cases []PerftCase, maxDepth int) []PerftResult {
+	results := make([]PerftResult, 0, len(cases))
+	for _, perftCase := range cases {
+		if maxDepth > 0 && perftCase.Depth > maxDepth {
+			continue
+		}
+		result := PerftResult{Case: perftCase}
+		game, err := NewGameFromFEN(perftCase.FEN)
+		if err != nil {
+			result.Status, result.Err = PerftError, err
+			results = append(results, result)
+			continue
+		}
+
+		started := time.Now()
+		result.Nodes = game.Perft(perftCase.Depth)
+		result.Elapsed = time.Since(started)
+		switch {
+		case result.Nodes == perftCase.Expected:
+			result.Status = PerftPass
+		case perftCase.Needs&^SupportedSpecialMoves != 0:
+			result.Status = PerftPending
+		default:
+			result.Status = PerftFail
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// ========== BOARD RENDERERS ==========
+// Drawing the board is a strategy chosen per game:
+// - UnicodeRenderer: chess glyphs, styled by a BoardTheme (the default)
+// - ASCIIRenderer: plain letters for terminals and logs without Unicode
+// - JSONRenderer: machine-readable board state for web frontends
+//
+// A renderer gets the whole Game, not just the Board, so state such as the
+// side to move and the game status can be part of the output.
+
+// BoardRenderer turns a game's current position into text
+type BoardRenderer interface {
+	Name() string
+	Render(g *Game) (string, error)
+}
+
+// BoardTheme styles the Unicode board
+type BoardTheme struct {
+	Name        string
+	Grid        bool   // Draw box-drawing lines between squares
+	LightSquare string // Shown on an empty light square (one column wide)
+	DarkSquare  string // Shown on an empty dark square (one column wide)
+}
+
+// Built-in themes
+var (
+	ThemeClassic = BoardTheme{Name: "Classic", Grid: true, LightSquare: " ", DarkSquare: " "}
+	ThemeShaded  = BoardTheme{Name: "Shaded", Grid: true, LightSquare: " ", DarkSquare: "░"}
+	ThemeCompact = BoardTheme{Name: "Compact", Grid: false, LightSquare: "·", DarkSquare: "░"}
+)
+
+// isDarkSquare reports the square color (a8, row 0 col 0, is light)
+func isDarkSquare(row, col int) bool {
+	return (row+col)%2 == 1
+}
+
+// renderGrid draws a bordered 8x8 board; cell returns each square's one-column content
+func renderGrid(cell func(row, col int) string, horizontal, top, middle, bottom, vertical string) string {
+	var sb strings.Builder
+	line := func(left, joint, right string) {
+		sb.WriteString("  " + left)
+		for col := 0; col < 8; col++ {
+			sb.WriteString(strings.Repeat(horizontal, 3))
+			if col < 7 {
+				sb.WriteString(joint)
+			}
+		}
+		sb.WriteString(right + "\n")
+	}
+	corners := func(set string) []string { return strings.Split(set, "") }
+
+	sb.WriteString("\n    a   b   c   d   e   f   g   h\n")
+	topCorners, middleCorners, bottomCorners := corners(top), corners(middle), corners(bottom)
+	line(topCorners[0], topCorners[1], topCorners[2])
+	for row := 0; row < 8; row++ {
+		fmt.Fprintf(&sb, "%d %s", 8-row, vertical)
+		for col := 0; col < 8; col++ {
+			fmt.Fprintf(&sb, " %s %s", cell(row, col), vertical)
+		}
+		fmt.Fprintf(&sb, " %d\n", 8-row)
+		if row < 7 {
+			line(middleCorners[0], middleCorners[1], middleCorners[2])
+		}
+	}
+	line(bottomCorners[0], bottomCorners[1], bottomCorners[2])
+	sb.WriteString("    a   b   c   d   e   f   g   h\n")
+	return sb.String()
+}
+
+// UnicodeRenderer draws the board with chess glyphs
+type UnicodeRenderer struct {
+	Theme BoardTheme
+}
+
+// Name returns the renderer name including its theme
+func (r *UnicodeRenderer) Name() string { return "Unicode (" + r.Theme.Name + ")" }
+
+// Render draws the game's board
+func (r *UnicodeRenderer) Render(g *Game) (string, error) {
+	return r.renderBoard(g.board), nil
+}
+
+// renderBoard draws a board in the renderer's theme
+func (r *UnicodeRenderer) renderBoard(b *Board) string {
+	cell := func(row, col int) string {
+		if piece := b.cells[row][col]; piece != nil {
+			return piece.GetSymbol()
+		}
+		if isDarkSquare(row, col) {
+			return r.Theme.DarkSquare
+		}
+		return r.Theme.LightSquare
+	}
+
+	if r.Theme.Grid {
+		return renderGrid(cell, "─", "┌┬┐", "├┼┤", "└┴┘", "│")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n  a b c d e f g h\n")
+	for row := 0; row < 8; row++ {
+		fmt.Fprintf(&sb, "%d", 8-row)
+		for col := 0; col < 8; col++ {
+			sb.WriteString(" " + cell(row, col))
+		}
+		fmt.Fprintf(&sb, " %d\n", 8-row)
+	}
+	sb.WriteString("  a b c d e f g h\n")
+	return sb.String()
+}
+
+// pieceLetter returns the FEN letter for a piece (uppercase = White)
+func pieceLetter(piece Piece) string {
+	letter := "P"
+	for symbol, pieceType := range backRankPieces {
+		if pieceType == piece.GetType() {
+			letter = string(symbol)
+		}
+	}
+	if piece.GetColor() == Black {
+		return strings.ToLower(letter)
+	}
+	return letter
+}
+
+// ASCIIRenderer draws the board with letters only (uppercase = White, '.' = empty)
+type ASCIIRenderer struct{}
+
+// Name returns the renderer name
+func (r *ASCIIRenderer) Name() string { return "ASCII" }
+
+// Render draws the game's board
+func (r *ASCIIRenderer) Render(g *Game) (string, error) {
+	cell := func(row, col int) string {
+		if piece := g.board.cells[row][col]; piece != nil {
+			return pieceLetter(piece)
+		}
+		return "."
+	}
+	return renderGrid(cell, "-", "+++", "+++", "+++", "|"), nil
+}
+
+// BoardStatePiece is one occupied square in the JSON board state
+type BoardStatePiece struct {
+	Square string `json:"square"`
+	Color  string `json:"color"`
+	Type   string `json:"type"`
+	Symbol string `json:"symbol"`
+}
+
+// BoardState is the JSON document produced by JSONRenderer
+type BoardState struct {
+	Variant        string            `json:"variant"`
+	Turn           string            `json:"turn"`
+	Status         string            `json:"status"`
+	Placement      string            `json:"placement"` // FEN piece placement field
+	Ranks          []string          `json:"ranks"`     // Rank 8 first, one letter or '.' per file
+	Pieces         []BoardStatePiece `json:"pieces"`    // Sorted by square
+	CastlingRights string            `json:"castling_rights"`
+	LastMove       string            `json:"last_move,omitempty"`
+	MoveCount      int               `json:"move_count"`
+	Result         string            `json:"result,omitempty"` // Set once the game is over
+}
+
+// JSONRenderer produces the board state as JSON for web frontends
+type JSONRenderer struct {
+	Indent bool // Pretty-print (for humans); compact otherwise
+}
+
+// Name returns the renderer name
+func (r *JSONRenderer) Name() string { return "JSON" }
+
+// Render encodes the game's board state
+func (r *JSONRenderer) Render(g *Game) (string, error) {
+	state := BoardState{
+		Variant:        g.variant.String(),
+		Turn:           g.currentTurn.String(),
+		Status:         g.status.String(),
+		Ranks:          make([]string, 0, 8),
+		Pieces:         make([]BoardStatePiece, 0, 32),
+		CastlingRights: g.board.CastlingRights(),
+		MoveCount:      len(g.moveHistory),
+	}
+	if len(g.moveHistory) > 0 {
+		state.LastMove = g.moveHistory[len(g.moveHistory)-1]
+	}
+	if g.IsOver() {
+		state.Result = g.pgnResult()
+	}
+
+	placement := make([]string, 0, 8)
+	for row := 0; row < 8; row++ {
+		rank, fenRank, emptyRun := "", "", 0
+		for col := 0; col < 8; col++ {
+			piece := g.board.cells[row][col]
+			if piece == nil {
+				rank += "."
+				emptyRun++
+				continue
+			}
+			letter := pieceLetter(piece)
+			rank += letter
+			if emptyRun > 0 {
+				fenRank += strconv.Itoa(emptyRun)
+				emptyRun = 0
+			}
+			fenRank += letter
+			state.Pieces = append(state.Pieces, BoardStatePiece{
+				Square: NewPosition(row, col).String(),
+				Color:  piece.GetColor().String(),
+				Type:   piece.GetType().String(),
+				Symbol: piece.GetSymbol(),
+			})
+		}
+		if emptyRun > 0 {
+			fenRank += strconv.Itoa(emptyRun)
+		}
+		state.Ranks = append(state.Ranks, rank)
+		placement = append(placement, fenRank)
+	}
+	state.Placement = strings.Join(placement, "/")
+	sort.Slice(state.Pieces, func(i, j int) bool { return state.Pieces[i].Square < state.Pieces[j].Square })
+
+	var data []byte
+	var err error
+	if r.Indent {
+		data, err = json.MarshalIndent(state, "", "  ")
+	} else {
+		data, err = json.Marshal(state)
+	}
+	if err != nil {
+		return "", fmt.Errorf("encoding board state: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// defaultRenderer is used by games that have not chosen one
+var defaultRenderer BoardRenderer = &UnicodeRenderer{Theme: ThemeClassic}
+
+// SetRenderer chooses how PrintBoard and RenderBoard draw the board (nil = default)
+func (g *Game) SetRenderer(renderer BoardRenderer) {
+	g.renderer = renderer
+}
+
+// GetRenderer returns the renderer the game draws its board with
+func (g *Game) GetRenderer() BoardRenderer {
+	if g.renderer == nil {
+		return defaultRenderer
+	}
+	return g.renderer
+}
+
+// RenderBoard returns the current position drawn by the game's renderer
+func (g *Game) RenderBoard() (string, error) {
+	return g.GetRenderer().Render(g)
+}
+
+// ========== OPENING BOOK ==========
+// An OpeningBook is a list of named opening lines, each a sequence of moves
+// in coordinate notation ("e2e4 e7e5 g1f3"). Loading a book replays every
+// line on a standard board and indexes the positions it passes through by
+// Zobrist hash, so:
+// - a Game with a book names its opening from the position it reaches
+//   (transpositions are recognized), keeping the last named one once play
+//   leaves the book
+// - BookEngine plays a book continuation while there is one, then hands
+//   over to a fallback engine
+//
+// Two formats are accepted. Text, one line per opening ('#' starts a comment):
+//   C50 | Italian Game | e2e4 e7e5 g1f3 b8c6 f1c4
+// JSON, an array of {"eco": "C50", "name": "Italian Game", "moves": ["e2e4", ...]}
+//
+// Lines must be legal for this move generator, so no castling or promotion.
+// Books only describe standard chess; Chess960 positions never match.
+
+// OpeningLine is one named opening in a book
+type OpeningLine struct {
+	ECO   string   `json:"eco"`   // Encyclopaedia of Chess Openings code (e.g. "C50")
+	Name  string   `json:"name"`  // Opening name (e.g. "Italian Game")
+	Moves []string `json:"moves"` // Moves in coordinate notation
+}
+
+// String formats the line as "C50 Italian Game"
+func (line OpeningLine) String() string {
+	if line.ECO == "" {
+		return line.Name
+	}
+	return line.ECO + " " + line.Name
+}
+
+// OpeningBook indexes opening lines by the positions they reach
+type OpeningBook struct {
+	lines         []OpeningLine
+	named         map[uint64]int           // Position hash -> index of the line ending there
+	continuations map[uint64][][2]Position // Position hash -> book moves from it (in load order)
+}
+
+// NewOpeningBook validates and indexes the given lines
+// When two lines end on the same position, the first one names it
+func NewOpeningBook(lines []OpeningLine) (*OpeningBook, error) {
+	book := &OpeningBook{
+		lines:         make([]OpeningLine, 0, len(lines)),
+		named:         make(map[uint64]int),
+		continuations: make(map[uint64][][2]Position),
+	}
+	for _, line := range lines {
+		if err := book.add(line); err != nil {
+			return nil, err
+		}
+	}
+	return book, nil
+}
+
+// add replays one line on a fresh board and indexes every position on the way
+func (book *OpeningBook) add(line OpeningLine) error {
+	if line.Name == "" {
+		return fmt.Errorf("opening line has no name")
+	}
+	if len(line.Moves) == 0 {
+		return fmt.Errorf("opening %q has no moves", line.Name)
+	}
+
+	replay := NewGame("Book", "Book")
+	replay.SetQuiet(true)
+	for ply, notation := range line.Moves {
+		from, to, err := parseCoordinateMove(notation)
+		if err != nil {
+			return fmt.Errorf("opening %q, move %d: %v", line.Name, ply+1, err)
+		}
+		hash := replay.PositionHash()
+		if err := replay.Move(from, to); err != nil {
+			return fmt.Errorf("opening %q, move %d (%s): %v", line.Name, ply+1, notation, err)
+		}
+		if !containsMove(book.continuations[hash], from, to) {
+			book.continuations[hash] = append(book.continuations[hash], [2]Position{from, to})
+		}
+	}
+
+	book.lines = append(book.lines, line)
+	if _, exists := book.named[replay.PositionHash()]; !exists {
+		book.named[replay.PositionHash()] = len(book.lines) - 1
+	}
+	return nil
+}
+
+// parseCoordinateMove parses a move like "e2e4" into its two squares
+func parseCoordinateMove(notation string) (Position, Position, error) {
+	if len(notation) != 4 {
+		return Position{}, Position{}, fmt.Errorf("invalid move %q (expected e.g. e2e4)", notation)
+	}
+	from, err := ParsePosition(notation[:2])
+	if err != nil {
+		return Position{}, Position{}, err
+	}
+	to, err := ParsePosition(notation[2:])
+	if err != nil {
+		return Position{}, Position{}, err
+	}
+	return from, to, nil
+}
+
+// containsMove reports whether the move is already in the list
+func containsMove(moves [][2]Position, from, to Position) bool {
+	for _, move := range moves {
+		if move[0] == from && move[1] == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOpeningBookText loads a book from "ECO | Name | moves" lines
+// The ECO code may be left empty; blank lines and '#' comments are skipped
+func ParseOpeningBookText(text string) (*OpeningBook, error) {
+	lines := make([]OpeningLine, 0)
+	for number, raw := range strings.Split(text, "\n") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || strings.HasPrefix(raw, "#") {
+			continue
+		}
+		fields := strings.Split(raw, "|")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected \"ECO | Name | moves\", got %q", number+1, raw)
+		}
+		lines = append(lines, OpeningLine{
+			ECO:   strings.TrimSpace(fields[0]),
+			Name:  strings.TrimSpace(fields[1]),
+			Moves: strings.Fields(fields[2]),
+		})
+	}
+	return NewOpeningBook(lines)
+}
+
+// ParseOpeningBookJSON loads a book from a JSON array of opening lines
+func ParseOpeningBookJSON(data []byte) (*OpeningBook, error) {
+	var lines []OpeningLine
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return nil, fmt.Errorf("invalid opening book: %v", err)
+	}
+	return NewOpeningBook(lines)
+}
+
+// Len returns the number of opening lines in the book
+func (book *OpeningBook) Len() int {
+	return len(book.lines)
+}
+
+// Identify returns the opening whose line ends on the game's current position
+func (book *OpeningBook) Identify(g *Game) (OpeningLine, bool) {
+	index, found := book.named[g.PositionHash()]
+	if !found {
+		return OpeningLine{}, false
+	}
+	return book.lines[index], true
+}
+
+// BookMoves returns the book's legal continuations from the game's current position
+func (book *OpeningBook) BookMoves(g *Game) [][2]Position {
+	moves := make([][2]Position, 0)
+	if g.IsOver() {
+		return moves
+	}
+	for _, move := range book.continuations[g.PositionHash()] {
+		if valid, _ := g.IsValidMove(move[0], move[1]); valid {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// SetOpeningBook lets the game name its opening as it is played (nil = off)
+// The current position is checked immediately, so a book can be attached mid-game
+func (g *Game) SetOpeningBook(book *OpeningBook) {
+	g.openingBook = book
+	g.opening = nil
+	g.trackOpening()
+}
+
+// GetOpening returns the most recent named opening the game has reached
+func (g *Game) GetOpening() (OpeningLine, bool) {
+	if g.opening == nil {
+		return OpeningLine{}, false
+	}
+	return *g.opening, true
+}
+
+// trackOpening records the opening named by the current position, if any
+func (g *Game) trackOpening() {
+	if g.openingBook == nil {
+		return
+	}
+	if line, found := g.openingBook.Identify(g); found {
+		g.opening = &line
+	}
+}
+
+// BookEngine plays from an opening book, then defers to another engine
+type BookEngine struct {
+	book     *OpeningBook
+	fallback Engine
+	rng      *rand.Rand
+}
+
+// NewBookEngine wraps fallback with a book; the seed picks among book moves reproducibly
+func NewBookEngine(book *OpeningBook, fallback Engine, seed int64) *BookEngine {
+	return &BookEngine{book: book, fallback: fallback, rng: rand.New(rand.NewSource(seed))}
+}
+
+// Name returns the engine's display name
+func (e *BookEngine) Name() string { return "Book+" + e.fallback.Name() }
+
+// ChooseMove plays a random book continuation while the game is in book
+func (e *BookEngine) ChooseMove(g *Game) (Position, Position, bool) {
+	if moves := e.book.BookMoves(g); len(moves) > 0 {
+		move := moves[e.rng.Intn(len(moves))]
+		return move[0], move[1], true
+	}
+	return e.fallback.ChooseMove(g)
+}
@@ -0,0 +1,27 @@
+package chess
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestPerft checks the move generator against the reference perft counts.
+// Cases that need special moves the generator does not play yet are skipped;
+// any other mismatch is a move generation bug.
+func TestPerft(t *testing.T) {
+	for _, perftCase := range StandardPerftSuite {
+		t.Run(fmt.Sprintf("%s/d%d", perftCase.Name, perftCase.Depth), func(t *testing.T) {
+			if missing := perftCase.Needs &^ SupportedSpecialMoves; missing != 0 {
+				t.Skipf("needs %s", missing)
+			}
+
+			game, err := NewGameFromFEN(perftCase.FEN)
+			if err != nil {
+				t.Fatalf("NewGameFromFEN(%q): %v", perftCase.FEN, err)
+			}
+			if nodes := game.Perft(perftCase.Depth); nodes != perftCase.Expected {
+				t.Errorf("Perft(%d) = %d, want %d", perftCase.Depth, nodes, perftCase.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package chesshash implements Zobrist hashing for chess positions.
+//
+// Zobrist hashing gives every (color, piece type, square) combination a random
+// 64-bit key. A position's hash is the XOR of the keys of all pieces on the
+// board, plus a side-to-move key when Black is to move and one key per
+// castling right still available.
+//
+// Because XOR is its own inverse, a move only needs to XOR out the piece on
+// its old square (and any captured piece) and XOR in the piece on its new
+// square - the hash is updated incrementally instead of rescanning 64 squares.
+//
+// The table works on plain indexes (color 0-1, piece type 0-5, square 0-63,
+// row*8+col), so it can back transposition tables or repetition detection in
+// any board model that numbers its colors and piece types the same way.
+package chesshash
+
+import "math/rand"
+
+// Castling wings, used to index CastlingKey
+const (
+	KingSide  = 0 // Castling with the rook on the higher file
+	QueenSide = 1 // Castling with the rook on the lower file
+)
+
+// Table holds the random keys positions are hashed with
+type Table struct {
+	pieceKeys    [2][6][64]uint64 // [color][pieceType][square] -> random key
+	blackToMove  uint64           // XORed in when it is Black's turn
+	castlingKeys [2][2]uint64     // [color][wing] -> XORed in while that right is held
+}
+
+// NewTable generates a key table from a fixed seed so hashes are
+// reproducible across runs
+func NewTable(seed int64) *Table {
+	rng := rand.New(rand.NewSource(seed))
+	table := &Table{}
+	for color := 0; color < 2; color++ {
+		for pieceType := 0; pieceType < 6; pieceType++ {
+			for square := 0; square < 64; square++ {
+				table.pieceKeys[color][pieceType][square] = rng.Uint64()
+			}
+		}
+	}
+	table.blackToMove = rng.Uint64()
+	for color := 0; color < 2; color++ {
+		for wing := 0; wing < 2; wing++ {
+			table.castlingKeys[color][wing] = rng.Uint64()
+		}
+	}
+	return table
+}
+
+// PieceKey returns the key for a piece of the given color and type on a square
+func (t *Table) PieceKey(color, pieceType, square int) uint64 {
+	return t.pieceKeys[color][pieceType][square]
+}
+
+// SideKey returns the key applied when Black is to move
+func (t *Table) SideKey() uint64 {
+	return t.blackToMove
+}
+
+// CastlingKey returns the key applied while color may still castle on wing
+// (KingSide or QueenSide)
+func (t *Table) CastlingKey(color, wing int) uint64 {
+	return t.castlingKeys[color][wing]
+}
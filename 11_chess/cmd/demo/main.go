@@ -0,0 +1,8 @@
+// Command demo runs the chess walkthrough: go run ./11_chess/cmd/demo
+package main
+
+import chess "github.com/ayushgupta5/GoLLD/11_chess"
+
+func main() {
+	chess.RunDemo()
+}
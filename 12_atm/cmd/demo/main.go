@@ -0,0 +1,8 @@
+// Command demo runs the atm walkthrough: go run ./12_atm/cmd/demo
+package main
+
+import atm "github.com/ayushgupta5/GoLLD/12_atm"
+
+func main() {
+	atm.RunDemo()
+}
@@ -0,0 +1,114 @@
+package atm
+
+import "fmt"
+
+// ============================================================================
+// SECTION 7: MAIN - Demonstration of the ATM system
+// ============================================================================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("           🏧 ATM MACHINE DEMO")
+	fmt.Println("═══════════════════════════════════════════")
+
+	// Step 1: Create a new ATM machine
+	atm := NewATM("ATM-001", "Main Street Branch")
+
+	// Step 2: Set up bank accounts (simulating bank database)
+	johnAccount := NewAccount("ACC001", "John Doe", 5000.00)
+	janeAccount := NewAccount("ACC002", "Jane Smith", 10000.00)
+	atm.RegisterAccount(johnAccount)
+	atm.RegisterAccount(janeAccount)
+
+	// Step 3: Register cards (simulating card issuance)
+	johnCard := NewCard("4111111111111111", "1234", "ACC001")
+	janeCard := NewCard("4222222222222222", "5678", "ACC002")
+	atm.RegisterCard(johnCard)
+	atm.RegisterCard(janeCard)
+
+	atm.DisplayCashStatus()
+
+	// ========== DEMO: John Doe's ATM Session ==========
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📌 USER SESSION: John Doe")
+	fmt.Println("─────────────────────────────────────────")
+
+	// Step 4: Insert card
+	err := atm.InsertCard("4111111111111111")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Step 5: Demonstrate wrong PIN handling
+	fmt.Println("\n⚠️  Attempting authentication with wrong PIN...")
+	err = atm.EnterPIN("0000") // Wrong PIN
+	if err != nil {
+		fmt.Printf("   ❌ %v\n", err)
+	}
+
+	// Step 6: Insert card again and use correct PIN
+	err = atm.InsertCard("4111111111111111")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	err = atm.EnterPIN("1234") // Correct PIN
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Step 7: Check balance
+	fmt.Println("\n📋 Checking account balance...")
+	_, err = atm.CheckBalance()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Step 8: Withdraw cash
+	fmt.Println("\n💸 Withdrawing $280...")
+	err = atm.Withdraw(280)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Step 9: Deposit cash
+	fmt.Println("\n💰 Depositing $500...")
+	err = atm.Deposit(500)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Step 10: Check final balance
+	fmt.Println("\n📋 Checking final balance...")
+	_, err = atm.CheckBalance()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Step 11: End session
+	fmt.Println()
+	atm.EjectCard()
+
+	atm.DisplayCashStatus()
+
+	// ========== Summary of Design Patterns Used ==========
+	fmt.Println("\n═══════════════════════════════════════════")
+	fmt.Println("  KEY DESIGN PATTERNS DEMONSTRATED:")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  1. STATE PATTERN: ATM transitions through")
+	fmt.Println("     states (Idle → CardInserted → Authenticated)")
+	fmt.Println()
+	fmt.Println("  2. CHAIN OF RESPONSIBILITY: Cash dispensers")
+	fmt.Println("     are chained ($100 → $50 → $20 → $10)")
+	fmt.Println()
+	fmt.Println("  3. THREAD SAFETY: All shared data protected")
+	fmt.Println("     with mutex locks for concurrent access")
+	fmt.Println()
+	fmt.Println("  4. TRANSACTION LOGGING: All operations are")
+	fmt.Println("     recorded for audit purposes")
+	fmt.Println("═══════════════════════════════════════════")
+}
@@ -0,0 +1,8 @@
+// Command demo runs the logger walkthrough: go run ./13_logger/cmd/demo
+package main
+
+import logger "github.com/ayushgupta5/GoLLD/13_logger"
+
+func main() {
+	logger.RunDemo()
+}
@@ -0,0 +1,2489 @@
+// Package logger is a leveled logging framework with pluggable handlers,
+// formatters and sinks.
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// ============================================================
+// LOGGER SYSTEM - Low Level Design
+// ============================================================
+//
+// This logger system demonstrates several important design patterns:
+//
+// 1. SINGLETON PATTERN: Ensures only one logger instance exists globally
+// 2. STRATEGY PATTERN: Different handlers (console, file) can be swapped
+// 3. CHAIN OF RESPONSIBILITY: Filters process messages in sequence
+// 4. THREAD SAFETY: Uses mutexes to prevent race conditions
+// 5. ADAPTER PATTERN: SlogHandler lets log/slog use this logger as a backend,
+//    and Logger.Writer lets io.Writer-only libraries log through it
+// 6. COMPOSITE PATTERN: TeeHandler fans one message out to several handlers
+// 7. CONFIGURATION PROFILES: one call sets up handlers, formats and levels
+//    for development, staging or production
+// 8. REDACTION: a filter masks card numbers, emails, API keys and secret
+//    fields before any handler writes them
+// 9. BATCHING: BatchingFileHandler buffers lines and writes them in one
+//    syscall per batch, with a configurable fsync policy
+// 10. RUNTIME RELOAD: LOG_LEVEL overrides the profile's level; Reload() or
+//     SIGHUP re-reads the environment without restarting the process
+// 11. TEST CAPTURE: MemoryHandler records messages so unit tests can
+//     assert on what was logged (WithCapturedLogs)
+// 12. LEVEL ROUTING: RoutingHandler sends each level to one destination
+//     (file, console, alerting webhook) from a single declarative spec
+// 13. CHILD LOGGERS: With("request_id", id) derives a logger that attaches
+//     fields to every message while sharing the root's handlers and filters
+// 14. COLOR DETECTION: ConsoleHandler colors only real terminals, honors
+//     NO_COLOR, and takes per-handler overrides and per-level colors
+//
+// ============================================================
+
+// ==================== LOG LEVEL ====================
+// LogLevel represents the severity of a log message.
+// Lower values = less severe, Higher values = more severe.
+
+type LogLevel int
+
+const (
+	DEBUG LogLevel = iota // 0 - Detailed debugging information
+	INFO                  // 1 - General informational messages
+	WARN                  // 2 - Warning messages for potential issues
+	ERROR                 // 3 - Error messages for failures
+	FATAL                 // 4 - Critical errors that may crash the app
+)
+
+// logLevelNames maps LogLevel to human-readable strings
+var logLevelNames = []string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// String returns the string representation of a LogLevel
+func (level LogLevel) String() string {
+	if level < DEBUG || level > FATAL {
+		return "UNKNOWN"
+	}
+	return logLevelNames[level]
+}
+
+// logLevelColors maps LogLevel to terminal color codes
+var logLevelColors = map[LogLevel]string{
+	DEBUG: "\033[36m", // Cyan - for debug messages
+	INFO:  "\033[32m", // Green - for info messages
+	WARN:  "\033[33m", // Yellow - for warnings
+	ERROR: "\033[31m", // Red - for errors
+	FATAL: "\033[35m", // Magenta - for fatal errors
+}
+
+// Color returns the ANSI color code for the log level
+func (level LogLevel) Color() string {
+	if color, exists := logLevelColors[level]; exists {
+		return color
+	}
+	return "" // No color for unknown levels
+}
+
+// ==================== LOG MESSAGE ====================
+// LogMessage holds all information about a single log entry.
+
+type LogMessage struct {
+	Level       LogLevel  // Severity level of the message
+	Message     string    // The actual log content
+	Timestamp   time.Time // When the message was created
+	Source      string    // Which component generated this log
+	Caller      string    // "file.go:line" of the call site (empty unless a handler asked for it)
+	GoroutineID uint64    // ID of the logging goroutine (0 unless a handler asked for it)
+}
+
+// NewLogMessage creates a new log message with the current timestamp
+func NewLogMessage(level LogLevel, message string, source string) *LogMessage {
+	return &LogMessage{
+		Level:     level,
+		Message:   message,
+		Timestamp: time.Now(),
+		Source:    source,
+	}
+}
+
+// ==================== CALLER INFO ====================
+// Capturing the call site (runtime.Caller) and goroutine ID (runtime.Stack)
+// is relatively expensive, so it is opt-in per handler. The logger only
+// captures what at least one registered handler has asked for.
+
+// CallerOptions selects which runtime details a handler prints.
+type CallerOptions struct {
+	IncludeCaller      bool // Print "file.go:line" of the logging call
+	IncludeGoroutineID bool // Print the ID of the logging goroutine
+}
+
+// CallerAwareHandler is implemented by handlers that can print caller details.
+type CallerAwareHandler interface {
+	GetCallerOptions() CallerOptions
+}
+
+// captureCaller returns "file.go:line" for the frame `skip` levels above its caller
+func captureCaller(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "???:0"
+	}
+	return filepath.Base(file) + ":" + strconv.Itoa(line)
+}
+
+// callerFromPC returns "file.go:line" for a program counter recorded elsewhere
+// (e.g. slog.Record.PC), so the call site is known without walking the stack
+func callerFromPC(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if frame.File == "" {
+		return "???:0"
+	}
+	return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+}
+
+// currentGoroutineID parses the goroutine ID from the first line of the stack
+// trace ("goroutine 42 [running]:"). Go deliberately doesn't expose this ID,
+// so it should only be used for debugging output.
+func currentGoroutineID() uint64 {
+	buffer := make([]byte, 64)
+	buffer = buffer[:runtime.Stack(buffer, false)]
+	buffer = bytes.TrimPrefix(buffer, []byte("goroutine "))
+	if end := bytes.IndexByte(buffer, ' '); end >= 0 {
+		buffer = buffer[:end]
+	}
+	id, _ := strconv.ParseUint(string(buffer), 10, 64)
+	return id
+}
+
+// formatCallerInfo renders the caller details a handler opted into,
+// e.g. " (main.go:42 g=7)", or "" if none
+func formatCallerInfo(message *LogMessage, options CallerOptions) string {
+	parts := ""
+	if options.IncludeCaller && message.Caller != "" {
+		parts = message.Caller
+	}
+	if options.IncludeGoroutineID && message.GoroutineID != 0 {
+		if parts != "" {
+			parts += " "
+		}
+		parts += fmt.Sprintf("g=%d", message.GoroutineID)
+	}
+	if parts == "" {
+		return ""
+	}
+	return " (" + parts + ")"
+}
+
+// ==================== LOG FORMAT ====================
+// LogFormat selects how handlers render a message: human-readable text or
+// one JSON object per line for log shippers (ELK, Loki, CloudWatch...).
+
+type LogFormat int
+
+const (
+	FormatText LogFormat = iota // [timestamp] LEVEL [source] (caller) message
+	FormatJSON                  // {"time":...,"level":...,"source":...,"message":...}
+)
+
+// logFormatNames maps LogFormat to human-readable strings
+var logFormatNames = []string{"text", "json"}
+
+// String returns the string representation of a LogFormat
+func (format LogFormat) String() string {
+	if format < FormatText || format > FormatJSON {
+		return "unknown"
+	}
+	return logFormatNames[format]
+}
+
+// jsonLogLine is the JSON shape of one log message
+type jsonLogLine struct {
+	Time        string `json:"time"`
+	Level       string `json:"level"`
+	Source      string `json:"source"`
+	Caller      string `json:"caller,omitempty"`
+	GoroutineID uint64 `json:"goroutine,omitempty"`
+	Message     string `json:"message"`
+}
+
+// formatLogLine renders a message (without color or trailing newline)
+func formatLogLine(message *LogMessage, format LogFormat, options CallerOptions) string {
+	if format == FormatJSON {
+		line := jsonLogLine{
+			Time:    message.Timestamp.Format(time.RFC3339Nano),
+			Level:   message.Level.String(),
+			Source:  message.Source,
+			Message: message.Message,
+		}
+		if options.IncludeCaller {
+			line.Caller = message.Caller
+		}
+		if options.IncludeGoroutineID {
+			line.GoroutineID = message.GoroutineID
+		}
+		encoded, err := json.Marshal(line)
+		if err != nil {
+			return fmt.Sprintf(`{"level":"ERROR","message":%q}`, err.Error())
+		}
+		return string(encoded)
+	}
+
+	return fmt.Sprintf("[%s] %s [%s]%s %s",
+		message.Timestamp.Format("2006-01-02 15:04:05"),
+		message.Level,
+		message.Source,
+		formatCallerInfo(message, options),
+		message.Message,
+	)
+}
+
+// ==================== LOG HANDLER INTERFACE ====================
+// LogHandler defines how log messages are output (console, file, etc.)
+// This is the STRATEGY PATTERN - different strategies for handling logs.
+
+type LogHandler interface {
+	// Handle processes and outputs a log message
+	Handle(message *LogMessage)
+
+	// SetLevel sets the minimum level this handler will process
+	SetLevel(level LogLevel)
+
+	// GetLevel returns the current minimum level
+	GetLevel() LogLevel
+}
+
+// ==================== CONSOLE HANDLER ====================
+// ConsoleHandler outputs log messages to the terminal (stdout).
+
+type ConsoleHandler struct {
+	minimumLevel  LogLevel            // Only log messages at or above this level
+	colorMode     ColorMode           // When to use colored output (see CONSOLE COLORS)
+	levelColors   map[LogLevel]string // Per-level color overrides (nil = defaults)
+	output        io.Writer           // Where lines are written (default os.Stdout)
+	format        LogFormat           // Text or JSON lines
+	callerOptions CallerOptions       // Which caller details to print
+	mutex         sync.Mutex          // Prevents concurrent writes from mixing up
+
+	colorResolved bool // colorDecision is valid for the current mode and output
+	colorDecision bool // Cached ColorAuto result
+}
+
+// NewConsoleHandler creates a handler that writes to the console
+// Colors are on when stdout is a terminal that supports them (ColorAuto)
+func NewConsoleHandler(minimumLevel LogLevel) *ConsoleHandler {
+	return &ConsoleHandler{
+		minimumLevel: minimumLevel,
+		colorMode:    ColorAuto,
+		output:       os.Stdout,
+	}
+}
+
+// SetLevel changes the minimum log level
+func (handler *ConsoleHandler) SetLevel(level LogLevel) {
+	handler.minimumLevel = level
+}
+
+// GetLevel returns the current minimum log level
+func (handler *ConsoleHandler) GetLevel() LogLevel {
+	return handler.minimumLevel
+}
+
+// SetCallerOptions enables or disables caller file:line and goroutine ID output
+func (handler *ConsoleHandler) SetCallerOptions(options CallerOptions) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.callerOptions = options
+}
+
+// GetCallerOptions returns which caller details this handler prints
+func (handler *ConsoleHandler) GetCallerOptions() CallerOptions {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	return handler.callerOptions
+}
+
+// SetColors enables colors where supported (ColorAuto) or disables them
+// (ColorNever); use SetColorMode(ColorAlways) to force them on
+func (handler *ConsoleHandler) SetColors(useColors bool) {
+	mode := ColorNever
+	if useColors {
+		mode = ColorAuto
+	}
+	handler.SetColorMode(mode)
+}
+
+// SetFormat switches between text and JSON output
+func (handler *ConsoleHandler) SetFormat(format LogFormat) {
+	handler.format = format
+}
+
+// Handle writes the log message to console if it meets the level threshold
+func (handler *ConsoleHandler) Handle(message *LogMessage) {
+	// Skip messages below our minimum level
+	if message.Level < handler.minimumLevel {
+		return
+	}
+
+	// Lock to prevent garbled output from concurrent goroutines
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	// ANSI reset code to clear color after the message
+	const colorReset = "\033[0m"
+
+	logLine := formatLogLine(message, handler.format, handler.callerOptions)
+
+	// JSON lines are never colored - escape codes would break parsers
+	color := ""
+	if handler.format == FormatText && handler.colorsEnabledLocked() {
+		color = handler.levelColorLocked(message.Level)
+	}
+	if color != "" {
+		fmt.Fprintf(handler.output, "%s%s%s\n", color, logLine, colorReset)
+	} else {
+		fmt.Fprintln(handler.output, logLine)
+	}
+}
+
+// ==================== CONSOLE COLORS ====================
+// ANSI escape codes only belong on a terminal that understands them. Piped
+// into a file, jq or a log shipper they are noise, so a ConsoleHandler
+// decides per handler whether to color:
+//
+//	ColorAuto   -> color only if the output is a terminal, NO_COLOR is unset
+//	               and the terminal supports ANSI (default)
+//	ColorAlways -> always color (an explicit choice overrides NO_COLOR)
+//	ColorNever  -> never color
+//
+// NO_COLOR follows https://no-color.org: any non-empty value disables color.
+// Legacy Windows consoles print escape codes literally, so on Windows auto
+// mode colors only in terminals known to handle ANSI (Windows Terminal,
+// ConEmu, ANSICON, or one that sets TERM such as mintty).
+
+// NoColorEnvVar disables colors for handlers in ColorAuto mode when non-empty
+const NoColorEnvVar = "NO_COLOR"
+
+// ColorMode decides when a ConsoleHandler emits ANSI colors
+type ColorMode int
+
+const (
+	ColorAuto   ColorMode = iota // Color when the output supports it
+	ColorAlways                  // Always color, even when piped or NO_COLOR is set
+	ColorNever                   // Never color
+)
+
+// colorModeNames maps ColorMode to the names ParseColorMode accepts
+var colorModeNames = []string{"auto", "always", "never"}
+
+// String returns the name of the color mode
+func (mode ColorMode) String() string {
+	if mode < ColorAuto || mode > ColorNever {
+		return "unknown"
+	}
+	return colorModeNames[mode]
+}
+
+// ParseColorMode converts "auto", "always" or "never" to a ColorMode
+func ParseColorMode(name string) (ColorMode, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	for index, modeName := range colorModeNames {
+		if modeName == normalized {
+			return ColorMode(index), nil
+		}
+	}
+	return ColorAuto, fmt.Errorf("invalid color mode %q (want one of %s)", name, strings.Join(colorModeNames, ", "))
+}
+
+// ansiColors maps the color names accepted by SetLevelColor to ANSI codes
+var ansiColors = map[string]string{
+	"none":    "", // Leave the level uncolored
+	"black":   "\033[30m",
+	"red":     "\033[31m",
+	"green":   "\033[32m",
+	"yellow":  "\033[33m",
+	"blue":    "\033[34m",
+	"magenta": "\033[35m",
+	"cyan":    "\033[36m",
+	"white":   "\033[37m",
+	"gray":    "\033[90m",
+
+	"bright-red":     "\033[91m",
+	"bright-green":   "\033[92m",
+	"bright-yellow":  "\033[93m",
+	"bright-blue":    "\033[94m",
+	"bright-magenta": "\033[95m",
+	"bright-cyan":    "\033[96m",
+	"bright-white":   "\033[97m",
+}
+
+// isTerminal reports whether the writer is a character device (a TTY)
+// rather than a pipe, regular file or in-memory buffer
+func isTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalSupportsANSI reports whether the current terminal interprets ANSI codes
+func terminalSupportsANSI() bool {
+	term := os.Getenv("TERM")
+	if term == "dumb" {
+		return false
+	}
+	if runtime.GOOS != "windows" {
+		return true
+	}
+	return os.Getenv("WT_SESSION") != "" || os.Getenv("ANSICON") != "" ||
+		strings.EqualFold(os.Getenv("ConEmuANSI"), "ON") || term != ""
+}
+
+// SetColorMode overrides when this handler colors its output
+func (handler *ConsoleHandler) SetColorMode(mode ColorMode) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.colorMode = mode
+	handler.colorResolved = false
+}
+
+// GetColorMode returns the handler's color mode
+func (handler *ConsoleHandler) GetColorMode() ColorMode {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	return handler.colorMode
+}
+
+// SetOutput redirects the handler (default os.Stdout); ColorAuto re-checks
+// whether the new destination is a terminal
+func (handler *ConsoleHandler) SetOutput(writer io.Writer) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.output = writer
+	handler.colorResolved = false
+}
+
+// SetLevelColor sets the color of one level by name ("red", "bright-cyan",
+// "none", ...), replacing the level's default color for this handler only
+func (handler *ConsoleHandler) SetLevelColor(level LogLevel, colorName string) error {
+	code, exists := ansiColors[strings.ToLower(strings.TrimSpace(colorName))]
+	if !exists {
+		return fmt.Errorf("unknown color %q for level %s", colorName, level)
+	}
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	if handler.levelColors == nil {
+		handler.levelColors = make(map[LogLevel]string)
+	}
+	handler.levelColors[level] = code
+	return nil
+}
+
+// ColorsEnabled reports whether the handler currently emits ANSI colors
+func (handler *ConsoleHandler) ColorsEnabled() bool {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	return handler.colorsEnabledLocked()
+}
+
+// colorsEnabledLocked resolves the color mode; the auto decision is cached
+// until the mode or output changes. Caller must hold handler.mutex.
+func (handler *ConsoleHandler) colorsEnabledLocked() bool {
+	switch handler.colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if !handler.colorResolved {
+		handler.colorDecision = os.Getenv(NoColorEnvVar) == "" &&
+			isTerminal(handler.output) && terminalSupportsANSI()
+		handler.colorResolved = true
+	}
+	return handler.colorDecision
+}
+
+// levelColorLocked returns the color for a level, honoring per-handler
+// overrides. Caller must hold handler.mutex.
+func (handler *ConsoleHandler) levelColorLocked(level LogLevel) string {
+	if code, overridden := handler.levelColors[level]; overridden {
+		return code
+	}
+	return level.Color()
+}
+
+// ==================== FILE HANDLER ====================
+// FileHandler writes log messages to a file for persistent storage.
+
+type FileHandler struct {
+	minimumLevel  LogLevel      // Only log messages at or above this level
+	filePath      string        // Path to the log file
+	file          *os.File      // The open file handle
+	format        LogFormat     // Text or JSON lines
+	callerOptions CallerOptions // Which caller details to write
+	mutex         sync.Mutex    // Prevents concurrent writes
+}
+
+// NewFileHandler creates a handler that writes to a file
+// Returns an error if the file cannot be opened/created
+func NewFileHandler(minimumLevel LogLevel, filePath string) (*FileHandler, error) {
+	// Open file in append mode, create if doesn't exist
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	return &FileHandler{
+		minimumLevel: minimumLevel,
+		filePath:     filePath,
+		file:         file,
+	}, nil
+}
+
+// SetLevel changes the minimum log level
+func (handler *FileHandler) SetLevel(level LogLevel) {
+	handler.minimumLevel = level
+}
+
+// GetLevel returns the current minimum log level
+func (handler *FileHandler) GetLevel() LogLevel {
+	return handler.minimumLevel
+}
+
+// SetCallerOptions enables or disables caller file:line and goroutine ID output
+func (handler *FileHandler) SetCallerOptions(options CallerOptions) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.callerOptions = options
+}
+
+// GetCallerOptions returns which caller details this handler writes
+func (handler *FileHandler) GetCallerOptions() CallerOptions {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	return handler.callerOptions
+}
+
+// SetFormat switches between text and JSON output
+func (handler *FileHandler) SetFormat(format LogFormat) {
+	handler.format = format
+}
+
+// Handle writes the log message to file if it meets the level threshold
+func (handler *FileHandler) Handle(message *LogMessage) {
+	// Skip messages below our minimum level
+	if message.Level < handler.minimumLevel {
+		return
+	}
+
+	// Lock to prevent file corruption from concurrent writes
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	// Format the log line (no colors in files)
+	logLine := formatLogLine(message, handler.format, handler.callerOptions)
+
+	// Write to file (ignoring errors for simplicity)
+	_, _ = handler.file.WriteString(logLine + "\n")
+}
+
+// Close closes the log file - always call this when done!
+func (handler *FileHandler) Close() error {
+	if handler.file != nil {
+		return handler.file.Close()
+	}
+	return nil
+}
+
+// ==================== BATCHING FILE HANDLER ====================
+// BatchingFileHandler is a FileHandler that collects formatted lines in
+// memory and writes them with a single syscall per batch. A batch is flushed
+// when it reaches MaxBatchLines or MaxBatchBytes, when FlushInterval passes,
+// when a message at FlushLevel or above arrives (so errors are never stuck
+// in memory), and on Flush/Close.
+//
+// The fsync policy decides how durable a flushed batch is:
+// - SyncNever:      leave it to the OS (fastest, may lose data on power loss)
+// - SyncInterval:   fsync at most once per SyncInterval
+// - SyncEveryBatch: fsync after every write (slowest, safest)
+//
+// Lines still in the buffer are lost if the process crashes, so always
+// Close the handler on shutdown.
+
+// SyncPolicy controls when a BatchingFileHandler calls fsync
+type SyncPolicy int
+
+const (
+	SyncNever      SyncPolicy = iota // Never fsync; the OS writes back on its own
+	SyncInterval                     // fsync at most once per SyncInterval
+	SyncEveryBatch                   // fsync after every batch write
+)
+
+// String returns the policy name
+func (policy SyncPolicy) String() string {
+	names := [...]string{"never", "interval", "every-batch"}
+	if int(policy) < len(names) {
+		return names[policy]
+	}
+	return "unknown"
+}
+
+// BatchOptions configures a BatchingFileHandler
+type BatchOptions struct {
+	MaxBatchLines int           // Flush after this many lines
+	MaxBatchBytes int           // Flush once the buffer reaches this size
+	FlushInterval time.Duration // Flush at least this often (0 = only on size)
+	FlushLevel    LogLevel      // Messages at this level or above flush immediately
+	Sync          SyncPolicy    // When to fsync after a flush
+	SyncInterval  time.Duration // Minimum time between fsyncs for SyncInterval
+}
+
+// DefaultBatchOptions flushes every 256 lines, 64 KiB or 200ms, and on
+// ERROR and above, with at most one fsync per second
+func DefaultBatchOptions() BatchOptions {
+	return BatchOptions{
+		MaxBatchLines: 256,
+		MaxBatchBytes: 64 * 1024,
+		FlushInterval: 200 * time.Millisecond,
+		FlushLevel:    ERROR,
+		Sync:          SyncInterval,
+		SyncInterval:  time.Second,
+	}
+}
+
+// BatchStats counts what the handler has done so far
+type BatchStats struct {
+	Messages int // Lines accepted
+	Writes   int // Write syscalls (one per flushed batch)
+	Syncs    int // fsync calls
+	Pending  int // Lines currently buffered
+}
+
+// BatchingFileHandler writes log lines to a file in batches
+type BatchingFileHandler struct {
+	minimumLevel  LogLevel      // Only log messages at or above this level
+	filePath      string        // Path to the log file
+	file          *os.File      // The open file handle
+	format        LogFormat     // Text or JSON lines
+	callerOptions CallerOptions // Which caller details to write
+	options       BatchOptions  // Flush and fsync policy
+	buffer        bytes.Buffer  // Formatted lines waiting to be written
+	pendingLines  int           // Number of lines in buffer
+	lastSync      time.Time     // When fsync last ran
+	stats         BatchStats    // Counters for monitoring and the demo
+	writeErr      error         // First write/sync error, reported by Close
+	stopFlusher   chan struct{} // Closed to stop the interval flusher
+	flusherDone   chan struct{} // Closed when the flusher has exited
+	closed        bool          // Set by Close; later messages are dropped
+	mutex         sync.Mutex    // Protects the buffer and the file
+}
+
+// NewBatchingFileHandler creates a batching handler that appends to a file
+// Returns an error if the file cannot be opened or the options are invalid
+func NewBatchingFileHandler(minimumLevel LogLevel, filePath string, options BatchOptions) (*BatchingFileHandler, error) {
+	if options.MaxBatchLines <= 0 || options.MaxBatchBytes <= 0 {
+		return nil, fmt.Errorf("batch limits must be positive (lines=%d, bytes=%d)",
+			options.MaxBatchLines, options.MaxBatchBytes)
+	}
+	if options.Sync == SyncInterval && options.SyncInterval <= 0 {
+		return nil, fmt.Errorf("sync policy %q needs a positive SyncInterval", options.Sync)
+	}
+
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	handler := &BatchingFileHandler{
+		minimumLevel: minimumLevel,
+		filePath:     filePath,
+		file:         file,
+		options:      options,
+		lastSync:     time.Now(),
+	}
+	handler.buffer.Grow(options.MaxBatchBytes)
+
+	if options.FlushInterval > 0 {
+		handler.stopFlusher = make(chan struct{})
+		handler.flusherDone = make(chan struct{})
+		go handler.runFlusher()
+	}
+	return handler, nil
+}
+
+// runFlusher flushes the buffer every FlushInterval until Close
+func (handler *BatchingFileHandler) runFlusher() {
+	defer close(handler.flusherDone)
+	ticker := time.NewTicker(handler.options.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = handler.Flush()
+		case <-handler.stopFlusher:
+			return
+		}
+	}
+}
+
+// SetLevel changes the minimum log level
+func (handler *BatchingFileHandler) SetLevel(level LogLevel) {
+	handler.minimumLevel = level
+}
+
+// GetLevel returns the current minimum log level
+func (handler *BatchingFileHandler) GetLevel() LogLevel {
+	return handler.minimumLevel
+}
+
+// SetCallerOptions enables or disables caller file:line and goroutine ID output
+func (handler *BatchingFileHandler) SetCallerOptions(options CallerOptions) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.callerOptions = options
+}
+
+// GetCallerOptions returns which caller details this handler writes
+func (handler *BatchingFileHandler) GetCallerOptions() CallerOptions {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	return handler.callerOptions
+}
+
+// SetFormat switches between text and JSON output
+func (handler *BatchingFileHandler) SetFormat(format LogFormat) {
+	handler.format = format
+}
+
+// Handle buffers the log message and flushes if a limit is reached
+func (handler *BatchingFileHandler) Handle(message *LogMessage) {
+	if message.Level < handler.minimumLevel {
+		return
+	}
+
+	// Format outside the lock; only the buffer append is serialized
+	logLine := formatLogLine(message, handler.format, handler.GetCallerOptions())
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	if handler.closed {
+		return
+	}
+
+	handler.buffer.WriteString(logLine)
+	handler.buffer.WriteByte('\n')
+	handler.pendingLines++
+	handler.stats.Messages++
+
+	if handler.pendingLines >= handler.options.MaxBatchLines ||
+		handler.buffer.Len() >= handler.options.MaxBatchBytes ||
+		message.Level >= handler.options.FlushLevel {
+		handler.flushLocked()
+	}
+}
+
+// Flush writes any buffered lines now (and fsyncs if the policy says so)
+func (handler *BatchingFileHandler) Flush() error {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.flushLocked()
+	return handler.writeErr
+}
+
+// flushLocked writes the buffer in one call and applies the sync policy
+// Caller must hold handler.mutex.
+func (handler *BatchingFileHandler) flushLocked() {
+	if handler.buffer.Len() == 0 || handler.file == nil {
+		return
+	}
+
+	if _, err := handler.file.Write(handler.buffer.Bytes()); err != nil && handler.writeErr == nil {
+		handler.writeErr = fmt.Errorf("batch write to %s: %w", handler.filePath, err)
+	}
+	handler.stats.Writes++
+	handler.buffer.Reset()
+	handler.pendingLines = 0
+
+	switch handler.options.Sync {
+	case SyncEveryBatch:
+		handler.syncLocked()
+	case SyncInterval:
+		if time.Since(handler.lastSync) >= handler.options.SyncInterval {
+			handler.syncLocked()
+		}
+	}
+}
+
+// syncLocked fsyncs the file. Caller must hold handler.mutex.
+func (handler *BatchingFileHandler) syncLocked() {
+	if err := handler.file.Sync(); err != nil && handler.writeErr == nil {
+		handler.writeErr = fmt.Errorf("fsync %s: %w", handler.filePath, err)
+	}
+	handler.stats.Syncs++
+	handler.lastSync = time.Now()
+}
+
+// GetStats returns the handler's counters
+func (handler *BatchingFileHandler) GetStats() BatchStats {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	stats := handler.stats
+	stats.Pending = handler.pendingLines
+	return stats
+}
+
+// Close stops the flusher, writes what is left, fsyncs (unless the policy
+// is SyncNever) and closes the file. Safe to call more than once.
+func (handler *BatchingFileHandler) Close() error {
+	handler.mutex.Lock()
+	if handler.closed {
+		handler.mutex.Unlock()
+		return nil
+	}
+	handler.closed = true
+	handler.mutex.Unlock()
+
+	// Stop the flusher without holding the lock it needs
+	if handler.stopFlusher != nil {
+		close(handler.stopFlusher)
+		<-handler.flusherDone
+	}
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.flushLocked()
+	if handler.options.Sync != SyncNever {
+		handler.syncLocked()
+	}
+	if err := handler.file.Close(); err != nil && handler.writeErr == nil {
+		handler.writeErr = err
+	}
+	handler.file = nil
+	return handler.writeErr
+}
+
+// ==================== TEE HANDLER ====================
+// TeeHandler forwards each message to several child handlers, e.g. a full
+// audit file plus an errors-only file. It is itself a LogHandler, so it can
+// be registered (or nested) anywhere a single handler is expected.
+//
+// Messages are delivered atomically: the tee holds its lock while calling
+// every child, so all children see messages in the same order and one
+// message is never interleaved with another across children.
+
+type TeeHandler struct {
+	minimumLevel LogLevel     // Messages below this level are not forwarded
+	children     []LogHandler // Handlers that receive every forwarded message
+	mutex        sync.Mutex   // Protects the fields above and serializes fan-out
+}
+
+// NewTeeHandler creates a handler that forwards to all the given children
+func NewTeeHandler(minimumLevel LogLevel, children ...LogHandler) *TeeHandler {
+	return &TeeHandler{
+		minimumLevel: minimumLevel,
+		children:     append([]LogHandler(nil), children...),
+	}
+}
+
+// AddHandler attaches another child handler
+func (handler *TeeHandler) AddHandler(child LogHandler) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.children = append(handler.children, child)
+}
+
+// SetLevel changes the minimum level forwarded to children
+// (each child still applies its own level on top)
+func (handler *TeeHandler) SetLevel(level LogLevel) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.minimumLevel = level
+}
+
+// GetLevel returns the current minimum log level
+func (handler *TeeHandler) GetLevel() LogLevel {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	return handler.minimumLevel
+}
+
+// GetCallerOptions merges the children's options so the logger captures
+// whatever any child wants to print
+func (handler *TeeHandler) GetCallerOptions() CallerOptions {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	var merged CallerOptions
+	for _, child := range handler.children {
+		if aware, ok := child.(CallerAwareHandler); ok {
+			options := aware.GetCallerOptions()
+			merged.IncludeCaller = merged.IncludeCaller || options.IncludeCaller
+			merged.IncludeGoroutineID = merged.IncludeGoroutineID || options.IncludeGoroutineID
+		}
+	}
+	return merged
+}
+
+// Handle forwards the message to every child while holding the tee's lock
+func (handler *TeeHandler) Handle(message *LogMessage) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	if message.Level < handler.minimumLevel {
+		return
+	}
+	for _, child := range handler.children {
+		child.Handle(message)
+	}
+}
+
+// ==================== ROUTING HANDLER ====================
+// RoutingHandler sends each message to exactly one child handler chosen by
+// level, e.g. DEBUG/INFO to a file, WARN to the console and ERROR/FATAL to
+// an alerting webhook. Instead of registering several handlers with
+// overlapping minimum levels (and accepting that an ERROR lands in all of
+// them), the whole routing table is declared in one place:
+//
+//	router, err := NewRoutingHandlerFromSpec("DEBUG-INFO=file; WARN=console; ERROR-FATAL=alerts",
+//		map[string]LogHandler{"file": fileHandler, "console": consoleHandler, "alerts": webhookHandler})
+//
+// Routes may not overlap, so every level has a single destination. Levels no
+// route covers go to the fallback handler if one is set, otherwise they are
+// dropped. Children still apply their own level, so create them at DEBUG and
+// let the routes decide. To send a level to two places, route it to a
+// TeeHandler.
+
+// LevelRoute sends messages from MinLevel through MaxLevel (inclusive) to Handler
+type LevelRoute struct {
+	Name     string     // Label used in errors and RouteFor (e.g. "alerts")
+	MinLevel LogLevel   // Lowest level on this route
+	MaxLevel LogLevel   // Highest level on this route
+	Handler  LogHandler // Where the messages go
+}
+
+// Covers reports whether the route carries messages of the given level
+func (route LevelRoute) Covers(level LogLevel) bool {
+	return level >= route.MinLevel && level <= route.MaxLevel
+}
+
+// String formats the route as "ERROR-FATAL=alerts"
+func (route LevelRoute) String() string {
+	if route.MinLevel == route.MaxLevel {
+		return fmt.Sprintf("%s=%s", route.MinLevel, route.Name)
+	}
+	return fmt.Sprintf("%s-%s=%s", route.MinLevel, route.MaxLevel, route.Name)
+}
+
+type RoutingHandler struct {
+	minimumLevel LogLevel     // Messages below this level are not routed
+	routes       []LevelRoute // Non-overlapping level ranges
+	fallback     LogHandler   // Receives levels no route covers (nil = drop)
+	mutex        sync.RWMutex // Guards routes and fallback
+}
+
+// NewRoutingHandler creates a handler from a routing table
+func NewRoutingHandler(routes ...LevelRoute) (*RoutingHandler, error) {
+	for index, route := range routes {
+		if route.Handler == nil {
+			return nil, fmt.Errorf("route %s has no handler", route)
+		}
+		if route.MinLevel < DEBUG || route.MaxLevel > FATAL || route.MinLevel > route.MaxLevel {
+			return nil, fmt.Errorf("route %s has an invalid level range", route)
+		}
+		for _, earlier := range routes[:index] {
+			if route.MinLevel <= earlier.MaxLevel && earlier.MinLevel <= route.MaxLevel {
+				return nil, fmt.Errorf("route %s overlaps route %s", route, earlier)
+			}
+		}
+	}
+	return &RoutingHandler{
+		minimumLevel: DEBUG,
+		routes:       append([]LevelRoute(nil), routes...),
+	}, nil
+}
+
+// NewRoutingHandlerFromSpec builds a router from a declarative spec such as
+// "DEBUG-INFO=file; WARN=console; ERROR-FATAL=alerts". Each entry is a level
+// or level range, '=', and the name of a handler in handlers.
+func NewRoutingHandlerFromSpec(spec string, handlers map[string]LogHandler) (*RoutingHandler, error) {
+	routes, err := ParseRoutes(spec, handlers)
+	if err != nil {
+		return nil, err
+	}
+	return NewRoutingHandler(routes...)
+}
+
+// ParseRoutes parses a routing spec into routes (see NewRoutingHandlerFromSpec)
+func ParseRoutes(spec string, handlers map[string]LogHandler) ([]LevelRoute, error) {
+	routes := make([]LevelRoute, 0)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		levels, name, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("route %q: expected LEVEL=handler or LEVEL-LEVEL=handler", entry)
+		}
+		name = strings.TrimSpace(name)
+		handler, exists := handlers[name]
+		if !exists {
+			return nil, fmt.Errorf("route %q: unknown handler %q", entry, name)
+		}
+
+		lowest, highest, isRange := strings.Cut(levels, "-")
+		minLevel, err := ParseLogLevel(lowest)
+		if err != nil {
+			return nil, fmt.Errorf("route %q: %w", entry, err)
+		}
+		maxLevel := minLevel
+		if isRange {
+			if maxLevel, err = ParseLogLevel(highest); err != nil {
+				return nil, fmt.Errorf("route %q: %w", entry, err)
+			}
+		}
+		routes = append(routes, LevelRoute{Name: name, MinLevel: minLevel, MaxLevel: maxLevel, Handler: handler})
+	}
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("routing spec %q has no routes", spec)
+	}
+	return routes, nil
+}
+
+// SetFallback sets the handler for levels no route covers (nil = drop them)
+func (handler *RoutingHandler) SetFallback(fallback LogHandler) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.fallback = fallback
+}
+
+// RouteFor returns the route that carries the given level
+func (handler *RoutingHandler) RouteFor(level LogLevel) (LevelRoute, bool) {
+	handler.mutex.RLock()
+	defer handler.mutex.RUnlock()
+	for _, route := range handler.routes {
+		if route.Covers(level) {
+			return route, true
+		}
+	}
+	return LevelRoute{}, false
+}
+
+// SetLevel changes the minimum level routed to children
+// (each child still applies its own level on top)
+func (handler *RoutingHandler) SetLevel(level LogLevel) {
+	handler.minimumLevel = level
+}
+
+// GetLevel returns the current minimum log level
+func (handler *RoutingHandler) GetLevel() LogLevel {
+	return handler.minimumLevel
+}
+
+// GetCallerOptions merges the children's options so the logger captures
+// whatever any destination wants to print
+func (handler *RoutingHandler) GetCallerOptions() CallerOptions {
+	handler.mutex.RLock()
+	defer handler.mutex.RUnlock()
+
+	children := make([]LogHandler, 0, len(handler.routes)+1)
+	for _, route := range handler.routes {
+		children = append(children, route.Handler)
+	}
+	if handler.fallback != nil {
+		children = append(children, handler.fallback)
+	}
+
+	var merged CallerOptions
+	for _, child := range children {
+		if aware, ok := child.(CallerAwareHandler); ok {
+			options := aware.GetCallerOptions()
+			merged.IncludeCaller = merged.IncludeCaller || options.IncludeCaller
+			merged.IncludeGoroutineID = merged.IncludeGoroutineID || options.IncludeGoroutineID
+		}
+	}
+	return merged
+}
+
+// Handle passes the message to the route for its level, or to the fallback
+func (handler *RoutingHandler) Handle(message *LogMessage) {
+	if message.Level < handler.minimumLevel {
+		return
+	}
+
+	if route, found := handler.RouteFor(message.Level); found {
+		route.Handler.Handle(message)
+		return
+	}
+
+	handler.mutex.RLock()
+	fallback := handler.fallback
+	handler.mutex.RUnlock()
+	if fallback != nil {
+		fallback.Handle(message)
+	}
+}
+
+// ==================== WEBHOOK HANDLER ====================
+// WebhookHandler POSTs each message as a JSON object to an HTTP endpoint,
+// e.g. an alerting service or a chat incoming-webhook. It is meant for the
+// rare, important messages (route ERROR/FATAL to it), so every message is
+// sent synchronously with a short timeout; failed deliveries are counted
+// rather than logged, since logging them could loop back into the webhook.
+
+// WebhookStats counts deliveries made by a WebhookHandler
+type WebhookStats struct {
+	Sent   int // Messages the endpoint accepted (2xx)
+	Failed int // Messages that could not be delivered
+}
+
+type WebhookHandler struct {
+	minimumLevel  LogLevel      // Only send messages at or above this level
+	url           string        // Endpoint that receives the POSTs
+	client        *http.Client  // HTTP client with a per-request timeout
+	callerOptions CallerOptions // Which caller details to include
+	stats         WebhookStats  // Delivery counters
+	mutex         sync.Mutex    // Serializes requests and guards stats
+}
+
+// webhookTimeout bounds how long one delivery may block the logging goroutine
+const webhookTimeout = 5 * time.Second
+
+// NewWebhookHandler creates a handler that POSTs messages to url
+func NewWebhookHandler(minimumLevel LogLevel, url string) *WebhookHandler {
+	return &WebhookHandler{
+		minimumLevel: minimumLevel,
+		url:          url,
+		client:       &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// SetLevel changes the minimum log level
+func (handler *WebhookHandler) SetLevel(level LogLevel) {
+	handler.minimumLevel = level
+}
+
+// GetLevel returns the current minimum log level
+func (handler *WebhookHandler) GetLevel() LogLevel {
+	return handler.minimumLevel
+}
+
+// SetCallerOptions enables or disables caller file:line and goroutine ID output
+func (handler *WebhookHandler) SetCallerOptions(options CallerOptions) {
+	handler.callerOptions = options
+}
+
+// GetCallerOptions returns which caller details this handler sends
+func (handler *WebhookHandler) GetCallerOptions() CallerOptions {
+	return handler.callerOptions
+}
+
+// Handle POSTs the message as JSON if it meets the level threshold
+func (handler *WebhookHandler) Handle(message *LogMessage) {
+	if message.Level < handler.minimumLevel {
+		return
+	}
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	body := formatLogLine(message, FormatJSON, handler.callerOptions)
+	response, err := handler.client.Post(handler.url, "application/json", strings.NewReader(body))
+	if err != nil {
+		handler.stats.Failed++
+		return
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		handler.stats.Failed++
+		return
+	}
+	handler.stats.Sent++
+}
+
+// GetStats returns the delivery counters
+func (handler *WebhookHandler) GetStats() WebhookStats {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	return handler.stats
+}
+
+// ==================== MEMORY HANDLER (TEST HELPER) ====================
+// MemoryHandler keeps every message it receives so tests can assert on
+// what was logged instead of scraping stdout:
+//
+//	func TestCheckoutLogsDecline(t *testing.T) {
+//		captured := WithCapturedLogs(t, func() {
+//			checkout.Pay(card)
+//		})
+//		if !captured.Contains("card declined") {
+//			t.Error("expected a decline to be logged")
+//		}
+//	}
+//
+// It sits behind the logger's filters like any other handler, so it sees
+// exactly what the console or a file would have received (already redacted,
+// already level-filtered). Messages are stored as copies; later changes to a
+// LogMessage do not alter what was captured.
+
+type MemoryHandler struct {
+	minimumLevel LogLevel     // Only capture messages at or above this level
+	messages     []LogMessage // Captured messages in arrival order
+	mutex        sync.Mutex   // Protects messages
+}
+
+// NewMemoryHandler creates a handler that captures messages in memory
+func NewMemoryHandler(minimumLevel LogLevel) *MemoryHandler {
+	return &MemoryHandler{minimumLevel: minimumLevel}
+}
+
+// SetLevel changes the minimum log level
+func (handler *MemoryHandler) SetLevel(level LogLevel) {
+	handler.minimumLevel = level
+}
+
+// GetLevel returns the current minimum log level
+func (handler *MemoryHandler) GetLevel() LogLevel {
+	return handler.minimumLevel
+}
+
+// Handle stores a copy of the message if it meets the level threshold
+func (handler *MemoryHandler) Handle(message *LogMessage) {
+	if message.Level < handler.minimumLevel {
+		return
+	}
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.messages = append(handler.messages, *message)
+}
+
+// GetMessages returns every captured message in arrival order
+func (handler *MemoryHandler) GetMessages() []LogMessage {
+	return handler.filter(func(LogMessage) bool { return true })
+}
+
+// ByLevel returns the captured messages with exactly the given level
+func (handler *MemoryHandler) ByLevel(level LogLevel) []LogMessage {
+	return handler.filter(func(message LogMessage) bool { return message.Level == level })
+}
+
+// BySource returns the captured messages logged by the given component
+func (handler *MemoryHandler) BySource(source string) []LogMessage {
+	return handler.filter(func(message LogMessage) bool { return message.Source == source })
+}
+
+// Contains reports whether any captured message text contains the substring
+func (handler *MemoryHandler) Contains(substring string) bool {
+	return len(handler.filter(func(message LogMessage) bool {
+		return strings.Contains(message.Message, substring)
+	})) > 0
+}
+
+// Len returns how many messages have been captured
+func (handler *MemoryHandler) Len() int {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	return len(handler.messages)
+}
+
+// Reset discards everything captured so far
+func (handler *MemoryHandler) Reset() {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.messages = nil
+}
+
+// filter returns copies of the captured messages that match
+func (handler *MemoryHandler) filter(matches func(LogMessage) bool) []LogMessage {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	result := make([]LogMessage, 0)
+	for _, message := range handler.messages {
+		if matches(message) {
+			result = append(result, message)
+		}
+	}
+	return result
+}
+
+// WithCapturedLogs runs fn with a MemoryHandler attached to the global
+// logger and returns it for assertions. The handler is detached when fn
+// returns (or panics). If the test fails, the captured messages are written
+// to the test log so the failure shows what was logged.
+func WithCapturedLogs(t testing.TB, fn func()) *MemoryHandler {
+	t.Helper()
+
+	captured := NewMemoryHandler(DEBUG)
+	logger := GetLogger()
+	logger.AddHandler(captured)
+	t.Cleanup(func() {
+		if t.Failed() {
+			for _, message := range captured.GetMessages() {
+				t.Logf("captured log: %s", formatLogLine(&message, FormatText, CallerOptions{}))
+			}
+		}
+	})
+
+	defer logger.RemoveHandler(captured)
+	fn()
+	return captured
+}
+
+// ==================== LOG FILTER INTERFACE ====================
+// LogFilter decides whether a message should be logged.
+// This is the CHAIN OF RESPONSIBILITY PATTERN - filters can be linked.
+
+type LogFilter interface {
+	// SetNext links this filter to the next one in the chain
+	SetNext(nextFilter LogFilter)
+
+	// ShouldLog returns true if the message passes this filter
+	ShouldLog(message *LogMessage) bool
+}
+
+// ==================== LEVEL FILTER ====================
+// LevelFilter only allows messages at or above a minimum level.
+
+type LevelFilter struct {
+	minimumLevel LogLevel  // Minimum level to allow
+	nextFilter   LogFilter // Next filter in chain (can be nil)
+}
+
+// NewLevelFilter creates a filter that blocks messages below the given level
+func NewLevelFilter(minimumLevel LogLevel) *LevelFilter {
+	return &LevelFilter{minimumLevel: minimumLevel}
+}
+
+// SetNext sets the next filter in the chain
+func (filter *LevelFilter) SetNext(nextFilter LogFilter) {
+	filter.nextFilter = nextFilter
+}
+
+// ShouldLog returns true if message level meets minimum requirement
+func (filter *LevelFilter) ShouldLog(message *LogMessage) bool {
+	// Block messages below minimum level
+	if message.Level < filter.minimumLevel {
+		return false
+	}
+
+	// If there's a next filter, check it too
+	if filter.nextFilter != nil {
+		return filter.nextFilter.ShouldLog(message)
+	}
+
+	// Passed all filters!
+	return true
+}
+
+// ==================== SOURCE FILTER ====================
+// SourceFilter only allows messages from specific sources (components).
+
+type SourceFilter struct {
+	allowedSources map[string]bool // Map of allowed source names
+	nextFilter     LogFilter       // Next filter in chain (can be nil)
+}
+
+// NewSourceFilter creates a filter that only allows specified sources
+// If sources is empty, all sources are allowed
+func NewSourceFilter(sources []string) *SourceFilter {
+	allowedMap := make(map[string]bool)
+	for _, source := range sources {
+		allowedMap[source] = true
+	}
+	return &SourceFilter{allowedSources: allowedMap}
+}
+
+// SetNext sets the next filter in the chain
+func (filter *SourceFilter) SetNext(nextFilter LogFilter) {
+	filter.nextFilter = nextFilter
+}
+
+// ShouldLog returns true if message source is in the allowed list
+func (filter *SourceFilter) ShouldLog(message *LogMessage) bool {
+	// If we have an allow list and source is not in it, block
+	if len(filter.allowedSources) > 0 && !filter.allowedSources[message.Source] {
+		return false
+	}
+
+	// If there's a next filter, check it too
+	if filter.nextFilter != nil {
+		return filter.nextFilter.ShouldLog(message)
+	}
+
+	// Passed all filters!
+	return true
+}
+
+// ==================== REDACTION FILTER ====================
+// RedactionFilter masks sensitive data before any handler sees it, so card
+// numbers, emails and API keys never reach the console, files or shippers.
+// It never blocks a message: it rewrites it and passes it down the chain.
+//
+// Structured fields (slog attributes) are rendered as key=value pairs, so
+// they are covered too; values of sensitive keys (password, token, ...) are
+// masked whatever they look like.
+//
+// Built-in rules:
+// - card:    13-19 digit numbers passing the Luhn check -> ****-1111
+// - email:   local part masked -> j***@email.com
+// - api_key: sk_live_/pk_test_ style keys, AWS access keys, Bearer tokens
+// - secrets: values of sensitive keys -> password=[REDACTED]
+
+// RedactedText replaces values that are masked completely
+const RedactedText = "[REDACTED]"
+
+// RedactionRule masks every match of Pattern with Mask(match)
+type RedactionRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Mask    func(match string) string
+}
+
+// defaultSensitiveKeys are field names whose values are always masked
+var defaultSensitiveKeys = []string{"password", "passwd", "secret", "token", "api_key", "apikey", "authorization"}
+
+type RedactionFilter struct {
+	rules         []RedactionRule // Applied in order: built-ins first, then registered patterns
+	sensitiveKeys []string        // Field names whose values are masked
+	keyRule       *regexp.Regexp  // Compiled from sensitiveKeys
+	nextFilter    LogFilter       // Next filter in chain (can be nil)
+	mutex         sync.RWMutex    // Rules may be registered while logging
+}
+
+// NewRedactionFilter creates a filter with the built-in rules
+func NewRedactionFilter() *RedactionFilter {
+	filter := &RedactionFilter{
+		rules: []RedactionRule{
+			{
+				Name:    "card",
+				Pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+				Mask:    maskCardNumber,
+			},
+			{
+				Name:    "email",
+				Pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`),
+				Mask:    maskEmail,
+			},
+			{
+				Name:    "api_key",
+				Pattern: regexp.MustCompile(`\b(?:sk|pk|rk)_(?:live|test)_[A-Za-z0-9]{8,}\b|\bAKIA[0-9A-Z]{16}\b|\bBearer\s+[A-Za-z0-9._~+/-]+=*`),
+				Mask:    func(string) string { return RedactedText },
+			},
+		},
+	}
+	filter.setSensitiveKeys(defaultSensitiveKeys)
+	return filter
+}
+
+// AddPattern registers a custom regular expression whose matches are replaced
+// with [REDACTED], e.g. AddPattern("ssn", `\b\d{3}-\d{2}-\d{4}\b`)
+func (filter *RedactionFilter) AddPattern(name string, expression string) error {
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return fmt.Errorf("redaction pattern %q: %w", name, err)
+	}
+	filter.AddRule(RedactionRule{Name: name, Pattern: pattern, Mask: func(string) string { return RedactedText }})
+	return nil
+}
+
+// AddRule registers a custom rule with its own mask
+func (filter *RedactionFilter) AddRule(rule RedactionRule) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+	filter.rules = append(filter.rules, rule)
+}
+
+// AddSensitiveKeys adds field names whose values are always masked
+func (filter *RedactionFilter) AddSensitiveKeys(keys ...string) {
+	filter.mutex.Lock()
+	defer filter.mutex.Unlock()
+	filter.setSensitiveKeys(append(filter.sensitiveKeys, keys...))
+}
+
+// setSensitiveKeys rebuilds the key=value rule. It matches keys with or
+// without a slog group prefix ("db.password=...") and quoted values.
+// Caller must hold filter.mutex (or own the filter exclusively).
+func (filter *RedactionFilter) setSensitiveKeys(keys []string) {
+	quoted := make([]string, len(keys))
+	for index, key := range keys {
+		quoted[index] = regexp.QuoteMeta(key)
+	}
+	filter.sensitiveKeys = keys
+	filter.keyRule = regexp.MustCompile(`(?i)\b((?:[\w-]+\.)*(?:` + strings.Join(quoted, "|") + `))([=:]\s*)("(?:[^"\\]|\\.)*"|(?:(?:bearer|basic)\s+)?[^\s,;]+)`)
+}
+
+// Redact returns text with every sensitive value masked
+func (filter *RedactionFilter) Redact(text string) string {
+	filter.mutex.RLock()
+	defer filter.mutex.RUnlock()
+
+	// Sensitive keys first, so their values aren't partially masked by other rules
+	text = filter.keyRule.ReplaceAllString(text, "${1}${2}"+RedactedText)
+	for _, rule := range filter.rules {
+		text = rule.Pattern.ReplaceAllStringFunc(text, rule.Mask)
+	}
+	return text
+}
+
+// SetNext sets the next filter in the chain
+func (filter *RedactionFilter) SetNext(nextFilter LogFilter) {
+	filter.nextFilter = nextFilter
+}
+
+// ShouldLog masks the message in place and defers the decision to the chain
+func (filter *RedactionFilter) ShouldLog(message *LogMessage) bool {
+	message.Message = filter.Redact(message.Message)
+
+	// If there's a next filter, check it too
+	if filter.nextFilter != nil {
+		return filter.nextFilter.ShouldLog(message)
+	}
+	return true
+}
+
+// maskCardNumber keeps the last four digits of numbers that pass the Luhn
+// check; other long digit runs (order IDs, timestamps) are left alone
+func maskCardNumber(match string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, match)
+	if !luhnValid(digits) {
+		return match
+	}
+	return "****-" + digits[len(digits)-4:]
+}
+
+// luhnValid reports whether a digit string has a valid Luhn checksum
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for index := len(digits) - 1; index >= 0; index-- {
+		digit := int(digits[index] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// maskEmail keeps the first character of the local part and the domain
+func maskEmail(match string) string {
+	at := strings.IndexByte(match, '@')
+	if at <= 0 {
+		return RedactedText
+	}
+	return match[:1] + "***" + match[at:]
+}
+
+// ==================== LOGGER (SINGLETON) ====================
+// Logger is the main logging system. Only one instance exists (Singleton).
+// It manages handlers (where to log) and filters (what to log).
+
+type Logger struct {
+	handlers       []LogHandler // List of output destinations
+	filters        []LogFilter  // List of message filters
+	callerSkip     int          // Extra stack frames to skip when capturing the caller
+	profile        string       // Name of the applied configuration profile ("" if none)
+	profileClosers []io.Closer  // Files opened by the applied profile
+	mutex          sync.RWMutex // Read-write lock for thread safety
+}
+
+// Global singleton variables
+var (
+	loggerInstance *Logger   // The single logger instance
+	loggerOnce     sync.Once // Ensures instance is created only once
+)
+
+// GetLogger returns the singleton logger instance.
+// This is thread-safe and always returns the same instance.
+func GetLogger() *Logger {
+	// sync.Once ensures this block runs exactly once, even with concurrent calls
+	loggerOnce.Do(func() {
+		loggerInstance = &Logger{
+			handlers: make([]LogHandler, 0),
+			filters:  make([]LogFilter, 0),
+		}
+	})
+	return loggerInstance
+}
+
+// AddHandler registers a new output handler (console, file, etc.)
+func (logger *Logger) AddHandler(handler LogHandler) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.handlers = append(logger.handlers, handler)
+}
+
+// SetHandlers replaces all registered handlers, e.g. with a single
+// RoutingHandler. Files opened by an applied profile stay open until the
+// next profile is applied.
+func (logger *Logger) SetHandlers(handlers ...LogHandler) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.handlers = append([]LogHandler(nil), handlers...)
+}
+
+// RemoveHandler detaches a previously added handler.
+// Returns false if the handler was not registered.
+func (logger *Logger) RemoveHandler(handler LogHandler) bool {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	for index, registered := range logger.handlers {
+		if registered == handler {
+			logger.handlers = append(logger.handlers[:index], logger.handlers[index+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// AddFilter registers a new filter to control which messages are logged
+func (logger *Logger) AddFilter(filter LogFilter) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.filters = append(logger.filters, filter)
+}
+
+// SetCallerSkip sets how many extra stack frames to skip when capturing the
+// caller. Use this when wrapping the logger in your own helper functions so
+// the reported file:line points at your caller instead of the wrapper.
+func (logger *Logger) SetCallerSkip(skip int) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.callerSkip = skip
+}
+
+// requiredCallerOptions merges the options of all handlers, so runtime
+// details are captured once only if some handler will print them.
+// Caller must hold at least a read lock.
+func (logger *Logger) requiredCallerOptions() CallerOptions {
+	var required CallerOptions
+	for _, handler := range logger.handlers {
+		if aware, ok := handler.(CallerAwareHandler); ok {
+			options := aware.GetCallerOptions()
+			required.IncludeCaller = required.IncludeCaller || options.IncludeCaller
+			required.IncludeGoroutineID = required.IncludeGoroutineID || options.IncludeGoroutineID
+		}
+	}
+	return required
+}
+
+// logCallDepth is the number of frames between log and the user's code:
+// log <- public method (Info, Infof, NamedLogger.Info, ...) <- user code.
+// Every public logging method must call log directly to keep this constant.
+const logCallDepth = 2
+
+// log is the internal method that processes all log messages
+func (logger *Logger) log(level LogLevel, source string, message string) {
+	// Create the log message with current timestamp
+	logger.dispatch(NewLogMessage(level, message, source), 0)
+}
+
+// dispatch runs a message through the filters and hands it to every handler.
+// callerPC is the call site's program counter if already known (slog records
+// carry one); when 0 the caller is captured from the stack instead.
+func (logger *Logger) dispatch(logMessage *LogMessage, callerPC uintptr) {
+	// Use read lock since we're only reading handlers/filters
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+
+	// Check all filters - if any filter blocks, don't log
+	for _, filter := range logger.filters {
+		if !filter.ShouldLog(logMessage) {
+			return // Message was filtered out
+		}
+	}
+
+	// Capture runtime details only if a handler wants them
+	required := logger.requiredCallerOptions()
+	if required.IncludeCaller {
+		if callerPC != 0 {
+			logMessage.Caller = callerFromPC(callerPC)
+		} else {
+			// +1 for dispatch itself, which sits between log and captureCaller
+			logMessage.Caller = captureCaller(logCallDepth + 1 + logger.callerSkip)
+		}
+	}
+	if required.IncludeGoroutineID {
+		logMessage.GoroutineID = currentGoroutineID()
+	}
+
+	// Send message to all registered handlers
+	for _, handler := range logger.handlers {
+		handler.Handle(logMessage)
+	}
+}
+
+// ==================== CONFIGURATION PROFILES ====================
+// A LogProfile describes a complete logger setup for one environment, so an
+// application configures logging with a single call at startup:
+//
+//	if err := GetLogger().ConfigureFromProfile(os.Getenv("APP_ENV")); err != nil { ... }
+//
+// Built-in profiles:
+// - development: colored text on the console, DEBUG and up, with file:line
+// - staging:     plain text on the console + batched JSON file, INFO and up, redacted
+// - production:  JSON on the console (for log shippers), WARN and up, redacted
+//
+// Applying a profile replaces all handlers and filters. Files opened by a
+// previous profile are closed; handlers added by hand are not.
+
+// LogProfile is a named logger configuration
+type LogProfile struct {
+	Name          string
+	Level         LogLevel      // Minimum level for every handler
+	ConsoleFormat LogFormat     // Format of console output
+	Colors        bool          // ANSI colors on a capable console (text format only)
+	CallerOptions CallerOptions // Caller details on every handler
+	FilePath      string        // Optional log file ("" for console only)
+	FileFormat    LogFormat     // Format of the log file
+	Redact        bool          // Mask sensitive data with the built-in RedactionFilter
+	BatchFile     bool          // Buffer file writes (BatchingFileHandler, default options)
+}
+
+// DefaultProfile is used when ConfigureFromProfile gets an empty name
+const DefaultProfile = "development"
+
+// logProfiles holds the built-in and registered profiles by name
+var (
+	logProfiles = map[string]LogProfile{
+		"development": {
+			Name:          "development",
+			Level:         DEBUG,
+			ConsoleFormat: FormatText,
+			Colors:        true,
+			CallerOptions: CallerOptions{IncludeCaller: true},
+		},
+		"staging": {
+			Name:          "staging",
+			Level:         INFO,
+			ConsoleFormat: FormatText,
+			FilePath:      "/tmp/app-staging.log",
+			FileFormat:    FormatJSON,
+			Redact:        true,
+			BatchFile:     true,
+		},
+		"production": {
+			Name:          "production",
+			Level:         WARN,
+			ConsoleFormat: FormatJSON,
+			Redact:        true,
+		},
+	}
+	logProfilesMutex sync.RWMutex
+)
+
+// RegisterProfile adds or replaces a named profile
+func RegisterProfile(profile LogProfile) error {
+	if profile.Name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	logProfilesMutex.Lock()
+	defer logProfilesMutex.Unlock()
+	logProfiles[strings.ToLower(profile.Name)] = profile
+	return nil
+}
+
+// GetProfile looks up a profile by name (case-insensitive)
+func GetProfile(name string) (LogProfile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+	logProfilesMutex.RLock()
+	defer logProfilesMutex.RUnlock()
+	profile, exists := logProfiles[strings.ToLower(name)]
+	if !exists {
+		return LogProfile{}, fmt.Errorf("unknown logging profile %q", name)
+	}
+	return profile, nil
+}
+
+// ConfigureFromProfile replaces the logger's handlers and filters with the
+// setup described by the named profile ("" means DefaultProfile)
+func (logger *Logger) ConfigureFromProfile(name string) error {
+	profile, err := GetProfile(name)
+	if err != nil {
+		return err
+	}
+	return logger.ApplyProfile(profile)
+}
+
+// ApplyProfile replaces the logger's handlers and filters with the profile's
+func (logger *Logger) ApplyProfile(profile LogProfile) error {
+	consoleHandler := NewConsoleHandler(profile.Level)
+	consoleHandler.SetFormat(profile.ConsoleFormat)
+	consoleHandler.SetColors(profile.Colors)
+	consoleHandler.SetCallerOptions(profile.CallerOptions)
+	handlers := []LogHandler{consoleHandler}
+	closers := make([]io.Closer, 0)
+
+	if profile.FilePath != "" && profile.BatchFile {
+		batchHandler, err := NewBatchingFileHandler(profile.Level, profile.FilePath, DefaultBatchOptions())
+		if err != nil {
+			return fmt.Errorf("profile %q: %w", profile.Name, err)
+		}
+		batchHandler.SetFormat(profile.FileFormat)
+		batchHandler.SetCallerOptions(profile.CallerOptions)
+		handlers = append(handlers, batchHandler)
+		closers = append(closers, batchHandler)
+	} else if profile.FilePath != "" {
+		fileHandler, err := NewFileHandler(profile.Level, profile.FilePath)
+		if err != nil {
+			return fmt.Errorf("profile %q: %w", profile.Name, err)
+		}
+		fileHandler.SetFormat(profile.FileFormat)
+		fileHandler.SetCallerOptions(profile.CallerOptions)
+		handlers = append(handlers, fileHandler)
+		closers = append(closers, fileHandler)
+	}
+
+	filters := []LogFilter{NewLevelFilter(profile.Level)}
+	if profile.Redact {
+		filters = append(filters, NewRedactionFilter())
+	}
+
+	logger.mutex.Lock()
+	previousClosers := logger.profileClosers
+	logger.handlers = handlers
+	logger.filters = filters
+	logger.profile = profile.Name
+	logger.profileClosers = closers
+	logger.mutex.Unlock()
+
+	// Close old files only after no message can be dispatched to them
+	for _, closer := range previousClosers {
+		_ = closer.Close()
+	}
+	return nil
+}
+
+// GetProfileName returns the name of the applied profile ("" if none)
+func (logger *Logger) GetProfileName() string {
+	logger.mutex.RLock()
+	defer logger.mutex.RUnlock()
+	return logger.profile
+}
+
+// ==================== ENVIRONMENT OVERRIDES & RELOAD ====================
+// Long-running services need to change verbosity without a restart. Two
+// environment variables drive the logger at startup and on every reload:
+//
+//	LOG_PROFILE=staging  -> which configuration profile to apply
+//	LOG_LEVEL=debug      -> minimum level, overriding the profile's level
+//
+// Reload re-reads both variables and re-applies the configuration. It runs
+// either when called explicitly or, after WatchReloadSignal, whenever the
+// process receives SIGHUP (the usual "re-read your config" signal):
+//
+//	stop := GetLogger().WatchReloadSignal(nil)
+//	defer stop()
+//
+// Both variables are validated before anything is changed, so a typo leaves
+// the current configuration in place.
+
+// Environment variables read by ConfigureFromEnv and Reload
+const (
+	LevelEnvVar   = "LOG_LEVEL"
+	ProfileEnvVar = "LOG_PROFILE"
+)
+
+// ParseLogLevel converts a level name such as "debug" or "WARN" to a LogLevel.
+// Matching is case-insensitive and "warning" is accepted as an alias for WARN.
+func ParseLogLevel(name string) (LogLevel, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(name))
+	if normalized == "WARNING" {
+		return WARN, nil
+	}
+	for index, levelName := range logLevelNames {
+		if levelName == normalized {
+			return LogLevel(index), nil
+		}
+	}
+	return DEBUG, fmt.Errorf("invalid log level %q (want one of %s)", name, strings.Join(logLevelNames, ", "))
+}
+
+// LevelFromEnv reads LOG_LEVEL. isSet is false when the variable is unset or
+// empty, in which case the caller keeps its own default level.
+func LevelFromEnv() (level LogLevel, isSet bool, err error) {
+	value := os.Getenv(LevelEnvVar)
+	if strings.TrimSpace(value) == "" {
+		return DEBUG, false, nil
+	}
+	level, err = ParseLogLevel(value)
+	if err != nil {
+		return DEBUG, false, fmt.Errorf("%s: %w", LevelEnvVar, err)
+	}
+	return level, true, nil
+}
+
+// SetLevel changes the minimum level of every handler and of any LevelFilter.
+// A TeeHandler's children keep their own levels (only the tee's is changed).
+func (logger *Logger) SetLevel(level LogLevel) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+
+	// Safe without per-handler locks: dispatch holds the read lock
+	for _, handler := range logger.handlers {
+		handler.SetLevel(level)
+	}
+	for _, filter := range logger.filters {
+		if levelFilter, ok := filter.(*LevelFilter); ok {
+			levelFilter.minimumLevel = level
+		}
+	}
+}
+
+// ConfigureFromEnv applies the profile named by LOG_PROFILE ("" means
+// DefaultProfile), then the LOG_LEVEL override if set
+func (logger *Logger) ConfigureFromEnv() error {
+	return logger.applyEnv(os.Getenv(ProfileEnvVar))
+}
+
+// Reload re-reads LOG_PROFILE and LOG_LEVEL and re-applies the configuration.
+// Without LOG_PROFILE the currently applied profile is rebuilt (reopening its
+// files, which also plays well with external log rotation). A logger that
+// was set up by hand keeps its handlers and only gets the level override.
+func (logger *Logger) Reload() error {
+	profileName := os.Getenv(ProfileEnvVar)
+	if profileName == "" {
+		profileName = logger.GetProfileName()
+	}
+	if profileName == "" {
+		level, isSet, err := LevelFromEnv()
+		if err != nil {
+			return fmt.Errorf("reload: %w", err)
+		}
+		if isSet {
+			logger.SetLevel(level)
+		}
+		return nil
+	}
+	if err := logger.applyEnv(profileName); err != nil {
+		return fmt.Errorf("reload: %w", err)
+	}
+	return nil
+}
+
+// applyEnv validates LOG_LEVEL and the profile name before touching the
+// current setup, then applies the profile with the level override
+func (logger *Logger) applyEnv(profileName string) error {
+	level, isSet, err := LevelFromEnv()
+	if err != nil {
+		return err
+	}
+	profile, err := GetProfile(profileName)
+	if err != nil {
+		return err
+	}
+	if isSet {
+		profile.Level = level
+	}
+	return logger.ApplyProfile(profile)
+}
+
+// WatchReloadSignal calls Reload every time the process receives SIGHUP.
+// The outcome is logged under the "Logger" source and, if onReload is not
+// nil, passed to it. Call the returned function to stop watching.
+func (logger *Logger) WatchReloadSignal(onReload func(err error)) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				err := logger.Reload()
+				if err != nil {
+					logger.Error("Logger", "SIGHUP reload failed, keeping current configuration: "+err.Error())
+				} else {
+					logger.Info("Logger", "Configuration reloaded on SIGHUP")
+				}
+				if onReload != nil {
+					onReload(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var stopOnce sync.Once
+	return func() {
+		stopOnce.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+// ==================== PUBLIC LOGGING METHODS ====================
+// These are the main methods users call to log messages.
+
+// Debug logs a debug-level message
+func (logger *Logger) Debug(source string, message string) {
+	logger.log(DEBUG, source, message)
+}
+
+// Info logs an info-level message
+func (logger *Logger) Info(source string, message string) {
+	logger.log(INFO, source, message)
+}
+
+// Warn logs a warning-level message
+func (logger *Logger) Warn(source string, message string) {
+	logger.log(WARN, source, message)
+}
+
+// Error logs an error-level message
+func (logger *Logger) Error(source string, message string) {
+	logger.log(ERROR, source, message)
+}
+
+// Fatal logs a fatal-level message
+func (logger *Logger) Fatal(source string, message string) {
+	logger.log(FATAL, source, message)
+}
+
+// ==================== FORMATTED LOGGING METHODS ====================
+// These methods support printf-style formatting.
+
+// Debugf logs a formatted debug message
+func (logger *Logger) Debugf(source string, format string, args ...interface{}) {
+	logger.log(DEBUG, source, fmt.Sprintf(format, args...))
+}
+
+// Infof logs a formatted info message
+func (logger *Logger) Infof(source string, format string, args ...interface{}) {
+	logger.log(INFO, source, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs a formatted warning message
+func (logger *Logger) Warnf(source string, format string, args ...interface{}) {
+	logger.log(WARN, source, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs a formatted error message
+func (logger *Logger) Errorf(source string, format string, args ...interface{}) {
+	logger.log(ERROR, source, fmt.Sprintf(format, args...))
+}
+
+// Fatalf logs a formatted fatal message
+func (logger *Logger) Fatalf(source string, format string, args ...interface{}) {
+	logger.log(FATAL, source, fmt.Sprintf(format, args...))
+}
+
+// ==================== NAMED LOGGER ====================
+// NamedLogger wraps the main Logger with a fixed source name.
+// This is convenient when a component needs to log many messages.
+
+type NamedLogger struct {
+	componentName string  // The name that appears in all log messages
+	logger        *Logger // Reference to the main logger
+}
+
+// NewNamedLogger creates a logger pre-configured with a component name
+func NewNamedLogger(componentName string) *NamedLogger {
+	return &NamedLogger{
+		componentName: componentName,
+		logger:        GetLogger(),
+	}
+}
+
+// Debug logs a debug message with the component name
+func (named *NamedLogger) Debug(message string) {
+	named.logger.log(DEBUG, named.componentName, message)
+}
+
+// Info logs an info message with the component name
+func (named *NamedLogger) Info(message string) {
+	named.logger.log(INFO, named.componentName, message)
+}
+
+// Warn logs a warning message with the component name
+func (named *NamedLogger) Warn(message string) {
+	named.logger.log(WARN, named.componentName, message)
+}
+
+// Error logs an error message with the component name
+func (named *NamedLogger) Error(message string) {
+	named.logger.log(ERROR, named.componentName, message)
+}
+
+// Fatal logs a fatal message with the component name
+func (named *NamedLogger) Fatal(message string) {
+	named.logger.log(FATAL, named.componentName, message)
+}
+
+// Debugf logs a formatted debug message
+func (named *NamedLogger) Debugf(format string, args ...interface{}) {
+	named.logger.log(DEBUG, named.componentName, fmt.Sprintf(format, args...))
+}
+
+// Infof logs a formatted info message
+func (named *NamedLogger) Infof(format string, args ...interface{}) {
+	named.logger.log(INFO, named.componentName, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs a formatted warning message
+func (named *NamedLogger) Warnf(format string, args ...interface{}) {
+	named.logger.log(WARN, named.componentName, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs a formatted error message
+func (named *NamedLogger) Errorf(format string, args ...interface{}) {
+	named.logger.log(ERROR, named.componentName, fmt.Sprintf(format, args...))
+}
+
+// Fatalf logs a formatted fatal message
+func (named *NamedLogger) Fatalf(format string, args ...interface{}) {
+	named.logger.log(FATAL, named.componentName, fmt.Sprintf(format, args...))
+}
+
+// ==================== IO.WRITER ADAPTER ====================
+// Many libraries only accept an io.Writer for their diagnostics, e.g.
+// net/http's Server.ErrorLog (a *log.Logger). Writer adapts this logger to
+// that interface so their output goes through our filters and handlers:
+//
+//	server := &http.Server{ErrorLog: log.New(GetLogger().Writer(ERROR, "HTTP"), "", 0)}
+//
+// Each line written becomes one log message. A partial line is buffered
+// until its newline arrives (log.Logger always ends writes with one).
+
+type logWriter struct {
+	logger  *Logger
+	level   LogLevel
+	source  string
+	pending []byte     // Bytes of an unfinished line
+	mutex   sync.Mutex // Writers may be shared between goroutines
+}
+
+// Writer returns an io.Writer that logs every line written at the given level
+func (logger *Logger) Writer(level LogLevel, source string) io.Writer {
+	return &logWriter{logger: logger, level: level, source: source}
+}
+
+// Write logs each complete line in p and buffers any trailing partial line
+func (writer *logWriter) Write(p []byte) (int, error) {
+	// The code calling Write (e.g. log.Logger) is reported as the caller,
+	// since stack depth from the real call site is unknown here
+	var callerPCs [1]uintptr
+	runtime.Callers(2, callerPCs[:])
+
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	writer.pending = append(writer.pending, p...)
+	for {
+		newline := bytes.IndexByte(writer.pending, '\n')
+		if newline < 0 {
+			break
+		}
+		line := strings.TrimSuffix(string(writer.pending[:newline]), "\r")
+		writer.pending = writer.pending[newline+1:]
+		if line == "" {
+			continue
+		}
+		writer.logger.dispatch(NewLogMessage(writer.level, line, writer.source), callerPCs[0])
+	}
+	return len(p), nil
+}
+
+// ==================== SLOG ADAPTER ====================
+// SlogHandler implements slog.Handler so code written against the standard
+// library's log/slog is routed through this logger's filters and handlers:
+//
+//	slogger := slog.New(NewSlogHandler(GetLogger(), "app"))
+//	slogger.Info("user signed in", "component", "Auth", "user_id", 42)
+//
+// The "component" attribute (if present) becomes the LogMessage Source, so
+// SourceFilter works on slog records too. All other attributes are appended
+// to the message as key=value pairs, with group names as dotted prefixes.
+
+// SlogSourceKey is the attribute key mapped to LogMessage.Source
+const SlogSourceKey = "component"
+
+type SlogHandler struct {
+	logger        *Logger  // Backend that filters and writes the records
+	defaultSource string   // Source used when no "component" attribute is set
+	source        string   // Source fixed by WithAttrs (overrides defaultSource)
+	preformatted  string   // Attributes from WithAttrs, already rendered
+	groups        []string // Open groups from WithGroup, outermost first
+}
+
+// NewSlogHandler creates an slog.Handler backed by the given logger
+func NewSlogHandler(logger *Logger, defaultSource string) *SlogHandler {
+	return &SlogHandler{
+		logger:        logger,
+		defaultSource: defaultSource,
+	}
+}
+
+// slogLevelToLogLevel maps slog's open-ended levels onto our five levels.
+// Anything above slog.LevelError is treated as FATAL.
+func slogLevelToLogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelWarn:
+		return INFO
+	case level < slog.LevelError:
+		return WARN
+	case level == slog.LevelError:
+		return ERROR
+	default:
+		return FATAL
+	}
+}
+
+// Enabled reports whether any handler would print a record at this level,
+// letting slog skip building records nobody will see
+func (handler *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	logLevel := slogLevelToLogLevel(level)
+
+	handler.logger.mutex.RLock()
+	defer handler.logger.mutex.RUnlock()
+
+	for _, logHandler := range handler.logger.handlers {
+		if logLevel >= logHandler.GetLevel() {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle converts the slog record into a LogMessage and dispatches it
+func (handler *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+	source := handler.source
+	var builder strings.Builder
+	builder.WriteString(record.Message)
+	builder.WriteString(handler.preformatted)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		if len(handler.groups) == 0 && attr.Key == SlogSourceKey {
+			source = attr.Value.String()
+			return true
+		}
+		appendSlogAttr(&builder, handler.groups, attr)
+		return true
+	})
+
+	if source == "" {
+		source = handler.defaultSource
+	}
+
+	logMessage := NewLogMessage(slogLevelToLogLevel(record.Level), builder.String(), source)
+	if !record.Time.IsZero() {
+		logMessage.Timestamp = record.Time
+	}
+
+	handler.logger.dispatch(logMessage, record.PC)
+	return nil
+}
+
+// WithAttrs returns a handler that adds the attributes to every record
+func (handler *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	derived := *handler
+	derived.groups = append([]string(nil), handler.groups...)
+
+	var builder strings.Builder
+	builder.WriteString(handler.preformatted)
+	for _, attr := range attrs {
+		if len(handler.groups) == 0 && attr.Key == SlogSourceKey {
+			derived.source = attr.Value.String()
+			continue
+		}
+		appendSlogAttr(&builder, handler.groups, attr)
+	}
+	derived.preformatted = builder.String()
+	return &derived
+}
+
+// WithGroup returns a handler that qualifies later attribute keys with name
+func (handler *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return handler
+	}
+	derived := *handler
+	derived.groups = append(append([]string(nil), handler.groups...), name)
+	return &derived
+}
+
+// appendSlogAttr renders " group.key=value", flattening nested groups
+func appendSlogAttr(builder *strings.Builder, groups []string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return // slog convention: empty attributes are ignored
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		nestedGroups := groups
+		if attr.Key != "" {
+			nestedGroups = append(append([]string(nil), groups...), attr.Key)
+		}
+		for _, nested := range attr.Value.Group() {
+			appendSlogAttr(builder, nestedGroups, nested)
+		}
+		return
+	}
+
+	builder.WriteByte(' ')
+	for _, group := range groups {
+		builder.WriteString(group)
+		builder.WriteByte('.')
+	}
+	builder.WriteString(attr.Key)
+	builder.WriteByte('=')
+
+	value := attr.Value.String()
+	if value == "" || strings.ContainsAny(value, " =\"") {
+		value = strconv.Quote(value)
+	}
+	builder.WriteString(value)
+}
+
+// ==================== CHILD LOGGERS ====================
+// With derives a logger that attaches fields to every message, so request
+// context is written once instead of on every call:
+//
+//	requestLogger := GetLogger().With("component", "API", "request_id", "req-7f3a")
+//	userLogger := requestLogger.With("user_id", 42)
+//	userLogger.Info("Order placed") // ... [API] Order placed request_id=req-7f3a user_id=42
+//
+// Arguments follow slog's convention: alternating keys and values, or
+// slog.Attr values. Fields render exactly like slog attributes (key=value,
+// appended to the message), so the redaction filter masks them as well. The
+// "component" key sets the source instead of becoming a field.
+//
+// Children keep a pointer to the root logger, so they share its handlers and
+// filters, see later configuration changes, and can be nested to any depth.
+
+// DefaultChildSource is the source used when no "component" field is set
+const DefaultChildSource = "app"
+
+type ChildLogger struct {
+	logger *Logger // Root logger whose handlers and filters are used
+	source string  // Source of every message (overridden by "component")
+	fields string  // Inherited fields, already rendered as " key=value"
+}
+
+// With returns a child logger that adds the fields to every message
+func (logger *Logger) With(fields ...interface{}) *ChildLogger {
+	return newChildLogger(logger, DefaultChildSource, "", fields)
+}
+
+// With returns a child logger with the component name as its source
+func (named *NamedLogger) With(fields ...interface{}) *ChildLogger {
+	return newChildLogger(named.logger, named.componentName, "", fields)
+}
+
+// With returns a grandchild that keeps this logger's fields and adds more
+func (child *ChildLogger) With(fields ...interface{}) *ChildLogger {
+	return newChildLogger(child.logger, child.source, child.fields, fields)
+}
+
+// newChildLogger renders the new fields after the inherited ones
+func newChildLogger(logger *Logger, source string, inherited string, fields []interface{}) *ChildLogger {
+	var builder strings.Builder
+	builder.WriteString(inherited)
+
+	// slog.Group applies slog's key/value pairing rules (including !BADKEY)
+	for _, attr := range slog.Group("", fields...).Value.Group() {
+		if attr.Key == SlogSourceKey {
+			source = attr.Value.String()
+			continue
+		}
+		appendSlogAttr(&builder, nil, attr)
+	}
+
+	return &ChildLogger{logger: logger, source: source, fields: builder.String()}
+}
+
+// GetSource returns the source every message is logged under
+func (child *ChildLogger) GetSource() string {
+	return child.source
+}
+
+// GetFields returns the rendered fields, e.g. " request_id=req-7f3a user_id=42"
+func (child *ChildLogger) GetFields() string {
+	return child.fields
+}
+
+// Debug logs a debug message with the inherited fields
+func (child *ChildLogger) Debug(message string) {
+	child.logger.log(DEBUG, child.source, message+child.fields)
+}
+
+// Info logs an info message with the inherited fields
+func (child *ChildLogger) Info(message string) {
+	child.logger.log(INFO, child.source, message+child.fields)
+}
+
+// Warn logs a warning message with the inherited fields
+func (child *ChildLogger) Warn(message string) {
+	child.logger.log(WARN, child.source, message+child.fields)
+}
+
+// Error logs an error message with the inherited fields
+func (child *ChildLogger) Error(message string) {
+	child.logger.log(ERROR, child.source, message+child.fields)
+}
+
+// Fatal logs a fatal message with the inherited fields
+func (child *ChildLogger) Fatal(message string) {
+	child.logger.log(FATAL, child.source, message+child.fields)
+}
+
+// Debugf logs a formatted debug message with the inherited fields
+func (child *ChildLogger) Debugf(format string, args ...interface{}) {
+	child.logger.log(DEBUG, child.source, fmt.Sprintf(format, args...)+child.fields)
+}
+
+// Infof logs a formatted info message with the inherited fields
+func (child *ChildLogger) Infof(format string, args ...interface{}) {
+	child.logger.log(INFO, child.source, fmt.Sprintf(format, args...)+child.fields)
+}
+
+// Warnf logs a formatted warning message with the inherited fields
+func (child *ChildLogger) Warnf(format string, args ...interface{}) {
+	child.logger.log(WARN, child.source, fmt.Sprintf(format, args...)+child.fields)
+}
+
+// Errorf logs a formatted error message with the inherited fields
+func (child *ChildLogger) Errorf(format string, args ...interface{}) {
+	child.logger.log(ERROR, child.source, fmt.Sprintf(format, args...)+child.fields)
+}
+
+// Fatalf logs a formatted fatal message with the inherited fields
+func (child *ChildLogger) Fatalf(format string, args ...interface{}) {
+	child.logger.log(FATAL, child.source, fmt.Sprintf(format, args...)+child.fields)
+}
@@ -0,0 +1,8 @@
+// Command demo runs the hotel walkthrough: go run ./14_hotel_management/cmd/demo
+package main
+
+import hotel "github.com/ayushgupta5/GoLLD/14_hotel_management"
+
+func main() {
+	hotel.RunDemo()
+}
@@ -0,0 +1,828 @@
+package hotel
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ratelimiter "github.com/ayushgupta5/GoLLD/09_rate_limiter"
+	pubsub "github.com/ayushgupta5/GoLLD/19_pubsub"
+)
+
+// ============================================================================
+// SECTION 20: MAIN - DEMONSTRATION
+// ============================================================================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("       🏨 HOTEL MANAGEMENT SYSTEM")
+	fmt.Println("═══════════════════════════════════════════")
+
+	// =========================================
+	// STEP 1: Create the hotel
+	// =========================================
+	hotel := NewHotel("Grand Plaza Hotel", "123 Main Street")
+
+	// =========================================
+	// STEP 2: Add rooms to the hotel
+	// =========================================
+	fmt.Println("\n📦 Setting up hotel rooms...")
+
+	hotel.AddRoom(NewRoom("101", 1, RoomTypeStandard))
+	hotel.AddRoom(NewRoom("102", 1, RoomTypeStandard))
+	hotel.AddRoom(NewRoom("201", 2, RoomTypeDeluxe))
+	hotel.AddRoom(NewRoom("202", 2, RoomTypeDeluxe))
+	hotel.AddRoom(NewRoom("301", 3, RoomTypeSuite))
+	hotel.AddRoom(NewRoom("401", 4, RoomTypePresidential))
+
+	fmt.Println("✅ 6 rooms added to hotel")
+
+	// =========================================
+	// STEP 3: Register guests
+	// =========================================
+	fmt.Println("\n👥 Registering guests...")
+
+	guest1 := NewGuest("G001", "John Smith", "john@email.com", "555-0101")
+	guest2 := NewGuest("G002", "Jane Doe", "jane@email.com", "555-0102")
+
+	hotel.RegisterGuest(guest1)
+	hotel.RegisterGuest(guest2)
+
+	fmt.Printf("✅ Registered: %s\n", guest1.GetName())
+	fmt.Printf("✅ Registered: %s\n", guest2.GetName())
+
+	// =========================================
+	// STEP 4: Display room status
+	// =========================================
+	hotel.DisplayRoomStatus()
+
+	// =========================================
+	// STEP 5: Search for available rooms
+	// =========================================
+	fmt.Println("\n📋 Available Deluxe Rooms:")
+	fmt.Println("─────────────────────────────────────────")
+
+	deluxeRooms := hotel.GetAvailableRoomsByType(RoomTypeDeluxe)
+	for _, room := range deluxeRooms {
+		fmt.Printf("  • %s\n", room)
+	}
+
+	// =========================================
+	// STEP 6: Create bookings
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📝 Creating Bookings...")
+
+	checkInDate := time.Now()
+	checkOutDate := checkInDate.Add(3 * 24 * time.Hour) // 3-night stay
+
+	booking1, err := hotel.CreateBooking("G001", "201", checkInDate, checkOutDate)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("✅ Booking created: %s for %s\n", booking1.GetID(), guest1.GetName())
+	}
+
+	booking2, err := hotel.CreateBooking("G002", "301", checkInDate, checkOutDate)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("✅ Booking created: %s for %s\n", booking2.GetID(), guest2.GetName())
+	}
+
+	// =========================================
+	// STEP 7: Confirm booking and check-in
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔑 Check-in Process...")
+
+	err = hotel.ConfirmBooking(booking1.GetID())
+	if err != nil {
+		fmt.Printf("❌ Error confirming: %v\n", err)
+	} else {
+		fmt.Printf("✅ Booking %s confirmed\n", booking1.GetID())
+	}
+
+	err = hotel.CheckIn(booking1.GetID())
+	if err != nil {
+		fmt.Printf("❌ Error checking in: %v\n", err)
+	} else {
+		fmt.Printf("✅ %s checked into Room %s\n", guest1.GetName(), booking1.GetRoom().GetNumber())
+	}
+
+	// =========================================
+	// STEP 8: Add services during stay
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🍽️  Adding Services...")
+
+	booking1.AddService("Room Service - Dinner", 45.00)
+	booking1.AddService("Mini Bar", 30.00)
+	booking1.AddService("Spa Treatment", 120.00)
+
+	fmt.Println("✅ Services added:")
+	fmt.Println("   • Room Service - Dinner: $45.00")
+	fmt.Println("   • Mini Bar: $30.00")
+	fmt.Println("   • Spa Treatment: $120.00")
+
+	// =========================================
+	// STEP 9: Display room status after check-in
+	// =========================================
+	hotel.DisplayRoomStatus()
+
+	// =========================================
+	// STEP 10: Process checkout
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🚪 Check-out Process...")
+
+	completedBooking, err := hotel.CheckOut(booking1.GetID())
+	if err != nil {
+		fmt.Printf("❌ Error checking out: %v\n", err)
+	} else {
+		fmt.Printf("✅ %s checked out from Room %s\n",
+			completedBooking.GetGuest().GetName(),
+			completedBooking.GetRoom().GetNumber())
+	}
+
+	// =========================================
+	// STEP 11: Generate and print the bill
+	// =========================================
+	fmt.Println(booking1.GenerateBill())
+
+	// =========================================
+	// STEP 12: Rate-limited public search API
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🚦 Public Search API (rate limited)...")
+
+	searchAPI := NewAvailabilitySearchAPI(
+		hotel,
+		ratelimiter.NewTokenBucketRateLimiter(3, 1, time.Second),  // anonymous: burst of 3 per IP
+		ratelimiter.NewTokenBucketRateLimiter(10, 5, time.Second), // authenticated: burst of 10 per guest
+	)
+	searchAPI.StartCompaction(time.Minute) // Drops the buckets of IPs that have gone quiet
+	defer searchAPI.StopCompaction()
+
+	anonymous := SearchRequest{ClientIP: "203.0.113.7"}
+	for i := 1; i <= 4; i++ {
+		rooms, err := searchAPI.SearchAvailability(anonymous, RoomTypeStandard)
+		if err != nil {
+			fmt.Printf("  ❌ Anonymous search %d: %v\n", i, err)
+		} else {
+			fmt.Printf("  ✅ Anonymous search %d: %d Standard rooms\n", i, len(rooms))
+		}
+	}
+
+	// Knowing a guest ID is not enough: only a session token from login counts
+	forged := SearchRequest{ClientIP: "203.0.113.7", SessionToken: "G002"}
+	if _, err := searchAPI.SearchAvailability(forged, RoomTypeStandard); err != nil {
+		fmt.Printf("  ❌ Forged session: %v\n", err)
+	}
+
+	sessionToken, _ := searchAPI.StartSession("G002")
+	authenticated := SearchRequest{ClientIP: "203.0.113.7", SessionToken: sessionToken}
+	for i := 1; i <= 4; i++ {
+		if _, err := searchAPI.SearchAvailability(authenticated, RoomTypeStandard); err != nil {
+			fmt.Printf("  ❌ Guest search %d: %v\n", i, err)
+		} else {
+			fmt.Printf("  ✅ Guest search %d allowed\n", i)
+		}
+	}
+
+	if _, err := searchAPI.Book(anonymous, "102", checkInDate, checkOutDate); err != nil {
+		fmt.Printf("  ❌ Anonymous booking: %v\n", err)
+	}
+	if booking3, err := searchAPI.Book(authenticated, "102", checkInDate, checkOutDate); err == nil {
+		fmt.Printf("  ✅ Guest booking created: %s\n", booking3.GetID())
+	}
+
+	// =========================================
+	// STEP 13: OTA channel manager
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🌐 OTA Channel Manager...")
+
+	channelManager := NewChannelManager(hotel)
+	channelManager.RegisterChannel(NewBookingComChannel(0.15))
+	channelManager.RegisterChannel(NewExpediaChannel(0.18))
+
+	arrival := checkInDate.Format(otaDateLayout)
+	departure := checkOutDate.Format(otaDateLayout)
+
+	bookingComPayload := []byte(fmt.Sprintf(`{
+		"reservation_id": "BDC-88231",
+		"booker": {"name": "Luca Rossi", "email": "luca@example.com"},
+		"room_type": "DELUXE",
+		"arrival": %q, "departure": %q}`, arrival, departure))
+	expediaStandardPayload := []byte(fmt.Sprintf(`{
+		"itineraryId": "EXP-5521",
+		"primaryGuest": {"givenName": "Aiko", "surname": "Tanaka", "email": "aiko@example.com"},
+		"roomTypeCode": "STD",
+		"checkInDate": %q, "checkOutDate": %q}`, arrival, departure))
+	expediaDeluxePayload := []byte(fmt.Sprintf(`{
+		"itineraryId": "EXP-5522",
+		"primaryGuest": {"givenName": "Omar", "surname": "Haddad", "email": "omar@example.com"},
+		"roomTypeCode": "DLX",
+		"checkInDate": %q, "checkOutDate": %q}`, arrival, departure))
+	expediaSoldOutPayload := []byte(fmt.Sprintf(`{
+		"itineraryId": "EXP-5523",
+		"primaryGuest": {"givenName": "Nina", "surname": "Berg", "email": "nina@example.com"},
+		"roomTypeCode": "DLX",
+		"checkInDate": %q, "checkOutDate": %q}`, arrival, departure))
+	expediaZeroNightPayload := []byte(fmt.Sprintf(`{
+		"itineraryId": "EXP-5524",
+		"primaryGuest": {"givenName": "Ravi", "surname": "Iyer", "email": "ravi@example.com"},
+		"roomTypeCode": "STD",
+		"checkInDate": %q, "checkOutDate": %q}`, arrival, arrival))
+
+	otaDeliveries := []struct {
+		channelName string
+		payload     []byte
+	}{
+		{"Booking.com", bookingComPayload}, // Room 201 is being cleaned, but free for these dates
+		{"Expedia", expediaStandardPayload},
+		{"Booking.com", bookingComPayload}, // Re-delivered by the OTA
+		{"Expedia", expediaDeluxePayload},
+		{"Expedia", expediaSoldOutPayload}, // Both Deluxe rooms are now taken
+		{"Expedia", expediaZeroNightPayload},
+	}
+	for _, delivery := range otaDeliveries {
+		booking, err := channelManager.IngestBooking(delivery.channelName, delivery.payload)
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			continue
+		}
+		fmt.Printf("  ✅ %s → %s: %s in Room %s (%s)\n",
+			delivery.channelName, booking.GetID(), booking.GetGuest().GetName(),
+			booking.GetRoom().GetNumber(), booking.GetStatus())
+	}
+
+	// The front desk tries to sell the same Deluxe room directly
+	if _, err := hotel.CreateBooking("G001", "202", checkInDate, checkOutDate); err != nil {
+		fmt.Printf("  ❌ Direct booking: %v\n", err)
+	}
+
+	fmt.Printf("  📥 Imported OTA bookings: %d\n", channelManager.GetImportedCount())
+	fmt.Printf("  💸 Commission owed: Booking.com $%.2f, Expedia $%.2f\n",
+		channelManager.GetCommission("Booking.com"), channelManager.GetCommission("Expedia"))
+
+	// =========================================
+	// STEP 14: Loyalty program
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("⭐ Loyalty Program...")
+
+	guest3 := NewGuest("G003", "Priya Nair", "priya@email.com", "555-0103")
+	hotel.RegisterGuest(guest3)
+	account, _ := hotel.EnrollInLoyalty("G003")
+
+	// A long project stay in Room 101 next month earns points and Gold status
+	projectStart := checkInDate.AddDate(0, 1, 0)
+	projectStay, err := hotel.CreateBooking("G003", "101", projectStart, projectStart.AddDate(0, 0, 26))
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		_ = hotel.ConfirmBooking(projectStay.GetID())
+		_ = hotel.CheckIn(projectStay.GetID())
+		_, _ = hotel.CheckOut(projectStay.GetID())
+		fmt.Printf("  ✅ %s stayed %d nights: %d points, tier %s\n",
+			guest3.GetName(), projectStay.GetNights(), account.GetPoints(), account.GetTier())
+	}
+
+	// Next visit: a 3-night Standard stay with 2 nights paid in points
+	visitStart := projectStart.AddDate(0, 2, 0)
+	visit, err := hotel.CreateBooking("G003", "102", visitStart, visitStart.AddDate(0, 0, 3))
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		if err := hotel.RedeemFreeNights(visit.GetID(), 5); err != nil {
+			fmt.Printf("  ❌ Redeem 5 nights: %v\n", err)
+		}
+		if err := hotel.RedeemFreeNights(visit.GetID(), 2); err == nil {
+			fmt.Printf("  ✅ Redeemed 2 free nights (%d pts each)\n", FreeNightCost(visit.GetRoom().GetPrice()))
+		}
+
+		// Gold tier: upgraded to the next room type and late checkout at check-in
+		_ = hotel.ConfirmBooking(visit.GetID())
+		_ = hotel.CheckIn(visit.GetID())
+		fmt.Printf("  ✅ Checked into Room %s (%s)\n", visit.GetRoom().GetNumber(), visit.GetRoom().GetType())
+		_, _ = hotel.CheckOut(visit.GetID())
+		fmt.Println(visit.GenerateBill())
+	}
+
+	// =========================================
+	// STEP 15: Early check-in and late checkout
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("⏰ Early Check-in & Late Checkout...")
+
+	stayStart := checkInDate.AddDate(0, 6, 0)
+	johnStay, johnErr := hotel.CreateBooking("G001", "102", stayStart, stayStart.AddDate(0, 0, 2))
+	janeStay, janeErr := hotel.CreateBooking("G002", "102", stayStart.AddDate(0, 0, 2), stayStart.AddDate(0, 0, 4))
+	if johnErr != nil || janeErr != nil {
+		fmt.Printf("  ❌ Error: %v %v\n", johnErr, janeErr)
+	} else {
+		_ = hotel.ScheduleHousekeeping("102", atHour(stayStart, 10), 2*time.Hour, "Carpet deep clean")
+
+		purchases := []struct {
+			label    string
+			purchase func() (float64, error)
+		}{
+			{"John: early check-in 9 AM", func() (float64, error) { return hotel.PurchaseEarlyCheckIn(johnStay.GetID(), 9) }},
+			{"John: early check-in 12 PM", func() (float64, error) { return hotel.PurchaseEarlyCheckIn(johnStay.GetID(), 12) }},
+			{"John: late checkout 5 PM", func() (float64, error) { return hotel.PurchaseLateCheckOut(johnStay.GetID(), 17) }},
+			{"John: late checkout 1 PM", func() (float64, error) { return hotel.PurchaseLateCheckOut(johnStay.GetID(), 13) }},
+			{"Jane: early check-in 1 PM", func() (float64, error) { return hotel.PurchaseEarlyCheckIn(janeStay.GetID(), 13) }},
+		}
+		for _, attempt := range purchases {
+			if fee, err := attempt.purchase(); err != nil {
+				fmt.Printf("  ❌ %s: %v\n", attempt.label, err)
+			} else {
+				fmt.Printf("  ✅ %s: $%.2f\n", attempt.label, fee)
+			}
+		}
+
+		fmt.Printf("  Room 102 held for %s: %s → %s\n", johnStay.GetID(),
+			johnStay.OccupancyStart().Format("Jan 02 3:04 PM"), johnStay.OccupancyEnd().Format("Jan 02 3:04 PM"))
+		fmt.Println(johnStay.GenerateBill())
+	}
+
+	// =========================================
+	// STEP 16: Maintenance tickets
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔧 Maintenance Tickets...")
+
+	remoteTicket, err := hotel.RaiseMaintenanceTicket("202", ReporterGuest, "G002", TicketPriorityMedium, "TV remote not working")
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("  📝 %s\n", remoteTicket)
+		fmt.Printf("  Room 202 status: %s\n", hotel.GetRoom("202").GetStatus())
+	}
+
+	leakTicket, err := hotel.RaiseMaintenanceTicket("202", ReporterStaff, "HK-07", TicketPriorityCritical, "Water leaking from ceiling")
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("  📝 %s\n", leakTicket)
+		fmt.Printf("  Room 202 status: %s\n", hotel.GetRoom("202").GetStatus())
+
+		if _, err := hotel.CreateBooking("G001", "202", checkInDate.AddDate(0, 1, 0), checkInDate.AddDate(0, 1, 2)); err != nil {
+			fmt.Printf("  ❌ Booking 202: %v\n", err)
+		}
+		if err := hotel.ResolveTicket(leakTicket.GetID(), "Pipe resealed"); err != nil {
+			fmt.Printf("  ❌ Resolve %s: %v\n", leakTicket.GetID(), err)
+		}
+
+		_ = hotel.AssignTicket(leakTicket.GetID(), "Tech Ravi")
+		_ = hotel.ResolveTicket(leakTicket.GetID(), "Pipe resealed, ceiling panel replaced")
+		_ = hotel.CloseTicket(leakTicket.GetID())
+		fmt.Printf("  ✅ %s\n", leakTicket)
+		fmt.Printf("  Room 202 status: %s\n", hotel.GetRoom("202").GetStatus())
+		for _, task := range hotel.GetHousekeepingSchedule("202") {
+			fmt.Printf("  🧹 Housekeeping queued: %s (%s – %s)\n",
+				task.Description, task.Start.Format("3:04 PM"), task.End.Format("3:04 PM"))
+		}
+		fmt.Printf("  Still open on 202: %d ticket(s)\n", len(hotel.GetOpenTickets("202")))
+	}
+
+	// =========================================
+	// STEP 17: Function spaces and event bookings
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🎉 Function Spaces & Events...")
+
+	hotel.AddFunctionSpace(NewFunctionSpace("Crystal Ballroom", FunctionSpaceBallroom, 200, 450))
+	hotel.AddFunctionSpace(NewFunctionSpace("Oak Boardroom", FunctionSpaceMeetingRoom, 14, 60))
+
+	eventDay := checkInDate.AddDate(0, 0, 1)
+	gala, err := hotel.CreateEventBooking("G002", "Crystal Ballroom", "Anniversary Gala", atHour(eventDay, 18), 4, 120)
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("  ✅ %s\n", gala)
+	}
+
+	// Overlapping slot, over-capacity and off-the-hour requests are rejected
+	if _, err := hotel.CreateEventBooking("G001", "Crystal Ballroom", "Product Launch", atHour(eventDay, 21), 2, 80); err != nil {
+		fmt.Printf("  ❌ Product Launch: %v\n", err)
+	}
+	if _, err := hotel.CreateEventBooking("G001", "Oak Boardroom", "Sales Kickoff", atHour(eventDay, 9), 3, 30); err != nil {
+		fmt.Printf("  ❌ Sales Kickoff: %v\n", err)
+	}
+	if _, err := hotel.CreateEventBooking("G001", "Oak Boardroom", "Board Meeting", atHour(eventDay, 9).Add(30*time.Minute), 2, 10); err != nil {
+		fmt.Printf("  ❌ Board Meeting: %v\n", err)
+	}
+
+	// Back-to-back slots in the same space are fine
+	launch, err := hotel.CreateEventBooking("G001", "Crystal Ballroom", "Product Launch", atHour(eventDay, 14), 4, 80)
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("  ✅ %s\n", launch)
+	}
+
+	if gala != nil {
+		_ = hotel.AddCatering(gala.GetID(), "Cocktail Hour")
+		_ = hotel.AddCatering(gala.GetID(), "Plated Dinner")
+		_ = hotel.ConfirmEventBooking(gala.GetID())
+		fmt.Print(gala.GenerateBill())
+
+		// The organizer is staying with us, so the event goes on their room bill
+		if err := hotel.PostEventToFolio(gala.GetID(), booking2.GetID()); err != nil {
+			fmt.Printf("  ❌ Post to folio: %v\n", err)
+		} else {
+			fmt.Printf("  🧾 %s charges posted to %s (room bill now $%.2f)\n",
+				gala.GetID(), booking2.GetID(), booking2.GetTotal())
+		}
+	}
+	if launch != nil {
+		if err := hotel.AddCatering(launch.GetID(), "Unknown Brunch"); err != nil {
+			fmt.Printf("  ❌ Catering: %v\n", err)
+		}
+		_ = hotel.CancelEventBooking(launch.GetID())
+	}
+
+	fmt.Printf("  📅 Crystal Ballroom on %s:\n", eventDay.Format("Jan 02"))
+	for _, event := range hotel.GetSpaceSchedule("Crystal Ballroom", eventDay) {
+		fmt.Printf("     • %s\n", event)
+	}
+	fmt.Printf("  Room 301 status (unaffected by events): %s\n", hotel.GetRoom("301").GetStatus())
+
+	// =========================================
+	// STEP 18: Booking events published to pub-sub
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📣 Booking Events (Pub-Sub + Outbox)...")
+
+	eventBroker := pubsub.NewMessageBroker()
+	CreateBookingTopics(eventBroker)
+	brokerLink := &flakyPublisher{broker: eventBroker}
+	outbox := hotel.SetEventPublisher(brokerLink)
+
+	// Downstream modules: notifications react to creation/checkout, analytics counts everything
+	notify := pubsub.NewSubscriber("notifications", func(msg *pubsub.Message) {
+		event := msg.Payload.(BookingEvent)
+		fmt.Printf("  📧 notifications → %s: %s for %s\n", event.GuestEmail, event.Type, event.BookingID)
+	})
+	_ = eventBroker.Subscribe(BookingCreated.Topic(), notify)
+	_ = eventBroker.Subscribe(BookingCheckedOut.Topic(), notify)
+
+	var countMutex sync.Mutex
+	eventCounts := make(map[BookingEventType]int)
+	analytics := pubsub.NewSubscriber("analytics", func(msg *pubsub.Message) {
+		event := msg.Payload.(BookingEvent)
+		countMutex.Lock()
+		eventCounts[event.Type]++
+		countMutex.Unlock()
+		fmt.Printf("  📊 analytics     ← %s\n", event)
+	})
+	for _, eventType := range []BookingEventType{BookingCreated, BookingCheckedIn, BookingCheckedOut, BookingCancelled} {
+		_ = eventBroker.Subscribe(eventType.Topic(), analytics)
+	}
+
+	weekend := checkInDate.AddDate(0, 2, 0)
+	freeRooms := hotel.GetAllAvailableRooms()
+	sort.Slice(freeRooms, func(i, j int) bool { return freeRooms[i].GetNumber() < freeRooms[j].GetNumber() })
+	eventStay, err := hotel.CreateBooking("G002", freeRooms[0].GetNumber(), weekend, weekend.AddDate(0, 0, 2))
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		time.Sleep(50 * time.Millisecond) // Let the subscribers catch up
+		_ = hotel.ConfirmBooking(eventStay.GetID())
+		_ = hotel.CheckIn(eventStay.GetID())
+		time.Sleep(50 * time.Millisecond)
+
+		// The broker goes down: events wait in the outbox instead of being lost
+		brokerLink.offline.Store(true)
+		_, _ = hotel.CheckOut(eventStay.GetID())
+		if cancelled, err := hotel.CreateBooking("G001", freeRooms[1].GetNumber(), weekend, weekend.AddDate(0, 0, 1)); err == nil {
+			_ = hotel.CancelBooking(cancelled.GetID())
+		}
+		fmt.Printf("  ⚠️  Broker offline: %d event(s) buffered (%v)\n", len(outbox.GetPending()), outbox.GetLastError())
+		for _, event := range outbox.GetPending() {
+			fmt.Printf("     • %s\n", event)
+		}
+
+		// Back online: the background flusher delivers the backlog in order
+		outbox.StartFlusher(20 * time.Millisecond)
+		brokerLink.offline.Store(false)
+		time.Sleep(100 * time.Millisecond)
+		outbox.StopFlusher()
+		fmt.Printf("  ✅ Broker back: %d pending, %d published in total\n",
+			len(outbox.GetPending()), outbox.GetPublishedCount())
+		countMutex.Lock()
+		fmt.Printf("  Analytics totals: created %d, checked in %d, checked out %d, cancelled %d\n",
+			eventCounts[BookingCreated], eventCounts[BookingCheckedIn],
+			eventCounts[BookingCheckedOut], eventCounts[BookingCancelled])
+		countMutex.Unlock()
+	}
+
+	// =========================================
+	// STEP 19: Allotment contracts
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🤝 Allotment Contracts...")
+
+	hotel.AddRoom(NewRoom("501", 5, RoomTypeDeluxe))
+	hotel.AddRoom(NewRoom("502", 5, RoomTypeDeluxe))
+
+	season := atHour(checkInDate.AddDate(0, 9, 0), 0)
+	contract, err := hotel.CreateAllotment("ACME-2026", "Acme Corp", RoomTypeDeluxe, 1, season, season.AddDate(0, 0, 7), 14)
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("  ✅ %s\n", contract)
+		if _, err := hotel.CreateAllotment("WANDER-TRAVEL", "Wander Travel", RoomTypeDeluxe, 9, season, season.AddDate(0, 0, 3), 7); err != nil {
+			fmt.Printf("  ❌ Second allotment: %v\n", err)
+		}
+
+		// The public sees only the rooms the contract does not hold
+		heldRoom := contract.GetRoomNumbers()[0]
+		publicRooms, _ := searchAPI.SearchAvailabilityForDates(SearchRequest{ClientIP: "198.51.100.4"}, RoomTypeDeluxe, season, season.AddDate(0, 0, 2))
+		publicNumbers := make([]string, 0, len(publicRooms))
+		for _, room := range publicRooms {
+			publicNumbers = append(publicNumbers, room.GetNumber())
+		}
+		fmt.Printf("  🔎 Public Deluxe rooms for %s: %v (Room %s held for Acme)\n",
+			season.Format("Jan 02"), publicNumbers, heldRoom)
+		if _, err := hotel.CreateBooking("G001", heldRoom, season, season.AddDate(0, 0, 2)); err != nil {
+			fmt.Printf("  ❌ Public booking of %s: %v\n", heldRoom, err)
+		}
+
+		// An Acme employee books with the allotment code
+		acmeGuest := NewGuest("G004", "Mei Chen", "mei@acme.example", "555-0104")
+		hotel.RegisterGuest(acmeGuest)
+		if acmeStay, err := hotel.CreateAllotmentBooking("G004", "ACME-2026", season.AddDate(0, 0, 5), season.AddDate(0, 0, 7)); err != nil {
+			fmt.Printf("  ❌ Error: %v\n", err)
+		} else {
+			fmt.Printf("  ✅ %s booked %s in Room %s via %s\n",
+				acmeGuest.GetName(), acmeStay.GetID(), acmeStay.GetRoom().GetNumber(), acmeStay.GetSource())
+		}
+
+		// Ten days before arrival, nights inside the 14-day window are released
+		hotel.SetClock(func() time.Time { return season.AddDate(0, 0, -10) })
+		fmt.Printf("  ⏩ Clock moved to %s\n", season.AddDate(0, 0, -10).Format("Jan 02"))
+		if _, err := hotel.CreateAllotmentBooking("G004", "ACME-2026", season, season.AddDate(0, 0, 2)); err != nil {
+			fmt.Printf("  ❌ Acme booking: %v\n", err)
+		}
+		if released, err := hotel.CreateBooking("G001", heldRoom, season, season.AddDate(0, 0, 2)); err == nil {
+			fmt.Printf("  ✅ Public booking %s in released Room %s\n", released.GetID(), heldRoom)
+		}
+
+		usage, _ := hotel.GetAllotmentUsage("ACME-2026")
+		for _, line := range usage {
+			state := "held"
+			if line.Released {
+				state = "released"
+			}
+			fmt.Printf("     %s: %d allotted, %d picked up, %d held (%s)\n",
+				line.Night.Format("Jan 02"), line.Allotted, line.PickedUp, line.Held, state)
+		}
+		hotel.SetClock(time.Now)
+	}
+	time.Sleep(50 * time.Millisecond) // Let the booking event subscribers catch up
+
+	// =========================================
+	// STEP 20: Waitlist for fully booked dates
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("⏳ Waitlist...")
+
+	waitlistNotify := pubsub.NewSubscriber("waitlist-notifications", func(msg *pubsub.Message) {
+		event := msg.Payload.(BookingEvent)
+		detail := ""
+		if event.Type == WaitlistOffered {
+			detail = fmt.Sprintf(" (Room %s, $%.2f, reply by %s)",
+				event.RoomNumber, event.Amount, event.OfferExpiresAt.Format("15:04"))
+		}
+		fmt.Printf("  📧 notifications → %s: %s for %s%s\n", event.GuestEmail, event.Type, event.WaitlistID, detail)
+	})
+	for _, eventType := range []BookingEventType{WaitlistJoined, WaitlistOffered, WaitlistOfferExpired} {
+		_ = eventBroker.Subscribe(eventType.Topic(), waitlistNotify)
+	}
+
+	waitlistNight := atHour(checkInDate.AddDate(0, 11, 0), 0)
+	soldOutEnd := waitlistNight.AddDate(0, 0, 2)
+	soldOut, err := hotel.CreateBookingForRoomType("G001", RoomTypePresidential, waitlistNight, soldOutEnd)
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("  ✅ %s takes the only Presidential room for %s\n", soldOut.GetID(), waitlistNight.Format("Jan 02"))
+		if _, err := hotel.JoinWaitlist("G002", RoomTypeSuite, waitlistNight, soldOutEnd); err != nil {
+			fmt.Printf("  ❌ Suite waitlist: %v\n", err)
+		}
+		first, _ := hotel.JoinWaitlist("G002", RoomTypePresidential, waitlistNight, soldOutEnd)
+		second, _ := hotel.JoinWaitlist("G004", RoomTypePresidential, waitlistNight, soldOutEnd)
+		time.Sleep(50 * time.Millisecond) // Let the subscribers catch up
+
+		// The cancellation offers the room to the first guest in line
+		_ = hotel.CancelBooking(soldOut.GetID())
+		time.Sleep(50 * time.Millisecond)
+		for _, entry := range hotel.GetWaitlist(RoomTypePresidential) {
+			fmt.Printf("     • %s\n", entry)
+		}
+		if _, err := hotel.CreateBookingForRoomType("G003", RoomTypePresidential, waitlistNight, soldOutEnd); err != nil {
+			fmt.Printf("  ❌ Walk-in booking: %v\n", err)
+		}
+
+		// Jane does not answer in time: the offer moves to Mei
+		later := time.Now().Add(DefaultOfferWindow + time.Hour)
+		hotel.SetClock(func() time.Time { return later })
+		fmt.Printf("  ⏩ Clock moved forward %s\n", DefaultOfferWindow+time.Hour)
+		hotel.ProcessWaitlist()
+		time.Sleep(50 * time.Millisecond)
+		if _, err := hotel.AcceptOffer(first.GetID()); err != nil {
+			fmt.Printf("  ❌ %s accepts: %v\n", first.GetGuest().GetName(), err)
+		}
+		if booking, err := hotel.AcceptOffer(second.GetID()); err != nil {
+			fmt.Printf("  ❌ Error: %v\n", err)
+		} else {
+			fmt.Printf("  ✅ %s accepted: %s in Room %s via %s\n",
+				second.GetGuest().GetName(), booking.GetID(), booking.GetRoom().GetNumber(), booking.GetSource())
+		}
+		fmt.Printf("  Presidential waitlist entries left: %d\n", len(hotel.GetWaitlist(RoomTypePresidential)))
+		hotel.SetClock(time.Now)
+	}
+
+	// =========================================
+	// STEP 21: Hotel chain across properties
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🏢 Hotel chain...")
+
+	chain := NewHotelChain("Plaza Hotels")
+	_ = chain.AddProperty("NYC", hotel)
+	bostonHotel := NewHotel("Plaza Boston Harbor", "9 Harbor Way")
+	bostonHotel.AddRoom(NewRoom("110", 1, RoomTypeDeluxe))
+	bostonHotel.AddRoom(NewRoom("120", 1, RoomTypeSuite))
+	_ = chain.AddProperty("BOS", bostonHotel)
+	if err := chain.AddProperty("BOS", NewHotel("Duplicate", "")); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	// One profile, known at every property
+	chainGuest := NewGuest("G005", "Priya Patel", "priya@email.com", "555-0105")
+	chain.RegisterGuest(chainGuest)
+	chain.RegisterGuest(guest1) // Existing NYC guests join the chain profile too
+	miamiHotel := NewHotel("Plaza Miami Beach", "1 Ocean Drive")
+	miamiHotel.AddRoom(NewRoom("501", 5, RoomTypeDeluxe))
+	_ = chain.AddProperty("MIA", miamiHotel)
+	fmt.Printf("  ✅ %s registered once with the chain (MIA joined afterwards and knows them too)\n", chainGuest.GetName())
+
+	chainArrival := atHour(checkInDate.AddDate(1, 0, 0), 0)
+	chainDeparture := chainArrival.AddDate(0, 0, 3)
+	fmt.Printf("  🔎 Deluxe rooms chain-wide for %s:\n", chainArrival.Format("Jan 02, 2006"))
+	for _, result := range chain.SearchAvailability(RoomTypeDeluxe, chainArrival, chainDeparture) {
+		fmt.Printf("     %s %-22s %d room(s) from $%.2f/night\n",
+			result.PropertyCode, result.HotelName, len(result.Rooms), result.LowestRate)
+	}
+
+	chainStay, err := chain.CreateBooking("G005", "NYC", RoomTypeDeluxe, chainArrival, chainDeparture)
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		_ = hotel.ConfirmBooking(chainStay.GetID())
+		fmt.Printf("  ✅ %s at NYC, Room %s, $%.2f\n", chainStay.GetID(), chainStay.GetRoom().GetNumber(), chainStay.GetTotal())
+
+		// The guest's conference moved to Boston: transfer the stay
+		if moved, err := chain.TransferBooking(chainStay.GetID(), "BOS"); err != nil {
+			fmt.Printf("  ❌ Transfer: %v\n", err)
+		} else {
+			fmt.Printf("  🔁 Transferred to BOS as %s, Room %s, %s, $%.2f (NYC booking now %s)\n",
+				moved.GetID(), moved.GetRoom().GetNumber(), moved.GetStatus(), moved.GetTotal(), chainStay.GetStatus())
+			if _, err := chain.TransferBooking(chainStay.GetID(), "MIA"); err != nil {
+				fmt.Printf("  ❌ Transfer again: %v\n", err)
+			}
+		}
+	}
+
+	// Boston has one Suite: a transfer there fails and keeps the original
+	bostonSuite, _ := chain.CreateBooking("G005", "BOS", RoomTypeSuite, chainArrival, chainDeparture)
+	nycSuite, _ := chain.CreateBooking("G001", "NYC", RoomTypeSuite, chainArrival, chainDeparture)
+	if bostonSuite != nil && nycSuite != nil {
+		if _, err := chain.TransferBooking(nycSuite.GetID(), "BOS"); err != nil {
+			fmt.Printf("  ❌ %v (%s still %s)\n", err, nycSuite.GetID(), nycSuite.GetStatus())
+		}
+	}
+
+	if profile, err := chain.GetGuestProfile("G005"); err == nil {
+		fmt.Printf("  👤 %s: %d stay(s) across the chain, %d nights\n",
+			profile.Guest.GetName(), len(profile.Stays), profile.TotalNights)
+		for _, stay := range profile.Stays {
+			fmt.Printf("     %s %s Room %s %s\n", stay.PropertyCode, stay.Booking.GetID(),
+				stay.Booking.GetRoom().GetNumber(), stay.Booking.GetStatus())
+		}
+	}
+	chain.DisplayReport()
+
+	// =========================================
+	// STEP 22: Key cards and door access
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🗝️  Key cards & door access...")
+
+	hotel.AddRoom(NewRoom("203", 2, RoomTypeDeluxe))
+	cardArrival := atHour(checkInDate.AddDate(2, 0, 0), StandardCheckInHour)
+	cardStay, err := hotel.CreateBooking("G002", "203", atHour(cardArrival, 0), atHour(cardArrival, 0).AddDate(0, 0, 2))
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		hotel.SetClock(func() time.Time { return cardArrival })
+		_ = hotel.ConfirmBooking(cardStay.GetID())
+		if err := hotel.CheckIn(cardStay.GetID()); err != nil {
+			fmt.Printf("  ❌ Check-in: %v\n", err)
+		}
+		mainCard := hotel.GetKeyCards(cardStay.GetID())[0]
+		spareCard, _ := hotel.IssueKeyCard(cardStay.GetID())
+		fmt.Printf("  💳 %s checked in: %s and %s for Room %s, valid until %s\n", guest2.GetName(),
+			mainCard.GetID(), spareCard.GetID(), mainCard.GetRoomNumber(), mainCard.GetValidUntil().Format("Jan 02 3:04 PM"))
+
+		swipeAt := func(at time.Time, roomNumber, cardID string) {
+			hotel.SetClock(func() time.Time { return at })
+			event := hotel.SwipeKeyCard(roomNumber, cardID)
+			icon := "🔓"
+			if !event.Granted() {
+				icon = "🔒"
+			}
+			fmt.Printf("  %s %s %-6s at Room %s: %s\n", icon, at.Format("Jan 02 3:04 PM"), cardID, roomNumber, event.Result)
+		}
+		swipeAt(cardArrival.Add(10*time.Minute), "203", mainCard.GetID())
+		swipeAt(cardArrival.Add(15*time.Minute), "202", mainCard.GetID())
+		swipeAt(cardArrival.Add(20*time.Minute), "203", "KC-999")
+
+		_ = hotel.ReportKeyCardLost(mainCard.GetID())
+		replacement, _ := hotel.IssueKeyCard(cardStay.GetID())
+		fmt.Printf("  ⚠️  %s reported lost, %s issued as replacement\n", mainCard.GetID(), replacement.GetID())
+		swipeAt(cardArrival.Add(5*time.Hour), "203", mainCard.GetID())
+		swipeAt(cardArrival.Add(5*time.Hour), "203", replacement.GetID())
+
+		// Past the departure time the cards stop working even before checkout
+		swipeAt(cardStay.OccupancyEnd().Add(30*time.Minute), "203", spareCard.GetID())
+		if _, err := hotel.PurchaseLateCheckOut(cardStay.GetID(), 14); err == nil {
+			fmt.Printf("  🕑 Late checkout 2 PM bought: cards valid until %s\n", spareCard.GetValidUntil().Format("Jan 02 3:04 PM"))
+		}
+		swipeAt(cardStay.OccupancyEnd().Add(-time.Hour), "203", spareCard.GetID())
+
+		_, _ = hotel.CheckOut(cardStay.GetID())
+		swipeAt(cardStay.OccupancyEnd().Add(-30*time.Minute), "203", replacement.GetID())
+
+		fmt.Println("  📜 Room 203 access log:")
+		for _, event := range hotel.GetAccessLog("203") {
+			fmt.Printf("     %s %-6s %-5s %s\n", event.At.Format("Jan 02 15:04"), event.CardID, event.GuestID, event.Result)
+		}
+		if stubLock, ok := hotel.GetDoorLockIntegration().(*StubDoorLock); ok {
+			commands := stubLock.GetCommands()
+			fmt.Printf("  🔌 Lock vendor commands (last %d of %d):\n", min(6, len(commands)), len(commands))
+			for _, command := range commands[max(0, len(commands)-6):] {
+				fmt.Printf("     %s\n", command)
+			}
+		}
+		hotel.SetClock(time.Now)
+	}
+
+	// =========================================
+	// SUMMARY: Key Design Decisions
+	// =========================================
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  KEY DESIGN DECISIONS:")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  1. Room has lifecycle: Available → Occupied → Cleaning")
+	fmt.Println("  2. Booking lifecycle: Pending → Confirmed → CheckedIn → CheckedOut")
+	fmt.Println("  3. Services added dynamically during stay")
+	fmt.Println("  4. Bill generated at checkout with itemized charges")
+	fmt.Println("  5. Thread-safe operations using mutex locks")
+	fmt.Println("  6. Clean separation of entities and service layer")
+	fmt.Println("  7. Public search throttled per IP, guests get higher limits")
+	fmt.Println("  8. OTA payloads adapted to one booking model; shared overlap check")
+	fmt.Println("  9. Loyalty ledger: tiers by lifetime nights, points for free nights")
+	fmt.Println("  10. Paid early/late extensions respect turnaround and housekeeping")
+	fmt.Println("  11. Maintenance tickets: critical issues block the room until resolved")
+	fmt.Println("  12. Function spaces: hourly slots with their own conflict check, shared guests and billing")
+	fmt.Println("  13. Booking events go through an outbox to pub-sub; nothing lost if the broker is down")
+	fmt.Println("  14. Allotments hide partner rooms from public sale, unused nights auto-release before arrival")
+	fmt.Println("  15. Waitlist: cancellations offer the room in join order, held for a limited time")
+	fmt.Println("  16. Hotel chain: shared guest profiles, chain-wide search, book-then-cancel transfers")
+	fmt.Println("  17. Key cards: bound to room and stay window, revoked on checkout/loss, audited swipes")
+	fmt.Println("═══════════════════════════════════════════")
+}
+
+// flakyPublisher publishes to the broker but can be taken offline to
+// simulate an outage between the hotel and the broker.
+type flakyPublisher struct {
+	broker  *pubsub.MessageBroker
+	offline atomic.Bool
+}
+
+// Publish forwards to the broker, or fails while offline.
+func (publisher *flakyPublisher) Publish(topicName string, payload interface{}) (*pubsub.Message, error) {
+	if publisher.offline.Load() {
+		return nil, fmt.Errorf("broker unavailable, cannot publish to '%s'", topicName)
+	}
+	return publisher.broker.Publish(topicName, payload)
+}
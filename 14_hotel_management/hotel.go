@@ -0,0 +1,4530 @@
+// Package hotel models a hotel: rooms, guests, bookings, billing and the
+// services built around them.
+package hotel
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ratelimiter "github.com/ayushgupta5/GoLLD/09_rate_limiter"
+	pubsub "github.com/ayushgupta5/GoLLD/19_pubsub"
+	"github.com/ayushgupta5/GoLLD/pkg/entity"
+	"github.com/ayushgupta5/GoLLD/pkg/idgen"
+	"github.com/ayushgupta5/GoLLD/pkg/money"
+	"github.com/ayushgupta5/GoLLD/pkg/periodic"
+)
+
+// ============================================================================
+// HOTEL MANAGEMENT SYSTEM - Low Level Design
+// ============================================================================
+//
+// This system demonstrates:
+// - Entity Modeling (Guest, Room, Booking, Hotel)
+// - State Management (Room status, Booking lifecycle)
+// - Business Logic (Check-in, Check-out, Billing)
+// - Loyalty Program (points ledger, tiers, free-night redemption)
+// - Stay Extensions (paid early check-in / late checkout, housekeeping-aware)
+// - Maintenance Tickets (priority workflow, critical issues block the room)
+// - Event Bookings (hourly function-space slots, capacity, catering add-ons)
+// - Booking Events (lifecycle events to pub-sub via an outbox)
+// - Allotment Contracts (partner room blocks with automatic release)
+// - Waitlist (fully booked dates, time-limited offers on cancellation)
+// - Hotel Chain (shared guest profiles, chain-wide search, transfers, reporting)
+// - Key Cards (issued at check-in, door access simulator, per-room audit log)
+// - Thread-safe operations using mutex locks
+//
+// ============================================================================
+
+// ============================================================================
+// SECTION 1: ROOM TYPE ENUM
+// ============================================================================
+
+// RoomType represents different categories of hotel rooms.
+// Using iota for auto-incrementing values (Standard=0, Deluxe=1, etc.)
+type RoomType int
+
+const (
+	RoomTypeStandard     RoomType = iota // 0 - Basic room with essential amenities
+	RoomTypeDeluxe                       // 1 - Upgraded room with extra amenities
+	RoomTypeSuite                        // 2 - Large room with living area
+	RoomTypePresidential                 // 3 - Top-tier luxury room
+)
+
+// String returns a human-readable name for the room type.
+func (roomType RoomType) String() string {
+	names := [...]string{"Standard", "Deluxe", "Suite", "Presidential"}
+	if int(roomType) < len(names) {
+		return names[roomType]
+	}
+	return "Unknown"
+}
+
+// BasePrice returns the nightly rate for each room type.
+func (roomType RoomType) BasePrice() float64 {
+	prices := [...]float64{100.0, 150.0, 250.0, 500.0}
+	if int(roomType) < len(prices) {
+		return prices[roomType]
+	}
+	return 0.0
+}
+
+// ============================================================================
+// SECTION 2: ROOM STATUS ENUM
+// ============================================================================
+
+// RoomStatus represents the current availability state of a room.
+type RoomStatus int
+
+const (
+	RoomStatusAvailable   RoomStatus = iota // 0 - Room is ready for booking
+	RoomStatusOccupied                      // 1 - Guest is currently staying
+	RoomStatusMaintenance                   // 2 - Under repair/maintenance
+	RoomStatusCleaning                      // 3 - Being cleaned after checkout
+)
+
+// String returns a human-readable name for the room status.
+func (status RoomStatus) String() string {
+	names := [...]string{"Available", "Occupied", "Maintenance", "Cleaning"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// ============================================================================
+// SECTION 3: BOOKING STATUS ENUM
+// ============================================================================
+
+// BookingStatus represents the lifecycle state of a booking.
+type BookingStatus int
+
+const (
+	BookingStatusPending    BookingStatus = iota // 0 - Booking created, awaiting confirmation
+	BookingStatusConfirmed                       // 1 - Booking confirmed, room reserved
+	BookingStatusCheckedIn                       // 2 - Guest has checked in
+	BookingStatusCheckedOut                      // 3 - Guest has checked out
+	BookingStatusCancelled                       // 4 - Booking was cancelled
+)
+
+// String returns a human-readable name for the booking status.
+func (status BookingStatus) String() string {
+	names := [...]string{"Pending", "Confirmed", "Checked-In", "Checked-Out", "Cancelled"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// ============================================================================
+// SECTION 4: GUEST ENTITY
+// ============================================================================
+
+// Guest represents a person who books a room at the hotel.
+type Guest struct {
+	entity.Person        // ID, name and contact details
+	identityCard  string // Government ID number (for verification)
+	address       string // Home address
+}
+
+// NewGuest creates and initializes a new Guest instance.
+func NewGuest(id, name, email, phone string) *Guest {
+	return &Guest{
+		Person:       entity.NewPerson(id, name, email, phone),
+		identityCard: "",
+		address:      "",
+	}
+}
+
+// SetIdentityCard sets the guest's ID card number (for verification at check-in).
+func (guest *Guest) SetIdentityCard(idCard string) {
+	guest.identityCard = idCard
+}
+
+// SetAddress sets the guest's home address.
+func (guest *Guest) SetAddress(address string) {
+	guest.address = address
+}
+
+// ============================================================================
+// SECTION 5: ROOM ENTITY
+// ============================================================================
+
+// Room represents a hotel room that can be booked by guests.
+type Room struct {
+	number        string     // Room number (e.g., "101", "201")
+	floor         int        // Floor number
+	roomType      RoomType   // Type of room (Standard, Deluxe, etc.)
+	status        RoomStatus // Current availability status
+	pricePerNight float64    // Cost per night in dollars
+	amenities     []string   // List of amenities (WiFi, TV, etc.)
+	mutex         sync.Mutex // Protects concurrent access to room state
+}
+
+// NewRoom creates a new Room with appropriate amenities based on room type.
+// Amenities are automatically assigned based on the room type.
+func NewRoom(roomNumber string, floor int, roomType RoomType) *Room {
+	// Base amenities for all rooms
+	amenities := []string{"WiFi", "TV", "AC"}
+
+	// Add amenities based on room type (cumulative)
+	if roomType >= RoomTypeDeluxe {
+		amenities = append(amenities, "Mini Bar", "Room Service")
+	}
+	if roomType >= RoomTypeSuite {
+		amenities = append(amenities, "Living Room", "Jacuzzi")
+	}
+	if roomType == RoomTypePresidential {
+		amenities = append(amenities, "Butler Service", "Private Pool")
+	}
+
+	return &Room{
+		number:        roomNumber,
+		floor:         floor,
+		roomType:      roomType,
+		status:        RoomStatusAvailable,
+		pricePerNight: roomType.BasePrice(),
+		amenities:     amenities,
+	}
+}
+
+// Getter methods for Room fields
+func (room *Room) GetNumber() string      { return room.number }
+func (room *Room) GetFloor() int          { return room.floor }
+func (room *Room) GetType() RoomType      { return room.roomType }
+func (room *Room) GetPrice() float64      { return room.pricePerNight }
+func (room *Room) GetAmenities() []string { return room.amenities }
+
+// GetStatus returns the current status of the room (thread-safe).
+func (room *Room) GetStatus() RoomStatus {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	return room.status
+}
+
+// SetStatus updates the room status (thread-safe).
+func (room *Room) SetStatus(newStatus RoomStatus) {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	room.status = newStatus
+}
+
+// IsAvailable checks if the room can be booked.
+func (room *Room) IsAvailable() bool {
+	return room.GetStatus() == RoomStatusAvailable
+}
+
+// String returns a formatted description of the room.
+func (room *Room) String() string {
+	return fmt.Sprintf("Room %s (%s) - $%.2f/night - %s",
+		room.number, room.roomType, room.pricePerNight, room.status)
+}
+
+// ============================================================================
+// SECTION 6: SERVICE ENTITY
+// ============================================================================
+
+// Service represents an additional service consumed by a guest during their stay.
+// Examples: Room Service, Spa Treatment, Mini Bar, Laundry, etc.
+type Service struct {
+	name      string    // Name of the service
+	price     float64   // Cost of the service
+	timestamp time.Time // When the service was ordered
+}
+
+// NewService creates a new Service instance.
+func NewService(name string, price float64) Service {
+	return Service{
+		name:      name,
+		price:     price,
+		timestamp: time.Now(),
+	}
+}
+
+func (service Service) GetName() string   { return service.name }
+func (service Service) GetPrice() float64 { return service.price }
+
+// ============================================================================
+// SECTION 7: BOOKING ENTITY
+// ============================================================================
+
+// BookingSourceDirect marks bookings made at the front desk or on the hotel's own site.
+const BookingSourceDirect = "Direct"
+
+// bookingIDGen generates unique IDs for bookings.
+var bookingIDGen = idgen.NewSequence("BK")
+
+// Booking represents a room reservation made by a guest.
+// It tracks the entire stay lifecycle from creation to checkout.
+type Booking struct {
+	id           string        // Unique identifier (e.g., "BK-1")
+	guest        *Guest        // Guest who made the booking
+	room         *Room         // Room that was booked
+	checkInDate  time.Time     // Scheduled check-in date
+	checkOutDate time.Time     // Scheduled check-out date
+	status       BookingStatus // Current status of the booking
+	nightlyRate  float64       // Rate locked in at booking time (kept on upgrade)
+	totalAmount  float64       // Total bill amount (room + services - free nights)
+	services     []Service     // Additional services consumed
+	source       string        // Where the booking came from ("Direct" or an OTA name)
+	createdAt    time.Time     // When the booking was created
+	mutex        sync.Mutex    // Protects concurrent modifications
+
+	// Loyalty details (zero values for non-members)
+	loyaltyAccount *LoyaltyAccount // Guest's loyalty account, if a member
+	freeNights     int             // Nights paid for with points
+	pointsRedeemed int             // Points spent on free nights
+	redeemedValue  float64         // Room charge waived by free nights
+	pointsEarned   int             // Points accrued at checkout
+	lateCheckout   string          // Late checkout time granted by tier
+	upgradedFrom   string          // Original room type if upgraded at check-in
+
+	// Occupancy window overrides (0 = standard check-in / checkout hour)
+	earlyCheckInHour int // Purchased arrival hour on the check-in date
+	lateCheckOutHour int // Purchased or tier-granted departure hour on the check-out date
+}
+
+// NewBooking creates a new booking for a guest and room.
+// The total amount is initially calculated based on room rate and number of nights.
+func NewBooking(guest *Guest, room *Room, checkInDate, checkOutDate time.Time) *Booking {
+	numberOfNights := calculateNights(checkInDate, checkOutDate)
+	roomTotal := room.GetPrice() * float64(numberOfNights)
+
+	return &Booking{
+		id:           bookingIDGen.NextID(),
+		guest:        guest,
+		room:         room,
+		checkInDate:  checkInDate,
+		checkOutDate: checkOutDate,
+		status:       BookingStatusPending,
+		nightlyRate:  room.GetPrice(),
+		totalAmount:  roomTotal,
+		services:     make([]Service, 0),
+		source:       BookingSourceDirect,
+		createdAt:    time.Now(),
+	}
+}
+
+// calculateNights computes the number of nights between two dates.
+// Minimum stay is 1 night.
+func calculateNights(checkIn, checkOut time.Time) int {
+	hours := checkOut.Sub(checkIn).Hours()
+	nights := int(hours / 24)
+	if nights < 1 {
+		nights = 1
+	}
+	return nights
+}
+
+// Getter methods for Booking
+func (booking *Booking) GetID() string              { return booking.id }
+func (booking *Booking) GetGuest() *Guest           { return booking.guest }
+func (booking *Booking) GetRoom() *Room             { return booking.room }
+func (booking *Booking) GetTotal() float64          { return booking.totalAmount }
+func (booking *Booking) GetCheckInDate() time.Time  { return booking.checkInDate }
+func (booking *Booking) GetCheckOutDate() time.Time { return booking.checkOutDate }
+func (booking *Booking) GetSource() string          { return booking.source }
+
+// Overlaps reports whether this booking still holds its room for any night
+// in [checkIn, checkOut). Cancelled and completed bookings hold nothing.
+func (booking *Booking) Overlaps(checkIn, checkOut time.Time) bool {
+	status := booking.GetStatus()
+	if status == BookingStatusCancelled || status == BookingStatusCheckedOut {
+		return false
+	}
+	return checkIn.Before(booking.checkOutDate) && booking.checkInDate.Before(checkOut)
+}
+
+// GetStatus returns the current booking status (thread-safe).
+func (booking *Booking) GetStatus() BookingStatus {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+	return booking.status
+}
+
+// GetNights returns the number of nights for this booking.
+func (booking *Booking) GetNights() int {
+	return calculateNights(booking.checkInDate, booking.checkOutDate)
+}
+
+// Confirm changes the booking status from Pending to Confirmed.
+func (booking *Booking) Confirm() error {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	if booking.status != BookingStatusPending {
+		return fmt.Errorf("cannot confirm: booking is not in pending status (current: %s)", booking.status)
+	}
+
+	booking.status = BookingStatusConfirmed
+	return nil
+}
+
+// CheckIn processes guest check-in.
+// Only confirmed bookings can be checked in.
+func (booking *Booking) CheckIn() error {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	if booking.status != BookingStatusConfirmed {
+		return fmt.Errorf("cannot check in: booking must be confirmed first (current: %s)", booking.status)
+	}
+
+	booking.status = BookingStatusCheckedIn
+	booking.room.SetStatus(RoomStatusOccupied)
+	return nil
+}
+
+// CheckOut processes guest checkout.
+// Only checked-in guests can check out.
+func (booking *Booking) CheckOut() error {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	if booking.status != BookingStatusCheckedIn {
+		return fmt.Errorf("cannot check out: guest has not checked in (current: %s)", booking.status)
+	}
+
+	booking.status = BookingStatusCheckedOut
+	booking.room.SetStatus(RoomStatusCleaning) // Room needs cleaning after checkout
+	return nil
+}
+
+// Cancel cancels the booking if the guest hasn't checked in yet.
+func (booking *Booking) Cancel() error {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	if booking.status == BookingStatusCheckedIn {
+		return fmt.Errorf("cannot cancel: guest has already checked in")
+	}
+
+	if booking.status == BookingStatusCheckedOut {
+		return fmt.Errorf("cannot cancel: booking has already been completed")
+	}
+
+	if booking.status == BookingStatusCancelled {
+		return fmt.Errorf("booking is already cancelled")
+	}
+
+	booking.status = BookingStatusCancelled
+	return nil
+}
+
+// AddService adds an additional service to the booking and updates the total.
+func (booking *Booking) AddService(serviceName string, price float64) {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	service := NewService(serviceName, price)
+	booking.services = append(booking.services, service)
+	booking.totalAmount += price
+}
+
+// GenerateBill creates a formatted invoice for the booking.
+func (booking *Booking) GenerateBill() string {
+	numberOfNights := booking.GetNights()
+	roomCharge := booking.nightlyRate * float64(numberOfNights)
+
+	bill := fmt.Sprintf(`
+╔════════════════════════════════════════════════╗
+║              🏨 HOTEL INVOICE                  ║
+╠════════════════════════════════════════════════╣
+  Booking ID: %s
+  Guest: %s
+  Room: %s (%s)
+  
+  Check-in:  %s
+  Check-out: %s
+  Nights: %d
+  
+  ─────────────────────────────────────
+  CHARGES:
+  Room (%d nights × $%.2f): $%.2f
+`,
+		booking.id,
+		booking.guest.GetName(),
+		booking.room.GetNumber(),
+		booking.room.GetType(),
+		booking.OccupancyStart().Format("Jan 02, 2006 3:04 PM"),
+		booking.OccupancyEnd().Format("Jan 02, 2006 3:04 PM"),
+		numberOfNights,
+		numberOfNights,
+		booking.nightlyRate,
+		roomCharge,
+	)
+
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	if booking.upgradedFrom != "" {
+		bill += fmt.Sprintf("  (Complimentary upgrade from %s)\n", booking.upgradedFrom)
+	}
+
+	// Add each service to the bill
+	for _, service := range booking.services {
+		bill += fmt.Sprintf("  %s: %s\n", service.GetName(), money.Format(service.GetPrice()))
+	}
+
+	// Free nights are shown as a credit against the room charge
+	if booking.freeNights > 0 {
+		bill += fmt.Sprintf("  Free nights (%d × %d pts): -%s\n",
+			booking.freeNights, booking.pointsRedeemed/booking.freeNights, money.Format(booking.redeemedValue))
+	}
+
+	bill += fmt.Sprintf(`  ─────────────────────────────────────
+  TOTAL: %s
+`, money.Format(booking.totalAmount))
+
+	if booking.loyaltyAccount != nil {
+		bill += fmt.Sprintf(`  ─────────────────────────────────────
+  LOYALTY (%s):
+  Points redeemed: %d ($%.2f)
+  Points earned:   %d
+  Balance:         %d pts
+`,
+			booking.loyaltyAccount.GetTier(),
+			booking.pointsRedeemed, booking.redeemedValue,
+			booking.pointsEarned,
+			booking.loyaltyAccount.GetPoints(),
+		)
+		if booking.lateCheckout != "" {
+			bill += fmt.Sprintf("  Late checkout:   %s\n", booking.lateCheckout)
+		}
+	}
+
+	bill += "╚════════════════════════════════════════════════╝\n"
+	return bill
+}
+
+// ============================================================================
+// SECTION 8: HOTEL (Main Service)
+// ============================================================================
+
+// Hotel is the central service that manages rooms, guests, and bookings.
+type Hotel struct {
+	name         string                        // Hotel name
+	address      string                        // Hotel address
+	rooms        map[string]*Room              // All rooms (key: room number)
+	bookings     map[string]*Booking           // All bookings (key: booking ID)
+	guests       map[string]*Guest             // All registered guests (key: guest ID)
+	loyalty      *LoyaltyProgram               // Points ledger for enrolled guests
+	housekeeping map[string][]HousekeepingTask // Scheduled tasks (key: room number)
+	tickets      map[string]*MaintenanceTicket // Maintenance tickets (key: ticket ID)
+
+	functionSpaces map[string]*FunctionSpace // Halls and meeting rooms (key: space name)
+	eventBookings  map[string]*EventBooking  // Event reservations (key: event ID)
+
+	outbox *BookingOutbox // Booking events waiting for the broker (nil = events off)
+
+	allotments map[string]*AllotmentContract // Corporate/agent room blocks (key: allotment code)
+	clock      func() time.Time              // Source of "now" for allotment releases (time.Now unless replaced)
+
+	waitlist        []*WaitlistEntry          // Active entries for sold-out dates, in join order
+	waitlistEntries map[string]*WaitlistEntry // Every entry, including closed ones (key: entry ID)
+	offerWindow     time.Duration             // How long a waitlist offer holds the room
+
+	keyCards *KeyCardSystem // Issued key cards and door audit logs
+
+	mutex sync.RWMutex // Read-write lock for thread-safe operations
+}
+
+// NewHotel creates and initializes a new Hotel instance.
+func NewHotel(name, address string) *Hotel {
+	return &Hotel{
+		name:     name,
+		address:  address,
+		rooms:    make(map[string]*Room),
+		bookings: make(map[string]*Booking),
+		guests:   make(map[string]*Guest),
+		loyalty:  NewLoyaltyProgram(),
+
+		housekeeping: make(map[string][]HousekeepingTask),
+		tickets:      make(map[string]*MaintenanceTicket),
+
+		functionSpaces: make(map[string]*FunctionSpace),
+		eventBookings:  make(map[string]*EventBooking),
+
+		allotments: make(map[string]*AllotmentContract),
+		clock:      time.Now,
+
+		waitlistEntries: make(map[string]*WaitlistEntry),
+		offerWindow:     DefaultOfferWindow,
+
+		keyCards: NewKeyCardSystem(),
+	}
+}
+
+// Getter methods for Hotel
+func (hotel *Hotel) GetName() string    { return hotel.name }
+func (hotel *Hotel) GetAddress() string { return hotel.address }
+
+// AddRoom adds a room to the hotel's inventory.
+func (hotel *Hotel) AddRoom(room *Room) {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+	hotel.rooms[room.GetNumber()] = room
+}
+
+// GetRoom returns a room by number, or nil if it doesn't exist.
+func (hotel *Hotel) GetRoom(roomNumber string) *Room {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+	return hotel.rooms[roomNumber]
+}
+
+// RegisterGuest adds a guest to the hotel's system.
+func (hotel *Hotel) RegisterGuest(guest *Guest) {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+	hotel.guests[guest.GetID()] = guest
+}
+
+// GetAvailableRoomsByType returns all available rooms of a specific type.
+func (hotel *Hotel) GetAvailableRoomsByType(roomType RoomType) []*Room {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	availableRooms := make([]*Room, 0)
+	for _, room := range hotel.rooms {
+		if room.IsAvailable() && room.GetType() == roomType {
+			availableRooms = append(availableRooms, room)
+		}
+	}
+	return availableRooms
+}
+
+// GetAllAvailableRooms returns all available rooms regardless of type.
+func (hotel *Hotel) GetAllAvailableRooms() []*Room {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	availableRooms := make([]*Room, 0)
+	for _, room := range hotel.rooms {
+		if room.IsAvailable() {
+			availableRooms = append(availableRooms, room)
+		}
+	}
+	return availableRooms
+}
+
+// CreateBooking creates a new booking for a guest.
+// Returns an error if the guest or room doesn't exist, or if the room isn't available.
+func (hotel *Hotel) CreateBooking(guestID, roomNumber string, checkIn, checkOut time.Time) (*Booking, error) {
+	booking, err := hotel.createBooking(guestID, roomNumber, checkIn, checkOut)
+	if err != nil {
+		return nil, err
+	}
+	hotel.flushEvents()
+	return booking, nil
+}
+
+// createBooking validates and stores a booking for a specific room.
+func (hotel *Hotel) createBooking(guestID, roomNumber string, checkIn, checkOut time.Time) (*Booking, error) {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	// Validate guest exists
+	guest, guestExists := hotel.guests[guestID]
+	if !guestExists {
+		return nil, fmt.Errorf("guest with ID '%s' not found", guestID)
+	}
+
+	// Validate room exists
+	room, roomExists := hotel.rooms[roomNumber]
+	if !roomExists {
+		return nil, fmt.Errorf("room '%s' not found", roomNumber)
+	}
+
+	// Validate room is available
+	if !room.IsAvailable() {
+		return nil, fmt.Errorf("room '%s' is not available (status: %s)", roomNumber, room.GetStatus())
+	}
+
+	// Validate dates
+	if checkOut.Before(checkIn) {
+		return nil, fmt.Errorf("check-out date cannot be before check-in date")
+	}
+
+	// Validate no other booking (direct or OTA) holds the room for these dates
+	if hotel.hasOverlappingBooking(roomNumber, checkIn, checkOut) {
+		return nil, fmt.Errorf("room '%s' is already booked for the requested dates", roomNumber)
+	}
+
+	// Validate no allotment contract hides the room from public sale
+	if hotel.isRoomAllotted(roomNumber, checkIn, checkOut) {
+		return nil, fmt.Errorf("room '%s' is held for an allotment on the requested dates", roomNumber)
+	}
+
+	// Validate no waitlisted guest has an open offer on the room
+	if hotel.isRoomOffered(roomNumber, checkIn, checkOut) {
+		return nil, fmt.Errorf("room '%s' is offered to a waitlisted guest for the requested dates", roomNumber)
+	}
+
+	// Create and store the booking
+	booking := NewBooking(guest, room, checkIn, checkOut)
+	hotel.bookings[booking.GetID()] = booking
+	hotel.recordBookingEventLocked(BookingCreated, booking)
+
+	return booking, nil
+}
+
+// isRoomBooked reports whether any active booking, unreleased allotment or
+// open waitlist offer holds the room for the dates. Purchased early arrivals
+// and late departures of other bookings count too, so a new stay never lands
+// inside someone's extended occupancy window. Caller must hold hotel.mutex.
+func (hotel *Hotel) isRoomBooked(roomNumber string, checkIn, checkOut time.Time) bool {
+	return hotel.hasOverlappingBooking(roomNumber, checkIn, checkOut) ||
+		hotel.isRoomAllotted(roomNumber, checkIn, checkOut) ||
+		hotel.isRoomOffered(roomNumber, checkIn, checkOut)
+}
+
+// hasOverlappingBooking reports whether any active booking holds the room
+// for the dates, ignoring allotments. Caller must hold hotel.mutex.
+func (hotel *Hotel) hasOverlappingBooking(roomNumber string, checkIn, checkOut time.Time) bool {
+	arrival := atHour(checkIn, StandardCheckInHour)
+	departure := atHour(checkOut, StandardCheckOutHour)
+	for _, booking := range hotel.bookings {
+		if booking.GetRoom().GetNumber() != roomNumber {
+			continue
+		}
+		if booking.Overlaps(checkIn, checkOut) || booking.holdsRoomDuring(arrival, departure) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateBookingForRoomType books any free room of the given type.
+// External channels sell room types, not room numbers, so the hotel picks
+// the room. Allocation happens under the same lock as CreateBooking, so an
+// OTA booking and a direct booking can never get the same room and dates.
+func (hotel *Hotel) CreateBookingForRoomType(guestID string, roomType RoomType, checkIn, checkOut time.Time) (*Booking, error) {
+	hotel.mutex.RLock()
+	guest, guestExists := hotel.guests[guestID]
+	hotel.mutex.RUnlock()
+
+	if !guestExists {
+		return nil, fmt.Errorf("guest with ID '%s' not found", guestID)
+	}
+	return hotel.createBookingForRoomType(guest, roomType, checkIn, checkOut)
+}
+
+// createBookingForRoomType allocates a room for guest, who need not be
+// registered yet, and publishes the booking event.
+func (hotel *Hotel) createBookingForRoomType(guest *Guest, roomType RoomType, checkIn, checkOut time.Time) (*Booking, error) {
+	booking, err := hotel.allocateBooking(guest, roomType, checkIn, checkOut)
+	if err != nil {
+		return nil, err
+	}
+	hotel.flushEvents()
+	return booking, nil
+}
+
+// allocateBooking picks the lowest-numbered free room of the type and books
+// it. Rooms are free by their bookings for the dates, not by today's status:
+// a room occupied or being cleaned now can still be sold for next week. Only
+// rooms under maintenance are skipped. An unregistered guest is registered
+// once a room is found, so a rejected reservation leaves no guest behind.
+func (hotel *Hotel) allocateBooking(guest *Guest, roomType RoomType, checkIn, checkOut time.Time) (*Booking, error) {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	if !checkOut.After(checkIn) {
+		return nil, fmt.Errorf("check-out date must be after check-in date")
+	}
+
+	// Sort room numbers so allocation is deterministic (lowest number first)
+	roomNumbers := make([]string, 0, len(hotel.rooms))
+	for number, room := range hotel.rooms {
+		if room.GetType() == roomType && room.GetStatus() != RoomStatusMaintenance {
+			roomNumbers = append(roomNumbers, number)
+		}
+	}
+	sort.Strings(roomNumbers)
+
+	for _, number := range roomNumbers {
+		if hotel.isRoomBooked(number, checkIn, checkOut) {
+			continue
+		}
+		if _, registered := hotel.guests[guest.GetID()]; !registered {
+			hotel.guests[guest.GetID()] = guest
+		}
+		booking := NewBooking(guest, hotel.rooms[number], checkIn, checkOut)
+		hotel.bookings[booking.GetID()] = booking
+		hotel.recordBookingEventLocked(BookingCreated, booking)
+		return booking, nil
+	}
+
+	return nil, fmt.Errorf("no %s room available for the requested dates", roomType)
+}
+
+// ConfirmBooking confirms a pending booking.
+func (hotel *Hotel) ConfirmBooking(bookingID string) error {
+	hotel.mutex.RLock()
+	booking, exists := hotel.bookings[bookingID]
+	hotel.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+
+	return booking.Confirm()
+}
+
+// CheckIn processes guest check-in for a booking.
+// Loyalty members receive their tier benefits before the room is occupied.
+// The guest gets a key card for the room; if the lock system refuses it,
+// the check-in still stands and IssueKeyCard can be retried.
+func (hotel *Hotel) CheckIn(bookingID string) error {
+	hotel.mutex.RLock()
+	booking, exists := hotel.bookings[bookingID]
+	now := hotel.clock()
+	hotel.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+
+	// Benefits and check-in happen under one hotel lock; benefits granted
+	// for a check-in that fails are taken back
+	checkIn := func() error {
+		undoBenefits := hotel.applyTierBenefits(booking)
+		if err := booking.CheckIn(); err != nil {
+			undoBenefits()
+			return err
+		}
+		return nil
+	}
+	if err := hotel.transitionBooking(booking, checkIn, BookingCheckedIn); err != nil {
+		return err
+	}
+	if _, err := hotel.keyCards.issue(booking, now); err != nil {
+		return fmt.Errorf("checked in, but no key card issued: %w", err)
+	}
+	return nil
+}
+
+// CheckOut processes guest checkout for a booking.
+// Returns the booking so the final bill can be generated.
+func (hotel *Hotel) CheckOut(bookingID string) (*Booking, error) {
+	hotel.mutex.RLock()
+	booking, exists := hotel.bookings[bookingID]
+	hotel.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+
+	if err := hotel.transitionBooking(booking, booking.CheckOut, BookingCheckedOut); err != nil {
+		return nil, err
+	}
+
+	// The cards stop opening the door right away; a failed revoke at the
+	// lock vendor only leaves the physical card encoded until it expires
+	_ = hotel.keyCards.deactivateBooking(bookingID)
+
+	// A critical issue reported during the stay takes the room out of service now
+	hotel.mutex.Lock()
+	hotel.syncMaintenanceStatusLocked(booking.GetRoom())
+	hotel.mutex.Unlock()
+
+	hotel.accrueLoyaltyPoints(booking)
+	return booking, nil
+}
+
+// CancelBooking cancels an existing booking.
+func (hotel *Hotel) CancelBooking(bookingID string) error {
+	hotel.mutex.RLock()
+	booking, exists := hotel.bookings[bookingID]
+	hotel.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+
+	if err := hotel.transitionBooking(booking, booking.Cancel, BookingCancelled); err != nil {
+		return err
+	}
+
+	// Give back any points spent on free nights
+	booking.mutex.Lock()
+	account, pointsRedeemed := booking.loyaltyAccount, booking.pointsRedeemed
+	booking.mutex.Unlock()
+	if account != nil && pointsRedeemed > 0 {
+		account.credit(bookingID, pointsRedeemed, "Refund for cancelled booking")
+	}
+
+	// The freed room goes to the first waitlisted guest it suits
+	hotel.ProcessWaitlist()
+	return nil
+}
+
+// transitionBooking applies a status change to the booking and records its
+// event in the same critical section, then publishes it.
+func (hotel *Hotel) transitionBooking(booking *Booking, change func() error, eventType BookingEventType) error {
+	hotel.mutex.Lock()
+	if err := change(); err != nil {
+		hotel.mutex.Unlock()
+		return err
+	}
+	hotel.recordBookingEventLocked(eventType, booking)
+	hotel.mutex.Unlock()
+
+	hotel.flushEvents()
+	return nil
+}
+
+// DisplayRoomStatus shows the current status of all rooms in the hotel.
+func (hotel *Hotel) DisplayRoomStatus() {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	fmt.Printf("\n🏨 %s - Room Status\n", hotel.name)
+	fmt.Println("─────────────────────────────────────────")
+
+	for _, room := range hotel.rooms {
+		// Use green circle for available, red for unavailable
+		statusIcon := "🟢"
+		if room.GetStatus() != RoomStatusAvailable {
+			statusIcon = "🔴"
+		}
+		fmt.Printf("  %s %s\n", statusIcon, room)
+	}
+}
+
+// ============================================================================
+// SECTION 9: RATE-LIMITED AVAILABILITY SEARCH API
+// ============================================================================
+//
+// The public availability search is the hotel's most-hit entry point and is
+// open to anonymous traffic, so it is throttled per client IP. Guests who
+// have logged in present a session token; they are keyed by guest ID and get
+// a larger bucket, and booking requests always require a session.
+//
+// Any limiter from 09_rate_limiter works. Limiters that support Compact
+// (Token Bucket, Sliding Window) have their idle keys dropped by the
+// compaction job, so a flood of one-off IPs can't grow memory without bound.
+//
+// ============================================================================
+
+// SessionTTL is how long a search API session stays valid after login.
+const SessionTTL = 24 * time.Hour
+
+// SearchRequest describes a caller of the public API.
+// SessionToken is empty for anonymous traffic.
+type SearchRequest struct {
+	ClientIP     string
+	SessionToken string // Issued by StartSession at login
+}
+
+// RateLimitError is returned when a caller has exhausted its quota,
+// so an HTTP layer can map it to a 429 response.
+type RateLimitError struct {
+	Key string // The limiter key that was throttled (e.g., "ip:10.0.0.1")
+}
+
+func (err *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s", err.Key)
+}
+
+// searchSession is a logged-in guest's session.
+type searchSession struct {
+	guestID   string
+	expiresAt time.Time
+}
+
+// compactable is implemented by limiters that can drop the state of idle keys.
+type compactable interface {
+	Compact() int
+}
+
+// AvailabilitySearchAPI is the public entry point in front of the Hotel.
+type AvailabilitySearchAPI struct {
+	hotel                *Hotel
+	anonymousLimiter     ratelimiter.RateLimiter  // Keyed by client IP
+	authenticatedLimiter ratelimiter.RateLimiter  // Keyed by guest ID (higher limits)
+	sessions             map[string]searchSession // Session token -> session
+	compaction           periodic.Job             // Drops idle limiter keys and expired sessions
+	mutex                sync.Mutex               // Protects sessions
+}
+
+// NewAvailabilitySearchAPI wires the hotel with separate limiters for
+// anonymous and authenticated callers.
+func NewAvailabilitySearchAPI(hotel *Hotel, anonymousLimiter, authenticatedLimiter ratelimiter.RateLimiter) *AvailabilitySearchAPI {
+	return &AvailabilitySearchAPI{
+		hotel:                hotel,
+		anonymousLimiter:     anonymousLimiter,
+		authenticatedLimiter: authenticatedLimiter,
+		sessions:             make(map[string]searchSession),
+	}
+}
+
+// StartSession logs a registered guest in and returns their session token.
+// The login flow calls it once the guest has proven who they are.
+func (api *AvailabilitySearchAPI) StartSession(guestID string) (string, error) {
+	api.hotel.mutex.RLock()
+	_, exists := api.hotel.guests[guestID]
+	api.hotel.mutex.RUnlock()
+	if !exists {
+		return "", fmt.Errorf("guest with ID '%s' not found", guestID)
+	}
+
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("generating session token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	api.sessions[token] = searchSession{guestID: guestID, expiresAt: time.Now().Add(SessionTTL)}
+	return token, nil
+}
+
+// EndSession logs the session out. Unknown tokens are ignored.
+func (api *AvailabilitySearchAPI) EndSession(token string) {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	delete(api.sessions, token)
+}
+
+// authenticatedGuest returns the guest whose live session the request
+// carries, or false for anonymous and expired callers.
+func (api *AvailabilitySearchAPI) authenticatedGuest(request SearchRequest) (string, bool) {
+	if request.SessionToken == "" {
+		return "", false
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	session, exists := api.sessions[request.SessionToken]
+	if !exists {
+		return "", false
+	}
+	if !time.Now().Before(session.expiresAt) {
+		delete(api.sessions, request.SessionToken)
+		return "", false
+	}
+	return session.guestID, true
+}
+
+// checkLimit picks the limiter for the caller and consumes one request.
+func (api *AvailabilitySearchAPI) checkLimit(request SearchRequest) error {
+	if guestID, ok := api.authenticatedGuest(request); ok {
+		key := "guest:" + guestID
+		if !api.authenticatedLimiter.Allow(key) {
+			return &RateLimitError{Key: key}
+		}
+		return nil
+	}
+
+	key := "ip:" + request.ClientIP
+	if !api.anonymousLimiter.Allow(key) {
+		return &RateLimitError{Key: key}
+	}
+	return nil
+}
+
+// Compact drops limiter state for idle keys and forgets expired sessions.
+// Returns how many limiter keys were dropped.
+func (api *AvailabilitySearchAPI) Compact() int {
+	dropped := 0
+	for _, limiter := range []ratelimiter.RateLimiter{api.anonymousLimiter, api.authenticatedLimiter} {
+		if compacting, ok := limiter.(compactable); ok {
+			dropped += compacting.Compact()
+		}
+	}
+
+	now := time.Now()
+	api.mutex.Lock()
+	for token, session := range api.sessions {
+		if !now.Before(session.expiresAt) {
+			delete(api.sessions, token)
+		}
+	}
+	api.mutex.Unlock()
+	return dropped
+}
+
+// StartCompaction runs Compact in the background every interval.
+// Calling it while compaction is running has no effect.
+func (api *AvailabilitySearchAPI) StartCompaction(interval time.Duration) {
+	api.compaction.Start(interval, func(time.Time) {
+		api.Compact()
+	})
+}
+
+// StopCompaction stops the background compaction and waits for a run in
+// progress to finish.
+func (api *AvailabilitySearchAPI) StopCompaction() {
+	api.compaction.Stop()
+}
+
+// SearchAvailability returns available rooms of the given type.
+func (api *AvailabilitySearchAPI) SearchAvailability(request SearchRequest, roomType RoomType) ([]*Room, error) {
+	if err := api.checkLimit(request); err != nil {
+		return nil, err
+	}
+	return api.hotel.GetAvailableRoomsByType(roomType), nil
+}
+
+// SearchAvailabilityForDates returns rooms of the given type bookable for the
+// dates. Rooms held by allotment contracts are not listed.
+func (api *AvailabilitySearchAPI) SearchAvailabilityForDates(request SearchRequest, roomType RoomType, checkIn, checkOut time.Time) ([]*Room, error) {
+	if err := api.checkLimit(request); err != nil {
+		return nil, err
+	}
+	return api.hotel.GetAvailableRoomsForDates(roomType, checkIn, checkOut), nil
+}
+
+// Book creates a booking for the guest whose session the request carries.
+// Anonymous callers must log in before booking.
+func (api *AvailabilitySearchAPI) Book(request SearchRequest, roomNumber string, checkIn, checkOut time.Time) (*Booking, error) {
+	guestID, ok := api.authenticatedGuest(request)
+	if !ok {
+		return nil, fmt.Errorf("booking requires a logged-in guest")
+	}
+	if err := api.checkLimit(request); err != nil {
+		return nil, err
+	}
+	return api.hotel.CreateBooking(guestID, roomNumber, checkIn, checkOut)
+}
+
+// ============================================================================
+// SECTION 10: OTA CHANNEL MANAGER
+// ============================================================================
+//
+// Hotels sell inventory through Online Travel Agencies (Booking.com, Expedia,
+// ...). Each OTA pushes reservations in its own payload format and charges a
+// commission on the room revenue. The ChannelManager:
+// - Parses each OTA's payload into a common ExternalBooking (Adapter Pattern)
+// - Allocates a room through the Hotel, sharing the same availability check
+//   as direct bookings (no double allocation)
+// - Ignores re-delivered payloads (OTAs retry on timeouts)
+// - Tracks commission owed per channel
+//
+// ============================================================================
+
+// ExternalBooking is the channel-neutral form of an OTA reservation.
+type ExternalBooking struct {
+	ChannelName  string    // OTA that sold the booking
+	ExternalRef  string    // OTA's own reservation ID
+	GuestName    string    // Lead guest's full name
+	GuestEmail   string    // Lead guest's email
+	RoomType     RoomType  // Room category sold
+	CheckInDate  time.Time // Arrival date
+	CheckOutDate time.Time // Departure date
+}
+
+// OTAChannel adapts one external channel's payload format.
+type OTAChannel interface {
+	GetName() string
+	GetCommissionRate() float64 // Fraction of room revenue, e.g. 0.15
+	ParseBooking(payload []byte) (*ExternalBooking, error)
+}
+
+// otaDateLayout is the date format both simulated OTAs use.
+const otaDateLayout = "2006-01-02"
+
+// parseStayDates parses arrival/departure strings in otaDateLayout.
+func parseStayDates(arrival, departure string) (time.Time, time.Time, error) {
+	checkIn, err := time.Parse(otaDateLayout, arrival)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid arrival date %q", arrival)
+	}
+	checkOut, err := time.Parse(otaDateLayout, departure)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid departure date %q", departure)
+	}
+	return checkIn, checkOut, nil
+}
+
+// BookingComChannel simulates a Booking.com-style reservation feed.
+type BookingComChannel struct {
+	commissionRate float64
+}
+
+// NewBookingComChannel creates the channel with its commission rate.
+func NewBookingComChannel(commissionRate float64) *BookingComChannel {
+	return &BookingComChannel{commissionRate: commissionRate}
+}
+
+func (channel *BookingComChannel) GetName() string            { return "Booking.com" }
+func (channel *BookingComChannel) GetCommissionRate() float64 { return channel.commissionRate }
+
+// bookingComPayload is the wire format pushed by Booking.com.
+type bookingComPayload struct {
+	ReservationID string `json:"reservation_id"`
+	Booker        struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"booker"`
+	RoomType  string `json:"room_type"` // "STANDARD", "DELUXE", "SUITE", "PRESIDENTIAL"
+	Arrival   string `json:"arrival"`
+	Departure string `json:"departure"`
+}
+
+// ParseBooking maps a Booking.com payload to an ExternalBooking.
+func (channel *BookingComChannel) ParseBooking(payload []byte) (*ExternalBooking, error) {
+	var reservation bookingComPayload
+	if err := json.Unmarshal(payload, &reservation); err != nil {
+		return nil, fmt.Errorf("malformed Booking.com payload: %v", err)
+	}
+
+	roomTypes := map[string]RoomType{
+		"STANDARD":     RoomTypeStandard,
+		"DELUXE":       RoomTypeDeluxe,
+		"SUITE":        RoomTypeSuite,
+		"PRESIDENTIAL": RoomTypePresidential,
+	}
+	roomType, known := roomTypes[reservation.RoomType]
+	if !known {
+		return nil, fmt.Errorf("unknown Booking.com room type %q", reservation.RoomType)
+	}
+
+	checkIn, checkOut, err := parseStayDates(reservation.Arrival, reservation.Departure)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExternalBooking{
+		ChannelName:  channel.GetName(),
+		ExternalRef:  reservation.ReservationID,
+		GuestName:    reservation.Booker.Name,
+		GuestEmail:   reservation.Booker.Email,
+		RoomType:     roomType,
+		CheckInDate:  checkIn,
+		CheckOutDate: checkOut,
+	}, nil
+}
+
+// ExpediaChannel simulates an Expedia-style reservation feed.
+type ExpediaChannel struct {
+	commissionRate float64
+}
+
+// NewExpediaChannel creates the channel with its commission rate.
+func NewExpediaChannel(commissionRate float64) *ExpediaChannel {
+	return &ExpediaChannel{commissionRate: commissionRate}
+}
+
+func (channel *ExpediaChannel) GetName() string            { return "Expedia" }
+func (channel *ExpediaChannel) GetCommissionRate() float64 { return channel.commissionRate }
+
+// expediaPayload is the wire format pushed by Expedia.
+type expediaPayload struct {
+	ItineraryID  string `json:"itineraryId"`
+	PrimaryGuest struct {
+		GivenName string `json:"givenName"`
+		Surname   string `json:"surname"`
+		Email     string `json:"email"`
+	} `json:"primaryGuest"`
+	RoomTypeCode string `json:"roomTypeCode"` // "STD", "DLX", "STE", "PRS"
+	CheckInDate  string `json:"checkInDate"`
+	CheckOutDate string `json:"checkOutDate"`
+}
+
+// ParseBooking maps an Expedia payload to an ExternalBooking.
+func (channel *ExpediaChannel) ParseBooking(payload []byte) (*ExternalBooking, error) {
+	var itinerary expediaPayload
+	if err := json.Unmarshal(payload, &itinerary); err != nil {
+		return nil, fmt.Errorf("malformed Expedia payload: %v", err)
+	}
+
+	roomTypes := map[string]RoomType{
+		"STD": RoomTypeStandard,
+		"DLX": RoomTypeDeluxe,
+		"STE": RoomTypeSuite,
+		"PRS": RoomTypePresidential,
+	}
+	roomType, known := roomTypes[itinerary.RoomTypeCode]
+	if !known {
+		return nil, fmt.Errorf("unknown Expedia room type code %q", itinerary.RoomTypeCode)
+	}
+
+	checkIn, checkOut, err := parseStayDates(itinerary.CheckInDate, itinerary.CheckOutDate)
+	if err != nil {
+		return nil, err
+	}
+
+	guest := itinerary.PrimaryGuest
+	return &ExternalBooking{
+		ChannelName:  channel.GetName(),
+		ExternalRef:  itinerary.ItineraryID,
+		GuestName:    strings.TrimSpace(guest.GivenName + " " + guest.Surname),
+		GuestEmail:   guest.Email,
+		RoomType:     roomType,
+		CheckInDate:  checkIn,
+		CheckOutDate: checkOut,
+	}, nil
+}
+
+// ChannelManager ingests OTA bookings into the hotel.
+type ChannelManager struct {
+	hotel       *Hotel
+	channels    map[string]OTAChannel // Registered channels (key: channel name)
+	imported    map[string]*Booking   // Already-ingested bookings (key: channel + external ref)
+	commissions map[string]float64    // Commission owed (key: channel name)
+	mutex       sync.Mutex            // Serializes ingestion for idempotency
+}
+
+// NewChannelManager creates a channel manager for a hotel.
+func NewChannelManager(hotel *Hotel) *ChannelManager {
+	return &ChannelManager{
+		hotel:       hotel,
+		channels:    make(map[string]OTAChannel),
+		imported:    make(map[string]*Booking),
+		commissions: make(map[string]float64),
+	}
+}
+
+// RegisterChannel connects an OTA to the hotel.
+func (manager *ChannelManager) RegisterChannel(channel OTAChannel) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.channels[channel.GetName()] = channel
+}
+
+// IngestBooking parses an OTA payload and creates a confirmed hotel booking.
+// Re-delivering the same reservation returns the original booking.
+func (manager *ChannelManager) IngestBooking(channelName string, payload []byte) (*Booking, error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	channel, exists := manager.channels[channelName]
+	if !exists {
+		return nil, fmt.Errorf("channel '%s' is not registered", channelName)
+	}
+
+	external, err := channel.ParseBooking(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	importKey := channelName + ":" + external.ExternalRef
+	if booking, alreadyImported := manager.imported[importKey]; alreadyImported {
+		return booking, nil
+	}
+
+	// OTA guests are not registered at the hotel yet; key them by channel
+	// reference. The hotel registers them only if a room is allocated.
+	guest := NewGuest(fmt.Sprintf("OTA-%s", importKey), external.GuestName, external.GuestEmail, "")
+	booking, err := manager.hotel.createBookingForRoomType(
+		guest, external.RoomType, external.CheckInDate, external.CheckOutDate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s reservation %s rejected: %v", channelName, external.ExternalRef, err)
+	}
+
+	// OTA reservations arrive already guaranteed by the channel
+	booking.source = channelName
+	if err := booking.Confirm(); err != nil {
+		return nil, err
+	}
+
+	manager.imported[importKey] = booking
+	manager.commissions[channelName] += booking.GetTotal() * channel.GetCommissionRate()
+	return booking, nil
+}
+
+// GetCommission returns the commission owed to a channel.
+func (manager *ChannelManager) GetCommission(channelName string) float64 {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return manager.commissions[channelName]
+}
+
+// GetImportedCount returns the number of bookings ingested from all channels.
+func (manager *ChannelManager) GetImportedCount() int {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return len(manager.imported)
+}
+
+// ============================================================================
+// SECTION 11: LOYALTY PROGRAM
+// ============================================================================
+//
+// Guests enrolled in the loyalty program earn points for every paid night
+// and climb tiers based on lifetime nights. Higher tiers earn points faster
+// and unlock late checkout and room upgrades at check-in. Points can be
+// redeemed for free nights on a pending or confirmed booking.
+
+const (
+	// PointsPerNight is the base number of points earned for each paid night.
+	PointsPerNight = 100
+
+	// PointsPerDollar sets the redemption price: a free night costs the
+	// booking's nightly rate × PointsPerDollar points.
+	PointsPerDollar = 10
+)
+
+// LoyaltyTier represents a guest's status level in the loyalty program.
+type LoyaltyTier int
+
+const (
+	LoyaltyTierMember   LoyaltyTier = iota // 0 - Just enrolled
+	LoyaltyTierSilver                      // 1 - 10+ lifetime nights
+	LoyaltyTierGold                        // 2 - 25+ lifetime nights
+	LoyaltyTierPlatinum                    // 3 - 50+ lifetime nights
+)
+
+// String returns a human-readable name for the tier.
+func (tier LoyaltyTier) String() string {
+	names := [...]string{"Member", "Silver", "Gold", "Platinum"}
+	if int(tier) < len(names) {
+		return names[tier]
+	}
+	return "Unknown"
+}
+
+// MinNights returns the lifetime nights needed to reach the tier.
+func (tier LoyaltyTier) MinNights() int {
+	thresholds := [...]int{0, 10, 25, 50}
+	if int(tier) < len(thresholds) {
+		return thresholds[tier]
+	}
+	return 0
+}
+
+// PointsMultiplier returns the bonus applied to points earned per night.
+func (tier LoyaltyTier) PointsMultiplier() float64 {
+	multipliers := [...]float64{1.0, 1.25, 1.5, 2.0}
+	if int(tier) < len(multipliers) {
+		return multipliers[tier]
+	}
+	return 1.0
+}
+
+// LateCheckoutHour returns the checkout hour the tier unlocks (0 for none).
+func (tier LoyaltyTier) LateCheckoutHour() int {
+	hours := [...]int{0, 13, 14, 16}
+	if int(tier) < len(hours) {
+		return hours[tier]
+	}
+	return 0
+}
+
+// LateCheckout returns the late checkout time the tier unlocks ("" for none).
+func (tier LoyaltyTier) LateCheckout() string {
+	if hour := tier.LateCheckoutHour(); hour > 0 {
+		return formatHour(hour)
+	}
+	return ""
+}
+
+// GetsUpgrade reports whether the tier unlocks a room upgrade at check-in.
+func (tier LoyaltyTier) GetsUpgrade() bool {
+	return tier >= LoyaltyTierGold
+}
+
+// tierForNights returns the highest tier reached with the given lifetime nights.
+func tierForNights(lifetimeNights int) LoyaltyTier {
+	tier := LoyaltyTierMember
+	for candidate := LoyaltyTierSilver; candidate <= LoyaltyTierPlatinum; candidate++ {
+		if lifetimeNights >= candidate.MinNights() {
+			tier = candidate
+		}
+	}
+	return tier
+}
+
+// FreeNightCost returns the points needed to redeem one night at the given rate.
+func FreeNightCost(nightlyRate float64) int {
+	return int(nightlyRate * PointsPerDollar)
+}
+
+// LoyaltyTransaction is one entry in a guest's points ledger.
+// Points are positive for accruals/refunds and negative for redemptions.
+type LoyaltyTransaction struct {
+	BookingID   string
+	Points      int
+	Description string
+	Timestamp   time.Time
+}
+
+// LoyaltyAccount tracks a guest's points balance, lifetime nights and history.
+type LoyaltyAccount struct {
+	guestID        string
+	points         int
+	lifetimeNights int
+	history        []LoyaltyTransaction
+	mutex          sync.Mutex
+}
+
+// GetGuestID returns the ID of the guest who owns the account.
+func (account *LoyaltyAccount) GetGuestID() string { return account.guestID }
+
+// GetPoints returns the current points balance.
+func (account *LoyaltyAccount) GetPoints() int {
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+	return account.points
+}
+
+// GetLifetimeNights returns the total nights stayed since enrollment.
+func (account *LoyaltyAccount) GetLifetimeNights() int {
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+	return account.lifetimeNights
+}
+
+// GetTier returns the guest's current tier.
+func (account *LoyaltyAccount) GetTier() LoyaltyTier {
+	return tierForNights(account.GetLifetimeNights())
+}
+
+// GetHistory returns a copy of the points ledger.
+func (account *LoyaltyAccount) GetHistory() []LoyaltyTransaction {
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+	return append([]LoyaltyTransaction(nil), account.history...)
+}
+
+// earnStay accrues points for a completed stay and returns the points earned.
+// Only paid nights earn points, but every night counts toward tier status.
+// The multiplier is the tier held before this stay is added.
+func (account *LoyaltyAccount) earnStay(bookingID string, paidNights, totalNights int) int {
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+
+	tier := tierForNights(account.lifetimeNights)
+	earned := int(float64(paidNights*PointsPerNight) * tier.PointsMultiplier())
+
+	account.points += earned
+	account.lifetimeNights += totalNights
+	account.history = append(account.history, LoyaltyTransaction{
+		BookingID:   bookingID,
+		Points:      earned,
+		Description: fmt.Sprintf("Stay: %d nights (%s × %.2f)", totalNights, tier, tier.PointsMultiplier()),
+		Timestamp:   time.Now(),
+	})
+	return earned
+}
+
+// redeem deducts points from the balance.
+func (account *LoyaltyAccount) redeem(bookingID string, points int, description string) error {
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+
+	if points > account.points {
+		return fmt.Errorf("insufficient points: need %d, have %d", points, account.points)
+	}
+
+	account.points -= points
+	account.history = append(account.history, LoyaltyTransaction{
+		BookingID:   bookingID,
+		Points:      -points,
+		Description: description,
+		Timestamp:   time.Now(),
+	})
+	return nil
+}
+
+// credit adds points back to the balance (e.g., refund on cancellation).
+func (account *LoyaltyAccount) credit(bookingID string, points int, description string) {
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+
+	account.points += points
+	account.history = append(account.history, LoyaltyTransaction{
+		BookingID:   bookingID,
+		Points:      points,
+		Description: description,
+		Timestamp:   time.Now(),
+	})
+}
+
+// LoyaltyProgram holds the accounts of all enrolled guests.
+type LoyaltyProgram struct {
+	accounts map[string]*LoyaltyAccount // Key: guest ID
+	mutex    sync.RWMutex
+}
+
+// NewLoyaltyProgram creates an empty loyalty program.
+func NewLoyaltyProgram() *LoyaltyProgram {
+	return &LoyaltyProgram{
+		accounts: make(map[string]*LoyaltyAccount),
+	}
+}
+
+// Enroll opens an account for a guest. Enrolling twice returns the existing account.
+func (program *LoyaltyProgram) Enroll(guestID string) *LoyaltyAccount {
+	program.mutex.Lock()
+	defer program.mutex.Unlock()
+
+	if account, exists := program.accounts[guestID]; exists {
+		return account
+	}
+
+	account := &LoyaltyAccount{guestID: guestID}
+	program.accounts[guestID] = account
+	return account
+}
+
+// GetAccount returns a guest's account, if the guest is enrolled.
+func (program *LoyaltyProgram) GetAccount(guestID string) (*LoyaltyAccount, bool) {
+	program.mutex.RLock()
+	defer program.mutex.RUnlock()
+	account, exists := program.accounts[guestID]
+	return account, exists
+}
+
+// EnrollInLoyalty enrolls a registered guest in the hotel's loyalty program.
+func (hotel *Hotel) EnrollInLoyalty(guestID string) (*LoyaltyAccount, error) {
+	hotel.mutex.RLock()
+	_, guestExists := hotel.guests[guestID]
+	hotel.mutex.RUnlock()
+
+	if !guestExists {
+		return nil, fmt.Errorf("guest with ID '%s' not found", guestID)
+	}
+
+	return hotel.loyalty.Enroll(guestID), nil
+}
+
+// GetLoyaltyAccount returns the loyalty account of an enrolled guest.
+func (hotel *Hotel) GetLoyaltyAccount(guestID string) (*LoyaltyAccount, error) {
+	account, isMember := hotel.loyalty.GetAccount(guestID)
+	if !isMember {
+		return nil, fmt.Errorf("guest '%s' is not a loyalty member", guestID)
+	}
+	return account, nil
+}
+
+// RedeemFreeNights pays for some nights of a booking with points.
+// The booking must not have started yet; the waived room charge is
+// deducted from the booking total.
+func (hotel *Hotel) RedeemFreeNights(bookingID string, nights int) error {
+	hotel.mutex.RLock()
+	booking, exists := hotel.bookings[bookingID]
+	hotel.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+
+	account, err := hotel.GetLoyaltyAccount(booking.GetGuest().GetID())
+	if err != nil {
+		return err
+	}
+
+	return booking.redeemFreeNights(account, nights)
+}
+
+// redeemFreeNights spends the guest's points on free nights for this booking.
+func (booking *Booking) redeemFreeNights(account *LoyaltyAccount, nights int) error {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	if booking.status != BookingStatusPending && booking.status != BookingStatusConfirmed {
+		return fmt.Errorf("cannot redeem points: booking is %s", booking.status)
+	}
+
+	if nights <= 0 || booking.freeNights+nights > booking.GetNights() {
+		return fmt.Errorf("can redeem between 1 and %d nights", booking.GetNights()-booking.freeNights)
+	}
+
+	pointsNeeded := nights * FreeNightCost(booking.nightlyRate)
+	description := fmt.Sprintf("Redeemed %d free night(s)", nights)
+	if err := account.redeem(booking.id, pointsNeeded, description); err != nil {
+		return err
+	}
+
+	waivedCharge := booking.nightlyRate * float64(nights)
+	booking.loyaltyAccount = account
+	booking.freeNights += nights
+	booking.pointsRedeemed += pointsNeeded
+	booking.redeemedValue += waivedCharge
+	booking.totalAmount -= waivedCharge
+	return nil
+}
+
+// applyTierBenefits upgrades Gold and above one room type if a free room
+// exists for the whole stay, then grants the tier's late checkout if the
+// room is not needed by the next guest or housekeeping in the meantime.
+// The guest keeps paying the original nightly rate.
+// It returns a function that restores the booking as it was, for when the
+// check-in the benefits were granted for fails. Caller must hold
+// hotel.mutex, so no other booking can grab the upgrade room or the late
+// checkout hours in the meantime.
+func (hotel *Hotel) applyTierBenefits(booking *Booking) (undo func()) {
+	booking.mutex.Lock()
+	loyaltyAccount, room, upgradedFrom := booking.loyaltyAccount, booking.room, booking.upgradedFrom
+	lateCheckOutHour, lateCheckout := booking.lateCheckOutHour, booking.lateCheckout
+	booking.mutex.Unlock()
+	undo = func() {
+		booking.mutex.Lock()
+		defer booking.mutex.Unlock()
+		booking.loyaltyAccount, booking.room, booking.upgradedFrom = loyaltyAccount, room, upgradedFrom
+		booking.lateCheckOutHour, booking.lateCheckout = lateCheckOutHour, lateCheckout
+	}
+
+	account, isMember := hotel.loyalty.GetAccount(booking.GetGuest().GetID())
+	if !isMember || booking.GetStatus() != BookingStatusConfirmed {
+		return undo
+	}
+
+	tier := account.GetTier()
+
+	booking.mutex.Lock()
+	booking.loyaltyAccount = account
+	booking.mutex.Unlock()
+
+	if tier.GetsUpgrade() {
+		hotel.upgradeRoom(booking)
+	}
+
+	currentEnd := booking.OccupancyEnd()
+	lateHour := tier.LateCheckoutHour()
+	if lateHour <= currentEnd.Hour() {
+		return undo
+	}
+	lateEnd := atHour(booking.checkOutDate, lateHour)
+	if hotel.checkRoomWindow(booking.GetRoom().GetNumber(), currentEnd, lateEnd, booking) != nil {
+		return undo
+	}
+	booking.mutex.Lock()
+	booking.lateCheckOutHour = lateHour
+	booking.lateCheckout = tier.LateCheckout()
+	booking.mutex.Unlock()
+	return undo
+}
+
+// upgradeRoom moves the booking to the lowest-numbered free room of the next
+// room type, if one is free for the booking's whole occupancy window.
+// Caller must hold hotel.mutex.
+func (hotel *Hotel) upgradeRoom(booking *Booking) {
+	currentType := booking.GetRoom().GetType()
+	if currentType >= RoomTypePresidential {
+		return
+	}
+	upgradeType := currentType + 1
+
+	roomNumbers := make([]string, 0)
+	for number, room := range hotel.rooms {
+		if room.GetType() == upgradeType && room.IsAvailable() {
+			roomNumbers = append(roomNumbers, number)
+		}
+	}
+	sort.Strings(roomNumbers)
+
+	start, end := booking.OccupancyStart(), booking.OccupancyEnd()
+	for _, number := range roomNumbers {
+		if hotel.isRoomBooked(number, booking.checkInDate, booking.checkOutDate) ||
+			hotel.checkRoomWindow(number, start, end, booking) != nil {
+			continue
+		}
+		booking.mutex.Lock()
+		booking.upgradedFrom = currentType.String()
+		booking.room = hotel.rooms[number]
+		booking.mutex.Unlock()
+		return
+	}
+}
+
+// accrueLoyaltyPoints credits points for a completed stay to a member's account.
+func (hotel *Hotel) accrueLoyaltyPoints(booking *Booking) {
+	account, isMember := hotel.loyalty.GetAccount(booking.GetGuest().GetID())
+	if !isMember {
+		return
+	}
+
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	totalNights := booking.GetNights()
+	booking.loyaltyAccount = account
+	booking.pointsEarned = account.earnStay(booking.id, totalNights-booking.freeNights, totalNights)
+}
+
+// ============================================================================
+// SECTION 12: EARLY CHECK-IN & LATE CHECKOUT
+// ============================================================================
+//
+// A stay occupies its room from the standard check-in time on the arrival
+// day to the standard checkout time on the departure day. Guests can buy an
+// earlier arrival or a later departure by the hour. The extra hours must
+// not collide with the room's neighbouring bookings (plus the housekeeping
+// turnaround between stays) or with housekeeping tasks scheduled for it.
+//
+// Purchases are billed as service line items, and the extended occupancy
+// window is what availability checks for new bookings see.
+
+const (
+	StandardCheckInHour  = 15 // 3 PM
+	StandardCheckOutHour = 11 // 11 AM
+	EarliestCheckInHour  = 6  // Earliest purchasable arrival
+	LatestCheckOutHour   = 18 // Latest purchasable departure
+
+	// HousekeepingTurnaround is the cleaning time needed between two stays.
+	HousekeepingTurnaround = 2 * time.Hour
+
+	// ExtensionHourlyRate is the share of the nightly rate charged per extra
+	// hour, capped at ExtensionMaxRate (a half-day rate).
+	ExtensionHourlyRate = 0.10
+	ExtensionMaxRate    = 0.50
+)
+
+// atHour returns the given hour on the calendar day of t.
+func atHour(t time.Time, hour int) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, hour, 0, 0, 0, t.Location())
+}
+
+// formatHour renders an hour of the day as "3:00 PM".
+func formatHour(hour int) string {
+	return time.Date(2000, 1, 1, hour, 0, 0, 0, time.UTC).Format("3:04 PM")
+}
+
+// extensionFee prices a number of extra hours at the booking's nightly rate.
+func extensionFee(nightlyRate float64, hours int) float64 {
+	return money.Round(nightlyRate * min(ExtensionMaxRate, ExtensionHourlyRate*float64(hours)))
+}
+
+// HousekeepingTask blocks a room for cleaning or maintenance.
+type HousekeepingTask struct {
+	RoomNumber  string
+	Start       time.Time
+	End         time.Time
+	Description string
+}
+
+// OccupancyStart returns when the guest may enter the room.
+func (booking *Booking) OccupancyStart() time.Time {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	hour := StandardCheckInHour
+	if booking.earlyCheckInHour > 0 {
+		hour = booking.earlyCheckInHour
+	}
+	return atHour(booking.checkInDate, hour)
+}
+
+// OccupancyEnd returns when the guest must leave the room.
+func (booking *Booking) OccupancyEnd() time.Time {
+	booking.mutex.Lock()
+	defer booking.mutex.Unlock()
+
+	hour := StandardCheckOutHour
+	if booking.lateCheckOutHour > 0 {
+		hour = booking.lateCheckOutHour
+	}
+	return atHour(booking.checkOutDate, hour)
+}
+
+// holdsRoomDuring reports whether this booking needs its room at any time in
+// [start, end), counting the housekeeping turnaround before and after the stay.
+func (booking *Booking) holdsRoomDuring(start, end time.Time) bool {
+	status := booking.GetStatus()
+	if status == BookingStatusCancelled || status == BookingStatusCheckedOut {
+		return false
+	}
+	busyFrom := booking.OccupancyStart().Add(-HousekeepingTurnaround)
+	busyUntil := booking.OccupancyEnd().Add(HousekeepingTurnaround)
+	return start.Before(busyUntil) && busyFrom.Before(end)
+}
+
+// ScheduleHousekeeping blocks a room for a cleaning or maintenance task.
+func (hotel *Hotel) ScheduleHousekeeping(roomNumber string, start time.Time, duration time.Duration, description string) error {
+	if duration <= 0 {
+		return fmt.Errorf("housekeeping duration must be positive")
+	}
+
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	if _, exists := hotel.rooms[roomNumber]; !exists {
+		return fmt.Errorf("room '%s' not found", roomNumber)
+	}
+	hotel.housekeeping[roomNumber] = append(hotel.housekeeping[roomNumber], HousekeepingTask{
+		RoomNumber:  roomNumber,
+		Start:       start,
+		End:         start.Add(duration),
+		Description: description,
+	})
+	return nil
+}
+
+// GetHousekeepingSchedule returns a room's housekeeping tasks in start order.
+func (hotel *Hotel) GetHousekeepingSchedule(roomNumber string) []HousekeepingTask {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	tasks := make([]HousekeepingTask, len(hotel.housekeeping[roomNumber]))
+	copy(tasks, hotel.housekeeping[roomNumber])
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Start.Before(tasks[j].Start) })
+	return tasks
+}
+
+// checkRoomWindow returns an error if the room is needed during [start, end)
+// by another booking or a housekeeping task. The booking itself is ignored.
+// Caller must hold hotel.mutex.
+func (hotel *Hotel) checkRoomWindow(roomNumber string, start, end time.Time, self *Booking) error {
+	for _, other := range hotel.bookings {
+		if other == self || other.GetRoom().GetNumber() != roomNumber {
+			continue
+		}
+		if other.holdsRoomDuring(start, end) {
+			return fmt.Errorf("room '%s' is needed for booking %s (%s – %s, plus %v turnaround)",
+				roomNumber, other.GetID(),
+				other.OccupancyStart().Format("Jan 02 3:04 PM"),
+				other.OccupancyEnd().Format("Jan 02 3:04 PM"),
+				HousekeepingTurnaround)
+		}
+	}
+	for _, task := range hotel.housekeeping[roomNumber] {
+		if start.Before(task.End) && task.Start.Before(end) {
+			return fmt.Errorf("room '%s' has housekeeping %s – %s (%s)",
+				roomNumber, task.Start.Format("3:04 PM"), task.End.Format("3:04 PM"), task.Description)
+		}
+	}
+	return nil
+}
+
+// PurchaseEarlyCheckIn lets the guest arrive at the given hour on the
+// check-in date. Returns the fee added to the bill. Buying an even earlier
+// hour later only charges the difference.
+func (hotel *Hotel) PurchaseEarlyCheckIn(bookingID string, hour int) (float64, error) {
+	if hour < EarliestCheckInHour || hour >= StandardCheckInHour {
+		return 0, fmt.Errorf("early check-in must be between %s and %s",
+			formatHour(EarliestCheckInHour), formatHour(StandardCheckInHour-1))
+	}
+
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	booking, exists := hotel.bookings[bookingID]
+	if !exists {
+		return 0, fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+	if status := booking.GetStatus(); status != BookingStatusPending && status != BookingStatusConfirmed {
+		return 0, fmt.Errorf("cannot buy early check-in: booking is %s", status)
+	}
+
+	currentStart := booking.OccupancyStart()
+	requestedStart := atHour(booking.checkInDate, hour)
+	if !requestedStart.Before(currentStart) {
+		return 0, fmt.Errorf("check-in is already at %s", currentStart.Format("3:04 PM"))
+	}
+	if err := hotel.checkRoomWindow(booking.GetRoom().GetNumber(), requestedStart, currentStart, booking); err != nil {
+		return 0, fmt.Errorf("early check-in at %s unavailable: %w", formatHour(hour), err)
+	}
+
+	fee := extensionFee(booking.nightlyRate, StandardCheckInHour-hour) -
+		extensionFee(booking.nightlyRate, StandardCheckInHour-currentStart.Hour())
+
+	booking.mutex.Lock()
+	booking.earlyCheckInHour = hour
+	booking.mutex.Unlock()
+	booking.AddService(fmt.Sprintf("Early check-in (%s)", formatHour(hour)), fee)
+	return fee, nil
+}
+
+// PurchaseLateCheckOut lets the guest stay until the given hour on the
+// check-out date. Returns the fee added to the bill. Hours already held,
+// such as a loyalty tier's complimentary late checkout, are not charged.
+func (hotel *Hotel) PurchaseLateCheckOut(bookingID string, hour int) (float64, error) {
+	if hour <= StandardCheckOutHour || hour > LatestCheckOutHour {
+		return 0, fmt.Errorf("late checkout must be between %s and %s",
+			formatHour(StandardCheckOutHour+1), formatHour(LatestCheckOutHour))
+	}
+
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	booking, exists := hotel.bookings[bookingID]
+	if !exists {
+		return 0, fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+	status := booking.GetStatus()
+	if status == BookingStatusCancelled || status == BookingStatusCheckedOut {
+		return 0, fmt.Errorf("cannot buy late checkout: booking is %s", status)
+	}
+
+	currentEnd := booking.OccupancyEnd()
+	requestedEnd := atHour(booking.checkOutDate, hour)
+	if !requestedEnd.After(currentEnd) {
+		return 0, fmt.Errorf("checkout is already at %s", currentEnd.Format("3:04 PM"))
+	}
+	if err := hotel.checkRoomWindow(booking.GetRoom().GetNumber(), currentEnd, requestedEnd, booking); err != nil {
+		return 0, fmt.Errorf("late checkout at %s unavailable: %w", formatHour(hour), err)
+	}
+
+	fee := extensionFee(booking.nightlyRate, hour-StandardCheckOutHour) -
+		extensionFee(booking.nightlyRate, currentEnd.Hour()-StandardCheckOutHour)
+
+	booking.mutex.Lock()
+	booking.lateCheckOutHour = hour
+	booking.mutex.Unlock()
+	booking.AddService(fmt.Sprintf("Late checkout (%s)", formatHour(hour)), fee)
+
+	// Cards already issued must keep working until the new departure time
+	if err := hotel.keyCards.extendBooking(bookingID, requestedEnd); err != nil {
+		return fee, fmt.Errorf("late checkout booked, but key cards not re-encoded: %w", err)
+	}
+	return fee, nil
+}
+
+// ============================================================================
+// SECTION 13: MAINTENANCE TICKETS
+// ============================================================================
+//
+// Guests and staff raise maintenance tickets against a room. Each ticket
+// moves through a small workflow:
+//
+//   Open → InProgress → Resolved → Closed
+//               ↑            │
+//               └── reopen ──┘ (Resolved → Open)
+//
+// A room with any open Critical ticket is taken out of service: it moves to
+// Maintenance right away, or at checkout if a guest is still in it. Once
+// its last critical ticket is resolved the room goes to Cleaning and a
+// post-maintenance clean is added to its housekeeping schedule, so it only
+// becomes bookable again after housekeeping has been through it.
+//
+// ============================================================================
+
+// PostMaintenanceCleaning is how long the housekeeping task queued after a
+// room leaves maintenance is scheduled for.
+const PostMaintenanceCleaning = 90 * time.Minute
+
+// TicketPriority ranks how urgently a maintenance issue needs attention.
+type TicketPriority int
+
+const (
+	TicketPriorityLow      TicketPriority = iota // 0 - Cosmetic (scuffed paint)
+	TicketPriorityMedium                         // 1 - Inconvenient (broken remote)
+	TicketPriorityHigh                           // 2 - Affects the stay (no hot water)
+	TicketPriorityCritical                       // 3 - Room unusable or unsafe (leak, no power)
+)
+
+// String returns a human-readable name for the priority.
+func (priority TicketPriority) String() string {
+	names := [...]string{"Low", "Medium", "High", "Critical"}
+	if int(priority) < len(names) {
+		return names[priority]
+	}
+	return "Unknown"
+}
+
+// TicketStatus is a maintenance ticket's position in the workflow.
+type TicketStatus int
+
+const (
+	TicketStatusOpen       TicketStatus = iota // 0 - Raised, waiting for a technician
+	TicketStatusInProgress                     // 1 - Technician assigned and working
+	TicketStatusResolved                       // 2 - Fix done, awaiting sign-off
+	TicketStatusClosed                         // 3 - Signed off, no further changes
+)
+
+// String returns a human-readable name for the ticket status.
+func (status TicketStatus) String() string {
+	names := [...]string{"Open", "In Progress", "Resolved", "Closed"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// IsOpen reports whether the ticket still needs work.
+func (status TicketStatus) IsOpen() bool {
+	return status == TicketStatusOpen || status == TicketStatusInProgress
+}
+
+// ticketTransitions lists the statuses each status may move to.
+var ticketTransitions = map[TicketStatus][]TicketStatus{
+	TicketStatusOpen:       {TicketStatusInProgress},
+	TicketStatusInProgress: {TicketStatusResolved},
+	TicketStatusResolved:   {TicketStatusClosed, TicketStatusOpen},
+}
+
+// ReporterType tells whether a ticket was raised by a guest or by staff.
+type ReporterType int
+
+const (
+	ReporterGuest ReporterType = iota // Raised by a registered guest
+	ReporterStaff                     // Raised by hotel staff
+)
+
+// String returns a human-readable name for the reporter type.
+func (reporter ReporterType) String() string {
+	names := [...]string{"Guest", "Staff"}
+	if int(reporter) < len(names) {
+		return names[reporter]
+	}
+	return "Unknown"
+}
+
+// TicketEvent is one entry in a ticket's audit trail.
+type TicketEvent struct {
+	Status TicketStatus
+	At     time.Time
+	Note   string
+}
+
+// ticketIDGen generates unique IDs for maintenance tickets.
+var ticketIDGen = idgen.NewSequence("MT")
+
+// MaintenanceTicket is an issue reported against a room.
+type MaintenanceTicket struct {
+	id          string         // Unique identifier (e.g., "MT-1")
+	roomNumber  string         // Room the issue is in
+	reporter    ReporterType   // Guest or staff
+	reporterID  string         // Guest ID or staff ID
+	description string         // What is wrong
+	priority    TicketPriority // How urgent it is
+	status      TicketStatus   // Current workflow status
+	assignee    string         // Technician working on it (empty until assigned)
+	resolution  string         // What was done to fix it
+	history     []TicketEvent  // Status changes, oldest first
+	createdAt   time.Time      // When the ticket was raised
+	mutex       sync.Mutex     // Protects concurrent modifications
+}
+
+// Getter methods for MaintenanceTicket
+func (ticket *MaintenanceTicket) GetID() string           { return ticket.id }
+func (ticket *MaintenanceTicket) GetRoomNumber() string   { return ticket.roomNumber }
+func (ticket *MaintenanceTicket) GetReporter() string     { return ticket.reporterID }
+func (ticket *MaintenanceTicket) GetDescription() string  { return ticket.description }
+func (ticket *MaintenanceTicket) GetCreatedAt() time.Time { return ticket.createdAt }
+
+// GetStatus returns the ticket's workflow status (thread-safe).
+func (ticket *MaintenanceTicket) GetStatus() TicketStatus {
+	ticket.mutex.Lock()
+	defer ticket.mutex.Unlock()
+	return ticket.status
+}
+
+// GetPriority returns the ticket's priority (thread-safe).
+func (ticket *MaintenanceTicket) GetPriority() TicketPriority {
+	ticket.mutex.Lock()
+	defer ticket.mutex.Unlock()
+	return ticket.priority
+}
+
+// GetAssignee returns the technician working on the ticket.
+func (ticket *MaintenanceTicket) GetAssignee() string {
+	ticket.mutex.Lock()
+	defer ticket.mutex.Unlock()
+	return ticket.assignee
+}
+
+// GetHistory returns a copy of the ticket's status changes.
+func (ticket *MaintenanceTicket) GetHistory() []TicketEvent {
+	ticket.mutex.Lock()
+	defer ticket.mutex.Unlock()
+
+	history := make([]TicketEvent, len(ticket.history))
+	copy(history, ticket.history)
+	return history
+}
+
+// isOpenCritical reports whether the ticket keeps its room out of service.
+func (ticket *MaintenanceTicket) isOpenCritical() bool {
+	ticket.mutex.Lock()
+	defer ticket.mutex.Unlock()
+	return ticket.status.IsOpen() && ticket.priority == TicketPriorityCritical
+}
+
+// transition moves the ticket to a new status if the workflow allows it.
+func (ticket *MaintenanceTicket) transition(to TicketStatus, note string) error {
+	ticket.mutex.Lock()
+	defer ticket.mutex.Unlock()
+
+	allowed := false
+	for _, next := range ticketTransitions[ticket.status] {
+		if next == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("ticket %s cannot move from %s to %s", ticket.id, ticket.status, to)
+	}
+
+	ticket.status = to
+	ticket.history = append(ticket.history, TicketEvent{Status: to, At: time.Now(), Note: note})
+	return nil
+}
+
+// String returns a one-line summary of the ticket.
+func (ticket *MaintenanceTicket) String() string {
+	ticket.mutex.Lock()
+	defer ticket.mutex.Unlock()
+	return fmt.Sprintf("%s [%s/%s] Room %s: %s (by %s %s)",
+		ticket.id, ticket.priority, ticket.status, ticket.roomNumber,
+		ticket.description, ticket.reporter, ticket.reporterID)
+}
+
+// RaiseMaintenanceTicket records an issue against a room. Guests must be
+// registered; staff IDs are taken as given. A Critical ticket takes the
+// room out of service.
+func (hotel *Hotel) RaiseMaintenanceTicket(roomNumber string, reporter ReporterType, reporterID string,
+	priority TicketPriority, description string) (*MaintenanceTicket, error) {
+	if description == "" {
+		return nil, fmt.Errorf("ticket description cannot be empty")
+	}
+	if priority < TicketPriorityLow || priority > TicketPriorityCritical {
+		return nil, fmt.Errorf("invalid ticket priority: %d", priority)
+	}
+
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	room, exists := hotel.rooms[roomNumber]
+	if !exists {
+		return nil, fmt.Errorf("room '%s' not found", roomNumber)
+	}
+	if reporter == ReporterGuest {
+		if _, registered := hotel.guests[reporterID]; !registered {
+			return nil, fmt.Errorf("guest with ID '%s' not found", reporterID)
+		}
+	}
+
+	now := time.Now()
+	ticket := &MaintenanceTicket{
+		id:          ticketIDGen.NextID(),
+		roomNumber:  roomNumber,
+		reporter:    reporter,
+		reporterID:  reporterID,
+		description: description,
+		priority:    priority,
+		status:      TicketStatusOpen,
+		history:     []TicketEvent{{Status: TicketStatusOpen, At: now, Note: "Raised"}},
+		createdAt:   now,
+	}
+	hotel.tickets[ticket.id] = ticket
+	hotel.syncMaintenanceStatusLocked(room)
+	return ticket, nil
+}
+
+// getTicketLocked looks up a ticket and its room.
+// Caller must hold hotel.mutex.
+func (hotel *Hotel) getTicketLocked(ticketID string) (*MaintenanceTicket, *Room, error) {
+	ticket, exists := hotel.tickets[ticketID]
+	if !exists {
+		return nil, nil, fmt.Errorf("maintenance ticket '%s' not found", ticketID)
+	}
+	return ticket, hotel.rooms[ticket.roomNumber], nil
+}
+
+// AssignTicket hands an open ticket to a technician and starts work on it.
+func (hotel *Hotel) AssignTicket(ticketID, technician string) error {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	ticket, _, err := hotel.getTicketLocked(ticketID)
+	if err != nil {
+		return err
+	}
+	if err := ticket.transition(TicketStatusInProgress, "Assigned to "+technician); err != nil {
+		return err
+	}
+	ticket.mutex.Lock()
+	ticket.assignee = technician
+	ticket.mutex.Unlock()
+	return nil
+}
+
+// SetTicketPriority re-triages an open ticket. Escalating to Critical takes
+// the room out of service; downgrading may release it.
+func (hotel *Hotel) SetTicketPriority(ticketID string, priority TicketPriority) error {
+	if priority < TicketPriorityLow || priority > TicketPriorityCritical {
+		return fmt.Errorf("invalid ticket priority: %d", priority)
+	}
+
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	ticket, room, err := hotel.getTicketLocked(ticketID)
+	if err != nil {
+		return err
+	}
+
+	ticket.mutex.Lock()
+	if !ticket.status.IsOpen() {
+		ticket.mutex.Unlock()
+		return fmt.Errorf("ticket %s is %s; only open tickets can be re-prioritized", ticketID, ticket.status)
+	}
+	ticket.priority = priority
+	ticket.mutex.Unlock()
+
+	hotel.syncMaintenanceStatusLocked(room)
+	return nil
+}
+
+// ResolveTicket records the fix. If it was the room's last open critical
+// ticket, the room is released to housekeeping.
+func (hotel *Hotel) ResolveTicket(ticketID, resolution string) error {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	ticket, room, err := hotel.getTicketLocked(ticketID)
+	if err != nil {
+		return err
+	}
+	if err := ticket.transition(TicketStatusResolved, resolution); err != nil {
+		return err
+	}
+	ticket.mutex.Lock()
+	ticket.resolution = resolution
+	ticket.mutex.Unlock()
+
+	hotel.syncMaintenanceStatusLocked(room)
+	return nil
+}
+
+// CloseTicket signs off a resolved ticket.
+func (hotel *Hotel) CloseTicket(ticketID string) error {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	ticket, _, err := hotel.getTicketLocked(ticketID)
+	if err != nil {
+		return err
+	}
+	return ticket.transition(TicketStatusClosed, "Closed")
+}
+
+// ReopenTicket sends a resolved ticket back to Open when the fix did not hold.
+func (hotel *Hotel) ReopenTicket(ticketID, reason string) error {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	ticket, room, err := hotel.getTicketLocked(ticketID)
+	if err != nil {
+		return err
+	}
+	if err := ticket.transition(TicketStatusOpen, reason); err != nil {
+		return err
+	}
+	hotel.syncMaintenanceStatusLocked(room)
+	return nil
+}
+
+// GetOpenTickets returns a room's open tickets, most urgent first.
+func (hotel *Hotel) GetOpenTickets(roomNumber string) []*MaintenanceTicket {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	openTickets := make([]*MaintenanceTicket, 0)
+	for _, ticket := range hotel.tickets {
+		if ticket.roomNumber == roomNumber && ticket.GetStatus().IsOpen() {
+			openTickets = append(openTickets, ticket)
+		}
+	}
+	sort.Slice(openTickets, func(i, j int) bool {
+		if openTickets[i].GetPriority() != openTickets[j].GetPriority() {
+			return openTickets[i].GetPriority() > openTickets[j].GetPriority()
+		}
+		return openTickets[i].createdAt.Before(openTickets[j].createdAt)
+	})
+	return openTickets
+}
+
+// syncMaintenanceStatusLocked puts a room into Maintenance while it has an
+// open critical ticket, and hands it to housekeeping once it has none.
+// Occupied rooms are left alone; CheckOut re-syncs them.
+// Caller must hold hotel.mutex.
+func (hotel *Hotel) syncMaintenanceStatusLocked(room *Room) {
+	hasCritical := false
+	for _, ticket := range hotel.tickets {
+		if ticket.roomNumber == room.GetNumber() && ticket.isOpenCritical() {
+			hasCritical = true
+			break
+		}
+	}
+
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+
+	switch {
+	case hasCritical && room.status != RoomStatusOccupied:
+		room.status = RoomStatusMaintenance
+	case !hasCritical && room.status == RoomStatusMaintenance:
+		room.status = RoomStatusCleaning
+		now := time.Now()
+		hotel.housekeeping[room.number] = append(hotel.housekeeping[room.number], HousekeepingTask{
+			RoomNumber:  room.number,
+			Start:       now,
+			End:         now.Add(PostMaintenanceCleaning),
+			Description: "Post-maintenance clean",
+		})
+	}
+}
+
+// ============================================================================
+// SECTION 14: FUNCTION SPACES & EVENT BOOKINGS
+// ============================================================================
+//
+// Besides rooms, the hotel rents out function spaces (ballrooms, banquet
+// halls, meeting rooms) for weddings, conferences and parties. They are sold
+// in whole-hour slots, not nights, so they keep their own inventory and
+// conflict check: an event never competes with a room stay.
+//
+// Events still use the shared subsystems: the organizer is a registered
+// Guest, and charges are the same Service line items rooms use. An event
+// bill can be settled on its own or posted to the organizer's room folio.
+//
+// ============================================================================
+
+// Function spaces can be booked between these hours (end is exclusive).
+const (
+	EventDayStartHour = 7
+	EventDayEndHour   = 24
+)
+
+// FunctionSpaceType classifies a bookable function space.
+type FunctionSpaceType int
+
+const (
+	FunctionSpaceBallroom    FunctionSpaceType = iota // 0 - Large hall for weddings and galas
+	FunctionSpaceBanquetHall                          // 1 - Seated dinners and receptions
+	FunctionSpaceMeetingRoom                          // 2 - Boardroom / conference setup
+	FunctionSpaceTerrace                              // 3 - Open-air space
+)
+
+// String returns a human-readable name for the space type.
+func (spaceType FunctionSpaceType) String() string {
+	names := [...]string{"Ballroom", "Banquet Hall", "Meeting Room", "Terrace"}
+	if int(spaceType) < len(names) {
+		return names[spaceType]
+	}
+	return "Unknown"
+}
+
+// FunctionSpace is a hall or meeting room rented by the hour.
+type FunctionSpace struct {
+	name       string            // Unique name (e.g., "Crystal Ballroom")
+	spaceType  FunctionSpaceType // Kind of space
+	capacity   int               // Maximum number of attendees
+	hourlyRate float64           // Rental price per hour
+}
+
+// NewFunctionSpace creates a new FunctionSpace instance.
+func NewFunctionSpace(name string, spaceType FunctionSpaceType, capacity int, hourlyRate float64) *FunctionSpace {
+	return &FunctionSpace{
+		name:       name,
+		spaceType:  spaceType,
+		capacity:   capacity,
+		hourlyRate: hourlyRate,
+	}
+}
+
+// Getter methods for FunctionSpace
+func (space *FunctionSpace) GetName() string            { return space.name }
+func (space *FunctionSpace) GetType() FunctionSpaceType { return space.spaceType }
+func (space *FunctionSpace) GetCapacity() int           { return space.capacity }
+func (space *FunctionSpace) GetHourlyRate() float64     { return space.hourlyRate }
+
+// CateringPackage is a catering add-on priced per attendee.
+type CateringPackage struct {
+	Name           string
+	PricePerPerson float64
+	MinAttendees   int // Kitchen won't prepare it for fewer guests
+}
+
+// cateringMenu lists the catering add-ons the kitchen offers (key: package name).
+var cateringMenu = map[string]CateringPackage{
+	"Coffee Break":  {Name: "Coffee Break", PricePerPerson: 8, MinAttendees: 1},
+	"Working Lunch": {Name: "Working Lunch", PricePerPerson: 22, MinAttendees: 10},
+	"Cocktail Hour": {Name: "Cocktail Hour", PricePerPerson: 35, MinAttendees: 20},
+	"Plated Dinner": {Name: "Plated Dinner", PricePerPerson: 65, MinAttendees: 20},
+}
+
+// eventIDGen generates unique IDs for event bookings.
+var eventIDGen = idgen.NewSequence("EVT")
+
+// EventBooking reserves a function space for a block of whole hours.
+// It reuses BookingStatus for its lifecycle: Pending → Confirmed → Cancelled.
+type EventBooking struct {
+	id          string         // Unique identifier (e.g., "EVT-1")
+	guest       *Guest         // Organizer, a registered hotel guest
+	space       *FunctionSpace // Space that was booked
+	eventName   string         // Title shown on the hotel's event board
+	start       time.Time      // First hour of the slot
+	end         time.Time      // End of the last hour (exclusive)
+	attendees   int            // Expected head count
+	status      BookingStatus  // Current status
+	services    []Service      // Rental, catering and other line items
+	totalAmount float64        // Sum of all line items
+	postedTo    string         // Room booking the charges were posted to, if any
+	mutex       sync.Mutex     // Protects concurrent modifications
+}
+
+// Getter methods for EventBooking
+func (event *EventBooking) GetID() string            { return event.id }
+func (event *EventBooking) GetGuest() *Guest         { return event.guest }
+func (event *EventBooking) GetSpace() *FunctionSpace { return event.space }
+func (event *EventBooking) GetName() string          { return event.eventName }
+func (event *EventBooking) GetStart() time.Time      { return event.start }
+func (event *EventBooking) GetEnd() time.Time        { return event.end }
+func (event *EventBooking) GetAttendees() int        { return event.attendees }
+
+// GetHours returns the number of hourly slots booked.
+func (event *EventBooking) GetHours() int {
+	return int(event.end.Sub(event.start) / time.Hour)
+}
+
+// GetStatus returns the current event status (thread-safe).
+func (event *EventBooking) GetStatus() BookingStatus {
+	event.mutex.Lock()
+	defer event.mutex.Unlock()
+	return event.status
+}
+
+// GetTotal returns the event's total charges (thread-safe).
+func (event *EventBooking) GetTotal() float64 {
+	event.mutex.Lock()
+	defer event.mutex.Unlock()
+	return event.totalAmount
+}
+
+// Overlaps reports whether this event still holds its space at any time
+// in [start, end). Cancelled events hold nothing.
+func (event *EventBooking) Overlaps(start, end time.Time) bool {
+	if event.GetStatus() == BookingStatusCancelled {
+		return false
+	}
+	return start.Before(event.end) && event.start.Before(end)
+}
+
+// addCharge appends a line item. Caller must hold event.mutex.
+func (event *EventBooking) addCharge(name string, price float64) {
+	event.services = append(event.services, NewService(name, price))
+	event.totalAmount += price
+}
+
+// String returns a one-line summary of the event.
+func (event *EventBooking) String() string {
+	return fmt.Sprintf("%s %q in %s, %s %s–%s, %d guests [%s]",
+		event.id, event.eventName, event.space.GetName(),
+		event.start.Format("Jan 02"), event.start.Format("3:04 PM"), event.end.Format("3:04 PM"),
+		event.attendees, event.GetStatus())
+}
+
+// GenerateBill creates a formatted invoice for the event.
+func (event *EventBooking) GenerateBill() string {
+	event.mutex.Lock()
+	defer event.mutex.Unlock()
+
+	bill := fmt.Sprintf(`
+╔════════════════════════════════════════════════╗
+║              🎉 EVENT INVOICE                  ║
+╠════════════════════════════════════════════════╣
+  Event ID: %s
+  Event: %s
+  Organizer: %s
+  Space: %s (%s)
+
+  Date: %s
+  Time: %s – %s (%d hours)
+  Attendees: %d
+
+  ─────────────────────────────────────
+  CHARGES:
+`,
+		event.id,
+		event.eventName,
+		event.guest.GetName(),
+		event.space.GetName(),
+		event.space.GetType(),
+		event.start.Format("Jan 02, 2006"),
+		event.start.Format("3:04 PM"),
+		event.end.Format("3:04 PM"),
+		event.GetHours(),
+		event.attendees,
+	)
+
+	for _, service := range event.services {
+		bill += fmt.Sprintf("  %s: $%.2f\n", service.GetName(), service.GetPrice())
+	}
+
+	bill += fmt.Sprintf(`  ─────────────────────────────────────
+  TOTAL: $%.2f
+`, event.totalAmount)
+
+	if event.postedTo != "" {
+		bill += fmt.Sprintf("  Posted to room folio: %s\n", event.postedTo)
+	}
+
+	bill += "╚════════════════════════════════════════════════╝\n"
+	return bill
+}
+
+// AddFunctionSpace adds a function space to the hotel's event inventory.
+func (hotel *Hotel) AddFunctionSpace(space *FunctionSpace) {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+	hotel.functionSpaces[space.GetName()] = space
+}
+
+// GetFunctionSpace returns a function space by name.
+func (hotel *Hotel) GetFunctionSpace(name string) *FunctionSpace {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+	return hotel.functionSpaces[name]
+}
+
+// CreateEventBooking reserves a function space for the given number of
+// hours starting at start. Slots must begin on the hour, fall within the
+// event day and fit the space's capacity. The check and the insert happen
+// under one lock, so two organizers can never get overlapping slots.
+func (hotel *Hotel) CreateEventBooking(guestID, spaceName, eventName string, start time.Time, hours, attendees int) (*EventBooking, error) {
+	if hours < 1 {
+		return nil, fmt.Errorf("event must last at least one hour")
+	}
+	if !start.Equal(start.Truncate(time.Hour)) {
+		return nil, fmt.Errorf("events start on the hour (got %s)", start.Format("3:04 PM"))
+	}
+	if start.Hour() < EventDayStartHour || start.Hour()+hours > EventDayEndHour {
+		return nil, fmt.Errorf("function spaces are bookable from %s to midnight",
+			formatHour(EventDayStartHour))
+	}
+	if attendees < 1 {
+		return nil, fmt.Errorf("attendee count must be positive")
+	}
+	end := start.Add(time.Duration(hours) * time.Hour)
+
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	guest, guestExists := hotel.guests[guestID]
+	if !guestExists {
+		return nil, fmt.Errorf("guest with ID '%s' not found", guestID)
+	}
+
+	space, spaceExists := hotel.functionSpaces[spaceName]
+	if !spaceExists {
+		return nil, fmt.Errorf("function space '%s' not found", spaceName)
+	}
+	if attendees > space.GetCapacity() {
+		return nil, fmt.Errorf("%s holds %d guests, %d requested", spaceName, space.GetCapacity(), attendees)
+	}
+
+	for _, existing := range hotel.eventBookings {
+		if existing.GetSpace() == space && existing.Overlaps(start, end) {
+			return nil, fmt.Errorf("%s is already booked %s–%s by %s",
+				spaceName, existing.GetStart().Format("3:04 PM"), existing.GetEnd().Format("3:04 PM"), existing.GetID())
+		}
+	}
+
+	event := &EventBooking{
+		id:        eventIDGen.NextID(),
+		guest:     guest,
+		space:     space,
+		eventName: eventName,
+		start:     start,
+		end:       end,
+		attendees: attendees,
+		status:    BookingStatusPending,
+		services:  make([]Service, 0),
+	}
+	event.addCharge(fmt.Sprintf("%s rental (%d hrs × $%.2f)", space.GetName(), hours, space.GetHourlyRate()),
+		space.GetHourlyRate()*float64(hours))
+	hotel.eventBookings[event.GetID()] = event
+
+	return event, nil
+}
+
+// ConfirmEventBooking moves an event from Pending to Confirmed.
+func (hotel *Hotel) ConfirmEventBooking(eventID string) error {
+	event, err := hotel.getEventBooking(eventID)
+	if err != nil {
+		return err
+	}
+
+	event.mutex.Lock()
+	defer event.mutex.Unlock()
+	if event.status != BookingStatusPending {
+		return fmt.Errorf("cannot confirm: event is not in pending status (current: %s)", event.status)
+	}
+	event.status = BookingStatusConfirmed
+	return nil
+}
+
+// CancelEventBooking cancels an event and frees its slots.
+func (hotel *Hotel) CancelEventBooking(eventID string) error {
+	event, err := hotel.getEventBooking(eventID)
+	if err != nil {
+		return err
+	}
+
+	event.mutex.Lock()
+	defer event.mutex.Unlock()
+	if event.status == BookingStatusCancelled {
+		return fmt.Errorf("event is already cancelled")
+	}
+	if event.postedTo != "" {
+		return fmt.Errorf("cannot cancel: charges already posted to booking %s", event.postedTo)
+	}
+	event.status = BookingStatusCancelled
+	return nil
+}
+
+// AddCatering adds a catering package for every attendee of the event.
+func (hotel *Hotel) AddCatering(eventID, packageName string) error {
+	event, err := hotel.getEventBooking(eventID)
+	if err != nil {
+		return err
+	}
+	catering, known := cateringMenu[packageName]
+	if !known {
+		return fmt.Errorf("unknown catering package '%s'", packageName)
+	}
+
+	event.mutex.Lock()
+	defer event.mutex.Unlock()
+	if event.status == BookingStatusCancelled {
+		return fmt.Errorf("cannot add catering: event is cancelled")
+	}
+	if event.postedTo != "" {
+		return fmt.Errorf("cannot add catering: charges already posted to booking %s", event.postedTo)
+	}
+	if event.attendees < catering.MinAttendees {
+		return fmt.Errorf("%s requires at least %d guests", catering.Name, catering.MinAttendees)
+	}
+	event.addCharge(fmt.Sprintf("%s (%d × $%.2f)", catering.Name, event.attendees, catering.PricePerPerson),
+		catering.PricePerPerson*float64(event.attendees))
+	return nil
+}
+
+// PostEventToFolio moves a confirmed event's charges onto the organizer's
+// room booking, so a guest staying at the hotel settles everything on one
+// bill at checkout.
+func (hotel *Hotel) PostEventToFolio(eventID, bookingID string) error {
+	event, err := hotel.getEventBooking(eventID)
+	if err != nil {
+		return err
+	}
+
+	hotel.mutex.RLock()
+	booking, exists := hotel.bookings[bookingID]
+	hotel.mutex.RUnlock()
+	if !exists {
+		return fmt.Errorf("booking '%s' not found", bookingID)
+	}
+	if booking.GetGuest() != event.GetGuest() {
+		return fmt.Errorf("booking %s belongs to %s, not the event organizer",
+			bookingID, booking.GetGuest().GetName())
+	}
+	if status := booking.GetStatus(); status == BookingStatusCancelled || status == BookingStatusCheckedOut {
+		return fmt.Errorf("cannot post to booking %s (status: %s)", bookingID, status)
+	}
+
+	event.mutex.Lock()
+	defer event.mutex.Unlock()
+	if event.status != BookingStatusConfirmed {
+		return fmt.Errorf("only confirmed events can be posted (current: %s)", event.status)
+	}
+	if event.postedTo != "" {
+		return fmt.Errorf("event already posted to booking %s", event.postedTo)
+	}
+	for _, service := range event.services {
+		booking.AddService(fmt.Sprintf("[%s] %s", event.id, service.GetName()), service.GetPrice())
+	}
+	event.postedTo = bookingID
+	return nil
+}
+
+// GetSpaceSchedule returns the active events in a space on the given day,
+// in start order.
+func (hotel *Hotel) GetSpaceSchedule(spaceName string, day time.Time) []*EventBooking {
+	dayStart := atHour(day, 0)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	schedule := make([]*EventBooking, 0)
+	for _, event := range hotel.eventBookings {
+		if event.GetSpace().GetName() == spaceName && event.Overlaps(dayStart, dayEnd) {
+			schedule = append(schedule, event)
+		}
+	}
+	sort.Slice(schedule, func(i, j int) bool {
+		return schedule[i].GetStart().Before(schedule[j].GetStart())
+	})
+	return schedule
+}
+
+// getEventBooking looks up an event booking by ID.
+func (hotel *Hotel) getEventBooking(eventID string) (*EventBooking, error) {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	event, exists := hotel.eventBookings[eventID]
+	if !exists {
+		return nil, fmt.Errorf("event booking '%s' not found", eventID)
+	}
+	return event, nil
+}
+
+// ============================================================================
+// SECTION 15: BOOKING EVENTS (PUB-SUB + OUTBOX)
+// ============================================================================
+//
+// Other modules care about the booking lifecycle: the notification system
+// sends confirmations and receipts, analytics tracks occupancy and revenue.
+// Instead of the hotel calling each of them, it publishes domain events to
+// the pub-sub broker (19_pubsub) and downstream modules subscribe.
+//
+// Topics (one per event type):
+//   hotel.booking.created, hotel.booking.checked_in,
+//   hotel.booking.checked_out, hotel.booking.cancelled,
+//   hotel.waitlist.joined, hotel.waitlist.offered, hotel.waitlist.expired
+//   (waitlist events are described in Section 17)
+//
+// Outbox pattern:
+// - Every event is appended to the outbox under the hotel lock, in the same
+//   critical section as the state change it describes, so events are in
+//   state-change order and none can be missed between the two
+// - The outbox is flushed once the lock is released. If the broker is down,
+//   the event stays in the outbox and is retried, so nothing is lost and
+//   events keep their order
+// - Flushing stops at the first failure; later events wait behind it. The
+//   background flusher (StartFlusher) retries until the broker is back
+// - The outbox lives with the bookings, so it is exactly as durable as they
+//   are; a database-backed hotel would write both in one transaction
+//
+// Events are published to 19_pubsub's MessageBroker. CreateBookingTopics
+// creates a topic per event type.
+//
+// ============================================================================
+
+// BookingEventType identifies what happened to a booking.
+type BookingEventType int
+
+const (
+	BookingCreated BookingEventType = iota
+	BookingCheckedIn
+	BookingCheckedOut
+	BookingCancelled
+	WaitlistJoined
+	WaitlistOffered
+	WaitlistOfferExpired
+)
+
+// String returns the event name.
+func (eventType BookingEventType) String() string {
+	names := []string{"BookingCreated", "CheckedIn", "CheckedOut", "BookingCancelled",
+		"WaitlistJoined", "WaitlistOffered", "WaitlistOfferExpired"}
+	if int(eventType) < len(names) {
+		return names[eventType]
+	}
+	return "Unknown"
+}
+
+// Topic returns the pub-sub topic the event is published on.
+func (eventType BookingEventType) Topic() string {
+	topics := []string{
+		"hotel.booking.created",
+		"hotel.booking.checked_in",
+		"hotel.booking.checked_out",
+		"hotel.booking.cancelled",
+		"hotel.waitlist.joined",
+		"hotel.waitlist.offered",
+		"hotel.waitlist.expired",
+	}
+	if int(eventType) < len(topics) {
+		return topics[eventType]
+	}
+	return "hotel.booking.unknown"
+}
+
+// BookingEvent is the payload published for a booking lifecycle change.
+type BookingEvent struct {
+	ID         string           // Unique event ID (for idempotent consumers)
+	Type       BookingEventType // What happened
+	BookingID  string           // The booking it happened to
+	GuestID    string           // Guest on the booking
+	GuestEmail string           // Where notifications go
+	RoomNumber string           // Room on the booking
+	Source     string           // Direct or the OTA channel name
+	Amount     float64          // Booking total at the time of the event
+	OccurredAt time.Time        // When it happened
+
+	WaitlistID     string    // Waitlist entry (waitlist events only)
+	OfferExpiresAt time.Time // Deadline to accept (WaitlistOffered only)
+}
+
+// String returns a one-line description of the event.
+func (event BookingEvent) String() string {
+	subject := event.BookingID
+	if subject == "" {
+		subject = event.WaitlistID
+	}
+	return fmt.Sprintf("%s %s: %s, room %s, $%.2f",
+		event.ID, event.Type, subject, event.RoomNumber, event.Amount)
+}
+
+// bookingEventIDGen generates unique IDs for booking events.
+var bookingEventIDGen = idgen.NewSequence("BEV")
+
+// EventPublisher delivers a payload to every subscriber of a topic.
+// *pubsub.MessageBroker implements it.
+type EventPublisher interface {
+	Publish(topicName string, payload interface{}) (*pubsub.Message, error)
+}
+
+// CreateBookingTopics creates the topic for every booking event type.
+func CreateBookingTopics(broker *pubsub.MessageBroker) {
+	for eventType := BookingCreated; eventType <= WaitlistOfferExpired; eventType++ {
+		broker.CreateTopic(eventType.Topic())
+	}
+}
+
+// BookingOutbox buffers booking events until the publisher accepts them.
+type BookingOutbox struct {
+	publisher  EventPublisher // Where events are delivered
+	pending    []BookingEvent // Recorded but not yet published, oldest first
+	published  int            // Events delivered so far
+	lastError  error          // Why the last flush stopped early (nil = drained)
+	mutex      sync.Mutex     // Protects pending, published and lastError
+	flushMutex sync.Mutex     // Serializes flushes so events go out in order
+	flusher    periodic.Job   // Retries pending events in the background
+}
+
+// NewBookingOutbox creates an outbox that publishes through publisher.
+func NewBookingOutbox(publisher EventPublisher) *BookingOutbox {
+	return &BookingOutbox{publisher: publisher}
+}
+
+// Record appends an event to the outbox and tries to flush it.
+// A failed delivery is not an error for the caller: the event stays
+// buffered and goes out on a later flush.
+func (outbox *BookingOutbox) Record(event BookingEvent) {
+	outbox.append(event)
+	outbox.Flush()
+}
+
+// append adds an event to the outbox without flushing it.
+func (outbox *BookingOutbox) append(event BookingEvent) {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	outbox.pending = append(outbox.pending, event)
+}
+
+// Flush publishes pending events in order until the outbox is empty or a
+// delivery fails. Returns how many events were published and the failure.
+// If another flush is already running (e.g. a subscriber triggered a new
+// event), this one returns at once: the running flush picks the event up,
+// or, if it is failing, the background flusher retries it.
+func (outbox *BookingOutbox) Flush() (int, error) {
+	if !outbox.flushMutex.TryLock() {
+		return 0, nil
+	}
+	defer outbox.flushMutex.Unlock()
+
+	publishedCount := 0
+	for {
+		outbox.mutex.Lock()
+		if len(outbox.pending) == 0 {
+			outbox.lastError = nil
+			outbox.mutex.Unlock()
+			return publishedCount, nil
+		}
+		event := outbox.pending[0]
+		outbox.mutex.Unlock()
+
+		// Publish without holding the mutex: subscribers may record new events
+		if _, err := outbox.publisher.Publish(event.Type.Topic(), event); err != nil {
+			outbox.mutex.Lock()
+			outbox.lastError = err
+			outbox.mutex.Unlock()
+			return publishedCount, fmt.Errorf("publishing %s: %w", event.ID, err)
+		}
+
+		outbox.mutex.Lock()
+		outbox.pending = outbox.pending[1:]
+		outbox.published++
+		outbox.mutex.Unlock()
+		publishedCount++
+	}
+}
+
+// StartFlusher retries pending events every interval until StopFlusher.
+// Calling it while the flusher is running has no effect.
+func (outbox *BookingOutbox) StartFlusher(interval time.Duration) {
+	outbox.flusher.Start(interval, func(time.Time) {
+		_, _ = outbox.Flush()
+	})
+}
+
+// StopFlusher stops the background flusher and waits for a flush in
+// progress to finish.
+func (outbox *BookingOutbox) StopFlusher() {
+	outbox.flusher.Stop()
+}
+
+// GetPending returns a copy of the events waiting to be published.
+func (outbox *BookingOutbox) GetPending() []BookingEvent {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	return append([]BookingEvent{}, outbox.pending...)
+}
+
+// GetPublishedCount returns how many events have been delivered.
+func (outbox *BookingOutbox) GetPublishedCount() int {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	return outbox.published
+}
+
+// GetLastError returns why the last flush stopped early, or nil.
+func (outbox *BookingOutbox) GetLastError() error {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+	return outbox.lastError
+}
+
+// SetEventPublisher starts publishing booking events through publisher.
+// Returns the hotel's outbox so callers can flush or inspect it.
+func (hotel *Hotel) SetEventPublisher(publisher EventPublisher) *BookingOutbox {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+	hotel.outbox = NewBookingOutbox(publisher)
+	return hotel.outbox
+}
+
+// GetOutbox returns the hotel's event outbox, or nil if events are off.
+func (hotel *Hotel) GetOutbox() *BookingOutbox {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+	return hotel.outbox
+}
+
+// recordBookingEventLocked adds a lifecycle event for the booking to the
+// outbox. Does nothing if no publisher is configured. Caller must hold
+// hotel.mutex, in the critical section that changed the booking, and call
+// flushEvents once it is released.
+func (hotel *Hotel) recordBookingEventLocked(eventType BookingEventType, booking *Booking) {
+	if hotel.outbox == nil {
+		return
+	}
+
+	guest := booking.GetGuest()
+	hotel.outbox.append(BookingEvent{
+		ID:         bookingEventIDGen.NextID(),
+		Type:       eventType,
+		BookingID:  booking.GetID(),
+		GuestID:    guest.GetID(),
+		GuestEmail: guest.GetEmail(),
+		RoomNumber: booking.GetRoom().GetNumber(),
+		Source:     booking.GetSource(),
+		Amount:     booking.GetTotal(),
+		OccurredAt: time.Now(),
+	})
+}
+
+// flushEvents publishes the events recorded so far. A failed delivery is
+// not an error for the caller: the event stays in the outbox for the next
+// flush. Must be called without hotel.mutex held, since subscribers may
+// call back into the hotel.
+func (hotel *Hotel) flushEvents() {
+	if outbox := hotel.GetOutbox(); outbox != nil {
+		_, _ = outbox.Flush()
+	}
+}
+
+// ============================================================================
+// SECTION 16: ALLOTMENT CONTRACTS
+// ============================================================================
+//
+// Corporate clients and travel agents sign allotment contracts: the hotel
+// sets aside a number of rooms of one type for a season, and the partner's
+// guests book them with the allotment code.
+//
+// Rules:
+// - Allotted rooms are invisible to public availability: direct bookings,
+//   OTA allocation and loyalty upgrades all skip them
+// - Only CreateAllotmentBooking with the contract's code can book them
+// - Unused nights are released automatically ReleaseDays before each night,
+//   so the hotel can still sell rooms the partner did not fill
+// - Release is evaluated against the hotel clock on every check; there is
+//   no background job to run
+//
+// Holds are per room and per night. A cancelled allotment booking gives the
+// night back to the contract unless that night has been released already.
+//
+// ============================================================================
+
+// AllotmentContract reserves specific rooms for a partner over a date range.
+type AllotmentContract struct {
+	code        string     // Code partners quote when booking (e.g. "ACME-2026")
+	holder      string     // Corporate client or travel agent name
+	roomType    RoomType   // Room category set aside
+	roomNumbers []string   // Rooms held for the partner (sorted)
+	start       time.Time  // First night of the contract (midnight)
+	end         time.Time  // Day after the last night (midnight)
+	releaseDays int        // Unused nights return to public sale this many days before arrival
+	bookings    []*Booking // Bookings made with the code
+}
+
+// Getter methods for AllotmentContract
+func (contract *AllotmentContract) GetCode() string       { return contract.code }
+func (contract *AllotmentContract) GetHolder() string     { return contract.holder }
+func (contract *AllotmentContract) GetRoomType() RoomType { return contract.roomType }
+func (contract *AllotmentContract) GetStart() time.Time   { return contract.start }
+func (contract *AllotmentContract) GetEnd() time.Time     { return contract.end }
+func (contract *AllotmentContract) GetReleaseDays() int   { return contract.releaseDays }
+func (contract *AllotmentContract) GetRoomNumbers() []string {
+	return append([]string(nil), contract.roomNumbers...)
+}
+
+// covers reports whether the night falls inside the contract.
+func (contract *AllotmentContract) covers(night time.Time) bool {
+	return !night.Before(contract.start) && night.Before(contract.end)
+}
+
+// holdsRoom reports whether the room is part of the contract.
+func (contract *AllotmentContract) holdsRoom(roomNumber string) bool {
+	for _, number := range contract.roomNumbers {
+		if number == roomNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleaseDate returns when an unused night goes back to public sale.
+func (contract *AllotmentContract) ReleaseDate(night time.Time) time.Time {
+	return atHour(night, 0).AddDate(0, 0, -contract.releaseDays)
+}
+
+// isReleased reports whether the night has gone back to public sale.
+func (contract *AllotmentContract) isReleased(night, now time.Time) bool {
+	return !now.Before(contract.ReleaseDate(night))
+}
+
+// String returns a one-line summary of the contract.
+func (contract *AllotmentContract) String() string {
+	return fmt.Sprintf("%s (%s): %d %s room(s) %s – %s, release %d days prior",
+		contract.code, contract.holder, len(contract.roomNumbers), contract.roomType,
+		contract.start.Format("Jan 02"), contract.end.AddDate(0, 0, -1).Format("Jan 02"),
+		contract.releaseDays)
+}
+
+// AllotmentNight is one line of an allotment usage report.
+type AllotmentNight struct {
+	Night    time.Time // Midnight at the start of the night
+	Allotted int       // Rooms in the contract
+	PickedUp int       // Rooms booked with the code
+	Held     int       // Rooms still hidden from public sale
+	Released bool      // Unused rooms are back on public sale
+}
+
+// stayNights returns midnight of every night between check-in and checkout.
+// A same-day stay counts as one night, matching calculateNights.
+func stayNights(checkIn, checkOut time.Time) []time.Time {
+	first := atHour(checkIn, 0)
+	nights := make([]time.Time, calculateNights(first, atHour(checkOut, 0)))
+	for i := range nights {
+		nights[i] = first.AddDate(0, 0, i)
+	}
+	return nights
+}
+
+// SetClock replaces the time source used to release unused allotments.
+// Tests and demos use it to move time forward without waiting.
+func (hotel *Hotel) SetClock(clock func() time.Time) {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+	hotel.clock = clock
+}
+
+// isRoomAllotted reports whether an allotment still hides the room from
+// public sale on any night of the stay. Caller must hold hotel.mutex.
+func (hotel *Hotel) isRoomAllotted(roomNumber string, checkIn, checkOut time.Time) bool {
+	now := hotel.clock()
+	for _, contract := range hotel.allotments {
+		if !contract.holdsRoom(roomNumber) {
+			continue
+		}
+		for _, night := range stayNights(checkIn, checkOut) {
+			if contract.covers(night) && !contract.isReleased(night, now) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CreateAllotment sets aside rooms of a type for a partner over [start, end).
+// The lowest-numbered rooms free for the whole range are held; the call fails
+// if fewer than the requested number are free.
+func (hotel *Hotel) CreateAllotment(code, holder string, roomType RoomType, rooms int,
+	start, end time.Time, releaseDays int) (*AllotmentContract, error) {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	if code == "" {
+		return nil, fmt.Errorf("allotment code is required")
+	}
+	if _, exists := hotel.allotments[code]; exists {
+		return nil, fmt.Errorf("allotment '%s' already exists", code)
+	}
+	if rooms < 1 {
+		return nil, fmt.Errorf("allotment must hold at least one room")
+	}
+	if releaseDays < 0 {
+		return nil, fmt.Errorf("release days cannot be negative")
+	}
+	start, end = atHour(start, 0), atHour(end, 0)
+	if !start.Before(end) {
+		return nil, fmt.Errorf("allotment end must be after its start")
+	}
+
+	roomNumbers := make([]string, 0)
+	for number, room := range hotel.rooms {
+		if room.GetType() == roomType && room.IsAvailable() {
+			roomNumbers = append(roomNumbers, number)
+		}
+	}
+	sort.Strings(roomNumbers)
+
+	held := make([]string, 0, rooms)
+	for _, number := range roomNumbers {
+		if len(held) == rooms {
+			break
+		}
+		if !hotel.isRoomBooked(number, start, end) {
+			held = append(held, number)
+		}
+	}
+	if len(held) < rooms {
+		return nil, fmt.Errorf("only %d of %d %s room(s) free for %s – %s",
+			len(held), rooms, roomType, start.Format("Jan 02"), end.Format("Jan 02"))
+	}
+
+	contract := &AllotmentContract{
+		code:        code,
+		holder:      holder,
+		roomType:    roomType,
+		roomNumbers: held,
+		start:       start,
+		end:         end,
+		releaseDays: releaseDays,
+		bookings:    make([]*Booking, 0),
+	}
+	hotel.allotments[code] = contract
+	return contract, nil
+}
+
+// GetAllotment returns a contract by code.
+func (hotel *Hotel) GetAllotment(code string) (*AllotmentContract, error) {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	contract, exists := hotel.allotments[code]
+	if !exists {
+		return nil, fmt.Errorf("allotment '%s' not found", code)
+	}
+	return contract, nil
+}
+
+// CreateAllotmentBooking books one of the contract's held rooms.
+// Every night must be inside the contract and not yet released.
+func (hotel *Hotel) CreateAllotmentBooking(guestID, code string, checkIn, checkOut time.Time) (*Booking, error) {
+	booking, err := hotel.allocateAllotmentBooking(guestID, code, checkIn, checkOut)
+	if err != nil {
+		return nil, err
+	}
+	hotel.flushEvents()
+	return booking, nil
+}
+
+// allocateAllotmentBooking picks the lowest-numbered held room free for the stay.
+func (hotel *Hotel) allocateAllotmentBooking(guestID, code string, checkIn, checkOut time.Time) (*Booking, error) {
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+
+	guest, guestExists := hotel.guests[guestID]
+	if !guestExists {
+		return nil, fmt.Errorf("guest with ID '%s' not found", guestID)
+	}
+	contract, exists := hotel.allotments[code]
+	if !exists {
+		return nil, fmt.Errorf("allotment '%s' not found", code)
+	}
+	if checkOut.Before(checkIn) {
+		return nil, fmt.Errorf("check-out date cannot be before check-in date")
+	}
+
+	now := hotel.clock()
+	for _, night := range stayNights(checkIn, checkOut) {
+		if !contract.covers(night) {
+			return nil, fmt.Errorf("night of %s is outside allotment %s", night.Format("Jan 02"), code)
+		}
+		if contract.isReleased(night, now) {
+			return nil, fmt.Errorf("allotment %s released the night of %s on %s",
+				code, night.Format("Jan 02"), contract.ReleaseDate(night).Format("Jan 02"))
+		}
+	}
+
+	for _, number := range contract.roomNumbers {
+		room := hotel.rooms[number]
+		if !room.IsAvailable() || hotel.hasOverlappingBooking(number, checkIn, checkOut) {
+			continue
+		}
+		booking := NewBooking(guest, room, checkIn, checkOut)
+		booking.source = contract.holder
+		hotel.bookings[booking.GetID()] = booking
+		contract.bookings = append(contract.bookings, booking)
+		hotel.recordBookingEventLocked(BookingCreated, booking)
+		return booking, nil
+	}
+
+	return nil, fmt.Errorf("allotment %s is fully picked up for the requested dates", code)
+}
+
+// GetAllotmentUsage reports pickup and release for every night of a contract.
+func (hotel *Hotel) GetAllotmentUsage(code string) ([]AllotmentNight, error) {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	contract, exists := hotel.allotments[code]
+	if !exists {
+		return nil, fmt.Errorf("allotment '%s' not found", code)
+	}
+
+	now := hotel.clock()
+	report := make([]AllotmentNight, 0)
+	for _, night := range stayNights(contract.start, contract.end) {
+		line := AllotmentNight{
+			Night:    night,
+			Allotted: len(contract.roomNumbers),
+			Released: contract.isReleased(night, now),
+		}
+		for _, booking := range contract.bookings {
+			if booking.Overlaps(night, night.AddDate(0, 0, 1)) {
+				line.PickedUp++
+			}
+		}
+		if !line.Released {
+			line.Held = line.Allotted - line.PickedUp
+		}
+		report = append(report, line)
+	}
+	return report, nil
+}
+
+// GetAvailableRoomsForDates returns rooms of a type that the public can book
+// for the dates: free of other bookings and not hidden by an allotment.
+func (hotel *Hotel) GetAvailableRoomsForDates(roomType RoomType, checkIn, checkOut time.Time) []*Room {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	availableRooms := make([]*Room, 0)
+	for number, room := range hotel.rooms {
+		if room.GetType() == roomType && room.IsAvailable() && !hotel.isRoomBooked(number, checkIn, checkOut) {
+			availableRooms = append(availableRooms, room)
+		}
+	}
+	sort.Slice(availableRooms, func(i, j int) bool {
+		return availableRooms[i].GetNumber() < availableRooms[j].GetNumber()
+	})
+	return availableRooms
+}
+
+// ============================================================================
+// SECTION 17: WAITLIST
+// ============================================================================
+//
+// When every room of a type is taken for the requested dates, guests can
+// join a waitlist instead of walking away.
+//
+// Rules:
+// - Joining is only allowed while no room of the type is free for the dates;
+//   otherwise the guest should simply book
+// - When a booking is cancelled, the freed room is offered to waitlisted
+//   guests in join order: the first guest whose type and dates fit gets it
+// - An offer holds the room for OfferWindow (DefaultOfferWindow unless
+//   changed). Nobody else can book it while the offer is open
+// - The guest accepts (the booking is created at once) or declines; an
+//   offer left unanswered expires and the room moves to the next guest
+// - A guest whose offer expired or who declined leaves the waitlist
+//
+// Like allotment releases, expiry is evaluated against the hotel clock and
+// there is no background job. ProcessWaitlist runs after every cancellation,
+// decline and late acceptance; call it periodically to pass on offers that
+// expired in between.
+//
+// Notifications: joins, offers and expiries are published as booking events
+// (Section 15) on the hotel.waitlist.* topics, so the notification system
+// can tell the guest without the hotel knowing about email or SMS.
+//
+// ============================================================================
+
+// DefaultOfferWindow is how long a waitlisted guest has to accept an offer.
+const DefaultOfferWindow = 2 * time.Hour
+
+// BookingSourceWaitlist marks bookings created by accepting a waitlist offer.
+const BookingSourceWaitlist = "Waitlist"
+
+// WaitlistStatus represents where a waitlist entry is in its lifecycle.
+type WaitlistStatus int
+
+const (
+	WaitlistStatusWaiting   WaitlistStatus = iota // In the queue, no room yet
+	WaitlistStatusOffered                         // A room is held for the guest
+	WaitlistStatusAccepted                        // Offer accepted, booking created
+	WaitlistStatusDeclined                        // Guest turned the offer down
+	WaitlistStatusExpired                         // Offer was not answered in time
+	WaitlistStatusWithdrawn                       // Guest left the waitlist
+)
+
+// String returns the status name.
+func (status WaitlistStatus) String() string {
+	names := []string{"Waiting", "Offered", "Accepted", "Declined", "Expired", "Withdrawn"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// IsActive reports whether the entry is still on the waitlist.
+func (status WaitlistStatus) IsActive() bool {
+	return status == WaitlistStatusWaiting || status == WaitlistStatusOffered
+}
+
+// waitlistIDGen generates unique IDs for waitlist entries.
+var waitlistIDGen = idgen.NewSequence("WL")
+
+// WaitlistEntry is one guest waiting for a room type over a date range.
+type WaitlistEntry struct {
+	id           string
+	guest        *Guest
+	roomType     RoomType
+	checkInDate  time.Time
+	checkOutDate time.Time
+	joinedAt     time.Time
+
+	status         WaitlistStatus
+	offeredRoom    *Room     // Room held for the guest (nil unless offered)
+	offerExpiresAt time.Time // Deadline to accept the offer
+	bookingID      string    // Booking created on acceptance
+	mutex          sync.Mutex
+}
+
+// Getters for WaitlistEntry
+func (entry *WaitlistEntry) GetID() string              { return entry.id }
+func (entry *WaitlistEntry) GetGuest() *Guest           { return entry.guest }
+func (entry *WaitlistEntry) GetRoomType() RoomType      { return entry.roomType }
+func (entry *WaitlistEntry) GetCheckInDate() time.Time  { return entry.checkInDate }
+func (entry *WaitlistEntry) GetCheckOutDate() time.Time { return entry.checkOutDate }
+func (entry *WaitlistEntry) GetJoinedAt() time.Time     { return entry.joinedAt }
+
+// GetStatus returns the entry's current status (thread-safe).
+func (entry *WaitlistEntry) GetStatus() WaitlistStatus {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	return entry.status
+}
+
+// GetOffer returns the held room and the acceptance deadline, or nil if
+// the entry has no open offer.
+func (entry *WaitlistEntry) GetOffer() (*Room, time.Time) {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	if entry.status != WaitlistStatusOffered {
+		return nil, time.Time{}
+	}
+	return entry.offeredRoom, entry.offerExpiresAt
+}
+
+// GetBookingID returns the booking created when the offer was accepted.
+func (entry *WaitlistEntry) GetBookingID() string {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	return entry.bookingID
+}
+
+// String returns a one-line description of the entry.
+func (entry *WaitlistEntry) String() string {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	description := fmt.Sprintf("%s: %s, %s %s → %s [%s]", entry.id, entry.guest.GetName(), entry.roomType,
+		entry.checkInDate.Format("Jan 02"), entry.checkOutDate.Format("Jan 02"), entry.status)
+	if entry.status == WaitlistStatusOffered {
+		description += fmt.Sprintf(" Room %s until %s", entry.offeredRoom.GetNumber(), entry.offerExpiresAt.Format("15:04"))
+	}
+	return description
+}
+
+// holdsRoom reports whether the entry has an open offer on the room that
+// overlaps the dates at time now. Caller must hold entry.mutex.
+func (entry *WaitlistEntry) holdsRoom(roomNumber string, checkIn, checkOut, now time.Time) bool {
+	return entry.status == WaitlistStatusOffered &&
+		now.Before(entry.offerExpiresAt) &&
+		entry.offeredRoom.GetNumber() == roomNumber &&
+		checkIn.Before(entry.checkOutDate) && entry.checkInDate.Before(checkOut)
+}
+
+// quote returns what the stay would cost in the offered room.
+// Caller must hold entry.mutex.
+func (entry *WaitlistEntry) quote() float64 {
+	if entry.offeredRoom == nil {
+		return 0
+	}
+	return entry.offeredRoom.GetPrice() * float64(calculateNights(entry.checkInDate, entry.checkOutDate))
+}
+
+// SetWaitlistOfferWindow changes how long future offers hold a room.
+func (hotel *Hotel) SetWaitlistOfferWindow(window time.Duration) error {
+	if window <= 0 {
+		return fmt.Errorf("offer window must be positive")
+	}
+	hotel.mutex.Lock()
+	defer hotel.mutex.Unlock()
+	hotel.offerWindow = window
+	return nil
+}
+
+// isRoomOffered reports whether an open waitlist offer holds the room on
+// any night of the stay. Caller must hold hotel.mutex.
+func (hotel *Hotel) isRoomOffered(roomNumber string, checkIn, checkOut time.Time) bool {
+	now := hotel.clock()
+	for _, entry := range hotel.waitlist {
+		entry.mutex.Lock()
+		held := entry.holdsRoom(roomNumber, checkIn, checkOut, now)
+		entry.mutex.Unlock()
+		if held {
+			return true
+		}
+	}
+	return false
+}
+
+// findFreeRoomLocked returns the lowest-numbered room of the type that the
+// public could book for the dates, or nil. Caller must hold hotel.mutex.
+func (hotel *Hotel) findFreeRoomLocked(roomType RoomType, checkIn, checkOut time.Time) *Room {
+	roomNumbers := make([]string, 0, len(hotel.rooms))
+	for number, room := range hotel.rooms {
+		if room.GetType() == roomType && room.IsAvailable() {
+			roomNumbers = append(roomNumbers, number)
+		}
+	}
+	sort.Strings(roomNumbers)
+
+	for _, number := range roomNumbers {
+		if !hotel.isRoomBooked(number, checkIn, checkOut) {
+			return hotel.rooms[number]
+		}
+	}
+	return nil
+}
+
+// JoinWaitlist puts the guest on the waitlist for a room type and dates.
+// Fails if a room of the type is free, since the guest can book it directly.
+func (hotel *Hotel) JoinWaitlist(guestID string, roomType RoomType, checkIn, checkOut time.Time) (*WaitlistEntry, error) {
+	hotel.mutex.Lock()
+	guest, guestExists := hotel.guests[guestID]
+	if !guestExists {
+		hotel.mutex.Unlock()
+		return nil, fmt.Errorf("guest with ID '%s' not found", guestID)
+	}
+	if checkOut.Before(checkIn) {
+		hotel.mutex.Unlock()
+		return nil, fmt.Errorf("check-out date cannot be before check-in date")
+	}
+	if room := hotel.findFreeRoomLocked(roomType, checkIn, checkOut); room != nil {
+		hotel.mutex.Unlock()
+		return nil, fmt.Errorf("%s room %s is available for the requested dates, book it directly", roomType, room.GetNumber())
+	}
+	for _, entry := range hotel.waitlist {
+		if entry.guest == guest && entry.roomType == roomType && entry.GetStatus().IsActive() &&
+			entry.checkInDate.Equal(checkIn) && entry.checkOutDate.Equal(checkOut) {
+			hotel.mutex.Unlock()
+			return nil, fmt.Errorf("guest '%s' is already on the waitlist as %s", guestID, entry.id)
+		}
+	}
+
+	entry := &WaitlistEntry{
+		id:           waitlistIDGen.NextID(),
+		guest:        guest,
+		roomType:     roomType,
+		checkInDate:  checkIn,
+		checkOutDate: checkOut,
+		joinedAt:     hotel.clock(),
+		status:       WaitlistStatusWaiting,
+	}
+	hotel.waitlist = append(hotel.waitlist, entry)
+	hotel.waitlistEntries[entry.id] = entry
+	hotel.recordWaitlistEventLocked(WaitlistJoined, entry)
+	hotel.mutex.Unlock()
+
+	hotel.flushEvents()
+	return entry, nil
+}
+
+// ProcessWaitlist expires unanswered offers and offers free rooms to
+// waiting guests in join order. Returns the entries that received an offer.
+func (hotel *Hotel) ProcessWaitlist() []*WaitlistEntry {
+	hotel.mutex.Lock()
+	now := hotel.clock()
+
+	// Expire first, so the rooms they held can be offered again right away
+	expired := make([]*WaitlistEntry, 0)
+	for _, entry := range hotel.waitlist {
+		entry.mutex.Lock()
+		if entry.status == WaitlistStatusOffered && !now.Before(entry.offerExpiresAt) {
+			entry.status = WaitlistStatusExpired
+			expired = append(expired, entry)
+		}
+		entry.mutex.Unlock()
+	}
+
+	offered := make([]*WaitlistEntry, 0)
+	for _, entry := range hotel.waitlist {
+		if entry.GetStatus() != WaitlistStatusWaiting {
+			continue
+		}
+		room := hotel.findFreeRoomLocked(entry.roomType, entry.checkInDate, entry.checkOutDate)
+		if room == nil {
+			continue
+		}
+		entry.mutex.Lock()
+		entry.status = WaitlistStatusOffered
+		entry.offeredRoom = room
+		entry.offerExpiresAt = now.Add(hotel.offerWindow)
+		entry.mutex.Unlock()
+		offered = append(offered, entry)
+	}
+	for _, entry := range expired {
+		hotel.recordWaitlistEventLocked(WaitlistOfferExpired, entry)
+	}
+	for _, entry := range offered {
+		hotel.recordWaitlistEventLocked(WaitlistOffered, entry)
+	}
+	hotel.pruneWaitlistLocked()
+	hotel.mutex.Unlock()
+
+	hotel.flushEvents()
+	return offered
+}
+
+// pruneWaitlistLocked drops entries that are no longer on the waitlist from
+// the queue; they stay in waitlistEntries for lookups. Caller must hold hotel.mutex.
+func (hotel *Hotel) pruneWaitlistLocked() {
+	active := hotel.waitlist[:0]
+	for _, entry := range hotel.waitlist {
+		if entry.GetStatus().IsActive() {
+			active = append(active, entry)
+		}
+	}
+	hotel.waitlist = active
+}
+
+// getWaitlistEntryLocked finds an entry by ID. Caller must hold hotel.mutex.
+func (hotel *Hotel) getWaitlistEntryLocked(entryID string) (*WaitlistEntry, error) {
+	entry, exists := hotel.waitlistEntries[entryID]
+	if !exists {
+		return nil, fmt.Errorf("waitlist entry '%s' not found", entryID)
+	}
+	return entry, nil
+}
+
+// AcceptOffer books the room held for the entry. Fails if the entry has no
+// open offer; an offer accepted after its deadline expires instead, and the
+// room moves on to the next guest.
+func (hotel *Hotel) AcceptOffer(entryID string) (*Booking, error) {
+	hotel.mutex.Lock()
+	entry, err := hotel.getWaitlistEntryLocked(entryID)
+	if err != nil {
+		hotel.mutex.Unlock()
+		return nil, err
+	}
+
+	entry.mutex.Lock()
+	if entry.status != WaitlistStatusOffered {
+		status := entry.status
+		entry.mutex.Unlock()
+		hotel.mutex.Unlock()
+		return nil, fmt.Errorf("waitlist entry '%s' has no open offer (status: %s)", entryID, status)
+	}
+	if !hotel.clock().Before(entry.offerExpiresAt) {
+		deadline := entry.offerExpiresAt
+		entry.mutex.Unlock()
+		hotel.mutex.Unlock()
+		hotel.ProcessWaitlist()
+		return nil, fmt.Errorf("offer for '%s' expired at %s", entryID, deadline.Format("Jan 02 15:04"))
+	}
+
+	// The offer kept everyone else off the room, so it is still free
+	booking := NewBooking(entry.guest, entry.offeredRoom, entry.checkInDate, entry.checkOutDate)
+	booking.source = BookingSourceWaitlist
+	hotel.bookings[booking.GetID()] = booking
+	entry.status = WaitlistStatusAccepted
+	entry.bookingID = booking.GetID()
+	entry.mutex.Unlock()
+	hotel.recordBookingEventLocked(BookingCreated, booking)
+	hotel.pruneWaitlistLocked()
+	hotel.mutex.Unlock()
+
+	hotel.flushEvents()
+	return booking, nil
+}
+
+// DeclineOffer turns the offer down and passes the room to the next guest.
+func (hotel *Hotel) DeclineOffer(entryID string) error {
+	return hotel.closeWaitlistEntry(entryID, WaitlistStatusDeclined, true)
+}
+
+// LeaveWaitlist removes the guest from the waitlist. An open offer is
+// released and passed to the next guest.
+func (hotel *Hotel) LeaveWaitlist(entryID string) error {
+	return hotel.closeWaitlistEntry(entryID, WaitlistStatusWithdrawn, false)
+}
+
+// closeWaitlistEntry moves an active entry to a final status and re-runs
+// the waitlist so any room it held is offered again.
+func (hotel *Hotel) closeWaitlistEntry(entryID string, status WaitlistStatus, mustBeOffered bool) error {
+	hotel.mutex.Lock()
+	entry, err := hotel.getWaitlistEntryLocked(entryID)
+	if err != nil {
+		hotel.mutex.Unlock()
+		return err
+	}
+
+	entry.mutex.Lock()
+	if mustBeOffered && entry.status != WaitlistStatusOffered {
+		current := entry.status
+		entry.mutex.Unlock()
+		hotel.mutex.Unlock()
+		return fmt.Errorf("waitlist entry '%s' has no open offer (status: %s)", entryID, current)
+	}
+	if !entry.status.IsActive() {
+		current := entry.status
+		entry.mutex.Unlock()
+		hotel.mutex.Unlock()
+		return fmt.Errorf("waitlist entry '%s' is already closed (status: %s)", entryID, current)
+	}
+	entry.status = status
+	entry.mutex.Unlock()
+	hotel.pruneWaitlistLocked()
+	hotel.mutex.Unlock()
+
+	hotel.ProcessWaitlist()
+	return nil
+}
+
+// GetWaitlist returns the active entries for a room type in join order.
+func (hotel *Hotel) GetWaitlist(roomType RoomType) []*WaitlistEntry {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	entries := make([]*WaitlistEntry, 0)
+	for _, entry := range hotel.waitlist {
+		if entry.roomType == roomType && entry.GetStatus().IsActive() {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// recordWaitlistEventLocked adds a waitlist event for the entry to the
+// outbox. Does nothing if no publisher is configured. Caller must hold
+// hotel.mutex (but not entry.mutex), like recordBookingEventLocked.
+func (hotel *Hotel) recordWaitlistEventLocked(eventType BookingEventType, entry *WaitlistEntry) {
+	if hotel.outbox == nil {
+		return
+	}
+
+	entry.mutex.Lock()
+	event := BookingEvent{
+		ID:         bookingEventIDGen.NextID(),
+		Type:       eventType,
+		BookingID:  entry.bookingID,
+		GuestID:    entry.guest.GetID(),
+		GuestEmail: entry.guest.GetEmail(),
+		Source:     BookingSourceWaitlist,
+		Amount:     entry.quote(),
+		OccurredAt: time.Now(),
+		WaitlistID: entry.id,
+	}
+	if entry.offeredRoom != nil {
+		event.RoomNumber = entry.offeredRoom.GetNumber()
+	}
+	if eventType == WaitlistOffered {
+		event.OfferExpiresAt = entry.offerExpiresAt
+	}
+	entry.mutex.Unlock()
+
+	hotel.outbox.append(event)
+}
+
+// ============================================================================
+// SECTION 18: HOTEL CHAIN (Multi-Property Management)
+// ============================================================================
+//
+// A HotelChain is the aggregate root above several Hotel instances. Each
+// property keeps running its own rooms, bookings and locks; the chain only
+// coordinates work that spans properties:
+// - Guest profiles: a guest registers once with the chain and is known at
+//   every property, current and future (the same *Guest is shared, so an
+//   address change is seen everywhere). GetGuestProfile lists stays across
+//   the whole chain
+// - Chain-wide availability: one search fans out to every property
+// - Booking transfers: move a not-yet-started stay to another property.
+//   The new booking is made first and the original is cancelled only once
+//   it succeeds, so a failed transfer leaves the guest's stay untouched
+// - Consolidated reporting: per-property figures plus chain totals
+//
+// Loyalty accounts stay per property. Cancelling the original booking of a
+// transfer refunds any points redeemed there, like any other cancellation.
+//
+// ============================================================================
+
+// BookingTransfer records a stay moved from one property to another.
+type BookingTransfer struct {
+	FromProperty  string    // Code of the property the stay left
+	FromBookingID string    // Cancelled booking at the old property
+	ToProperty    string    // Code of the property the stay moved to
+	ToBookingID   string    // New booking at the new property
+	TransferredAt time.Time // When the transfer happened
+}
+
+// PropertyAvailability lists the free rooms of one property for a search.
+type PropertyAvailability struct {
+	PropertyCode string  // Chain code of the property (e.g. "NYC")
+	HotelName    string  // Property name
+	Rooms        []*Room // Bookable rooms, lowest number first
+	LowestRate   float64 // Cheapest nightly rate among Rooms
+}
+
+// PropertyStay is one booking in a chain guest profile.
+type PropertyStay struct {
+	PropertyCode string   // Property the booking belongs to
+	Booking      *Booking // The booking itself
+}
+
+// GuestProfile is a guest's chain-wide view: who they are and every stay
+// at every property, earliest check-in first.
+type GuestProfile struct {
+	Guest       *Guest         // Shared guest record
+	Stays       []PropertyStay // Bookings across the chain, cancelled ones included
+	TotalNights int            // Nights of stays that were not cancelled
+	TotalSpent  float64        // Billed total of completed stays
+}
+
+// PropertyReport holds one property's figures for the chain report.
+type PropertyReport struct {
+	PropertyCode      string  // Chain code of the property
+	HotelName         string  // Property name
+	Rooms             int     // Rooms in inventory
+	OccupiedRooms     int     // Rooms with a guest in them right now
+	ActiveBookings    int     // Pending, confirmed or checked-in bookings
+	CancelledBookings int     // Cancelled bookings (transfers included)
+	Revenue           float64 // Billed total of checked-out stays
+	OnTheBooks        float64 // Value of active bookings not yet billed
+}
+
+// OccupancyRate returns the share of rooms occupied, from 0 to 1.
+func (report PropertyReport) OccupancyRate() float64 {
+	if report.Rooms == 0 {
+		return 0
+	}
+	return float64(report.OccupiedRooms) / float64(report.Rooms)
+}
+
+// ChainReport is the consolidated report across all properties.
+type ChainReport struct {
+	ChainName  string           // Name of the chain
+	Properties []PropertyReport // One entry per property, in the order they joined
+	Totals     PropertyReport   // Sums over all properties (code "ALL")
+	Transfers  int              // Bookings moved between properties
+}
+
+// getBooking returns a booking by ID.
+func (hotel *Hotel) getBooking(bookingID string) (*Booking, error) {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	booking, exists := hotel.bookings[bookingID]
+	if !exists {
+		return nil, fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+	return booking, nil
+}
+
+// bookingsForGuest returns the guest's bookings at this hotel.
+func (hotel *Hotel) bookingsForGuest(guestID string) []*Booking {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	guestBookings := make([]*Booking, 0)
+	for _, booking := range hotel.bookings {
+		if booking.GetGuest().GetID() == guestID {
+			guestBookings = append(guestBookings, booking)
+		}
+	}
+	return guestBookings
+}
+
+// summarize computes this hotel's figures for a chain report.
+func (hotel *Hotel) summarize(propertyCode string) PropertyReport {
+	hotel.mutex.RLock()
+	defer hotel.mutex.RUnlock()
+
+	report := PropertyReport{PropertyCode: propertyCode, HotelName: hotel.name, Rooms: len(hotel.rooms)}
+	for _, room := range hotel.rooms {
+		if room.GetStatus() == RoomStatusOccupied {
+			report.OccupiedRooms++
+		}
+	}
+	for _, booking := range hotel.bookings {
+		switch booking.GetStatus() {
+		case BookingStatusCheckedOut:
+			report.Revenue += booking.GetTotal()
+		case BookingStatusCancelled:
+			report.CancelledBookings++
+		default:
+			report.ActiveBookings++
+			report.OnTheBooks += booking.GetTotal()
+		}
+	}
+	return report
+}
+
+// HotelChain manages several properties and the guests they share.
+type HotelChain struct {
+	name          string            // Chain name
+	properties    map[string]*Hotel // Member hotels (key: property code)
+	propertyCodes []string          // Property codes in the order they joined
+	guests        map[string]*Guest // Chain guest profiles (key: guest ID)
+	transfers     []BookingTransfer // Every transfer, oldest first
+	mutex         sync.RWMutex      // Protects the chain's maps and slices
+}
+
+// NewHotelChain creates an empty hotel chain.
+func NewHotelChain(name string) *HotelChain {
+	return &HotelChain{
+		name:          name,
+		properties:    make(map[string]*Hotel),
+		propertyCodes: make([]string, 0),
+		guests:        make(map[string]*Guest),
+		transfers:     make([]BookingTransfer, 0),
+	}
+}
+
+// GetName returns the chain name.
+func (chain *HotelChain) GetName() string { return chain.name }
+
+// AddProperty brings a hotel into the chain under a unique code.
+// Guests already registered with the chain are registered at the new property.
+func (chain *HotelChain) AddProperty(code string, hotel *Hotel) error {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	if code == "" {
+		return fmt.Errorf("property code is required")
+	}
+	if _, exists := chain.properties[code]; exists {
+		return fmt.Errorf("property '%s' already exists in %s", code, chain.name)
+	}
+
+	chain.properties[code] = hotel
+	chain.propertyCodes = append(chain.propertyCodes, code)
+	for _, guest := range chain.guests {
+		hotel.RegisterGuest(guest)
+	}
+	return nil
+}
+
+// GetProperty returns the hotel with the given code.
+func (chain *HotelChain) GetProperty(code string) (*Hotel, error) {
+	chain.mutex.RLock()
+	defer chain.mutex.RUnlock()
+
+	hotel, exists := chain.properties[code]
+	if !exists {
+		return nil, fmt.Errorf("property '%s' not found in %s", code, chain.name)
+	}
+	return hotel, nil
+}
+
+// RegisterGuest creates the guest's chain profile and registers them at
+// every property.
+func (chain *HotelChain) RegisterGuest(guest *Guest) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	chain.guests[guest.GetID()] = guest
+	for _, code := range chain.propertyCodes {
+		chain.properties[code].RegisterGuest(guest)
+	}
+}
+
+// SearchAvailability returns, for every property with at least one free
+// room of the type, the rooms bookable for the dates. Properties are listed
+// cheapest first, ties in the order they joined the chain.
+func (chain *HotelChain) SearchAvailability(roomType RoomType, checkIn, checkOut time.Time) []PropertyAvailability {
+	chain.mutex.RLock()
+	defer chain.mutex.RUnlock()
+
+	results := make([]PropertyAvailability, 0)
+	for _, code := range chain.propertyCodes {
+		hotel := chain.properties[code]
+		rooms := hotel.GetAvailableRoomsForDates(roomType, checkIn, checkOut)
+		if len(rooms) == 0 {
+			continue
+		}
+		lowestRate := rooms[0].GetPrice()
+		for _, room := range rooms[1:] {
+			lowestRate = min(lowestRate, room.GetPrice())
+		}
+		results = append(results, PropertyAvailability{
+			PropertyCode: code,
+			HotelName:    hotel.GetName(),
+			Rooms:        rooms,
+			LowestRate:   lowestRate,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].LowestRate < results[j].LowestRate
+	})
+	return results
+}
+
+// CreateBooking books a room of the type at one property for a chain guest.
+func (chain *HotelChain) CreateBooking(guestID, propertyCode string, roomType RoomType, checkIn, checkOut time.Time) (*Booking, error) {
+	chain.mutex.RLock()
+	_, isChainGuest := chain.guests[guestID]
+	hotel, exists := chain.properties[propertyCode]
+	chain.mutex.RUnlock()
+
+	if !isChainGuest {
+		return nil, fmt.Errorf("guest with ID '%s' has no %s profile", guestID, chain.name)
+	}
+	if !exists {
+		return nil, fmt.Errorf("property '%s' not found in %s", propertyCode, chain.name)
+	}
+	return hotel.CreateBookingForRoomType(guestID, roomType, checkIn, checkOut)
+}
+
+// findBooking returns the property code and hotel holding a booking.
+func (chain *HotelChain) findBooking(bookingID string) (string, *Hotel, *Booking, error) {
+	chain.mutex.RLock()
+	defer chain.mutex.RUnlock()
+
+	for _, code := range chain.propertyCodes {
+		hotel := chain.properties[code]
+		if booking, err := hotel.getBooking(bookingID); err == nil {
+			return code, hotel, booking, nil
+		}
+	}
+	return "", nil, nil, fmt.Errorf("booking with ID '%s' not found in %s", bookingID, chain.name)
+}
+
+// TransferBooking moves a pending or confirmed booking to another property,
+// keeping the guest, dates, room type, source and confirmation. The stay is
+// repriced at the new property's rate. Returns the new booking.
+func (chain *HotelChain) TransferBooking(bookingID, toPropertyCode string) (*Booking, error) {
+	fromCode, fromHotel, original, err := chain.findBooking(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if fromCode == toPropertyCode {
+		return nil, fmt.Errorf("booking %s is already at %s", bookingID, toPropertyCode)
+	}
+	toHotel, err := chain.GetProperty(toPropertyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	status := original.GetStatus()
+	if status != BookingStatusPending && status != BookingStatusConfirmed {
+		return nil, fmt.Errorf("cannot transfer: booking %s is %s", bookingID, status)
+	}
+
+	// Book the new property first so a full house leaves the original intact
+	transferred, err := toHotel.CreateBookingForRoomType(original.GetGuest().GetID(), original.GetRoom().GetType(),
+		original.GetCheckInDate(), original.GetCheckOutDate())
+	if err != nil {
+		return nil, fmt.Errorf("cannot transfer to %s: %v", toPropertyCode, err)
+	}
+	transferred.source = original.GetSource()
+	if status == BookingStatusConfirmed {
+		if err := transferred.Confirm(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The guest may have checked in meanwhile: undo the new booking
+	if err := fromHotel.CancelBooking(bookingID); err != nil {
+		_ = toHotel.CancelBooking(transferred.GetID())
+		return nil, fmt.Errorf("cannot transfer: %v", err)
+	}
+
+	chain.mutex.Lock()
+	chain.transfers = append(chain.transfers, BookingTransfer{
+		FromProperty:  fromCode,
+		FromBookingID: bookingID,
+		ToProperty:    toPropertyCode,
+		ToBookingID:   transferred.GetID(),
+		TransferredAt: time.Now(),
+	})
+	chain.mutex.Unlock()
+	return transferred, nil
+}
+
+// GetTransfers returns every transfer, oldest first.
+func (chain *HotelChain) GetTransfers() []BookingTransfer {
+	chain.mutex.RLock()
+	defer chain.mutex.RUnlock()
+
+	transfers := make([]BookingTransfer, len(chain.transfers))
+	copy(transfers, chain.transfers)
+	return transfers
+}
+
+// GetGuestProfile returns the guest's stays across every property.
+func (chain *HotelChain) GetGuestProfile(guestID string) (GuestProfile, error) {
+	chain.mutex.RLock()
+	defer chain.mutex.RUnlock()
+
+	guest, exists := chain.guests[guestID]
+	if !exists {
+		return GuestProfile{}, fmt.Errorf("guest with ID '%s' has no %s profile", guestID, chain.name)
+	}
+
+	profile := GuestProfile{Guest: guest, Stays: make([]PropertyStay, 0)}
+	for _, code := range chain.propertyCodes {
+		for _, booking := range chain.properties[code].bookingsForGuest(guestID) {
+			profile.Stays = append(profile.Stays, PropertyStay{PropertyCode: code, Booking: booking})
+			switch booking.GetStatus() {
+			case BookingStatusCancelled:
+				continue
+			case BookingStatusCheckedOut:
+				profile.TotalSpent += booking.GetTotal()
+			}
+			profile.TotalNights += booking.GetNights()
+		}
+	}
+	sort.SliceStable(profile.Stays, func(i, j int) bool {
+		return profile.Stays[i].Booking.GetCheckInDate().Before(profile.Stays[j].Booking.GetCheckInDate())
+	})
+	return profile, nil
+}
+
+// GenerateReport builds the consolidated chain report.
+func (chain *HotelChain) GenerateReport() ChainReport {
+	chain.mutex.RLock()
+	defer chain.mutex.RUnlock()
+
+	report := ChainReport{
+		ChainName:  chain.name,
+		Properties: make([]PropertyReport, 0, len(chain.propertyCodes)),
+		Totals:     PropertyReport{PropertyCode: "ALL", HotelName: chain.name},
+		Transfers:  len(chain.transfers),
+	}
+	for _, code := range chain.propertyCodes {
+		property := chain.properties[code].summarize(code)
+		report.Properties = append(report.Properties, property)
+		report.Totals.Rooms += property.Rooms
+		report.Totals.OccupiedRooms += property.OccupiedRooms
+		report.Totals.ActiveBookings += property.ActiveBookings
+		report.Totals.CancelledBookings += property.CancelledBookings
+		report.Totals.Revenue += property.Revenue
+		report.Totals.OnTheBooks += property.OnTheBooks
+	}
+	return report
+}
+
+// DisplayReport prints the consolidated chain report.
+func (chain *HotelChain) DisplayReport() {
+	report := chain.GenerateReport()
+
+	fmt.Printf("\n🏢 %s - Chain Report\n", report.ChainName)
+	fmt.Println("─────────────────────────────────────────")
+	for _, property := range append(report.Properties, report.Totals) {
+		fmt.Printf("  %-4s %-22s rooms %2d | occupied %3.0f%% | active %d | cancelled %d | revenue $%.2f | on books $%.2f\n",
+			property.PropertyCode, property.HotelName, property.Rooms, property.OccupancyRate()*100,
+			property.ActiveBookings, property.CancelledBookings, property.Revenue, property.OnTheBooks)
+	}
+	fmt.Printf("  Transfers between properties: %d\n", report.Transfers)
+}
+
+// ============================================================================
+// SECTION 19: KEY CARDS & DOOR ACCESS
+// ============================================================================
+//
+// Every check-in issues a key card bound to one room and a validity window:
+// from issuance until the booking's occupancy end (a purchased late
+// checkout moves it, see PurchaseLateCheckOut). Front desk can issue extra
+// cards for the same stay, up to MaxKeyCardsPerBooking.
+//
+// A card stops opening the door when:
+// - the guest checks out (all cards of the booking are deactivated)
+// - it is reported lost (only that card; a replacement can be issued)
+// - its validity window ends, even if nobody deactivated it
+//
+// Door locks are vendor hardware. DoorLockIntegration is the boundary to
+// the vendor's system: the hotel encodes and revokes cards through it. The
+// StubDoorLock implementation only records the commands it would send.
+// SwipeKeyCard simulates a door reading a card; every attempt, granted or
+// denied, goes into the room's access audit log.
+//
+// ============================================================================
+
+// MaxKeyCardsPerBooking caps how many cards one stay can hold at once.
+const MaxKeyCardsPerBooking = 4
+
+// KeyCardStatus is whether a key card can still open its door.
+type KeyCardStatus int
+
+const (
+	KeyCardActive      KeyCardStatus = iota // 0 - Opens the door within its validity window
+	KeyCardDeactivated                      // 1 - Guest checked out (or the stay was cancelled)
+	KeyCardLost                             // 2 - Reported lost; never opens again
+)
+
+// String returns a human-readable name for the key card status.
+func (status KeyCardStatus) String() string {
+	names := [...]string{"Active", "Deactivated", "Lost"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// AccessResult is the outcome of one swipe at a door.
+type AccessResult int
+
+const (
+	AccessGranted         AccessResult = iota // 0 - Door opened
+	AccessUnknownCard                         // 1 - Card was never issued by this hotel
+	AccessWrongRoom                           // 2 - Card belongs to another room
+	AccessNotYetValid                         // 3 - Validity window has not started
+	AccessExpired                             // 4 - Validity window has ended
+	AccessCardDeactivated                     // 5 - Guest has checked out
+	AccessCardLost                            // 6 - Card was reported lost
+)
+
+// String returns a human-readable name for the access result.
+func (result AccessResult) String() string {
+	names := [...]string{"Granted", "Unknown card", "Wrong room", "Not yet valid", "Expired", "Deactivated", "Reported lost"}
+	if int(result) < len(names) {
+		return names[result]
+	}
+	return "Unknown"
+}
+
+// AccessEvent is one entry in a room's door audit log.
+type AccessEvent struct {
+	At         time.Time    // When the card was swiped
+	RoomNumber string       // Door that was swiped
+	CardID     string       // Card presented
+	GuestID    string       // Card holder ("" for unknown cards)
+	Result     AccessResult // Whether the door opened, and why not
+}
+
+// Granted reports whether the door opened.
+func (event AccessEvent) Granted() bool {
+	return event.Result == AccessGranted
+}
+
+// keyCardIDGen generates unique IDs for key cards.
+var keyCardIDGen = idgen.NewSequence("KC")
+
+// KeyCard is a room key bound to one stay.
+type KeyCard struct {
+	id         string        // Unique identifier (e.g., "KC-1")
+	roomNumber string        // The only door it opens
+	bookingID  string        // Stay it was issued for
+	guestID    string        // Guest holding the stay
+	validFrom  time.Time     // Opens the door from this moment...
+	validUntil time.Time     // ...until this one (exclusive)
+	status     KeyCardStatus // Active, deactivated or lost
+	issuedAt   time.Time     // When front desk encoded it
+	mutex      sync.Mutex    // Protects status and validUntil
+}
+
+// Getter methods for KeyCard
+func (card *KeyCard) GetID() string           { return card.id }
+func (card *KeyCard) GetRoomNumber() string   { return card.roomNumber }
+func (card *KeyCard) GetBookingID() string    { return card.bookingID }
+func (card *KeyCard) GetValidFrom() time.Time { return card.validFrom }
+
+// GetStatus returns the card's status (thread-safe).
+func (card *KeyCard) GetStatus() KeyCardStatus {
+	card.mutex.Lock()
+	defer card.mutex.Unlock()
+	return card.status
+}
+
+// GetValidUntil returns when the card stops opening the door (thread-safe).
+func (card *KeyCard) GetValidUntil() time.Time {
+	card.mutex.Lock()
+	defer card.mutex.Unlock()
+	return card.validUntil
+}
+
+// check decides whether the card opens roomNumber at time at.
+func (card *KeyCard) check(roomNumber string, at time.Time) AccessResult {
+	card.mutex.Lock()
+	defer card.mutex.Unlock()
+
+	switch {
+	case card.status == KeyCardLost:
+		return AccessCardLost
+	case card.status == KeyCardDeactivated:
+		return AccessCardDeactivated
+	case card.roomNumber != roomNumber:
+		return AccessWrongRoom
+	case at.Before(card.validFrom):
+		return AccessNotYetValid
+	case !at.Before(card.validUntil):
+		return AccessExpired
+	}
+	return AccessGranted
+}
+
+// DoorLockIntegration is the boundary to the door lock vendor's system.
+// A production hotel implements it against the vendor's API or encoder.
+type DoorLockIntegration interface {
+	EncodeCard(cardID, roomNumber string, validFrom, validUntil time.Time) error
+	RevokeCard(cardID string) error
+}
+
+// StubDoorLock records the commands a real lock system would receive.
+type StubDoorLock struct {
+	commands []string
+	mutex    sync.Mutex
+}
+
+// EncodeCard records an encode (or re-encode) command.
+func (lock *StubDoorLock) EncodeCard(cardID, roomNumber string, validFrom, validUntil time.Time) error {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	lock.commands = append(lock.commands, fmt.Sprintf("ENCODE %s room=%s until=%s",
+		cardID, roomNumber, validUntil.Format("Jan 02 3:04 PM")))
+	return nil
+}
+
+// RevokeCard records a revoke command.
+func (lock *StubDoorLock) RevokeCard(cardID string) error {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	lock.commands = append(lock.commands, "REVOKE "+cardID)
+	return nil
+}
+
+// GetCommands returns a copy of the recorded commands, oldest first.
+func (lock *StubDoorLock) GetCommands() []string {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+
+	commands := make([]string, len(lock.commands))
+	copy(commands, lock.commands)
+	return commands
+}
+
+// KeyCardSystem tracks issued cards and the door audit logs of a hotel.
+type KeyCardSystem struct {
+	cards       map[string]*KeyCard      // All issued cards (key: card ID)
+	bookingCard map[string][]*KeyCard    // Cards per stay (key: booking ID)
+	accessLogs  map[string][]AccessEvent // Door audit logs (key: room number), oldest first
+	lock        DoorLockIntegration      // Vendor lock system
+	mutex       sync.Mutex               // Protects the maps
+}
+
+// NewKeyCardSystem creates a key card system backed by a stub lock vendor.
+func NewKeyCardSystem() *KeyCardSystem {
+	return &KeyCardSystem{
+		cards:       make(map[string]*KeyCard),
+		bookingCard: make(map[string][]*KeyCard),
+		accessLogs:  make(map[string][]AccessEvent),
+		lock:        &StubDoorLock{},
+	}
+}
+
+// vendor returns the current lock integration.
+func (system *KeyCardSystem) vendor() DoorLockIntegration {
+	system.mutex.Lock()
+	defer system.mutex.Unlock()
+	return system.lock
+}
+
+// issue encodes a new card for a checked-in booking.
+func (system *KeyCardSystem) issue(booking *Booking, now time.Time) (*KeyCard, error) {
+	if status := booking.GetStatus(); status != BookingStatusCheckedIn {
+		return nil, fmt.Errorf("cannot issue key card: booking is %s, not checked in", status)
+	}
+
+	system.mutex.Lock()
+	defer system.mutex.Unlock()
+
+	active := 0
+	for _, card := range system.bookingCard[booking.GetID()] {
+		if card.GetStatus() == KeyCardActive {
+			active++
+		}
+	}
+	if active >= MaxKeyCardsPerBooking {
+		return nil, fmt.Errorf("booking %s already has %d active key cards", booking.GetID(), active)
+	}
+
+	card := &KeyCard{
+		id:         keyCardIDGen.NextID(),
+		roomNumber: booking.GetRoom().GetNumber(),
+		bookingID:  booking.GetID(),
+		guestID:    booking.GetGuest().GetID(),
+		validFrom:  now,
+		validUntil: booking.OccupancyEnd(),
+		status:     KeyCardActive,
+		issuedAt:   now,
+	}
+	if err := system.lock.EncodeCard(card.id, card.roomNumber, card.validFrom, card.validUntil); err != nil {
+		return nil, fmt.Errorf("door lock system rejected key card: %w", err)
+	}
+	system.cards[card.id] = card
+	system.bookingCard[card.bookingID] = append(system.bookingCard[card.bookingID], card)
+	return card, nil
+}
+
+// setStatus moves an active card to a final status and revokes it at the
+// lock vendor. Cards that are no longer active are left alone.
+func (system *KeyCardSystem) setStatus(card *KeyCard, status KeyCardStatus) error {
+	card.mutex.Lock()
+	if card.status != KeyCardActive {
+		card.mutex.Unlock()
+		return nil
+	}
+	card.status = status
+	card.mutex.Unlock()
+	return system.vendor().RevokeCard(card.id)
+}
+
+// deactivateBooking deactivates every active card of a stay.
+func (system *KeyCardSystem) deactivateBooking(bookingID string) error {
+	system.mutex.Lock()
+	cards := system.bookingCard[bookingID]
+	system.mutex.Unlock()
+
+	var errs []error
+	for _, card := range cards {
+		if err := system.setStatus(card, KeyCardDeactivated); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// extendBooking moves the validity end of a stay's active cards, e.g.
+// after a late checkout purchase, and re-encodes them.
+func (system *KeyCardSystem) extendBooking(bookingID string, validUntil time.Time) error {
+	system.mutex.Lock()
+	cards := system.bookingCard[bookingID]
+	system.mutex.Unlock()
+
+	var errs []error
+	for _, card := range cards {
+		card.mutex.Lock()
+		active := card.status == KeyCardActive
+		if active {
+			card.validUntil = validUntil
+		}
+		card.mutex.Unlock()
+		if active {
+			errs = append(errs, system.vendor().EncodeCard(card.id, card.roomNumber, card.validFrom, validUntil))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// swipe validates a card at a door and appends the attempt to its audit log.
+func (system *KeyCardSystem) swipe(roomNumber, cardID string, at time.Time) AccessEvent {
+	system.mutex.Lock()
+	defer system.mutex.Unlock()
+
+	event := AccessEvent{At: at, RoomNumber: roomNumber, CardID: cardID, Result: AccessUnknownCard}
+	if card, exists := system.cards[cardID]; exists {
+		event.GuestID = card.guestID
+		event.Result = card.check(roomNumber, at)
+	}
+	system.accessLogs[roomNumber] = append(system.accessLogs[roomNumber], event)
+	return event
+}
+
+// SetDoorLockIntegration replaces the stub with a real lock vendor.
+func (hotel *Hotel) SetDoorLockIntegration(lock DoorLockIntegration) {
+	hotel.keyCards.mutex.Lock()
+	defer hotel.keyCards.mutex.Unlock()
+	hotel.keyCards.lock = lock
+}
+
+// GetDoorLockIntegration returns the lock vendor the hotel talks to.
+func (hotel *Hotel) GetDoorLockIntegration() DoorLockIntegration {
+	return hotel.keyCards.vendor()
+}
+
+// IssueKeyCard issues another card for a checked-in stay (e.g. for a
+// second guest, or to replace a lost card).
+func (hotel *Hotel) IssueKeyCard(bookingID string) (*KeyCard, error) {
+	hotel.mutex.RLock()
+	booking, exists := hotel.bookings[bookingID]
+	now := hotel.clock()
+	hotel.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("booking with ID '%s' not found", bookingID)
+	}
+	return hotel.keyCards.issue(booking, now)
+}
+
+// ReportKeyCardLost permanently disables a card. Other cards of the same
+// stay keep working.
+func (hotel *Hotel) ReportKeyCardLost(cardID string) error {
+	hotel.keyCards.mutex.Lock()
+	card, exists := hotel.keyCards.cards[cardID]
+	hotel.keyCards.mutex.Unlock()
+
+	if !exists {
+		return fmt.Errorf("key card '%s' not found", cardID)
+	}
+	if status := card.GetStatus(); status != KeyCardActive {
+		return fmt.Errorf("key card %s is already %s", cardID, status)
+	}
+	return hotel.keyCards.setStatus(card, KeyCardLost)
+}
+
+// GetKeyCards returns every card issued for a stay, oldest first.
+func (hotel *Hotel) GetKeyCards(bookingID string) []*KeyCard {
+	hotel.keyCards.mutex.Lock()
+	defer hotel.keyCards.mutex.Unlock()
+
+	cards := make([]*KeyCard, len(hotel.keyCards.bookingCard[bookingID]))
+	copy(cards, hotel.keyCards.bookingCard[bookingID])
+	return cards
+}
+
+// SwipeKeyCard simulates presenting a card at a room's door, at the hotel
+// clock's current time. The attempt is recorded whatever the outcome.
+func (hotel *Hotel) SwipeKeyCard(roomNumber, cardID string) AccessEvent {
+	hotel.mutex.RLock()
+	now := hotel.clock()
+	hotel.mutex.RUnlock()
+
+	return hotel.keyCards.swipe(roomNumber, cardID, now)
+}
+
+// GetAccessLog returns a copy of a room's door audit log, oldest first.
+func (hotel *Hotel) GetAccessLog(roomNumber string) []AccessEvent {
+	hotel.keyCards.mutex.Lock()
+	defer hotel.keyCards.mutex.Unlock()
+
+	log := make([]AccessEvent, len(hotel.keyCards.accessLogs[roomNumber]))
+	copy(log, hotel.keyCards.accessLogs[roomNumber])
+	return log
+}
@@ -0,0 +1,8 @@
+// Command demo runs the shoppingcart walkthrough: go run ./15_shopping_cart/cmd/demo
+package main
+
+import shoppingcart "github.com/ayushgupta5/GoLLD/15_shopping_cart"
+
+func main() {
+	shoppingcart.RunDemo()
+}
@@ -0,0 +1,546 @@
+package shoppingcart
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	notification "github.com/ayushgupta5/GoLLD/18_notification_system"
+	pubsub "github.com/ayushgupta5/GoLLD/19_pubsub"
+	"github.com/ayushgupta5/GoLLD/pkg/money"
+)
+
+// ============================================================================
+// SECTION 16: MAIN - DEMONSTRATION
+// ============================================================================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("        🛒 SHOPPING CART SYSTEM")
+	fmt.Println("═══════════════════════════════════════════")
+
+	// =========================================
+	// STEP 1: Create product catalog
+	// =========================================
+	products := []*Product{
+		NewProduct("P001", "iPhone 15 Pro", 999.00, CategoryElectronics, 10),
+		NewProduct("P002", "MacBook Air M3", 1299.00, CategoryElectronics, 5),
+		NewProduct("P003", "Cotton T-Shirt", 29.99, CategoryClothing, 100),
+		NewProduct("P004", "Go Programming Book", 49.99, CategoryBooks, 50),
+		NewProduct("P005", "Organic Coffee", 15.99, CategoryGrocery, 200),
+	}
+
+	// Display available products
+	fmt.Println("\n📦 Available Products:")
+	fmt.Println("─────────────────────────────────────────")
+	for _, product := range products {
+		fmt.Printf("  %s: %s - $%.2f (Stock: %d) [%s]\n",
+			product.GetID(),
+			product.GetName(),
+			product.GetPrice(),
+			product.GetStock(),
+			product.GetCategory())
+	}
+
+	// =========================================
+	// STEP 2: Create a shopping cart
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🛒 Adding items to cart...")
+
+	shoppingCart := NewCart("USER001")
+
+	// Add items to cart
+	shoppingCart.AddItem(products[0], 1) // 1 iPhone
+	shoppingCart.AddItem(products[2], 2) // 2 T-Shirts
+	shoppingCart.AddItem(products[3], 1) // 1 Book
+	shoppingCart.AddItem(products[4], 3) // 3 Coffee
+
+	// Display cart contents
+	shoppingCart.PrintCart()
+
+	// =========================================
+	// STEP 3: Apply a discount code
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🏷️  Applying coupon code...")
+
+	// Create a 10% discount using the Strategy Pattern
+	percentageDiscount := NewPercentageDiscount("SAVE10", 10)
+	shoppingCart.ApplyDiscount(percentageDiscount)
+
+	// Display cart with discount applied
+	shoppingCart.PrintCart()
+
+	// =========================================
+	// STEP 4: Create order from cart
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📦 Creating order...")
+
+	order, err := NewOrderFromCart(shoppingCart, "123 Main St, New York, NY 10001")
+	if err != nil {
+		fmt.Printf("❌ Error creating order: %v\n", err)
+		return
+	}
+
+	// Confirm the order
+	order.Confirm()
+
+	// Display order confirmation
+	order.PrintOrder()
+
+	// =========================================
+	// STEP 5: Show updated inventory
+	// =========================================
+	fmt.Println("\n📦 Updated Inventory:")
+	fmt.Println("─────────────────────────────────────────")
+	for _, product := range products {
+		fmt.Printf("  %s: %d in stock\n", product.GetName(), product.GetStock())
+	}
+
+	// =========================================
+	// STEP 6: Concurrent checkout of the last unit
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("⚡ 10 shoppers race to buy the last limited-edition watch...")
+
+	watch := NewProduct("P006", "Limited Edition Watch", 499.00, CategoryElectronics, 1)
+	coffee := products[4]
+	coffeeBefore := coffee.GetStock()
+
+	var waitGroup sync.WaitGroup
+	var resultMutex sync.Mutex
+	successfulOrders := 0
+	for shopper := 1; shopper <= 10; shopper++ {
+		racingCart := NewCart(fmt.Sprintf("RACER%02d", shopper))
+		racingCart.items[watch.GetID()] = NewCartItem(watch, 1)
+		racingCart.items[coffee.GetID()] = NewCartItem(coffee, 1)
+
+		waitGroup.Add(1)
+		go func(cart *Cart) {
+			defer waitGroup.Done()
+			if _, err := NewOrderFromCart(cart, "Somewhere"); err == nil {
+				resultMutex.Lock()
+				successfulOrders++
+				resultMutex.Unlock()
+			}
+		}(racingCart)
+	}
+	waitGroup.Wait()
+
+	fmt.Printf("  Successful orders: %d\n", successfulOrders)
+	fmt.Printf("  Watch stock: %d (version %d)\n", watch.GetStock(), watch.GetVersion())
+	fmt.Printf("  Coffee sold with watch: %d (failed checkouts take nothing)\n", coffeeBefore-coffee.GetStock())
+
+	// =========================================
+	// STEP 7: Price-drop and back-in-stock watchers
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔔 Product Watchers...")
+
+	// Alerts and reminders go out by email through the notification system
+	emailChannel := notification.NewEmailChannel("smtp.shop.example", 587, "alerts@shop.example")
+	productEvents := pubsub.NewMessageBroker()
+	watchService, err := NewWatchService(productEvents, emailChannel)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	macbook := products[1]
+	macbook.AttachEventBroker(productEvents)
+	watch.AttachEventBroker(productEvents)
+
+	watchService.WatchPriceDrop("alice@example.com", macbook, 1199.00) // Only below $1199
+	watchService.WatchPriceDrop("bob@example.com", macbook, 0)         // Any drop
+	watchService.WatchBackInStock("carol@example.com", watch)          // Sold out above
+
+	// The broker delivers asynchronously; the pauses keep the output in order
+	fmt.Println("\n  MacBook price: $1299 → $1249")
+	macbook.SetPrice(1249.00)
+	time.Sleep(50 * time.Millisecond)
+	fmt.Println("\n  MacBook price: $1249 → $1149")
+	macbook.SetPrice(1149.00)
+	time.Sleep(50 * time.Millisecond)
+	fmt.Println("\n  Watch restocked with 5 units")
+	watch.AddStock(5)
+	time.Sleep(50 * time.Millisecond)
+	fmt.Printf("\n  Remaining watches: MacBook %d, Watch %d (alerts are one-shot)\n",
+		watchService.GetWatchCount(macbook.GetID()), watchService.GetWatchCount(watch.GetID()))
+
+	// =========================================
+	// STEP 8: Guest checkout and account merge
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("👤 Guest checkout, then registering an account...")
+
+	checkout := NewCheckoutService()
+	session, err := checkout.StartGuestSession()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	guestCart, _ := checkout.GetGuestCart(session.GetToken())
+	guestCart.AddItem(products[3], 1) // Book
+
+	if _, err := checkout.GuestCheckout(session.GetToken(), "", "42 Elm St, Austin, TX"); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+	guestOrder, err := checkout.GuestCheckout(session.GetToken(), " Dana@Example.com ", "42 Elm St, Austin, TX")
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	fmt.Printf("  Guest order %s placed, updates go to %s\n", guestOrder.GetID(), guestOrder.GetContactEmail())
+
+	// The guest keeps shopping, then decides to sign up
+	guestCart, _ = checkout.GetGuestCart(session.GetToken())
+	guestCart.AddItem(products[4], 2) // Coffee
+
+	dana, _ := checkout.RegisterCustomer("CUST-DANA", "Dana", "dana@example.com")
+	danaCart, _ := checkout.GetCustomerCart(dana.GetID())
+	danaCart.AddItem(products[4], 1) // Coffee added from another device
+
+	for _, claimable := range checkout.FindGuestSessionsByEmail(dana.GetEmail()) {
+		result, err := checkout.MergeGuestSession(claimable.GetToken(), dana.GetID())
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			continue
+		}
+		fmt.Printf("  Merged into %s: %d order(s), %d cart line(s)\n",
+			result.CustomerID, result.OrdersMoved, result.ItemsMerged)
+	}
+
+	for _, pastOrder := range checkout.GetOrders(dana.GetID()) {
+		fmt.Printf("  %s history: %s (%s, $%.2f, merged from %s)\n", dana.GetName(),
+			pastOrder.GetID(), pastOrder.GetStatus(), pastOrder.GetTotal(), pastOrder.GetMergedFrom())
+	}
+	fmt.Printf("  %s's cart now holds %d item(s)\n", dana.GetName(), danaCart.GetItemCount())
+
+	if _, err := checkout.GetGuestCart(session.GetToken()); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+
+	// =========================================
+	// STEP 9: Abandoned cart reminders
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("⏳ Abandoned cart detection...")
+
+	// A short threshold stands in for the usual hour so the demo runs quickly
+	orderEvents := pubsub.NewMessageBroker()
+	recovery, err := NewCartRecoveryService(checkout, orderEvents, emailChannel, 100*time.Millisecond)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	erin, _ := checkout.RegisterCustomer("CUST-ERIN", "Erin", "erin@example.com")
+	erinCart, _ := checkout.GetCustomerCart(erin.GetID())
+	erinCart.AddItem(products[2], 2) // T-Shirts, then Erin leaves
+
+	recovery.StartDetector(25 * time.Millisecond)
+	time.Sleep(250 * time.Millisecond) // Dana's and Erin's carts go idle
+	recovery.StopDetector()
+
+	// A later manual scan sends nothing new: each idle period is reminded once
+	fmt.Printf("  Second scan sent %d reminder(s)\n", len(recovery.DetectAbandonedCarts(time.Now())))
+
+	// Erin follows the deep link and buys
+	erinReminder, _ := recovery.GetReminder(erinCart.GetID())
+	erinToken := erinReminder.Token
+	if recoveredCart, err := recovery.OpenRecoveryLink(erinToken); err == nil {
+		fmt.Printf("  Erin reopened %s with %d item(s)\n", recoveredCart.GetID(), recoveredCart.GetItemCount())
+	}
+	if recoveredOrder, err := checkout.Checkout(erin.GetID(), "9 Oak Ave, Denver, CO"); err == nil {
+		fmt.Printf("  Erin placed %s for $%.2f\n", recoveredOrder.GetID(), recoveredOrder.GetTotal())
+	}
+	time.Sleep(50 * time.Millisecond) // Let the order-placed event reach the recovery service
+	if _, err := recovery.OpenRecoveryLink(erinToken); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+
+	metrics := recovery.GetMetrics()
+	fmt.Printf("  Reminded %d cart(s), %d opened, %d recovered ($%.2f), conversion %.0f%%\n",
+		metrics.CartsReminded, metrics.LinksOpened, metrics.CartsRecovered,
+		metrics.RecoveredRevenue, metrics.ConversionRate()*100)
+
+	// =========================================
+	// STEP 10: Wishlist sharing and gift orders
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🎁 Shared wishlists and gift orders...")
+
+	wishlists := NewWishlistService(checkout)
+	birthdayList, _ := wishlists.CreateWishlist(dana.GetID(), "Birthday", "42 Elm St, Austin, TX")
+	birthdayList.AddItem(products[3], 1) // Book
+	birthdayList.AddItem(products[4], 4) // Coffee
+
+	wishlistLink, _ := wishlists.ShareWishlist(dana.GetID(), birthdayList.GetID())
+	fmt.Printf("  Dana shared %s\n", wishlistLink.URL())
+
+	if _, err := wishlists.PlaceGiftOrder(wishlistLink.Token, dana.GetID(), map[string]int{"P004": 1}, ""); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+
+	// Erin opens the link: she sees what is left, but not Dana's address
+	shared, _ := wishlists.OpenSharedWishlist(wishlistLink.Token)
+	fmt.Printf("  Erin opens \"%s\" by %s:\n", shared.Name, shared.OwnerName)
+	for _, item := range shared.Items {
+		fmt.Printf("    %s: %d of %d still wanted\n", item.GetProduct().GetName(), item.Remaining(), item.GetDesired())
+	}
+
+	giftOrder, err := wishlists.PlaceGiftOrder(wishlistLink.Token, erin.GetID(),
+		map[string]int{"P004": 1, "P005": 2}, "Happy birthday, Dana!")
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	fmt.Printf("  Erin placed gift %s ($%.2f charged, confirmation to %s)\n",
+		giftOrder.GetID(), giftOrder.GetTotal(), giftOrder.GetContactEmail())
+	fmt.Print(giftOrder.PackingSlip())
+
+	// A second gifter can't duplicate the book, but can buy the remaining coffee
+	frank, _ := checkout.RegisterCustomer("CUST-FRANK", "Frank", "frank@example.com")
+	if _, err := wishlists.PlaceGiftOrder(wishlistLink.Token, frank.GetID(), map[string]int{"P004": 1}, ""); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+	if frankGift, err := wishlists.PlaceGiftOrder(wishlistLink.Token, frank.GetID(), map[string]int{"P005": 2}, "Enjoy!"); err == nil {
+		fmt.Printf("  Frank placed gift %s from wishlist %s\n", frankGift.GetID(), frankGift.GetWishlistID())
+	}
+	for _, item := range birthdayList.GetItems() {
+		fmt.Printf("  Dana's list: %s %d/%d purchased\n", item.GetProduct().GetName(), item.GetPurchased(), item.GetDesired())
+	}
+
+	// Cart sharing: read-only for the recipient, who can copy the items
+	cartLink, _ := wishlists.ShareCart(dana.GetID())
+	if copied, err := wishlists.CopySharedCart(cartLink.Token, frank.GetID()); err == nil {
+		frankCart, _ := checkout.GetCustomerCart(frank.GetID())
+		fmt.Printf("  Frank copied %d product(s) from Dana's cart (%d item(s) now)\n", copied, frankCart.GetItemCount())
+	}
+	if _, err := wishlists.PlaceGiftOrder(cartLink.Token, frank.GetID(), map[string]int{"P005": 1}, ""); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+	_ = wishlists.RevokeShare(dana.GetID(), cartLink.Token)
+	if _, err := wishlists.OpenSharedCart(cartLink.Token); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+
+	// =========================================
+	// STEP 11: Invoices (text, HTML, PDF) by email
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🧾 Rendering and emailing invoices...")
+
+	// The order from STEP 4 has no contact email, so it can only be printed
+	if err := SendInvoice(context.Background(), emailChannel, order); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+
+	_ = guestOrder.SetShipping("Express", 12.50)
+	guestOrder.PrintOrder()
+
+	for _, renderer := range []InvoiceRenderer{&HTMLInvoiceRenderer{}, &PDFInvoiceRenderer{}} {
+		attachment, err := RenderInvoiceAttachment(guestOrder, renderer)
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			continue
+		}
+		path := "/tmp/" + attachment.Filename
+		if err := os.WriteFile(path, attachment.Data, 0o644); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			continue
+		}
+		fmt.Printf("  Wrote %s (%d bytes)\n", path, len(attachment.Data))
+	}
+	if err := SendInvoice(context.Background(), emailChannel, guestOrder); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	// =========================================
+	// STEP 12: Multi-currency pricing and checkout
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("💱 Shopping in another currency...")
+
+	rates := NewStaticExchangeRates()
+	_ = rates.SetRate(money.EUR, 1.08)
+	_ = rates.SetRate(money.GBP, 1.27)
+	teaTin := NewProductInCurrency("P101", "Earl Grey Tin", 8.00, money.GBP, CategoryGrocery, 40)
+	woolScarf := NewProductInCurrency("P102", "Merino Wool Scarf", 24.00, money.EUR, CategoryClothing, 15)
+
+	travelCart := NewCart("USER004")
+	if err := travelCart.AddItem(teaTin, 1); err != nil {
+		fmt.Printf("  ❌ Expected (no rates yet): %v\n", err)
+	}
+	if err := travelCart.SetCurrency(money.EUR, rates); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+	_ = travelCart.AddItem(teaTin, 2)
+	_ = travelCart.AddItem(woolScarf, 1)
+	_ = travelCart.AddItem(products[3], 1)
+	for _, product := range []*Product{teaTin, woolScarf, products[3]} {
+		if price, err := travelCart.DisplayPrice(product); err == nil {
+			fmt.Printf("  %-20s listed %-9s shown %s\n", product.GetName(),
+				money.Money{Amount: product.GetPrice(), Currency: product.GetCurrency()}, price)
+		}
+	}
+
+	// Rates move after the cart quoted them; the shopper's prices do not
+	_ = rates.SetRate(money.GBP, 1.35)
+	travelCart.PrintCart()
+
+	euroOrder, err := NewOrderFromCart(travelCart, "7 Rue de Rivoli, Paris")
+	if err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	} else {
+		fmt.Printf("  Order %s charged %s\n", euroOrder.GetID(), euroOrder.GetCurrency().Format(euroOrder.GetTotal()))
+		for _, productID := range []string{"P101", "P102", "P004"} {
+			original, _ := euroOrder.GetOriginalUnitPrice(productID)
+			charged, _ := euroOrder.GetChargedUnitPrice(productID)
+			rate, _ := euroOrder.GetExchangeRate(original.Currency)
+			fmt.Printf("    %s: %s → %s (rate %.4f)\n", productID, original, charged, rate)
+		}
+		originals := euroOrder.GetOriginalSubtotals()
+		fmt.Printf("  Original subtotals: %s + %s + %s\n",
+			money.GBP.Format(originals[money.GBP]), money.EUR.Format(originals[money.EUR]),
+			money.USD.Format(originals[money.USD]))
+	}
+
+	// =========================================
+	// STEP 13: Tiered pricing with B2B price lists
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🏢 Pricing for business customers...")
+
+	wholesaleList := NewPriceList(PriceTierWholesale, "Wholesale")
+	_ = wholesaleList.SetPrice(products[2], 18.00) // T-Shirts
+	_ = wholesaleList.SetPrice(products[4], 11.50) // Coffee
+	vipList := NewPriceList(PriceTierVIP, "VIP")
+	_ = vipList.SetPrice(products[0], 949.00) // iPhone
+	for _, list := range []*PriceList{wholesaleList, vipList} {
+		if err := checkout.RegisterPriceList(list); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+		}
+	}
+	if err := checkout.RegisterPriceList(NewPriceList(PriceTierRetail, "Retail")); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+
+	cafe, _ := checkout.RegisterCustomer("CUST-CAFE", "Corner Cafe Ltd", "buying@cornercafe.example")
+	if err := checkout.AssignPriceTier(cafe.GetID(), "platinum"); err != nil {
+		fmt.Printf("  ❌ Expected: %v\n", err)
+	}
+	cafeCart, _ := checkout.GetCustomerCart(cafe.GetID())
+	_ = cafeCart.AddItem(products[4], 20) // Coffee
+	_ = cafeCart.AddItem(products[2], 10) // T-Shirts
+	_ = cafeCart.AddItem(products[3], 2)  // Book (not on the wholesale list)
+	fmt.Printf("  %s at %s: subtotal $%.2f\n", cafe.GetName(), cafeCart.GetPriceTier(), cafeCart.GetSubtotal())
+
+	// Assigning the tier reprices the open cart
+	_ = checkout.AssignPriceTier(cafe.GetID(), PriceTierWholesale)
+	fmt.Printf("  %s at %s: subtotal $%.2f\n", cafe.GetName(), cafeCart.GetPriceTier(), cafeCart.GetSubtotal())
+	for _, product := range []*Product{products[4], products[2], products[3]} {
+		fmt.Printf("    %-20s base $%-8.2f wholesale $%.2f\n",
+			product.GetName(), product.GetPrice(), wholesaleList.GetPrice(product))
+	}
+
+	wholesaleOrder, err := checkout.Checkout(cafe.GetID(), "12 Market Street, Springfield")
+	if err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	} else {
+		fmt.Printf("  Order %s priced with %s, total $%.2f\n",
+			wholesaleOrder.GetID(), wholesaleOrder.GetPriceTier(), wholesaleOrder.GetTotal())
+		for _, product := range []*Product{products[4], products[2], products[3]} {
+			source, _ := wholesaleOrder.GetPriceSource(product.GetID())
+			charged, _ := wholesaleOrder.GetChargedUnitPrice(product.GetID())
+			fmt.Printf("    %s: %s from %s price list\n", product.GetID(), charged, source)
+		}
+	}
+
+	// The next cart keeps the tier; guests always see retail prices
+	nextCafeCart, _ := checkout.GetCustomerCart(cafe.GetID())
+	browsingSession, _ := checkout.StartGuestSession()
+	browsingCart, _ := checkout.GetGuestCart(browsingSession.GetToken())
+	fmt.Printf("  New cart tier: %s, guest cart tier: %s\n", nextCafeCart.GetPriceTier(), browsingCart.GetPriceTier())
+
+	// =========================================
+	// STEP 14: Scheduled promotions (flash and sitewide sales)
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔥 Scheduled promotions...")
+
+	saleDay := time.Date(2024, time.November, 29, 0, 0, 0, 0, time.UTC)
+	flashSale, _ := NewCategoryPromotion("Electronics Flash Sale", CategoryElectronics, 20,
+		saleDay.Add(9*time.Hour), saleDay.Add(12*time.Hour))
+	sitewideSale, _ := NewSitewidePromotion("Black Friday", 10, saleDay, saleDay.Add(24*time.Hour))
+	if _, err := NewSitewidePromotion("Backwards", 15, saleDay, saleDay.Add(-time.Hour)); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	promotions := NewPromotionEngine()
+	promotions.Schedule(flashSale)
+	promotions.Schedule(sitewideSale)
+	checkout.AttachPromotionEngine(promotions)
+
+	shopper, _ := checkout.RegisterCustomer("CUST-GRACE", "Grace", "grace@example.com")
+	shopperCart, _ := checkout.GetCustomerCart(shopper.GetID())
+	shopperCart.AddItem(products[1], 1) // MacBook: flash sale beats sitewide
+	shopperCart.AddItem(products[2], 2) // T-shirts: sitewide only
+
+	// The scheduler job flips promotions as their windows open and close
+	for _, hour := range []int{8, 10, 13, 24} {
+		for _, change := range promotions.RefreshPromotions(saleDay.Add(time.Duration(hour) * time.Hour)) {
+			fmt.Printf("  %02d:00 %s\n", hour, change)
+		}
+		if hour == 10 {
+			shopperCart.PrintCart()
+			promoOrder, err := checkout.Checkout(shopper.GetID(), "7 Elm Street, Portland")
+			if err != nil {
+				fmt.Printf("  ❌ %v\n", err)
+				continue
+			}
+			fmt.Printf("  Order %s: MacBook via %s, total $%.2f\n",
+				promoOrder.GetID(), promoOrder.GetPromotionID(products[1].GetID()), promoOrder.GetTotal())
+		}
+	}
+	for _, report := range promotions.GetReports() {
+		fmt.Printf("  %s %s: %d order(s), %d unit(s), revenue $%.2f, discount given $%.2f (active: %v)\n",
+			report.PromotionID, report.Name, report.Orders, report.UnitsSold,
+			report.Revenue[BaseCurrency], report.DiscountGiven[BaseCurrency], report.Active)
+	}
+
+	// The background job does the same refresh on a timer
+	cyberHour, _ := NewSitewidePromotion("Cyber Hour", 5, time.Now(), time.Now().Add(time.Hour))
+	promotions.Schedule(cyberHour)
+	promotions.StartScheduler(10 * time.Millisecond)
+	fmt.Printf("  Scheduler running: %d active promotion(s)\n", len(promotions.GetActivePromotions()))
+	promotions.StopScheduler()
+
+	// =========================================
+	// SUMMARY: Key Design Decisions
+	// =========================================
+	fmt.Println("\n═══════════════════════════════════════════")
+	fmt.Println("  KEY DESIGN DECISIONS:")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  1. Strategy Pattern for flexible discount types")
+	fmt.Println("  2. Category-based tax rates (18%, 12%, 5%, 0%)")
+	fmt.Println("  3. All-or-nothing stock reservation under per-product locks")
+	fmt.Println("  4. Factory Pattern: Cart → Order conversion")
+	fmt.Println("  5. Thread-safe operations using mutex locks")
+	fmt.Println("  6. Clear separation of entities and logic")
+	fmt.Println("  7. Observer via pub-sub: product events drive customer alerts")
+	fmt.Println("  8. Guest carts/orders keyed by session token, merged on sign-up")
+	fmt.Println("  9. Idle-cart detector sends one reminder per idle period; orders attribute recovery")
+	fmt.Println("  10. Token share links; gift orders mark wishlist items purchased")
+	fmt.Println("  11. Invoice renderers (Strategy): text receipt, HTML email, PDF attachment")
+	fmt.Println("  12. Exchange-rate provider (Strategy); carts lock quoted rates, orders keep both currencies")
+	fmt.Println("  13. Per-customer price tiers fall back to base prices; orders record the list used")
+	fmt.Println("  14. Scheduler-driven promotions: best active sale prices items, reported per promotion")
+	fmt.Println("═══════════════════════════════════════════")
+}
@@ -0,0 +1,3303 @@
+// Package shoppingcart runs an online store: products, carts, discounts and
+// coupons, taxes, and checkout into orders.
+package shoppingcart
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	notification "github.com/ayushgupta5/GoLLD/18_notification_system"
+	pubsub "github.com/ayushgupta5/GoLLD/19_pubsub"
+	"github.com/ayushgupta5/GoLLD/pkg/entity"
+	"github.com/ayushgupta5/GoLLD/pkg/idgen"
+	"github.com/ayushgupta5/GoLLD/pkg/money"
+	"github.com/ayushgupta5/GoLLD/pkg/periodic"
+)
+
+// ============================================================================
+// SHOPPING CART SYSTEM - Low Level DESIGN
+// ============================================================================
+//
+// This system demonstrates:
+// - Strategy Pattern: Different discount strategies (percentage, flat, buy-x-get-y)
+// - Factory Pattern: Creating orders from carts
+// - Entity Modeling: Products, Cart Items, Shopping Cart, Orders
+// - Thread-safe operations using mutex locks
+// - Category-based tax calculation
+// - Observer Pattern: Price-drop and back-in-stock watchers via pub-sub
+// - Guest checkout by session token, merged into a registered account later
+// - Abandoned cart detection with reminder deep links and recovery metrics
+// - Shareable wishlists/carts and gift orders with price-free packing slips
+// - Invoice renderers (text, HTML, PDF) with per-category tax, emailed as attachments
+// - Multi-currency pricing: exchange-rate providers, cart currency, original vs charged amounts
+// - Tiered B2B pricing: retail/wholesale/VIP price lists per customer, recorded on orders
+// - Scheduled promotions: timed category/sitewide sales applied without codes, reported per promotion
+//
+// ============================================================================
+
+// ============================================================================
+// SECTION 1: PRODUCT CATEGORY ENUM
+// ============================================================================
+
+// ProductCategory represents different types of products with associated tax rates.
+// Using iota for auto-incrementing values (Electronics=0, Clothing=1, etc.)
+type ProductCategory int
+
+const (
+	CategoryElectronics ProductCategory = iota // 0 - Electronics (18% tax)
+	CategoryClothing                           // 1 - Clothing (12% tax)
+	CategoryBooks                              // 2 - Books (0% tax - exempt)
+	CategoryGrocery                            // 3 - Grocery (5% tax)
+)
+
+// String returns a human-readable name for the product category.
+func (category ProductCategory) String() string {
+	names := [...]string{"Electronics", "Clothing", "Books", "Grocery"}
+	if int(category) < len(names) {
+		return names[category]
+	}
+	return "Unknown"
+}
+
+// TaxRate returns the tax rate for each product category.
+// Different categories have different tax rates based on regulations.
+func (category ProductCategory) TaxRate() float64 {
+	taxRates := map[ProductCategory]float64{
+		CategoryElectronics: 0.18, // 18% tax on electronics
+		CategoryClothing:    0.12, // 12% tax on clothing
+		CategoryBooks:       0.0,  // Books are tax-exempt
+		CategoryGrocery:     0.05, // 5% tax on groceries
+	}
+
+	if rate, exists := taxRates[category]; exists {
+		return rate
+	}
+	return 0.0
+}
+
+// ============================================================================
+// SECTION 2: PRODUCT ENTITY
+// ============================================================================
+
+// Product represents an item available for purchase in the store.
+type Product struct {
+	id          string                // Unique identifier (e.g., "P001")
+	name        string                // Display name (e.g., "iPhone 15 Pro")
+	description string                // Detailed description of the product
+	price       float64               // Price per unit, in currency
+	currency    money.Currency        // Currency the price is listed in
+	category    ProductCategory       // Category for tax calculation
+	stockCount  int                   // Number of units available
+	version     uint64                // Incremented on every stock change
+	events      *pubsub.MessageBroker // Where price/stock events are published (nil = none)
+	mutex       sync.Mutex            // Protects concurrent access to stock and price
+}
+
+// ErrStockVersionConflict is returned when a product's stock changed between
+// reading it and trying to update it.
+var ErrStockVersionConflict = errors.New("stock was modified concurrently")
+
+// NewProduct creates and initializes a new Product priced in BaseCurrency.
+func NewProduct(id, name string, price float64, category ProductCategory, initialStock int) *Product {
+	return NewProductInCurrency(id, name, price, BaseCurrency, category, initialStock)
+}
+
+// NewProductInCurrency creates a Product whose price is listed in currency.
+func NewProductInCurrency(id, name string, price float64, currency money.Currency, category ProductCategory, initialStock int) *Product {
+	return &Product{
+		id:          id,
+		name:        name,
+		description: "",
+		price:       price,
+		currency:    currency,
+		category:    category,
+		stockCount:  initialStock,
+	}
+}
+
+// Getter methods for Product fields
+func (product *Product) GetID() string                { return product.id }
+func (product *Product) GetName() string              { return product.name }
+func (product *Product) GetCategory() ProductCategory { return product.category }
+func (product *Product) GetCurrency() money.Currency  { return product.currency }
+
+// GetPrice returns the current unit price (thread-safe).
+func (product *Product) GetPrice() float64 {
+	product.mutex.Lock()
+	defer product.mutex.Unlock()
+	return product.price
+}
+
+// AttachEventBroker makes the product publish price and stock events to
+// broker, creating their topics if needed.
+func (product *Product) AttachEventBroker(broker *pubsub.MessageBroker) {
+	broker.CreateTopic(TopicPriceDropped)
+	broker.CreateTopic(TopicBackInStock)
+
+	product.mutex.Lock()
+	defer product.mutex.Unlock()
+	product.events = broker
+}
+
+// SetPrice changes the unit price. A lower price publishes a price-drop event.
+func (product *Product) SetPrice(newPrice float64) error {
+	if newPrice <= 0 {
+		return fmt.Errorf("price must be positive")
+	}
+
+	product.mutex.Lock()
+	oldPrice := product.price
+	product.price = newPrice
+	broker := product.events
+	product.mutex.Unlock()
+
+	// Publish outside the lock so subscribers can read the product
+	if broker != nil && newPrice < oldPrice {
+		publishEvent(broker, TopicPriceDropped, ProductEvent{
+			ProductID: product.id, ProductName: product.name,
+			OldPrice: oldPrice, NewPrice: newPrice,
+		})
+	}
+	return nil
+}
+
+// GetStock returns the current stock count (thread-safe).
+func (product *Product) GetStock() int {
+	product.mutex.Lock()
+	defer product.mutex.Unlock()
+	return product.stockCount
+}
+
+// GetStockAndVersion returns the stock count together with its version,
+// so a caller can later update stock only if nothing changed in between.
+func (product *Product) GetStockAndVersion() (int, uint64) {
+	product.mutex.Lock()
+	defer product.mutex.Unlock()
+	return product.stockCount, product.version
+}
+
+// GetVersion returns the current stock version (thread-safe).
+func (product *Product) GetVersion() uint64 {
+	product.mutex.Lock()
+	defer product.mutex.Unlock()
+	return product.version
+}
+
+// ReduceStock decreases the stock count by the specified quantity.
+// Returns an error if there isn't enough stock available.
+func (product *Product) ReduceStock(quantity int) error {
+	product.mutex.Lock()
+	defer product.mutex.Unlock()
+
+	if quantity > product.stockCount {
+		return fmt.Errorf("insufficient stock: requested %d, available %d", quantity, product.stockCount)
+	}
+
+	product.stockCount -= quantity
+	product.version++
+	return nil
+}
+
+// ReduceStockIfVersion decreases stock only if the version still matches
+// expectedVersion (compare-and-swap). Returns ErrStockVersionConflict if
+// another checkout changed the stock since it was read.
+func (product *Product) ReduceStockIfVersion(quantity int, expectedVersion uint64) error {
+	product.mutex.Lock()
+	defer product.mutex.Unlock()
+
+	if product.version != expectedVersion {
+		return ErrStockVersionConflict
+	}
+	if quantity > product.stockCount {
+		return fmt.Errorf("insufficient stock: requested %d, available %d", quantity, product.stockCount)
+	}
+
+	product.stockCount -= quantity
+	product.version++
+	return nil
+}
+
+// AddStock increases the stock count by the specified quantity when the
+// product is restocked. Going from zero to positive stock publishes a
+// back-in-stock event.
+func (product *Product) AddStock(quantity int) {
+	product.mutex.Lock()
+	wasOutOfStock := product.stockCount == 0
+	product.stockCount += quantity
+	product.version++
+	newStock := product.stockCount
+	price := product.price
+	broker := product.events
+	product.mutex.Unlock()
+
+	if broker != nil && wasOutOfStock && newStock > 0 {
+		publishEvent(broker, TopicBackInStock, ProductEvent{
+			ProductID: product.id, ProductName: product.name,
+			NewPrice: price, Stock: newStock,
+		})
+	}
+}
+
+// restoreStock gives back units reserved for an order that was then
+// abandoned. It publishes nothing: the units were only briefly held, so
+// watchers must not get a back-in-stock alert for them.
+func (product *Product) restoreStock(quantity int) {
+	product.mutex.Lock()
+	defer product.mutex.Unlock()
+	product.stockCount += quantity
+	product.version++
+}
+
+// ============================================================================
+// SECTION 3: CART ITEM ENTITY
+// ============================================================================
+
+// CartItem represents a product with a specific quantity in a shopping cart.
+type CartItem struct {
+	product    *Product         // Reference to the product
+	quantity   int              // Number of units in the cart
+	priceList  *PriceList       // Tier prices to charge (nil = base price)
+	promotions *PromotionEngine // Automatic discounts (nil = none)
+}
+
+// NewCartItem creates a new CartItem instance.
+func NewCartItem(product *Product, quantity int) *CartItem {
+	return &CartItem{
+		product:  product,
+		quantity: quantity,
+	}
+}
+
+// GetProduct returns the product reference.
+func (item *CartItem) GetProduct() *Product {
+	return item.product
+}
+
+// GetQuantity returns the quantity of this item.
+func (item *CartItem) GetQuantity() int {
+	return item.quantity
+}
+
+// GetUnitPrice returns the price of one unit under the item's price list,
+// after the best active promotion, in the product's currency.
+func (item *CartItem) GetUnitPrice() float64 {
+	price := item.GetListPrice()
+	if promotion := item.GetPromotion(); promotion != nil {
+		return promotion.apply(price)
+	}
+	return price
+}
+
+// GetSubtotal calculates the price for this item (price × quantity), in the
+// product's currency. Tax is NOT included in the subtotal.
+func (item *CartItem) GetSubtotal() float64 {
+	return item.GetUnitPrice() * float64(item.quantity)
+}
+
+// GetTax calculates the tax amount for this item.
+// Tax = Subtotal × Category Tax Rate
+func (item *CartItem) GetTax() float64 {
+	return item.GetSubtotal() * item.product.GetCategory().TaxRate()
+}
+
+// ============================================================================
+// SECTION 4: DISCOUNT STRATEGY PATTERN
+// ============================================================================
+//
+// The Strategy Pattern allows us to define a family of algorithms (discount types),
+// encapsulate each one, and make them interchangeable.
+//
+// Benefits:
+// - Easy to add new discount types without modifying existing code
+// - Each discount type has its own calculation logic
+// - Discounts can be swapped at runtime
+//
+
+// DiscountStrategy is the interface that all discount types must implement.
+type DiscountStrategy interface {
+	// CalculateDiscount computes the discount amount based on the subtotal
+	CalculateDiscount(subtotal float64) float64
+
+	// GetDescription returns a human-readable description of the discount
+	GetDescription() string
+}
+
+// ----------------------------------------------------------------------------
+// Strategy 1: Percentage Discount (e.g., "10% OFF")
+// ----------------------------------------------------------------------------
+
+// PercentageDiscount applies a percentage-based discount.
+// Example: 10% off on a $100 order = $10 discount
+type PercentageDiscount struct {
+	percentage float64 // Discount percentage (e.g., 10 for 10%)
+	couponCode string  // Coupon code that activates this discount
+}
+
+// NewPercentageDiscount creates a new percentage-based discount.
+func NewPercentageDiscount(couponCode string, percentage float64) *PercentageDiscount {
+	return &PercentageDiscount{
+		couponCode: couponCode,
+		percentage: percentage,
+	}
+}
+
+// CalculateDiscount returns the discount amount (subtotal × percentage / 100).
+func (discount *PercentageDiscount) CalculateDiscount(subtotal float64) float64 {
+	return subtotal * discount.percentage / 100
+}
+
+// GetDescription returns a readable description of this discount.
+func (discount *PercentageDiscount) GetDescription() string {
+	return fmt.Sprintf("%.0f%% OFF (Code: %s)", discount.percentage, discount.couponCode)
+}
+
+// ----------------------------------------------------------------------------
+// Strategy 2: Flat Discount (e.g., "$20 OFF")
+// ----------------------------------------------------------------------------
+
+// FlatDiscount applies a fixed amount discount.
+// Example: $20 off on any order (discount can't exceed subtotal)
+type FlatDiscount struct {
+	amount     float64 // Fixed discount amount in dollars
+	couponCode string  // Coupon code that activates this discount
+}
+
+// NewFlatDiscount creates a new flat-amount discount.
+func NewFlatDiscount(couponCode string, amount float64) *FlatDiscount {
+	return &FlatDiscount{
+		couponCode: couponCode,
+		amount:     amount,
+	}
+}
+
+// CalculateDiscount returns the discount amount.
+// The discount cannot exceed the subtotal.
+func (discount *FlatDiscount) CalculateDiscount(subtotal float64) float64 {
+	if discount.amount > subtotal {
+		return subtotal // Don't discount more than the subtotal
+	}
+	return discount.amount
+}
+
+// GetDescription returns a readable description of this discount.
+func (discount *FlatDiscount) GetDescription() string {
+	return fmt.Sprintf("$%.2f OFF (Code: %s)", discount.amount, discount.couponCode)
+}
+
+// ----------------------------------------------------------------------------
+// Strategy 3: Buy X Get Y Free Discount
+// ----------------------------------------------------------------------------
+
+// BuyXGetYDiscount applies a "Buy X Get Y Free" discount for a specific product.
+// Example: Buy 2 get 1 free - customer pays for 2, gets 3
+type BuyXGetYDiscount struct {
+	productID string // Product this discount applies to
+	buyCount  int    // Number of items to buy
+	freeCount int    // Number of free items
+}
+
+// NewBuyXGetYDiscount creates a new buy-x-get-y discount.
+func NewBuyXGetYDiscount(productID string, buyCount, freeCount int) *BuyXGetYDiscount {
+	return &BuyXGetYDiscount{
+		productID: productID,
+		buyCount:  buyCount,
+		freeCount: freeCount,
+	}
+}
+
+// CalculateDiscount returns the discount amount.
+// Note: This is a simplified implementation. A full implementation would
+// need access to the cart items to calculate the actual discount.
+func (discount *BuyXGetYDiscount) CalculateDiscount(subtotal float64) float64 {
+	// In a full implementation, this would:
+	// 1. Find the product in the cart
+	// 2. Calculate how many free items the customer gets
+	// 3. Return the value of those free items
+	return 0
+}
+
+// GetDescription returns a readable description of this discount.
+func (discount *BuyXGetYDiscount) GetDescription() string {
+	return fmt.Sprintf("Buy %d Get %d Free", discount.buyCount, discount.freeCount)
+}
+
+// ============================================================================
+// SECTION 5: SHOPPING CART
+// ============================================================================
+
+// cartIDGen generates unique IDs for shopping carts.
+var cartIDGen = idgen.NewSequence("CART")
+
+// Cart represents a shopping cart that holds items before checkout.
+type Cart struct {
+	id              string                     // Unique cart identifier
+	userID          string                     // ID of the user who owns this cart
+	items           map[string]*CartItem       // Map of productID -> CartItem
+	appliedDiscount DiscountStrategy           // Currently applied discount (can be nil)
+	lastActivity    time.Time                  // Last time the shopper changed the cart
+	currency        money.Currency             // Currency totals are shown and charged in
+	rateProvider    ExchangeRateProvider       // Source of exchange rates (nil = single currency)
+	quotedRates     map[money.Currency]float64 // Product currency -> cart currency, locked in when first needed
+	priceList       *PriceList                 // Owner's tier prices (nil = retail base prices)
+	promotions      *PromotionEngine           // Automatic discounts (nil = none)
+	mutex           sync.Mutex                 // Protects concurrent access to cart
+}
+
+// NewCart creates a new empty shopping cart for a user.
+func NewCart(userID string) *Cart {
+	return &Cart{
+		id:              cartIDGen.NextID(),
+		userID:          userID,
+		items:           make(map[string]*CartItem),
+		appliedDiscount: nil,
+		lastActivity:    time.Now(),
+		currency:        BaseCurrency,
+		quotedRates:     make(map[money.Currency]float64),
+	}
+}
+
+// GetID returns the cart's unique identifier.
+func (cart *Cart) GetID() string {
+	return cart.id
+}
+
+// GetLastActivity returns when the shopper last changed the cart.
+func (cart *Cart) GetLastActivity() time.Time {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+	return cart.lastActivity
+}
+
+// AddItem adds a product to the cart with the specified quantity.
+// If the product already exists in the cart, the quantity is increased.
+func (cart *Cart) AddItem(product *Product, quantity int) error {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	// Check if enough stock is available
+	if product.GetStock() < quantity {
+		return fmt.Errorf("insufficient stock for '%s': requested %d, available %d",
+			product.GetName(), quantity, product.GetStock())
+	}
+
+	// The cart must be able to price the product in its own currency
+	if _, err := cart.quoteRateLocked(product.GetCurrency()); err != nil {
+		return fmt.Errorf("cannot add '%s': %w", product.GetName(), err)
+	}
+
+	// If product already in cart, increase quantity; otherwise, add new item
+	if existingItem, exists := cart.items[product.GetID()]; exists {
+		existingItem.quantity += quantity
+	} else {
+		cart.items[product.GetID()] = cart.newItemLocked(product, quantity)
+	}
+	cart.lastActivity = time.Now()
+
+	fmt.Printf("  ✅ Added %d x %s to cart\n", quantity, product.GetName())
+	return nil
+}
+
+// RemoveItem removes a product from the cart completely.
+func (cart *Cart) RemoveItem(productID string) {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+	delete(cart.items, productID)
+	cart.lastActivity = time.Now()
+}
+
+// UpdateQuantity changes the quantity of a product in the cart.
+// If quantity is 0 or negative, the item is removed from the cart.
+func (cart *Cart) UpdateQuantity(productID string, newQuantity int) error {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	item, exists := cart.items[productID]
+	if !exists {
+		return fmt.Errorf("product '%s' is not in the cart", productID)
+	}
+
+	cart.lastActivity = time.Now()
+
+	// Remove item if quantity is zero or negative
+	if newQuantity <= 0 {
+		delete(cart.items, productID)
+		return nil
+	}
+
+	// Check stock availability
+	if item.product.GetStock() < newQuantity {
+		return fmt.Errorf("insufficient stock: requested %d, available %d",
+			newQuantity, item.product.GetStock())
+	}
+
+	item.quantity = newQuantity
+	return nil
+}
+
+// ApplyDiscount sets a discount strategy on the cart.
+func (cart *Cart) ApplyDiscount(discount DiscountStrategy) {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+	cart.appliedDiscount = discount
+	cart.lastActivity = time.Now()
+	fmt.Printf("  🏷️  Discount applied: %s\n", discount.GetDescription())
+}
+
+// calculateSubtotalInternal computes subtotal in the cart's currency without
+// locking (used internally).
+func (cart *Cart) calculateSubtotalInternal() float64 {
+	var subtotal float64
+	for _, item := range cart.items {
+		subtotal += cart.convertLocked(item.GetSubtotal(), item.product.GetCurrency())
+	}
+	return subtotal
+}
+
+// calculateTaxInternal computes tax in the cart's currency without locking
+// (used internally).
+func (cart *Cart) calculateTaxInternal() float64 {
+	var totalTax float64
+	for _, item := range cart.items {
+		totalTax += cart.convertLocked(item.GetTax(), item.product.GetCurrency())
+	}
+	return totalTax
+}
+
+// GetSubtotal returns the total price of all items before tax and discount,
+// in the cart's currency.
+func (cart *Cart) GetSubtotal() float64 {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+	return cart.calculateSubtotalInternal()
+}
+
+// GetTax returns the total tax amount for all items.
+func (cart *Cart) GetTax() float64 {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+	return cart.calculateTaxInternal()
+}
+
+// GetDiscountDescription describes the applied discount ("" if none).
+func (cart *Cart) GetDiscountDescription() string {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	if cart.appliedDiscount == nil {
+		return ""
+	}
+	return cart.appliedDiscount.GetDescription()
+}
+
+// GetDiscount returns the discount amount based on the applied discount strategy.
+func (cart *Cart) GetDiscount() float64 {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	if cart.appliedDiscount == nil {
+		return 0
+	}
+	subtotal := cart.calculateSubtotalInternal()
+	return cart.appliedDiscount.CalculateDiscount(subtotal)
+}
+
+// GetTotal returns the final amount: Subtotal + Tax - Discount.
+func (cart *Cart) GetTotal() float64 {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	subtotal := cart.calculateSubtotalInternal()
+	tax := cart.calculateTaxInternal()
+
+	var discountAmount float64
+	if cart.appliedDiscount != nil {
+		discountAmount = cart.appliedDiscount.CalculateDiscount(subtotal)
+	}
+
+	return subtotal + tax - discountAmount
+}
+
+// GetItemCount returns the total number of items in the cart.
+func (cart *Cart) GetItemCount() int {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	totalCount := 0
+	for _, item := range cart.items {
+		totalCount += item.quantity
+	}
+	return totalCount
+}
+
+// snapshotItems returns a copy of the cart items, so checkout works on a
+// stable view even if the cart is modified concurrently.
+func (cart *Cart) snapshotItems() []*CartItem {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	items := make([]*CartItem, 0, len(cart.items))
+	for _, item := range cart.items {
+		items = append(items, cart.newItemLocked(item.product, item.quantity))
+	}
+	return items
+}
+
+// absorb moves every item of the other cart into this one, summing quantities
+// for products present in both. Items priced in a currency this cart cannot
+// quote stay behind; everything else leaves the other cart. If this cart has
+// no discount, it takes over the other cart's discount. Returns the number of
+// distinct products moved.
+func (cart *Cart) absorb(other *Cart) int {
+	other.mutex.Lock()
+	defer other.mutex.Unlock()
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	moved := 0
+	for productID, item := range other.items {
+		if _, err := cart.quoteRateLocked(item.product.GetCurrency()); err != nil {
+			continue
+		}
+		moved++
+		delete(other.items, productID)
+		if existingItem, exists := cart.items[productID]; exists {
+			existingItem.quantity += item.quantity
+		} else {
+			cart.items[productID] = cart.newItemLocked(item.product, item.quantity)
+		}
+	}
+	if cart.appliedDiscount == nil {
+		cart.appliedDiscount = other.appliedDiscount
+	}
+	if other.lastActivity.After(cart.lastActivity) {
+		cart.lastActivity = other.lastActivity
+	}
+
+	other.appliedDiscount = nil
+	return moved
+}
+
+// newItemLocked creates an item priced with the cart's price list and
+// promotions. Caller must hold cart.mutex.
+func (cart *Cart) newItemLocked(product *Product, quantity int) *CartItem {
+	item := NewCartItem(product, quantity)
+	item.priceList = cart.priceList
+	item.promotions = cart.promotions
+	return item
+}
+
+// IsEmpty checks if the cart has no items.
+func (cart *Cart) IsEmpty() bool {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+	return len(cart.items) == 0
+}
+
+// PrintCart displays the cart contents in a formatted receipt-style layout.
+func (cart *Cart) PrintCart() {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	fmt.Println("\n╔════════════════════════════════════════════════╗")
+	fmt.Println("║              🛒 SHOPPING CART                  ║")
+	fmt.Println("╠════════════════════════════════════════════════╣")
+
+	if len(cart.items) == 0 {
+		fmt.Println("║  Your cart is empty                            ║")
+	} else {
+		for _, item := range cart.items {
+			itemCurrency := item.product.GetCurrency()
+			fmt.Printf("  %s x%d\n", item.product.GetName(), item.quantity)
+			fmt.Printf("    %s each = %s (Tax: %s)\n",
+				cart.currency.Format(cart.convertLocked(item.GetUnitPrice(), itemCurrency)),
+				cart.currency.Format(cart.convertLocked(item.GetSubtotal(), itemCurrency)),
+				cart.currency.Format(cart.convertLocked(item.GetTax(), itemCurrency)))
+			if promotion := item.GetPromotion(); promotion != nil {
+				fmt.Printf("    🔥 %s (was %s)\n", promotion.GetDescription(),
+					cart.currency.Format(cart.convertLocked(item.GetListPrice(), itemCurrency)))
+			}
+		}
+	}
+
+	// Calculate totals (already holding lock, so use internal methods)
+	subtotal := cart.calculateSubtotalInternal()
+	tax := cart.calculateTaxInternal()
+
+	var discountAmount float64
+	if cart.appliedDiscount != nil {
+		discountAmount = cart.appliedDiscount.CalculateDiscount(subtotal)
+	}
+
+	total := subtotal + tax - discountAmount
+
+	fmt.Println("╠════════════════════════════════════════════════╣")
+	fmt.Printf("  Subtotal: %s\n", cart.currency.Format(subtotal))
+	fmt.Printf("  Tax:      %s\n", cart.currency.Format(tax))
+
+	if cart.appliedDiscount != nil {
+		fmt.Printf("  Discount: -%s (%s)\n", cart.currency.Format(discountAmount), cart.appliedDiscount.GetDescription())
+	}
+
+	fmt.Println("  ────────────────────────────────")
+	fmt.Printf("  TOTAL:    %s\n", cart.currency.Format(total))
+	fmt.Println("╚════════════════════════════════════════════════╝")
+}
+
+// ============================================================================
+// SECTION 6: ORDER STATUS ENUM
+// ============================================================================
+
+// OrderStatus represents the lifecycle state of an order.
+type OrderStatus int
+
+const (
+	OrderStatusPending   OrderStatus = iota // 0 - Order created, awaiting confirmation
+	OrderStatusConfirmed                    // 1 - Order confirmed
+	OrderStatusShipped                      // 2 - Order shipped to customer
+	OrderStatusDelivered                    // 3 - Order delivered to customer
+	OrderStatusCancelled                    // 4 - Order was cancelled
+)
+
+// String returns a human-readable name for the order status.
+func (status OrderStatus) String() string {
+	names := [...]string{"Pending", "Confirmed", "Shipped", "Delivered", "Cancelled"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// ============================================================================
+// SECTION 7: ORDER ENTITY
+// ============================================================================
+
+// orderIDGen generates unique IDs for orders.
+var orderIDGen = idgen.NewSequence("ORD")
+
+// Order represents a confirmed purchase made from a shopping cart.
+type Order struct {
+	id              string                     // Unique order identifier
+	userID          string                     // ID of the user who placed the order
+	items           []*CartItem                // List of items in the order
+	subtotal        float64                    // Total before tax and discount
+	taxAmount       float64                    // Total tax amount
+	discountAmount  float64                    // Discount applied
+	totalAmount     float64                    // Final amount charged
+	status          OrderStatus                // Current status of the order
+	createdAt       time.Time                  // When the order was placed
+	shippingAddress string                     // Delivery address
+	contactEmail    string                     // Where order updates are sent (required for guests)
+	mergedFrom      string                     // Guest owner ID this order was moved from (empty if none)
+	isGift          bool                       // Placed against someone else's wishlist
+	giftMessage     string                     // Printed on the packing slip
+	giftRecipient   string                     // Wishlist owner's name
+	wishlistID      string                     // Wishlist the gift was bought from
+	discountLabel   string                     // Description of the applied discount (empty if none)
+	unitPrices      map[string]float64         // Product ID -> charged price when the order was placed
+	shippingMethod  string                     // e.g. "Standard" (empty if not set)
+	shippingFee     float64                    // Included in totalAmount
+	currency        money.Currency             // Currency every amount above is charged in
+	originalPrices  map[string]money.Money     // Product ID -> list (tier or base) price in the product's own currency
+	exchangeRates   map[money.Currency]float64 // Product currency -> charged currency, as quoted at checkout
+	priceTier       PriceTier                  // Price list the cart was priced with
+	priceSources    map[string]PriceTier       // Product ID -> tier that supplied its price (retail = base fallback)
+	promotions      map[string]string          // Product ID -> promotion that lowered its price (absent if none)
+}
+
+// reserveStock reduces stock for every item or for none of them.
+//
+// Every product in the order is locked, in ID order so two checkouts can't
+// deadlock, and all the stock is checked before any of it is taken. Nothing
+// is taken and then given back, so other checkouts never see stock that is
+// about to return, and a checkout only fails on a real shortage.
+func reserveStock(items []*CartItem) error {
+	quantities := make(map[*Product]int, len(items))
+	products := make([]*Product, 0, len(items))
+	for _, item := range items {
+		if _, seen := quantities[item.product]; !seen {
+			products = append(products, item.product)
+		}
+		quantities[item.product] += item.quantity
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].id < products[j].id })
+
+	for _, product := range products {
+		product.mutex.Lock()
+		defer product.mutex.Unlock()
+	}
+	for _, product := range products {
+		if quantities[product] > product.stockCount {
+			return fmt.Errorf("failed to reserve '%s': insufficient stock: requested %d, available %d",
+				product.name, quantities[product], product.stockCount)
+		}
+	}
+	for _, product := range products {
+		product.stockCount -= quantities[product]
+		product.version++
+	}
+	return nil
+}
+
+// NewOrderFromCart creates a new Order from a shopping cart.
+// This is an example of the Factory Pattern - creating complex objects.
+// Inventory for all items is reserved atomically: either every item is
+// reserved or none is, so concurrent checkouts can never oversell.
+func NewOrderFromCart(cart *Cart, shippingAddress string) (*Order, error) {
+	// Validate cart is not empty
+	if cart.IsEmpty() {
+		return nil, fmt.Errorf("cannot create order: cart is empty")
+	}
+
+	// Calculate totals before creating order (in the cart's currency)
+	currency, rates := cart.snapshotPricing()
+	priceTier := cart.GetPriceTier()
+	subtotal := cart.GetSubtotal()
+	taxAmount := cart.GetTax()
+	discountAmount := cart.GetDiscount()
+	totalAmount := cart.GetTotal()
+
+	// Create the order
+	order := &Order{
+		id:              orderIDGen.NextID(),
+		userID:          cart.userID,
+		items:           make([]*CartItem, 0),
+		subtotal:        subtotal,
+		taxAmount:       taxAmount,
+		discountAmount:  discountAmount,
+		totalAmount:     totalAmount,
+		status:          OrderStatusPending,
+		createdAt:       time.Now(),
+		shippingAddress: shippingAddress,
+		discountLabel:   cart.GetDiscountDescription(),
+		unitPrices:      make(map[string]float64),
+		currency:        currency,
+		originalPrices:  make(map[string]money.Money),
+		exchangeRates:   rates,
+		priceTier:       priceTier,
+		priceSources:    make(map[string]PriceTier),
+		promotions:      make(map[string]string),
+	}
+
+	// Reserve inventory for all items as a single unit
+	items := cart.snapshotItems()
+	if err := reserveStock(items); err != nil {
+		return nil, err
+	}
+	order.items = items
+
+	// Remember what was charged, which price list it came from and which
+	// promotion lowered it, so invoices and audits survive later changes
+	var promotions *PromotionEngine
+	for _, item := range items {
+		unitPrice, source := resolvePrice(item.priceList, item.product)
+		listPrice := money.Money{Amount: unitPrice, Currency: item.product.GetCurrency()}
+		if promotion := item.GetPromotion(); promotion != nil {
+			unitPrice = promotion.apply(unitPrice)
+			order.promotions[item.product.GetID()] = promotion.id
+			promotions = item.promotions
+		}
+		order.originalPrices[item.product.GetID()] = listPrice
+		order.priceSources[item.product.GetID()] = source
+		order.unitPrices[item.product.GetID()] = unitPrice * order.exchangeRate(listPrice.Currency)
+	}
+	if promotions != nil {
+		promotions.recordOrder(order)
+	}
+
+	return order, nil
+}
+
+// Getter methods for Order
+func (order *Order) GetID() string           { return order.id }
+func (order *Order) GetUserID() string       { return order.userID }
+func (order *Order) GetStatus() OrderStatus  { return order.status }
+func (order *Order) GetTotal() float64       { return order.totalAmount }
+func (order *Order) GetContactEmail() string { return order.contactEmail }
+func (order *Order) GetCreatedAt() time.Time { return order.createdAt }
+func (order *Order) GetMergedFrom() string   { return order.mergedFrom }
+func (order *Order) IsGift() bool            { return order.isGift }
+func (order *Order) GetWishlistID() string   { return order.wishlistID }
+
+// SetShipping sets the shipping method and fee, adjusting the order total.
+func (order *Order) SetShipping(method string, fee float64) error {
+	if fee < 0 {
+		return fmt.Errorf("shipping fee cannot be negative: %.2f", fee)
+	}
+	order.totalAmount += fee - order.shippingFee
+	order.shippingMethod = method
+	order.shippingFee = fee
+	return nil
+}
+
+// Confirm changes the order status to Confirmed.
+func (order *Order) Confirm() {
+	order.status = OrderStatusConfirmed
+}
+
+// Ship changes the order status to Shipped.
+func (order *Order) Ship() {
+	order.status = OrderStatusShipped
+}
+
+// Deliver changes the order status to Delivered.
+func (order *Order) Deliver() {
+	order.status = OrderStatusDelivered
+}
+
+// Cancel changes the order status to Cancelled.
+// In a full implementation, this would also restore the inventory.
+func (order *Order) Cancel() {
+	order.status = OrderStatusCancelled
+	// TODO: Restore inventory for cancelled items
+}
+
+// PrintOrder displays the order's invoice as a plain-text receipt.
+func (order *Order) PrintOrder() {
+	text, err := (&TextInvoiceRenderer{}).Render(NewInvoice(order))
+	if err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+		return
+	}
+	fmt.Print("\n" + string(text))
+}
+
+// PackingSlip returns the slip packed in the parcel. Gift orders omit all
+// prices and include the gift message instead.
+func (order *Order) PackingSlip() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("PACKING SLIP - Order %s\n", order.id))
+	if order.isGift {
+		builder.WriteString(fmt.Sprintf("🎁 A gift for %s\n", order.giftRecipient))
+	}
+	for _, item := range order.items {
+		if order.isGift {
+			builder.WriteString(fmt.Sprintf("  • %s x%d\n", item.product.GetName(), item.quantity))
+		} else {
+			amount := order.unitPrices[item.product.GetID()] * float64(item.quantity)
+			builder.WriteString(fmt.Sprintf("  • %s x%d  %s\n", item.product.GetName(), item.quantity, order.currency.Format(amount)))
+		}
+	}
+	if order.isGift {
+		if order.giftMessage != "" {
+			builder.WriteString(fmt.Sprintf("  Message: \"%s\"\n", order.giftMessage))
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("  Total: %s\n", order.currency.Format(order.totalAmount)))
+	}
+	return builder.String()
+}
+
+// ============================================================================
+// SECTION 8: PRODUCT WATCHERS (Price Drop / Back in Stock)
+// ============================================================================
+//
+// Customers can watch a product for a price drop (optionally below a target
+// price) or for it coming back in stock. Product mutations publish events to
+// a pub-sub broker; the WatchService subscribes to those topics and sends an
+// alert through a notification channel. Watches are one-shot: they are
+// removed once the alert is sent, so customers aren't spammed.
+//
+// Events go through 19_pubsub's MessageBroker and alerts through an
+// 18_notification_system channel. The broker delivers asynchronously, so
+// watchers must not assume an alert has gone out when a setter returns.
+//
+// ============================================================================
+
+// Topics published by products.
+const (
+	TopicPriceDropped = "product.price_dropped"
+	TopicBackInStock  = "product.back_in_stock"
+)
+
+// ProductEvent is the payload published on price and stock changes.
+type ProductEvent struct {
+	ProductID   string
+	ProductName string
+	OldPrice    float64 // Previous price (price-drop events only)
+	NewPrice    float64 // Current price
+	Stock       int     // Current stock (back-in-stock events only)
+}
+
+// publishEvent publishes payload on topic. Events are best effort: a
+// failure is logged, never returned to the caller that changed the product.
+func publishEvent(broker *pubsub.MessageBroker, topic string, payload interface{}) {
+	if _, err := broker.Publish(topic, payload); err != nil {
+		fmt.Printf("  ⚠️  %s event not published: %v\n", topic, err)
+	}
+}
+
+// WatchType is what a customer wants to be alerted about.
+type WatchType int
+
+const (
+	WatchPriceDrop   WatchType = iota // 0 - Alert when the price goes down
+	WatchBackInStock                  // 1 - Alert when an out-of-stock product is restocked
+)
+
+// String returns a human-readable name for the watch type.
+func (watchType WatchType) String() string {
+	names := [...]string{"Price Drop", "Back in Stock"}
+	if int(watchType) < len(names) {
+		return names[watchType]
+	}
+	return "Unknown"
+}
+
+// Watch is one customer's subscription to a product event.
+type Watch struct {
+	CustomerEmail string
+	ProductID     string
+	Type          WatchType
+	TargetPrice   float64 // Price drops only: alert when price <= target (0 = any drop)
+}
+
+// WatchService matches product events to watches and sends alerts.
+type WatchService struct {
+	watches     map[string][]*Watch              // Product ID -> active watches
+	channel     notification.NotificationChannel // How alerts are delivered
+	sendTimeout time.Duration                    // Upper bound per alert
+	mutex       sync.Mutex
+}
+
+// NewWatchService creates the service and subscribes it to product events.
+func NewWatchService(broker *pubsub.MessageBroker, channel notification.NotificationChannel) (*WatchService, error) {
+	service := &WatchService{
+		watches:     make(map[string][]*Watch),
+		channel:     channel,
+		sendTimeout: 5 * time.Second,
+	}
+	for _, topic := range []string{TopicPriceDropped, TopicBackInStock} {
+		broker.CreateTopic(topic)
+		if err := broker.Subscribe(topic, service); err != nil {
+			return nil, err
+		}
+	}
+	return service, nil
+}
+
+// GetID identifies the service as a broker subscriber.
+func (service *WatchService) GetID() string {
+	return "watch-service"
+}
+
+// WatchPriceDrop alerts the customer when the price falls to targetPrice or
+// below. Pass 0 to be alerted on any price drop.
+func (service *WatchService) WatchPriceDrop(customerEmail string, product *Product, targetPrice float64) {
+	service.addWatch(&Watch{
+		CustomerEmail: customerEmail, ProductID: product.GetID(),
+		Type: WatchPriceDrop, TargetPrice: targetPrice,
+	})
+}
+
+// WatchBackInStock alerts the customer when the product is restocked.
+func (service *WatchService) WatchBackInStock(customerEmail string, product *Product) {
+	service.addWatch(&Watch{
+		CustomerEmail: customerEmail, ProductID: product.GetID(),
+		Type: WatchBackInStock,
+	})
+}
+
+// addWatch registers a watch.
+func (service *WatchService) addWatch(watch *Watch) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.watches[watch.ProductID] = append(service.watches[watch.ProductID], watch)
+}
+
+// GetWatchCount returns the number of active watches on a product.
+func (service *WatchService) GetWatchCount(productID string) int {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	return len(service.watches[productID])
+}
+
+// OnMessage handles a product event: fires and removes matching watches.
+func (service *WatchService) OnMessage(msg *pubsub.Message) {
+	event, ok := msg.Payload.(ProductEvent)
+	if !ok {
+		return
+	}
+
+	watchType := WatchPriceDrop
+	if msg.Topic == TopicBackInStock {
+		watchType = WatchBackInStock
+	}
+
+	// Split watches into triggered and remaining under the lock,
+	// then send alerts without holding it
+	service.mutex.Lock()
+	triggered := make([]*Watch, 0)
+	remaining := make([]*Watch, 0)
+	for _, watch := range service.watches[event.ProductID] {
+		if watch.Type == watchType && watch.matches(event) {
+			triggered = append(triggered, watch)
+		} else {
+			remaining = append(remaining, watch)
+		}
+	}
+	service.watches[event.ProductID] = remaining
+	service.mutex.Unlock()
+
+	for _, watch := range triggered {
+		service.sendAlert(watch, event)
+	}
+}
+
+// matches checks a watch's own condition against the event.
+func (watch *Watch) matches(event ProductEvent) bool {
+	if watch.Type == WatchPriceDrop && watch.TargetPrice > 0 {
+		return event.NewPrice <= watch.TargetPrice
+	}
+	return true
+}
+
+// sendAlert delivers one alert, bounded by the send timeout.
+func (service *WatchService) sendAlert(watch *Watch, event ProductEvent) {
+	var title, body string
+	switch watch.Type {
+	case WatchPriceDrop:
+		title = fmt.Sprintf("Price drop: %s", event.ProductName)
+		body = fmt.Sprintf("now $%.2f (was $%.2f)", event.NewPrice, event.OldPrice)
+	case WatchBackInStock:
+		title = fmt.Sprintf("Back in stock: %s", event.ProductName)
+		body = fmt.Sprintf("%d available at $%.2f", event.Stock, event.NewPrice)
+	}
+	alert := notification.NewNotification(watch.CustomerEmail, title, body,
+		service.channel.GetType(), notification.PriorityMedium)
+
+	ctx, cancel := context.WithTimeout(context.Background(), service.sendTimeout)
+	defer cancel()
+	if err := service.channel.Send(ctx, alert); err != nil {
+		fmt.Printf("  ❌ Alert to %s failed: %v\n", watch.CustomerEmail, err)
+	}
+}
+
+// ============================================================================
+// SECTION 9: GUEST CHECKOUT & ACCOUNT MERGE
+// ============================================================================
+//
+// Anonymous shoppers get a guest session identified by a random token. The
+// token owns a cart and any orders placed with it; an email address is
+// captured at checkout so the guest still receives order updates.
+//
+// When the shopper later registers, MergeGuestSession re-associates the guest
+// cart and orders with the new Customer. Orders keep their original IDs and
+// timestamps and remember which guest owner they came from, so no history is
+// lost. The token is retired after the merge.
+//
+// ============================================================================
+
+// guestOwnerPrefix marks cart/order owner IDs that belong to guest sessions.
+const guestOwnerPrefix = "GUEST-"
+
+// Customer is a registered shopper account.
+type Customer struct {
+	entity.Person // ID, name and email (no phone collected)
+	createdAt     time.Time
+	priceTier     PriceTier // Price list the customer buys at ("" = retail)
+}
+
+// GuestSession tracks an anonymous shopper between visits.
+type GuestSession struct {
+	token      string
+	createdAt  time.Time
+	email      string // Captured at checkout
+	mergedInto string // Customer ID once merged (session is then retired)
+}
+
+func (session *GuestSession) GetToken() string { return session.token }
+
+// ownerID is the cart/order owner ID used for this guest.
+func (session *GuestSession) ownerID() string {
+	return guestOwnerPrefix + session.token
+}
+
+// MergeResult summarizes what was moved from a guest session into an account.
+type MergeResult struct {
+	CustomerID   string
+	OrdersMoved  int
+	ItemsMerged  int
+	GuestOwnerID string
+}
+
+// CheckoutService owns customers, guest sessions, and the carts and orders
+// belonging to each owner (customer ID or guest owner ID).
+type CheckoutService struct {
+	customers        map[string]*Customer
+	customersByEmail map[string]*Customer
+	sessions         map[string]*GuestSession
+	carts            map[string]*Cart
+	orders           map[string][]*Order
+	priceLists       map[PriceTier]*PriceList
+	promotions       *PromotionEngine      // Optional: automatic discounts for every cart
+	events           *pubsub.MessageBroker // Optional: receives order-placed events
+	mutex            sync.Mutex
+}
+
+// NewCheckoutService creates an empty checkout service.
+func NewCheckoutService() *CheckoutService {
+	return &CheckoutService{
+		customers:        make(map[string]*Customer),
+		customersByEmail: make(map[string]*Customer),
+		sessions:         make(map[string]*GuestSession),
+		carts:            make(map[string]*Cart),
+		orders:           make(map[string][]*Order),
+		priceLists:       make(map[PriceTier]*PriceList),
+	}
+}
+
+// AttachEventBroker makes the service publish order-placed events to
+// broker, creating the topic if needed.
+func (service *CheckoutService) AttachEventBroker(broker *pubsub.MessageBroker) {
+	broker.CreateTopic(TopicOrderPlaced)
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.events = broker
+}
+
+// newSessionToken returns a random, unguessable session token.
+func newSessionToken() (string, error) {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", fmt.Errorf("generate session token: %w", err)
+	}
+	return hex.EncodeToString(buffer), nil
+}
+
+// normalizeEmail trims and lower-cases an email, rejecting obviously invalid ones.
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.Index(email, "@")
+	if at <= 0 || at != strings.LastIndex(email, "@") || !strings.Contains(email[at+1:], ".") {
+		return "", fmt.Errorf("invalid email address %q", email)
+	}
+	return email, nil
+}
+
+// StartGuestSession opens a new guest session and returns it.
+func (service *CheckoutService) StartGuestSession() (*GuestSession, error) {
+	token, err := newSessionToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &GuestSession{token: token, createdAt: time.Now()}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.sessions[token] = session
+	return session, nil
+}
+
+// activeSessionLocked looks up a session that has not been merged yet.
+// Caller must hold service.mutex.
+func (service *CheckoutService) activeSessionLocked(token string) (*GuestSession, error) {
+	session, exists := service.sessions[token]
+	if !exists {
+		return nil, fmt.Errorf("unknown guest session")
+	}
+	if session.mergedInto != "" {
+		return nil, fmt.Errorf("guest session was merged into account %s", session.mergedInto)
+	}
+	return session, nil
+}
+
+// cartLocked returns the owner's cart, creating it on first use.
+// Caller must hold service.mutex.
+func (service *CheckoutService) cartLocked(ownerID string) *Cart {
+	cart, exists := service.carts[ownerID]
+	if !exists {
+		cart = service.newCartLocked(ownerID)
+		service.carts[ownerID] = cart
+	}
+	return cart
+}
+
+// newCartLocked creates an empty cart priced with the owner's price list
+// and the active promotions. Caller must hold service.mutex.
+func (service *CheckoutService) newCartLocked(ownerID string) *Cart {
+	cart := NewCart(ownerID)
+	cart.priceList = service.priceListLocked(ownerID)
+	cart.promotions = service.promotions
+	return cart
+}
+
+// RegisterCustomer creates a customer account. Emails must be unique.
+func (service *CheckoutService) RegisterCustomer(id, name, email string) (*Customer, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if _, exists := service.customers[id]; exists {
+		return nil, fmt.Errorf("customer %s already exists", id)
+	}
+	if _, exists := service.customersByEmail[email]; exists {
+		return nil, fmt.Errorf("email %s is already registered", email)
+	}
+
+	customer := &Customer{Person: entity.NewPerson(id, name, email, ""), createdAt: time.Now()}
+	service.customers[id] = customer
+	service.customersByEmail[email] = customer
+	return customer, nil
+}
+
+// GetCustomer returns a registered customer.
+func (service *CheckoutService) GetCustomer(customerID string) (*Customer, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	customer, exists := service.customers[customerID]
+	if !exists {
+		return nil, fmt.Errorf("customer %s not found", customerID)
+	}
+	return customer, nil
+}
+
+// GetGuestCart returns the cart for an active guest session.
+func (service *CheckoutService) GetGuestCart(token string) (*Cart, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	session, err := service.activeSessionLocked(token)
+	if err != nil {
+		return nil, err
+	}
+	return service.cartLocked(session.ownerID()), nil
+}
+
+// GetCustomerCart returns the cart for a registered customer.
+func (service *CheckoutService) GetCustomerCart(customerID string) (*Cart, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if _, exists := service.customers[customerID]; !exists {
+		return nil, fmt.Errorf("customer %s not found", customerID)
+	}
+	return service.cartLocked(customerID), nil
+}
+
+// checkoutLocked turns the owner's cart into an order and starts a fresh cart.
+// Caller must hold service.mutex.
+func (service *CheckoutService) checkoutLocked(ownerID, email, shippingAddress string) (*Order, error) {
+	cart := service.cartLocked(ownerID)
+	order, err := NewOrderFromCart(cart, shippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	order.contactEmail = email
+	service.orders[ownerID] = append(service.orders[ownerID], order)
+	service.carts[ownerID] = service.newCartLocked(ownerID)
+
+	if service.events != nil {
+		publishEvent(service.events, TopicOrderPlaced, OrderPlacedEvent{
+			OrderID: order.id, OwnerID: ownerID, CartID: cart.id, Total: order.totalAmount,
+		})
+	}
+	return order, nil
+}
+
+// recordOrder adds an order placed outside the owner's cart (e.g. a gift
+// order) to a customer's history, with the customer's email as contact.
+func (service *CheckoutService) recordOrder(customerID string, order *Order) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	customer, exists := service.customers[customerID]
+	if !exists {
+		return fmt.Errorf("customer %s not found", customerID)
+	}
+	order.contactEmail = customer.GetEmail()
+	service.orders[customerID] = append(service.orders[customerID], order)
+	return nil
+}
+
+// cartContact is a non-empty cart together with who can be reminded about it.
+type cartContact struct {
+	cart    *Cart
+	ownerID string
+	email   string
+}
+
+// cartsWithContact returns non-empty carts whose owner has a known email:
+// customers always, guests once they have checked out before. Sorted by
+// cart ID for a stable scan order.
+func (service *CheckoutService) cartsWithContact() []cartContact {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	contacts := make([]cartContact, 0)
+	for ownerID, cart := range service.carts {
+		email := ""
+		if customer, exists := service.customers[ownerID]; exists {
+			email = customer.GetEmail()
+		} else if strings.HasPrefix(ownerID, guestOwnerPrefix) {
+			if session, exists := service.sessions[strings.TrimPrefix(ownerID, guestOwnerPrefix)]; exists && session.mergedInto == "" {
+				email = session.email
+			}
+		}
+		if email != "" && !cart.IsEmpty() {
+			contacts = append(contacts, cartContact{cart: cart, ownerID: ownerID, email: email})
+		}
+	}
+	sort.Slice(contacts, func(i, j int) bool { return contacts[i].cart.id < contacts[j].cart.id })
+	return contacts
+}
+
+// GuestCheckout places an order for a guest. An email address is required so
+// the guest can be reached about the order.
+func (service *CheckoutService) GuestCheckout(token, email, shippingAddress string) (*Order, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("guest checkout requires an email: %w", err)
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	session, err := service.activeSessionLocked(token)
+	if err != nil {
+		return nil, err
+	}
+	order, err := service.checkoutLocked(session.ownerID(), email, shippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	session.email = email
+	return order, nil
+}
+
+// Checkout places an order for a registered customer.
+func (service *CheckoutService) Checkout(customerID, shippingAddress string) (*Order, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	customer, exists := service.customers[customerID]
+	if !exists {
+		return nil, fmt.Errorf("customer %s not found", customerID)
+	}
+	return service.checkoutLocked(customerID, customer.GetEmail(), shippingAddress)
+}
+
+// MergeGuestSession moves a guest session's cart items and orders into a
+// customer account and retires the session token.
+func (service *CheckoutService) MergeGuestSession(token, customerID string) (*MergeResult, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	customer, exists := service.customers[customerID]
+	if !exists {
+		return nil, fmt.Errorf("customer %s not found", customerID)
+	}
+	session, err := service.activeSessionLocked(token)
+	if err != nil {
+		return nil, err
+	}
+
+	guestOwnerID := session.ownerID()
+	result := &MergeResult{CustomerID: customer.GetID(), GuestOwnerID: guestOwnerID}
+
+	// Cart: fold guest items into the customer's cart
+	if guestCart, exists := service.carts[guestOwnerID]; exists {
+		result.ItemsMerged = service.cartLocked(customerID).absorb(guestCart)
+		delete(service.carts, guestOwnerID)
+	}
+
+	// Orders: re-associate, keeping IDs, timestamps and contact emails
+	guestOrders := service.orders[guestOwnerID]
+	for _, order := range guestOrders {
+		order.userID = customerID
+		order.mergedFrom = guestOwnerID
+	}
+	merged := append(service.orders[customerID], guestOrders...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].createdAt.Before(merged[j].createdAt)
+	})
+	service.orders[customerID] = merged
+	delete(service.orders, guestOwnerID)
+	result.OrdersMoved = len(guestOrders)
+
+	session.mergedInto = customerID
+	return result, nil
+}
+
+// FindGuestSessionsByEmail returns active guest sessions that checked out with
+// the given email, so a new account can offer to claim them.
+func (service *CheckoutService) FindGuestSessionsByEmail(email string) []*GuestSession {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	var matches []*GuestSession
+	for _, session := range service.sessions {
+		if session.mergedInto == "" && session.email == email {
+			matches = append(matches, session)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].createdAt.Before(matches[j].createdAt)
+	})
+	return matches
+}
+
+// GetOrders returns the order history of an owner, oldest first.
+func (service *CheckoutService) GetOrders(ownerID string) []*Order {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	orders := make([]*Order, len(service.orders[ownerID]))
+	copy(orders, service.orders[ownerID])
+	return orders
+}
+
+// ============================================================================
+// SECTION 10: ABANDONED CART RECOVERY
+// ============================================================================
+//
+// Every cart change updates the cart's last-activity time. A background
+// detector scans carts periodically; a non-empty cart idle for longer than
+// the threshold is "abandoned", and its owner gets a reminder through the
+// notification channel with a deep link that reopens the cart.
+//
+// Reminders are bounded: a cart is reminded at most once per idle period
+// (new activity re-arms it) and at most MaxRemindersPerCart times overall.
+//
+// Recovery is measured through the order-placed events published by the
+// CheckoutService: an order placed from a reminded cart within the recovery
+// window counts as recovered revenue.
+//
+// ============================================================================
+
+// TopicOrderPlaced is published by the CheckoutService for every order.
+const TopicOrderPlaced = "order.placed"
+
+// OrderPlacedEvent is the payload published when an order is placed.
+type OrderPlacedEvent struct {
+	OrderID string
+	OwnerID string
+	CartID  string // Cart the order was created from
+	Total   float64
+}
+
+const (
+	// DefaultAbandonThreshold is how long a cart must be idle to be abandoned.
+	DefaultAbandonThreshold = 1 * time.Hour
+
+	// MaxRemindersPerCart bounds how often one cart is reminded.
+	MaxRemindersPerCart = 2
+
+	// RecoveryWindow is how long after a reminder an order counts as recovered.
+	RecoveryWindow = 7 * 24 * time.Hour
+
+	// RecoveryLinkBase is the deep link prefix; the recovery token is appended.
+	RecoveryLinkBase = "https://shop.example.com/cart/recover?token="
+)
+
+// CartReminder tracks the reminders sent for one cart.
+type CartReminder struct {
+	CartID      string
+	OwnerID     string
+	Email       string
+	Token       string    // Recovery token embedded in the deep link
+	CartValue   float64   // Cart total when the last reminder was sent
+	Count       int       // Reminders sent so far
+	LastSentAt  time.Time // When the last reminder was sent
+	Opened      bool      // Deep link was opened
+	RecoveredBy string    // Order ID that recovered the cart ("" if not recovered)
+	cart        *Cart
+}
+
+// DeepLink returns the URL included in the reminder.
+func (reminder *CartReminder) DeepLink() string {
+	return RecoveryLinkBase + reminder.Token
+}
+
+// RecoveryMetrics summarizes how well reminders convert.
+type RecoveryMetrics struct {
+	CartsReminded    int
+	RemindersSent    int
+	LinksOpened      int
+	CartsRecovered   int
+	RecoveredRevenue float64
+}
+
+// ConversionRate returns the fraction of reminded carts that became orders.
+func (metrics RecoveryMetrics) ConversionRate() float64 {
+	if metrics.CartsReminded == 0 {
+		return 0
+	}
+	return float64(metrics.CartsRecovered) / float64(metrics.CartsReminded)
+}
+
+// CartRecoveryService detects abandoned carts and sends reminders.
+type CartRecoveryService struct {
+	checkout    *CheckoutService
+	channel     notification.NotificationChannel
+	threshold   time.Duration
+	sendTimeout time.Duration
+	reminders   map[string]*CartReminder // Cart ID -> reminder state
+	byToken     map[string]*CartReminder // Recovery token -> reminder
+	detector    periodic.Job             // Runs DetectAbandonedCarts in the background
+	mutex       sync.Mutex
+}
+
+// NewCartRecoveryService creates the service and subscribes it to order events.
+func NewCartRecoveryService(checkout *CheckoutService, broker *pubsub.MessageBroker, channel notification.NotificationChannel, threshold time.Duration) (*CartRecoveryService, error) {
+	if threshold <= 0 {
+		threshold = DefaultAbandonThreshold
+	}
+	service := &CartRecoveryService{
+		checkout:    checkout,
+		channel:     channel,
+		threshold:   threshold,
+		sendTimeout: 5 * time.Second,
+		reminders:   make(map[string]*CartReminder),
+		byToken:     make(map[string]*CartReminder),
+	}
+	checkout.AttachEventBroker(broker)
+	if err := broker.Subscribe(TopicOrderPlaced, service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// GetID identifies the service as a broker subscriber.
+func (service *CartRecoveryService) GetID() string {
+	return "cart-recovery-service"
+}
+
+// DetectAbandonedCarts reminds the owners of carts idle since before
+// now - threshold. Returns the reminders sent by this scan.
+func (service *CartRecoveryService) DetectAbandonedCarts(now time.Time) []*CartReminder {
+	sent := make([]*CartReminder, 0)
+	for _, contact := range service.checkout.cartsWithContact() {
+		lastActivity := contact.cart.GetLastActivity()
+		if now.Sub(lastActivity) < service.threshold {
+			continue
+		}
+
+		service.mutex.Lock()
+		reminder, exists := service.reminders[contact.cart.id]
+		if exists && (reminder.Count >= MaxRemindersPerCart || reminder.LastSentAt.After(lastActivity) ||
+			reminder.RecoveredBy != "") {
+			service.mutex.Unlock()
+			continue // Already reminded for this idle period, or capped
+		}
+		if !exists {
+			token, err := newSessionToken()
+			if err != nil {
+				service.mutex.Unlock()
+				continue
+			}
+			reminder = &CartReminder{
+				CartID: contact.cart.id, OwnerID: contact.ownerID, Email: contact.email,
+				Token: token, cart: contact.cart,
+			}
+			service.reminders[reminder.CartID] = reminder
+			service.byToken[token] = reminder
+		}
+		reminder.Count++
+		reminder.LastSentAt = now
+		reminder.CartValue = contact.cart.GetTotal()
+		message := notification.NewNotification(reminder.Email, "You left something in your cart",
+			fmt.Sprintf("%d item(s) worth $%.2f are waiting: %s",
+				contact.cart.GetItemCount(), reminder.CartValue, reminder.DeepLink()),
+			service.channel.GetType(), notification.PriorityMedium)
+		service.mutex.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), service.sendTimeout)
+		err := service.channel.Send(ctx, message)
+		cancel()
+		if err != nil {
+			fmt.Printf("  ❌ Reminder to %s failed: %v\n", reminder.Email, err)
+			continue
+		}
+		sent = append(sent, reminder)
+	}
+	return sent
+}
+
+// StartDetector runs DetectAbandonedCarts in the background every interval.
+// Calling it while the detector is already running has no effect.
+func (service *CartRecoveryService) StartDetector(interval time.Duration) {
+	service.detector.Start(interval, func(now time.Time) {
+		service.DetectAbandonedCarts(now)
+	})
+}
+
+// StopDetector stops the background detector and waits for a scan in
+// progress to finish.
+func (service *CartRecoveryService) StopDetector() {
+	service.detector.Stop()
+}
+
+// GetReminder returns a copy of the reminder state for a cart.
+func (service *CartRecoveryService) GetReminder(cartID string) (CartReminder, bool) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	reminder, exists := service.reminders[cartID]
+	if !exists {
+		return CartReminder{}, false
+	}
+	return *reminder, true
+}
+
+// OpenRecoveryLink resolves a deep link token to the cart it reminds about.
+func (service *CartRecoveryService) OpenRecoveryLink(token string) (*Cart, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	reminder, exists := service.byToken[token]
+	if !exists {
+		return nil, fmt.Errorf("unknown or expired recovery link")
+	}
+	if reminder.RecoveredBy != "" {
+		return nil, fmt.Errorf("cart was already checked out in order %s", reminder.RecoveredBy)
+	}
+	reminder.Opened = true
+	return reminder.cart, nil
+}
+
+// OnMessage attributes an order to a reminder sent for the same cart.
+func (service *CartRecoveryService) OnMessage(msg *pubsub.Message) {
+	event, ok := msg.Payload.(OrderPlacedEvent)
+	if !ok {
+		return
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	reminder, exists := service.reminders[event.CartID]
+	if !exists || reminder.RecoveredBy != "" || time.Since(reminder.LastSentAt) > RecoveryWindow {
+		return
+	}
+	reminder.RecoveredBy = event.OrderID
+	reminder.CartValue = event.Total
+}
+
+// GetMetrics returns reminder and recovery counts.
+func (service *CartRecoveryService) GetMetrics() RecoveryMetrics {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	var metrics RecoveryMetrics
+	for _, reminder := range service.reminders {
+		metrics.CartsReminded++
+		metrics.RemindersSent += reminder.Count
+		if reminder.Opened {
+			metrics.LinksOpened++
+		}
+		if reminder.RecoveredBy != "" {
+			metrics.CartsRecovered++
+			metrics.RecoveredRevenue += reminder.CartValue
+		}
+	}
+	return metrics
+}
+
+// ============================================================================
+// SECTION 11: WISHLISTS, SHARE LINKS & GIFT ORDERS
+// ============================================================================
+//
+// Customers keep named wishlists and can share a wishlist or their cart
+// through an unguessable token link. A shared cart is read-only for the
+// recipient, who can copy its items into their own cart.
+//
+// Anyone with a wishlist link can place a gift order against it:
+// - The order ships to the address the wishlist owner set. The gifter never
+//   sees that address.
+// - The packing slip lists items and the gift message but no prices.
+// - Gifted quantities are marked as purchased on the wishlist. A later gift
+//   that would exceed the wished-for quantity is rejected, so two gifters
+//   can't buy the same thing.
+//
+// ============================================================================
+
+// ShareLinkBase is the URL prefix of share links; the token is appended.
+const ShareLinkBase = "https://shop.example.com/shared/"
+
+// ShareKind says what a share link points at.
+type ShareKind int
+
+const (
+	ShareKindCart     ShareKind = iota // 0 - A customer's current cart (read-only)
+	ShareKindWishlist                  // 1 - A wishlist (read-only, giftable)
+)
+
+// String returns a human-readable name for the share kind.
+func (kind ShareKind) String() string {
+	names := [...]string{"Cart", "Wishlist"}
+	if int(kind) < len(names) {
+		return names[kind]
+	}
+	return "Unknown"
+}
+
+// ShareLink grants read access to a cart or wishlist to whoever holds the token.
+type ShareLink struct {
+	Token     string
+	Kind      ShareKind
+	OwnerID   string // Customer who shared
+	TargetID  string // Wishlist ID (empty for carts: the owner's current cart is shown)
+	CreatedAt time.Time
+	revoked   bool
+}
+
+// URL returns the link to send to friends and family.
+func (link *ShareLink) URL() string {
+	return ShareLinkBase + link.Token
+}
+
+// wishlistIDGen generates unique IDs for wishlists.
+var wishlistIDGen = idgen.NewSequence("WL")
+
+// WishlistItem is a product the owner wants, and how many were already gifted.
+type WishlistItem struct {
+	product   *Product
+	desired   int // Quantity the owner wants
+	purchased int // Quantity already bought as gifts
+}
+
+func (item WishlistItem) GetProduct() *Product { return item.product }
+func (item WishlistItem) GetDesired() int      { return item.desired }
+func (item WishlistItem) GetPurchased() int    { return item.purchased }
+
+// Remaining returns how many more units can be gifted.
+func (item WishlistItem) Remaining() int {
+	return item.desired - item.purchased
+}
+
+// Wishlist is a named list of products a customer would like to receive.
+type Wishlist struct {
+	id              string
+	ownerID         string
+	name            string
+	shippingAddress string                   // Where gifts are shipped (never shown to gifters)
+	items           map[string]*WishlistItem // Map of productID -> WishlistItem
+	mutex           sync.Mutex               // Serializes gift orders against this list
+}
+
+// NewWishlist creates an empty wishlist.
+func NewWishlist(ownerID, name, shippingAddress string) *Wishlist {
+	return &Wishlist{
+		id:              wishlistIDGen.NextID(),
+		ownerID:         ownerID,
+		name:            name,
+		shippingAddress: shippingAddress,
+		items:           make(map[string]*WishlistItem),
+	}
+}
+
+func (wishlist *Wishlist) GetID() string      { return wishlist.id }
+func (wishlist *Wishlist) GetOwnerID() string { return wishlist.ownerID }
+func (wishlist *Wishlist) GetName() string    { return wishlist.name }
+
+// AddItem adds a product to the wishlist, increasing the wanted quantity if
+// it is already there.
+func (wishlist *Wishlist) AddItem(product *Product, quantity int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("quantity must be positive")
+	}
+
+	wishlist.mutex.Lock()
+	defer wishlist.mutex.Unlock()
+
+	if item, exists := wishlist.items[product.GetID()]; exists {
+		item.desired += quantity
+		return nil
+	}
+	wishlist.items[product.GetID()] = &WishlistItem{product: product, desired: quantity}
+	return nil
+}
+
+// GetItems returns a snapshot of the wishlist items, sorted by product ID.
+func (wishlist *Wishlist) GetItems() []WishlistItem {
+	wishlist.mutex.Lock()
+	defer wishlist.mutex.Unlock()
+
+	items := make([]WishlistItem, 0, len(wishlist.items))
+	for _, item := range wishlist.items {
+		items = append(items, *item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].product.GetID() < items[j].product.GetID() })
+	return items
+}
+
+// SharedWishlist is what a share link recipient sees: no shipping address.
+type SharedWishlist struct {
+	WishlistID string
+	Name       string
+	OwnerName  string
+	Items      []WishlistItem
+}
+
+// WishlistService owns wishlists and the share links for wishlists and carts.
+type WishlistService struct {
+	checkout  *CheckoutService
+	wishlists map[string]*Wishlist
+	shares    map[string]*ShareLink // Token -> link
+	mutex     sync.Mutex
+}
+
+// NewWishlistService creates a wishlist service for the checkout's customers.
+func NewWishlistService(checkout *CheckoutService) *WishlistService {
+	return &WishlistService{
+		checkout:  checkout,
+		wishlists: make(map[string]*Wishlist),
+		shares:    make(map[string]*ShareLink),
+	}
+}
+
+// CreateWishlist creates a wishlist for a registered customer.
+func (service *WishlistService) CreateWishlist(customerID, name, shippingAddress string) (*Wishlist, error) {
+	if _, err := service.checkout.GetCustomer(customerID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(shippingAddress) == "" {
+		return nil, fmt.Errorf("wishlist needs a shipping address for gifts")
+	}
+
+	wishlist := NewWishlist(customerID, name, shippingAddress)
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.wishlists[wishlist.id] = wishlist
+	return wishlist, nil
+}
+
+// createShareLocked stores a new share link.
+// Caller must hold service.mutex.
+func (service *WishlistService) createShareLocked(kind ShareKind, ownerID, targetID string) (*ShareLink, error) {
+	token, err := newSessionToken()
+	if err != nil {
+		return nil, err
+	}
+	link := &ShareLink{Token: token, Kind: kind, OwnerID: ownerID, TargetID: targetID, CreatedAt: time.Now()}
+	service.shares[token] = link
+	return link, nil
+}
+
+// ShareWishlist creates a share link for one of the customer's wishlists.
+func (service *WishlistService) ShareWishlist(customerID, wishlistID string) (*ShareLink, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	wishlist, exists := service.wishlists[wishlistID]
+	if !exists || wishlist.ownerID != customerID {
+		return nil, fmt.Errorf("wishlist %s not found", wishlistID)
+	}
+	return service.createShareLocked(ShareKindWishlist, customerID, wishlistID)
+}
+
+// ShareCart creates a read-only share link for the customer's current cart.
+func (service *WishlistService) ShareCart(customerID string) (*ShareLink, error) {
+	if _, err := service.checkout.GetCustomer(customerID); err != nil {
+		return nil, err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	return service.createShareLocked(ShareKindCart, customerID, "")
+}
+
+// RevokeShare disables a share link. Only the customer who shared it may revoke it.
+func (service *WishlistService) RevokeShare(customerID, token string) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	link, exists := service.shares[token]
+	if !exists || link.OwnerID != customerID {
+		return fmt.Errorf("share link not found")
+	}
+	link.revoked = true
+	return nil
+}
+
+// resolveShare returns an active share link of the expected kind.
+func (service *WishlistService) resolveShare(token string, kind ShareKind) (*ShareLink, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	link, exists := service.shares[token]
+	if !exists || link.revoked {
+		return nil, fmt.Errorf("unknown or revoked share link")
+	}
+	if link.Kind != kind {
+		return nil, fmt.Errorf("share link is for a %s, not a %s", link.Kind, kind)
+	}
+	return link, nil
+}
+
+// sharedWishlist resolves a wishlist share token to its wishlist.
+func (service *WishlistService) sharedWishlist(token string) (*Wishlist, error) {
+	link, err := service.resolveShare(token, ShareKindWishlist)
+	if err != nil {
+		return nil, err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	return service.wishlists[link.TargetID], nil
+}
+
+// OpenSharedWishlist returns the recipient's view of a shared wishlist.
+func (service *WishlistService) OpenSharedWishlist(token string) (*SharedWishlist, error) {
+	wishlist, err := service.sharedWishlist(token)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := service.checkout.GetCustomer(wishlist.ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return &SharedWishlist{
+		WishlistID: wishlist.id,
+		Name:       wishlist.name,
+		OwnerName:  owner.GetName(),
+		Items:      wishlist.GetItems(),
+	}, nil
+}
+
+// OpenSharedCart returns a snapshot of the shared cart's items.
+func (service *WishlistService) OpenSharedCart(token string) ([]*CartItem, error) {
+	link, err := service.resolveShare(token, ShareKindCart)
+	if err != nil {
+		return nil, err
+	}
+	cart, err := service.checkout.GetCustomerCart(link.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	return cart.snapshotItems(), nil
+}
+
+// CopySharedCart adds the shared cart's items to the customer's own cart.
+// Returns the number of distinct products copied.
+func (service *WishlistService) CopySharedCart(token, customerID string) (int, error) {
+	items, err := service.OpenSharedCart(token)
+	if err != nil {
+		return 0, err
+	}
+	cart, err := service.checkout.GetCustomerCart(customerID)
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range items {
+		if err := cart.AddItem(item.product, item.quantity); err != nil {
+			return 0, err
+		}
+	}
+	return len(items), nil
+}
+
+// PlaceGiftOrder buys items from a shared wishlist for its owner.
+// selections maps product ID -> quantity. The buyer must be a registered
+// customer other than the owner. The order ships to the wishlist's address,
+// and the gifted quantities are marked as purchased.
+func (service *WishlistService) PlaceGiftOrder(token, buyerID string, selections map[string]int, giftMessage string) (*Order, error) {
+	wishlist, err := service.sharedWishlist(token)
+	if err != nil {
+		return nil, err
+	}
+	if buyerID == wishlist.ownerID {
+		return nil, fmt.Errorf("cannot place a gift order on your own wishlist")
+	}
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("gift order has no items")
+	}
+	owner, err := service.checkout.GetCustomer(wishlist.ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold the wishlist lock from the remaining-quantity check until the
+	// purchase is recorded, so concurrent gifters can't both take the last unit
+	wishlist.mutex.Lock()
+	defer wishlist.mutex.Unlock()
+
+	productIDs := make([]string, 0, len(selections))
+	for productID := range selections {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+
+	giftCart := NewCart(buyerID)
+	giftCart.SetPriceList(service.checkout.GetPriceList(buyerID))
+	for _, productID := range productIDs {
+		quantity := selections[productID]
+		item, exists := wishlist.items[productID]
+		if !exists {
+			return nil, fmt.Errorf("product %s is not on wishlist %s", productID, wishlist.id)
+		}
+		if quantity > item.Remaining() {
+			return nil, fmt.Errorf("'%s' already gifted: %d of %d purchased, requested %d",
+				item.product.GetName(), item.purchased, item.desired, quantity)
+		}
+		if err := giftCart.AddItem(item.product, quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := NewOrderFromCart(giftCart, wishlist.shippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	order.isGift = true
+	order.giftMessage = giftMessage
+	order.giftRecipient = owner.GetName()
+	order.wishlistID = wishlist.id
+
+	if err := service.checkout.recordOrder(buyerID, order); err != nil {
+		// Buyer vanished between checks: give the stock back
+		for _, item := range order.items {
+			item.product.restoreStock(item.quantity)
+		}
+		return nil, err
+	}
+	for _, productID := range productIDs {
+		wishlist.items[productID].purchased += selections[productID]
+	}
+	return order, nil
+}
+
+// ============================================================================
+// SECTION 12: INVOICE RENDERING
+// ============================================================================
+//
+// An Invoice is a snapshot of an order laid out for the customer: itemized
+// lines, tax grouped by category, the discount and the shipping charge.
+// InvoiceRenderer is another Strategy: the same Invoice can be rendered as
+// plain text (the console receipt), HTML (the email body) or a minimal PDF
+// (the attachment), and SendInvoice mails the renderings through the
+// notification channel as attachments.
+//
+// Prices come from the order snapshot, so a later price drop never changes
+// an invoice that was already issued.
+
+// InvoiceLine is one itemized row on an invoice.
+type InvoiceLine struct {
+	ProductID   string
+	Description string
+	Category    ProductCategory
+	Quantity    int
+	UnitPrice   float64
+	Amount      float64 // UnitPrice × Quantity, before tax
+	Tax         float64
+}
+
+// InvoiceTaxLine is the tax charged for one product category.
+type InvoiceTaxLine struct {
+	Category ProductCategory
+	Rate     float64
+	Taxable  float64 // Sum of line amounts in this category
+	Tax      float64
+}
+
+// Label describes the tax line, e.g. "Electronics 18%".
+func (line InvoiceTaxLine) Label() string {
+	return fmt.Sprintf("%s %.0f%%", line.Category, line.Rate*100)
+}
+
+// Invoice is everything needed to render a bill for one order.
+type Invoice struct {
+	Number         string
+	OrderID        string
+	Status         OrderStatus
+	IssuedAt       time.Time
+	BillTo         string
+	ShipTo         string
+	Lines          []InvoiceLine
+	Subtotal       float64
+	Taxes          []InvoiceTaxLine // One per category present, in category order
+	TaxTotal       float64
+	DiscountLabel  string // Empty when no discount was applied
+	Discount       float64
+	ShippingMethod string
+	Shipping       float64
+	Total          float64
+	Currency       money.Currency // Every amount on the invoice is in this currency
+}
+
+// NewInvoice builds the invoice for an order.
+func NewInvoice(order *Order) *Invoice {
+	invoice := &Invoice{
+		Number:         "INV-" + strings.TrimPrefix(order.id, "ORD-"),
+		OrderID:        order.id,
+		Status:         order.status,
+		IssuedAt:       order.createdAt,
+		BillTo:         order.contactEmail,
+		ShipTo:         order.shippingAddress,
+		Lines:          make([]InvoiceLine, 0, len(order.items)),
+		Subtotal:       order.subtotal,
+		TaxTotal:       order.taxAmount,
+		DiscountLabel:  order.discountLabel,
+		Discount:       order.discountAmount,
+		ShippingMethod: order.shippingMethod,
+		Shipping:       order.shippingFee,
+		Total:          order.totalAmount,
+		Currency:       order.currency,
+	}
+	if invoice.BillTo == "" {
+		invoice.BillTo = order.userID
+	}
+
+	taxByCategory := make(map[ProductCategory]*InvoiceTaxLine)
+	for _, item := range order.items {
+		product := item.product
+		unitPrice, snapshotted := order.unitPrices[product.GetID()]
+		if !snapshotted {
+			unitPrice = product.GetPrice()
+		}
+		category := product.GetCategory()
+		line := InvoiceLine{
+			ProductID:   product.GetID(),
+			Description: product.GetName(),
+			Category:    category,
+			Quantity:    item.quantity,
+			UnitPrice:   unitPrice,
+			Amount:      unitPrice * float64(item.quantity),
+		}
+		line.Tax = line.Amount * category.TaxRate()
+		invoice.Lines = append(invoice.Lines, line)
+
+		taxLine, exists := taxByCategory[category]
+		if !exists {
+			taxLine = &InvoiceTaxLine{Category: category, Rate: category.TaxRate()}
+			taxByCategory[category] = taxLine
+		}
+		taxLine.Taxable += line.Amount
+		taxLine.Tax += line.Tax
+	}
+
+	sort.Slice(invoice.Lines, func(i, j int) bool {
+		return invoice.Lines[i].ProductID < invoice.Lines[j].ProductID
+	})
+	for _, taxLine := range taxByCategory {
+		invoice.Taxes = append(invoice.Taxes, *taxLine)
+	}
+	sort.Slice(invoice.Taxes, func(i, j int) bool {
+		return invoice.Taxes[i].Category < invoice.Taxes[j].Category
+	})
+	return invoice
+}
+
+// Money formats an amount in the invoice currency, e.g. "€12.50".
+func (invoice *Invoice) Money(amount float64) string {
+	return invoice.Currency.Format(amount)
+}
+
+// ShippingLabel describes the shipping line, e.g. "Shipping (Express)".
+func (invoice *Invoice) ShippingLabel() string {
+	if invoice.ShippingMethod == "" {
+		return "Shipping"
+	}
+	return fmt.Sprintf("Shipping (%s)", invoice.ShippingMethod)
+}
+
+// InvoiceRenderer turns an invoice into a document (Strategy Pattern).
+type InvoiceRenderer interface {
+	Render(invoice *Invoice) ([]byte, error)
+	ContentType() string   // MIME type of the rendered document
+	FileExtension() string // Used to name email attachments
+}
+
+// ----------------------------------------------------------------------------
+// Plain text (console receipt)
+// ----------------------------------------------------------------------------
+
+// TextInvoiceRenderer renders a fixed-width ASCII invoice. It is what
+// PrintOrder shows and what the PDF renderer lays out on the page.
+type TextInvoiceRenderer struct{}
+
+const invoiceTextWidth = 60
+
+// Render produces the fixed-width invoice.
+func (renderer *TextInvoiceRenderer) Render(invoice *Invoice) ([]byte, error) {
+	var builder strings.Builder
+	rule := strings.Repeat("-", invoiceTextWidth) + "\n"
+	amountRow := func(label string, amount string) {
+		builder.WriteString(fmt.Sprintf("%-*s%12s\n", invoiceTextWidth-12, truncateText(label, invoiceTextWidth-13), amount))
+	}
+
+	builder.WriteString(strings.Repeat("=", invoiceTextWidth) + "\n")
+	builder.WriteString(fmt.Sprintf("%-*s%12s\n", invoiceTextWidth-12, "INVOICE "+invoice.Number, invoice.IssuedAt.Format("Jan 02, 2006")))
+	builder.WriteString(fmt.Sprintf("Order %s (%s)\n", invoice.OrderID, invoice.Status))
+	builder.WriteString(fmt.Sprintf("Bill to: %s\n", invoice.BillTo))
+	builder.WriteString(fmt.Sprintf("Ship to: %s\n", invoice.ShipTo))
+	builder.WriteString(rule)
+	builder.WriteString(fmt.Sprintf("%-28s%5s%12s%15s\n", "Item", "Qty", "Unit", "Amount"))
+	for _, line := range invoice.Lines {
+		builder.WriteString(fmt.Sprintf("%-28s%5d%12.2f%15.2f\n",
+			truncateText(line.Description, 27), line.Quantity, line.UnitPrice, line.Amount))
+	}
+	builder.WriteString(rule)
+
+	amountRow("Subtotal", fmt.Sprintf("%.2f", invoice.Subtotal))
+	if invoice.Discount > 0 {
+		amountRow("Discount: "+invoice.DiscountLabel, fmt.Sprintf("-%.2f", invoice.Discount))
+	}
+	for _, taxLine := range invoice.Taxes {
+		amountRow(fmt.Sprintf("Tax %s on %.2f", taxLine.Label(), taxLine.Taxable), fmt.Sprintf("%.2f", taxLine.Tax))
+	}
+	if invoice.Shipping > 0 {
+		amountRow(invoice.ShippingLabel(), fmt.Sprintf("%.2f", invoice.Shipping))
+	} else {
+		amountRow(invoice.ShippingLabel(), "Free")
+	}
+	builder.WriteString(rule)
+	amountRow("TOTAL", invoice.Money(invoice.Total))
+	builder.WriteString(strings.Repeat("=", invoiceTextWidth) + "\n")
+	return []byte(builder.String()), nil
+}
+
+// ContentType returns the MIME type of the text invoice.
+func (renderer *TextInvoiceRenderer) ContentType() string { return "text/plain; charset=utf-8" }
+
+// FileExtension returns the attachment extension.
+func (renderer *TextInvoiceRenderer) FileExtension() string { return "txt" }
+
+// truncateText shortens text to at most width runes, marking the cut with "~".
+func truncateText(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "~"
+}
+
+// ----------------------------------------------------------------------------
+// HTML (email body)
+// ----------------------------------------------------------------------------
+
+// HTMLInvoiceRenderer renders a self-contained HTML page with inline styles,
+// since most email clients ignore external stylesheets. Product names and
+// addresses are escaped by html/template.
+type HTMLInvoiceRenderer struct{}
+
+var invoiceHTMLTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"date": func(moment time.Time) string { return moment.Format("Jan 02, 2006") },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Invoice {{.Number}}</title></head>
+<body style="font-family: Helvetica, Arial, sans-serif; color: #222;">
+<h1 style="margin-bottom: 0;">Invoice {{.Number}}</h1>
+<p>Order {{.OrderID}} ({{.Status}}) &middot; {{date .IssuedAt}}</p>
+<p><strong>Bill to:</strong> {{.BillTo}}<br><strong>Ship to:</strong> {{.ShipTo}}</p>
+<table style="border-collapse: collapse; width: 100%;">
+<thead><tr style="border-bottom: 2px solid #222;"><th align="left">Item</th><th align="left">Category</th><th align="right">Qty</th><th align="right">Unit</th><th align="right">Amount</th></tr></thead>
+<tbody>
+{{- range .Lines}}
+<tr style="border-bottom: 1px solid #ddd;"><td>{{.Description}}</td><td>{{.Category}}</td><td align="right">{{.Quantity}}</td><td align="right">{{$.Money .UnitPrice}}</td><td align="right">{{$.Money .Amount}}</td></tr>
+{{- end}}
+</tbody>
+<tfoot>
+<tr><td colspan="4" align="right">Subtotal</td><td align="right">{{$.Money .Subtotal}}</td></tr>
+{{- if gt .Discount 0.0}}
+<tr><td colspan="4" align="right">Discount: {{.DiscountLabel}}</td><td align="right">-{{$.Money .Discount}}</td></tr>
+{{- end}}
+{{- range .Taxes}}
+<tr><td colspan="4" align="right">Tax {{.Label}} on {{$.Money .Taxable}}</td><td align="right">{{$.Money .Tax}}</td></tr>
+{{- end}}
+<tr><td colspan="4" align="right">{{.ShippingLabel}}</td><td align="right">{{if gt .Shipping 0.0}}{{$.Money .Shipping}}{{else}}Free{{end}}</td></tr>
+<tr style="border-top: 2px solid #222;"><td colspan="4" align="right"><strong>Total</strong></td><td align="right"><strong>{{$.Money .Total}}</strong></td></tr>
+</tfoot>
+</table>
+</body>
+</html>
+`))
+
+// Render produces the HTML invoice.
+func (renderer *HTMLInvoiceRenderer) Render(invoice *Invoice) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := invoiceHTMLTemplate.Execute(&buffer, invoice); err != nil {
+		return nil, fmt.Errorf("render invoice %s as HTML: %w", invoice.Number, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// ContentType returns the MIME type of the HTML invoice.
+func (renderer *HTMLInvoiceRenderer) ContentType() string { return "text/html; charset=utf-8" }
+
+// FileExtension returns the attachment extension.
+func (renderer *HTMLInvoiceRenderer) FileExtension() string { return "html" }
+
+// ----------------------------------------------------------------------------
+// PDF (attachment)
+// ----------------------------------------------------------------------------
+
+// PDFInvoiceRenderer writes the text invoice onto Letter-size pages using
+// the built-in Courier font, so columns line up without embedding fonts.
+// It is a deliberately small PDF 1.4 writer: one content stream per page,
+// no compression, and characters outside ASCII printed as "?".
+type PDFInvoiceRenderer struct{}
+
+const (
+	pdfPageWidth    = 612 // Letter, in points
+	pdfPageHeight   = 792
+	pdfMargin       = 54
+	pdfFontSize     = 10
+	pdfLeading      = 14
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// Render produces the PDF invoice.
+func (renderer *PDFInvoiceRenderer) Render(invoice *Invoice) ([]byte, error) {
+	text, err := (&TextInvoiceRenderer{}).Render(invoice)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(string(text), "\n"), "\n")
+
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// Objects: 1 catalog, 2 page tree, 3 font, then a page + content pair per page
+	objects := []string{"", "", "<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>"}
+	kids := make([]string, 0, len(pages))
+	for _, pageLines := range pages {
+		var content strings.Builder
+		content.WriteString(fmt.Sprintf("BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin))
+		for _, line := range pageLines {
+			content.WriteString("(" + pdfEscape(line) + ") Tj T*\n")
+		}
+		content.WriteString("ET")
+
+		pageNumber := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageNumber))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, pageNumber+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+	objects[0] = "<< /Type /Catalog /Pages 2 0 R >>"
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buffer bytes.Buffer
+	buffer.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for index, object := range objects {
+		offsets[index] = buffer.Len()
+		buffer.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", index+1, object))
+	}
+	xrefOffset := buffer.Len()
+	buffer.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, offset := range offsets {
+		buffer.WriteString(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	buffer.WriteString(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset))
+	return buffer.Bytes(), nil
+}
+
+// ContentType returns the MIME type of the PDF invoice.
+func (renderer *PDFInvoiceRenderer) ContentType() string { return "application/pdf" }
+
+// FileExtension returns the attachment extension.
+func (renderer *PDFInvoiceRenderer) FileExtension() string { return "pdf" }
+
+// pdfEscape makes text safe inside a PDF string literal.
+func pdfEscape(text string) string {
+	var builder strings.Builder
+	for _, char := range text {
+		switch {
+		case char == '(' || char == ')' || char == '\\':
+			builder.WriteRune('\\')
+			builder.WriteRune(char)
+		case char < 32 || char > 126:
+			builder.WriteRune('?')
+		default:
+			builder.WriteRune(char)
+		}
+	}
+	return builder.String()
+}
+
+// ----------------------------------------------------------------------------
+// Emailing invoices
+// ----------------------------------------------------------------------------
+
+// RenderInvoiceAttachment renders an order's invoice as an email attachment
+// named after the invoice number, e.g. "INV-3.pdf".
+func RenderInvoiceAttachment(order *Order, renderer InvoiceRenderer) (notification.Attachment, error) {
+	invoice := NewInvoice(order)
+	data, err := renderer.Render(invoice)
+	if err != nil {
+		return notification.Attachment{}, err
+	}
+	return notification.Attachment{
+		Filename:    invoice.Number + "." + renderer.FileExtension(),
+		ContentType: renderer.ContentType(),
+		Data:        data,
+	}, nil
+}
+
+// SendInvoice emails the order's invoice to its contact address with one
+// attachment per renderer (HTML and PDF when none are given).
+func SendInvoice(ctx context.Context, channel notification.NotificationChannel, order *Order, renderers ...InvoiceRenderer) error {
+	if order.contactEmail == "" {
+		return fmt.Errorf("cannot email invoice for order %s: no contact email", order.id)
+	}
+	if len(renderers) == 0 {
+		renderers = []InvoiceRenderer{&HTMLInvoiceRenderer{}, &PDFInvoiceRenderer{}}
+	}
+
+	attachments := make([]notification.Attachment, 0, len(renderers))
+	for _, renderer := range renderers {
+		attachment, err := RenderInvoiceAttachment(order, renderer)
+		if err != nil {
+			return err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	email := notification.NewNotification(order.contactEmail,
+		fmt.Sprintf("Your invoice for order %s", order.id),
+		fmt.Sprintf("Thanks for your order! Total charged: %s", order.currency.Format(order.totalAmount)),
+		channel.GetType(), notification.PriorityMedium)
+	email.Attachments = attachments
+	return channel.Send(ctx, email)
+}
+
+// ============================================================================
+// SECTION 13: MULTI-CURRENCY PRICING
+// ============================================================================
+//
+// Products are listed in their own currency and each cart picks the currency
+// the shopper pays in. An ExchangeRateProvider (Strategy Pattern) supplies the
+// rates; the cart quotes a rate the first time it needs one and keeps it, so
+// the prices a shopper sees do not drift while they shop. Switching the cart
+// currency re-quotes every rate. At checkout the order records the list price
+// of every item in its original currency, the rates used, and the amounts
+// charged in the cart currency.
+
+// BaseCurrency is the currency products and carts use unless told otherwise.
+const BaseCurrency = money.USD
+
+// ExchangeRateProvider supplies conversion rates between currencies.
+// Rate returns how many units of "to" one unit of "from" buys.
+type ExchangeRateProvider interface {
+	Rate(from, to money.Currency) (float64, error)
+}
+
+// StaticExchangeRates is an in-memory provider. Every currency is stored as
+// its value in BaseCurrency, so any pair can be converted through the base.
+type StaticExchangeRates struct {
+	perBase map[money.Currency]float64 // Currency -> units of BaseCurrency per unit
+	mutex   sync.Mutex
+}
+
+// NewStaticExchangeRates creates a provider that only knows BaseCurrency.
+func NewStaticExchangeRates() *StaticExchangeRates {
+	return &StaticExchangeRates{
+		perBase: map[money.Currency]float64{BaseCurrency: 1},
+	}
+}
+
+// SetRate sets the value of one unit of currency in BaseCurrency,
+// e.g. SetRate(CurrencyEUR, 1.08) means €1 = $1.08.
+func (rates *StaticExchangeRates) SetRate(currency money.Currency, valueInBase float64) error {
+	if valueInBase <= 0 {
+		return fmt.Errorf("exchange rate for %s must be positive: %v", currency, valueInBase)
+	}
+	if currency == BaseCurrency && valueInBase != 1 {
+		return fmt.Errorf("the rate of the base currency %s is always 1", BaseCurrency)
+	}
+	rates.mutex.Lock()
+	defer rates.mutex.Unlock()
+	rates.perBase[currency] = valueInBase
+	return nil
+}
+
+// Rate converts through BaseCurrency.
+func (rates *StaticExchangeRates) Rate(from, to money.Currency) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	rates.mutex.Lock()
+	defer rates.mutex.Unlock()
+
+	fromValue, fromKnown := rates.perBase[from]
+	if !fromKnown {
+		return 0, fmt.Errorf("no exchange rate for %s", from)
+	}
+	toValue, toKnown := rates.perBase[to]
+	if !toKnown {
+		return 0, fmt.Errorf("no exchange rate for %s", to)
+	}
+	return fromValue / toValue, nil
+}
+
+// quoteRateLocked returns the rate from a product currency to the cart
+// currency, asking the provider the first time. Caller must hold cart.mutex.
+func (cart *Cart) quoteRateLocked(from money.Currency) (float64, error) {
+	if from == cart.currency {
+		return 1, nil
+	}
+	if rate, quoted := cart.quotedRates[from]; quoted {
+		return rate, nil
+	}
+	if cart.rateProvider == nil {
+		return 0, fmt.Errorf("cart %s has no exchange rates to convert %s to %s", cart.id, from, cart.currency)
+	}
+	rate, err := cart.rateProvider.Rate(from, cart.currency)
+	if err != nil {
+		return 0, fmt.Errorf("convert %s to %s: %w", from, cart.currency, err)
+	}
+	cart.quotedRates[from] = rate
+	return rate, nil
+}
+
+// convertLocked converts an amount into the cart currency using an already
+// quoted rate. Every item in the cart has one, because AddItem, SetCurrency
+// and absorb refuse products they cannot quote. Caller must hold cart.mutex.
+func (cart *Cart) convertLocked(amount float64, from money.Currency) float64 {
+	if from == cart.currency {
+		return amount
+	}
+	return amount * cart.quotedRates[from]
+}
+
+// SetCurrency switches the currency the cart is shown and charged in, using
+// provider for exchange rates (nil keeps the current provider). Rates for
+// every item are re-quoted; if any is unavailable the cart is left unchanged.
+// Flat discount amounts are taken to be in the cart currency.
+func (cart *Cart) SetCurrency(currency money.Currency, provider ExchangeRateProvider) error {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	if provider == nil {
+		provider = cart.rateProvider
+	}
+	quotes := make(map[money.Currency]float64)
+	for _, item := range cart.items {
+		from := item.product.GetCurrency()
+		if _, quoted := quotes[from]; quoted || from == currency {
+			continue
+		}
+		if provider == nil {
+			return fmt.Errorf("cannot switch cart %s to %s: no exchange rates for %s", cart.id, currency, from)
+		}
+		rate, err := provider.Rate(from, currency)
+		if err != nil {
+			return fmt.Errorf("cannot switch cart %s to %s: %w", cart.id, currency, err)
+		}
+		quotes[from] = rate
+	}
+
+	cart.currency = currency
+	cart.rateProvider = provider
+	cart.quotedRates = quotes
+	cart.lastActivity = time.Now()
+	return nil
+}
+
+// GetCurrency returns the currency the cart is shown and charged in.
+func (cart *Cart) GetCurrency() money.Currency {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+	return cart.currency
+}
+
+// DisplayPrice returns a product's unit price, under the cart's price list,
+// converted into the cart currency, quoting a rate if the cart has none yet.
+func (cart *Cart) DisplayPrice(product *Product) (money.Money, error) {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	rate, err := cart.quoteRateLocked(product.GetCurrency())
+	if err != nil {
+		return money.Money{}, err
+	}
+	price, _ := resolvePrice(cart.priceList, product)
+	return money.Money{Amount: price * rate, Currency: cart.currency}, nil
+}
+
+// snapshotPricing returns the cart currency and a copy of the quoted rates,
+// so an order keeps the rates it was charged at.
+func (cart *Cart) snapshotPricing() (money.Currency, map[money.Currency]float64) {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	rates := make(map[money.Currency]float64, len(cart.quotedRates))
+	for from, rate := range cart.quotedRates {
+		rates[from] = rate
+	}
+	return cart.currency, rates
+}
+
+// exchangeRate returns the rate the order used to charge items priced in from.
+func (order *Order) exchangeRate(from money.Currency) float64 {
+	if from == order.currency {
+		return 1
+	}
+	return order.exchangeRates[from]
+}
+
+// GetCurrency returns the currency the order was charged in.
+func (order *Order) GetCurrency() money.Currency { return order.currency }
+
+// GetExchangeRate returns the rate used to convert from into the charged
+// currency, and whether the order used one.
+func (order *Order) GetExchangeRate(from money.Currency) (float64, bool) {
+	if from == order.currency {
+		return 1, true
+	}
+	rate, used := order.exchangeRates[from]
+	return rate, used
+}
+
+// GetOriginalUnitPrice returns a product's list price, in its own currency,
+// at the time the order was placed.
+func (order *Order) GetOriginalUnitPrice(productID string) (money.Money, bool) {
+	price, exists := order.originalPrices[productID]
+	return price, exists
+}
+
+// GetChargedUnitPrice returns what one unit of a product was charged, in the
+// order currency.
+func (order *Order) GetChargedUnitPrice(productID string) (money.Money, bool) {
+	price, exists := order.unitPrices[productID]
+	return money.Money{Amount: price, Currency: order.currency}, exists
+}
+
+// GetOriginalSubtotals returns the item subtotals at list price (before
+// promotions, tax, discount and shipping) grouped by the currency the
+// products are listed in.
+func (order *Order) GetOriginalSubtotals() map[money.Currency]float64 {
+	subtotals := make(map[money.Currency]float64)
+	for _, item := range order.items {
+		price, exists := order.originalPrices[item.product.GetID()]
+		if !exists {
+			price = money.Money{Amount: item.product.GetPrice(), Currency: item.product.GetCurrency()}
+		}
+		subtotals[price.Currency] += price.Amount * float64(item.quantity)
+	}
+	return subtotals
+}
+
+// ============================================================================
+// SECTION 14: TIERED CUSTOMER PRICING (B2B PRICE LISTS)
+// ============================================================================
+//
+// Business customers buy at negotiated prices. A PriceList holds per-product
+// prices for one tier (wholesale, VIP, ...); retail is the products' base
+// price and needs no list. Each customer is assigned a tier and their carts
+// are priced with that tier's list. A product missing from the list falls
+// back to its base price. Orders record the tier the cart was priced with
+// and, per product, whether the tier price or the base price was charged.
+
+// PriceTier identifies a price list, e.g. "wholesale".
+type PriceTier string
+
+const (
+	PriceTierRetail    PriceTier = "retail" // Base product prices
+	PriceTierWholesale PriceTier = "wholesale"
+	PriceTierVIP       PriceTier = "vip"
+)
+
+// PriceList overrides product prices for the customers of one tier.
+type PriceList struct {
+	tier   PriceTier
+	name   string             // Display name, e.g. "Wholesale 2024"
+	prices map[string]float64 // Product ID -> tier price, in the product's currency
+	mutex  sync.RWMutex       // Prices may change while carts are priced
+}
+
+// NewPriceList creates an empty price list for a tier.
+func NewPriceList(tier PriceTier, name string) *PriceList {
+	return &PriceList{
+		tier:   tier,
+		name:   name,
+		prices: make(map[string]float64),
+	}
+}
+
+func (list *PriceList) GetTier() PriceTier { return list.tier }
+func (list *PriceList) GetName() string    { return list.name }
+
+// SetPrice sets the tier price of a product, in the product's currency.
+func (list *PriceList) SetPrice(product *Product, price float64) error {
+	if price <= 0 {
+		return fmt.Errorf("%s price for '%s' must be positive", list.tier, product.GetName())
+	}
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	list.prices[product.GetID()] = price
+	return nil
+}
+
+// RemovePrice drops a product from the list, so it sells at its base price.
+func (list *PriceList) RemovePrice(productID string) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	delete(list.prices, productID)
+}
+
+// GetPrice returns the product's price for this tier, falling back to the
+// base price when the list has none.
+func (list *PriceList) GetPrice(product *Product) float64 {
+	price, _ := resolvePrice(list, product)
+	return price
+}
+
+// resolvePrice returns the unit price of a product under list (nil means
+// retail) and the tier that supplied it: list's tier, or retail on fallback.
+func resolvePrice(list *PriceList, product *Product) (float64, PriceTier) {
+	if list != nil {
+		list.mutex.RLock()
+		price, listed := list.prices[product.GetID()]
+		list.mutex.RUnlock()
+		if listed {
+			return price, list.tier
+		}
+	}
+	return product.GetPrice(), PriceTierRetail
+}
+
+// GetPriceTier returns the tier a customer buys at.
+func (customer *Customer) GetPriceTier() PriceTier {
+	if customer.priceTier == "" {
+		return PriceTierRetail
+	}
+	return customer.priceTier
+}
+
+// RegisterPriceList makes a tier available for AssignPriceTier. Registering
+// a tier again replaces its list for carts created afterwards.
+func (service *CheckoutService) RegisterPriceList(list *PriceList) error {
+	if list.tier == "" || list.tier == PriceTierRetail {
+		return fmt.Errorf("price list needs a tier other than %s (retail uses base prices)", PriceTierRetail)
+	}
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.priceLists[list.tier] = list
+	return nil
+}
+
+// AssignPriceTier moves a customer to a tier and reprices their open cart.
+func (service *CheckoutService) AssignPriceTier(customerID string, tier PriceTier) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	customer, exists := service.customers[customerID]
+	if !exists {
+		return fmt.Errorf("customer %s not found", customerID)
+	}
+	if _, registered := service.priceLists[tier]; !registered && tier != PriceTierRetail {
+		return fmt.Errorf("price tier %q is not registered", tier)
+	}
+
+	customer.priceTier = tier
+	if cart, exists := service.carts[customerID]; exists {
+		cart.SetPriceList(service.priceListLocked(customerID))
+	}
+	return nil
+}
+
+// GetPriceList returns the price list a customer's carts use (nil = retail).
+func (service *CheckoutService) GetPriceList(customerID string) *PriceList {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	return service.priceListLocked(customerID)
+}
+
+// priceListLocked returns the owner's price list, nil for retail customers
+// and guests. Caller must hold service.mutex.
+func (service *CheckoutService) priceListLocked(ownerID string) *PriceList {
+	customer, exists := service.customers[ownerID]
+	if !exists {
+		return nil
+	}
+	return service.priceLists[customer.GetPriceTier()]
+}
+
+// SetPriceList reprices every item in the cart with list (nil = retail).
+func (cart *Cart) SetPriceList(list *PriceList) {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	cart.priceList = list
+	for _, item := range cart.items {
+		item.priceList = list
+	}
+}
+
+// GetPriceTier returns the tier the cart is priced with.
+func (cart *Cart) GetPriceTier() PriceTier {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	if cart.priceList == nil {
+		return PriceTierRetail
+	}
+	return cart.priceList.tier
+}
+
+// GetPriceTier returns the tier the order's cart was priced with.
+func (order *Order) GetPriceTier() PriceTier { return order.priceTier }
+
+// GetPriceSource returns which tier supplied a product's unit price:
+// the order's tier, or retail if the product was not on its price list.
+func (order *Order) GetPriceSource(productID string) (PriceTier, bool) {
+	tier, exists := order.priceSources[productID]
+	return tier, exists
+}
+
+// ============================================================================
+// SECTION 15: SCHEDULED PROMOTIONS
+// ============================================================================
+//
+// Promotions are automatic, time-windowed discounts: a flash sale on one
+// category or a sitewide sale. Shoppers do not enter a code; any active
+// promotion covering a product lowers its unit price during pricing.
+//
+// A background scheduler (StartScheduler) activates each promotion when its
+// window opens and deactivates it when the window closes. Pricing only looks
+// at the active set, so a promotion starts and stops exactly when the
+// scheduler flips it, and every cart sees the same prices at the same time.
+//
+// Promotions do not stack with each other: an item gets the best active
+// promotion covering it, applied on top of the customer's tier price. Coupon
+// codes still apply afterwards to the promoted subtotal. Orders record which
+// promotion priced each item, and the engine reports per promotion the units
+// sold and the revenue impact (discount given) in each charged currency.
+
+// PromotionScope decides which products a promotion covers.
+type PromotionScope int
+
+const (
+	PromotionSitewide PromotionScope = iota // Every product
+	PromotionCategory                       // Products in one category
+)
+
+// promotionIDGen generates unique promotion IDs.
+var promotionIDGen = idgen.NewSequence("PROMO")
+
+// Promotion is an automatic percentage discount for a time window.
+type Promotion struct {
+	id         string
+	name       string          // e.g. "Electronics Flash Sale"
+	scope      PromotionScope  // Sitewide or one category
+	category   ProductCategory // Covered category (PromotionCategory only)
+	percentOff float64         // 0-100, exclusive
+	startsAt   time.Time       // Window opens (inclusive)
+	endsAt     time.Time       // Window closes (exclusive)
+}
+
+// NewSitewidePromotion creates a promotion covering every product.
+func NewSitewidePromotion(name string, percentOff float64, startsAt, endsAt time.Time) (*Promotion, error) {
+	return newPromotion(name, PromotionSitewide, 0, percentOff, startsAt, endsAt)
+}
+
+// NewCategoryPromotion creates a flash sale on one category.
+func NewCategoryPromotion(name string, category ProductCategory, percentOff float64, startsAt, endsAt time.Time) (*Promotion, error) {
+	return newPromotion(name, PromotionCategory, category, percentOff, startsAt, endsAt)
+}
+
+// newPromotion validates the discount and window before assigning an ID.
+func newPromotion(name string, scope PromotionScope, category ProductCategory, percentOff float64, startsAt, endsAt time.Time) (*Promotion, error) {
+	if percentOff <= 0 || percentOff >= 100 {
+		return nil, fmt.Errorf("promotion '%s': percent off must be between 0 and 100, got %.1f", name, percentOff)
+	}
+	if !endsAt.After(startsAt) {
+		return nil, fmt.Errorf("promotion '%s': window must end after it starts", name)
+	}
+	return &Promotion{
+		id:         promotionIDGen.NextID(),
+		name:       name,
+		scope:      scope,
+		category:   category,
+		percentOff: percentOff,
+		startsAt:   startsAt,
+		endsAt:     endsAt,
+	}, nil
+}
+
+func (promotion *Promotion) GetID() string          { return promotion.id }
+func (promotion *Promotion) GetName() string        { return promotion.name }
+func (promotion *Promotion) GetPercentOff() float64 { return promotion.percentOff }
+
+// GetDescription returns a human-readable description of the promotion.
+func (promotion *Promotion) GetDescription() string {
+	if promotion.scope == PromotionSitewide {
+		return fmt.Sprintf("%s: %.0f%% off everything", promotion.name, promotion.percentOff)
+	}
+	return fmt.Sprintf("%s: %.0f%% off %s", promotion.name, promotion.percentOff, promotion.category)
+}
+
+// inWindow reports whether the promotion should be running at now.
+func (promotion *Promotion) inWindow(now time.Time) bool {
+	return !now.Before(promotion.startsAt) && now.Before(promotion.endsAt)
+}
+
+// covers reports whether the promotion applies to the product.
+func (promotion *Promotion) covers(product *Product) bool {
+	return promotion.scope == PromotionSitewide || promotion.category == product.GetCategory()
+}
+
+// apply returns the promoted price of one unit.
+func (promotion *Promotion) apply(price float64) float64 {
+	return price * (1 - promotion.percentOff/100)
+}
+
+// PromotionChange is one activation or deactivation made by a refresh.
+type PromotionChange struct {
+	PromotionID string
+	Name        string
+	Activated   bool // false = deactivated
+	At          time.Time
+}
+
+// String describes the change, e.g. "PROMO-1 Flash Sale started".
+func (change PromotionChange) String() string {
+	verb := "ended"
+	if change.Activated {
+		verb = "started"
+	}
+	return fmt.Sprintf("%s %s %s", change.PromotionID, change.Name, verb)
+}
+
+// PromotionReport summarizes the sales priced with one promotion.
+type PromotionReport struct {
+	PromotionID   string
+	Name          string
+	Active        bool
+	Orders        int                        // Orders with at least one promoted item
+	UnitsSold     int                        // Promoted units across all orders
+	Revenue       map[money.Currency]float64 // Amount charged for promoted units, per charged currency
+	DiscountGiven map[money.Currency]float64 // Revenue impact: list price minus charged, per currency
+}
+
+// PromotionEngine holds the scheduled promotions and the currently active set.
+type PromotionEngine struct {
+	promotions map[string]*Promotion
+	order      []string                    // Promotion IDs in the order they were added
+	active     map[string]bool             // Promotion ID -> running now
+	reports    map[string]*PromotionReport // Promotion ID -> sales so far
+	scheduler  periodic.Job                // Runs RefreshPromotions in the background
+	mutex      sync.RWMutex                // Pricing reads the active set concurrently
+}
+
+// NewPromotionEngine creates an engine with no promotions.
+func NewPromotionEngine() *PromotionEngine {
+	return &PromotionEngine{
+		promotions: make(map[string]*Promotion),
+		active:     make(map[string]bool),
+		reports:    make(map[string]*PromotionReport),
+	}
+}
+
+// Schedule adds a promotion. It takes effect on the next refresh.
+func (engine *PromotionEngine) Schedule(promotion *Promotion) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.promotions[promotion.id] = promotion
+	engine.order = append(engine.order, promotion.id)
+	engine.reports[promotion.id] = &PromotionReport{
+		PromotionID:   promotion.id,
+		Name:          promotion.name,
+		Revenue:       make(map[money.Currency]float64),
+		DiscountGiven: make(map[money.Currency]float64),
+	}
+}
+
+// RefreshPromotions activates promotions whose window contains now and
+// deactivates the rest. Returns the changes made by this refresh.
+func (engine *PromotionEngine) RefreshPromotions(now time.Time) []PromotionChange {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	changes := make([]PromotionChange, 0)
+	for _, promotionID := range engine.order {
+		promotion := engine.promotions[promotionID]
+		shouldRun := promotion.inWindow(now)
+		if shouldRun == engine.active[promotionID] {
+			continue
+		}
+		engine.active[promotionID] = shouldRun
+		changes = append(changes, PromotionChange{
+			PromotionID: promotionID, Name: promotion.name, Activated: shouldRun, At: now,
+		})
+	}
+	return changes
+}
+
+// StartScheduler runs RefreshPromotions in the background every interval,
+// starting immediately. Calling it while the scheduler is running has no effect.
+func (engine *PromotionEngine) StartScheduler(interval time.Duration) {
+	started := engine.scheduler.Start(interval, func(now time.Time) {
+		engine.RefreshPromotions(now)
+	})
+	if started {
+		engine.RefreshPromotions(time.Now())
+	}
+}
+
+// StopScheduler stops the background scheduler and waits for a refresh in
+// progress to finish. Active promotions stay active until the next refresh.
+func (engine *PromotionEngine) StopScheduler() {
+	engine.scheduler.Stop()
+}
+
+// GetActivePromotions returns the running promotions in scheduling order.
+func (engine *PromotionEngine) GetActivePromotions() []*Promotion {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	active := make([]*Promotion, 0)
+	for _, promotionID := range engine.order {
+		if engine.active[promotionID] {
+			active = append(active, engine.promotions[promotionID])
+		}
+	}
+	return active
+}
+
+// bestPromotion returns the active promotion with the largest discount
+// covering the product (nil if none). A nil engine has no promotions.
+func (engine *PromotionEngine) bestPromotion(product *Product) *Promotion {
+	if engine == nil {
+		return nil
+	}
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	var best *Promotion
+	for _, promotionID := range engine.order {
+		promotion := engine.promotions[promotionID]
+		if engine.active[promotionID] && promotion.covers(product) &&
+			(best == nil || promotion.percentOff > best.percentOff) {
+			best = promotion
+		}
+	}
+	return best
+}
+
+// recordOrder adds an order's promoted items to the promotion reports.
+func (engine *PromotionEngine) recordOrder(order *Order) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	counted := make(map[string]bool)
+	for _, item := range order.items {
+		productID := item.product.GetID()
+		promotionID, promoted := order.promotions[productID]
+		report, exists := engine.reports[promotionID]
+		if !promoted || !exists {
+			continue
+		}
+		if !counted[promotionID] {
+			counted[promotionID] = true
+			report.Orders++
+		}
+		charged := order.unitPrices[productID] * float64(item.quantity)
+		listed := order.originalPrices[productID].Amount * order.exchangeRate(item.product.GetCurrency()) * float64(item.quantity)
+		report.UnitsSold += item.quantity
+		report.Revenue[order.currency] += charged
+		report.DiscountGiven[order.currency] += listed - charged
+	}
+}
+
+// GetReport returns a copy of one promotion's sales report.
+func (engine *PromotionEngine) GetReport(promotionID string) (PromotionReport, bool) {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	report, exists := engine.reports[promotionID]
+	if !exists {
+		return PromotionReport{}, false
+	}
+	return engine.copyReportLocked(report), true
+}
+
+// GetReports returns a copy of every promotion's report in scheduling order.
+func (engine *PromotionEngine) GetReports() []PromotionReport {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	reports := make([]PromotionReport, 0, len(engine.order))
+	for _, promotionID := range engine.order {
+		reports = append(reports, engine.copyReportLocked(engine.reports[promotionID]))
+	}
+	return reports
+}
+
+// copyReportLocked copies a report so callers cannot change the engine's
+// totals. Caller must hold engine.mutex.
+func (engine *PromotionEngine) copyReportLocked(report *PromotionReport) PromotionReport {
+	snapshot := *report
+	snapshot.Active = engine.active[report.PromotionID]
+	snapshot.Revenue = make(map[money.Currency]float64, len(report.Revenue))
+	for currency, amount := range report.Revenue {
+		snapshot.Revenue[currency] = amount
+	}
+	snapshot.DiscountGiven = make(map[money.Currency]float64, len(report.DiscountGiven))
+	for currency, amount := range report.DiscountGiven {
+		snapshot.DiscountGiven[currency] = amount
+	}
+	return snapshot
+}
+
+// AttachPromotionEngine prices every cart, open and future, with the
+// engine's active promotions.
+func (service *CheckoutService) AttachPromotionEngine(engine *PromotionEngine) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	service.promotions = engine
+	for _, cart := range service.carts {
+		cart.SetPromotionEngine(engine)
+	}
+}
+
+// SetPromotionEngine makes the cart apply the engine's active promotions
+// (nil = no automatic discounts).
+func (cart *Cart) SetPromotionEngine(engine *PromotionEngine) {
+	cart.mutex.Lock()
+	defer cart.mutex.Unlock()
+
+	cart.promotions = engine
+	for _, item := range cart.items {
+		item.promotions = engine
+	}
+}
+
+// GetPromotion returns the promotion pricing the item right now (nil if none).
+func (item *CartItem) GetPromotion() *Promotion {
+	return item.promotions.bestPromotion(item.product)
+}
+
+// GetListPrice returns the price of one unit before promotions, in the
+// product's currency.
+func (item *CartItem) GetListPrice() float64 {
+	price, _ := resolvePrice(item.priceList, item.product)
+	return price
+}
+
+// GetPromotionID returns the promotion that priced a product ("" if none).
+func (order *Order) GetPromotionID(productID string) string {
+	return order.promotions[productID]
+}
@@ -0,0 +1,3886 @@
+// Package carrental models a car rental service: fleet, reservations,
+// pricing and returns.
+package carrental
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	notification "github.com/ayushgupta5/GoLLD/18_notification_system"
+	"github.com/ayushgupta5/GoLLD/pkg/entity"
+	"github.com/ayushgupta5/GoLLD/pkg/idgen"
+	"github.com/ayushgupta5/GoLLD/pkg/money"
+	"github.com/ayushgupta5/GoLLD/pkg/periodic"
+)
+
+// ============================================================================
+// CAR RENTAL SYSTEM - Low Level Design
+// ============================================================================
+//
+// This system demonstrates:
+// - Entity Modeling (Vehicle, Customer, Reservation)
+// - Reservation Lifecycle Management (Pending -> Confirmed -> PickedUp -> Returned)
+// - Vehicle holds backed by payment pre-authorization, expired automatically
+// - Pricing Strategy with daily rates and extras
+// - Location-based Fleet Management
+// - Fleet Analytics (utilization, revenue, idle vehicles, top customers, CSV)
+// - GPS Telemetry with geofenced return validation and wrong-location fees
+// - Partner garage network for cross-city returns with capacity-aware rebalancing
+// - Condition reports at pickup/return with photos; the diff justifies damage charges
+// - Itemized receipts as structured data with JSON/HTML export
+// - Driver license verification at registration and pickup (pluggable verifier)
+// - Lifecycle notifications (confirmation, pickup/return reminders, overdue) via templates
+// - Subscription plans: monthly fee per vehicle class, limited swaps, mileage caps
+// - Thread-safe operations using mutex locks
+//
+// ============================================================================
+
+// ============================================================================
+// SECTION 1: ENUMS (Type-safe constants)
+// ============================================================================
+
+// VehicleType represents different categories of vehicles available for rent.
+// Using iota for auto-incrementing values (Bike=0, Car=1, SUV=2, etc.)
+type VehicleType int
+
+const (
+	VehicleTypeBike   VehicleType = iota // 0 - Two-wheeler
+	VehicleTypeCar                       // 1 - Standard sedan
+	VehicleTypeSUV                       // 2 - Sport Utility Vehicle
+	VehicleTypeLuxury                    // 3 - Premium vehicles
+	VehicleTypeVan                       // 4 - Multi-passenger vehicle
+)
+
+// String returns a human-readable name for the vehicle type.
+func (vehicleType VehicleType) String() string {
+	names := [...]string{"Bike", "Car", "SUV", "Luxury", "Van"}
+	if int(vehicleType) < len(names) {
+		return names[vehicleType]
+	}
+	return "Unknown"
+}
+
+// DailyRate returns the base rental rate per day for each vehicle type.
+func (vehicleType VehicleType) DailyRate() float64 {
+	rates := [...]float64{15.0, 40.0, 60.0, 120.0, 80.0}
+	if int(vehicleType) < len(rates) {
+		return rates[vehicleType]
+	}
+	return 0.0
+}
+
+// VehicleStatus represents the current availability state of a vehicle.
+type VehicleStatus int
+
+const (
+	VehicleStatusAvailable   VehicleStatus = iota // 0 - Ready for rental
+	VehicleStatusRented                           // 1 - Currently rented out
+	VehicleStatusMaintenance                      // 2 - Under maintenance
+	VehicleStatusReserved                         // 3 - Reserved but not picked up yet
+)
+
+// String returns a human-readable name for the vehicle status.
+func (status VehicleStatus) String() string {
+	names := [...]string{"Available", "Rented", "Maintenance", "Reserved"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// ReservationStatus represents the lifecycle state of a reservation.
+type ReservationStatus int
+
+const (
+	ReservationStatusPending   ReservationStatus = iota // 0 - Vehicle held and payment pre-authorized, awaiting confirmation
+	ReservationStatusConfirmed                          // 1 - Confirmed, ready for pickup
+	ReservationStatusPickedUp                           // 2 - Customer has the vehicle
+	ReservationStatusReturned                           // 3 - Vehicle returned, rental complete
+	ReservationStatusCancelled                          // 4 - Reservation was cancelled
+)
+
+// String returns a human-readable name for the reservation status.
+func (status ReservationStatus) String() string {
+	names := [...]string{"Pending (Hold)", "Confirmed", "Picked Up", "Returned", "Cancelled"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// ============================================================================
+// SECTION 2: VEHICLE ENTITY
+// ============================================================================
+
+// Vehicle represents a rentable vehicle in the fleet.
+// It contains all information about the vehicle and its current state.
+type Vehicle struct {
+	id           string        // Unique identifier for the vehicle
+	licensePlate string        // License plate number (e.g., "ABC-123")
+	make         string        // Manufacturer (e.g., "Toyota")
+	model        string        // Model name (e.g., "Camry")
+	year         int           // Manufacturing year
+	vehicleType  VehicleType   // Category of vehicle
+	status       VehicleStatus // Current availability status
+	mileage      int           // Total miles driven (for tracking)
+	fuelLevel    int           // Fuel percentage (0-100)
+	dailyRate    float64       // Rental cost per day
+	location     string        // Current location (e.g., "Airport")
+	mutex        sync.Mutex    // Protects concurrent access to vehicle state
+}
+
+// NewVehicle creates and initializes a new Vehicle instance.
+// The vehicle starts with status "Available" and full fuel tank.
+func NewVehicle(id, licensePlate, make, model string, year int, vehicleType VehicleType, location string) *Vehicle {
+	return &Vehicle{
+		id:           id,
+		licensePlate: licensePlate,
+		make:         make,
+		model:        model,
+		year:         year,
+		vehicleType:  vehicleType,
+		status:       VehicleStatusAvailable,
+		mileage:      0,
+		fuelLevel:    100,
+		dailyRate:    vehicleType.DailyRate(),
+		location:     location,
+	}
+}
+
+// Getter methods for Vehicle fields
+// These provide controlled read access to private fields
+
+func (vehicle *Vehicle) GetID() string           { return vehicle.id }
+func (vehicle *Vehicle) GetLicensePlate() string { return vehicle.licensePlate }
+func (vehicle *Vehicle) GetType() VehicleType    { return vehicle.vehicleType }
+func (vehicle *Vehicle) GetDailyRate() float64   { return vehicle.dailyRate }
+func (vehicle *Vehicle) GetMake() string         { return vehicle.make }
+func (vehicle *Vehicle) GetModel() string        { return vehicle.model }
+func (vehicle *Vehicle) GetYear() int            { return vehicle.year }
+
+// GetStatus returns the current status of the vehicle (thread-safe).
+func (vehicle *Vehicle) GetStatus() VehicleStatus {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	return vehicle.status
+}
+
+// GetLocation returns the location the vehicle is parked at (thread-safe).
+func (vehicle *Vehicle) GetLocation() string {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	return vehicle.location
+}
+
+// setLocation moves the vehicle to another location (thread-safe).
+func (vehicle *Vehicle) setLocation(location string) {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	vehicle.location = location
+}
+
+// SetStatus updates the vehicle status (thread-safe).
+func (vehicle *Vehicle) SetStatus(newStatus VehicleStatus) {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	vehicle.status = newStatus
+}
+
+// IsAvailable checks if the vehicle can be rented.
+func (vehicle *Vehicle) IsAvailable() bool {
+	return vehicle.GetStatus() == VehicleStatusAvailable
+}
+
+// String returns a formatted description of the vehicle.
+func (vehicle *Vehicle) String() string {
+	return fmt.Sprintf("%d %s %s (%s) - $%.2f/day - %s",
+		vehicle.year, vehicle.make, vehicle.model,
+		vehicle.vehicleType, vehicle.dailyRate, vehicle.status)
+}
+
+// ============================================================================
+// SECTION 3: CUSTOMER ENTITY
+// ============================================================================
+
+// Customer represents a person who can rent vehicles.
+type Customer struct {
+	entity.Person                // ID, name and contact details
+	driverLicense string         // Driver's license number (required for rental)
+	rentalHistory []*Reservation // History of past rentals
+}
+
+// NewCustomer creates and initializes a new Customer instance.
+func NewCustomer(id, name, email, phone, driverLicense string) *Customer {
+	return &Customer{
+		Person:        entity.NewPerson(id, name, email, phone),
+		driverLicense: driverLicense,
+		rentalHistory: make([]*Reservation, 0),
+	}
+}
+
+// GetDriverLicense returns the customer's driver's license number.
+func (customer *Customer) GetDriverLicense() string { return customer.driverLicense }
+
+// AddRentalToHistory adds a completed reservation to customer's history.
+func (customer *Customer) AddRentalToHistory(reservation *Reservation) {
+	customer.rentalHistory = append(customer.rentalHistory, reservation)
+}
+
+// ============================================================================
+// SECTION 4: EXTRA (Add-on) ENTITY
+// ============================================================================
+
+// Extra represents an additional service/item that can be added to a rental.
+// Examples: GPS Navigation, Child Seat, Toll Pass, etc.
+// Not every extra fits every vehicle (no child seat on a bike, ski racks
+// only on SUVs and vans), so each extra lists the vehicle types it fits.
+// Insurance is sold separately through the InsuranceCatalog.
+type Extra struct {
+	name               string               // Name of the extra service
+	dailyPrice         float64              // Cost per day for this extra
+	compatibleVehicles map[VehicleType]bool // Vehicle types this extra can be added to
+}
+
+// NewExtra creates an Extra that fits the given vehicle types.
+func NewExtra(name string, dailyPrice float64, vehicleTypes ...VehicleType) Extra {
+	compatible := make(map[VehicleType]bool, len(vehicleTypes))
+	for _, vehicleType := range vehicleTypes {
+		compatible[vehicleType] = true
+	}
+	return Extra{
+		name:               name,
+		dailyPrice:         dailyPrice,
+		compatibleVehicles: compatible,
+	}
+}
+
+func (extra Extra) GetName() string        { return extra.name }
+func (extra Extra) GetDailyPrice() float64 { return extra.dailyPrice }
+
+// IsCompatible checks whether this extra can be added to a vehicle type.
+func (extra Extra) IsCompatible(vehicleType VehicleType) bool {
+	return extra.compatibleVehicles[vehicleType]
+}
+
+// ExtrasCatalog holds the add-ons offered by the rental company.
+type ExtrasCatalog struct {
+	extras map[string]Extra // Key: extra name
+	names  []string         // Catalog order for listings
+}
+
+// NewExtrasCatalog creates the default catalog.
+// Child seats and extra drivers need a car body; helmets are bike-only;
+// ski racks need roof rails, which only SUVs and vans have.
+func NewExtrasCatalog() *ExtrasCatalog {
+	allTypes := []VehicleType{VehicleTypeBike, VehicleTypeCar, VehicleTypeSUV, VehicleTypeLuxury, VehicleTypeVan}
+	fourWheelers := []VehicleType{VehicleTypeCar, VehicleTypeSUV, VehicleTypeLuxury, VehicleTypeVan}
+
+	catalog := &ExtrasCatalog{extras: make(map[string]Extra)}
+	catalog.add(NewExtra("GPS Navigation", 5.00, allTypes...))
+	catalog.add(NewExtra("Toll Pass", 3.00, allTypes...))
+	catalog.add(NewExtra("Child Seat", 8.00, fourWheelers...))
+	catalog.add(NewExtra("Additional Driver", 10.00, fourWheelers...))
+	catalog.add(NewExtra("Ski Rack", 12.00, VehicleTypeSUV, VehicleTypeVan))
+	catalog.add(NewExtra("Helmet", 2.00, VehicleTypeBike))
+	return catalog
+}
+
+// add registers an extra, keeping catalog order.
+func (catalog *ExtrasCatalog) add(extra Extra) {
+	if _, exists := catalog.extras[extra.name]; !exists {
+		catalog.names = append(catalog.names, extra.name)
+	}
+	catalog.extras[extra.name] = extra
+}
+
+// GetExtra returns an extra by name.
+func (catalog *ExtrasCatalog) GetExtra(name string) (Extra, error) {
+	extra, exists := catalog.extras[name]
+	if !exists {
+		return Extra{}, fmt.Errorf("extra '%s' not offered", name)
+	}
+	return extra, nil
+}
+
+// GetCompatibleExtras lists the extras that can be added to a vehicle type.
+func (catalog *ExtrasCatalog) GetCompatibleExtras(vehicleType VehicleType) []Extra {
+	compatible := make([]Extra, 0)
+	for _, name := range catalog.names {
+		if extra := catalog.extras[name]; extra.IsCompatible(vehicleType) {
+			compatible = append(compatible, extra)
+		}
+	}
+	return compatible
+}
+
+// ============================================================================
+// SECTION 5: INSURANCE PRODUCTS
+// ============================================================================
+
+// InsuranceTier represents the coverage level a customer can buy.
+type InsuranceTier int
+
+const (
+	InsuranceTierBasic          InsuranceTier = iota // 0 - Third-party + collision with high deductible
+	InsuranceTierPremium                             // 1 - Full coverage with low deductible
+	InsuranceTierZeroDeductible                      // 2 - Full coverage, customer pays nothing for damage
+)
+
+// String returns a human-readable name for the insurance tier.
+func (tier InsuranceTier) String() string {
+	names := [...]string{"Basic", "Premium", "Zero-Deductible"}
+	if int(tier) < len(names) {
+		return names[tier]
+	}
+	return "Unknown"
+}
+
+// InsuranceProduct describes one tier's pricing and which vehicles it covers.
+type InsuranceProduct struct {
+	tier             InsuranceTier        // Coverage level
+	dailyPrice       float64              // Cost per rental day
+	deductible       float64              // Maximum the customer pays per claim
+	eligibleVehicles map[VehicleType]bool // Vehicle types this product may be sold for
+}
+
+func (product *InsuranceProduct) GetTier() InsuranceTier { return product.tier }
+func (product *InsuranceProduct) GetDailyPrice() float64 { return product.dailyPrice }
+func (product *InsuranceProduct) GetDeductible() float64 { return product.deductible }
+
+// IsEligible checks whether this product can be sold for a vehicle type.
+func (product *InsuranceProduct) IsEligible(vehicleType VehicleType) bool {
+	return product.eligibleVehicles[vehicleType]
+}
+
+// CustomerLiability returns how much of a damage cost the customer pays.
+// The customer pays up to the deductible; the insurer covers the rest.
+func (product *InsuranceProduct) CustomerLiability(damageCost float64) float64 {
+	return min(damageCost, product.deductible)
+}
+
+// InsuranceCatalog holds the insurance products offered by the rental company.
+type InsuranceCatalog struct {
+	products map[InsuranceTier]*InsuranceProduct
+}
+
+// NewInsuranceCatalog creates the default catalog.
+// Bikes only get Basic cover; Zero-Deductible is not sold for Luxury vehicles
+// because repair costs are too unpredictable.
+func NewInsuranceCatalog() *InsuranceCatalog {
+	allTypes := map[VehicleType]bool{
+		VehicleTypeBike: true, VehicleTypeCar: true, VehicleTypeSUV: true,
+		VehicleTypeLuxury: true, VehicleTypeVan: true,
+	}
+	fourWheelers := map[VehicleType]bool{
+		VehicleTypeCar: true, VehicleTypeSUV: true, VehicleTypeLuxury: true, VehicleTypeVan: true,
+	}
+	nonLuxury := map[VehicleType]bool{
+		VehicleTypeCar: true, VehicleTypeSUV: true, VehicleTypeVan: true,
+	}
+
+	return &InsuranceCatalog{
+		products: map[InsuranceTier]*InsuranceProduct{
+			InsuranceTierBasic:          {tier: InsuranceTierBasic, dailyPrice: 10.0, deductible: 1000.0, eligibleVehicles: allTypes},
+			InsuranceTierPremium:        {tier: InsuranceTierPremium, dailyPrice: 20.0, deductible: 250.0, eligibleVehicles: fourWheelers},
+			InsuranceTierZeroDeductible: {tier: InsuranceTierZeroDeductible, dailyPrice: 30.0, deductible: 0.0, eligibleVehicles: nonLuxury},
+		},
+	}
+}
+
+// GetProduct returns the product for a tier.
+func (catalog *InsuranceCatalog) GetProduct(tier InsuranceTier) (*InsuranceProduct, error) {
+	product, exists := catalog.products[tier]
+	if !exists {
+		return nil, fmt.Errorf("insurance tier '%s' not offered", tier)
+	}
+	return product, nil
+}
+
+// GetEligibleProducts lists the products that can be sold for a vehicle type.
+func (catalog *InsuranceCatalog) GetEligibleProducts(vehicleType VehicleType) []*InsuranceProduct {
+	eligible := make([]*InsuranceProduct, 0)
+	for tier := InsuranceTierBasic; tier <= InsuranceTierZeroDeductible; tier++ {
+		if product, exists := catalog.products[tier]; exists && product.IsEligible(vehicleType) {
+			eligible = append(eligible, product)
+		}
+	}
+	return eligible
+}
+
+// InsuranceClaim records damage found at return and how the cost was split.
+type InsuranceClaim struct {
+	reservationID string    // Reservation the damage belongs to
+	description   string    // What was damaged
+	damageCost    float64   // Total repair cost
+	customerPays  float64   // Amount charged to the customer (up to deductible)
+	insurerPays   float64   // Amount covered by insurance
+	recordedAt    time.Time // When the claim was recorded
+}
+
+func (claim *InsuranceClaim) GetReservationID() string { return claim.reservationID }
+func (claim *InsuranceClaim) GetDescription() string   { return claim.description }
+func (claim *InsuranceClaim) GetDamageCost() float64   { return claim.damageCost }
+func (claim *InsuranceClaim) GetCustomerPays() float64 { return claim.customerPays }
+func (claim *InsuranceClaim) GetInsurerPays() float64  { return claim.insurerPays }
+
+// ============================================================================
+// SECTION 6: PAYMENT PRE-AUTHORIZATION
+// ============================================================================
+//
+// Creating a reservation places a pre-authorization (a temporary hold on the
+// customer's card for the estimated total) instead of charging it. The
+// authorization is captured when the rental is completed, or released if the
+// reservation is cancelled or its hold expires before confirmation.
+
+// PreAuthStatus represents the state of a card pre-authorization.
+type PreAuthStatus int
+
+const (
+	PreAuthStatusAuthorized PreAuthStatus = iota // 0 - Funds held on the card
+	PreAuthStatusCaptured                        // 1 - Funds charged
+	PreAuthStatusReleased                        // 2 - Hold released, nothing charged
+)
+
+// String returns a human-readable name for the pre-authorization status.
+func (status PreAuthStatus) String() string {
+	names := [...]string{"Authorized", "Captured", "Released"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// PreAuthorization is a hold placed on a customer's card.
+// Its fields are only modified by the PaymentGateway that issued it.
+type PreAuthorization struct {
+	id             string        // Gateway reference (e.g., "AUTH-1")
+	customerID     string        // Card holder
+	amount         float64       // Amount held
+	capturedAmount float64       // Amount actually charged on capture
+	status         PreAuthStatus // Current state
+}
+
+func (auth *PreAuthorization) GetID() string              { return auth.id }
+func (auth *PreAuthorization) GetAmount() float64         { return auth.amount }
+func (auth *PreAuthorization) GetCapturedAmount() float64 { return auth.capturedAmount }
+
+// PaymentGateway abstracts the card processor (Strategy Pattern).
+type PaymentGateway interface {
+	Authorize(customerID string, amount float64) (*PreAuthorization, error)
+	Capture(auth *PreAuthorization, amount float64) error
+	Release(auth *PreAuthorization) error
+	GetStatus(auth *PreAuthorization) PreAuthStatus
+}
+
+// SimulatedPaymentGateway approves every authorization in memory.
+type SimulatedPaymentGateway struct {
+	authIDs *idgen.Sequence
+	mutex   sync.Mutex // Guards every authorization's state
+}
+
+// NewSimulatedPaymentGateway creates an in-memory payment gateway.
+func NewSimulatedPaymentGateway() *SimulatedPaymentGateway {
+	return &SimulatedPaymentGateway{authIDs: idgen.NewSequence("AUTH")}
+}
+
+// Authorize places a hold for the amount on the customer's card.
+func (gateway *SimulatedPaymentGateway) Authorize(customerID string, amount float64) (*PreAuthorization, error) {
+	gateway.mutex.Lock()
+	defer gateway.mutex.Unlock()
+
+	if amount <= 0 {
+		return nil, fmt.Errorf("authorization amount must be positive")
+	}
+
+	return &PreAuthorization{
+		id:         gateway.authIDs.NextID(),
+		customerID: customerID,
+		amount:     amount,
+		status:     PreAuthStatusAuthorized,
+	}, nil
+}
+
+// Capture charges the final amount against an authorization.
+// The final amount may exceed the hold (extras, damage); real processors
+// allow this within a tolerance, the simulation accepts any amount.
+func (gateway *SimulatedPaymentGateway) Capture(auth *PreAuthorization, amount float64) error {
+	gateway.mutex.Lock()
+	defer gateway.mutex.Unlock()
+
+	if auth.status != PreAuthStatusAuthorized {
+		return fmt.Errorf("cannot capture %s: authorization is %s", auth.id, auth.status)
+	}
+	auth.capturedAmount = amount
+	auth.status = PreAuthStatusCaptured
+	return nil
+}
+
+// Release removes the hold without charging the customer.
+func (gateway *SimulatedPaymentGateway) Release(auth *PreAuthorization) error {
+	gateway.mutex.Lock()
+	defer gateway.mutex.Unlock()
+
+	if auth.status != PreAuthStatusAuthorized {
+		return fmt.Errorf("cannot release %s: authorization is %s", auth.id, auth.status)
+	}
+	auth.status = PreAuthStatusReleased
+	return nil
+}
+
+// GetStatus returns the current state of an authorization.
+func (gateway *SimulatedPaymentGateway) GetStatus(auth *PreAuthorization) PreAuthStatus {
+	gateway.mutex.Lock()
+	defer gateway.mutex.Unlock()
+	return auth.status
+}
+
+// ============================================================================
+// SECTION 7: RESERVATION ENTITY
+// ============================================================================
+
+// Reservation represents a vehicle booking made by a customer.
+// It tracks the entire lifecycle from creation to completion.
+type Reservation struct {
+	id               string               // Unique identifier (e.g., "RES-1")
+	customer         *Customer            // Customer who made the reservation
+	vehicle          *Vehicle             // Reserved vehicle
+	pickupDate       time.Time            // When the rental starts
+	returnDate       time.Time            // When the rental ends
+	pickupLocation   string               // Where to pick up the vehicle
+	returnLocation   string               // Where to return the vehicle
+	status           ReservationStatus    // Current status of the reservation
+	dailyRate        float64              // Base daily rate at time of booking
+	totalAmount      float64              // Total cost including extras
+	extras           []Extra              // Additional services added
+	insurance        *InsuranceProduct    // Purchased insurance cover (nil if none)
+	damageCharge     float64              // Damage cost billed to the customer at return
+	preAuth          *PreAuthorization    // Card hold placed at creation
+	holdExpiresAt    time.Time            // Pending reservations auto-cancel after this
+	locationCheck    *ReturnLocationCheck // GPS check of where the vehicle was returned (nil before return)
+	dropOffFee       float64              // One-way fee for returning at a partner garage
+	pickupReport     *ConditionReport     // Inspection filed at pickup (nil until filed)
+	returnReport     *ConditionReport     // Inspection filed at return (nil until filed)
+	subscription     *Subscription        // Membership the rental is billed to (nil = per-day pricing)
+	milesDriven      int                  // Miles recorded at hand-back (subscriptions only)
+	excessMileageFee float64              // Charge for miles beyond the plan's monthly cap
+	createdAt        time.Time            // When the reservation was created
+	mutex            sync.Mutex           // Protects concurrent modifications
+}
+
+// reservationIDGen generates unique IDs for reservations.
+var reservationIDGen = idgen.NewSequence("RES")
+
+// NewReservation creates a new reservation for a customer and vehicle.
+// It calculates the initial total based on the number of rental days.
+func NewReservation(customer *Customer, vehicle *Vehicle, pickupDate, returnDate time.Time, location string) *Reservation {
+	rentalDays := calculateRentalDays(pickupDate, returnDate)
+	dailyRate := vehicle.GetDailyRate()
+
+	return &Reservation{
+		id:             reservationIDGen.NextID(),
+		customer:       customer,
+		vehicle:        vehicle,
+		pickupDate:     pickupDate,
+		returnDate:     returnDate,
+		pickupLocation: location,
+		returnLocation: location,
+		status:         ReservationStatusPending,
+		dailyRate:      dailyRate,
+		totalAmount:    dailyRate * float64(rentalDays),
+		extras:         make([]Extra, 0),
+		createdAt:      time.Now(),
+	}
+}
+
+// calculateRentalDays computes the number of days between two dates.
+// Minimum rental period is 1 day.
+func calculateRentalDays(startDate, endDate time.Time) int {
+	hours := endDate.Sub(startDate).Hours()
+	days := int(hours/24) + 1 // +1 because partial days count as full days
+	if days < 1 {
+		days = 1
+	}
+	return days
+}
+
+// Getter methods for Reservation
+func (reservation *Reservation) GetID() string     { return reservation.id }
+func (reservation *Reservation) GetTotal() float64 { return reservation.totalAmount }
+func (reservation *Reservation) GetStatus() ReservationStatus {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	return reservation.status
+}
+
+// GetPreAuthorization returns the card hold placed for this reservation.
+func (reservation *Reservation) GetPreAuthorization() *PreAuthorization {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	return reservation.preAuth
+}
+
+// GetHoldExpiresAt returns when an unconfirmed reservation will be released.
+func (reservation *Reservation) GetHoldExpiresAt() time.Time {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	return reservation.holdExpiresAt
+}
+
+// placeHold attaches the pre-authorization and holds the vehicle until expiresAt.
+func (reservation *Reservation) placeHold(auth *PreAuthorization, expiresAt time.Time) {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	reservation.preAuth = auth
+	reservation.holdExpiresAt = expiresAt
+	reservation.vehicle.SetStatus(VehicleStatusReserved)
+}
+
+// isHoldExpired reports whether a pending hold has lapsed.
+// Caller must hold reservation.mutex.
+func (reservation *Reservation) isHoldExpired(now time.Time) bool {
+	return reservation.status == ReservationStatusPending &&
+		!reservation.holdExpiresAt.IsZero() &&
+		!now.Before(reservation.holdExpiresAt)
+}
+
+// expireHold cancels the reservation if its hold has lapsed.
+// Returns true if the reservation was cancelled by this call.
+func (reservation *Reservation) expireHold(now time.Time) bool {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	if !reservation.isHoldExpired(now) {
+		return false
+	}
+
+	reservation.status = ReservationStatusCancelled
+	reservation.vehicle.SetStatus(VehicleStatusAvailable)
+	return true
+}
+
+// GetRentalDays returns the total number of rental days.
+func (reservation *Reservation) GetRentalDays() int {
+	return calculateRentalDays(reservation.pickupDate, reservation.returnDate)
+}
+
+// AddExtra adds an optional service/item to the reservation.
+// The extra must fit the vehicle type and can only be added once.
+// The extra's cost is added to the total for each rental day.
+func (reservation *Reservation) AddExtra(extra Extra) error {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	if reservation.status != ReservationStatusPending && reservation.status != ReservationStatusConfirmed {
+		return fmt.Errorf("cannot add extra: reservation is %s", reservation.status)
+	}
+	if !extra.IsCompatible(reservation.vehicle.GetType()) {
+		return fmt.Errorf("%s is not available for %s vehicles", extra.GetName(), reservation.vehicle.GetType())
+	}
+	for _, existing := range reservation.extras {
+		if existing.GetName() == extra.GetName() {
+			return fmt.Errorf("reservation already has %s", extra.GetName())
+		}
+	}
+
+	reservation.extras = append(reservation.extras, extra)
+
+	// Calculate extra cost: dailyPrice × number of rental days
+	rentalDays := calculateRentalDays(reservation.pickupDate, reservation.returnDate)
+	reservation.totalAmount += extra.GetDailyPrice() * float64(rentalDays)
+	return nil
+}
+
+// AddInsurance attaches an insurance product to the reservation.
+// The product must be eligible for the vehicle type and only one product
+// can be bought per reservation.
+func (reservation *Reservation) AddInsurance(product *InsuranceProduct) error {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	if reservation.status != ReservationStatusPending && reservation.status != ReservationStatusConfirmed {
+		return fmt.Errorf("cannot add insurance: reservation is %s", reservation.status)
+	}
+	if reservation.insurance != nil {
+		return fmt.Errorf("reservation already has %s insurance", reservation.insurance.GetTier())
+	}
+	if !product.IsEligible(reservation.vehicle.GetType()) {
+		return fmt.Errorf("%s insurance is not available for %s vehicles",
+			product.GetTier(), reservation.vehicle.GetType())
+	}
+
+	reservation.insurance = product
+	rentalDays := calculateRentalDays(reservation.pickupDate, reservation.returnDate)
+	reservation.totalAmount += product.GetDailyPrice() * float64(rentalDays)
+	return nil
+}
+
+// GetInsurance returns the purchased insurance product, or nil if uninsured.
+func (reservation *Reservation) GetInsurance() *InsuranceProduct {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	return reservation.insurance
+}
+
+// recordDamage splits a damage cost between customer and insurer and bills
+// the customer's share. Uninsured customers pay the full cost.
+func (reservation *Reservation) recordDamage(description string, damageCost float64) *InsuranceClaim {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	customerPays := damageCost
+	if reservation.insurance != nil {
+		customerPays = reservation.insurance.CustomerLiability(damageCost)
+	}
+
+	reservation.damageCharge += customerPays
+	reservation.totalAmount += customerPays
+
+	return &InsuranceClaim{
+		reservationID: reservation.id,
+		description:   description,
+		damageCost:    damageCost,
+		customerPays:  customerPays,
+		insurerPays:   damageCost - customerPays,
+		recordedAt:    time.Now(),
+	}
+}
+
+// Confirm moves the reservation from Pending to Confirmed status.
+// The vehicle is marked as Reserved to prevent double-booking.
+// A reservation whose hold has lapsed cannot be confirmed, even if the
+// expiry job has not processed it yet.
+func (reservation *Reservation) Confirm() error {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	if reservation.status != ReservationStatusPending {
+		return fmt.Errorf("cannot confirm: reservation is not in pending status (current: %s)", reservation.status)
+	}
+
+	if reservation.isHoldExpired(time.Now()) {
+		return fmt.Errorf("cannot confirm: hold expired at %s", reservation.holdExpiresAt.Format("15:04:05"))
+	}
+
+	reservation.status = ReservationStatusConfirmed
+	reservation.vehicle.SetStatus(VehicleStatusReserved)
+	return nil
+}
+
+// PickUp processes the vehicle pickup by the customer.
+// Only confirmed reservations can be picked up.
+func (reservation *Reservation) PickUp() error {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	if reservation.status != ReservationStatusConfirmed {
+		return fmt.Errorf("cannot pick up: reservation is not confirmed (current: %s)", reservation.status)
+	}
+
+	reservation.status = ReservationStatusPickedUp
+	reservation.vehicle.SetStatus(VehicleStatusRented)
+	return nil
+}
+
+// Return processes the vehicle return by the customer.
+// Only picked-up reservations can be returned.
+func (reservation *Reservation) Return() error {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	if reservation.status != ReservationStatusPickedUp {
+		return fmt.Errorf("cannot return: vehicle was not picked up (current: %s)", reservation.status)
+	}
+
+	reservation.status = ReservationStatusReturned
+	reservation.vehicle.SetStatus(VehicleStatusAvailable)
+
+	// Add to customer's rental history
+	reservation.customer.AddRentalToHistory(reservation)
+	return nil
+}
+
+// Cancel cancels the reservation if the vehicle hasn't been picked up.
+func (reservation *Reservation) Cancel() error {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	if reservation.status == ReservationStatusPickedUp {
+		return fmt.Errorf("cannot cancel: vehicle has already been picked up")
+	}
+
+	if reservation.status == ReservationStatusReturned {
+		return fmt.Errorf("cannot cancel: rental has already been completed")
+	}
+
+	if reservation.status == ReservationStatusCancelled {
+		return fmt.Errorf("reservation is already cancelled")
+	}
+
+	reservation.status = ReservationStatusCancelled
+	reservation.vehicle.SetStatus(VehicleStatusAvailable)
+	return nil
+}
+
+// PrintReceipt displays a formatted receipt for the reservation.
+func (reservation *Reservation) PrintReceipt() {
+	_ = reservation.BuildReceipt().WriteText(os.Stdout)
+}
+
+// ============================================================================
+// SECTION 8: RENTAL SERVICE (Main Business Logic)
+// ============================================================================
+
+// RentalService is the central service that manages the car rental operations.
+// It coordinates vehicles, customers, and reservations.
+type RentalService struct {
+	vehicles       map[string]*Vehicle         // All vehicles in the fleet (key: vehicle ID)
+	customers      map[string]*Customer        // All registered customers (key: customer ID)
+	reservations   map[string]*Reservation     // All reservations (key: reservation ID)
+	locations      []string                    // Available pickup/return locations
+	insurance      *InsuranceCatalog           // Insurance products offered
+	extras         *ExtrasCatalog              // Add-ons offered, with vehicle compatibility
+	claims         []*InsuranceClaim           // Damage claims recorded at return
+	payments       PaymentGateway              // Card processor for pre-authorizations
+	holdDuration   time.Duration               // How long a pending reservation holds the vehicle
+	expiryJob      periodic.Job                // Runs ExpireHolds in the background
+	telemetry      TelemetryStore              // Latest GPS position per vehicle
+	geofences      map[string]Geofence         // Return area per location (key: location name)
+	partners       map[string]PartnerGarage    // Partner garages accepting one-way returns (key: name)
+	rebalanceQueue []string                    // Vehicles accepted over a garage cap, awaiting transfer
+	photos         PhotoStore                  // Inspection photos referenced by condition reports
+	licenses       LicenseVerifier             // Driver license checks (nil = verification off)
+	notifier       *ReservationNotifier        // Lifecycle notifications (nil = notifications off)
+	noticeJob      periodic.Job                // Runs DispatchNotices in the background
+	plans          map[string]SubscriptionPlan // Subscription plans offered (key: plan name)
+	subscriptions  map[string]*Subscription    // Memberships (key: subscription ID)
+	members        map[string]*Subscription    // Active membership per customer (key: customer ID)
+	mutex          sync.RWMutex                // Read-write lock for thread-safe operations
+}
+
+// DefaultHoldDuration is how long an unconfirmed reservation holds a vehicle.
+const DefaultHoldDuration = 30 * time.Minute
+
+// NewRentalService creates and initializes a new RentalService.
+func NewRentalService() *RentalService {
+	return &RentalService{
+		vehicles:      make(map[string]*Vehicle),
+		customers:     make(map[string]*Customer),
+		reservations:  make(map[string]*Reservation),
+		locations:     []string{"Airport", "Downtown", "Mall"},
+		insurance:     NewInsuranceCatalog(),
+		extras:        NewExtrasCatalog(),
+		claims:        make([]*InsuranceClaim, 0),
+		payments:      NewSimulatedPaymentGateway(),
+		holdDuration:  DefaultHoldDuration,
+		telemetry:     NewInMemoryTelemetryStore(),
+		geofences:     make(map[string]Geofence),
+		partners:      make(map[string]PartnerGarage),
+		photos:        NewInMemoryPhotoStore(),
+		plans:         make(map[string]SubscriptionPlan),
+		subscriptions: make(map[string]*Subscription),
+		members:       make(map[string]*Subscription),
+	}
+}
+
+// SetHoldDuration changes the hold window for reservations created afterwards.
+func (service *RentalService) SetHoldDuration(duration time.Duration) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.holdDuration = duration
+}
+
+// GetPaymentStatus returns the state of a reservation's card hold.
+func (service *RentalService) GetPaymentStatus(reservation *Reservation) PreAuthStatus {
+	return service.payments.GetStatus(reservation.GetPreAuthorization())
+}
+
+// AddVehicle adds a vehicle to the fleet.
+func (service *RentalService) AddVehicle(vehicle *Vehicle) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.vehicles[vehicle.GetID()] = vehicle
+}
+
+// RegisterCustomer adds a new customer to the system.
+// If license verification is on, the customer's license must pass first.
+func (service *RentalService) RegisterCustomer(customer *Customer) error {
+	if verifier := service.getLicenseVerifier(); verifier != nil {
+		if err := verifier.Verify(customer.GetDriverLicense(), time.Now()); err != nil {
+			return err
+		}
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.customers[customer.GetID()] = customer
+	return nil
+}
+
+// GetAvailableVehiclesByType returns all available vehicles of a specific type at a location.
+func (service *RentalService) GetAvailableVehiclesByType(vehicleType VehicleType, location string) []*Vehicle {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	availableVehicles := make([]*Vehicle, 0)
+	for _, vehicle := range service.vehicles {
+		if vehicle.IsAvailable() &&
+			vehicle.GetType() == vehicleType &&
+			vehicle.GetLocation() == location {
+			availableVehicles = append(availableVehicles, vehicle)
+		}
+	}
+	return availableVehicles
+}
+
+// GetAllAvailableVehicles returns all available vehicles at a specific location.
+func (service *RentalService) GetAllAvailableVehicles(location string) []*Vehicle {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	availableVehicles := make([]*Vehicle, 0)
+	for _, vehicle := range service.vehicles {
+		if vehicle.IsAvailable() && vehicle.GetLocation() == location {
+			availableVehicles = append(availableVehicles, vehicle)
+		}
+	}
+	return availableVehicles
+}
+
+// CreateReservation creates a new reservation for a customer and vehicle.
+// Returns an error if the customer or vehicle doesn't exist, or if the vehicle isn't available.
+func (service *RentalService) CreateReservation(customerID, vehicleID string, pickupDate, returnDate time.Time) (*Reservation, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	// Validate customer exists
+	customer, customerExists := service.customers[customerID]
+	if !customerExists {
+		return nil, fmt.Errorf("customer with ID '%s' not found", customerID)
+	}
+
+	// Validate vehicle exists
+	vehicle, vehicleExists := service.vehicles[vehicleID]
+	if !vehicleExists {
+		return nil, fmt.Errorf("vehicle with ID '%s' not found", vehicleID)
+	}
+
+	// Validate vehicle availability
+	if !vehicle.IsAvailable() {
+		return nil, fmt.Errorf("vehicle '%s' is not available (status: %s)", vehicleID, vehicle.GetStatus())
+	}
+
+	// Validate dates
+	if returnDate.Before(pickupDate) {
+		return nil, fmt.Errorf("return date cannot be before pickup date")
+	}
+
+	// Create the reservation and pre-authorize the estimated total
+	reservation := NewReservation(customer, vehicle, pickupDate, returnDate, vehicle.GetLocation())
+	auth, err := service.payments.Authorize(customerID, reservation.GetTotal())
+	if err != nil {
+		return nil, fmt.Errorf("payment pre-authorization failed: %v", err)
+	}
+
+	// Hold the vehicle so nobody else can reserve it while we await confirmation
+	reservation.placeHold(auth, time.Now().Add(service.holdDuration))
+	service.reservations[reservation.GetID()] = reservation
+
+	return reservation, nil
+}
+
+// ExpireHolds cancels every pending reservation whose hold has lapsed,
+// releasing the vehicle and the payment pre-authorization.
+// Returns the IDs of the reservations that were expired.
+func (service *RentalService) ExpireHolds(now time.Time) []string {
+	service.mutex.RLock()
+	pending := make([]*Reservation, 0)
+	for _, reservation := range service.reservations {
+		if reservation.GetStatus() == ReservationStatusPending {
+			pending = append(pending, reservation)
+		}
+	}
+	service.mutex.RUnlock()
+
+	expiredIDs := make([]string, 0)
+	for _, reservation := range pending {
+		if !reservation.expireHold(now) {
+			continue // Confirmed (or still within its hold) in the meantime
+		}
+		if auth := reservation.GetPreAuthorization(); auth != nil {
+			service.payments.Release(auth)
+		}
+		expiredIDs = append(expiredIDs, reservation.GetID())
+	}
+	return expiredIDs
+}
+
+// StartHoldExpiryJob runs ExpireHolds in the background every interval.
+// Calling it while a job is already running has no effect.
+func (service *RentalService) StartHoldExpiryJob(interval time.Duration) {
+	service.expiryJob.Start(interval, func(now time.Time) {
+		for _, reservationID := range service.ExpireHolds(now) {
+			fmt.Printf("  ⏰ [expiry job] Hold expired, %s cancelled\n", reservationID)
+		}
+	})
+}
+
+// StopHoldExpiryJob stops the background expiry job and waits for a run in
+// progress to finish.
+func (service *RentalService) StopHoldExpiryJob() {
+	service.expiryJob.Stop()
+}
+
+// capturePayment charges the final total once a rental is completed.
+func (service *RentalService) capturePayment(reservation *Reservation) error {
+	auth := reservation.GetPreAuthorization()
+	if auth == nil {
+		return nil
+	}
+	return service.payments.Capture(auth, reservation.GetTotal())
+}
+
+// ConfirmReservation confirms a pending reservation.
+func (service *RentalService) ConfirmReservation(reservationID string) error {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	if err := reservation.Confirm(); err != nil {
+		return err
+	}
+	service.notifyConfirmed(reservation)
+	return nil
+}
+
+// PickUpVehicle processes the vehicle pickup for a reservation.
+// A license that fails verification blocks the pickup with a
+// *LicenseVerificationError; the reservation stays confirmed.
+func (service *RentalService) PickUpVehicle(reservationID string) error {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	if err := service.verifyLicenseForPickup(reservation); err != nil {
+		return err
+	}
+	return reservation.PickUp()
+}
+
+// ReturnVehicle processes the vehicle return for a reservation.
+func (service *RentalService) ReturnVehicle(reservationID string) error {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	if err := reservation.Return(); err != nil {
+		return err
+	}
+	service.checkReturnLocation(reservation)
+	return service.capturePayment(reservation)
+}
+
+// GetExtrasCatalog returns the add-ons offered.
+func (service *RentalService) GetExtrasCatalog() *ExtrasCatalog {
+	return service.extras
+}
+
+// GetCompatibleExtras lists the extras that can be added to a vehicle.
+func (service *RentalService) GetCompatibleExtras(vehicleID string) ([]Extra, error) {
+	service.mutex.RLock()
+	vehicle, exists := service.vehicles[vehicleID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("vehicle with ID '%s' not found", vehicleID)
+	}
+	return service.extras.GetCompatibleExtras(vehicle.GetType()), nil
+}
+
+// AddExtra adds a catalog extra to a reservation.
+func (service *RentalService) AddExtra(reservationID, extraName string) error {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	extra, err := service.extras.GetExtra(extraName)
+	if err != nil {
+		return err
+	}
+
+	return reservation.AddExtra(extra)
+}
+
+// GetInsuranceCatalog returns the insurance products offered.
+func (service *RentalService) GetInsuranceCatalog() *InsuranceCatalog {
+	return service.insurance
+}
+
+// AddInsurance buys an insurance tier for a reservation.
+func (service *RentalService) AddInsurance(reservationID string, tier InsuranceTier) error {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	product, err := service.insurance.GetProduct(tier)
+	if err != nil {
+		return err
+	}
+
+	return reservation.AddInsurance(product)
+}
+
+// ReturnVehicleWithDamage processes a return where damage was found.
+// The customer is charged up to their insurance deductible and a claim is recorded.
+func (service *RentalService) ReturnVehicleWithDamage(reservationID, description string, damageCost float64) (*InsuranceClaim, error) {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	if damageCost <= 0 {
+		return nil, fmt.Errorf("damage cost must be positive")
+	}
+
+	if err := reservation.Return(); err != nil {
+		return nil, err
+	}
+	service.checkReturnLocation(reservation)
+
+	claim := reservation.recordDamage(description, damageCost)
+
+	service.mutex.Lock()
+	service.claims = append(service.claims, claim)
+	service.mutex.Unlock()
+
+	if err := service.capturePayment(reservation); err != nil {
+		return claim, err
+	}
+	return claim, nil
+}
+
+// GetClaims returns all recorded insurance claims.
+func (service *RentalService) GetClaims() []*InsuranceClaim {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	claims := make([]*InsuranceClaim, len(service.claims))
+	copy(claims, service.claims)
+	return claims
+}
+
+// CancelReservation cancels an existing reservation and releases its card hold.
+func (service *RentalService) CancelReservation(reservationID string) error {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	if err := reservation.Cancel(); err != nil {
+		return err
+	}
+	if auth := reservation.GetPreAuthorization(); auth != nil {
+		return service.payments.Release(auth)
+	}
+	return nil
+}
+
+// ShowFleetStatus displays the current status of all vehicles in the fleet.
+func (service *RentalService) ShowFleetStatus() {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	fmt.Println("\n🚗 Fleet Status:")
+	fmt.Println("─────────────────────────────────────────")
+
+	for _, vehicle := range service.vehicles {
+		// Use green circle for available, red for unavailable
+		statusIcon := "🟢"
+		if vehicle.GetStatus() != VehicleStatusAvailable {
+			statusIcon = "🔴"
+		}
+		fmt.Printf("  %s [%s] %s\n", statusIcon, vehicle.GetLocation(), vehicle)
+	}
+}
+
+// GetLocations returns all available pickup/return locations.
+func (service *RentalService) GetLocations() []string {
+	return service.locations
+}
+
+// ============================================================================
+// SECTION 9: FLEET ANALYTICS
+// ============================================================================
+//
+// Reporting APIs return structured report types so callers can render them
+// however they like (dashboard, CSV export, alerts).
+//
+// Definitions used by every report over a period [from, to):
+// - Utilization: time a vehicle was committed to confirmed, active or
+//   completed rentals, divided by the length of the period
+// - Revenue: totals of completed rentals picked up within the period
+
+// VehicleUtilization is one vehicle's row in a utilization report.
+type VehicleUtilization struct {
+	VehicleID    string
+	LicensePlate string
+	Type         VehicleType
+	Location     string
+	Rentals      int     // Rentals overlapping the period
+	BookedHours  float64 // Hours committed to rentals within the period
+	Utilization  float64 // BookedHours / period hours (0.0 - 1.0)
+	Revenue      float64 // Revenue from completed rentals in the period
+}
+
+// GroupUtilization aggregates utilization for a vehicle type or location.
+type GroupUtilization struct {
+	Group       string
+	Vehicles    int
+	BookedHours float64
+	Utilization float64 // BookedHours / (Vehicles × period hours)
+	Revenue     float64
+}
+
+// CustomerRanking is one customer's row in a top-customers report.
+type CustomerRanking struct {
+	CustomerID string
+	Name       string
+	Rentals    int
+	Revenue    float64
+}
+
+// FleetReport bundles all analytics for a period.
+type FleetReport struct {
+	From         time.Time
+	To           time.Time
+	Vehicles     []VehicleUtilization
+	ByType       []GroupUtilization
+	ByLocation   []GroupUtilization
+	IdleVehicles []VehicleUtilization
+	TopCustomers []CustomerRanking
+}
+
+// validateReportPeriod checks that a report period is non-empty.
+func validateReportPeriod(from, to time.Time) error {
+	if !to.After(from) {
+		return fmt.Errorf("report period end must be after start")
+	}
+	return nil
+}
+
+// countsTowardUtilization reports whether the rental committed the vehicle.
+// Pending holds and cancelled rentals do not.
+func (reservation *Reservation) countsTowardUtilization() bool {
+	switch reservation.GetStatus() {
+	case ReservationStatusConfirmed, ReservationStatusPickedUp, ReservationStatusReturned:
+		return true
+	}
+	return false
+}
+
+// overlapWith returns how much of the rental falls inside [from, to).
+func (reservation *Reservation) overlapWith(from, to time.Time) time.Duration {
+	start := reservation.pickupDate
+	if from.After(start) {
+		start = from
+	}
+	end := reservation.returnDate
+	if to.Before(end) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// earnedRevenueIn returns the rental total if it was completed and picked up
+// within [from, to), otherwise 0.
+func (reservation *Reservation) earnedRevenueIn(from, to time.Time) float64 {
+	if reservation.GetStatus() != ReservationStatusReturned {
+		return 0
+	}
+	if reservation.pickupDate.Before(from) || !reservation.pickupDate.Before(to) {
+		return 0
+	}
+	return reservation.GetTotal()
+}
+
+// snapshotFleet copies vehicles and reservations so reports run without
+// holding the service lock.
+func (service *RentalService) snapshotFleet() ([]*Vehicle, []*Reservation) {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	vehicles := make([]*Vehicle, 0, len(service.vehicles))
+	for _, vehicle := range service.vehicles {
+		vehicles = append(vehicles, vehicle)
+	}
+	reservations := make([]*Reservation, 0, len(service.reservations))
+	for _, reservation := range service.reservations {
+		reservations = append(reservations, reservation)
+	}
+	return vehicles, reservations
+}
+
+// GetVehicleUtilization reports utilization and revenue per vehicle,
+// sorted by vehicle ID.
+func (service *RentalService) GetVehicleUtilization(from, to time.Time) ([]VehicleUtilization, error) {
+	if err := validateReportPeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	vehicles, reservations := service.snapshotFleet()
+	periodHours := to.Sub(from).Hours()
+
+	rowsByVehicle := make(map[string]*VehicleUtilization, len(vehicles))
+	for _, vehicle := range vehicles {
+		rowsByVehicle[vehicle.GetID()] = &VehicleUtilization{
+			VehicleID:    vehicle.GetID(),
+			LicensePlate: vehicle.GetLicensePlate(),
+			Type:         vehicle.GetType(),
+			Location:     vehicle.GetLocation(),
+		}
+	}
+
+	for _, reservation := range reservations {
+		row, exists := rowsByVehicle[reservation.vehicle.GetID()]
+		if !exists || !reservation.countsTowardUtilization() {
+			continue
+		}
+		overlap := reservation.overlapWith(from, to)
+		if overlap == 0 {
+			continue
+		}
+		row.Rentals++
+		row.BookedHours += overlap.Hours()
+		row.Revenue += reservation.earnedRevenueIn(from, to)
+	}
+
+	rows := make([]VehicleUtilization, 0, len(rowsByVehicle))
+	for _, row := range rowsByVehicle {
+		row.Utilization = row.BookedHours / periodHours
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].VehicleID < rows[j].VehicleID })
+	return rows, nil
+}
+
+// groupUtilization rolls vehicle rows up by the key returned from groupOf.
+func groupUtilization(rows []VehicleUtilization, periodHours float64, groupOf func(VehicleUtilization) string) []GroupUtilization {
+	groups := make(map[string]*GroupUtilization)
+	for _, row := range rows {
+		key := groupOf(row)
+		group, exists := groups[key]
+		if !exists {
+			group = &GroupUtilization{Group: key}
+			groups[key] = group
+		}
+		group.Vehicles++
+		group.BookedHours += row.BookedHours
+		group.Revenue += row.Revenue
+	}
+
+	result := make([]GroupUtilization, 0, len(groups))
+	for _, group := range groups {
+		group.Utilization = group.BookedHours / (float64(group.Vehicles) * periodHours)
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Group < result[j].Group })
+	return result
+}
+
+// GetUtilizationByType reports utilization and revenue per vehicle type.
+func (service *RentalService) GetUtilizationByType(from, to time.Time) ([]GroupUtilization, error) {
+	rows, err := service.GetVehicleUtilization(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return groupUtilization(rows, to.Sub(from).Hours(), func(row VehicleUtilization) string {
+		return row.Type.String()
+	}), nil
+}
+
+// GetUtilizationByLocation reports utilization and revenue per location.
+func (service *RentalService) GetUtilizationByLocation(from, to time.Time) ([]GroupUtilization, error) {
+	rows, err := service.GetVehicleUtilization(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return groupUtilization(rows, to.Sub(from).Hours(), func(row VehicleUtilization) string {
+		return row.Location
+	}), nil
+}
+
+// GetIdleVehicles returns vehicles whose utilization is below threshold
+// (e.g., 0.2 for "booked less than 20% of the time"), least used first.
+func (service *RentalService) GetIdleVehicles(from, to time.Time, threshold float64) ([]VehicleUtilization, error) {
+	rows, err := service.GetVehicleUtilization(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return filterIdle(rows, threshold), nil
+}
+
+// filterIdle keeps rows below the utilization threshold, least used first.
+func filterIdle(rows []VehicleUtilization, threshold float64) []VehicleUtilization {
+	idle := make([]VehicleUtilization, 0)
+	for _, row := range rows {
+		if row.Utilization < threshold {
+			idle = append(idle, row)
+		}
+	}
+	sort.SliceStable(idle, func(i, j int) bool { return idle[i].Utilization < idle[j].Utilization })
+	return idle
+}
+
+// GetTopCustomers ranks customers by revenue from completed rentals in the
+// period and returns at most limit entries.
+func (service *RentalService) GetTopCustomers(from, to time.Time, limit int) ([]CustomerRanking, error) {
+	if err := validateReportPeriod(from, to); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	_, reservations := service.snapshotFleet()
+
+	rankings := make(map[string]*CustomerRanking)
+	for _, reservation := range reservations {
+		revenue := reservation.earnedRevenueIn(from, to)
+		if revenue == 0 {
+			continue
+		}
+		customer := reservation.customer
+		ranking, exists := rankings[customer.GetID()]
+		if !exists {
+			ranking = &CustomerRanking{CustomerID: customer.GetID(), Name: customer.GetName()}
+			rankings[customer.GetID()] = ranking
+		}
+		ranking.Rentals++
+		ranking.Revenue += revenue
+	}
+
+	result := make([]CustomerRanking, 0, len(rankings))
+	for _, ranking := range rankings {
+		result = append(result, *ranking)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Revenue != result[j].Revenue {
+			return result[i].Revenue > result[j].Revenue
+		}
+		return result[i].CustomerID < result[j].CustomerID
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GenerateFleetReport builds every report for the period in one call.
+func (service *RentalService) GenerateFleetReport(from, to time.Time, idleThreshold float64, topCustomers int) (*FleetReport, error) {
+	vehicles, err := service.GetVehicleUtilization(from, to)
+	if err != nil {
+		return nil, err
+	}
+	customers, err := service.GetTopCustomers(from, to, topCustomers)
+	if err != nil {
+		return nil, err
+	}
+
+	periodHours := to.Sub(from).Hours()
+	return &FleetReport{
+		From:     from,
+		To:       to,
+		Vehicles: vehicles,
+		ByType: groupUtilization(vehicles, periodHours, func(row VehicleUtilization) string {
+			return row.Type.String()
+		}),
+		ByLocation: groupUtilization(vehicles, periodHours, func(row VehicleUtilization) string {
+			return row.Location
+		}),
+		IdleVehicles: filterIdle(vehicles, idleThreshold),
+		TopCustomers: customers,
+	}, nil
+}
+
+// WriteVehiclesCSV exports the per-vehicle rows as CSV.
+func (report *FleetReport) WriteVehiclesCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"vehicle_id", "plate", "type", "location", "rentals", "booked_hours", "utilization_pct", "revenue"})
+	for _, row := range report.Vehicles {
+		csvWriter.Write([]string{
+			row.VehicleID,
+			row.LicensePlate,
+			row.Type.String(),
+			row.Location,
+			strconv.Itoa(row.Rentals),
+			strconv.FormatFloat(row.BookedHours, 'f', 1, 64),
+			strconv.FormatFloat(row.Utilization*100, 'f', 1, 64),
+			money.FormatPlain(row.Revenue),
+		})
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// WriteCustomersCSV exports the top-customers ranking as CSV.
+func (report *FleetReport) WriteCustomersCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"rank", "customer_id", "name", "rentals", "revenue"})
+	for rank, ranking := range report.TopCustomers {
+		csvWriter.Write([]string{
+			strconv.Itoa(rank + 1),
+			ranking.CustomerID,
+			ranking.Name,
+			strconv.Itoa(ranking.Rentals),
+			money.FormatPlain(ranking.Revenue),
+		})
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// ============================================================================
+// SECTION 10: GPS TELEMETRY & GEOFENCED RETURNS
+// ============================================================================
+//
+// Vehicles report GPS pings, and a TelemetryStore keeps the latest position
+// of each vehicle. Every rental location can have a circular geofence.
+//
+// At return, the vehicle's last known position must be inside the geofence
+// of the reservation's return location. Otherwise the customer pays a
+// wrong-location fee: a base fee plus a per-km charge for the distance
+// outside the fence. The vehicle is moved to whichever location's fence it
+// is actually in. A return without a recent ping cannot be verified and is
+// not charged.
+
+const (
+	// WrongLocationBaseFee is charged when a vehicle is left outside the
+	// return location's geofence.
+	WrongLocationBaseFee = 75.00
+
+	// WrongLocationFeePerKm is added for every km outside the geofence.
+	WrongLocationFeePerKm = 2.00
+
+	// MaxPingAge is how old the last ping may be to verify a return.
+	MaxPingAge = 15 * time.Minute
+
+	earthRadiusMeters = 6371000.0
+)
+
+// GeoPoint is a WGS84 coordinate in decimal degrees.
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Validate checks that the coordinate is on the globe.
+func (point GeoPoint) Validate() error {
+	if point.Latitude < -90 || point.Latitude > 90 || point.Longitude < -180 || point.Longitude > 180 {
+		return fmt.Errorf("invalid coordinate (%.5f, %.5f)", point.Latitude, point.Longitude)
+	}
+	return nil
+}
+
+// DistanceTo returns the great-circle distance in meters (haversine formula).
+func (point GeoPoint) DistanceTo(other GeoPoint) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	deltaLatitude := toRadians(other.Latitude - point.Latitude)
+	deltaLongitude := toRadians(other.Longitude - point.Longitude)
+	a := math.Sin(deltaLatitude/2)*math.Sin(deltaLatitude/2) +
+		math.Cos(toRadians(point.Latitude))*math.Cos(toRadians(other.Latitude))*
+			math.Sin(deltaLongitude/2)*math.Sin(deltaLongitude/2)
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// Geofence is a circular area around a rental location.
+type Geofence struct {
+	Location     string   // Rental location name (e.g., "Airport")
+	Center       GeoPoint // Center of the lot
+	RadiusMeters float64  // Accepted return radius
+}
+
+// Contains reports whether the point lies inside the fence.
+func (fence Geofence) Contains(point GeoPoint) bool {
+	return fence.Center.DistanceTo(point) <= fence.RadiusMeters
+}
+
+// GPSPing is one position report from a vehicle's tracker.
+type GPSPing struct {
+	VehicleID  string
+	Position   GeoPoint
+	RecordedAt time.Time // When the tracker took the fix
+}
+
+// TelemetryStore ingests GPS pings and serves the latest position per vehicle.
+// Swap the in-memory store for a time-series database in production.
+type TelemetryStore interface {
+	// Record stores the ping. Returns false if a newer ping is already
+	// stored for the vehicle (trackers may deliver out of order).
+	Record(ping GPSPing) bool
+	// Latest returns the most recent ping for the vehicle.
+	Latest(vehicleID string) (GPSPing, bool)
+}
+
+// InMemoryTelemetryStore keeps only the latest ping per vehicle.
+type InMemoryTelemetryStore struct {
+	latest map[string]GPSPing
+	mutex  sync.RWMutex
+}
+
+// NewInMemoryTelemetryStore creates an empty telemetry store.
+func NewInMemoryTelemetryStore() *InMemoryTelemetryStore {
+	return &InMemoryTelemetryStore{latest: make(map[string]GPSPing)}
+}
+
+// Record stores the ping unless a newer one is already known.
+func (store *InMemoryTelemetryStore) Record(ping GPSPing) bool {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if current, exists := store.latest[ping.VehicleID]; exists && !ping.RecordedAt.After(current.RecordedAt) {
+		return false
+	}
+	store.latest[ping.VehicleID] = ping
+	return true
+}
+
+// Latest returns the most recent ping for the vehicle.
+func (store *InMemoryTelemetryStore) Latest(vehicleID string) (GPSPing, bool) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	ping, exists := store.latest[vehicleID]
+	return ping, exists
+}
+
+// ReturnLocationCheck is the outcome of the GPS check made at return.
+type ReturnLocationCheck struct {
+	Verified         bool     // False if there was no recent ping or no geofence
+	Position         GeoPoint // Last known position used for the check
+	PingAge          time.Duration
+	DetectedLocation string  // Location whose geofence contains the vehicle ("" if none)
+	DistanceMeters   float64 // Distance from the return location's center
+	Fee              float64 // Wrong-location fee billed (0 if returned correctly)
+}
+
+// SetTelemetryStore replaces the store used for GPS pings.
+func (service *RentalService) SetTelemetryStore(store TelemetryStore) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.telemetry = store
+}
+
+// SetLocationGeofence defines the return area of a rental location.
+func (service *RentalService) SetLocationGeofence(location string, center GeoPoint, radiusMeters float64) error {
+	if err := center.Validate(); err != nil {
+		return err
+	}
+	if radiusMeters <= 0 {
+		return fmt.Errorf("geofence radius must be positive")
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	knownLocation := false
+	for _, name := range service.locations {
+		if name == location {
+			knownLocation = true
+			break
+		}
+	}
+	if !knownLocation {
+		return fmt.Errorf("unknown location '%s'", location)
+	}
+
+	service.geofences[location] = Geofence{Location: location, Center: center, RadiusMeters: radiusMeters}
+	return nil
+}
+
+// IngestPing records a GPS ping from a vehicle's tracker.
+// Returns false if the ping was older than the latest known position.
+func (service *RentalService) IngestPing(ping GPSPing) (bool, error) {
+	if err := ping.Position.Validate(); err != nil {
+		return false, err
+	}
+
+	service.mutex.RLock()
+	_, exists := service.vehicles[ping.VehicleID]
+	store := service.telemetry
+	service.mutex.RUnlock()
+
+	if !exists {
+		return false, fmt.Errorf("vehicle with ID '%s' not found", ping.VehicleID)
+	}
+	return store.Record(ping), nil
+}
+
+// GetLastKnownPosition returns the latest GPS ping of a vehicle.
+func (service *RentalService) GetLastKnownPosition(vehicleID string) (GPSPing, error) {
+	service.mutex.RLock()
+	store := service.telemetry
+	service.mutex.RUnlock()
+
+	ping, exists := store.Latest(vehicleID)
+	if !exists {
+		return GPSPing{}, fmt.Errorf("no GPS position for vehicle '%s'", vehicleID)
+	}
+	return ping, nil
+}
+
+// checkReturnLocation validates the returned vehicle's position against the
+// return location's geofence, bills any wrong-location fee and moves the
+// vehicle to the location it was actually left at.
+func (service *RentalService) checkReturnLocation(reservation *Reservation) {
+	service.mutex.RLock()
+	store := service.telemetry
+	fence, hasFence := service.geofences[reservation.returnLocation]
+	fences := make([]Geofence, 0, len(service.geofences))
+	for _, name := range service.locations {
+		if candidate, exists := service.geofences[name]; exists {
+			fences = append(fences, candidate)
+		}
+	}
+	service.mutex.RUnlock()
+
+	check := &ReturnLocationCheck{}
+	ping, hasPing := store.Latest(reservation.vehicle.GetID())
+	if hasPing {
+		check.Position = ping.Position
+		check.PingAge = time.Since(ping.RecordedAt)
+	}
+
+	if hasFence && hasPing && check.PingAge <= MaxPingAge {
+		check.Verified = true
+		check.DistanceMeters = fence.Center.DistanceTo(ping.Position)
+		for _, candidate := range fences {
+			if candidate.Contains(ping.Position) {
+				check.DetectedLocation = candidate.Location
+				break
+			}
+		}
+		if !fence.Contains(ping.Position) {
+			kmOutside := (check.DistanceMeters - fence.RadiusMeters) / 1000
+			check.Fee = WrongLocationBaseFee + money.Round(kmOutside*WrongLocationFeePerKm)
+		}
+		if check.DetectedLocation != "" {
+			reservation.vehicle.setLocation(check.DetectedLocation)
+		}
+	}
+
+	reservation.mutex.Lock()
+	reservation.locationCheck = check
+	reservation.totalAmount += check.Fee
+	reservation.mutex.Unlock()
+}
+
+// GetReturnLocationCheck returns the GPS check made at return (nil before return).
+func (reservation *Reservation) GetReturnLocationCheck() *ReturnLocationCheck {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	return reservation.locationCheck
+}
+
+// ============================================================================
+// SECTION 11: PARTNER GARAGES & FLEET REBALANCING
+// ============================================================================
+//
+// Partner garages let customers return a vehicle in another city. Each
+// garage has a total capacity and optional per-type caps (e.g. at most two
+// SUVs), and charges a one-way drop-off fee. Our own locations are not
+// capped.
+//
+// When a return would push a garage over a cap, the garage's overflow
+// policy decides what happens:
+//   - OverflowReject: the return is refused and the customer must go elsewhere
+//   - OverflowQueue:  the return is accepted and the vehicle is queued for
+//     rebalancing, so it is moved out at the next transfer run
+//
+// The rebalancing planner only suggests transfers. Queued vehicles go
+// first, then anything still over a cap. Each vehicle goes to a garage
+// with room in the same city, or else to the home location holding the
+// fewest vehicles of its type. Transfers are applied one by one with
+// ExecuteTransfer, which re-checks capacity at that point.
+
+// HomeCity is the city our own rental locations are in.
+const HomeCity = "New York"
+
+// OverflowPolicy decides what a partner garage does with an over-cap return.
+type OverflowPolicy int
+
+const (
+	OverflowReject OverflowPolicy = iota // 0 - Refuse the return
+	OverflowQueue                        // 1 - Accept and queue for rebalancing
+)
+
+// String returns a human-readable name for the overflow policy.
+func (policy OverflowPolicy) String() string {
+	names := [...]string{"Reject", "Queue"}
+	if int(policy) < len(names) {
+		return names[policy]
+	}
+	return "Unknown"
+}
+
+// PartnerGarage is a third-party location that accepts one-way returns.
+type PartnerGarage struct {
+	Name       string              // Location name (must be unique across all locations)
+	City       string              // City the garage is in
+	Capacity   int                 // Maximum vehicles parked at once
+	TypeCaps   map[VehicleType]int // Optional per-type limits (missing = only Capacity applies)
+	DropOffFee float64             // One-way fee charged for returning here
+	Overflow   OverflowPolicy      // What to do with a return beyond a cap
+}
+
+// Validate checks the garage definition.
+func (garage PartnerGarage) Validate() error {
+	if garage.Name == "" || garage.City == "" {
+		return fmt.Errorf("partner garage needs a name and a city")
+	}
+	if garage.Capacity <= 0 {
+		return fmt.Errorf("partner garage '%s' needs a positive capacity", garage.Name)
+	}
+	for vehicleType, limit := range garage.TypeCaps {
+		if limit < 0 || limit > garage.Capacity {
+			return fmt.Errorf("cap for %s at '%s' must be between 0 and %d", vehicleType, garage.Name, garage.Capacity)
+		}
+	}
+	if garage.DropOffFee < 0 {
+		return fmt.Errorf("drop-off fee cannot be negative")
+	}
+	return nil
+}
+
+// typeCap returns the limit for a vehicle type (Capacity if uncapped).
+func (garage PartnerGarage) typeCap(vehicleType VehicleType) int {
+	if limit, capped := garage.TypeCaps[vehicleType]; capped {
+		return limit
+	}
+	return garage.Capacity
+}
+
+// GarageOccupancy counts the vehicles parked at a location.
+type GarageOccupancy struct {
+	Total  int
+	ByType map[VehicleType]int
+}
+
+// fits reports whether one more vehicle of the type stays within the garage's caps.
+func (occupancy GarageOccupancy) fits(garage PartnerGarage, vehicleType VehicleType) bool {
+	return occupancy.Total < garage.Capacity && occupancy.ByType[vehicleType] < garage.typeCap(vehicleType)
+}
+
+// VehicleTransfer is a suggested move of one vehicle between locations.
+type VehicleTransfer struct {
+	VehicleID   string
+	VehicleType VehicleType
+	From        string
+	To          string
+	Reason      string
+}
+
+// String returns a one-line description of the transfer.
+func (transfer VehicleTransfer) String() string {
+	return fmt.Sprintf("%s (%s): %s → %s [%s]",
+		transfer.VehicleID, transfer.VehicleType, transfer.From, transfer.To, transfer.Reason)
+}
+
+// AddPartnerGarage registers a partner garage as a return location.
+func (service *RentalService) AddPartnerGarage(garage PartnerGarage) error {
+	if err := garage.Validate(); err != nil {
+		return err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	for _, name := range service.locations {
+		if name == garage.Name {
+			return fmt.Errorf("location '%s' already exists", garage.Name)
+		}
+	}
+	service.partners[garage.Name] = garage
+	service.locations = append(service.locations, garage.Name)
+	return nil
+}
+
+// GetPartnerGarage returns a partner garage by name.
+func (service *RentalService) GetPartnerGarage(name string) (PartnerGarage, bool) {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+	garage, exists := service.partners[name]
+	return garage, exists
+}
+
+// cityOf returns the city a location is in.
+// Caller must hold service.mutex.
+func (service *RentalService) cityOf(location string) string {
+	if garage, isPartner := service.partners[location]; isPartner {
+		return garage.City
+	}
+	return HomeCity
+}
+
+// occupancyAt counts vehicles parked at a location (rented vehicles are away).
+// Caller must hold service.mutex.
+func (service *RentalService) occupancyAt(location string) GarageOccupancy {
+	occupancy := GarageOccupancy{ByType: make(map[VehicleType]int)}
+	for _, vehicle := range service.vehicles {
+		if vehicle.GetLocation() == location && vehicle.GetStatus() != VehicleStatusRented {
+			occupancy.Total++
+			occupancy.ByType[vehicle.GetType()]++
+		}
+	}
+	return occupancy
+}
+
+// GetGarageOccupancy returns how many vehicles are parked at a partner garage.
+func (service *RentalService) GetGarageOccupancy(name string) (GarageOccupancy, error) {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	if _, isPartner := service.partners[name]; !isPartner {
+		return GarageOccupancy{}, fmt.Errorf("partner garage '%s' not found", name)
+	}
+	return service.occupancyAt(name), nil
+}
+
+// ReturnVehicleAt returns a vehicle at any location, including a partner
+// garage in another city. Returns that would exceed a garage's caps are
+// refused or queued for rebalancing, depending on the garage's policy.
+func (service *RentalService) ReturnVehicleAt(reservationID, location string) error {
+	service.mutex.Lock()
+
+	reservation, exists := service.reservations[reservationID]
+	if !exists {
+		service.mutex.Unlock()
+		return fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	knownLocation := false
+	for _, name := range service.locations {
+		if name == location {
+			knownLocation = true
+			break
+		}
+	}
+	if !knownLocation {
+		service.mutex.Unlock()
+		return fmt.Errorf("unknown location '%s'", location)
+	}
+
+	vehicle := reservation.vehicle
+	garage, isPartner := service.partners[location]
+	overCap := isPartner && !service.occupancyAt(location).fits(garage, vehicle.GetType())
+	if overCap && garage.Overflow == OverflowReject {
+		service.mutex.Unlock()
+		return fmt.Errorf("%s is full for %s vehicles, please return elsewhere", location, vehicle.GetType())
+	}
+
+	if err := reservation.returnAt(location, garage.DropOffFee); err != nil {
+		service.mutex.Unlock()
+		return err
+	}
+	vehicle.setLocation(location)
+	if overCap {
+		service.rebalanceQueue = append(service.rebalanceQueue, vehicle.GetID())
+	}
+	service.mutex.Unlock()
+
+	service.checkReturnLocation(reservation)
+	return service.capturePayment(reservation)
+}
+
+// returnAt completes the return and records where the vehicle was actually
+// left and any drop-off fee.
+func (reservation *Reservation) returnAt(location string, dropOffFee float64) error {
+	if err := reservation.Return(); err != nil {
+		return err
+	}
+
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	reservation.returnLocation = location
+	reservation.dropOffFee = dropOffFee
+	reservation.totalAmount += dropOffFee
+	return nil
+}
+
+// GetRebalancingQueue returns the IDs of vehicles accepted over a garage cap
+// that still need to be moved.
+func (service *RentalService) GetRebalancingQueue() []string {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+	return append([]string(nil), service.rebalanceQueue...)
+}
+
+// PlanRebalancing suggests transfers that bring every partner garage back
+// within its caps. It does not move anything.
+func (service *RentalService) PlanRebalancing() []VehicleTransfer {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	// Working copy of occupancy, updated as transfers are planned
+	occupancy := make(map[string]GarageOccupancy)
+	for _, location := range service.locations {
+		occupancy[location] = service.occupancyAt(location)
+	}
+
+	queued := make(map[string]bool)
+	for _, vehicleID := range service.rebalanceQueue {
+		queued[vehicleID] = true
+	}
+
+	garageNames := make([]string, 0, len(service.partners))
+	for name := range service.partners {
+		garageNames = append(garageNames, name)
+	}
+	sort.Strings(garageNames)
+
+	transfers := make([]VehicleTransfer, 0)
+	for _, name := range garageNames {
+		garage := service.partners[name]
+
+		// Movable vehicles parked here: queued ones first, then by ID
+		parked := make([]*Vehicle, 0)
+		for _, vehicle := range service.vehicles {
+			if vehicle.GetLocation() == name && vehicle.IsAvailable() {
+				parked = append(parked, vehicle)
+			}
+		}
+		sort.Slice(parked, func(i, j int) bool {
+			if queued[parked[i].GetID()] != queued[parked[j].GetID()] {
+				return queued[parked[i].GetID()]
+			}
+			return parked[i].GetID() < parked[j].GetID()
+		})
+
+		for _, vehicle := range parked {
+			here := occupancy[name]
+			vehicleType := vehicle.GetType()
+			var reason string
+			switch {
+			case queued[vehicle.GetID()]:
+				reason = "queued overflow return"
+			case here.ByType[vehicleType] > garage.typeCap(vehicleType):
+				reason = fmt.Sprintf("over %s cap (%d/%d)", vehicleType, here.ByType[vehicleType], garage.typeCap(vehicleType))
+			case here.Total > garage.Capacity:
+				reason = fmt.Sprintf("over capacity (%d/%d)", here.Total, garage.Capacity)
+			default:
+				continue
+			}
+
+			destination := service.pickDestination(name, vehicleType, occupancy)
+			if destination == "" {
+				continue
+			}
+			transfers = append(transfers, VehicleTransfer{
+				VehicleID:   vehicle.GetID(),
+				VehicleType: vehicleType,
+				From:        name,
+				To:          destination,
+				Reason:      reason,
+			})
+
+			here.Total--
+			here.ByType[vehicleType]--
+			there := occupancy[destination]
+			there.Total++
+			there.ByType[vehicleType]++
+			occupancy[destination] = there
+		}
+	}
+	return transfers
+}
+
+// pickDestination chooses where to move a vehicle out of an over-cap garage:
+// a partner garage in the same city with room, else the home location with
+// the fewest vehicles of that type. Returns "" if nothing fits.
+// Caller must hold service.mutex.
+func (service *RentalService) pickDestination(from string, vehicleType VehicleType, occupancy map[string]GarageOccupancy) string {
+	city := service.cityOf(from)
+	best := ""
+	for _, location := range service.locations {
+		if location == from {
+			continue
+		}
+		garage, isPartner := service.partners[location]
+		if isPartner {
+			if garage.City == city && occupancy[location].fits(garage, vehicleType) {
+				return location
+			}
+			continue
+		}
+		if best == "" || occupancy[location].ByType[vehicleType] < occupancy[best].ByType[vehicleType] {
+			best = location
+		}
+	}
+	return best
+}
+
+// ExecuteTransfer moves a vehicle as planned. Capacity at the destination is
+// re-checked, since the fleet may have changed since the plan was made.
+func (service *RentalService) ExecuteTransfer(transfer VehicleTransfer) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	vehicle, exists := service.vehicles[transfer.VehicleID]
+	if !exists {
+		return fmt.Errorf("vehicle with ID '%s' not found", transfer.VehicleID)
+	}
+	if vehicle.GetLocation() != transfer.From {
+		return fmt.Errorf("vehicle '%s' is no longer at %s", transfer.VehicleID, transfer.From)
+	}
+	if !vehicle.IsAvailable() {
+		return fmt.Errorf("vehicle '%s' cannot be moved (status: %s)", transfer.VehicleID, vehicle.GetStatus())
+	}
+	if garage, isPartner := service.partners[transfer.To]; isPartner &&
+		!service.occupancyAt(transfer.To).fits(garage, vehicle.GetType()) {
+		return fmt.Errorf("%s has no room for another %s", transfer.To, vehicle.GetType())
+	}
+
+	vehicle.setLocation(transfer.To)
+	for i, vehicleID := range service.rebalanceQueue {
+		if vehicleID == transfer.VehicleID {
+			service.rebalanceQueue = append(service.rebalanceQueue[:i], service.rebalanceQueue[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// SECTION 12: VEHICLE CONDITION REPORTS
+// ============================================================================
+//
+// A condition report is filed at pickup and again at return. It lists every
+// damage found as a structured annotation (panel, kind, severity, repair
+// estimate) and can point to photos of it.
+//
+// Photos are not kept in the report itself. They go to a PhotoStore, and the
+// report only keeps references:
+//   - InMemoryPhotoStore keeps uploaded bytes (tests, demos)
+//   - DirectoryPhotoStore writes each photo to disk; the ref is its path
+//
+// At return, the two reports are diffed. Damage that is new, or worse than
+// at pickup, is what the customer is charged for. Anything already noted at
+// pickup is not, so the pickup report protects the customer as much as the
+// company.
+
+// ReportStage says when a condition report was filed.
+type ReportStage int
+
+const (
+	ReportStagePickup ReportStage = iota // 0 - Before the customer drives off
+	ReportStageReturn                    // 1 - When the vehicle comes back
+)
+
+// String returns a human-readable name for the report stage.
+func (stage ReportStage) String() string {
+	switch stage {
+	case ReportStagePickup:
+		return "Pickup"
+	case ReportStageReturn:
+		return "Return"
+	default:
+		return "Unknown"
+	}
+}
+
+// DamageSeverity grades a single damage annotation.
+type DamageSeverity int
+
+const (
+	DamageMinor    DamageSeverity = iota + 1 // 1 - Cosmetic (light scratch, scuff)
+	DamageModerate                           // 2 - Needs repair (dent, deep scratch)
+	DamageSevere                             // 3 - Part replacement (cracked glass, broken light)
+)
+
+// String returns a human-readable name for the severity.
+func (severity DamageSeverity) String() string {
+	switch severity {
+	case DamageMinor:
+		return "Minor"
+	case DamageModerate:
+		return "Moderate"
+	case DamageSevere:
+		return "Severe"
+	default:
+		return "Unknown"
+	}
+}
+
+// DamageAnnotation is one damage found during an inspection.
+// Annotations with the same Panel and Kind are the same damage across reports.
+type DamageAnnotation struct {
+	Panel      string         // Where on the vehicle (e.g. "front bumper")
+	Kind       string         // What it is (e.g. "scratch", "dent")
+	Severity   DamageSeverity // How bad it is
+	RepairCost float64        // Estimated cost to repair
+	PhotoRefs  []string       // References returned by the PhotoStore
+}
+
+// key identifies the damage across pickup and return reports.
+func (annotation DamageAnnotation) key() string {
+	return strings.ToLower(annotation.Panel) + "/" + strings.ToLower(annotation.Kind)
+}
+
+// String formats the annotation for reports and claim descriptions.
+func (annotation DamageAnnotation) String() string {
+	return fmt.Sprintf("%s %s (%s, $%.2f)", annotation.Panel, annotation.Kind, annotation.Severity, annotation.RepairCost)
+}
+
+// Validate checks that the annotation is complete.
+func (annotation DamageAnnotation) Validate() error {
+	if annotation.Panel == "" || annotation.Kind == "" {
+		return fmt.Errorf("damage annotation needs a panel and a kind")
+	}
+	if annotation.Severity < DamageMinor || annotation.Severity > DamageSevere {
+		return fmt.Errorf("invalid severity for %s %s", annotation.Panel, annotation.Kind)
+	}
+	if annotation.RepairCost < 0 {
+		return fmt.Errorf("repair cost for %s %s cannot be negative", annotation.Panel, annotation.Kind)
+	}
+	return nil
+}
+
+// PhotoStore saves inspection photos and hands back a reference to them.
+// Swap in an object store (S3, GCS) in production.
+type PhotoStore interface {
+	// Save stores the photo and returns its reference.
+	Save(reservationID, name string, data []byte) (string, error)
+	// Load returns the photo bytes for a reference.
+	Load(ref string) ([]byte, error)
+	// Exists reports whether a reference points to a stored photo.
+	Exists(ref string) bool
+}
+
+// InMemoryPhotoStore keeps photo bytes in memory.
+type InMemoryPhotoStore struct {
+	photos map[string][]byte
+	mutex  sync.RWMutex
+}
+
+// NewInMemoryPhotoStore creates an empty in-memory photo store.
+func NewInMemoryPhotoStore() *InMemoryPhotoStore {
+	return &InMemoryPhotoStore{photos: make(map[string][]byte)}
+}
+
+// Save stores a copy of the photo under "mem://<reservation>/<name>".
+func (store *InMemoryPhotoStore) Save(reservationID, name string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", fmt.Errorf("photo %q is empty", name)
+	}
+	ref := fmt.Sprintf("mem://%s/%s", reservationID, name)
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if _, exists := store.photos[ref]; exists {
+		return "", fmt.Errorf("photo %s already exists", ref)
+	}
+	store.photos[ref] = append([]byte(nil), data...)
+	return ref, nil
+}
+
+// Load returns the stored photo bytes.
+func (store *InMemoryPhotoStore) Load(ref string) ([]byte, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	data, exists := store.photos[ref]
+	if !exists {
+		return nil, fmt.Errorf("photo %s not found", ref)
+	}
+	return data, nil
+}
+
+// Exists reports whether the photo was stored.
+func (store *InMemoryPhotoStore) Exists(ref string) bool {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	_, exists := store.photos[ref]
+	return exists
+}
+
+// DirectoryPhotoStore writes photos to <root>/<reservation>/<name>.
+// The reference is the file path.
+type DirectoryPhotoStore struct {
+	root string
+}
+
+// NewDirectoryPhotoStore creates a photo store rooted at a directory.
+func NewDirectoryPhotoStore(root string) (*DirectoryPhotoStore, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create photo directory: %w", err)
+	}
+	return &DirectoryPhotoStore{root: root}, nil
+}
+
+// Save writes the photo file; an existing file is never overwritten.
+func (store *DirectoryPhotoStore) Save(reservationID, name string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", fmt.Errorf("photo %q is empty", name)
+	}
+	if name != filepath.Base(name) {
+		return "", fmt.Errorf("photo name %q must not contain a path", name)
+	}
+	directory := filepath.Join(store.root, reservationID)
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", fmt.Errorf("failed to create photo directory: %w", err)
+	}
+
+	path := filepath.Join(directory, name)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to save photo: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to save photo: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to save photo: %w", err)
+	}
+	return path, nil
+}
+
+// Load reads the photo file.
+func (store *DirectoryPhotoStore) Load(ref string) ([]byte, error) {
+	return os.ReadFile(ref)
+}
+
+// Exists reports whether the photo file is present.
+func (store *DirectoryPhotoStore) Exists(ref string) bool {
+	info, err := os.Stat(ref)
+	return err == nil && !info.IsDir()
+}
+
+// ConditionReport is the inspection record filed at pickup or return.
+type ConditionReport struct {
+	ID            string
+	ReservationID string
+	VehicleID     string
+	Stage         ReportStage
+	Inspector     string
+	Notes         string
+	Damages       []DamageAnnotation
+	RecordedAt    time.Time
+}
+
+// conditionReportIDGen generates unique IDs for condition reports.
+var conditionReportIDGen = idgen.NewSequence("CR")
+
+// DamageChange is damage that got worse between pickup and return.
+type DamageChange struct {
+	Before DamageAnnotation
+	After  DamageAnnotation
+}
+
+// ConditionDiff is what changed between the pickup and return reports.
+type ConditionDiff struct {
+	NewDamage  []DamageAnnotation // Not in the pickup report
+	Worsened   []DamageChange     // In both, but more severe at return
+	Unchanged  []DamageAnnotation // Pre-existing damage, not charged
+	Chargeable float64            // Repair cost attributable to this rental
+}
+
+// HasChargeableDamage reports whether the customer caused any damage.
+func (diff *ConditionDiff) HasChargeableDamage() bool {
+	return diff.ChargeableCount() > 0
+}
+
+// ChargeableCount returns how many damages the customer is charged for.
+func (diff *ConditionDiff) ChargeableCount() int {
+	return len(diff.NewDamage) + len(diff.Worsened)
+}
+
+// Description summarizes the chargeable damage for an insurance claim.
+func (diff *ConditionDiff) Description() string {
+	parts := make([]string, 0, diff.ChargeableCount())
+	for _, damage := range diff.NewDamage {
+		parts = append(parts, "new "+damage.Panel+" "+damage.Kind)
+	}
+	for _, change := range diff.Worsened {
+		parts = append(parts, fmt.Sprintf("%s %s %s → %s",
+			change.After.Panel, change.After.Kind, change.Before.Severity, change.After.Severity))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// DiffConditionReports compares a pickup report with a return report.
+// Worsened damage is charged for the increase in repair cost only.
+func DiffConditionReports(pickup, returned *ConditionReport) *ConditionDiff {
+	before := make(map[string]DamageAnnotation, len(pickup.Damages))
+	for _, damage := range pickup.Damages {
+		before[damage.key()] = damage
+	}
+
+	diff := &ConditionDiff{}
+	for _, damage := range returned.Damages {
+		earlier, existed := before[damage.key()]
+		switch {
+		case !existed:
+			diff.NewDamage = append(diff.NewDamage, damage)
+			diff.addCharge(damage.RepairCost)
+		case damage.Severity > earlier.Severity:
+			diff.Worsened = append(diff.Worsened, DamageChange{Before: earlier, After: damage})
+			diff.addCharge(damage.RepairCost - earlier.RepairCost)
+		default:
+			diff.Unchanged = append(diff.Unchanged, damage)
+		}
+	}
+	return diff
+}
+
+// addCharge adds a non-negative repair cost to the chargeable total.
+func (diff *ConditionDiff) addCharge(cost float64) {
+	diff.Chargeable += max(0, cost)
+}
+
+// SetPhotoStore replaces the store used for inspection photos.
+func (service *RentalService) SetPhotoStore(store PhotoStore) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.photos = store
+}
+
+// UploadInspectionPhoto stores a photo for a reservation and returns the
+// reference to put in a DamageAnnotation.
+func (service *RentalService) UploadInspectionPhoto(reservationID, name string, data []byte) (string, error) {
+	service.mutex.RLock()
+	_, exists := service.reservations[reservationID]
+	photos := service.photos
+	service.mutex.RUnlock()
+
+	if !exists {
+		return "", fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+	return photos.Save(reservationID, name, data)
+}
+
+// FileConditionReport records the pickup or return inspection of a reservation.
+// Pickup reports are filed once the reservation is confirmed (before or just
+// after the customer drives off); return reports while the vehicle is out.
+// Each stage has one report; filing again replaces it.
+func (service *RentalService) FileConditionReport(reservationID string, stage ReportStage, inspector, notes string, damages []DamageAnnotation) (*ConditionReport, error) {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	photos := service.photos
+	service.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+	if inspector == "" {
+		return nil, fmt.Errorf("condition report needs an inspector")
+	}
+
+	seen := make(map[string]bool, len(damages))
+	for _, damage := range damages {
+		if err := damage.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[damage.key()] {
+			return nil, fmt.Errorf("%s %s is listed twice", damage.Panel, damage.Kind)
+		}
+		seen[damage.key()] = true
+		for _, ref := range damage.PhotoRefs {
+			if !photos.Exists(ref) {
+				return nil, fmt.Errorf("photo %s for %s %s not found", ref, damage.Panel, damage.Kind)
+			}
+		}
+	}
+
+	report := &ConditionReport{
+		ID:            conditionReportIDGen.NextID(),
+		ReservationID: reservationID,
+		VehicleID:     reservation.vehicle.GetID(),
+		Stage:         stage,
+		Inspector:     inspector,
+		Notes:         notes,
+		Damages:       append([]DamageAnnotation(nil), damages...),
+		RecordedAt:    time.Now(),
+	}
+
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	switch stage {
+	case ReportStagePickup:
+		if reservation.status != ReservationStatusConfirmed && reservation.status != ReservationStatusPickedUp {
+			return nil, fmt.Errorf("cannot file pickup report: reservation is %s", reservation.status)
+		}
+		reservation.pickupReport = report
+	case ReportStageReturn:
+		if reservation.status != ReservationStatusPickedUp {
+			return nil, fmt.Errorf("cannot file return report: vehicle is not out (current: %s)", reservation.status)
+		}
+		if reservation.pickupReport == nil {
+			return nil, fmt.Errorf("cannot file return report: no pickup report to compare with")
+		}
+		reservation.returnReport = report
+	default:
+		return nil, fmt.Errorf("unknown report stage %d", stage)
+	}
+	return report, nil
+}
+
+// GetConditionReports returns the pickup and return reports (nil if not filed).
+func (service *RentalService) GetConditionReports(reservationID string) (*ConditionReport, *ConditionReport, error) {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return nil, nil, fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	return reservation.pickupReport, reservation.returnReport, nil
+}
+
+// CompareConditionReports diffs the reservation's pickup and return reports.
+func (service *RentalService) CompareConditionReports(reservationID string) (*ConditionDiff, error) {
+	pickup, returned, err := service.GetConditionReports(reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if pickup == nil || returned == nil {
+		return nil, fmt.Errorf("reservation %s needs both a pickup and a return report", reservationID)
+	}
+	return DiffConditionReports(pickup, returned), nil
+}
+
+// ReturnVehicleWithInspection completes a return using the filed reports.
+// If the return report shows new or worsened damage, it is billed through
+// the normal insurance claim flow; otherwise this is a plain return.
+func (service *RentalService) ReturnVehicleWithInspection(reservationID string) (*InsuranceClaim, *ConditionDiff, error) {
+	diff, err := service.CompareConditionReports(reservationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !diff.HasChargeableDamage() || diff.Chargeable <= 0 {
+		return nil, diff, service.ReturnVehicle(reservationID)
+	}
+
+	claim, err := service.ReturnVehicleWithDamage(reservationID, diff.Description(), diff.Chargeable)
+	return claim, diff, err
+}
+
+// ============================================================================
+// SECTION 13: RECEIPTS (Structured Data + JSON/HTML Export)
+// ============================================================================
+//
+// A Receipt is the reservation's bill as plain data: who, what, when, and
+// one itemized line per charge. It is built once from the reservation and
+// then rendered by separate writers, the same split as the fleet report and
+// its CSV export:
+//   - WriteText: the console receipt (PrintReceipt)
+//   - WriteJSON: for API responses
+//   - WriteHTML: a self-contained page for email
+//
+// Line kinds cover base rate, extras, insurance, fees, taxes and discounts.
+// Reservations carry no taxes or discounts yet; when pricing adds them they
+// become lines of those kinds and the totals below already account for them.
+//
+// ============================================================================
+
+// ReceiptLineKind classifies a receipt line.
+type ReceiptLineKind int
+
+const (
+	ReceiptLineBase ReceiptLineKind = iota
+	ReceiptLineExtra
+	ReceiptLineInsurance
+	ReceiptLineFee
+	ReceiptLineTax
+	ReceiptLineDiscount
+)
+
+// String returns the kind name.
+func (kind ReceiptLineKind) String() string {
+	names := []string{"base", "extra", "insurance", "fee", "tax", "discount"}
+	if int(kind) < len(names) {
+		return names[kind]
+	}
+	return "unknown"
+}
+
+// MarshalText makes the kind appear by name in JSON.
+func (kind ReceiptLineKind) MarshalText() ([]byte, error) {
+	return []byte(kind.String()), nil
+}
+
+// ReceiptLine is one itemized charge. Discounts have a negative Amount.
+type ReceiptLine struct {
+	Kind        ReceiptLineKind `json:"kind"`
+	Description string          `json:"description"`
+	Quantity    int             `json:"quantity,omitempty"`   // Days, for per-day charges
+	UnitPrice   float64         `json:"unit_price,omitempty"` // Price per day, for per-day charges
+	Amount      float64         `json:"amount"`
+	Note        string          `json:"note,omitempty"` // Extra detail (deductible, distance...)
+}
+
+// Receipt is a reservation's bill as structured data.
+type Receipt struct {
+	ReservationID  string        `json:"reservation_id"`
+	Status         string        `json:"status"`
+	CustomerName   string        `json:"customer_name"`
+	CustomerEmail  string        `json:"customer_email"`
+	DriverLicense  string        `json:"driver_license"`
+	Vehicle        string        `json:"vehicle"` // e.g. "2023 Toyota Camry"
+	VehicleType    string        `json:"vehicle_type"`
+	LicensePlate   string        `json:"license_plate"`
+	PickupDate     time.Time     `json:"pickup_date"`
+	PickupLocation string        `json:"pickup_location"`
+	ReturnDate     time.Time     `json:"return_date"`
+	ReturnLocation string        `json:"return_location"`
+	RentalDays     int           `json:"rental_days"`
+	Lines          []ReceiptLine `json:"lines"`
+	Subtotal       float64       `json:"subtotal"`  // Everything except taxes and discounts
+	Discounts      float64       `json:"discounts"` // Sum of discount lines (zero or negative)
+	Taxes          float64       `json:"taxes"`     // Sum of tax lines
+	Total          float64       `json:"total"`
+	IssuedAt       time.Time     `json:"issued_at"`
+}
+
+// BuildReceipt snapshots the reservation's charges into a Receipt.
+func (reservation *Reservation) BuildReceipt() *Receipt {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	rentalDays := calculateRentalDays(reservation.pickupDate, reservation.returnDate)
+	vehicle := reservation.vehicle
+	receipt := &Receipt{
+		ReservationID:  reservation.id,
+		Status:         reservation.status.String(),
+		CustomerName:   reservation.customer.GetName(),
+		CustomerEmail:  reservation.customer.GetEmail(),
+		DriverLicense:  reservation.customer.GetDriverLicense(),
+		Vehicle:        fmt.Sprintf("%d %s %s", vehicle.GetYear(), vehicle.GetMake(), vehicle.GetModel()),
+		VehicleType:    vehicle.GetType().String(),
+		LicensePlate:   vehicle.GetLicensePlate(),
+		PickupDate:     reservation.pickupDate,
+		PickupLocation: reservation.pickupLocation,
+		ReturnDate:     reservation.returnDate,
+		ReturnLocation: reservation.returnLocation,
+		RentalDays:     rentalDays,
+		IssuedAt:       time.Now(),
+	}
+
+	addPerDay := func(kind ReceiptLineKind, description string, dailyPrice float64, note string) {
+		receipt.Lines = append(receipt.Lines, ReceiptLine{
+			Kind:        kind,
+			Description: description,
+			Quantity:    rentalDays,
+			UnitPrice:   dailyPrice,
+			Amount:      dailyPrice * float64(rentalDays),
+			Note:        note,
+		})
+	}
+	addFlat := func(kind ReceiptLineKind, description string, amount float64, note string) {
+		receipt.Lines = append(receipt.Lines, ReceiptLine{Kind: kind, Description: description, Amount: amount, Note: note})
+	}
+
+	if subscription := reservation.subscription; subscription != nil {
+		addFlat(ReceiptLineBase, "Subscription: "+subscription.plan.Name, 0,
+			fmt.Sprintf("per-day pricing waived, %d miles driven", reservation.milesDriven))
+	} else {
+		addPerDay(ReceiptLineBase, "Daily Rate", reservation.dailyRate, "")
+	}
+	for _, extra := range reservation.extras {
+		addPerDay(ReceiptLineExtra, extra.GetName(), extra.GetDailyPrice(), "")
+	}
+	if insurance := reservation.insurance; insurance != nil {
+		addPerDay(ReceiptLineInsurance, insurance.GetTier().String()+" Insurance", insurance.GetDailyPrice(),
+			fmt.Sprintf("deductible $%.2f", insurance.GetDeductible()))
+	}
+	if reservation.damageCharge > 0 {
+		addFlat(ReceiptLineFee, "Damage Charge", reservation.damageCharge, "")
+	}
+	if check := reservation.locationCheck; check != nil && check.Fee > 0 {
+		addFlat(ReceiptLineFee, "Wrong-location Fee", check.Fee,
+			fmt.Sprintf("%.1f km from %s", check.DistanceMeters/1000, reservation.returnLocation))
+	}
+	if reservation.dropOffFee > 0 {
+		addFlat(ReceiptLineFee, "One-way Drop-off Fee", reservation.dropOffFee, "")
+	}
+	if reservation.excessMileageFee > 0 {
+		addFlat(ReceiptLineFee, "Excess Mileage", reservation.excessMileageFee,
+			fmt.Sprintf("$%.2f/mile over the monthly cap", reservation.subscription.plan.ExcessMileRate))
+	}
+
+	for _, line := range receipt.Lines {
+		switch line.Kind {
+		case ReceiptLineTax:
+			receipt.Taxes += line.Amount
+		case ReceiptLineDiscount:
+			receipt.Discounts += line.Amount
+		default:
+			receipt.Subtotal += line.Amount
+		}
+	}
+	receipt.Total = receipt.Subtotal + receipt.Discounts + receipt.Taxes
+	return receipt
+}
+
+// String formats a line the way the console receipt shows it.
+func (line ReceiptLine) String() string {
+	text := fmt.Sprintf("%s: $%.2f", line.Description, line.Amount)
+	if line.Quantity > 0 {
+		text = fmt.Sprintf("%s: $%.2f x %d days = $%.2f", line.Description, line.UnitPrice, line.Quantity, line.Amount)
+	}
+	if line.Note != "" {
+		text += " (" + line.Note + ")"
+	}
+	return text
+}
+
+// WriteText writes the console receipt.
+func (receipt *Receipt) WriteText(writer io.Writer) error {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, `
+╔════════════════════════════════════════════════╗
+║           🚗 RENTAL RECEIPT                    ║
+╠════════════════════════════════════════════════╣
+  Reservation: %s
+  Status: %s
+  
+  Customer: %s
+  License: %s
+  
+  Vehicle: %s
+  Type: %s
+  Plate: %s
+  
+  Pickup:  %s at %s
+  Return:  %s at %s
+  Days: %d
+  
+  ────────────────────────────────
+  CHARGES:
+`,
+		receipt.ReservationID, receipt.Status,
+		receipt.CustomerName, receipt.DriverLicense,
+		receipt.Vehicle, receipt.VehicleType, receipt.LicensePlate,
+		receipt.PickupDate.Format("Jan 02"), receipt.PickupLocation,
+		receipt.ReturnDate.Format("Jan 02"), receipt.ReturnLocation,
+		receipt.RentalDays)
+
+	for _, line := range receipt.Lines {
+		fmt.Fprintf(&builder, "  %s\n", line)
+	}
+
+	fmt.Fprintf(&builder, `  ────────────────────────────────
+  TOTAL: $%.2f
+╚════════════════════════════════════════════════╝
+`, receipt.Total)
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// WriteJSON writes the receipt as indented JSON.
+func (receipt *Receipt) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(receipt); err != nil {
+		return fmt.Errorf("encode receipt %s as JSON: %w", receipt.ReservationID, err)
+	}
+	return nil
+}
+
+// receiptHTMLTemplate renders a self-contained page with inline styles, since
+// most email clients ignore external stylesheets. Names and locations are
+// escaped by html/template.
+var receiptHTMLTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"money": money.Format,
+	"date":  func(moment time.Time) string { return moment.Format("Jan 02, 2006") },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Rental Receipt {{.ReservationID}}</title></head>
+<body style="font-family: Helvetica, Arial, sans-serif; color: #222;">
+<h1 style="margin-bottom: 0;">Rental Receipt</h1>
+<p>Reservation {{.ReservationID}} ({{.Status}}) &middot; {{date .IssuedAt}}</p>
+<p><strong>Customer:</strong> {{.CustomerName}} (license {{.DriverLicense}})<br>
+<strong>Vehicle:</strong> {{.Vehicle}} &middot; {{.VehicleType}} &middot; {{.LicensePlate}}<br>
+<strong>Pickup:</strong> {{date .PickupDate}} at {{.PickupLocation}}<br>
+<strong>Return:</strong> {{date .ReturnDate}} at {{.ReturnLocation}} ({{.RentalDays}} days)</p>
+<table style="border-collapse: collapse; width: 100%;">
+<thead><tr style="border-bottom: 2px solid #222;"><th align="left">Item</th><th align="right">Days</th><th align="right">Per Day</th><th align="right">Amount</th></tr></thead>
+<tbody>
+{{- range .Lines}}
+<tr style="border-bottom: 1px solid #ddd;"><td>{{.Description}}{{if .Note}} <small>({{.Note}})</small>{{end}}</td><td align="right">{{if .Quantity}}{{.Quantity}}{{end}}</td><td align="right">{{if .Quantity}}{{money .UnitPrice}}{{end}}</td><td align="right">{{money .Amount}}</td></tr>
+{{- end}}
+</tbody>
+<tfoot>
+<tr><td colspan="3" align="right">Subtotal</td><td align="right">{{money .Subtotal}}</td></tr>
+{{- if .Discounts}}
+<tr><td colspan="3" align="right">Discounts</td><td align="right">{{money .Discounts}}</td></tr>
+{{- end}}
+{{- if .Taxes}}
+<tr><td colspan="3" align="right">Taxes</td><td align="right">{{money .Taxes}}</td></tr>
+{{- end}}
+<tr style="border-top: 2px solid #222;"><td colspan="3" align="right"><strong>Total</strong></td><td align="right"><strong>{{money .Total}}</strong></td></tr>
+</tfoot>
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes the receipt as an HTML page.
+func (receipt *Receipt) WriteHTML(writer io.Writer) error {
+	if err := receiptHTMLTemplate.Execute(writer, receipt); err != nil {
+		return fmt.Errorf("render receipt %s as HTML: %w", receipt.ReservationID, err)
+	}
+	return nil
+}
+
+// GetReceipt builds the receipt for a reservation.
+func (service *RentalService) GetReceipt(reservationID string) (*Receipt, error) {
+	service.mutex.RLock()
+	reservation, exists := service.reservations[reservationID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("reservation with ID '%s' not found", reservationID)
+	}
+	return reservation.BuildReceipt(), nil
+}
+
+// ============================================================================
+// SECTION 14: DRIVER LICENSE VERIFICATION
+// ============================================================================
+//
+// A LicenseVerifier checks a customer's driver license against the issuing
+// authority (Strategy Pattern, like PaymentGateway). It runs twice:
+//   - At registration: the license must exist, be in good standing and not
+//     be expired today
+//   - At pickup: the license must still be valid on the return date and
+//     carry the class the vehicle type requires
+//
+// Failures are returned as *LicenseVerificationError so callers can tell a
+// rejected license (show the reason at the counter) from other errors with
+// errors.As. Verification is off until SetLicenseVerifier is called.
+//
+// ============================================================================
+
+// LicenseClass is a driving entitlement printed on a license.
+type LicenseClass int
+
+const (
+	LicenseClassA LicenseClass = iota // 0 - Motorcycles
+	LicenseClassB                     // 1 - Cars, SUVs and luxury cars
+	LicenseClassD                     // 2 - Passenger vans
+)
+
+// String returns the class letter.
+func (class LicenseClass) String() string {
+	names := [...]string{"A", "B", "D"}
+	if int(class) < len(names) {
+		return names[class]
+	}
+	return "Unknown"
+}
+
+// RequiredLicenseClass returns the license class needed to drive the vehicle type.
+func (vehicleType VehicleType) RequiredLicenseClass() LicenseClass {
+	switch vehicleType {
+	case VehicleTypeBike:
+		return LicenseClassA
+	case VehicleTypeVan:
+		return LicenseClassD
+	default:
+		return LicenseClassB
+	}
+}
+
+// LicenseRecord is what the issuing authority knows about a license.
+type LicenseRecord struct {
+	Number    string         // License number (matches Customer.driverLicense)
+	Holder    string         // Name on the license
+	Classes   []LicenseClass // Vehicle classes the holder may drive
+	ExpiresAt time.Time      // Last day the license is valid
+	Suspended bool           // Revoked or suspended by the authority
+}
+
+// hasClass reports whether the license carries the class.
+func (record LicenseRecord) hasClass(class LicenseClass) bool {
+	for _, held := range record.Classes {
+		if held == class {
+			return true
+		}
+	}
+	return false
+}
+
+// LicenseFailureReason says why a license was rejected.
+type LicenseFailureReason int
+
+const (
+	LicenseNotFound      LicenseFailureReason = iota // 0 - Authority has no such license
+	LicenseExpired                                   // 1 - Expired before the date checked
+	LicenseSuspended                                 // 2 - Suspended or revoked
+	LicenseClassMismatch                             // 3 - Missing the class for the vehicle type
+)
+
+// String returns a human-readable name for the failure reason.
+func (reason LicenseFailureReason) String() string {
+	names := [...]string{"Not Found", "Expired", "Suspended", "Class Mismatch"}
+	if int(reason) < len(names) {
+		return names[reason]
+	}
+	return "Unknown"
+}
+
+// LicenseVerificationError is returned when a license fails verification.
+type LicenseVerificationError struct {
+	LicenseNumber string               // License that was checked
+	Reason        LicenseFailureReason // Why it was rejected
+	Detail        string               // Human-readable explanation
+}
+
+func (err *LicenseVerificationError) Error() string {
+	return fmt.Sprintf("license %s rejected (%s): %s", err.LicenseNumber, err.Reason, err.Detail)
+}
+
+// LicenseVerifier checks a license with the issuing authority.
+// With no vehicle types it checks existence, standing and expiry only;
+// otherwise the license must also carry each type's required class.
+type LicenseVerifier interface {
+	Verify(licenseNumber string, validOn time.Time, vehicleTypes ...VehicleType) error
+}
+
+// SimulatedLicenseVerifier answers from an in-memory license registry.
+type SimulatedLicenseVerifier struct {
+	records map[string]LicenseRecord // key: license number
+	mutex   sync.RWMutex
+}
+
+// NewSimulatedLicenseVerifier creates a verifier with an empty registry.
+func NewSimulatedLicenseVerifier() *SimulatedLicenseVerifier {
+	return &SimulatedLicenseVerifier{records: make(map[string]LicenseRecord)}
+}
+
+// AddLicense registers (or replaces) a license the authority knows about.
+func (verifier *SimulatedLicenseVerifier) AddLicense(record LicenseRecord) {
+	verifier.mutex.Lock()
+	defer verifier.mutex.Unlock()
+	verifier.records[record.Number] = record
+}
+
+// Verify checks the license against the registry.
+func (verifier *SimulatedLicenseVerifier) Verify(licenseNumber string, validOn time.Time, vehicleTypes ...VehicleType) error {
+	verifier.mutex.RLock()
+	record, exists := verifier.records[licenseNumber]
+	verifier.mutex.RUnlock()
+
+	reject := func(reason LicenseFailureReason, detail string) error {
+		return &LicenseVerificationError{LicenseNumber: licenseNumber, Reason: reason, Detail: detail}
+	}
+
+	if !exists {
+		return reject(LicenseNotFound, "no record with the issuing authority")
+	}
+	if record.Suspended {
+		return reject(LicenseSuspended, "license is suspended")
+	}
+	if record.ExpiresAt.Before(validOn) {
+		return reject(LicenseExpired, fmt.Sprintf("expires %s, needed until %s",
+			record.ExpiresAt.Format("2006-01-02"), validOn.Format("2006-01-02")))
+	}
+	for _, vehicleType := range vehicleTypes {
+		required := vehicleType.RequiredLicenseClass()
+		if !record.hasClass(required) {
+			return reject(LicenseClassMismatch, fmt.Sprintf("%s requires class %s", vehicleType, required))
+		}
+	}
+	return nil
+}
+
+// SetLicenseVerifier turns on license checks at registration and pickup.
+func (service *RentalService) SetLicenseVerifier(verifier LicenseVerifier) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.licenses = verifier
+}
+
+// getLicenseVerifier returns the configured verifier, or nil if checks are off.
+func (service *RentalService) getLicenseVerifier() LicenseVerifier {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+	return service.licenses
+}
+
+// verifyLicenseForPickup checks the renter's license covers the vehicle
+// for the whole rental.
+func (service *RentalService) verifyLicenseForPickup(reservation *Reservation) error {
+	verifier := service.getLicenseVerifier()
+	if verifier == nil {
+		return nil
+	}
+	return verifier.Verify(reservation.customer.GetDriverLicense(), reservation.returnDate, reservation.vehicle.GetType())
+}
+
+// ============================================================================
+// SECTION 15: RESERVATION NOTIFICATIONS
+// ============================================================================
+//
+// Customers hear about their rental through the notification system
+// (18_notification_system). The rental service never writes message text:
+// each lifecycle notice names a template, and the service only supplies the
+// placeholder values and timestamps.
+//
+// Notices:
+//   - Confirmed:      sent as soon as a reservation is confirmed
+//   - PickupReminder: PickupReminderLead (24h) before pickup
+//   - ReturnReminder: the return reminder lead before the return time
+//   - Overdue:        once, the overdue grace after the return time, if the
+//     vehicle has not come back
+//
+// Reminders are scheduled at confirmation and sent by DispatchNotices (or
+// the background notification job). Each one checks the reservation status
+// when it comes due, so a cancelled or returned rental never gets a stale
+// reminder and nothing has to be unscheduled.
+//
+// Notices go out through 18_notification_system: its NotificationService
+// satisfies TemplateSender, and DefaultRentalTemplates builds the templates
+// to register with it. Notifications are off until SetNotificationSender is
+// called.
+//
+// A send that fails is reported and kept in the log with its error; it is
+// not retried here. Retries belong to the notification system's channels
+// (RetryDecorator), which record each attempt.
+//
+// ============================================================================
+
+// ReservationNotice is a lifecycle message sent to the customer.
+type ReservationNotice int
+
+const (
+	NoticeConfirmed      ReservationNotice = iota // 0 - Reservation confirmed
+	NoticePickupReminder                          // 1 - Pickup is coming up
+	NoticeReturnReminder                          // 2 - Return is coming up
+	NoticeOverdue                                 // 3 - Return time has passed
+)
+
+// String returns a human-readable name for the notice.
+func (notice ReservationNotice) String() string {
+	names := [...]string{"Confirmed", "Pickup Reminder", "Return Reminder", "Overdue"}
+	if int(notice) < len(names) {
+		return names[notice]
+	}
+	return "Unknown"
+}
+
+// TemplateID returns the notification template the notice is rendered with.
+func (notice ReservationNotice) TemplateID() string {
+	ids := [...]string{"rental.confirmed", "rental.pickup_reminder", "rental.return_reminder", "rental.overdue"}
+	if int(notice) < len(ids) {
+		return ids[notice]
+	}
+	return "rental.unknown"
+}
+
+// appliesTo reports whether the notice still makes sense for a reservation
+// in the given status when it comes due.
+func (notice ReservationNotice) appliesTo(status ReservationStatus) bool {
+	switch notice {
+	case NoticeConfirmed, NoticePickupReminder:
+		return status == ReservationStatusConfirmed
+	case NoticeReturnReminder, NoticeOverdue:
+		return status == ReservationStatusPickedUp
+	}
+	return false
+}
+
+const (
+	PickupReminderLead        = 24 * time.Hour   // Pickup reminder goes out this long before pickup
+	DefaultReturnReminderLead = 2 * time.Hour    // Return reminder goes out this long before return
+	DefaultOverdueGrace       = 30 * time.Minute // Overdue notice waits this long after the return time
+	NoticeSendTimeout         = 5 * time.Second  // Bound on a single send to the notification system
+)
+
+// TemplateSender delivers a templated notification to a user.
+type TemplateSender interface {
+	SendLocalizedTemplate(ctx context.Context, userID string, templateID string,
+		parameters map[string]string, timestamps map[string]time.Time) error
+}
+
+// ScheduledNotice is a notice waiting to be sent, or the record of one that was.
+type ScheduledNotice struct {
+	Notice        ReservationNotice
+	ReservationID string
+	CustomerID    string
+	DueAt         time.Time // When the notice should go out
+	SentAt        time.Time // When it was sent (zero if pending or skipped)
+	Skipped       bool      // The reservation moved on before the notice came due
+	Err           error     // Why sending failed (nil on success)
+}
+
+// String returns a one-line description of the notice.
+func (scheduled ScheduledNotice) String() string {
+	state := "pending"
+	switch {
+	case scheduled.Skipped:
+		state = "skipped"
+	case scheduled.Err != nil:
+		state = fmt.Sprintf("failed: %v", scheduled.Err)
+	case !scheduled.SentAt.IsZero():
+		state = "sent"
+	}
+	return fmt.Sprintf("%s %s for %s due %s (%s)", scheduled.ReservationID, scheduled.Notice,
+		scheduled.CustomerID, scheduled.DueAt.Format("Jan 02 15:04"), state)
+}
+
+// ReservationNotifier schedules lifecycle notices and sends them through a
+// TemplateSender.
+type ReservationNotifier struct {
+	sender       TemplateSender
+	returnLead   time.Duration      // How long before return the reminder goes out
+	overdueGrace time.Duration      // How long after return time the overdue notice waits
+	pending      []*ScheduledNotice // Not yet due, oldest due first
+	log          []ScheduledNotice  // Sent, failed and skipped notices, in dispatch order
+	mutex        sync.Mutex         // Protects all fields above
+}
+
+// NewReservationNotifier creates a notifier with the default reminder timing.
+func NewReservationNotifier(sender TemplateSender) *ReservationNotifier {
+	return &ReservationNotifier{
+		sender:       sender,
+		returnLead:   DefaultReturnReminderLead,
+		overdueGrace: DefaultOverdueGrace,
+	}
+}
+
+// SetReturnTiming changes when return reminders and overdue notices go out
+// for reservations confirmed afterwards.
+func (notifier *ReservationNotifier) SetReturnTiming(reminderLead, overdueGrace time.Duration) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	notifier.returnLead = reminderLead
+	notifier.overdueGrace = overdueGrace
+}
+
+// GetPending returns copies of the notices not yet due.
+func (notifier *ReservationNotifier) GetPending() []ScheduledNotice {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	pending := make([]ScheduledNotice, len(notifier.pending))
+	for i, scheduled := range notifier.pending {
+		pending[i] = *scheduled
+	}
+	return pending
+}
+
+// GetLog returns the notices dispatched so far.
+func (notifier *ReservationNotifier) GetLog() []ScheduledNotice {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+	return append([]ScheduledNotice{}, notifier.log...)
+}
+
+// GetFailed returns the dispatched notices whose send failed.
+func (notifier *ReservationNotifier) GetFailed() []ScheduledNotice {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	failed := make([]ScheduledNotice, 0)
+	for _, scheduled := range notifier.log {
+		if scheduled.Err != nil {
+			failed = append(failed, scheduled)
+		}
+	}
+	return failed
+}
+
+// schedule queues the reminders for a freshly confirmed reservation.
+func (notifier *ReservationNotifier) schedule(reservationID, customerID string, pickupDate, returnDate time.Time) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	notices := []*ScheduledNotice{
+		{Notice: NoticePickupReminder, DueAt: pickupDate.Add(-PickupReminderLead)},
+		{Notice: NoticeReturnReminder, DueAt: returnDate.Add(-notifier.returnLead)},
+		{Notice: NoticeOverdue, DueAt: returnDate.Add(notifier.overdueGrace)},
+	}
+	for _, scheduled := range notices {
+		scheduled.ReservationID = reservationID
+		scheduled.CustomerID = customerID
+		notifier.pending = append(notifier.pending, scheduled)
+	}
+	sort.SliceStable(notifier.pending, func(i, j int) bool {
+		return notifier.pending[i].DueAt.Before(notifier.pending[j].DueAt)
+	})
+}
+
+// takeDue removes and returns the notices due at or before now.
+func (notifier *ReservationNotifier) takeDue(now time.Time) []*ScheduledNotice {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	dueCount := sort.Search(len(notifier.pending), func(i int) bool {
+		return notifier.pending[i].DueAt.After(now)
+	})
+	due := notifier.pending[:dueCount]
+	notifier.pending = append([]*ScheduledNotice{}, notifier.pending[dueCount:]...)
+	return due
+}
+
+// send renders the notice for the reservation through the sender.
+func (notifier *ReservationNotifier) send(reservation *Reservation, scheduled *ScheduledNotice, now time.Time) {
+	if !scheduled.Notice.appliesTo(reservation.GetStatus()) {
+		scheduled.Skipped = true
+	} else {
+		parameters, timestamps := reservation.noticeDetails()
+		ctx, cancel := context.WithTimeout(context.Background(), NoticeSendTimeout)
+		scheduled.Err = notifier.sender.SendLocalizedTemplate(ctx, scheduled.CustomerID,
+			scheduled.Notice.TemplateID(), parameters, timestamps)
+		cancel()
+		if scheduled.Err == nil {
+			scheduled.SentAt = now
+		} else {
+			fmt.Printf("  ⚠️  [notifier] %s %s not sent: %v\n", scheduled.ReservationID, scheduled.Notice, scheduled.Err)
+		}
+	}
+
+	notifier.mutex.Lock()
+	notifier.log = append(notifier.log, *scheduled)
+	notifier.mutex.Unlock()
+}
+
+// noticeDetails returns the template placeholders for the reservation.
+func (reservation *Reservation) noticeDetails() (map[string]string, map[string]time.Time) {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+
+	vehicle := reservation.vehicle
+	parameters := map[string]string{
+		"name":            reservation.customer.GetName(),
+		"reservation_id":  reservation.id,
+		"vehicle":         fmt.Sprintf("%d %s %s", vehicle.GetYear(), vehicle.GetMake(), vehicle.GetModel()),
+		"plate":           vehicle.GetLicensePlate(),
+		"pickup_location": reservation.pickupLocation,
+		"return_location": reservation.returnLocation,
+		"total":           money.FormatPlain(reservation.totalAmount),
+	}
+	timestamps := map[string]time.Time{
+		"pickup": reservation.pickupDate,
+		"return": reservation.returnDate,
+	}
+	return parameters, timestamps
+}
+
+// SetNotificationSender turns on lifecycle notifications through sender.
+// Returns the notifier so callers can tune timing or inspect the schedule.
+func (service *RentalService) SetNotificationSender(sender TemplateSender) *ReservationNotifier {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.notifier = NewReservationNotifier(sender)
+	return service.notifier
+}
+
+// getNotifier returns the notifier, or nil if notifications are off.
+func (service *RentalService) getNotifier() *ReservationNotifier {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+	return service.notifier
+}
+
+// notifyConfirmed sends the confirmation and schedules the reminders.
+// Does nothing if notifications are off.
+func (service *RentalService) notifyConfirmed(reservation *Reservation) {
+	notifier := service.getNotifier()
+	if notifier == nil {
+		return
+	}
+
+	reservation.mutex.Lock()
+	customerID := reservation.customer.GetID()
+	pickupDate, returnDate := reservation.pickupDate, reservation.returnDate
+	reservation.mutex.Unlock()
+
+	now := time.Now()
+	notifier.send(reservation, &ScheduledNotice{
+		Notice:        NoticeConfirmed,
+		ReservationID: reservation.GetID(),
+		CustomerID:    customerID,
+		DueAt:         now,
+	}, now)
+	notifier.schedule(reservation.GetID(), customerID, pickupDate, returnDate)
+}
+
+// DispatchNotices sends every reminder due at or before now. Reminders
+// whose reservation has moved on (cancelled, returned, not yet picked up)
+// are skipped. Returns the dispatched notices in due order.
+func (service *RentalService) DispatchNotices(now time.Time) []ScheduledNotice {
+	notifier := service.getNotifier()
+	if notifier == nil {
+		return nil
+	}
+
+	dispatched := make([]ScheduledNotice, 0)
+	for _, scheduled := range notifier.takeDue(now) {
+		service.mutex.RLock()
+		reservation, exists := service.reservations[scheduled.ReservationID]
+		service.mutex.RUnlock()
+		if !exists {
+			continue
+		}
+		notifier.send(reservation, scheduled, now)
+		dispatched = append(dispatched, *scheduled)
+	}
+	return dispatched
+}
+
+// StartNotificationJob runs DispatchNotices in the background every interval.
+// Calling it while a job is already running has no effect.
+func (service *RentalService) StartNotificationJob(interval time.Duration) {
+	service.noticeJob.Start(interval, func(now time.Time) {
+		service.DispatchNotices(now)
+	})
+}
+
+// StopNotificationJob stops the background notification job and waits for
+// a dispatch in progress to finish.
+func (service *RentalService) StopNotificationJob() {
+	service.noticeJob.Stop()
+}
+
+// DefaultRentalTemplates returns a template for every ReservationNotice,
+// sent on the given channel. Register them with the notification service
+// (AddTemplate) before use.
+func DefaultRentalTemplates(channel notification.NotificationType) []*notification.NotificationTemplate {
+	return []*notification.NotificationTemplate{
+		notification.NewTemplate(NoticeConfirmed.TemplateID(), "Rental Confirmed",
+			"Reservation {reservation_id} confirmed",
+			"Hi {name}, your {vehicle} is booked from {pickup} to {return} at {pickup_location}. Estimated total ${total}.",
+			channel),
+		notification.NewTemplate(NoticePickupReminder.TemplateID(), "Rental Pickup Reminder",
+			"Pickup tomorrow: {vehicle}",
+			"Hi {name}, pick up {vehicle} ({plate}) at {pickup_location} on {pickup:date} at {pickup:time}. Bring your driver license.",
+			channel),
+		notification.NewTemplate(NoticeReturnReminder.TemplateID(), "Rental Return Reminder",
+			"Return due at {return:time}",
+			"Hi {name}, please return {plate} to {return_location} by {return:time} on {return:date}.",
+			channel),
+		notification.NewTemplate(NoticeOverdue.TemplateID(), "Rental Overdue",
+			"Rental {reservation_id} is overdue",
+			"Hi {name}, {plate} was due back at {return_location} at {return}. Late returns are charged per extra day.",
+			channel),
+	}
+}
+
+// ============================================================================
+// SECTION 16: SUBSCRIPTION PLANS (Monthly Car Swap Membership)
+// ============================================================================
+//
+// A subscription replaces per-day pricing with a monthly fee:
+// - The plan entitles the member to one vehicle class (e.g. SUV)
+// - The member drives one vehicle at a time and may swap it for another of
+//   that class up to SwapsPerMonth times per billing month; the first
+//   vehicle of a month is not a swap
+// - Reservations under a subscription carry no daily rate and no card hold;
+//   they are confirmed on creation, and pickup still runs license checks
+// - Miles are recorded when a vehicle is handed back. Miles beyond the
+//   plan's MileageCap in a month are charged per mile on that reservation
+//
+// Billing months start on the subscription date. There is no billing job:
+// every subscription operation first rolls the member into the month that
+// `now` falls in and charges the fee for each month started.
+//
+// ============================================================================
+
+// SubscriptionPlan defines a membership product.
+type SubscriptionPlan struct {
+	Name           string      // Unique plan name (e.g. "SUV Unlimited")
+	VehicleType    VehicleType // Vehicle class the member may drive
+	MonthlyFee     float64     // Charged at the start of every billing month
+	SwapsPerMonth  int         // Vehicle swaps allowed per billing month
+	MileageCap     int         // Miles included per billing month
+	ExcessMileRate float64     // Charged per mile beyond MileageCap
+}
+
+// Validate checks the plan definition.
+func (plan SubscriptionPlan) Validate() error {
+	if plan.Name == "" {
+		return fmt.Errorf("subscription plan needs a name")
+	}
+	if plan.MonthlyFee <= 0 {
+		return fmt.Errorf("plan '%s' needs a positive monthly fee", plan.Name)
+	}
+	if plan.SwapsPerMonth < 0 || plan.MileageCap <= 0 || plan.ExcessMileRate < 0 {
+		return fmt.Errorf("plan '%s' needs non-negative swaps, a positive mileage cap and a non-negative excess rate", plan.Name)
+	}
+	return nil
+}
+
+// SubscriptionStatus represents the state of a membership.
+type SubscriptionStatus int
+
+const (
+	SubscriptionStatusActive    SubscriptionStatus = iota // Billed monthly, may reserve vehicles
+	SubscriptionStatusCancelled                           // Ended; no further fees or reservations
+)
+
+// String returns a human-readable status.
+func (status SubscriptionStatus) String() string {
+	names := [...]string{"Active", "Cancelled"}
+	if int(status) < len(names) {
+		return names[status]
+	}
+	return "Unknown"
+}
+
+// SubscriptionCharge is one payment taken for a subscription.
+type SubscriptionCharge struct {
+	At          time.Time
+	Description string
+	Amount      float64
+}
+
+// SubscriptionUsage is a member's allowance for the current billing month.
+type SubscriptionUsage struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	SwapsUsed   int
+	SwapsLeft   int
+	MilesUsed   int
+	MilesLeft   int // Zero once the cap is reached; further miles are excess
+}
+
+// subscriptionIDGen generates unique IDs for subscriptions.
+var subscriptionIDGen = idgen.NewSequence("SUB")
+
+// Subscription is a customer's membership in a plan.
+type Subscription struct {
+	id           string               // Unique identifier (e.g., "SUB-1")
+	customer     *Customer            // Member
+	plan         SubscriptionPlan     // Plan the member pays for
+	status       SubscriptionStatus   // Active or cancelled
+	periodStart  time.Time            // Start of the current billing month
+	swapsUsed    int                  // Swaps made in the current billing month
+	milesUsed    int                  // Miles driven in the current billing month
+	current      *Reservation         // Vehicle the member holds right now (nil if none)
+	reservations []*Reservation       // Every reservation made under the subscription
+	charges      []SubscriptionCharge // Monthly fees and excess mileage taken
+	mutex        sync.Mutex           // Protects concurrent modifications
+}
+
+// Getter methods for Subscription
+func (subscription *Subscription) GetID() string             { return subscription.id }
+func (subscription *Subscription) GetCustomer() *Customer    { return subscription.customer }
+func (subscription *Subscription) GetPlan() SubscriptionPlan { return subscription.plan }
+func (subscription *Subscription) GetStatus() SubscriptionStatus {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+	return subscription.status
+}
+
+// GetCurrentReservation returns the reservation for the vehicle the member
+// holds, or nil.
+func (subscription *Subscription) GetCurrentReservation() *Reservation {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+	return subscription.current
+}
+
+// GetCharges returns every payment taken, oldest first.
+func (subscription *Subscription) GetCharges() []SubscriptionCharge {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+
+	charges := make([]SubscriptionCharge, len(subscription.charges))
+	copy(charges, subscription.charges)
+	return charges
+}
+
+// periodEnd returns when the current billing month ends.
+// Caller must hold subscription.mutex.
+func (subscription *Subscription) periodEnd() time.Time {
+	return subscription.periodStart.AddDate(0, 1, 0)
+}
+
+// rollPeriod moves the subscription into the billing month containing now,
+// resetting swaps and miles. Returns the start of every month entered, so
+// the caller can charge the fee for each. Caller must hold subscription.mutex.
+func (subscription *Subscription) rollPeriod(now time.Time) []time.Time {
+	started := make([]time.Time, 0)
+	for !now.Before(subscription.periodEnd()) {
+		subscription.periodStart = subscription.periodEnd()
+		subscription.swapsUsed = 0
+		subscription.milesUsed = 0
+		started = append(started, subscription.periodStart)
+	}
+	return started
+}
+
+// addMiles records miles driven this month and returns the excess mileage
+// charge they incur. Caller must hold subscription.mutex.
+func (subscription *Subscription) addMiles(miles int) float64 {
+	excessBefore := max(0, subscription.milesUsed-subscription.plan.MileageCap)
+	subscription.milesUsed += miles
+	excessAfter := max(0, subscription.milesUsed-subscription.plan.MileageCap)
+	return float64(excessAfter-excessBefore) * subscription.plan.ExcessMileRate
+}
+
+// addMileage adds miles to the vehicle's odometer (thread-safe).
+func (vehicle *Vehicle) addMileage(miles int) {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	vehicle.mileage += miles
+}
+
+// GetMileage returns the vehicle's odometer reading (thread-safe).
+func (vehicle *Vehicle) GetMileage() int {
+	vehicle.mutex.Lock()
+	defer vehicle.mutex.Unlock()
+	return vehicle.mileage
+}
+
+// GetSubscription returns the subscription the reservation was made under,
+// or nil for a per-day rental.
+func (reservation *Reservation) GetSubscription() *Subscription {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	return reservation.subscription
+}
+
+// GetMilesDriven returns the miles recorded at hand-back (subscriptions only).
+func (reservation *Reservation) GetMilesDriven() int {
+	reservation.mutex.Lock()
+	defer reservation.mutex.Unlock()
+	return reservation.milesDriven
+}
+
+// chargeCustomer takes an immediate payment (authorize, then capture).
+func (service *RentalService) chargeCustomer(customerID string, amount float64) error {
+	auth, err := service.payments.Authorize(customerID, amount)
+	if err != nil {
+		return err
+	}
+	return service.payments.Capture(auth, amount)
+}
+
+// AddSubscriptionPlan registers a plan customers can subscribe to.
+func (service *RentalService) AddSubscriptionPlan(plan SubscriptionPlan) error {
+	if err := plan.Validate(); err != nil {
+		return err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if _, exists := service.plans[plan.Name]; exists {
+		return fmt.Errorf("subscription plan '%s' already exists", plan.Name)
+	}
+	service.plans[plan.Name] = plan
+	return nil
+}
+
+// Subscribe enrolls a customer in a plan and charges the first month.
+func (service *RentalService) Subscribe(customerID, planName string, now time.Time) (*Subscription, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	customer, customerExists := service.customers[customerID]
+	if !customerExists {
+		return nil, fmt.Errorf("customer with ID '%s' not found", customerID)
+	}
+	plan, planExists := service.plans[planName]
+	if !planExists {
+		return nil, fmt.Errorf("subscription plan '%s' not found", planName)
+	}
+	if existing, isMember := service.members[customerID]; isMember {
+		return nil, fmt.Errorf("customer '%s' already has active subscription %s", customerID, existing.id)
+	}
+
+	if err := service.chargeCustomer(customerID, plan.MonthlyFee); err != nil {
+		return nil, fmt.Errorf("first month payment failed: %v", err)
+	}
+
+	subscription := &Subscription{
+		id:           subscriptionIDGen.NextID(),
+		customer:     customer,
+		plan:         plan,
+		status:       SubscriptionStatusActive,
+		periodStart:  now,
+		reservations: make([]*Reservation, 0),
+		charges: []SubscriptionCharge{
+			{At: now, Description: fmt.Sprintf("%s monthly fee", plan.Name), Amount: plan.MonthlyFee},
+		},
+	}
+	service.subscriptions[subscription.id] = subscription
+	service.members[customerID] = subscription
+	return subscription, nil
+}
+
+// getActiveSubscription looks up a subscription and rolls it into the
+// billing month of `now`, charging the fee for each month started.
+// Returns the subscription with its mutex held; the caller must unlock it.
+// Lock order is subscription.mutex, then service.mutex.
+func (service *RentalService) getActiveSubscription(subscriptionID string, now time.Time) (*Subscription, error) {
+	service.mutex.RLock()
+	subscription, exists := service.subscriptions[subscriptionID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("subscription '%s' not found", subscriptionID)
+	}
+
+	subscription.mutex.Lock()
+	if subscription.status != SubscriptionStatusActive {
+		subscription.mutex.Unlock()
+		return nil, fmt.Errorf("subscription '%s' is %s", subscriptionID, subscription.status)
+	}
+	for _, monthStart := range subscription.rollPeriod(now) {
+		fee := subscription.plan.MonthlyFee
+		if err := service.chargeCustomer(subscription.customer.GetID(), fee); err != nil {
+			subscription.mutex.Unlock()
+			return nil, fmt.Errorf("monthly payment failed: %v", err)
+		}
+		subscription.charges = append(subscription.charges, SubscriptionCharge{
+			At:          monthStart,
+			Description: fmt.Sprintf("%s monthly fee", subscription.plan.Name),
+			Amount:      fee,
+		})
+	}
+	return subscription, nil
+}
+
+// reserveForSubscription creates a confirmed, unpriced reservation for the
+// member. Caller must hold subscription.mutex.
+func (service *RentalService) reserveForSubscription(subscription *Subscription, vehicleID string, now time.Time) (*Reservation, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	vehicle, vehicleExists := service.vehicles[vehicleID]
+	if !vehicleExists {
+		return nil, fmt.Errorf("vehicle with ID '%s' not found", vehicleID)
+	}
+	if vehicle.GetType() != subscription.plan.VehicleType {
+		return nil, fmt.Errorf("plan '%s' covers %s vehicles, not %s",
+			subscription.plan.Name, subscription.plan.VehicleType, vehicle.GetType())
+	}
+	if !vehicle.IsAvailable() {
+		return nil, fmt.Errorf("vehicle '%s' is not available (status: %s)", vehicleID, vehicle.GetStatus())
+	}
+
+	// Held until the end of the billing month; the real return date is
+	// recorded when the member hands the vehicle back
+	reservation := NewReservation(subscription.customer, vehicle, now, subscription.periodEnd(), vehicle.GetLocation())
+	reservation.dailyRate = 0
+	reservation.totalAmount = 0
+	reservation.subscription = subscription
+	reservation.status = ReservationStatusConfirmed
+	vehicle.SetStatus(VehicleStatusReserved)
+	service.reservations[reservation.GetID()] = reservation
+
+	subscription.current = reservation
+	subscription.reservations = append(subscription.reservations, reservation)
+	return reservation, nil
+}
+
+// handBack ends the member's current reservation: a picked-up vehicle is
+// returned with its miles recorded, one never collected is cancelled.
+// Excess miles are charged on that reservation. Caller must hold
+// subscription.mutex.
+func (service *RentalService) handBack(subscription *Subscription, milesDriven int, now time.Time) error {
+	reservation := subscription.current
+	if reservation == nil {
+		return nil
+	}
+	if milesDriven < 0 {
+		return fmt.Errorf("miles driven cannot be negative")
+	}
+
+	switch reservation.GetStatus() {
+	case ReservationStatusConfirmed:
+		if milesDriven > 0 {
+			return fmt.Errorf("%s was never picked up, so no miles can be recorded", reservation.GetID())
+		}
+		if err := reservation.Cancel(); err != nil {
+			return err
+		}
+	case ReservationStatusPickedUp:
+		if err := reservation.Return(); err != nil {
+			return err
+		}
+		excessFee := subscription.addMiles(milesDriven)
+		reservation.vehicle.addMileage(milesDriven)
+
+		reservation.mutex.Lock()
+		reservation.returnDate = now
+		reservation.milesDriven = milesDriven
+		reservation.excessMileageFee = excessFee
+		reservation.totalAmount += excessFee
+		reservation.mutex.Unlock()
+
+		if excessFee > 0 {
+			if err := service.chargeCustomer(subscription.customer.GetID(), excessFee); err != nil {
+				return fmt.Errorf("excess mileage payment failed: %v", err)
+			}
+			subscription.charges = append(subscription.charges, SubscriptionCharge{
+				At:          now,
+				Description: fmt.Sprintf("Excess mileage on %s", reservation.GetID()),
+				Amount:      excessFee,
+			})
+		}
+	}
+	subscription.current = nil
+	return nil
+}
+
+// StartSubscriptionVehicle reserves a vehicle of the plan's class for a
+// member who holds none. This does not count as a swap.
+func (service *RentalService) StartSubscriptionVehicle(subscriptionID, vehicleID string, now time.Time) (*Reservation, error) {
+	subscription, err := service.getActiveSubscription(subscriptionID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer subscription.mutex.Unlock()
+
+	if subscription.current != nil {
+		return nil, fmt.Errorf("member already holds %s; swap or return it first", subscription.current.GetID())
+	}
+	return service.reserveForSubscription(subscription, vehicleID, now)
+}
+
+// SwapVehicle hands back the member's current vehicle (recording the miles
+// driven) and reserves another one of the plan's class. Fails without
+// changing anything once the month's swaps are used up or the new vehicle
+// can't be had.
+func (service *RentalService) SwapVehicle(subscriptionID, newVehicleID string, milesDriven int, now time.Time) (*Reservation, error) {
+	subscription, err := service.getActiveSubscription(subscriptionID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer subscription.mutex.Unlock()
+
+	if subscription.current == nil {
+		return nil, fmt.Errorf("member holds no vehicle to swap")
+	}
+	if subscription.swapsUsed >= subscription.plan.SwapsPerMonth {
+		return nil, fmt.Errorf("all %d swaps used this month (resets %s)",
+			subscription.plan.SwapsPerMonth, subscription.periodEnd().Format("Jan 02"))
+	}
+
+	// Check the new vehicle before letting go of the old one
+	service.mutex.RLock()
+	newVehicle, exists := service.vehicles[newVehicleID]
+	service.mutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("vehicle with ID '%s' not found", newVehicleID)
+	}
+	if newVehicle.GetType() != subscription.plan.VehicleType || !newVehicle.IsAvailable() {
+		return nil, fmt.Errorf("vehicle '%s' is not an available %s", newVehicleID, subscription.plan.VehicleType)
+	}
+
+	if err := service.handBack(subscription, milesDriven, now); err != nil {
+		return nil, err
+	}
+	reservation, err := service.reserveForSubscription(subscription, newVehicleID, now)
+	if err != nil {
+		return nil, err
+	}
+	subscription.swapsUsed++
+	return reservation, nil
+}
+
+// ReturnSubscriptionVehicle hands back the member's current vehicle without
+// taking a new one.
+func (service *RentalService) ReturnSubscriptionVehicle(subscriptionID string, milesDriven int, now time.Time) error {
+	subscription, err := service.getActiveSubscription(subscriptionID, now)
+	if err != nil {
+		return err
+	}
+	defer subscription.mutex.Unlock()
+
+	if subscription.current == nil {
+		return fmt.Errorf("member holds no vehicle to return")
+	}
+	return service.handBack(subscription, milesDriven, now)
+}
+
+// CancelSubscription hands back any vehicle and ends the membership.
+// Fees already taken are not refunded.
+func (service *RentalService) CancelSubscription(subscriptionID string, milesDriven int, now time.Time) error {
+	subscription, err := service.getActiveSubscription(subscriptionID, now)
+	if err != nil {
+		return err
+	}
+	defer subscription.mutex.Unlock()
+
+	if err := service.handBack(subscription, milesDriven, now); err != nil {
+		return err
+	}
+	subscription.status = SubscriptionStatusCancelled
+
+	service.mutex.Lock()
+	delete(service.members, subscription.customer.GetID())
+	service.mutex.Unlock()
+	return nil
+}
+
+// GetSubscriptionUsage reports swaps and miles for the billing month of `now`.
+func (service *RentalService) GetSubscriptionUsage(subscriptionID string, now time.Time) (SubscriptionUsage, error) {
+	subscription, err := service.getActiveSubscription(subscriptionID, now)
+	if err != nil {
+		return SubscriptionUsage{}, err
+	}
+	defer subscription.mutex.Unlock()
+
+	return SubscriptionUsage{
+		PeriodStart: subscription.periodStart,
+		PeriodEnd:   subscription.periodEnd(),
+		SwapsUsed:   subscription.swapsUsed,
+		SwapsLeft:   subscription.plan.SwapsPerMonth - subscription.swapsUsed,
+		MilesUsed:   subscription.milesUsed,
+		MilesLeft:   max(0, subscription.plan.MileageCap-subscription.milesUsed),
+	}, nil
+}
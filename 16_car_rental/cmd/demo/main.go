@@ -0,0 +1,8 @@
+// Command demo runs the carrental walkthrough: go run ./16_car_rental/cmd/demo
+package main
+
+import carrental "github.com/ayushgupta5/GoLLD/16_car_rental"
+
+func main() {
+	carrental.RunDemo()
+}
@@ -0,0 +1,683 @@
+package carrental
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	notification "github.com/ayushgupta5/GoLLD/18_notification_system"
+)
+
+// ============================================================================
+// SECTION 17: MAIN - DEMONSTRATION
+// ============================================================================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("         🚗 CAR RENTAL SYSTEM")
+	fmt.Println("═══════════════════════════════════════════")
+
+	// Initialize the rental service
+	rentalService := NewRentalService()
+
+	// =========================================
+	// STEP 1: Add vehicles to the fleet
+	// =========================================
+	fmt.Println("\n📦 Adding vehicles to fleet...")
+
+	rentalService.AddVehicle(NewVehicle("V001", "ABC-123", "Toyota", "Camry", 2023, VehicleTypeCar, "Airport"))
+	rentalService.AddVehicle(NewVehicle("V002", "XYZ-789", "Honda", "CR-V", 2023, VehicleTypeSUV, "Airport"))
+	rentalService.AddVehicle(NewVehicle("V003", "DEF-456", "BMW", "5 Series", 2024, VehicleTypeLuxury, "Downtown"))
+	rentalService.AddVehicle(NewVehicle("V004", "GHI-321", "Ford", "Explorer", 2022, VehicleTypeSUV, "Airport"))
+	rentalService.AddVehicle(NewVehicle("V005", "JKL-654", "Toyota", "Sienna", 2023, VehicleTypeVan, "Mall"))
+
+	fmt.Println("✅ 5 vehicles added to fleet")
+
+	// =========================================
+	// STEP 2: Register customers
+	// =========================================
+	fmt.Println("\n👥 Registering customers...")
+
+	customer1 := NewCustomer("C001", "John Doe", "john@email.com", "555-0101", "DL-12345")
+	customer2 := NewCustomer("C002", "Jane Smith", "jane@email.com", "555-0102", "DL-67890")
+
+	rentalService.RegisterCustomer(customer1)
+	rentalService.RegisterCustomer(customer2)
+
+	fmt.Printf("✅ Registered: %s\n", customer1.GetName())
+	fmt.Printf("✅ Registered: %s\n", customer2.GetName())
+
+	// =========================================
+	// STEP 3: Display fleet status
+	// =========================================
+	rentalService.ShowFleetStatus()
+
+	// =========================================
+	// STEP 4: Search for available vehicles
+	// =========================================
+	fmt.Println("\n🔍 Available at Airport:")
+	fmt.Println("─────────────────────────────────────────")
+
+	availableVehicles := rentalService.GetAllAvailableVehicles("Airport")
+	for _, vehicle := range availableVehicles {
+		fmt.Printf("  • %s\n", vehicle)
+	}
+
+	// =========================================
+	// STEP 5: Create a reservation
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📝 Creating Reservation...")
+
+	pickupDate := time.Now()
+	returnDate := pickupDate.Add(3 * 24 * time.Hour) // 3-day rental
+
+	reservation, err := rentalService.CreateReservation("C001", "V002", pickupDate, returnDate)
+	if err != nil {
+		fmt.Printf("❌ Error creating reservation: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Reservation created: %s (vehicle held until %s, %s pre-authorized $%.2f)\n",
+		reservation.GetID(), reservation.GetHoldExpiresAt().Format("15:04"),
+		reservation.GetPreAuthorization().GetID(), reservation.GetPreAuthorization().GetAmount())
+
+	// =========================================
+	// STEP 6: Add extras to the reservation
+	// =========================================
+	fmt.Println("\n🎁 Extras available for this SUV:")
+	compatibleExtras, _ := rentalService.GetCompatibleExtras("V002")
+	for _, extra := range compatibleExtras {
+		fmt.Printf("  • %s: $%.2f/day\n", extra.GetName(), extra.GetDailyPrice())
+	}
+
+	for _, extraName := range []string{"GPS Navigation", "Child Seat", "Helmet", "GPS Navigation"} {
+		if err := rentalService.AddExtra(reservation.GetID(), extraName); err != nil {
+			fmt.Printf("❌ %s: %v\n", extraName, err)
+		} else {
+			fmt.Printf("✅ Extra added: %s\n", extraName)
+		}
+	}
+
+	// =========================================
+	// STEP 6b: Choose insurance cover
+	// =========================================
+	fmt.Println("\n🛡️  Insurance options for SUV:")
+	for _, product := range rentalService.GetInsuranceCatalog().GetEligibleProducts(VehicleTypeSUV) {
+		fmt.Printf("  • %s: $%.2f/day, deductible $%.2f\n",
+			product.GetTier(), product.GetDailyPrice(), product.GetDeductible())
+	}
+
+	err = rentalService.AddInsurance(reservation.GetID(), InsuranceTierPremium)
+	if err != nil {
+		fmt.Printf("❌ Error adding insurance: %v\n", err)
+		return
+	}
+	fmt.Println("✅ Premium insurance added")
+
+	// =========================================
+	// STEP 7: Confirm and pickup the vehicle
+	// =========================================
+	err = rentalService.ConfirmReservation(reservation.GetID())
+	if err != nil {
+		fmt.Printf("❌ Error confirming reservation: %v\n", err)
+		return
+	}
+	fmt.Println("✅ Reservation confirmed")
+
+	err = rentalService.PickUpVehicle(reservation.GetID())
+	if err != nil {
+		fmt.Printf("❌ Error picking up vehicle: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Vehicle picked up by %s\n", customer1.GetName())
+
+	// Show updated fleet status
+	rentalService.ShowFleetStatus()
+
+	// =========================================
+	// STEP 8: Return the vehicle
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔑 Returning vehicle...")
+
+	claim, err := rentalService.ReturnVehicleWithDamage(reservation.GetID(), "Scratched rear bumper", 600.00)
+	if err != nil {
+		fmt.Printf("❌ Error returning vehicle: %v\n", err)
+		return
+	}
+	fmt.Println("✅ Vehicle returned with damage")
+	fmt.Printf("   Claim: %s - $%.2f total, customer pays $%.2f, insurer pays $%.2f\n",
+		claim.GetDescription(), claim.GetDamageCost(), claim.GetCustomerPays(), claim.GetInsurerPays())
+
+	// =========================================
+	// STEP 9: Print the final receipt
+	// =========================================
+	reservation.PrintReceipt()
+	fmt.Printf("💳 Payment %s: %s $%.2f\n", reservation.GetPreAuthorization().GetID(),
+		rentalService.GetPaymentStatus(reservation), reservation.GetPreAuthorization().GetCapturedAmount())
+
+	// Show final fleet status
+	rentalService.ShowFleetStatus()
+
+	// =========================================
+	// STEP 10: Unconfirmed hold expires
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("⏳ Hold Expiry...")
+
+	rentalService.SetHoldDuration(200 * time.Millisecond) // Shortened for the demo
+	rentalService.StartHoldExpiryJob(50 * time.Millisecond)
+	defer rentalService.StopHoldExpiryJob()
+
+	heldReservation, err := rentalService.CreateReservation("C002", "V003", pickupDate, returnDate)
+	if err != nil {
+		fmt.Printf("❌ Error creating reservation: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ %s holds V003 (%s), customer never confirms...\n",
+		heldReservation.GetID(), heldReservation.GetStatus())
+
+	time.Sleep(400 * time.Millisecond)
+
+	fmt.Printf("   Reservation: %s, Payment: %s\n",
+		heldReservation.GetStatus(), rentalService.GetPaymentStatus(heldReservation))
+	if err := rentalService.ConfirmReservation(heldReservation.GetID()); err != nil {
+		fmt.Printf("❌ Late confirmation: %v\n", err)
+	}
+	rentalService.ShowFleetStatus()
+
+	// =========================================
+	// STEP 11: Fleet analytics
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📈 Fleet Analytics...")
+
+	// Complete a couple more rentals so the week has some history
+	completedRentals := []struct {
+		customerID, vehicleID string
+		days                  int
+	}{
+		{"C002", "V001", 5},
+		{"C001", "V005", 2},
+	}
+	for _, rental := range completedRentals {
+		rentalReservation, err := rentalService.CreateReservation(rental.customerID, rental.vehicleID,
+			pickupDate, pickupDate.Add(time.Duration(rental.days)*24*time.Hour))
+		if err != nil {
+			fmt.Printf("❌ Error creating reservation: %v\n", err)
+			continue
+		}
+		_ = rentalService.ConfirmReservation(rentalReservation.GetID())
+		_ = rentalService.PickUpVehicle(rentalReservation.GetID())
+		_ = rentalService.ReturnVehicle(rentalReservation.GetID())
+	}
+
+	reportFrom := pickupDate
+	reportTo := pickupDate.Add(7 * 24 * time.Hour)
+	report, err := rentalService.GenerateFleetReport(reportFrom, reportTo, 0.2, 3)
+	if err != nil {
+		fmt.Printf("❌ Error generating report: %v\n", err)
+		return
+	}
+
+	fmt.Println("  Utilization by type (7 days):")
+	for _, group := range report.ByType {
+		fmt.Printf("    %-8s %d vehicle(s)  %5.1f%%  $%.2f\n",
+			group.Group, group.Vehicles, group.Utilization*100, group.Revenue)
+	}
+	fmt.Println("  Utilization by location:")
+	for _, group := range report.ByLocation {
+		fmt.Printf("    %-8s %d vehicle(s)  %5.1f%%  $%.2f\n",
+			group.Group, group.Vehicles, group.Utilization*100, group.Revenue)
+	}
+
+	idleIDs := make([]string, 0, len(report.IdleVehicles))
+	for _, row := range report.IdleVehicles {
+		idleIDs = append(idleIDs, row.VehicleID)
+	}
+	fmt.Printf("  Idle vehicles (<20%% utilized): %s\n", strings.Join(idleIDs, ", "))
+
+	var csvOutput strings.Builder
+	if err := report.WriteVehiclesCSV(&csvOutput); err == nil {
+		fmt.Println("  vehicles.csv:")
+		fmt.Print("    " + strings.ReplaceAll(strings.TrimSpace(csvOutput.String()), "\n", "\n    ") + "\n")
+	}
+	csvOutput.Reset()
+	if err := report.WriteCustomersCSV(&csvOutput); err == nil {
+		fmt.Println("  top_customers.csv:")
+		fmt.Print("    " + strings.ReplaceAll(strings.TrimSpace(csvOutput.String()), "\n", "\n    ") + "\n")
+	}
+
+	// =========================================
+	// STEP 12: GPS tracking and geofenced returns
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📍 GPS Tracking & Geofenced Returns...")
+
+	_ = rentalService.SetLocationGeofence("Airport", GeoPoint{40.6413, -73.7781}, 1500)
+	_ = rentalService.SetLocationGeofence("Downtown", GeoPoint{40.7128, -74.0060}, 800)
+	_ = rentalService.SetLocationGeofence("Mall", GeoPoint{40.7580, -73.9855}, 500)
+
+	tripReservation, err := rentalService.CreateReservation("C002", "V004", pickupDate, pickupDate.Add(24*time.Hour))
+	if err != nil {
+		fmt.Printf("❌ Error creating reservation: %v\n", err)
+		return
+	}
+	_ = rentalService.ConfirmReservation(tripReservation.GetID())
+	_ = rentalService.PickUpVehicle(tripReservation.GetID())
+
+	tripStart := time.Now()
+	trip := []GPSPing{
+		{VehicleID: "V004", Position: GeoPoint{40.6450, -73.7800}, RecordedAt: tripStart},                       // Airport lot
+		{VehicleID: "V004", Position: GeoPoint{40.6900, -73.8900}, RecordedAt: tripStart.Add(20 * time.Minute)}, // Highway
+		{VehicleID: "V004", Position: GeoPoint{40.7130, -74.0050}, RecordedAt: tripStart.Add(45 * time.Minute)}, // Downtown
+		{VehicleID: "V004", Position: GeoPoint{40.6800, -73.8500}, RecordedAt: tripStart.Add(30 * time.Minute)}, // Late delivery
+	}
+	for _, ping := range trip {
+		if accepted, err := rentalService.IngestPing(ping); err != nil || !accepted {
+			fmt.Printf("  ⚠️  Ignored out-of-order ping from %s\n", ping.RecordedAt.Format("15:04"))
+		}
+	}
+	if _, err := rentalService.IngestPing(GPSPing{VehicleID: "V999", Position: GeoPoint{0, 0}, RecordedAt: tripStart}); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	lastKnown, _ := rentalService.GetLastKnownPosition("V004")
+	fmt.Printf("  V004 last seen at (%.4f, %.4f)\n", lastKnown.Position.Latitude, lastKnown.Position.Longitude)
+
+	// The customer leaves the car downtown instead of at the airport
+	if err := rentalService.ReturnVehicle(tripReservation.GetID()); err != nil {
+		fmt.Printf("❌ Error returning vehicle: %v\n", err)
+		return
+	}
+	check := tripReservation.GetReturnLocationCheck()
+	fmt.Printf("  Returned inside %s geofence, %.1f km from Airport → fee $%.2f\n",
+		check.DetectedLocation, check.DistanceMeters/1000, check.Fee)
+	tripReservation.PrintReceipt()
+	rentalService.ShowFleetStatus()
+
+	// =========================================
+	// STEP 13: Partner garages and fleet rebalancing
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🏢 Partner Garages & Rebalancing...")
+
+	garages := []PartnerGarage{
+		{Name: "Boston Back Bay", City: "Boston", Capacity: 3, TypeCaps: map[VehicleType]int{VehicleTypeSUV: 1}, DropOffFee: 150, Overflow: OverflowQueue},
+		{Name: "Boston Seaport", City: "Boston", Capacity: 4, DropOffFee: 150, Overflow: OverflowQueue},
+		{Name: "Philly Center", City: "Philadelphia", Capacity: 1, DropOffFee: 120, Overflow: OverflowReject},
+	}
+	for _, garage := range garages {
+		if err := rentalService.AddPartnerGarage(garage); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+		}
+	}
+	if err := rentalService.AddPartnerGarage(PartnerGarage{Name: "Airport", City: HomeCity, Capacity: 10}); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	rentalService.AddVehicle(NewVehicle("V006", "BOS-001", "Jeep", "Cherokee", 2023, VehicleTypeSUV, "Boston Back Bay"))
+	rentalService.AddVehicle(NewVehicle("V007", "PHL-001", "Kia", "Forte", 2022, VehicleTypeCar, "Philly Center"))
+	rentalService.AddVehicle(NewVehicle("V008", "NYC-808", "Toyota", "RAV4", 2024, VehicleTypeSUV, "Airport"))
+
+	oneWay, err := rentalService.CreateReservation("C001", "V008", pickupDate, pickupDate.Add(48*time.Hour))
+	if err != nil {
+		fmt.Printf("❌ Error creating reservation: %v\n", err)
+		return
+	}
+	_ = rentalService.ConfirmReservation(oneWay.GetID())
+	_ = rentalService.PickUpVehicle(oneWay.GetID())
+
+	// Philadelphia is full and refuses; Boston already has its one SUV but queues the overflow
+	if err := rentalService.ReturnVehicleAt(oneWay.GetID(), "Philly Center"); err != nil {
+		fmt.Printf("  ❌ Return at Philly Center: %v\n", err)
+	}
+	if err := rentalService.ReturnVehicleAt(oneWay.GetID(), "Boston Back Bay"); err != nil {
+		fmt.Printf("  ❌ Return at Boston Back Bay: %v\n", err)
+	} else {
+		occupancy, _ := rentalService.GetGarageOccupancy("Boston Back Bay")
+		fmt.Printf("  ✅ V008 returned at Boston Back Bay (%d parked, %d SUVs)\n", occupancy.Total, occupancy.ByType[VehicleTypeSUV])
+		fmt.Printf("  Rebalancing queue: %v\n", rentalService.GetRebalancingQueue())
+	}
+	oneWay.PrintReceipt()
+
+	plan := rentalService.PlanRebalancing()
+	fmt.Println("  🔁 Suggested transfers:")
+	for _, transfer := range plan {
+		fmt.Printf("     • %s\n", transfer)
+	}
+	for _, transfer := range plan {
+		if err := rentalService.ExecuteTransfer(transfer); err != nil {
+			fmt.Printf("  ❌ Transfer %s: %v\n", transfer.VehicleID, err)
+		}
+	}
+	for _, garage := range garages {
+		occupancy, _ := rentalService.GetGarageOccupancy(garage.Name)
+		fmt.Printf("  %s (%s): %d/%d parked\n", garage.Name, garage.City, occupancy.Total, garage.Capacity)
+	}
+	fmt.Printf("  Rebalancing queue after transfers: %v\n", rentalService.GetRebalancingQueue())
+
+	// =========================================
+	// STEP 14: Condition reports at pickup and return
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📸 Condition Reports & Damage Diff...")
+
+	rentalService.AddVehicle(NewVehicle("V009", "NYC-909", "Mazda", "CX-5", 2023, VehicleTypeSUV, "Downtown"))
+	inspected, err := rentalService.CreateReservation("C002", "V009", pickupDate, pickupDate.Add(72*time.Hour))
+	if err != nil {
+		fmt.Printf("❌ Error creating reservation: %v\n", err)
+		return
+	}
+	_ = rentalService.AddInsurance(inspected.GetID(), InsuranceTierPremium)
+	_ = rentalService.ConfirmReservation(inspected.GetID())
+
+	bumperPhoto, _ := rentalService.UploadInspectionPhoto(inspected.GetID(), "pickup-rear-bumper.jpg", []byte{0xFF, 0xD8, 0xFF, 0xE0})
+	pickupReport, err := rentalService.FileConditionReport(inspected.GetID(), ReportStagePickup, "Maria (Downtown)", "Clean interior",
+		[]DamageAnnotation{
+			{Panel: "Rear bumper", Kind: "scratch", Severity: DamageMinor, RepairCost: 120, PhotoRefs: []string{bumperPhoto}},
+			{Panel: "Driver door", Kind: "dent", Severity: DamageMinor, RepairCost: 150},
+		})
+	if err != nil {
+		fmt.Printf("  ❌ Pickup report: %v\n", err)
+		return
+	}
+	fmt.Printf("  📝 %s (%s): %d pre-existing damages, photo %s\n",
+		pickupReport.ID, pickupReport.Stage, len(pickupReport.Damages), bumperPhoto)
+	_ = rentalService.PickUpVehicle(inspected.GetID())
+
+	// Photos can also live on disk: the reference is then the file path
+	if diskStore, err := NewDirectoryPhotoStore(filepath.Join(os.TempDir(), "car-rental-photos")); err == nil {
+		_ = os.RemoveAll(filepath.Join(os.TempDir(), "car-rental-photos", inspected.GetID()))
+		rentalService.SetPhotoStore(diskStore)
+	}
+	windshieldPhoto, err := rentalService.UploadInspectionPhoto(inspected.GetID(), "return-windshield.jpg", []byte{0xFF, 0xD8, 0xFF, 0xE1})
+	if err != nil {
+		fmt.Printf("  ❌ Photo upload: %v\n", err)
+	}
+
+	_, err = rentalService.FileConditionReport(inspected.GetID(), ReportStageReturn, "Sam (Downtown)", "",
+		[]DamageAnnotation{{Panel: "Windshield", Kind: "crack", Severity: DamageSevere, RepairCost: 400, PhotoRefs: []string{"missing.jpg"}}})
+	fmt.Printf("  ❌ Return report with unknown photo: %v\n", err)
+
+	returnReport, err := rentalService.FileConditionReport(inspected.GetID(), ReportStageReturn, "Sam (Downtown)", "Returned with full tank",
+		[]DamageAnnotation{
+			{Panel: "Rear bumper", Kind: "scratch", Severity: DamageMinor, RepairCost: 120},
+			{Panel: "Driver door", Kind: "dent", Severity: DamageModerate, RepairCost: 350},
+			{Panel: "Windshield", Kind: "crack", Severity: DamageSevere, RepairCost: 400, PhotoRefs: []string{windshieldPhoto}},
+		})
+	if err != nil {
+		fmt.Printf("  ❌ Return report: %v\n", err)
+		return
+	}
+	fmt.Printf("  📝 %s (%s): %d damages, photo %s\n", returnReport.ID, returnReport.Stage, len(returnReport.Damages), windshieldPhoto)
+
+	inspectionClaim, diff, err := rentalService.ReturnVehicleWithInspection(inspected.GetID())
+	if err != nil {
+		fmt.Printf("  ❌ Return: %v\n", err)
+	}
+	for _, damage := range diff.Unchanged {
+		fmt.Printf("     = %s (pre-existing, not charged)\n", damage)
+	}
+	for _, change := range diff.Worsened {
+		fmt.Printf("     ↑ %s → %s (charged $%.2f)\n", change.Before, change.After.Severity,
+			change.After.RepairCost-change.Before.RepairCost)
+	}
+	for _, damage := range diff.NewDamage {
+		fmt.Printf("     + %s (new)\n", damage)
+	}
+	if inspectionClaim != nil {
+		fmt.Printf("  🧾 Claim: %s — cost $%.2f, customer pays $%.2f, insurer $%.2f\n",
+			inspectionClaim.GetDescription(), inspectionClaim.GetDamageCost(),
+			inspectionClaim.GetCustomerPays(), inspectionClaim.GetInsurerPays())
+	}
+
+	// =========================================
+	// STEP 15: Receipts as data, exported to JSON and HTML
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🧾 Receipt Export...")
+
+	receipt, err := rentalService.GetReceipt(reservation.GetID())
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("  %s: %d line(s), subtotal $%.2f, total $%.2f\n",
+			receipt.ReservationID, len(receipt.Lines), receipt.Subtotal, receipt.Total)
+		for _, line := range receipt.Lines {
+			fmt.Printf("     [%s] %s\n", line.Kind, line)
+		}
+
+		fmt.Println("  JSON (API response):")
+		var jsonBody strings.Builder
+		if err := receipt.WriteJSON(&jsonBody); err != nil {
+			fmt.Printf("  ❌ JSON: %v\n", err)
+		}
+		for _, jsonLine := range strings.Split(strings.TrimRight(jsonBody.String(), "\n"), "\n") {
+			fmt.Printf("     %s\n", jsonLine)
+		}
+
+		// The HTML page is an email body; write it to a file instead of the console
+		htmlPath := filepath.Join(os.TempDir(), "receipt-"+receipt.ReservationID+".html")
+		if htmlFile, err := os.Create(htmlPath); err != nil {
+			fmt.Printf("  ❌ HTML: %v\n", err)
+		} else {
+			writeErr := receipt.WriteHTML(htmlFile)
+			closeErr := htmlFile.Close()
+			if writeErr != nil || closeErr != nil {
+				fmt.Printf("  ❌ HTML: %v\n", errors.Join(writeErr, closeErr))
+			} else {
+				fmt.Printf("  HTML (email body) written to %s\n", htmlPath)
+			}
+		}
+	}
+
+	// =========================================
+	// STEP 16: Driver license verification
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🪪 Driver License Verification...")
+
+	licenseAuthority := NewSimulatedLicenseVerifier()
+	licenseAuthority.AddLicense(LicenseRecord{Number: "DL-55501", Holder: "Ravi Kumar",
+		Classes: []LicenseClass{LicenseClassB}, ExpiresAt: pickupDate.AddDate(2, 0, 0)})
+	licenseAuthority.AddLicense(LicenseRecord{Number: "DL-55502", Holder: "Sara Lind",
+		Classes: []LicenseClass{LicenseClassA, LicenseClassB}, ExpiresAt: pickupDate.AddDate(0, 0, 2)})
+	licenseAuthority.AddLicense(LicenseRecord{Number: "DL-55503", Holder: "Tom Berg",
+		Classes: []LicenseClass{LicenseClassB}, ExpiresAt: pickupDate.AddDate(1, 0, 0), Suspended: true})
+	rentalService.SetLicenseVerifier(licenseAuthority)
+
+	applicants := []*Customer{
+		NewCustomer("C101", "Ravi Kumar", "ravi@email.com", "555-0201", "DL-55501"),
+		NewCustomer("C102", "Sara Lind", "sara@email.com", "555-0202", "DL-55502"),
+		NewCustomer("C103", "Tom Berg", "tom@email.com", "555-0203", "DL-55503"),
+		NewCustomer("C104", "Ana Lopez", "ana@email.com", "555-0204", "DL-00000"),
+	}
+	for _, applicant := range applicants {
+		if err := rentalService.RegisterCustomer(applicant); err != nil {
+			fmt.Printf("  ❌ Register %s: %v\n", applicant.GetName(), err)
+		} else {
+			fmt.Printf("  ✅ Registered %s (%s)\n", applicant.GetName(), applicant.GetDriverLicense())
+		}
+	}
+
+	// pickupAttempt reserves a vehicle for a registered customer and tries to drive off
+	rentalService.AddVehicle(NewVehicle("V020", "VAN-020", "Ford", "Transit", 2024, VehicleTypeVan, "Downtown"))
+	rentalService.AddVehicle(NewVehicle("V021", "CAR-021", "Mazda", "3", 2024, VehicleTypeCar, "Downtown"))
+	pickupAttempt := func(customerID, vehicleID string, days int) {
+		attempt, err := rentalService.CreateReservation(customerID, vehicleID, pickupDate, pickupDate.AddDate(0, 0, days))
+		if err != nil {
+			fmt.Printf("  ❌ Reserve %s: %v\n", vehicleID, err)
+			return
+		}
+		_ = rentalService.ConfirmReservation(attempt.GetID())
+
+		err = rentalService.PickUpVehicle(attempt.GetID())
+		var licenseErr *LicenseVerificationError
+		switch {
+		case errors.As(err, &licenseErr):
+			fmt.Printf("  🚫 Pickup blocked for %s, reservation kept %s: %s\n",
+				attempt.GetID(), attempt.GetStatus(), licenseErr.Detail)
+			_ = rentalService.CancelReservation(attempt.GetID())
+		case err != nil:
+			fmt.Printf("  ❌ Pickup %s: %v\n", attempt.GetID(), err)
+		default:
+			fmt.Printf("  ✅ %s picked up %s for %d day(s)\n", customerID, vehicleID, days)
+			_ = rentalService.ReturnVehicle(attempt.GetID())
+		}
+	}
+	pickupAttempt("C101", "V020", 1) // Class B cannot drive a van
+	pickupAttempt("C102", "V021", 5) // License expires mid-rental
+	pickupAttempt("C101", "V021", 3)
+
+	// =========================================
+	// STEP 17: Reservation lifecycle notifications
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔔 Reservation Notifications...")
+
+	// Notices go out through the notification system's email channel
+	notifications := notification.NewNotificationService()
+	notifications.RegisterChannel(notification.NewEmailChannel("smtp.rentals.example.com", 587, "rentals@example.com"))
+	for _, template := range DefaultRentalTemplates(notification.NotificationTypeEmail) {
+		notifications.AddTemplate(template)
+	}
+	notifier := rentalService.SetNotificationSender(notifications)
+
+	rentalService.AddVehicle(NewVehicle("V030", "CAR-030", "Honda", "Civic", 2025, VehicleTypeCar, "Airport"))
+	rentalService.AddVehicle(NewVehicle("V031", "SUV-031", "Kia", "Sorento", 2025, VehicleTypeSUV, "Airport"))
+	rentalStart := time.Now().Add(72 * time.Hour).Truncate(time.Hour)
+	rentalEnd := rentalStart.Add(4 * 24 * time.Hour)
+
+	rental, err := rentalService.CreateReservation("C101", "V030", rentalStart, rentalEnd)
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		_ = rentalService.ConfirmReservation(rental.GetID())
+
+		// A second booking is cancelled; its reminders are skipped when they come due
+		if dropped, err := rentalService.CreateReservation("C101", "V031", rentalStart, rentalEnd); err == nil {
+			_ = rentalService.ConfirmReservation(dropped.GetID())
+			_ = rentalService.CancelReservation(dropped.GetID())
+		}
+		fmt.Printf("  🗓️  %d reminder(s) scheduled\n", len(notifier.GetPending()))
+
+		// Dispatch as the clock passes each reminder
+		dispatchAt := func(label string, now time.Time) {
+			fmt.Printf("  ⏩ %s (%s)\n", label, now.Format("Jan 02 15:04"))
+			for _, scheduled := range rentalService.DispatchNotices(now) {
+				if scheduled.Skipped {
+					fmt.Printf("  ⏭️  Skipped: %s\n", scheduled)
+				}
+			}
+		}
+		dispatchAt("A day before pickup", rentalStart.Add(-23*time.Hour))
+		_ = rentalService.PickUpVehicle(rental.GetID())
+		dispatchAt("Before return", rentalEnd.Add(-time.Hour))
+		dispatchAt("Return time passed", rentalEnd.Add(time.Hour))
+		_ = rentalService.ReturnVehicle(rental.GetID())
+		fmt.Printf("  ✅ %d notice(s) dispatched, %d failed, %d still pending\n",
+			len(notifier.GetLog()), len(notifier.GetFailed()), len(notifier.GetPending()))
+	}
+
+	// =========================================
+	// STEP 18: Subscription plans (monthly car swap)
+	// =========================================
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔄 Subscription Plans...")
+
+	_ = rentalService.AddSubscriptionPlan(SubscriptionPlan{Name: "SUV Flex", VehicleType: VehicleTypeSUV,
+		MonthlyFee: 899, SwapsPerMonth: 1, MileageCap: 1500, ExcessMileRate: 0.25})
+	if err := rentalService.AddSubscriptionPlan(SubscriptionPlan{Name: "Broken", MonthlyFee: 0}); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+	firstSUV := NewVehicle("V040", "SUB-040", "Toyota", "RAV4", 2025, VehicleTypeSUV, "Airport")
+	lastSUV := NewVehicle("V042", "SUB-042", "Subaru", "Outback", 2025, VehicleTypeSUV, "Airport")
+	rentalService.AddVehicle(firstSUV)
+	rentalService.AddVehicle(NewVehicle("V041", "SUB-041", "Mazda", "CX-5", 2025, VehicleTypeSUV, "Airport"))
+	rentalService.AddVehicle(lastSUV)
+	rentalService.AddVehicle(NewVehicle("V043", "SUB-043", "Audi", "A6", 2025, VehicleTypeLuxury, "Airport"))
+
+	memberSince := time.Now().Truncate(time.Hour)
+	membership, err := rentalService.Subscribe("C101", "SUV Flex", memberSince)
+	if err != nil {
+		fmt.Printf("  ❌ Error: %v\n", err)
+	} else {
+		fmt.Printf("  ✅ %s subscribed to %s ($%.2f/month, %d swap, %d miles)\n", membership.GetCustomer().GetName(),
+			membership.GetPlan().Name, membership.GetPlan().MonthlyFee, membership.GetPlan().SwapsPerMonth, membership.GetPlan().MileageCap)
+
+		if _, err := rentalService.StartSubscriptionVehicle(membership.GetID(), "V043", memberSince); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+		}
+		firstCar, _ := rentalService.StartSubscriptionVehicle(membership.GetID(), "V040", memberSince)
+		_ = rentalService.PickUpVehicle(firstCar.GetID())
+		fmt.Printf("  🚙 %s: V040 picked up, total $%.2f (no per-day pricing)\n", firstCar.GetID(), firstCar.GetTotal())
+
+		// Day 10: swap after 700 miles
+		swapDay := memberSince.AddDate(0, 0, 10)
+		secondCar, err := rentalService.SwapVehicle(membership.GetID(), "V041", 700, swapDay)
+		if err != nil {
+			fmt.Printf("  ❌ Swap: %v\n", err)
+		} else {
+			_ = rentalService.PickUpVehicle(secondCar.GetID())
+			fmt.Printf("  🔁 Day 10: swapped to V041 (%s), V040 odometer %d miles\n", secondCar.GetID(), firstSUV.GetMileage())
+		}
+		if _, err := rentalService.SwapVehicle(membership.GetID(), "V042", 200, memberSince.AddDate(0, 0, 15)); err != nil {
+			fmt.Printf("  ❌ Day 15 swap: %v\n", err)
+		}
+
+		// Day 25: hand back after another 900 miles (100 over the cap)
+		_ = rentalService.ReturnSubscriptionVehicle(membership.GetID(), 900, memberSince.AddDate(0, 0, 25))
+		usage, _ := rentalService.GetSubscriptionUsage(membership.GetID(), memberSince.AddDate(0, 0, 25))
+		fmt.Printf("  📊 Month 1: %d/%d swaps, %d miles (%d left)\n",
+			usage.SwapsUsed, usage.SwapsUsed+usage.SwapsLeft, usage.MilesUsed, usage.MilesLeft)
+		if secondCar != nil {
+			if receipt, err := rentalService.GetReceipt(secondCar.GetID()); err == nil {
+				for _, line := range receipt.Lines {
+					fmt.Printf("     %s\n", line)
+				}
+			}
+		}
+
+		// Next month: the fee is charged and the allowance resets
+		nextMonth := memberSince.AddDate(0, 1, 2)
+		if _, err := rentalService.StartSubscriptionVehicle(membership.GetID(), "V042", nextMonth); err == nil {
+			usage, _ = rentalService.GetSubscriptionUsage(membership.GetID(), nextMonth)
+			fmt.Printf("  📊 Month 2 (from %s): V042 reserved, %d swap(s) left, %d miles left\n",
+				usage.PeriodStart.Format("Jan 02"), usage.SwapsLeft, usage.MilesLeft)
+		}
+		_ = rentalService.CancelSubscription(membership.GetID(), 0, nextMonth)
+		for _, charge := range membership.GetCharges() {
+			fmt.Printf("  💳 %s  %-34s $%.2f\n", charge.At.Format("Jan 02"), charge.Description, charge.Amount)
+		}
+		fmt.Printf("  Subscription %s, V042 %s\n", membership.GetStatus(), lastSUV.GetStatus())
+	}
+
+	// =========================================
+	// SUMMARY: Key Design Decisions
+	// =========================================
+	fmt.Println("\n═══════════════════════════════════════════")
+	fmt.Println("  KEY DESIGN DECISIONS:")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  1. Vehicle types with different daily rates")
+	fmt.Println("  2. Reservation lifecycle: Pending → Confirmed → PickedUp → Returned")
+	fmt.Println("  3. Extras added dynamically, validated against vehicle type")
+	fmt.Println("  4. Location-based fleet management")
+	fmt.Println("  5. Thread-safe operations using mutex locks")
+	fmt.Println("  6. Clean separation of entities and service layer")
+	fmt.Println("  7. Insurance tiers with eligibility rules and deductible-based claims")
+	fmt.Println("  8. Pending reservations hold the vehicle + card; expiry job releases both")
+	fmt.Println("  9. Analytics return report structs; CSV export is a separate renderer")
+	fmt.Println("  10. Pluggable telemetry store; returns checked against location geofences")
+	fmt.Println("  11. Partner garages cap returns per type; planner suggests transfers, never moves")
+	fmt.Println("  12. Condition reports keep photo refs only; pickup/return diff drives damage claims")
+	fmt.Println("  13. Receipts are structured data; text, JSON and HTML are separate writers")
+	fmt.Println("  14. License verifier runs at registration and pickup; failures are typed errors")
+	fmt.Println("  15. Lifecycle notices are templates + scheduled reminders, checked against status when due")
+	fmt.Println("  16. Subscriptions: monthly fee per class, limited swaps, unpriced reservations with mileage caps")
+	fmt.Println("═══════════════════════════════════════════")
+}
@@ -0,0 +1,8 @@
+// Command demo runs the library walkthrough: go run ./17_library_management/cmd/demo
+package main
+
+import library "github.com/ayushgupta5/GoLLD/17_library_management"
+
+func main() {
+	library.RunDemo()
+}
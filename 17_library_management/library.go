@@ -1,4 +1,6 @@
-package main
+// Package library lends books to members: copies, loans, due dates and
+// fines, reservations and search.
+package library
 
 import (
 	"fmt"
@@ -667,97 +669,3 @@ func (lib *Library) PrintCatalog() {
 		fmt.Printf("  %s %s\n", availabilityIndicator, book)
 	}
 }
-
-// ========== MAIN ==========
-
-func main() {
-	fmt.Println("═══════════════════════════════════════════")
-	fmt.Println("       📚 LIBRARY MANAGEMENT SYSTEM")
-	fmt.Println("═══════════════════════════════════════════")
-
-	// Create library
-	library := NewLibrary("City Central Library")
-
-	// Add books
-	library.AddBook(NewBook("978-0134190440", "Clean Code", "Robert C. Martin", "Pearson", 3))
-	library.AddBook(NewBook("978-0201633610", "Design Patterns", "Gang of Four", "Addison-Wesley", 2))
-	library.AddBook(NewBook("978-0596007126", "Head First Design Patterns", "Eric Freeman", "O'Reilly", 4))
-	library.AddBook(NewBook("978-0132350884", "Clean Architecture", "Robert C. Martin", "Pearson", 2))
-	library.AddBook(NewBook("978-1617294549", "Go in Action", "William Kennedy", "Manning", 3))
-
-	// Register members
-	member1 := NewMember("M001", "John Doe", "john@email.com", "555-0101")
-	member2 := NewMember("M002", "Jane Smith", "jane@email.com", "555-0102")
-	library.RegisterMember(member1)
-	library.RegisterMember(member2)
-
-	library.PrintCatalog()
-
-	// Search books
-	fmt.Println("\n🔍 Searching for 'Clean'...")
-	results := library.SearchByTitle("Clean")
-	for _, book := range results {
-		fmt.Printf("  Found: %s\n", book.title)
-	}
-
-	// Issue books
-	fmt.Println("\n─────────────────────────────────────────")
-	fmt.Println("📖 Issuing books...")
-
-	loan1, err := library.IssueBook("M001", "978-0134190440")
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-	} else {
-		fmt.Printf("✅ Issued '%s' to %s\n", loan1.bookCopy.book.title, member1.name)
-		fmt.Printf("   Due date: %s\n", loan1.dueDate.Format("Jan 02, 2006"))
-	}
-
-	loan2, err := library.IssueBook("M001", "978-0201633610")
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-	} else {
-		fmt.Printf("✅ Issued '%s' to %s\n", loan2.bookCopy.book.title, member1.name)
-	}
-
-	loan3, err := library.IssueBook("M002", "978-1617294549")
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-	} else {
-		fmt.Printf("✅ Issued '%s' to %s\n", loan3.bookCopy.book.title, member2.name)
-	}
-
-	library.PrintCatalog()
-
-	// Show member's books
-	fmt.Println("\n📋 John's borrowed books:")
-	for _, loan := range library.GetMemberLoans("M001") {
-		fmt.Printf("  • %s (Due: %s)\n",
-			loan.bookCopy.book.title,
-			loan.dueDate.Format("Jan 02"))
-	}
-
-	// Return a book
-	fmt.Println("\n─────────────────────────────────────────")
-	fmt.Println("📥 Returning book...")
-
-	fine, err := library.ReturnBook(loan1.id)
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-	} else {
-		fmt.Printf("✅ Returned '%s'\n", loan1.bookCopy.book.title)
-		if fine > 0 {
-			fmt.Printf("   Fine: $%.2f\n", fine)
-		}
-	}
-
-	library.PrintCatalog()
-
-	fmt.Println("\n═══════════════════════════════════════════")
-	fmt.Println("  KEY DESIGN DECISIONS:")
-	fmt.Println("═══════════════════════════════════════════")
-	fmt.Println("  1. Book vs BookCopy separation")
-	fmt.Println("  2. Member borrowing limits")
-	fmt.Println("  3. Fine calculation for overdue")
-	fmt.Println("  4. Search by title/author/ISBN")
-	fmt.Println("═══════════════════════════════════════════")
-}
@@ -0,0 +1,8 @@
+// Command demo runs the notification walkthrough: go run ./18_notification_system/cmd/demo
+package main
+
+import notification "github.com/ayushgupta5/GoLLD/18_notification_system"
+
+func main() {
+	notification.RunDemo()
+}
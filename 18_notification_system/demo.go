@@ -0,0 +1,542 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==================== MAIN - DEMO ====================
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("       🔔 NOTIFICATION SYSTEM DEMO")
+	fmt.Println("═══════════════════════════════════════════")
+
+	// ========== STEP 1: Create the notification service ==========
+	service := NewNotificationService()
+	ctx := context.Background()
+
+	// ========== STEP 2: Configure notification channels ==========
+	// We use decorators to add logging and retry capabilities
+
+	// Email channel with retry and logging
+	emailChannel := NewLoggingDecorator(
+		NewRetryDecorator(
+			NewEmailChannel("smtp.example.com", 587, "noreply@example.com"),
+			3,           // Max 3 retries
+			time.Second, // 1 second between retries
+		),
+	)
+
+	// Other channels with logging only
+	smsChannel := NewLoggingDecorator(
+		NewSMSChannel("twilio", "api-key-here"),
+	)
+	pushChannel := NewLoggingDecorator(
+		NewPushChannel("fcm-key-here"),
+	)
+	slackChannel := NewLoggingDecorator(
+		NewSlackChannel("https://hooks.slack.com/services/..."),
+	)
+
+	// Register all channels with the service
+	service.RegisterChannel(emailChannel)
+	service.RegisterChannel(smsChannel)
+	service.RegisterChannel(pushChannel)
+	service.RegisterChannel(slackChannel)
+
+	// ========== STEP 3: Configure user preferences ==========
+	userPrefs := NewUserPreferences("user123")
+	userPrefs.Email = "user@example.com"
+	userPrefs.Phone = "+1234567890"
+	userPrefs.EnabledChannels[NotificationTypeSMS] = true // Enable SMS
+	// userPrefs.QuietHoursStart = 22 // Uncomment to test quiet hours
+	// userPrefs.QuietHoursEnd = 7
+	service.SetUserPreferences(userPrefs)
+
+	// ========== STEP 4: Add notification templates ==========
+	welcomeTemplate := NewTemplate(
+		"welcome",
+		"Welcome Email",
+		"Welcome to {app_name}!",
+		"Hi {name}, thanks for joining {app_name}. Get started now!",
+		NotificationTypeEmail,
+	)
+
+	orderShippedTemplate := NewTemplate(
+		"order_shipped",
+		"Order Shipped",
+		"Your order #{order_id} has shipped!",
+		"Track your package: {tracking_url}",
+		NotificationTypePush,
+	)
+
+	service.AddTemplate(welcomeTemplate)
+	service.AddTemplate(orderShippedTemplate)
+
+	// ========== STEP 5: Send various notifications ==========
+	fmt.Println("\n📤 Sending Notifications...")
+	fmt.Println("─────────────────────────────────────────")
+
+	// Example 1: Direct email notification
+	fmt.Println("\n1️⃣  Direct Email Notification:")
+	passwordResetNotif := NewNotification(
+		"user123",
+		"Password Reset Request",
+		"Click the link below to reset your password.",
+		NotificationTypeEmail,
+		PriorityHigh,
+	)
+	service.SendNotification(ctx, passwordResetNotif)
+
+	// Example 2: SMS notification (for OTP)
+	fmt.Println("\n2️⃣  SMS Notification (OTP):")
+	otpNotif := NewNotification(
+		"user123",
+		"", // SMS typically doesn't have a title
+		"Your verification code is 123456. Valid for 5 minutes.",
+		NotificationTypeSMS,
+		PriorityCritical,
+	)
+	service.SendNotification(ctx, otpNotif)
+
+	// Example 3: Push notification
+	fmt.Println("\n3️⃣  Push Notification:")
+	saleNotif := NewNotification(
+		"user123",
+		"Flash Sale! 🎉",
+		"50% off on all items for the next 2 hours!",
+		NotificationTypePush,
+		PriorityMedium,
+	)
+	service.SendNotification(ctx, saleNotif)
+
+	// Example 4: Using a template
+	fmt.Println("\n4️⃣  Notification from Template:")
+	service.SendFromTemplate(ctx, "user123", "welcome", map[string]string{
+		"name":     "John",
+		"app_name": "MyApp",
+	})
+
+	// Example 5: Multi-channel security alert
+	fmt.Println("\n5️⃣  Multi-Channel Security Alert:")
+	service.SendToMultipleChannels(
+		ctx,
+		"user123",
+		"⚠️ Security Alert",
+		"New login detected from an unknown device in New York, USA.",
+		[]NotificationType{
+			NotificationTypeEmail,
+			NotificationTypePush,
+			NotificationTypeSMS,
+		},
+		PriorityCritical,
+	)
+
+	// Example 6: Slack notification for team
+	fmt.Println("\n6️⃣  Slack Team Notification:")
+	deployNotif := NewNotification(
+		"team-devops",
+		"🚀 Deployment Complete",
+		"Version 2.0.0 has been deployed to production successfully.",
+		NotificationTypeSlack,
+		PriorityMedium,
+	)
+	service.SendNotification(ctx, deployNotif)
+
+	// Example 7: Per-channel timeout on a hung SMTP server
+	fmt.Println("\n7️⃣  Send Timeout (hung SMTP server):")
+	hungEmailChannel := NewEmailChannel("smtp.hung.example.com", 587, "noreply@example.com")
+	hungEmailChannel.Latency = time.Minute // Simulates a connection that never answers
+
+	timeoutService := NewNotificationService()
+	timeoutService.RegisterChannel(NewLoggingDecorator(hungEmailChannel))
+	timeoutService.SetChannelTimeout(NotificationTypeEmail, 200*time.Millisecond)
+
+	sendStart := time.Now()
+	err := timeoutService.SendNotification(ctx, NewNotification(
+		"user123", "Receipt", "Thanks for your order!", NotificationTypeEmail, PriorityMedium,
+	))
+	fmt.Printf("  ⏱️  Gave up after %v: %v\n", time.Since(sendStart).Round(10*time.Millisecond), err)
+
+	// Example 8: Shutdown cancels in-flight queued sends
+	fmt.Println("\n8️⃣  Graceful Shutdown:")
+	timeoutService.SetChannelTimeout(NotificationTypeEmail, time.Minute)
+	timeoutService.QueueNotification(ctx, NewNotification(
+		"user123", "Newsletter", "This week's highlights...", NotificationTypeEmail, PriorityLow,
+	))
+	time.Sleep(50 * time.Millisecond) // Let the worker pick it up
+
+	shutdownContext, cancelShutdown := context.WithTimeout(ctx, time.Second)
+	defer cancelShutdown()
+	if err := timeoutService.Shutdown(shutdownContext); err != nil {
+		fmt.Printf("  ❌ Shutdown did not finish: %v\n", err)
+	} else {
+		fmt.Println("  ✅ Service shut down, worker stopped")
+	}
+	if err := timeoutService.QueueNotification(ctx, NewNotification(
+		"user123", "Late", "Too late", NotificationTypeEmail, PriorityLow,
+	)); err != nil {
+		fmt.Printf("  ❌ Queue after shutdown: %v\n", err)
+	}
+
+	// Example 9: In-app inbox and read receipts
+	fmt.Println("\n9️⃣  In-App Inbox & Read Receipts:")
+	service.SendNotification(ctx, NewNotification(
+		"user123", "New follower", "Alex started following you.", NotificationTypeInApp, PriorityLow,
+	))
+	commentNotif := NewNotification(
+		"user123", "New comment", "Sam commented on your post.", NotificationTypeInApp, PriorityMedium,
+	)
+	service.SendNotification(ctx, commentNotif)
+	fmt.Printf("  🔴 Unread badge: %d\n", service.GetUnreadCount("user123"))
+
+	service.MarkAsRead("user123", commentNotif.ID)
+	service.MarkAsRead("user123", saleNotif.ID) // User tapped the push notification
+	for _, entry := range service.GetInbox("user123", InboxAll) {
+		readState := "unread"
+		if entry.IsRead() {
+			readState = "read"
+		}
+		fmt.Printf("  %s %-13s (%s)\n", entry.Notification.ID, entry.Notification.Title, readState)
+	}
+	if err := service.MarkAsRead("someone-else", commentNotif.ID); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+	fmt.Printf("  Marked all read: %d, unread badge: %d\n",
+		service.MarkAllAsRead("user123"), service.GetUnreadCount("user123"))
+
+	fmt.Println("  📊 Read rate by channel:")
+	for _, stats := range service.GetReadStats() {
+		fmt.Printf("     %-6s %d/%d read (%.0f%%)\n",
+			stats.Channel, stats.Read, stats.Sent, stats.ReadRate()*100)
+	}
+
+	// Example 10: Per-channel costs and monthly budget caps
+	fmt.Println("\n🔟 Cost Tracking & Budget Caps:")
+	budgetService := NewNotificationService()
+	for _, tenantID := range []string{"acme", "globex"} {
+		_ = budgetService.RegisterTenant(TenantConfig{ID: tenantID})
+		_ = budgetService.RegisterTenantChannel(tenantID, NewSMSChannel("twilio", "api-key-here"))
+		_ = budgetService.RegisterTenantChannel(tenantID, NewPushChannel("fcm-key-here"))
+		_ = budgetService.RegisterTenantChannel(tenantID, NewEmailChannel("smtp.example.com", 587, "noreply@example.com"))
+	}
+	budgetService.SetBudgetCap(BudgetCap{Tenant: "acme", Channel: NotificationTypeSMS, MonthlyLimit: 0.02, Action: BudgetDowngrade})
+	budgetService.SetBudgetCap(BudgetCap{Tenant: "globex", Channel: NotificationTypeSMS, MonthlyLimit: 0.01, Action: BudgetBlock})
+
+	longMessage := strings.Repeat("Your order has shipped and will arrive soon. ", 4) // 180 chars: 2 segments
+	fmt.Printf("  Segments: short=%d, long=%d, emoji=%d\n",
+		SMSSegments("Code: 123456"), SMSSegments(longMessage), SMSSegments(strings.Repeat("🎉", 71)))
+
+	for _, send := range []struct {
+		tenant  string
+		message string
+	}{
+		{"acme", "Your verification code is 123456."},
+		{"acme", longMessage}, // Would exceed acme's $0.02: downgraded to push
+		{"globex", "Your verification code is 654321."},
+		{"globex", "Your verification code is 111222."}, // Would exceed globex's $0.01: blocked
+	} {
+		notification := NewTenantNotification(send.tenant, "user123", "Update", send.message, NotificationTypeSMS, PriorityHigh)
+		if err := budgetService.SendNotification(ctx, notification); err != nil {
+			fmt.Printf("  ❌ [%s] %v\n", send.tenant, err)
+			continue
+		}
+		if original := notification.Metadata[MetadataDowngradedFrom]; original != "" {
+			fmt.Printf("  ⬇️  [%s] %s downgraded from %s to %s\n", send.tenant, notification.ID, original, notification.Channel)
+		}
+	}
+
+	report := budgetService.GetSpendReport("")
+	fmt.Printf("  📊 Spend report %s (total $%.4f):\n", report.Month, report.Total)
+	for _, line := range report.Lines {
+		capInfo := "uncapped"
+		if line.Limit > 0 {
+			capInfo = fmt.Sprintf("%.0f%% of $%.2f", line.Utilization()*100, line.Limit)
+		}
+		fmt.Printf("     %-7s %-6s %d msg  $%.4f  (%s)\n", line.Tenant, line.Channel, line.Messages, line.Spend, capInfo)
+	}
+
+	// Example 11: Quiet hours and timestamps in each user's timezone
+	fmt.Println("\n🌍 Timezones & Locales:")
+	deliveryTemplate := NewTemplate(
+		"delivery_window",
+		"Delivery Window",
+		"Delivery on {slot:date}",
+		"Your courier arrives at {slot:time}. Booked {booked}.",
+		NotificationTypeInApp,
+	)
+	service.AddTemplate(deliveryTemplate)
+
+	// Both users have quiet hours 22:00-07:00 on their own wall clock
+	sameMoment := time.Date(2024, time.March, 15, 13, 30, 0, 0, time.UTC)
+	for _, user := range []struct {
+		id       string
+		timezone string
+		locale   string
+	}{
+		{"ny-user", "America/New_York", "en-US"},
+		{"tokyo-user", "Asia/Tokyo", "ja-JP"},
+		{"berlin-user", "Europe/Berlin", "de-DE"},
+	} {
+		prefs := NewUserPreferences(user.id)
+		prefs.QuietHoursStart, prefs.QuietHoursEnd = 22, 7
+		prefs.Locale = user.locale
+		if err := prefs.SetTimezone(user.timezone); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			continue
+		}
+		service.SetUserPreferences(prefs)
+
+		title, body := deliveryTemplate.RenderLocalized(nil, map[string]time.Time{
+			"slot":   sameMoment.Add(20 * time.Hour),
+			"booked": sameMoment,
+		}, prefs)
+		fmt.Printf("  %-11s local %s, quiet: %-5v | %s — %s\n", user.id,
+			prefs.LocalTime(sameMoment).Format("15:04"), prefs.IsQuietHoursAt(sameMoment), title, body)
+	}
+	if err := NewUserPreferences("typo-user").SetTimezone("Mars/Olympus_Mons"); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	// Example 12: Several applications sharing one service
+	fmt.Println("\n🏢 Multi-Tenant Isolation:")
+	platform := NewNotificationService()
+	_ = platform.RegisterTenant(TenantConfig{ID: "shop", Name: "Shop App", RateLimit: 2, RateWindow: time.Minute})
+	_ = platform.RegisterTenant(TenantConfig{
+		ID:           "bank",
+		Name:         "Bank App",
+		SendTimeouts: map[NotificationType]time.Duration{NotificationTypeSMS: 5 * time.Second},
+	})
+	_ = platform.RegisterTenantChannel("shop", NewEmailChannel("smtp.shop.example.com", 587, "hello@shop.example.com"))
+	_ = platform.RegisterTenantChannel("bank", NewSMSChannel("twilio", "bank-api-key"))
+
+	// Same template ID and user ID in both tenants, with different content
+	_ = platform.AddTenantTemplate("shop", NewTemplate("welcome", "Welcome", "Welcome to Shop, {name}!", "Here is 10% off your first order.", NotificationTypeEmail))
+	_ = platform.AddTenantTemplate("bank", NewTemplate("welcome", "Welcome", "Welcome to Bank, {name}!", "Your account is ready.", NotificationTypeSMS))
+	bankPrefs := NewUserPreferences("user-1")
+	bankPrefs.EnabledChannels[NotificationTypeSMS] = true // Only the bank's user opted in to SMS
+	_ = platform.SetTenantUserPreferences("bank", bankPrefs)
+
+	for _, tenantID := range []string{"shop", "bank"} {
+		if err := platform.SendTenantTemplate(ctx, tenantID, "user-1", "welcome", map[string]string{"name": "Ada"}); err != nil {
+			fmt.Printf("  ❌ [%s] %v\n", tenantID, err)
+		}
+	}
+
+	// The bank has no email channel of its own and can't borrow the shop's
+	if err := platform.SendNotification(ctx, NewTenantNotification(
+		"bank", "user-1", "Statement", "Your statement is ready.", NotificationTypeEmail, PriorityMedium,
+	)); err != nil {
+		fmt.Printf("  ❌ [bank] %v\n", err)
+	}
+	if err := platform.SendNotification(ctx, NewTenantNotification(
+		"casino", "user-1", "Bonus", "Free spins!", NotificationTypeInApp, PriorityLow,
+	)); err != nil {
+		fmt.Printf("  ❌ [casino] %v\n", err)
+	}
+
+	// The shop's limit is 2 sends per minute; raising it applies immediately
+	for attempt := 1; attempt <= 2; attempt++ {
+		if err := platform.SendNotification(ctx, NewTenantNotification(
+			"shop", "user-1", "Flash Sale", fmt.Sprintf("Deal #%d is live!", attempt), NotificationTypeEmail, PriorityLow,
+		)); err != nil {
+			fmt.Printf("  ❌ [shop] %v\n", err)
+		}
+	}
+	_ = platform.UpdateTenantConfig(TenantConfig{ID: "shop", Name: "Shop App", RateLimit: 10, RateWindow: time.Minute})
+	if err := platform.SendNotification(ctx, NewTenantNotification(
+		"shop", "user-1", "Flash Sale", "Deal #2 is live!", NotificationTypeEmail, PriorityLow,
+	)); err == nil {
+		fmt.Println("  ✅ [shop] Sent after raising the limit to 10/min")
+	}
+
+	for _, tenantID := range []string{"shop", "bank"} {
+		history, _ := platform.GetTenantHistory(tenantID)
+		config, _ := platform.GetTenantConfig(tenantID)
+		titles := make([]string, 0, len(history))
+		for _, notification := range history {
+			titles = append(titles, notification.Title)
+		}
+		limit := "unlimited"
+		if config.RateLimit > 0 {
+			limit = fmt.Sprintf("%d per %v", config.RateLimit, config.RateWindow)
+		}
+		fmt.Printf("  📜 %-8s (%s): %s\n", config.Name, limit, strings.Join(titles, " | "))
+	}
+
+	// Example 13: Delivery analytics for a dashboard
+	fmt.Println("\n📊 Delivery Analytics:")
+	analytics := NewNotificationService()
+	// Email fails once per message and recovers on retry; SMS gateway is down
+	analytics.RegisterChannel(NewRetryDecorator(
+		&flakyChannel{NotificationChannel: NewEmailChannel("smtp.example.com", 587, "noreply@example.com"), failFirst: 1},
+		2, 10*time.Millisecond,
+	))
+	analytics.RegisterChannel(NewRetryDecorator(
+		&flakyChannel{NotificationChannel: NewSMSChannel("twilio", "api-key-here"), failFirst: 10},
+		2, 10*time.Millisecond,
+	))
+	analytics.RegisterChannel(NewPushChannel("fcm-key-here"))
+
+	sends := []struct {
+		userID  string
+		channel NotificationType
+	}{
+		{"alice", NotificationTypeEmail}, {"alice", NotificationTypePush}, {"alice", NotificationTypeSMS},
+		{"bob", NotificationTypeSMS}, {"bob", NotificationTypeSMS}, {"bob", NotificationTypePush},
+	}
+	for _, send := range sends {
+		_ = analytics.SendNotification(ctx, NewNotification(send.userID, "Weekly digest", "Your weekly summary", send.channel, PriorityLow))
+	}
+
+	deliveryReport := analytics.GetDeliveryReport(time.Time{}, time.Time{})
+	fmt.Printf("\n  %-6s %-5s %4s %6s %8s\n", "Day", "Chan", "Sent", "Failed", "Retries")
+	for _, day := range deliveryReport.Daily {
+		fmt.Printf("  %-6s %-5s %4d %6d %8d\n", day.Day[5:], day.Channel, day.Sent, day.Failed, day.Retries)
+	}
+	fmt.Printf("  Average retries per send: %.2f\n", deliveryReport.AverageRetries)
+	for rank, stats := range deliveryReport.TopFailing {
+		fmt.Printf("  Top failing #%d: %s (%d failed, %.0f%%)\n", rank+1, stats.Channel, stats.Failed, stats.FailureRate*100)
+	}
+	for _, user := range deliveryReport.Users {
+		fmt.Printf("  %-5s delivered %d/%d (%.0f%%)\n", user.UserID, user.Sent, user.Attempts(), user.SuccessRate*100)
+	}
+	if encoded, err := json.Marshal(deliveryReport.TopFailing); err == nil {
+		fmt.Printf("  JSON top_failing: %s\n", encoded)
+	}
+	fmt.Println("  Prometheus export (excerpt):")
+	var metrics strings.Builder
+	_ = deliveryReport.WritePrometheus(&metrics)
+	for _, line := range strings.Split(metrics.String(), "\n") {
+		if strings.HasPrefix(line, "notification_deliveries_total") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+
+	// Example 14: SMS encoding, segment counting and long-message policies
+	fmt.Println("\n✂️  SMS Segmentation:")
+	for _, text := range []string{
+		"Your code is 123456.",
+		"Café crème: €4.50 [member price]",
+		strings.Repeat("Привет! ", 10),
+		strings.Repeat("🎉", 36),
+	} {
+		info := AnalyzeSMS(text)
+		fmt.Printf("  %-7s %3d units → %d segment(s): %.30q\n", info.Encoding, info.Length, info.Segments, text)
+	}
+
+	shippingUpdate := "Your order #48213 has shipped! It is travelling with FastFreight and should reach you on Thursday between 9am and 1pm. " +
+		"Track it any time at https://example.com/t/48213 or reply STOP to opt out of delivery updates."
+	fmt.Printf("  Shipping update: %d chars, %d segments\n", len([]rune(shippingUpdate)), SMSSegments(shippingUpdate))
+	for _, policy := range []SMSOverflowPolicy{SMSReject, SMSTruncate, SMSSplit} {
+		maxSegments := 1
+		if policy == SMSSplit {
+			maxSegments = 3 // Up to three separate texts
+		}
+		smsChannel := NewSMSChannel("twilio", "api-key-here")
+		smsChannel.SetOverflowPolicy(maxSegments, policy)
+		notification := NewNotification("user123", "", shippingUpdate, NotificationTypeSMS, PriorityMedium)
+		fmt.Printf("  -- %s (max %d), est. $%.4f\n", policy, smsChannel.MaxSegments, smsChannel.EstimateCost(notification))
+		if err := smsChannel.Send(ctx, notification); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+		}
+	}
+
+	// Example 15: Grouping related notifications into threads
+	fmt.Println("\n🧵 Notification Threads:")
+	threadService := NewNotificationService()
+	threadService.RegisterChannel(NewPushChannel("fcm-key-here"))
+	orderThread := GroupKeyFor("order", "123")
+	for _, update := range []struct{ title, message string }{
+		{"Order confirmed", "Order #123 is confirmed."},
+		{"Order shipped", "Order #123 left the warehouse."},
+		{"Out for delivery", "Order #123 arrives today."},
+	} {
+		for _, channelType := range []NotificationType{NotificationTypePush, NotificationTypeInApp} {
+			notification := NewNotification("user123", update.title, update.message, channelType, PriorityMedium)
+			notification.GroupKey = orderThread
+			threadService.SendNotification(ctx, notification)
+		}
+	}
+	threadService.SendNotification(ctx, NewNotification(
+		"user123", "Weekly digest", "5 new posts from people you follow.", NotificationTypeInApp, PriorityLow,
+	))
+
+	for _, thread := range threadService.GetThreads("user123", InboxAll) {
+		fmt.Printf("  %-14s %d message(s), %d unread, latest: %s\n",
+			thread.GroupKey, thread.Count, thread.UnreadCount, thread.Latest.Notification.Title)
+	}
+	fmt.Printf("  Thread %s in-app: ", orderThread)
+	for _, entry := range threadService.GetThread("user123", orderThread) {
+		fmt.Printf("%q ", entry.Notification.Title)
+	}
+	fmt.Printf("\n  Thread %s on all channels: %d notification(s)\n",
+		orderThread, len(threadService.GetThreadHistory("user123", orderThread)))
+	fmt.Printf("  Marked thread read: %d, unread threads left: %d\n",
+		threadService.MarkThreadAsRead("user123", orderThread), len(threadService.GetThreads("user123", InboxUnread)))
+
+	// ========== SUMMARY ==========
+	fmt.Println("\n═══════════════════════════════════════════")
+	fmt.Println("  📚 KEY DESIGN PATTERNS USED:")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  1. Strategy Pattern")
+	fmt.Println("     → Different channels (Email, SMS, Push, Slack, In-App)")
+	fmt.Println("     → All implement NotificationChannel interface")
+	fmt.Println()
+	fmt.Println("  2. Decorator Pattern")
+	fmt.Println("     → RetryDecorator adds automatic retry logic")
+	fmt.Println("     → LoggingDecorator adds send logging")
+	fmt.Println("     → Can be stacked for combined functionality")
+	fmt.Println()
+	fmt.Println("  3. Template Pattern")
+	fmt.Println("     → Reusable notification templates")
+	fmt.Println("     → Dynamic content with placeholders")
+	fmt.Println()
+	fmt.Println("  4. Additional Features:")
+	fmt.Println("     → User preferences (channel opt-in/out)")
+	fmt.Println("     → Quiet hours in each user's timezone")
+	fmt.Println("     → Locale-aware timestamps in templates")
+	fmt.Println("     → Async queue processing")
+	fmt.Println("     → Per-channel send timeouts via context")
+	fmt.Println("     → Shutdown cancels in-flight sends")
+	fmt.Println("     → In-app inbox with unread counts and read receipts")
+	fmt.Println("     → Per-channel cost estimates with monthly budget caps")
+	fmt.Println("     → SMS encoding detection, segment pricing, truncate/split")
+	fmt.Println("     → Tenant-partitioned channels/templates/preferences/history")
+	fmt.Println("     → Per-tenant rate limits and send timeouts")
+	fmt.Println("     → Delivery analytics report with JSON and Prometheus export")
+	fmt.Println("     → Group keys thread related notifications in inbox and push")
+	fmt.Println("     → Thread-safe operations")
+	fmt.Println("═══════════════════════════════════════════")
+}
+
+// flakyChannel fails the first failFirst attempts of every notification,
+// standing in for an unreliable provider in the analytics demo
+type flakyChannel struct {
+	NotificationChannel
+	failFirst int
+	attempts  map[string]int
+	mutex     sync.Mutex
+}
+
+// Send fails until the notification has been attempted failFirst times
+func (channel *flakyChannel) Send(ctx context.Context, notification *Notification) error {
+	channel.mutex.Lock()
+	if channel.attempts == nil {
+		channel.attempts = make(map[string]int)
+	}
+	channel.attempts[notification.ID]++
+	attempt := channel.attempts[notification.ID]
+	channel.mutex.Unlock()
+
+	if attempt <= channel.failFirst {
+		return fmt.Errorf("%s provider unavailable (attempt %d)", channel.GetType(), attempt)
+	}
+	return channel.NotificationChannel.Send(ctx, notification)
+}
@@ -0,0 +1,2555 @@
+// Package notification sends notifications over pluggable channels
+// (email, SMS, push) with retries, templates and preferences.
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================
+// NOTIFICATION SYSTEM - Low Level Design
+// ============================================================
+//
+// This system demonstrates how to build a scalable notification
+// service that can send messages through multiple channels
+// (Email, SMS, Push, Slack, In-App inbox).
+//
+// Design Patterns Used:
+// 1. Strategy Pattern - Different notification channels
+// 2. Decorator Pattern - Add retry/logging capabilities
+// 3. Template Pattern - Reusable notification templates
+//
+// Sends are also metered: each channel has a cost estimator, and monthly
+// budget caps per channel/tenant block or downgrade sends that would exceed them.
+//
+// Quiet hours are evaluated in each user's own timezone, and timestamps in
+// templates are rendered in that timezone using the user's locale format.
+//
+// One service can serve several applications: channels, templates, user
+// preferences, history and the in-app inbox are partitioned per tenant, and
+// each tenant has its own rate limit and send timeouts.
+//
+// Every delivery attempt is logged, which feeds a dashboard report (per
+// channel per day, retries, top failing channels, per-user success rates)
+// that can also be exported in the Prometheus text format.
+//
+// SMS text is encoding-aware (GSM-7 vs UCS-2): segments are counted and
+// priced exactly, and long texts are rejected, truncated or split into
+// numbered parts as the SMS channel is configured.
+//
+// Related notifications share a group key, so the inbox and push devices
+// can collapse them into threads.
+//
+// ============================================================
+
+// ==================== ENUMS (Type Definitions) ====================
+//
+// In Go, we simulate enums using custom types with constants.
+// This provides type safety and better code readability.
+
+// NotificationType represents the channel through which
+// a notification will be sent (Email, SMS, Push, or Slack)
+type NotificationType int
+
+const (
+	NotificationTypeEmail NotificationType = iota // 0 - Email notifications
+	NotificationTypeSMS                           // 1 - SMS text messages
+	NotificationTypePush                          // 2 - Mobile push notifications
+	NotificationTypeSlack                         // 3 - Slack messages
+	NotificationTypeInApp                         // 4 - In-app inbox messages
+)
+
+// String converts NotificationType to a readable string
+func (notificationType NotificationType) String() string {
+	typeNames := []string{"Email", "SMS", "Push", "Slack", "InApp"}
+	if int(notificationType) < len(typeNames) {
+		return typeNames[notificationType]
+	}
+	return "Unknown"
+}
+
+// NotificationPriority determines how urgent a notification is
+type NotificationPriority int
+
+const (
+	PriorityLow      NotificationPriority = iota // 0 - Can be delayed
+	PriorityMedium                               // 1 - Normal priority
+	PriorityHigh                                 // 2 - Should be sent soon
+	PriorityCritical                             // 3 - Must be sent immediately (ignores quiet hours)
+)
+
+// String converts NotificationPriority to a readable string
+func (priority NotificationPriority) String() string {
+	priorityNames := []string{"Low", "Medium", "High", "Critical"}
+	if int(priority) < len(priorityNames) {
+		return priorityNames[priority]
+	}
+	return "Unknown"
+}
+
+// NotificationStatus tracks the current state of a notification
+type NotificationStatus int
+
+const (
+	StatusPending  NotificationStatus = iota // 0 - Waiting to be sent
+	StatusSent                               // 1 - Successfully delivered
+	StatusFailed                             // 2 - Failed to send
+	StatusRetrying                           // 3 - Retrying after failure
+)
+
+// String converts NotificationStatus to a readable string
+func (status NotificationStatus) String() string {
+	statusNames := []string{"Pending", "Sent", "Failed", "Retrying"}
+	if int(status) < len(statusNames) {
+		return statusNames[status]
+	}
+	return "Unknown"
+}
+
+// ==================== NOTIFICATION MODEL ====================
+//
+// Notification holds all the information needed to send a message
+// to a user through a specific channel.
+
+type Notification struct {
+	ID          string               // Unique identifier for this notification
+	UserID      string               // Target user who will receive this notification
+	Title       string               // Subject/Title of the notification
+	Message     string               // Body content of the notification
+	Channel     NotificationType     // Which channel to use (Email, SMS, etc.)
+	Priority    NotificationPriority // How urgent is this notification
+	Status      NotificationStatus   // Current delivery status
+	CreatedAt   time.Time            // When was this notification created
+	SentAt      time.Time            // When was this notification actually sent
+	RetryCount  int                  // How many times a send was retried after failing
+	Cost        float64              // Estimated cost charged to the tenant's budget (USD)
+	GroupKey    string               // Thread shared by related notifications, e.g. "order:123" (empty = standalone)
+	Metadata    map[string]string    // Additional data (e.g., tracking info)
+	Attachments []Attachment         // Files sent along, e.g. a rendered invoice (email only)
+}
+
+// Attachment is a file sent along with a notification
+type Attachment struct {
+	Filename    string // Name shown to the recipient, e.g. "INV-3.pdf"
+	ContentType string // MIME type, e.g. "application/pdf"
+	Data        []byte // File contents
+}
+
+// notificationIDCounter generates unique IDs for notifications
+// Note: In production, use UUID or database-generated IDs
+var notificationIDCounter int
+var notificationIDMutex sync.Mutex // Protects counter in concurrent access
+
+// NewNotification creates a new notification with sensible defaults
+func NewNotification(
+	userID string,
+	title string,
+	message string,
+	channel NotificationType,
+	priority NotificationPriority,
+) *Notification {
+	// Thread-safe ID generation
+	notificationIDMutex.Lock()
+	notificationIDCounter++
+	id := fmt.Sprintf("NOTIF-%d", notificationIDCounter)
+	notificationIDMutex.Unlock()
+
+	return &Notification{
+		ID:        id,
+		UserID:    userID,
+		Title:     title,
+		Message:   message,
+		Channel:   channel,
+		Priority:  priority,
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+		Metadata:  make(map[string]string),
+	}
+}
+
+// ==================== NOTIFICATION CHANNEL INTERFACE ====================
+//
+// Strategy Pattern: Define a common interface for all notification channels.
+// This allows the service to work with any channel without knowing
+// the implementation details.
+
+type NotificationChannel interface {
+	// Send delivers the notification and returns any error.
+	// Implementations must give up and return ctx.Err() once ctx is done.
+	Send(ctx context.Context, notification *Notification) error
+	// GetType returns the type of this channel
+	GetType() NotificationType
+}
+
+// simulateNetworkCall stands in for a network round-trip of the given latency.
+// It returns early with ctx.Err() if the context is cancelled or times out,
+// which is how a real client (SMTP, HTTP) would abort a hung connection.
+func simulateNetworkCall(ctx context.Context, latency time.Duration) error {
+	if latency <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ==================== EMAIL CHANNEL ====================
+
+// EmailChannel handles sending email notifications
+type EmailChannel struct {
+	SMTPHost string        // Email server hostname
+	SMTPPort int           // Email server port
+	FromAddr string        // Sender email address
+	Latency  time.Duration // Simulated SMTP round-trip time
+}
+
+// NewEmailChannel creates a new email channel with SMTP configuration
+func NewEmailChannel(host string, port int, fromAddress string) *EmailChannel {
+	return &EmailChannel{
+		SMTPHost: host,
+		SMTPPort: port,
+		FromAddr: fromAddress,
+	}
+}
+
+// Send delivers an email notification
+func (emailChannel *EmailChannel) Send(ctx context.Context, notification *Notification) error {
+	// In a real implementation, this would connect to SMTP server
+	// and send the actual email. Here we simulate the send.
+	if err := simulateNetworkCall(ctx, emailChannel.Latency); err != nil {
+		return fmt.Errorf("smtp %s: %w", emailChannel.SMTPHost, err)
+	}
+	fmt.Printf("  📧 EMAIL to %s\n", notification.UserID)
+	fmt.Printf("     Subject: %s\n", notification.Title)
+	fmt.Printf("     Body: %s\n", notification.Message)
+	for _, attachment := range notification.Attachments {
+		fmt.Printf("     📎 %s (%s, %d bytes)\n", attachment.Filename, attachment.ContentType, len(attachment.Data))
+	}
+	return nil
+}
+
+// GetType returns the channel type (Email)
+func (emailChannel *EmailChannel) GetType() NotificationType {
+	return NotificationTypeEmail
+}
+
+// ==================== SMS CHANNEL ====================
+
+// SMSChannel handles sending SMS text messages
+type SMSChannel struct {
+	Provider string // SMS provider name (e.g., "twilio")
+	APIKey   string // API key for authentication
+
+	// Long-message handling (see SMS SEGMENTATION)
+	MaxSegments        int               // Longest text allowed, or most parts with SMSSplit (0 = no limit)
+	Overflow           SMSOverflowPolicy // What to do with longer texts
+	TruncationMarker   string            // Appended to truncated text ("" = DefaultTruncationMarker)
+	ContinuationFormat string            // Part/total marker for split texts ("" = DefaultContinuationFormat)
+	PerSegmentCost     float64           // Provider price per segment (USD)
+}
+
+// NewSMSChannel creates a new SMS channel
+func NewSMSChannel(provider string, apiKey string) *SMSChannel {
+	return &SMSChannel{
+		Provider:       provider,
+		APIKey:         apiKey,
+		PerSegmentCost: 0.0075,
+	}
+}
+
+// Send delivers an SMS notification, truncated or split per the overflow policy
+func (smsChannel *SMSChannel) Send(ctx context.Context, notification *Notification) error {
+	// In a real implementation, this would call the SMS provider's API
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	parts, err := smsChannel.PrepareMessages(notification.Message)
+	if err != nil {
+		return fmt.Errorf("sms %s: %w", smsChannel.Provider, err)
+	}
+	for _, part := range parts {
+		fmt.Printf("  📱 SMS to %s: %s\n", notification.UserID, part)
+	}
+	return nil
+}
+
+// GetType returns the channel type (SMS)
+func (smsChannel *SMSChannel) GetType() NotificationType {
+	return NotificationTypeSMS
+}
+
+// ==================== SMS SEGMENTATION ====================
+//
+// Carriers bill SMS per segment, and how much fits in a segment depends on
+// the encoding the text forces:
+// - GSM-7: 160 septets in one segment, 153 per segment once split. A few
+//   characters (€ [ ] { } ~ ^ | \) come from the extension table and take two.
+// - UCS-2: used as soon as one character is outside GSM-7 (e.g. an emoji or
+//   Cyrillic). 70 UTF-16 code units in one segment, 67 when split; emoji
+//   outside the Basic Multilingual Plane take two.
+//
+// A segment never ends halfway through a character. SMSChannel decides what
+// to do with text longer than its MaxSegments: reject it or truncate it with
+// a marker. For handsets that do not reassemble concatenated messages it can
+// instead split every long text into separate single-segment texts ending in
+// "(1/3)", with MaxSegments capping the number of parts.
+
+// SMSEncoding is the character set a text must be sent in
+type SMSEncoding int
+
+const (
+	EncodingGSM7 SMSEncoding = iota // 0 - GSM 03.38 default alphabet
+	EncodingUCS2                    // 1 - 16-bit Unicode
+)
+
+// String returns the encoding's usual name
+func (encoding SMSEncoding) String() string {
+	if encoding == EncodingUCS2 {
+		return "UCS-2"
+	}
+	return "GSM-7"
+}
+
+// Segment capacities in units (septets or UTF-16 code units)
+const (
+	gsm7SingleSegment = 160
+	gsm7MultiSegment  = 153
+	ucs2SingleSegment = 70
+	ucs2MultiSegment  = 67
+)
+
+// gsm7Basic and gsm7Extended hold the GSM 03.38 alphabet and extension table
+var (
+	gsm7Basic    = runeSet("@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà")
+	gsm7Extended = runeSet("^{}\\[~]|€\f")
+)
+
+// runeSet builds a lookup set of the characters in text
+func runeSet(text string) map[rune]bool {
+	set := make(map[rune]bool)
+	for _, character := range text {
+		set[character] = true
+	}
+	return set
+}
+
+// DetectSMSEncoding returns GSM-7 if every character is in the GSM alphabet
+func DetectSMSEncoding(text string) SMSEncoding {
+	for _, character := range text {
+		if !gsm7Basic[character] && !gsm7Extended[character] {
+			return EncodingUCS2
+		}
+	}
+	return EncodingGSM7
+}
+
+// smsUnits returns how many septets or code units one character takes
+func smsUnits(character rune, encoding SMSEncoding) int {
+	if encoding == EncodingGSM7 && gsm7Extended[character] {
+		return 2
+	}
+	if encoding == EncodingUCS2 && character > 0xFFFF {
+		return 2 // Surrogate pair
+	}
+	return 1
+}
+
+// smsLength returns the length of text in units of the encoding
+func smsLength(text string, encoding SMSEncoding) int {
+	length := 0
+	for _, character := range text {
+		length += smsUnits(character, encoding)
+	}
+	return length
+}
+
+// SMSInfo describes how a text will be encoded and billed
+type SMSInfo struct {
+	Encoding        SMSEncoding
+	Length          int // Septets (GSM-7) or UTF-16 code units (UCS-2)
+	Segments        int // Segments billed
+	SegmentCapacity int // Units per segment at this length (160/153 or 70/67)
+}
+
+// AnalyzeSMS detects the encoding and counts the segments of text
+func AnalyzeSMS(text string) SMSInfo {
+	info := SMSInfo{Encoding: DetectSMSEncoding(text)}
+	info.Length = smsLength(text, info.Encoding)
+
+	single, multi := gsm7SingleSegment, gsm7MultiSegment
+	if info.Encoding == EncodingUCS2 {
+		single, multi = ucs2SingleSegment, ucs2MultiSegment
+	}
+	if info.Length <= single {
+		info.Segments, info.SegmentCapacity = 1, single
+		return info
+	}
+	info.Segments = len(splitSMSText(text, info.Encoding, multi, false))
+	info.SegmentCapacity = multi
+	return info
+}
+
+// splitSMSText cuts text into chunks of at most capacity units without
+// splitting a character. With atWords, a chunk ends after its last space
+// when that keeps at least half of it.
+func splitSMSText(text string, encoding SMSEncoding, capacity int, atWords bool) []string {
+	characters := []rune(text)
+	chunks := make([]string, 0)
+	start, used, lastSpace := 0, 0, -1
+	for index, character := range characters {
+		size := smsUnits(character, encoding)
+		if used+size > capacity && index > start {
+			end := index
+			if atWords && lastSpace >= start+(index-start)/2 {
+				end = lastSpace + 1
+			}
+			chunks = append(chunks, string(characters[start:end]))
+			start, lastSpace = end, -1
+			used = smsLength(string(characters[start:index]), encoding)
+		}
+		if character == ' ' {
+			lastSpace = index
+		}
+		used += size
+	}
+	return append(chunks, string(characters[start:]))
+}
+
+// SMSOverflowPolicy decides what happens to text longer than MaxSegments
+type SMSOverflowPolicy int
+
+const (
+	SMSReject   SMSOverflowPolicy = iota // 0 - Fail the send
+	SMSTruncate                          // 1 - Cut the text and append TruncationMarker
+	SMSSplit                             // 2 - Always send separate single-segment texts with ContinuationFormat
+)
+
+// String returns the policy name
+func (policy SMSOverflowPolicy) String() string {
+	policyNames := []string{"Reject", "Truncate", "Split"}
+	if int(policy) < len(policyNames) {
+		return policyNames[policy]
+	}
+	return "Unknown"
+}
+
+// Default markers for truncated and split texts (GSM-7, so they never
+// change the encoding on their own)
+const (
+	DefaultTruncationMarker   = "..."
+	DefaultContinuationFormat = " (%d/%d)"
+)
+
+// SetOverflowPolicy limits messages to maxSegments (0 = no limit) and sets
+// what happens to longer ones
+func (smsChannel *SMSChannel) SetOverflowPolicy(maxSegments int, policy SMSOverflowPolicy) {
+	smsChannel.MaxSegments = maxSegments
+	smsChannel.Overflow = policy
+}
+
+// Analyze reports the encoding and segment count of text
+func (smsChannel *SMSChannel) Analyze(text string) SMSInfo {
+	return AnalyzeSMS(text)
+}
+
+// PrepareMessages applies the overflow policy and returns the texts to send
+// (one, unless the policy is SMSSplit)
+func (smsChannel *SMSChannel) PrepareMessages(text string) ([]string, error) {
+	info := AnalyzeSMS(text)
+	if info.Segments == 1 {
+		return []string{text}, nil
+	}
+	if smsChannel.Overflow == SMSSplit {
+		return smsChannel.split(text, info.Encoding)
+	}
+	if smsChannel.MaxSegments <= 0 || info.Segments <= smsChannel.MaxSegments {
+		return []string{text}, nil
+	}
+
+	switch smsChannel.Overflow {
+	case SMSTruncate:
+		return []string{smsChannel.truncate(text)}, nil
+	default:
+		return nil, fmt.Errorf("message needs %d %s segments, limit is %d", info.Segments, info.Encoding, smsChannel.MaxSegments)
+	}
+}
+
+// truncate shortens text until it plus the marker fits in MaxSegments
+func (smsChannel *SMSChannel) truncate(text string) string {
+	marker := smsChannel.TruncationMarker
+	if marker == "" {
+		marker = DefaultTruncationMarker
+	}
+	characters := []rune(text)
+	for keep := len(characters); keep > 0; keep-- {
+		candidate := strings.TrimRight(string(characters[:keep]), " ") + marker
+		if AnalyzeSMS(candidate).Segments <= smsChannel.MaxSegments {
+			return candidate
+		}
+	}
+	return marker
+}
+
+// split cuts text into single-segment parts at word boundaries, each ending
+// in a continuation marker such as " (2/3)"
+func (smsChannel *SMSChannel) split(text string, encoding SMSEncoding) ([]string, error) {
+	format := smsChannel.ContinuationFormat
+	if format == "" {
+		format = DefaultContinuationFormat
+	}
+	single := gsm7SingleSegment
+	if encoding == EncodingUCS2 {
+		single = ucs2SingleSegment
+	}
+
+	// The marker's width depends on the part count, so grow the guess until
+	// the text fits in that many parts
+	for total := 2; ; total++ {
+		if smsChannel.MaxSegments > 0 && total > smsChannel.MaxSegments {
+			return nil, fmt.Errorf("message needs more than %d parts", smsChannel.MaxSegments)
+		}
+		capacity := single - smsLength(fmt.Sprintf(format, total, total), encoding)
+		if capacity <= 0 {
+			return nil, fmt.Errorf("continuation marker %q leaves no room for text", format)
+		}
+		chunks := splitSMSText(text, encoding, capacity, true)
+		if len(chunks) > total {
+			continue
+		}
+		parts := make([]string, len(chunks))
+		for index, chunk := range chunks {
+			parts[index] = strings.TrimRight(chunk, " ") + fmt.Sprintf(format, index+1, len(chunks))
+		}
+		return parts, nil
+	}
+}
+
+// EstimateCost prices a notification as this channel would send it, so the
+// channel can be registered as the SMS CostEstimator (0 if it would be rejected)
+func (smsChannel *SMSChannel) EstimateCost(notification *Notification) float64 {
+	parts, err := smsChannel.PrepareMessages(notification.Message)
+	if err != nil {
+		return 0
+	}
+	segments := 0
+	for _, part := range parts {
+		segments += AnalyzeSMS(part).Segments
+	}
+	return float64(segments) * smsChannel.PerSegmentCost
+}
+
+// ==================== PUSH NOTIFICATION CHANNEL ====================
+
+// PushChannel handles sending mobile push notifications
+type PushChannel struct {
+	FCMKey       string         // Firebase Cloud Messaging API key
+	threadCounts map[string]int // Pushes per "userID/groupKey", for group summaries
+	mutex        sync.Mutex
+}
+
+// NewPushChannel creates a new push notification channel
+func NewPushChannel(fcmKey string) *PushChannel {
+	return &PushChannel{FCMKey: fcmKey, threadCounts: make(map[string]int)}
+}
+
+// Send delivers a push notification
+func (pushChannel *PushChannel) Send(ctx context.Context, notification *Notification) error {
+	// In a real implementation, this would call FCM or APNS
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	payload := pushChannel.BuildPayload(notification)
+	thread := ""
+	if payload.ThreadID != "" {
+		thread = fmt.Sprintf(" [thread %s #%d]", payload.ThreadID, payload.GroupCount)
+	}
+	fmt.Printf("  🔔 PUSH to %s%s: %s - %s\n",
+		payload.UserID,
+		thread,
+		payload.Title,
+		payload.Body,
+	)
+	return nil
+}
+
+// GetType returns the channel type (Push)
+func (pushChannel *PushChannel) GetType() NotificationType {
+	return NotificationTypePush
+}
+
+// ==================== SLACK CHANNEL ====================
+
+// SlackChannel handles sending Slack messages
+type SlackChannel struct {
+	WebhookURL string // Slack incoming webhook URL
+}
+
+// NewSlackChannel creates a new Slack channel
+func NewSlackChannel(webhookURL string) *SlackChannel {
+	return &SlackChannel{WebhookURL: webhookURL}
+}
+
+// Send delivers a Slack notification
+func (slackChannel *SlackChannel) Send(ctx context.Context, notification *Notification) error {
+	// In a real implementation, this would POST to the webhook URL
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	fmt.Printf("  💬 SLACK: [%s] %s\n", notification.Title, notification.Message)
+	return nil
+}
+
+// GetType returns the channel type (Slack)
+func (slackChannel *SlackChannel) GetType() NotificationType {
+	return NotificationTypeSlack
+}
+
+// ==================== IN-APP CHANNEL ====================
+//
+// The in-app channel has no external provider: notifications land in a
+// per-user inbox that the app reads from. Users list their inbox, mark
+// entries as read, and the app shows an unread badge count.
+
+// InboxFilter selects which inbox entries to list
+type InboxFilter int
+
+const (
+	InboxAll    InboxFilter = iota // 0 - Every entry
+	InboxUnread                    // 1 - Only entries not yet read
+	InboxRead                      // 2 - Only entries already read
+)
+
+// InboxEntry is one notification in a user's inbox
+type InboxEntry struct {
+	Notification *Notification // The delivered notification
+	ReceivedAt   time.Time     // When it landed in the inbox
+	ReadAt       time.Time     // When the user read it (zero if unread)
+}
+
+// IsRead reports whether the user has read this entry
+func (entry InboxEntry) IsRead() bool {
+	return !entry.ReadAt.IsZero()
+}
+
+// matches reports whether the entry passes the filter
+func (entry InboxEntry) matches(filter InboxFilter) bool {
+	switch filter {
+	case InboxUnread:
+		return !entry.IsRead()
+	case InboxRead:
+		return entry.IsRead()
+	default:
+		return true
+	}
+}
+
+// InboxStore persists in-app notifications per user.
+// Swap the in-memory store for a database-backed one in production.
+type InboxStore interface {
+	// Add puts a notification into its user's inbox
+	Add(notification *Notification, receivedAt time.Time) error
+	// List returns the user's entries matching the filter, newest first
+	List(userID string, filter InboxFilter) []InboxEntry
+	// MarkRead marks one entry as read; marking twice keeps the first read time
+	MarkRead(userID string, notificationID string, readAt time.Time) error
+	// UnreadCount returns how many entries the user has not read
+	UnreadCount(userID string) int
+	// ListThreads returns the user's entries collapsed by thread, most recent first
+	ListThreads(userID string, filter InboxFilter) []InboxThread
+	// GetThread returns one thread's entries, oldest first
+	GetThread(userID string, groupKey string) []InboxEntry
+}
+
+// MemoryInboxStore keeps inboxes in memory
+type MemoryInboxStore struct {
+	inboxes map[string][]*InboxEntry // Entries by userID, oldest first
+	mutex   sync.Mutex
+}
+
+// NewMemoryInboxStore creates an empty in-memory inbox store
+func NewMemoryInboxStore() *MemoryInboxStore {
+	return &MemoryInboxStore{inboxes: make(map[string][]*InboxEntry)}
+}
+
+// Add puts a notification into its user's inbox
+func (store *MemoryInboxStore) Add(notification *Notification, receivedAt time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, entry := range store.inboxes[notification.UserID] {
+		if entry.Notification.ID == notification.ID {
+			return fmt.Errorf("notification %s already in inbox", notification.ID)
+		}
+	}
+	store.inboxes[notification.UserID] = append(store.inboxes[notification.UserID], &InboxEntry{
+		Notification: notification,
+		ReceivedAt:   receivedAt,
+	})
+	return nil
+}
+
+// List returns copies of the user's entries matching the filter, newest first
+func (store *MemoryInboxStore) List(userID string, filter InboxFilter) []InboxEntry {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entries := store.inboxes[userID]
+	result := make([]InboxEntry, 0, len(entries))
+	for index := len(entries) - 1; index >= 0; index-- {
+		if entries[index].matches(filter) {
+			result = append(result, *entries[index])
+		}
+	}
+	return result
+}
+
+// MarkRead marks one entry as read
+func (store *MemoryInboxStore) MarkRead(userID string, notificationID string, readAt time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, entry := range store.inboxes[userID] {
+		if entry.Notification.ID == notificationID {
+			if !entry.IsRead() {
+				entry.ReadAt = readAt
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("notification %s not in %s's inbox", notificationID, userID)
+}
+
+// UnreadCount returns how many entries the user has not read
+func (store *MemoryInboxStore) UnreadCount(userID string) int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	unread := 0
+	for _, entry := range store.inboxes[userID] {
+		if !entry.IsRead() {
+			unread++
+		}
+	}
+	return unread
+}
+
+// InAppChannel delivers notifications into an InboxStore
+type InAppChannel struct {
+	store InboxStore
+}
+
+// NewInAppChannel creates an in-app channel backed by the given store
+func NewInAppChannel(store InboxStore) *InAppChannel {
+	return &InAppChannel{store: store}
+}
+
+// Send stores the notification in the user's inbox
+func (inAppChannel *InAppChannel) Send(ctx context.Context, notification *Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := inAppChannel.store.Add(notification, time.Now()); err != nil {
+		return err
+	}
+	fmt.Printf("  📥 INBOX for %s: %s\n", notification.UserID, notification.Title)
+	return nil
+}
+
+// GetType returns the channel type (InApp)
+func (inAppChannel *InAppChannel) GetType() NotificationType {
+	return NotificationTypeInApp
+}
+
+// ==================== CHANNEL DECORATORS ====================
+//
+// Decorator Pattern: Wrap channels to add extra functionality
+// like retry logic or logging, without modifying the original channel.
+
+// RetryDecorator wraps a channel to add automatic retry on failure
+type RetryDecorator struct {
+	wrappedChannel NotificationChannel // The channel being decorated
+	maxRetries     int                 // Maximum number of retry attempts
+	retryDelay     time.Duration       // Time to wait between retries
+}
+
+// NewRetryDecorator creates a decorator that adds retry capability
+func NewRetryDecorator(
+	channel NotificationChannel,
+	maxRetries int,
+	retryDelay time.Duration,
+) *RetryDecorator {
+	return &RetryDecorator{
+		wrappedChannel: channel,
+		maxRetries:     maxRetries,
+		retryDelay:     retryDelay,
+	}
+}
+
+// Send attempts to deliver the notification with retries on failure
+// Retrying stops as soon as the context is done
+func (decorator *RetryDecorator) Send(ctx context.Context, notification *Notification) error {
+	var lastError error
+
+	// Try sending up to (maxRetries + 1) times
+	for attempt := 0; attempt <= decorator.maxRetries; attempt++ {
+		// If this is a retry, wait before trying again
+		if attempt > 0 {
+			notification.Status = StatusRetrying
+			notification.RetryCount++ // Track the retry count
+			fmt.Printf("     ⟳ Retry attempt %d/%d...\n", attempt, decorator.maxRetries)
+			if err := simulateNetworkCall(ctx, decorator.retryDelay); err != nil {
+				return lastError
+			}
+		}
+
+		// Attempt to send
+		lastError = decorator.wrappedChannel.Send(ctx, notification)
+		if lastError == nil {
+			// Success! No need to retry
+			return nil
+		}
+
+		// No point retrying once the deadline has passed or we were cancelled
+		if ctx.Err() != nil {
+			return lastError
+		}
+	}
+
+	// All retries exhausted, return the last error
+	return lastError
+}
+
+// GetType returns the wrapped channel's type
+func (decorator *RetryDecorator) GetType() NotificationType {
+	return decorator.wrappedChannel.GetType()
+}
+
+// LoggingDecorator wraps a channel to add logging
+type LoggingDecorator struct {
+	wrappedChannel NotificationChannel
+}
+
+// NewLoggingDecorator creates a decorator that adds logging
+func NewLoggingDecorator(channel NotificationChannel) *LoggingDecorator {
+	return &LoggingDecorator{wrappedChannel: channel}
+}
+
+// Send logs the attempt and result of sending a notification
+func (decorator *LoggingDecorator) Send(ctx context.Context, notification *Notification) error {
+	// Log before sending
+	fmt.Printf("  [LOG] Sending %s notification %s\n",
+		notification.Channel,
+		notification.ID,
+	)
+
+	// Send the notification
+	err := decorator.wrappedChannel.Send(ctx, notification)
+
+	// Log the result
+	if err != nil {
+		fmt.Printf("  [LOG] ✗ Failed: %v\n", err)
+	} else {
+		fmt.Printf("  [LOG] ✓ Success: %s sent\n", notification.ID)
+	}
+
+	return err
+}
+
+// GetType returns the wrapped channel's type
+func (decorator *LoggingDecorator) GetType() NotificationType {
+	return decorator.wrappedChannel.GetType()
+}
+
+// ==================== USER PREFERENCES ====================
+//
+// UserPreferences stores user-specific notification settings
+// like which channels they want to receive, quiet hours, etc.
+
+type UserPreferences struct {
+	UserID          string                    // User identifier
+	EnabledChannels map[NotificationType]bool // Which channels user has enabled
+	Email           string                    // User's email address
+	Phone           string                    // User's phone number
+	PushToken       string                    // User's device push token
+	QuietHoursStart int                       // Start of quiet hours (0-23), in the user's timezone
+	QuietHoursEnd   int                       // End of quiet hours (0-23), in the user's timezone
+	Location        *time.Location            // User's timezone (nil = server local time)
+	Locale          string                    // Formatting locale, e.g. "en-US", "de-DE" ("" = DefaultLocale)
+}
+
+// NewUserPreferences creates preferences with default settings
+func NewUserPreferences(userID string) *UserPreferences {
+	return &UserPreferences{
+		UserID: userID,
+		// Default: Email and Push enabled, SMS disabled
+		EnabledChannels: map[NotificationType]bool{
+			NotificationTypeEmail: true,
+			NotificationTypeSMS:   false,
+			NotificationTypePush:  true,
+			NotificationTypeSlack: true,
+			NotificationTypeInApp: true,
+		},
+		QuietHoursStart: 0, // No quiet hours by default
+		QuietHoursEnd:   0,
+	}
+}
+
+// IsChannelEnabled checks if user has enabled a specific channel
+func (prefs *UserPreferences) IsChannelEnabled(channel NotificationType) bool {
+	enabled, exists := prefs.EnabledChannels[channel]
+	return exists && enabled
+}
+
+// SetTimezone sets the user's timezone from an IANA name like "Asia/Tokyo"
+func (prefs *UserPreferences) SetTimezone(name string) error {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	prefs.Location = location
+	return nil
+}
+
+// LocalTime converts a moment to the user's timezone
+func (prefs *UserPreferences) LocalTime(moment time.Time) time.Time {
+	if prefs.Location == nil {
+		return moment.Local()
+	}
+	return moment.In(prefs.Location)
+}
+
+// IsQuietHours checks if current time is within user's quiet hours
+// During quiet hours, only Critical notifications are sent
+func (prefs *UserPreferences) IsQuietHours() bool {
+	return prefs.IsQuietHoursAt(time.Now())
+}
+
+// IsQuietHoursAt checks if a moment falls within the user's quiet hours,
+// judged by the wall clock in the user's timezone
+func (prefs *UserPreferences) IsQuietHoursAt(moment time.Time) bool {
+	// If start and end are same, quiet hours are disabled
+	if prefs.QuietHoursStart == prefs.QuietHoursEnd {
+		return false
+	}
+
+	currentHour := prefs.LocalTime(moment).Hour()
+
+	// Normal case: quiet hours don't span midnight (e.g., 9-17)
+	if prefs.QuietHoursStart < prefs.QuietHoursEnd {
+		return currentHour >= prefs.QuietHoursStart && currentHour < prefs.QuietHoursEnd
+	}
+
+	// Overnight case: quiet hours span midnight (e.g., 22-7)
+	return currentHour >= prefs.QuietHoursStart || currentHour < prefs.QuietHoursEnd
+}
+
+// ==================== LOCALE FORMATS ====================
+//
+// Locales decide how timestamps look in rendered templates. Go's layouts
+// only print English month names, so non-English locales use numeric dates.
+
+// DefaultLocale is used when a user has no (or an unknown) locale
+const DefaultLocale = "en-US"
+
+// LocaleFormat holds the time layouts for one locale
+type LocaleFormat struct {
+	DateTime string // Used for {name} placeholders
+	Date     string // Used for {name:date} placeholders
+	Time     string // Used for {name:time} placeholders
+}
+
+// localeFormats maps locale tags to their layouts
+var localeFormats = map[string]LocaleFormat{
+	"en-US": {DateTime: "Jan 2, 2006 3:04 PM MST", Date: "Jan 2, 2006", Time: "3:04 PM MST"},
+	"en-GB": {DateTime: "2 Jan 2006 15:04 MST", Date: "2 Jan 2006", Time: "15:04 MST"},
+	"de-DE": {DateTime: "02.01.2006 15:04 MST", Date: "02.01.2006", Time: "15:04 MST"},
+	"fr-FR": {DateTime: "02/01/2006 15:04 MST", Date: "02/01/2006", Time: "15:04 MST"},
+	"ja-JP": {DateTime: "2006/01/02 15:04 MST", Date: "2006/01/02", Time: "15:04 MST"},
+}
+
+// GetLocaleFormat returns the layouts for a locale, falling back to DefaultLocale
+func GetLocaleFormat(locale string) LocaleFormat {
+	if format, exists := localeFormats[locale]; exists {
+		return format
+	}
+	return localeFormats[DefaultLocale]
+}
+
+// FormatTime renders a moment in the user's timezone and locale
+// style is "" (date and time), "date" or "time"
+func (prefs *UserPreferences) FormatTime(moment time.Time, style string) string {
+	format := GetLocaleFormat(prefs.Locale)
+	layout := format.DateTime
+	switch style {
+	case "date":
+		layout = format.Date
+	case "time":
+		layout = format.Time
+	}
+	return prefs.LocalTime(moment).Format(layout)
+}
+
+// ==================== NOTIFICATION TEMPLATE ====================
+//
+// Templates allow reusing notification content with placeholders
+// that get filled in at send time.
+
+type NotificationTemplate struct {
+	ID          string           // Unique template identifier
+	Name        string           // Human-readable template name
+	TitleFormat string           // Title with {placeholders}
+	BodyFormat  string           // Body with {placeholders}
+	Channel     NotificationType // Default channel for this template
+}
+
+// NewTemplate creates a new notification template
+func NewTemplate(
+	id string,
+	name string,
+	titleFormat string,
+	bodyFormat string,
+	channel NotificationType,
+) *NotificationTemplate {
+	return &NotificationTemplate{
+		ID:          id,
+		Name:        name,
+		TitleFormat: titleFormat,
+		BodyFormat:  bodyFormat,
+		Channel:     channel,
+	}
+}
+
+// Render fills in the template placeholders with actual values
+// Parameters should be a map like {"name": "John", "order_id": "12345"}
+func (template *NotificationTemplate) Render(parameters map[string]string) (title string, body string) {
+	title = template.TitleFormat
+	body = template.BodyFormat
+
+	// Replace each placeholder with its value
+	for key, value := range parameters {
+		placeholder := "{" + key + "}"
+		title = strings.ReplaceAll(title, placeholder, value)
+		body = strings.ReplaceAll(body, placeholder, value)
+	}
+
+	return title, body
+}
+
+// RenderLocalized fills in placeholders like Render, and also timestamps:
+// {key}, {key:date} and {key:time} for each entry in timestamps, formatted
+// in the user's timezone and locale (server local time and DefaultLocale
+// if prefs is nil)
+func (template *NotificationTemplate) RenderLocalized(
+	parameters map[string]string,
+	timestamps map[string]time.Time,
+	prefs *UserPreferences,
+) (title string, body string) {
+	if prefs == nil {
+		prefs = &UserPreferences{}
+	}
+
+	merged := make(map[string]string, len(parameters)+3*len(timestamps))
+	for key, value := range parameters {
+		merged[key] = value
+	}
+	for key, moment := range timestamps {
+		merged[key] = prefs.FormatTime(moment, "")
+		merged[key+":date"] = prefs.FormatTime(moment, "date")
+		merged[key+":time"] = prefs.FormatTime(moment, "time")
+	}
+	return template.Render(merged)
+}
+
+// ==================== NOTIFICATION SERVICE ====================
+//
+// The main service that coordinates all notification operations.
+// It manages channels, user preferences, templates, and queuing.
+
+// DefaultSendTimeout bounds a single Send when no per-channel timeout is set
+const DefaultSendTimeout = 10 * time.Second
+
+type NotificationService struct {
+	tenants           map[string]*tenantScope            // Partitioned state by tenant ID (DefaultTenant always exists)
+	channelTimeouts   map[NotificationType]time.Duration // Per-channel send timeouts
+	notificationQueue chan *Notification                 // Async processing queue
+	readReceipts      map[string]time.Time               // First read time by notification ID
+	costEstimators    map[NotificationType]CostEstimator // Cost model per channel
+	budgetCaps        []*BudgetCap                       // Monthly spend limits
+	spend             map[spendKey]*spendEntry           // Spend per tenant/channel/month
+	deliveries        []DeliveryRecord                   // Outcome of every send that reached the limits or a channel
+	mutex             sync.RWMutex                       // Thread-safety lock
+
+	// Lifecycle: the root context is cancelled by Shutdown, which aborts
+	// in-flight sends made by the queue worker
+	rootContext  context.Context
+	cancelRoot   context.CancelFunc
+	workerDone   chan struct{}  // Closed when the queue worker exits
+	closed       bool           // Set by Shutdown; rejects new queued notifications
+	queueSenders sync.WaitGroup // QueueNotification calls that passed the closed check
+}
+
+// NewNotificationService creates and initializes a new service
+func NewNotificationService() *NotificationService {
+	rootContext, cancelRoot := context.WithCancel(context.Background())
+
+	service := &NotificationService{
+		tenants:           make(map[string]*tenantScope),
+		channelTimeouts:   make(map[NotificationType]time.Duration),
+		notificationQueue: make(chan *Notification, 100), // Buffer for 100 notifications
+		readReceipts:      make(map[string]time.Time),
+		costEstimators:    DefaultCostEstimators(),
+		spend:             make(map[spendKey]*spendEntry),
+		rootContext:       rootContext,
+		cancelRoot:        cancelRoot,
+		workerDone:        make(chan struct{}),
+	}
+
+	// Everything configured without naming a tenant belongs to DefaultTenant
+	service.tenants[DefaultTenant] = newTenantScope(TenantConfig{ID: DefaultTenant})
+
+	// Start background worker to process queued notifications
+	go service.processNotificationQueue()
+
+	return service
+}
+
+// SetChannelTimeout sets the maximum time a single send on a channel may take
+func (service *NotificationService) SetChannelTimeout(channelType NotificationType, timeout time.Duration) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.channelTimeouts[channelType] = timeout
+}
+
+// getChannelTimeout returns the tenant's timeout for the channel, else the
+// service's, else DefaultSendTimeout
+// Caller must hold at least a read lock
+func (service *NotificationService) getChannelTimeout(scope *tenantScope, channelType NotificationType) time.Duration {
+	if timeout, exists := scope.config.SendTimeouts[channelType]; exists {
+		return timeout
+	}
+	if timeout, exists := service.channelTimeouts[channelType]; exists {
+		return timeout
+	}
+	return DefaultSendTimeout
+}
+
+// sendWithTimeout runs channel.Send under a deadline.
+// The send runs in its own goroutine so that even a channel that ignores
+// ctx cannot stall the caller past the deadline. The goroutine works on a
+// copy, and its changes (status, retry count) are written back only if it
+// finishes first, so an abandoned send never races with the caller.
+func sendWithTimeout(ctx context.Context, channel NotificationChannel, notification *Notification, timeout time.Duration) error {
+	sendContext, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	attempt := notification.clone()
+	result := make(chan error, 1) // Buffered so the goroutine never blocks
+	go func() {
+		result <- channel.Send(sendContext, attempt)
+	}()
+
+	select {
+	case err := <-result:
+		*notification = *attempt
+		return err
+	case <-sendContext.Done():
+		return fmt.Errorf("%s send aborted: %w", channel.GetType(), sendContext.Err())
+	}
+}
+
+// RegisterChannel adds a notification channel for DefaultTenant
+func (service *NotificationService) RegisterChannel(channel NotificationChannel) {
+	_ = service.RegisterTenantChannel(DefaultTenant, channel)
+}
+
+// SetUserPreferences saves notification preferences for a DefaultTenant user
+func (service *NotificationService) SetUserPreferences(preferences *UserPreferences) {
+	_ = service.SetTenantUserPreferences(DefaultTenant, preferences)
+}
+
+// AddTemplate registers a new notification template for DefaultTenant
+func (service *NotificationService) AddTemplate(template *NotificationTemplate) {
+	_ = service.AddTenantTemplate(DefaultTenant, template)
+}
+
+// SendNotification immediately sends a notification using the channels and
+// preferences of its tenant (see MetadataTenant)
+// Returns an error if sending fails, times out, or is blocked by preferences,
+// the tenant's rate limit or a budget cap
+func (service *NotificationService) SendNotification(ctx context.Context, notification *Notification) error {
+	// Get the tenant's channel and user preferences (read lock)
+	service.mutex.RLock()
+	scope, err := service.scopeLocked(notificationTenant(notification))
+	if err != nil {
+		service.mutex.RUnlock()
+		return err
+	}
+	_, channelExists := scope.channels[notification.Channel]
+	userPrefs := scope.userPreferences[notification.UserID]
+	service.mutex.RUnlock()
+
+	// Check if the channel is configured
+	if !channelExists {
+		return fmt.Errorf("channel %s is not configured", notification.Channel)
+	}
+
+	// Check user preferences if they exist
+	if userPrefs != nil {
+		// Check if user has disabled this channel
+		if !userPrefs.IsChannelEnabled(notification.Channel) {
+			return fmt.Errorf("user has disabled %s notifications", notification.Channel)
+		}
+
+		// Check quiet hours (Critical notifications bypass quiet hours)
+		if userPrefs.IsQuietHours() && notification.Priority != PriorityCritical {
+			return fmt.Errorf("quiet hours active - notification queued for later")
+		}
+	}
+
+	// The tenant's rate limit protects other tenants from a noisy neighbour
+	if err := service.takeRateSlot(scope, time.Now()); err != nil {
+		notification.Status = StatusFailed
+		service.recordDelivery(notification, err)
+		return err
+	}
+
+	// Budget caps may block the send or move it to a cheaper channel
+	if err := service.reserveBudget(notification, userPrefs); err != nil {
+		notification.Status = StatusFailed
+		service.recordDelivery(notification, err)
+		return err
+	}
+	service.mutex.RLock()
+	channel := scope.channels[notification.Channel]
+	timeout := service.getChannelTimeout(scope, notification.Channel)
+	service.mutex.RUnlock()
+
+	// Send the notification, bounded by the channel's timeout
+	err = sendWithTimeout(ctx, channel, notification, timeout)
+	if err != nil {
+		notification.Status = StatusFailed
+		service.releaseBudget(notification)
+		service.recordDelivery(notification, err)
+		return err
+	}
+
+	// Mark as sent and record the time
+	notification.Status = StatusSent
+	notification.SentAt = time.Now()
+
+	// Add to the tenant's history (write lock)
+	service.mutex.Lock()
+	scope.history = append(scope.history, notification)
+	service.mutex.Unlock()
+	service.recordDelivery(notification, nil)
+
+	return nil
+}
+
+// QueueNotification adds a notification to the async processing queue
+// Use this for non-urgent notifications to avoid blocking
+// When the queue is full it waits for room until ctx is done
+// Returns an error if the service has been shut down or ctx is done first
+func (service *NotificationService) QueueNotification(ctx context.Context, notification *Notification) error {
+	// The lock only guards the closed check; holding it while waiting for
+	// room would block Shutdown behind a full queue
+	service.mutex.RLock()
+	if service.closed {
+		service.mutex.RUnlock()
+		return errServiceShutDown
+	}
+	service.queueSenders.Add(1)
+	service.mutex.RUnlock()
+	defer service.queueSenders.Done()
+
+	select {
+	case service.notificationQueue <- notification:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-service.rootContext.Done():
+		return errServiceShutDown
+	}
+}
+
+// errServiceShutDown is returned when queueing after Shutdown
+var errServiceShutDown = errors.New("notification service is shut down")
+
+// processNotificationQueue is a background worker that processes
+// queued notifications one by one
+// Sends use the service's root context, so Shutdown aborts them
+func (service *NotificationService) processNotificationQueue() {
+	defer close(service.workerDone)
+
+	for {
+		select {
+		case notification := <-service.notificationQueue:
+			service.sendQueued(notification)
+		case <-service.rootContext.Done():
+			// No sender can still be enqueueing once they have all
+			// returned, so whatever is buffered now is the last of it
+			service.queueSenders.Wait()
+			for {
+				select {
+				case notification := <-service.notificationQueue:
+					service.sendQueued(notification)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// sendQueued sends one notification taken off the queue
+func (service *NotificationService) sendQueued(notification *Notification) {
+	err := service.SendNotification(service.rootContext, notification)
+	if err != nil {
+		fmt.Printf("  [QUEUE] Failed to send %s: %v\n", notification.ID, err)
+	}
+}
+
+// Shutdown stops accepting queued notifications, cancels in-flight sends,
+// and waits for the queue worker to drain (or until ctx is done).
+// Notifications still in the queue fail fast with a cancellation error.
+func (service *NotificationService) Shutdown(ctx context.Context) error {
+	service.mutex.Lock()
+	if service.closed {
+		service.mutex.Unlock()
+		return nil
+	}
+	service.closed = true
+	service.mutex.Unlock()
+
+	service.cancelRoot()
+
+	select {
+	case <-service.workerDone:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SendFromTemplate creates and sends a notification using a DefaultTenant template
+func (service *NotificationService) SendFromTemplate(
+	ctx context.Context,
+	userID string,
+	templateID string,
+	parameters map[string]string,
+) error {
+	return service.SendTenantTemplate(ctx, DefaultTenant, userID, templateID, parameters)
+}
+
+// SendLocalizedTemplate is SendFromTemplate with timestamp placeholders,
+// rendered in the recipient's timezone and locale
+func (service *NotificationService) SendLocalizedTemplate(
+	ctx context.Context,
+	userID string,
+	templateID string,
+	parameters map[string]string,
+	timestamps map[string]time.Time,
+) error {
+	service.mutex.RLock()
+	scope := service.tenants[DefaultTenant]
+	template, exists := scope.templates[templateID]
+	userPrefs := scope.userPreferences[userID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("template not found: %s", templateID)
+	}
+
+	title, body := template.RenderLocalized(parameters, timestamps, userPrefs)
+	notification := NewNotification(userID, title, body, template.Channel, PriorityMedium)
+	return service.SendNotification(ctx, notification)
+}
+
+// SendToMultipleChannels sends the same message through multiple channels
+// Useful for critical alerts that need maximum visibility
+func (service *NotificationService) SendToMultipleChannels(
+	ctx context.Context,
+	userID string,
+	title string,
+	message string,
+	channels []NotificationType,
+	priority NotificationPriority,
+) {
+	for _, channelType := range channels {
+		notification := NewNotification(userID, title, message, channelType, priority)
+		err := service.SendNotification(ctx, notification)
+		if err != nil {
+			fmt.Printf("  [MULTI] Failed on %s: %v\n", channelType, err)
+		}
+	}
+}
+
+// GetNotificationHistory returns a copy of DefaultTenant's notification history
+func (service *NotificationService) GetNotificationHistory() []*Notification {
+	history, _ := service.GetTenantHistory(DefaultTenant)
+	return history
+}
+
+// ==================== MULTI-TENANCY ====================
+//
+// Several applications can share one NotificationService. Each tenant owns
+// a separate partition (tenantScope) of channels, templates, user
+// preferences, history and in-app inbox, so the same user ID or template ID
+// in two applications never collides, and one application's SMS provider
+// can't be used to send another's messages.
+//
+// A notification belongs to the tenant named in Metadata[MetadataTenant]
+// (DefaultTenant when unset); the tenant must have been registered. The
+// methods without "Tenant" in their name work on DefaultTenant.
+//
+// Per-tenant configuration:
+// - RateLimit sends per RateWindow (sliding window), so a burst from one
+//   tenant can't starve the others
+// - SendTimeouts overriding the service-wide channel timeouts
+
+// DefaultTenantRateWindow is used when a rate-limited tenant sets no window
+const DefaultTenantRateWindow = time.Minute
+
+// TenantConfig is the configuration of one tenant
+type TenantConfig struct {
+	ID           string                             // Tenant identifier, also the MetadataTenant value
+	Name         string                             // Display name of the application
+	RateLimit    int                                // Max sends per RateWindow (0 = unlimited)
+	RateWindow   time.Duration                      // Rate limit window (0 = DefaultTenantRateWindow)
+	SendTimeouts map[NotificationType]time.Duration // Overrides of the service's channel timeouts
+}
+
+// tenantScope is the state owned by one tenant
+type tenantScope struct {
+	config          TenantConfig
+	channels        map[NotificationType]NotificationChannel // Registered channels
+	userPreferences map[string]*UserPreferences              // User settings by userID
+	templates       map[string]*NotificationTemplate         // Templates by ID
+	history         []*Notification                          // Sent notification history
+	inbox           InboxStore                               // Backs the in-app channel
+	recentSends     []time.Time                              // Send times inside the rate window
+}
+
+// newTenantScope creates an empty partition with the built-in in-app channel
+func newTenantScope(config TenantConfig) *tenantScope {
+	scope := &tenantScope{
+		config:          copyTenantConfig(config),
+		channels:        make(map[NotificationType]NotificationChannel),
+		userPreferences: make(map[string]*UserPreferences),
+		templates:       make(map[string]*NotificationTemplate),
+		history:         make([]*Notification, 0),
+		inbox:           NewMemoryInboxStore(),
+	}
+	// The in-app channel is built in: it needs no provider credentials
+	scope.channels[NotificationTypeInApp] = NewInAppChannel(scope.inbox)
+	return scope
+}
+
+// copyTenantConfig returns a copy that doesn't share the caller's map
+func copyTenantConfig(config TenantConfig) TenantConfig {
+	timeouts := make(map[NotificationType]time.Duration, len(config.SendTimeouts))
+	for channelType, timeout := range config.SendTimeouts {
+		timeouts[channelType] = timeout
+	}
+	config.SendTimeouts = timeouts
+	if config.RateWindow <= 0 {
+		config.RateWindow = DefaultTenantRateWindow
+	}
+	return config
+}
+
+// validateTenantConfig checks a configuration before it is stored
+func validateTenantConfig(config TenantConfig) error {
+	if config.ID == "" {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if config.RateLimit < 0 {
+		return fmt.Errorf("tenant %s: rate limit must not be negative", config.ID)
+	}
+	return nil
+}
+
+// scopeLocked returns the tenant's partition ("" means DefaultTenant)
+// Caller must hold at least a read lock
+func (service *NotificationService) scopeLocked(tenantID string) (*tenantScope, error) {
+	if tenantID == "" {
+		tenantID = DefaultTenant
+	}
+	scope, exists := service.tenants[tenantID]
+	if !exists {
+		return nil, fmt.Errorf("tenant %s is not registered", tenantID)
+	}
+	return scope, nil
+}
+
+// RegisterTenant adds a new tenant with its own empty partition
+func (service *NotificationService) RegisterTenant(config TenantConfig) error {
+	if err := validateTenantConfig(config); err != nil {
+		return err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if _, exists := service.tenants[config.ID]; exists {
+		return fmt.Errorf("tenant %s is already registered", config.ID)
+	}
+	service.tenants[config.ID] = newTenantScope(config)
+	return nil
+}
+
+// UpdateTenantConfig replaces a tenant's rate limit and timeouts at runtime;
+// its channels, templates, preferences and history are kept
+func (service *NotificationService) UpdateTenantConfig(config TenantConfig) error {
+	if err := validateTenantConfig(config); err != nil {
+		return err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	scope, err := service.scopeLocked(config.ID)
+	if err != nil {
+		return err
+	}
+	scope.config = copyTenantConfig(config)
+	return nil
+}
+
+// GetTenantConfig returns a copy of a tenant's configuration
+func (service *NotificationService) GetTenantConfig(tenantID string) (TenantConfig, error) {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	scope, err := service.scopeLocked(tenantID)
+	if err != nil {
+		return TenantConfig{}, err
+	}
+	return copyTenantConfig(scope.config), nil
+}
+
+// RegisterTenantChannel adds a notification channel for one tenant
+func (service *NotificationService) RegisterTenantChannel(tenantID string, channel NotificationChannel) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	scope, err := service.scopeLocked(tenantID)
+	if err != nil {
+		return err
+	}
+	scope.channels[channel.GetType()] = channel
+	return nil
+}
+
+// SetTenantUserPreferences saves notification preferences for a tenant's user
+func (service *NotificationService) SetTenantUserPreferences(tenantID string, preferences *UserPreferences) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	scope, err := service.scopeLocked(tenantID)
+	if err != nil {
+		return err
+	}
+	scope.userPreferences[preferences.UserID] = preferences
+	return nil
+}
+
+// AddTenantTemplate registers a notification template for one tenant
+func (service *NotificationService) AddTenantTemplate(tenantID string, template *NotificationTemplate) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	scope, err := service.scopeLocked(tenantID)
+	if err != nil {
+		return err
+	}
+	scope.templates[template.ID] = template
+	return nil
+}
+
+// NewTenantNotification creates a notification that belongs to a tenant
+func NewTenantNotification(
+	tenantID string,
+	userID string,
+	title string,
+	message string,
+	channel NotificationType,
+	priority NotificationPriority,
+) *Notification {
+	notification := NewNotification(userID, title, message, channel, priority)
+	if tenantID != "" && tenantID != DefaultTenant {
+		notification.Metadata[MetadataTenant] = tenantID
+	}
+	return notification
+}
+
+// SendTenantTemplate creates and sends a notification from one of the
+// tenant's templates
+func (service *NotificationService) SendTenantTemplate(
+	ctx context.Context,
+	tenantID string,
+	userID string,
+	templateID string,
+	parameters map[string]string,
+) error {
+	// Get the template
+	service.mutex.RLock()
+	scope, err := service.scopeLocked(tenantID)
+	if err != nil {
+		service.mutex.RUnlock()
+		return err
+	}
+	template, exists := scope.templates[templateID]
+	service.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("template not found: %s", templateID)
+	}
+
+	// Render the template with parameters
+	title, body := template.Render(parameters)
+
+	// Create and send the notification
+	notification := NewTenantNotification(tenantID, userID, title, body, template.Channel, PriorityMedium)
+	return service.SendNotification(ctx, notification)
+}
+
+// GetTenantHistory returns a copy of one tenant's notification history
+func (service *NotificationService) GetTenantHistory(tenantID string) ([]*Notification, error) {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	scope, err := service.scopeLocked(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return a copy to prevent external modification
+	historyCopy := make([]*Notification, len(scope.history))
+	copy(historyCopy, scope.history)
+	return historyCopy, nil
+}
+
+// GetTenantInbox lists a tenant user's in-app notifications, newest first
+func (service *NotificationService) GetTenantInbox(tenantID string, userID string, filter InboxFilter) ([]InboxEntry, error) {
+	service.mutex.RLock()
+	scope, err := service.scopeLocked(tenantID)
+	service.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	return scope.inbox.List(userID, filter), nil
+}
+
+// takeRateSlot records a send against the tenant's rate limit, or fails if
+// the tenant already sent RateLimit notifications in the last RateWindow
+func (service *NotificationService) takeRateSlot(scope *tenantScope, now time.Time) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	limit, window := scope.config.RateLimit, scope.config.RateWindow
+	if limit == 0 {
+		return nil
+	}
+
+	// Drop sends that have slid out of the window
+	cutoff := now.Add(-window)
+	kept := scope.recentSends[:0]
+	for _, sentAt := range scope.recentSends {
+		if sentAt.After(cutoff) {
+			kept = append(kept, sentAt)
+		}
+	}
+	scope.recentSends = kept
+
+	if len(scope.recentSends) >= limit {
+		return fmt.Errorf("tenant %s rate limit of %d per %v exceeded", scope.config.ID, limit, window)
+	}
+	scope.recentSends = append(scope.recentSends, now)
+	return nil
+}
+
+// ==================== INBOX & READ RECEIPTS ====================
+//
+// Every channel can report that a notification was read: the in-app inbox
+// when the user opens an entry, email via a tracking pixel, push when the
+// user taps it. Receipts are recorded once per notification, which gives
+// read-rate analytics per channel.
+
+// ReadStats summarizes read receipts for one channel
+type ReadStats struct {
+	Channel        NotificationType
+	Sent           int
+	Read           int
+	AverageLatency time.Duration // Average time from send to first read
+}
+
+// ReadRate returns the fraction of sent notifications that were read
+func (stats ReadStats) ReadRate() float64 {
+	if stats.Sent == 0 {
+		return 0
+	}
+	return float64(stats.Read) / float64(stats.Sent)
+}
+
+// GetInboxStore returns the store backing DefaultTenant's in-app channel
+func (service *NotificationService) GetInboxStore() InboxStore {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+	return service.tenants[DefaultTenant].inbox
+}
+
+// GetInbox lists a DefaultTenant user's in-app notifications, newest first
+func (service *NotificationService) GetInbox(userID string, filter InboxFilter) []InboxEntry {
+	return service.GetInboxStore().List(userID, filter)
+}
+
+// GetUnreadCount returns the user's unread in-app notification count
+func (service *NotificationService) GetUnreadCount(userID string) int {
+	return service.GetInboxStore().UnreadCount(userID)
+}
+
+// findSentNotification looks up a notification delivered for DefaultTenant by ID
+// Caller must hold at least a read lock
+func (service *NotificationService) findSentNotification(notificationID string) *Notification {
+	for _, notification := range service.tenants[DefaultTenant].history {
+		if notification.ID == notificationID {
+			return notification
+		}
+	}
+	return nil
+}
+
+// MarkAsRead records a read receipt for a notification delivered for DefaultTenant.
+// In-app notifications are also marked read in the user's inbox.
+// Only the first read is recorded; later calls are no-ops.
+func (service *NotificationService) MarkAsRead(userID string, notificationID string) error {
+	readAt := time.Now()
+
+	service.mutex.Lock()
+	notification := service.findSentNotification(notificationID)
+	if notification == nil || notification.UserID != userID {
+		service.mutex.Unlock()
+		return fmt.Errorf("notification %s not found for user %s", notificationID, userID)
+	}
+	if _, alreadyRead := service.readReceipts[notificationID]; !alreadyRead {
+		service.readReceipts[notificationID] = readAt
+	}
+	service.mutex.Unlock()
+
+	if notification.Channel == NotificationTypeInApp {
+		return service.GetInboxStore().MarkRead(userID, notificationID, readAt)
+	}
+	return nil
+}
+
+// MarkAllAsRead marks every unread in-app notification of the user as read
+// and returns how many were marked
+func (service *NotificationService) MarkAllAsRead(userID string) int {
+	marked := 0
+	for _, entry := range service.GetInbox(userID, InboxUnread) {
+		if service.MarkAsRead(userID, entry.Notification.ID) == nil {
+			marked++
+		}
+	}
+	return marked
+}
+
+// GetReadStats returns DefaultTenant's read-receipt analytics per channel,
+// covering every channel that has sent at least one notification
+func (service *NotificationService) GetReadStats() []ReadStats {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	statsByChannel := make(map[NotificationType]*ReadStats)
+	totalLatency := make(map[NotificationType]time.Duration)
+	for _, notification := range service.tenants[DefaultTenant].history {
+		stats, exists := statsByChannel[notification.Channel]
+		if !exists {
+			stats = &ReadStats{Channel: notification.Channel}
+			statsByChannel[notification.Channel] = stats
+		}
+		stats.Sent++
+		if readAt, read := service.readReceipts[notification.ID]; read {
+			stats.Read++
+			totalLatency[notification.Channel] += readAt.Sub(notification.SentAt)
+		}
+	}
+
+	result := make([]ReadStats, 0, len(statsByChannel))
+	for channel, stats := range statsByChannel {
+		if stats.Read > 0 {
+			stats.AverageLatency = totalLatency[channel] / time.Duration(stats.Read)
+		}
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Channel < result[j].Channel })
+	return result
+}
+
+// ==================== COST TRACKING & BUDGET CAPS ====================
+//
+// Every send is charged an estimated cost: SMS is billed per segment, email
+// costs a fraction of a cent, in-app is free. Spend is tracked per tenant,
+// channel and calendar month (UTC).
+//
+// A BudgetCap limits monthly spend on one channel, either for one tenant or
+// platform-wide (Tenant == ""). When a send would exceed a cap:
+// - BudgetBlock rejects it
+// - BudgetDowngrade moves it to the next cheaper channel (SMS → Push →
+//   In-App, Email → In-App), provided that channel is registered, enabled by
+//   the user and itself within budget
+//
+// Budget is reserved before the send and released if the send fails, so
+// concurrent sends can never overshoot a cap.
+
+// MetadataTenant is the notification metadata key naming the paying tenant
+const MetadataTenant = "tenant"
+
+// MetadataDowngradedFrom records the original channel of a downgraded send
+const MetadataDowngradedFrom = "downgraded_from"
+
+// DefaultTenant is charged when a notification names no tenant
+const DefaultTenant = "default"
+
+// CostEstimator prices one notification on a channel (Strategy Pattern)
+type CostEstimator interface {
+	EstimateCost(notification *Notification) float64
+}
+
+// FlatCost charges the same amount for every message
+type FlatCost struct {
+	PerMessage float64
+}
+
+// EstimateCost returns the flat per-message price
+func (cost FlatCost) EstimateCost(_ *Notification) float64 {
+	return cost.PerMessage
+}
+
+// SMSSegmentCost charges per SMS segment (see SMS SEGMENTATION for how
+// segments are counted). An SMSChannel is a more precise estimator when it
+// truncates or splits long texts.
+type SMSSegmentCost struct {
+	PerSegment float64
+}
+
+// SMSSegments returns how many segments the text is split into
+func SMSSegments(text string) int {
+	return AnalyzeSMS(text).Segments
+}
+
+// EstimateCost returns the segment count times the per-segment price
+func (cost SMSSegmentCost) EstimateCost(notification *Notification) float64 {
+	return float64(SMSSegments(notification.Message)) * cost.PerSegment
+}
+
+// DefaultCostEstimators returns typical provider list prices (USD)
+func DefaultCostEstimators() map[NotificationType]CostEstimator {
+	return map[NotificationType]CostEstimator{
+		NotificationTypeEmail: FlatCost{PerMessage: 0.0001},
+		NotificationTypeSMS:   SMSSegmentCost{PerSegment: 0.0075},
+		NotificationTypePush:  FlatCost{PerMessage: 0.00002},
+		NotificationTypeSlack: FlatCost{PerMessage: 0},
+		NotificationTypeInApp: FlatCost{PerMessage: 0},
+	}
+}
+
+// channelDowngrades lists the cheaper channel to try when a cap is exceeded
+var channelDowngrades = map[NotificationType]NotificationType{
+	NotificationTypeSMS:   NotificationTypePush,
+	NotificationTypePush:  NotificationTypeInApp,
+	NotificationTypeEmail: NotificationTypeInApp,
+}
+
+// BudgetAction is what happens to a send that would exceed a cap
+type BudgetAction int
+
+const (
+	BudgetBlock     BudgetAction = iota // 0 - Reject the send
+	BudgetDowngrade                     // 1 - Retry on a cheaper channel
+)
+
+// String converts BudgetAction to a readable string
+func (action BudgetAction) String() string {
+	actionNames := []string{"Block", "Downgrade"}
+	if int(action) < len(actionNames) {
+		return actionNames[action]
+	}
+	return "Unknown"
+}
+
+// BudgetCap is a monthly spend limit on one channel
+type BudgetCap struct {
+	Tenant       string           // Tenant the cap applies to ("" = all tenants combined)
+	Channel      NotificationType // Capped channel
+	MonthlyLimit float64          // Maximum spend per calendar month (USD)
+	Action       BudgetAction     // What to do once the limit would be exceeded
+}
+
+// spendKey identifies one spend bucket
+type spendKey struct {
+	tenant  string
+	channel NotificationType
+	month   string // "2006-01"
+}
+
+// spendEntry is the running total of one bucket
+type spendEntry struct {
+	messages int
+	amount   float64
+}
+
+// budgetMonth returns the spend bucket month for a time
+func budgetMonth(moment time.Time) string {
+	return moment.UTC().Format("2006-01")
+}
+
+// notificationTenant returns the tenant a notification is charged to
+func notificationTenant(notification *Notification) string {
+	if tenant := notification.Metadata[MetadataTenant]; tenant != "" {
+		return tenant
+	}
+	return DefaultTenant
+}
+
+// SetCostEstimator replaces the cost model of a channel
+func (service *NotificationService) SetCostEstimator(channelType NotificationType, estimator CostEstimator) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.costEstimators[channelType] = estimator
+}
+
+// SetBudgetCap adds a cap, replacing any cap for the same tenant and channel
+func (service *NotificationService) SetBudgetCap(budgetCap BudgetCap) error {
+	if budgetCap.MonthlyLimit < 0 {
+		return fmt.Errorf("budget limit must not be negative")
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	for index, existing := range service.budgetCaps {
+		if existing.Tenant == budgetCap.Tenant && existing.Channel == budgetCap.Channel {
+			service.budgetCaps[index] = &budgetCap
+			return nil
+		}
+	}
+	service.budgetCaps = append(service.budgetCaps, &budgetCap)
+	return nil
+}
+
+// monthSpendLocked returns the spend on a channel this month for one tenant,
+// or for all tenants when tenant is ""
+// Caller must hold the lock
+func (service *NotificationService) monthSpendLocked(tenant string, channelType NotificationType, month string) float64 {
+	total := 0.0
+	for key, entry := range service.spend {
+		if key.channel == channelType && key.month == month && (tenant == "" || key.tenant == tenant) {
+			total += entry.amount
+		}
+	}
+	return total
+}
+
+// exceededCapLocked returns the first cap the extra cost would break, or nil
+// Caller must hold the lock
+func (service *NotificationService) exceededCapLocked(tenant string, channelType NotificationType, month string, cost float64) *BudgetCap {
+	for _, budgetCap := range service.budgetCaps {
+		if budgetCap.Channel != channelType || (budgetCap.Tenant != "" && budgetCap.Tenant != tenant) {
+			continue
+		}
+		if service.monthSpendLocked(budgetCap.Tenant, channelType, month)+cost > budgetCap.MonthlyLimit {
+			return budgetCap
+		}
+	}
+	return nil
+}
+
+// reserveBudget charges the notification's cost to its tenant, downgrading
+// its channel or rejecting it if a cap would be exceeded
+func (service *NotificationService) reserveBudget(notification *Notification, userPrefs *UserPreferences) error {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	tenant := notificationTenant(notification)
+	month := budgetMonth(time.Now())
+	originalChannel := notification.Channel
+
+	for {
+		cost := 0.0
+		if estimator, exists := service.costEstimators[notification.Channel]; exists {
+			cost = estimator.EstimateCost(notification)
+		}
+
+		budgetCap := service.exceededCapLocked(tenant, notification.Channel, month, cost)
+		if budgetCap == nil {
+			key := spendKey{tenant: tenant, channel: notification.Channel, month: month}
+			if service.spend[key] == nil {
+				service.spend[key] = &spendEntry{}
+			}
+			service.spend[key].messages++
+			service.spend[key].amount += cost
+			notification.Cost = cost
+			if notification.Channel != originalChannel {
+				notification.Metadata[MetadataDowngradedFrom] = originalChannel.String()
+			}
+			return nil
+		}
+
+		capOwner := budgetCap.Tenant
+		if capOwner == "" {
+			capOwner = "platform"
+		}
+		exhausted := fmt.Errorf("%s budget of $%.2f for %s exhausted this month",
+			notification.Channel, budgetCap.MonthlyLimit, capOwner)
+		if budgetCap.Action == BudgetBlock {
+			return exhausted
+		}
+
+		fallback, hasFallback := channelDowngrades[notification.Channel]
+		_, registered := service.tenants[tenant].channels[fallback]
+		if !hasFallback || !registered || (userPrefs != nil && !userPrefs.IsChannelEnabled(fallback)) {
+			return fmt.Errorf("%w, and no cheaper channel is available", exhausted)
+		}
+		notification.Channel = fallback
+	}
+}
+
+// releaseBudget refunds the reservation of a notification that failed to send
+func (service *NotificationService) releaseBudget(notification *Notification) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	key := spendKey{tenant: notificationTenant(notification), channel: notification.Channel, month: budgetMonth(time.Now())}
+	if entry, exists := service.spend[key]; exists {
+		entry.messages--
+		entry.amount -= notification.Cost
+	}
+	notification.Cost = 0
+}
+
+// SpendLine is the spend of one tenant on one channel
+type SpendLine struct {
+	Tenant   string
+	Channel  NotificationType
+	Messages int
+	Spend    float64
+	Limit    float64 // Tenant cap on this channel (0 if uncapped)
+}
+
+// Utilization returns spend as a fraction of the cap (0 if uncapped)
+func (line SpendLine) Utilization() float64 {
+	if line.Limit == 0 {
+		return 0
+	}
+	return line.Spend / line.Limit
+}
+
+// SpendReport is the spend of every tenant and channel for one month
+type SpendReport struct {
+	Month string
+	Lines []SpendLine // Sorted by tenant, then channel
+	Total float64
+}
+
+// GetSpendReport returns spend for a month ("2006-01"; "" = current month)
+func (service *NotificationService) GetSpendReport(month string) SpendReport {
+	if month == "" {
+		month = budgetMonth(time.Now())
+	}
+
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	report := SpendReport{Month: month, Lines: make([]SpendLine, 0)}
+	for key, entry := range service.spend {
+		if key.month != month || entry.messages == 0 {
+			continue
+		}
+		line := SpendLine{Tenant: key.tenant, Channel: key.channel, Messages: entry.messages, Spend: entry.amount}
+		for _, budgetCap := range service.budgetCaps {
+			if budgetCap.Tenant == key.tenant && budgetCap.Channel == key.channel {
+				line.Limit = budgetCap.MonthlyLimit
+			}
+		}
+		report.Lines = append(report.Lines, line)
+		report.Total += entry.amount
+	}
+	sort.Slice(report.Lines, func(i, j int) bool {
+		if report.Lines[i].Tenant != report.Lines[j].Tenant {
+			return report.Lines[i].Tenant < report.Lines[j].Tenant
+		}
+		return report.Lines[i].Channel < report.Lines[j].Channel
+	})
+	return report
+}
+
+// ==================== DELIVERY ANALYTICS ====================
+//
+// SendNotification logs the outcome of every send that got past the user's
+// preferences: delivered, or failed on the rate limit, a budget cap, the
+// channel itself or its timeout. Reports aggregate that log for a dashboard:
+// sends and failures per channel per day, retry counts, the channels that
+// fail most, and delivery success per user. Report types carry JSON tags for
+// a dashboard API, and WritePrometheus exports the same numbers as metrics.
+
+// DeliveryRecord is the logged outcome of one send
+type DeliveryRecord struct {
+	NotificationID string
+	Tenant         string
+	UserID         string
+	Channel        NotificationType // Channel actually used (after any budget downgrade)
+	Status         NotificationStatus
+	RetryCount     int
+	At             time.Time
+	Error          string // Empty when delivered
+}
+
+// recordDelivery appends the outcome of a send to the delivery log
+func (service *NotificationService) recordDelivery(notification *Notification, sendError error) {
+	record := DeliveryRecord{
+		NotificationID: notification.ID,
+		Tenant:         notificationTenant(notification),
+		UserID:         notification.UserID,
+		Channel:        notification.Channel,
+		Status:         StatusSent,
+		RetryCount:     notification.RetryCount,
+		At:             time.Now(),
+	}
+	if sendError != nil {
+		record.Status = StatusFailed
+		record.Error = sendError.Error()
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	service.deliveries = append(service.deliveries, record)
+}
+
+// MarshalText makes channels appear by name in JSON reports
+func (notificationType NotificationType) MarshalText() ([]byte, error) {
+	return []byte(notificationType.String()), nil
+}
+
+// DeliveryCounts are the sends, failures and retries of one group of records
+type DeliveryCounts struct {
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
+	Retries int `json:"retries"`
+}
+
+// add counts one record
+func (counts *DeliveryCounts) add(record DeliveryRecord) {
+	if record.Status == StatusSent {
+		counts.Sent++
+	} else {
+		counts.Failed++
+	}
+	counts.Retries += record.RetryCount
+}
+
+// Attempts returns the number of sends, delivered or not
+func (counts DeliveryCounts) Attempts() int {
+	return counts.Sent + counts.Failed
+}
+
+// FailureRate returns the fraction of sends that failed
+func (counts DeliveryCounts) FailureRate() float64 {
+	if counts.Attempts() == 0 {
+		return 0
+	}
+	return float64(counts.Failed) / float64(counts.Attempts())
+}
+
+// SuccessRate returns the fraction of sends that were delivered
+func (counts DeliveryCounts) SuccessRate() float64 {
+	if counts.Attempts() == 0 {
+		return 0
+	}
+	return float64(counts.Sent) / float64(counts.Attempts())
+}
+
+// AverageRetries returns the mean retry count per send
+func (counts DeliveryCounts) AverageRetries() float64 {
+	if counts.Attempts() == 0 {
+		return 0
+	}
+	return float64(counts.Retries) / float64(counts.Attempts())
+}
+
+// ChannelDayStats are one channel's deliveries on one UTC day
+type ChannelDayStats struct {
+	Day     string           `json:"day"` // "2006-01-02"
+	Channel NotificationType `json:"channel"`
+	DeliveryCounts
+}
+
+// ChannelStats are one channel's deliveries over the whole report period
+type ChannelStats struct {
+	Channel NotificationType `json:"channel"`
+	DeliveryCounts
+	AverageRetries float64 `json:"average_retries"`
+	FailureRate    float64 `json:"failure_rate"`
+}
+
+// UserDeliveryStats are one user's deliveries across all channels
+type UserDeliveryStats struct {
+	Tenant string `json:"tenant"`
+	UserID string `json:"user_id"`
+	DeliveryCounts
+	SuccessRate float64 `json:"success_rate"`
+}
+
+// DeliveryReport is the dashboard view of the delivery log
+type DeliveryReport struct {
+	Tenant         string              `json:"tenant,omitempty"` // Empty = all tenants
+	From           time.Time           `json:"from"`             // Zero = since the first send
+	To             time.Time           `json:"to"`               // Exclusive; zero = up to now
+	GeneratedAt    time.Time           `json:"generated_at"`
+	Totals         DeliveryCounts      `json:"totals"`
+	AverageRetries float64             `json:"average_retries"`
+	Daily          []ChannelDayStats   `json:"daily"`       // Sorted by day, then channel
+	Channels       []ChannelStats      `json:"channels"`    // Sorted by channel
+	TopFailing     []ChannelStats      `json:"top_failing"` // Channels with failures, most failures first
+	Users          []UserDeliveryStats `json:"users"`       // Sorted by tenant, then user
+}
+
+// GetDeliveryReport aggregates deliveries of all tenants sent in [from, to).
+// A zero from or to leaves that end of the period open.
+func (service *NotificationService) GetDeliveryReport(from, to time.Time) DeliveryReport {
+	return service.buildDeliveryReport("", from, to)
+}
+
+// GetTenantDeliveryReport is GetDeliveryReport restricted to one tenant
+func (service *NotificationService) GetTenantDeliveryReport(tenantID string, from, to time.Time) (DeliveryReport, error) {
+	service.mutex.RLock()
+	_, err := service.scopeLocked(tenantID)
+	service.mutex.RUnlock()
+	if err != nil {
+		return DeliveryReport{}, err
+	}
+	return service.buildDeliveryReport(tenantID, from, to), nil
+}
+
+// buildDeliveryReport aggregates the delivery log ("" tenant = all tenants)
+func (service *NotificationService) buildDeliveryReport(tenantID string, from, to time.Time) DeliveryReport {
+	type dayKey struct {
+		day     string
+		channel NotificationType
+	}
+	type userKey struct {
+		tenant string
+		userID string
+	}
+	daily := make(map[dayKey]*DeliveryCounts)
+	channels := make(map[NotificationType]*DeliveryCounts)
+	users := make(map[userKey]*DeliveryCounts)
+	report := DeliveryReport{Tenant: tenantID, From: from, To: to, GeneratedAt: time.Now()}
+
+	service.mutex.RLock()
+	for _, record := range service.deliveries {
+		if tenantID != "" && record.Tenant != tenantID {
+			continue
+		}
+		if (!from.IsZero() && record.At.Before(from)) || (!to.IsZero() && !record.At.Before(to)) {
+			continue
+		}
+		day := dayKey{day: record.At.UTC().Format("2006-01-02"), channel: record.Channel}
+		user := userKey{tenant: record.Tenant, userID: record.UserID}
+		if daily[day] == nil {
+			daily[day] = &DeliveryCounts{}
+		}
+		if channels[record.Channel] == nil {
+			channels[record.Channel] = &DeliveryCounts{}
+		}
+		if users[user] == nil {
+			users[user] = &DeliveryCounts{}
+		}
+		daily[day].add(record)
+		channels[record.Channel].add(record)
+		users[user].add(record)
+		report.Totals.add(record)
+	}
+	service.mutex.RUnlock()
+
+	report.AverageRetries = report.Totals.AverageRetries()
+	for key, counts := range daily {
+		report.Daily = append(report.Daily, ChannelDayStats{Day: key.day, Channel: key.channel, DeliveryCounts: *counts})
+	}
+	sort.Slice(report.Daily, func(i, j int) bool {
+		if report.Daily[i].Day != report.Daily[j].Day {
+			return report.Daily[i].Day < report.Daily[j].Day
+		}
+		return report.Daily[i].Channel < report.Daily[j].Channel
+	})
+
+	for channel, counts := range channels {
+		stats := ChannelStats{
+			Channel:        channel,
+			DeliveryCounts: *counts,
+			AverageRetries: counts.AverageRetries(),
+			FailureRate:    counts.FailureRate(),
+		}
+		report.Channels = append(report.Channels, stats)
+		if counts.Failed > 0 {
+			report.TopFailing = append(report.TopFailing, stats)
+		}
+	}
+	sort.Slice(report.Channels, func(i, j int) bool { return report.Channels[i].Channel < report.Channels[j].Channel })
+	sort.Slice(report.TopFailing, func(i, j int) bool {
+		left, right := report.TopFailing[i], report.TopFailing[j]
+		if left.Failed != right.Failed {
+			return left.Failed > right.Failed
+		}
+		if left.FailureRate != right.FailureRate {
+			return left.FailureRate > right.FailureRate
+		}
+		return left.Channel < right.Channel
+	})
+
+	for key, counts := range users {
+		report.Users = append(report.Users, UserDeliveryStats{
+			Tenant:         key.tenant,
+			UserID:         key.userID,
+			DeliveryCounts: *counts,
+			SuccessRate:    counts.SuccessRate(),
+		})
+	}
+	sort.Slice(report.Users, func(i, j int) bool {
+		if report.Users[i].Tenant != report.Users[j].Tenant {
+			return report.Users[i].Tenant < report.Users[j].Tenant
+		}
+		return report.Users[i].UserID < report.Users[j].UserID
+	})
+	return report
+}
+
+// WritePrometheus writes the report in the Prometheus text exposition format.
+// Per-day buckets are left out: Prometheus builds time series itself, so the
+// counters cover the whole report period.
+func (report DeliveryReport) WritePrometheus(writer io.Writer) error {
+	var builder strings.Builder
+	tenantLabel := ""
+	if report.Tenant != "" {
+		tenantLabel = fmt.Sprintf("tenant=%q,", report.Tenant)
+	}
+
+	builder.WriteString("# HELP notification_deliveries_total Notifications by channel and outcome.\n")
+	builder.WriteString("# TYPE notification_deliveries_total counter\n")
+	for _, stats := range report.Channels {
+		builder.WriteString(fmt.Sprintf("notification_deliveries_total{%schannel=%q,status=\"sent\"} %d\n", tenantLabel, stats.Channel, stats.Sent))
+		builder.WriteString(fmt.Sprintf("notification_deliveries_total{%schannel=%q,status=\"failed\"} %d\n", tenantLabel, stats.Channel, stats.Failed))
+	}
+	builder.WriteString("# HELP notification_retries_total Retries made by channel decorators.\n")
+	builder.WriteString("# TYPE notification_retries_total counter\n")
+	for _, stats := range report.Channels {
+		builder.WriteString(fmt.Sprintf("notification_retries_total{%schannel=%q} %d\n", tenantLabel, stats.Channel, stats.Retries))
+	}
+	builder.WriteString("# HELP notification_retries_average Mean retries per send.\n")
+	builder.WriteString("# TYPE notification_retries_average gauge\n")
+	for _, stats := range report.Channels {
+		builder.WriteString(fmt.Sprintf("notification_retries_average{%schannel=%q} %g\n", tenantLabel, stats.Channel, stats.AverageRetries))
+	}
+	builder.WriteString("# HELP notification_user_success_ratio Fraction of a user's notifications that were delivered.\n")
+	builder.WriteString("# TYPE notification_user_success_ratio gauge\n")
+	for _, stats := range report.Users {
+		builder.WriteString(fmt.Sprintf("notification_user_success_ratio{tenant=%q,user=%q} %g\n", stats.Tenant, stats.UserID, stats.SuccessRate))
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// ==================== NOTIFICATION GROUPING ====================
+//
+// Related notifications (every update about order #123, every reply in one
+// conversation) share a GroupKey so clients can collapse them into a thread.
+// The in-app inbox lists threads with their latest entry and unread count,
+// and the push channel tags each payload with the thread ID (APNs thread-id /
+// FCM tag) plus a running count so the device can stack them under one
+// summary. Notifications without a GroupKey form a thread of their own.
+
+// GroupKeyFor builds a group key from an entity kind and ID, e.g. "order:123"
+func GroupKeyFor(kind string, id string) string {
+	return kind + ":" + id
+}
+
+// clone returns a copy of the notification with its own Metadata map
+func (notification *Notification) clone() *Notification {
+	copied := *notification
+	copied.Metadata = make(map[string]string, len(notification.Metadata))
+	for key, value := range notification.Metadata {
+		copied.Metadata[key] = value
+	}
+	copied.Attachments = append([]Attachment(nil), notification.Attachments...)
+	return &copied
+}
+
+// ThreadKey returns the key of the thread the notification belongs to: its
+// GroupKey, or its own ID when it is not grouped
+func (notification *Notification) ThreadKey() string {
+	if notification.GroupKey == "" {
+		return notification.ID
+	}
+	return notification.GroupKey
+}
+
+// InboxThread is a collapsed view of one thread in a user's inbox
+type InboxThread struct {
+	GroupKey    string     // Thread key (GroupKey, or the notification ID if ungrouped)
+	Latest      InboxEntry // Most recent entry, shown as the thread's preview
+	Count       int        // Entries in the thread
+	UnreadCount int        // Entries the user has not read
+}
+
+// IsGrouped reports whether the thread collects several related notifications
+func (thread InboxThread) IsGrouped() bool {
+	return thread.Latest.Notification.GroupKey != ""
+}
+
+// matches reports whether the thread passes the filter: unread threads have
+// at least one unread entry, read threads have none
+func (thread InboxThread) matches(filter InboxFilter) bool {
+	switch filter {
+	case InboxUnread:
+		return thread.UnreadCount > 0
+	case InboxRead:
+		return thread.UnreadCount == 0
+	default:
+		return true
+	}
+}
+
+// ListThreads returns the user's threads matching the filter, most recently
+// updated first
+func (store *MemoryInboxStore) ListThreads(userID string, filter InboxFilter) []InboxThread {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	threads := make([]InboxThread, 0)
+	threadIndex := make(map[string]int)
+	entries := store.inboxes[userID]
+	for index := len(entries) - 1; index >= 0; index-- {
+		entry := entries[index]
+		key := entry.Notification.ThreadKey()
+		position, exists := threadIndex[key]
+		if !exists {
+			// Walking newest first, so the first entry seen is the latest
+			position = len(threads)
+			threadIndex[key] = position
+			threads = append(threads, InboxThread{GroupKey: key, Latest: *entry})
+		}
+		threads[position].Count++
+		if !entry.IsRead() {
+			threads[position].UnreadCount++
+		}
+	}
+
+	result := make([]InboxThread, 0, len(threads))
+	for _, thread := range threads {
+		if thread.matches(filter) {
+			result = append(result, thread)
+		}
+	}
+	return result
+}
+
+// GetThread returns copies of the thread's entries, oldest first
+func (store *MemoryInboxStore) GetThread(userID string, groupKey string) []InboxEntry {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	result := make([]InboxEntry, 0)
+	for _, entry := range store.inboxes[userID] {
+		if entry.Notification.ThreadKey() == groupKey {
+			result = append(result, *entry)
+		}
+	}
+	return result
+}
+
+// PushPayload is what the push provider receives for one notification
+type PushPayload struct {
+	UserID     string
+	Title      string
+	Body       string
+	ThreadID   string // APNs thread-id / FCM tag; empty for ungrouped notifications
+	GroupCount int    // Notifications pushed to this user in the thread so far
+	Summary    string // Text for the collapsed group, e.g. "3 updates"
+}
+
+// BuildPayload renders the notification as a push payload, counting it
+// towards its thread
+func (pushChannel *PushChannel) BuildPayload(notification *Notification) PushPayload {
+	payload := PushPayload{
+		UserID: notification.UserID,
+		Title:  notification.Title,
+		Body:   notification.Message,
+	}
+	if notification.GroupKey == "" {
+		return payload
+	}
+
+	pushChannel.mutex.Lock()
+	threadKey := notification.UserID + "/" + notification.GroupKey
+	pushChannel.threadCounts[threadKey]++
+	payload.GroupCount = pushChannel.threadCounts[threadKey]
+	pushChannel.mutex.Unlock()
+
+	payload.ThreadID = notification.GroupKey
+	if payload.GroupCount > 1 {
+		payload.Summary = fmt.Sprintf("%d updates", payload.GroupCount)
+	}
+	return payload
+}
+
+// GetThreads lists a DefaultTenant user's inbox collapsed into threads
+func (service *NotificationService) GetThreads(userID string, filter InboxFilter) []InboxThread {
+	return service.GetInboxStore().ListThreads(userID, filter)
+}
+
+// GetThread returns the in-app messages of one thread, oldest first
+func (service *NotificationService) GetThread(userID string, groupKey string) []InboxEntry {
+	return service.GetInboxStore().GetThread(userID, groupKey)
+}
+
+// GetThreadHistory returns every notification sent to the user in a thread
+// on any channel (DefaultTenant), oldest first
+func (service *NotificationService) GetThreadHistory(userID string, groupKey string) []*Notification {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	result := make([]*Notification, 0)
+	for _, notification := range service.tenants[DefaultTenant].history {
+		if notification.UserID == userID && notification.ThreadKey() == groupKey {
+			result = append(result, notification)
+		}
+	}
+	return result
+}
+
+// MarkThreadAsRead marks every unread in-app entry of a thread as read and
+// returns how many were marked
+func (service *NotificationService) MarkThreadAsRead(userID string, groupKey string) int {
+	marked := 0
+	for _, entry := range service.GetThread(userID, groupKey) {
+		if !entry.IsRead() && service.MarkAsRead(userID, entry.Notification.ID) == nil {
+			marked++
+		}
+	}
+	return marked
+}
@@ -0,0 +1,8 @@
+// Command demo runs the pubsub walkthrough: go run ./19_pubsub/cmd/demo
+package main
+
+import pubsub "github.com/ayushgupta5/GoLLD/19_pubsub"
+
+func main() {
+	pubsub.RunDemo()
+}
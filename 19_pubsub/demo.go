@@ -0,0 +1,638 @@
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ========== MAIN FUNCTION ==========
+
+// RunDemo demonstrates the pub-sub and message queue system; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("       📬 PUB-SUB MESSAGE SYSTEM DEMO")
+	fmt.Println("═══════════════════════════════════════════")
+
+	// Step 1: Create the message broker
+	// The broker is the central hub for all pub-sub operations
+	broker := NewMessageBroker()
+
+	// Step 2: Create topics
+	// Topics are channels that group related messages
+	broker.CreateTopic("orders")
+	broker.CreateTopic("payments")
+	broker.CreateTopic("notifications")
+	broker.CreateTopic("analytics")
+
+	fmt.Println("\n📋 Created Topics:", broker.ListTopics())
+
+	// Step 3: Set up subscribers
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("👥 Setting up subscribers...")
+
+	// Create subscribers for the "orders" topic
+	orderLogger := NewLoggingSubscriber("order-logger")
+	inventoryService := NewSubscriber("inventory-service", func(msg *Message) {
+		fmt.Printf("  📦 [inventory-service] Processing order: %v\n", msg.Payload)
+	})
+
+	broker.Subscribe("orders", orderLogger)
+	broker.Subscribe("orders", inventoryService)
+
+	// Create subscribers for the "payments" topic
+	paymentLogger := NewLoggingSubscriber("payment-logger")
+	accountingService := NewSubscriber("accounting-service", func(msg *Message) {
+		fmt.Printf("  💰 [accounting-service] Recording payment: %v\n", msg.Payload)
+	})
+
+	broker.Subscribe("payments", paymentLogger)
+	broker.Subscribe("payments", accountingService)
+
+	// Create subscriber for the "notifications" topic
+	emailNotifier := NewEmailSubscriber("email-notifier", "admin@example.com")
+	broker.Subscribe("notifications", emailNotifier)
+
+	// Create analytics subscriber that listens to multiple topics
+	analyticsService := NewSubscriber("analytics-service", func(msg *Message) {
+		fmt.Printf("  📊 [analytics-service] Tracking: %v\n", msg.Payload)
+	})
+	broker.Subscribe("analytics", analyticsService)
+	broker.Subscribe("orders", analyticsService)   // Also track orders
+	broker.Subscribe("payments", analyticsService) // Also track payments
+
+	// Step 4: Publish messages
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📤 Publishing messages...")
+
+	// Publish an order event
+	fmt.Println("\n1️⃣ Order Event:")
+	broker.Publish("orders", map[string]interface{}{
+		"order_id": "ORD-001",
+		"customer": "John Doe",
+		"total":    99.99,
+	})
+	time.Sleep(100 * time.Millisecond) // Wait for async delivery to complete
+
+	// Publish a payment event
+	fmt.Println("\n2️⃣ Payment Event:")
+	broker.Publish("payments", map[string]interface{}{
+		"payment_id": "PAY-001",
+		"order_id":   "ORD-001",
+		"amount":     99.99,
+		"status":     "completed",
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	// Publish a notification event
+	fmt.Println("\n3️⃣ Notification Event:")
+	broker.Publish("notifications", "New user signup: jane@email.com")
+	time.Sleep(100 * time.Millisecond)
+
+	// Publish multiple orders
+	fmt.Println("\n4️⃣ Multiple Orders:")
+	for i := 2; i <= 4; i++ {
+		broker.Publish("orders", map[string]interface{}{
+			"order_id": fmt.Sprintf("ORD-00%d", i),
+			"total":    float64(i) * 50.0,
+		})
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	// Step 5: Demonstrate Message Queue (Point-to-Point)
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📬 Point-to-Point Queue Demo...")
+
+	// Create a task queue
+	taskQueue := NewMessageQueue("tasks", 10)
+
+	// Producer adds tasks to the queue
+	taskQueue.Enqueue("Task 1: Send email")
+	taskQueue.Enqueue("Task 2: Generate report")
+	taskQueue.Enqueue("Task 3: Cleanup logs")
+
+	fmt.Printf("Queue size: %d\n", taskQueue.Size())
+
+	// Consumer processes tasks one by one
+	// Each task is delivered to exactly ONE consumer
+	for taskQueue.Size() > 0 {
+		task := taskQueue.Dequeue()
+		if task != nil {
+			fmt.Printf("  ⚡ Processing: %v\n", task.Payload)
+		}
+	}
+
+	// Step 6: Demonstrate unsubscribing
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔕 Unsubscribing order-logger...")
+	broker.Unsubscribe("orders", "order-logger")
+
+	fmt.Println("\n5️⃣ Order after unsubscribe:")
+	broker.Publish("orders", map[string]interface{}{
+		"order_id": "ORD-005",
+		"note":     "Logger won't receive this",
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	// Step 7: Demonstrate topic-level access control
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔐 Access Control Demo...")
+
+	broker.CreateTopic("billing")
+	billingToken, _ := broker.RegisterClient("billing-team")
+	auditToken, _ := broker.RegisterClient("audit-team")
+	marketingToken, _ := broker.RegisterClient("marketing-team")
+
+	broker.GrantAccess("billing", "billing-team", PermissionAll)
+	broker.GrantAccess("billing", "audit-team", PermissionSubscribe)
+	fmt.Printf("  billing-team: %s, audit-team: %s, marketing-team: %s\n",
+		broker.GetPermissions("billing", "billing-team"),
+		broker.GetPermissions("billing", "audit-team"),
+		broker.GetPermissions("billing", "marketing-team"))
+
+	auditor := NewSubscriber("auditor", func(msg *Message) {
+		fmt.Printf("  🧾 [auditor] %v (from %s)\n", msg.Payload, msg.GetHeader(HeaderPublisher))
+	})
+	if err := broker.SubscribeAs(auditToken, "billing", auditor); err == nil {
+		fmt.Println("  ✅ audit-team subscribed to billing")
+	}
+
+	spy := NewLoggingSubscriber("marketing-spy")
+	if err := broker.SubscribeAs(marketingToken, "billing", spy); err != nil {
+		fmt.Printf("  ❌ marketing-team subscribe: %v\n", err)
+	}
+	if _, err := broker.PublishAs(auditToken, "billing", "Forged invoice"); errors.Is(err, ErrForbidden) {
+		fmt.Printf("  ❌ audit-team publish: %v\n", err)
+	}
+	if _, err := broker.PublishAs("stolen-token", "billing", "Forged invoice"); errors.Is(err, ErrUnauthenticated) {
+		fmt.Printf("  ❌ unknown token publish: %v\n", err)
+	}
+	if _, err := broker.Publish("billing", "Forged invoice"); errors.Is(err, ErrForbidden) {
+		fmt.Printf("  ❌ unauthenticated publish: %v\n", err)
+	}
+	if err := broker.Subscribe("billing", spy); errors.Is(err, ErrForbidden) {
+		fmt.Printf("  ❌ unauthenticated subscribe: %v\n", err)
+	}
+
+	fmt.Println("\n6️⃣ Authenticated publish:")
+	broker.PublishAs(billingToken, "billing", "Invoice INV-001 issued")
+	time.Sleep(100 * time.Millisecond)
+
+	fmt.Println("\n7️⃣ After revoking audit-team's subscribe access:")
+	broker.RevokeAccess("billing", "audit-team", PermissionSubscribe)
+	broker.PublishAs(billingToken, "billing", "Invoice INV-002 issued")
+	time.Sleep(100 * time.Millisecond)
+	fmt.Printf("  billing subscribers: %d\n", broker.GetTopic("billing").GetSubscriberCount())
+
+	// Step 8: Demonstrate message TTL and expiry
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("⏳ Message TTL Demo...")
+
+	priceTopic := broker.CreateTopic("price-ticks")
+	broker.Subscribe("price-ticks", NewLoggingSubscriber("ticker-display"))
+	broker.StartJanitor(50 * time.Millisecond)
+	defer broker.StopJanitor()
+
+	// A price tick is worthless after a short time
+	broker.PublishWithTTL("price-ticks", "AAPL 189.20", 150*time.Millisecond)
+	broker.Publish("price-ticks", "Market opens at 09:30") // No TTL: kept forever
+	time.Sleep(20 * time.Millisecond)
+
+	// Simulate a backlog: the tick's TTL has already elapsed when it is delivered
+	broker.PublishWithTTL("price-ticks", "AAPL 189.05 (stale)", time.Nanosecond)
+	time.Sleep(20 * time.Millisecond)
+
+	fmt.Printf("  History before janitor: %d messages\n", priceTopic.GetMessageCount())
+	time.Sleep(250 * time.Millisecond) // Let the janitor run past the 150ms TTL
+	fmt.Printf("  History after janitor:  %d messages\n", priceTopic.GetMessageCount())
+	fmt.Printf("  Expired: %d skipped at delivery, %d purged from history\n",
+		priceTopic.GetSkippedDeliveryCount(), priceTopic.GetPurgedCount())
+
+	// Step 9: Demonstrate priority scheduling with a slow subscriber
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🚦 Priority Scheduling Demo...")
+
+	alertsTopic := broker.CreateTopic("ops-events")
+	alertsTopic.SetDefaultPriority(PriorityLow) // Most ops events are bulk metrics
+	alertsTopic.EnablePriorityScheduling(DefaultPriorityWeights)
+
+	var orderMutex sync.Mutex
+	deliveryOrder := make([]string, 0)
+	gate := make(chan struct{})
+	slowConsumer := NewSubscriber("pager", func(msg *Message) {
+		<-gate // Blocks until the backlog has built up
+		orderMutex.Lock()
+		deliveryOrder = append(deliveryOrder, msg.Priority.String()[:1])
+		orderMutex.Unlock()
+	})
+	broker.Subscribe("ops-events", slowConsumer)
+
+	// The first metric occupies the consumer while everything else queues up
+	broker.Publish("ops-events", "cpu=41%")
+	time.Sleep(10 * time.Millisecond)
+	for i := 1; i <= 5; i++ {
+		broker.Publish("ops-events", fmt.Sprintf("cpu sample %d", i))
+		broker.PublishWithPriority("ops-events", fmt.Sprintf("deploy step %d", i), PriorityNormal)
+		broker.PublishWithPriority("ops-events", fmt.Sprintf("disk alert %d", i), PriorityHigh)
+	}
+	fmt.Printf("  Backlog: %d queued deliveries\n", alertsTopic.GetPendingDeliveries())
+	close(gate)
+	for alertsTopic.GetPendingDeliveries() > 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	orderMutex.Lock()
+	fmt.Printf("  Delivery order (h/n/l): %v\n", deliveryOrder)
+	orderMutex.Unlock()
+	fmt.Printf("  Delivered: high=%d normal=%d low=%d (low never starved)\n",
+		alertsTopic.GetDeliveredCount(PriorityHigh),
+		alertsTopic.GetDeliveredCount(PriorityNormal),
+		alertsTopic.GetDeliveredCount(PriorityLow))
+
+	// Step 10: Capture the broker and reproduce it on a fresh instance
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📸 Snapshot & Replay Demo...")
+
+	snapshot, err := broker.Snapshot()
+	if err != nil {
+		fmt.Printf("  ❌ snapshot failed: %v\n", err)
+		return
+	}
+	fmt.Printf("  Snapshot: %d bytes of JSON\n", len(snapshot))
+
+	// Only the inventory service is rebuilt; other registrations are reported
+	replayBroker := NewMessageBroker()
+	report, err := replayBroker.Restore(snapshot, func(topicName, subscriberID string) Subscriber {
+		if subscriberID != "inventory-service" {
+			return nil
+		}
+		return NewSubscriber(subscriberID, func(msg *Message) {
+			fmt.Printf("  🔁 [%s] %s %v\n", subscriberID, msg.ID, msg.Payload)
+		})
+	})
+	if err != nil {
+		fmt.Printf("  ❌ restore failed: %v\n", err)
+		return
+	}
+	fmt.Printf("  Restored %d topics, %d messages, %d subscribers (%d unresolved)\n",
+		report.Topics, report.Messages, report.Subscribers, len(report.Unresolved))
+
+	delivered, _ := replayBroker.Replay("orders")
+	fmt.Printf("  Replayed orders: %d deliveries\n", delivered)
+	if _, err := replayBroker.Restore(snapshot, nil); err != nil {
+		fmt.Printf("  ❌ second restore: %v\n", err)
+	}
+
+	// Step 11: Metrics hooks and trace propagation producer -> consumers
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔭 Instrumentation & Tracing Demo...")
+
+	tracedBroker := NewMessageBroker()
+	tracedBroker.CreateTopic("checkout")
+	metrics := NewMetricsRecorder()
+	tracedBroker.AddInstrumentation(metrics)
+
+	// A span log, like a tracing backend would collect
+	var spanMutex sync.Mutex
+	spanLog := make([]string, 0)
+	tracedBroker.AddInstrumentation(InstrumentationHooks{
+		Publish: func(event PublishEvent) {
+			spanMutex.Lock()
+			defer spanMutex.Unlock()
+			spanLog = append(spanLog, fmt.Sprintf("publish %s span=%s parent=%s",
+				event.Message.ID, event.Span.SpanID[:6], event.Span.ParentSpanID[:6]))
+		},
+		Ack: func(event DeliveryEvent) {
+			spanMutex.Lock()
+			defer spanMutex.Unlock()
+			spanLog = append(spanLog, fmt.Sprintf("ack     %s span=%s parent=%s by %s",
+				event.Message.ID, event.Span.SpanID[:6], event.Span.ParentSpanID[:6], event.SubscriberID))
+		},
+	})
+
+	for _, subscriberID := range []string{"fulfillment", "fraud-check"} {
+		processingTime := 5 * time.Millisecond
+		if subscriberID == "fraud-check" {
+			processingTime = 20 * time.Millisecond
+		}
+		tracedBroker.Subscribe("checkout", NewSubscriber(subscriberID, func(msg *Message) {
+			time.Sleep(processingTime)
+		}))
+	}
+
+	// The producer handles an HTTP request under its own span
+	requestSpan := NewRootSpan()
+	checkoutMessage := NewMessage("checkout", "cart C-42 paid")
+	InjectTrace(checkoutMessage, requestSpan)
+	tracedBroker.GetTopic("checkout").Publish(checkoutMessage)
+	time.Sleep(50 * time.Millisecond)
+
+	carried, _ := ExtractTrace(checkoutMessage)
+	fmt.Printf("  Request span %s, trace %s…\n", requestSpan.SpanID[:6], requestSpan.TraceID[:8])
+	fmt.Printf("  Same trace in message headers: %v\n", carried.TraceID == requestSpan.TraceID)
+	spanMutex.Lock()
+	sort.Strings(spanLog[1:]) // Acks arrive in any order
+	for _, line := range spanLog {
+		fmt.Printf("    %s\n", line)
+	}
+	spanMutex.Unlock()
+
+	checkoutMetrics := metrics.GetTopicMetrics("checkout")
+	fmt.Printf("  checkout: published=%d delivered=%d acked=%d, avg processing %v\n",
+		checkoutMetrics.Published, checkoutMetrics.Delivered, checkoutMetrics.Acked,
+		checkoutMetrics.AverageProcessingTime().Round(5*time.Millisecond))
+	if _, err := ParseTraceParent("00-not-a-trace"); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	// Step 12: Retained messages on state topics
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📌 Retained Messages Demo...")
+
+	stateBroker := NewMessageBroker()
+	stateBroker.CreateTopic("config")
+	stateBroker.CreateTopic("prices")
+	_ = stateBroker.SetRetainPolicy("config", RetainLast)
+	_ = stateBroker.SetRetainPolicy("prices", RetainPerKey)
+
+	stateBroker.Publish("config", "max_connections=100")
+	stateBroker.Publish("config", "max_connections=250") // Replaces the retained value
+	stateBroker.PublishKeyed("prices", "AAPL", 189.50)
+	stateBroker.PublishKeyed("prices", "GOOG", 141.20)
+	stateBroker.PublishKeyed("prices", "AAPL", 190.10) // Latest AAPL price wins
+
+	stateSubscriber := func(subscriberID string) Subscriber {
+		return NewSubscriber(subscriberID, func(msg *Message) {
+			label := msg.Topic
+			if key := msg.GetHeader(HeaderRetainKey); key != "" {
+				label += "/" + key
+			}
+			source := "live"
+			if msg.GetHeader(HeaderRetained) == "true" {
+				source = "retained"
+			}
+			fmt.Printf("  📥 [%s] %s = %v (%s)\n", subscriberID, label, msg.Payload, source)
+		})
+	}
+
+	// Late joiners get the current state the moment they subscribe
+	stateBroker.Subscribe("config", stateSubscriber("web-1"))
+	stateBroker.Subscribe("prices", stateSubscriber("ticker-ui"))
+
+	// A nil payload clears GOOG, so the next subscriber no longer sees it
+	stateBroker.PublishKeyed("prices", "GOOG", nil)
+	time.Sleep(20 * time.Millisecond)
+	stateBroker.Subscribe("prices", stateSubscriber("ticker-mobile"))
+
+	// The retain policy survives a snapshot; retained values are rebuilt
+	if stateSnapshot, err := stateBroker.Snapshot(); err == nil {
+		restoredState := NewMessageBroker()
+		if _, err := restoredState.Restore(stateSnapshot, nil); err == nil {
+			restoredState.Subscribe("config", stateSubscriber("web-2"))
+		}
+	}
+	if _, err := stateBroker.PublishKeyed("prices", "", 1.0); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	// Step 13: Schema registry and payload validation
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📐 Schema Registry Demo...")
+
+	schemaBroker := NewMessageBroker()
+	schemaBroker.CreateTopic("payments")
+	schemaBroker.CreateTopic("audit")
+	_ = schemaBroker.GetSchemaRegistry().SetCompatibility("payments", CompatibilityBackward)
+
+	paymentV1, _ := ParseJSONSchema(`{
+		"type": "object",
+		"required": ["id", "amount"],
+		"properties": {
+			"id": {"type": "string"},
+			"amount": {"type": "number"},
+			"currency": {"type": "string", "enum": ["USD", "EUR"]}
+		}
+	}`)
+	if version, err := schemaBroker.RegisterSchema("payments", paymentV1); err == nil {
+		fmt.Printf("  Registered payments v%d: %s\n", version.Version, version.Validator.Describe())
+	}
+	schemaBroker.Subscribe("payments", NewSubscriber("ledger", func(msg *Message) {
+		version, _ := SchemaVersionOf(msg)
+		fmt.Printf("  [ledger] v%d payment: %v\n", version, msg.Payload)
+	}))
+
+	// v2 may add optional fields, but a new required field would break
+	// consumers reading messages published under v1
+	paymentV2Breaking, _ := ParseJSONSchema(`{"type": "object", "required": ["id", "amount", "method"],
+		"properties": {"id": {"type": "string"}, "amount": {"type": "number"}, "method": {"type": "string"}}}`)
+	if _, err := schemaBroker.RegisterSchema("payments", paymentV2Breaking); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+	paymentV2, _ := ParseJSONSchema(`{"type": "object", "required": ["id", "amount"],
+		"properties": {"id": {"type": "string"}, "amount": {"type": "number"},
+			"currency": {"type": "string", "enum": ["USD", "EUR"]}, "method": {"type": "string"}}}`)
+	if version, err := schemaBroker.RegisterSchema("payments", paymentV2); err == nil {
+		fmt.Printf("  Registered payments v%d: %s\n", version.Version, version.Validator.Describe())
+	}
+
+	schemaBroker.Publish("payments", map[string]interface{}{"id": "PAY-1", "amount": 42.5, "method": "card"})
+	schemaBroker.PublishWithSchema("payments", 1, map[string]interface{}{"id": "PAY-2", "amount": 10})
+	for _, payload := range []interface{}{
+		map[string]interface{}{"id": "PAY-3"},
+		map[string]interface{}{"id": "PAY-4", "amount": "ten"},
+		map[string]interface{}{"id": "PAY-5", "amount": 5, "currency": "GBP"},
+	} {
+		if _, err := schemaBroker.Publish("payments", payload); errors.Is(err, ErrSchemaViolation) {
+			fmt.Printf("  ❌ Rejected: %v\n", err)
+		}
+	}
+
+	// A Go struct validator with an extra business rule
+	type AuditEntry struct {
+		Actor  string
+		Action string
+	}
+	_, _ = schemaBroker.RegisterSchema("audit", NewStructValidator(AuditEntry{}, func(payload interface{}) error {
+		if entry, ok := payload.(AuditEntry); ok && entry.Actor == "" {
+			return fmt.Errorf("actor is required")
+		}
+		return nil
+	}))
+	if _, err := schemaBroker.Publish("audit", AuditEntry{Actor: "alice", Action: "refund"}); err == nil {
+		fmt.Println("  ✅ Audit entry accepted (go main.AuditEntry)")
+	}
+	for _, payload := range []interface{}{"refund by alice", AuditEntry{Action: "refund"}} {
+		if _, err := schemaBroker.Publish("audit", payload); err != nil {
+			fmt.Printf("  ❌ Rejected: %v\n", err)
+		}
+	}
+	time.Sleep(50 * time.Millisecond) // Let async deliveries finish
+
+	// Step 14: Bridging brokers across processes
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🌉 Bridge Demo...")
+
+	// Two "processes" (US and EU regions) joined by a simulated network link,
+	// plus an in-process analytics broker that only receives
+	usBroker, euBroker, analyticsBroker := NewMessageBroker(), NewMessageBroker(), NewMessageBroker()
+	for _, regionBroker := range []*MessageBroker{usBroker, euBroker, analyticsBroker} {
+		regionBroker.CreateTopic("orders")
+	}
+	usEnd, euEnd := NewNetworkLink("us-east", "eu-west", 5*time.Millisecond)
+	usBridge := NewBridge("us-eu", usBroker, usEnd)
+	euBridge := NewBridge("eu-us", euBroker, euEnd)
+	analyticsBridge := NewBridge("us-analytics", usBroker, NewBrokerConnector("analytics", analyticsBroker))
+	_ = usBridge.Mirror("orders")
+	_ = euBridge.Mirror("orders")
+	_ = analyticsBridge.ForwardOut("orders")
+	if err := usBridge.ForwardIn("refunds"); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	for name, regionBroker := range map[string]*MessageBroker{"us": usBroker, "eu": euBroker, "analytics": analyticsBroker} {
+		region := name
+		regionBroker.Subscribe("orders", NewSubscriber(region+"-worker", func(msg *Message) {
+			path := msg.GetHeader(HeaderBridgePath)
+			if path == "" {
+				path = "local"
+			}
+			fmt.Printf("  [%s] %v (via %s)\n", region, msg.Payload, path)
+		}))
+	}
+
+	usBroker.Publish("orders", map[string]interface{}{"id": "ORD-US-1", "total": 120})
+	time.Sleep(50 * time.Millisecond)
+	euBroker.Publish("orders", map[string]interface{}{"id": "ORD-EU-1", "total": 80})
+	time.Sleep(50 * time.Millisecond)
+
+	// A partition: the US side cannot reach the EU, analytics still gets the order
+	usEnd.SetDown(true)
+	usBroker.Publish("orders", map[string]interface{}{"id": "ORD-US-2", "total": 45})
+	time.Sleep(50 * time.Millisecond)
+	usEnd.SetDown(false)
+
+	for _, bridge := range []*Bridge{usBridge, euBridge, analyticsBridge} {
+		stats := bridge.GetStats()
+		fmt.Printf("  Bridge %-12s forwarded=%d received=%d failed=%d loops dropped=%d\n",
+			bridge.GetID(), stats.Forwarded, stats.Received, stats.Failed, stats.LoopsDropped)
+		_ = bridge.Close()
+	}
+
+	// Step 15: At-least-once delivery with idempotent consumers
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔁 Consumer Deduplication Demo...")
+
+	dedupBroker := NewMessageBroker()
+	dedupBroker.CreateTopic("payments")
+	var charged atomic.Int64
+	ledger, _ := NewDedupSubscriber(NewSubscriber("ledger", func(msg *Message) {
+		charged.Add(1)
+		fmt.Printf("  💳 Charging %v (message %s)\n", msg.Payload, msg.ID)
+	}), 2)
+	_ = dedupBroker.Subscribe("payments", ledger)
+
+	// The producer's first publish "times out", so it retries with the same ID
+	for _, attempt := range []string{"pay-1001", "pay-1001", "pay-1002", "pay-1001"} {
+		if _, err := dedupBroker.PublishWithID("payments", attempt, "$25.00 for "+attempt); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if _, err := dedupBroker.PublishWithID("payments", "", "$10.00"); err != nil {
+		fmt.Printf("  ❌ Rejected: %v\n", err)
+	}
+
+	// A window of 2 IDs: once pay-1001 is evicted, a late redelivery gets through
+	for _, attempt := range []string{"pay-1003", "pay-1004", "pay-1001"} {
+		_, _ = dedupBroker.PublishWithID("payments", attempt, "$25.00 for "+attempt)
+		time.Sleep(20 * time.Millisecond)
+	}
+	dedupStats := ledger.GetStats()
+	fmt.Printf("  Charges: %d, processed=%d duplicates skipped=%d evicted=%d\n",
+		charged.Load(), dedupStats.Processed, dedupStats.Duplicates, dedupStats.Evicted)
+
+	// Demo: a panicking subscriber is isolated instead of crashing the process
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🛡️  Subscriber Failure Isolation Demo...")
+
+	isolationBroker := NewMessageBroker()
+	isolationBroker.CreateTopic("invoices")
+	var failureLog sync.Mutex
+	isolationBroker.AddFailureHandler(FailureHooks{
+		Error: func(failure DeliveryFailure) {
+			failureLog.Lock()
+			defer failureLog.Unlock()
+			fmt.Printf("  ⚠️  OnError %s on %s: %v\n", failure.SubscriberID, failure.Message.ID, failure.Err)
+		},
+		Panic: func(failure DeliveryFailure) {
+			failureLog.Lock()
+			defer failureLog.Unlock()
+			fmt.Printf("  💥 OnPanic %s on %s (%d in a row, quarantined=%v): %v\n",
+				failure.SubscriberID, failure.Message.ID, failure.Consecutive, failure.Quarantined, failure.PanicValue)
+		},
+	})
+
+	var mailed atomic.Int32
+	pdfBroken := atomic.Bool{}
+	pdfBroken.Store(true)
+	_ = isolationBroker.Subscribe("invoices", NewSubscriber("pdf-renderer", func(msg *Message) {
+		if pdfBroken.Load() {
+			var template map[string]string
+			template["header"] = msg.Payload.(string) // nil map write: panics
+		}
+	}))
+	_ = isolationBroker.Subscribe("invoices", NewFallibleSubscriber("mailer", func(msg *Message) error {
+		if msg.Payload == "INV-2" {
+			return errors.New("SMTP 421: try again later")
+		}
+		mailed.Add(1)
+		return nil
+	}))
+
+	for _, invoice := range []string{"INV-1", "INV-2", "INV-3", "INV-4"} {
+		_, _ = isolationBroker.Publish("invoices", invoice)
+		time.Sleep(20 * time.Millisecond) // One at a time so the streak is easy to follow
+	}
+	invoices := isolationBroker.GetTopic("invoices")
+	fmt.Printf("  Process still running: mailer sent %d, failed deliveries=%d, subscribers left=%d\n",
+		mailed.Load(), invoices.GetFailedDeliveryCount(), invoices.GetSubscriberCount())
+	for _, parked := range isolationBroker.GetQuarantined() {
+		fmt.Printf("  Quarantined: %s on %s after %d panics (last: %s)\n",
+			parked.Subscriber.GetID(), parked.Topic, parked.Panics, parked.LastPanic)
+	}
+
+	// After the fix ships, release it back onto the topic
+	pdfBroken.Store(false)
+	if err := isolationBroker.ReleaseQuarantined("invoices", "pdf-renderer"); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+	_, _ = isolationBroker.Publish("invoices", "INV-5")
+	time.Sleep(20 * time.Millisecond)
+	fmt.Printf("  Released: subscribers=%d, failed deliveries=%d, quarantined=%d\n",
+		invoices.GetSubscriberCount(), invoices.GetFailedDeliveryCount(), len(isolationBroker.GetQuarantined()))
+
+	// Summary of design decisions
+	fmt.Println("\n═══════════════════════════════════════════")
+	fmt.Println("  KEY DESIGN DECISIONS:")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  1. Topic-based pub-sub (fan-out to all subscribers)")
+	fmt.Println("  2. Message Queue for point-to-point (one consumer)")
+	fmt.Println("  3. Async delivery via goroutines (non-blocking)")
+	fmt.Println("  4. Subscriber interface for flexibility")
+	fmt.Println("  5. Thread-safe operations using mutex/atomic")
+	fmt.Println("  6. Token auth + per-topic ACLs for tenant isolation")
+	fmt.Println("  7. Message TTL: checked at delivery, janitor purges history")
+	fmt.Println("  8. Priority queues per subscriber, weighted round robin vs starvation")
+	fmt.Println("  9. JSON snapshots + factory-based restore for deterministic replay")
+	fmt.Println("  10. Publish/deliver/ack hooks; W3C traceparent carried in headers")
+	fmt.Println("  11. Retained last value per topic/key, replayed to new subscribers")
+	fmt.Println("  12. Versioned schemas per topic; publish rejects non-conforming payloads")
+	fmt.Println("  13. Bridges fan out across processes; bridge-path header stops echo loops")
+	fmt.Println("  14. Producer message IDs + per-subscriber LRU dedup for idempotent consumers")
+	fmt.Println("  15. Per-delivery panic recovery, OnError/OnPanic callbacks, quarantine on repeat panics")
+	fmt.Println("═══════════════════════════════════════════")
+}
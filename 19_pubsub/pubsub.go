@@ -0,0 +1,3547 @@
+// Package pubsub is an in-memory message broker: topics with fan-out
+// delivery, message queues, access control and delivery guarantees.
+package pubsub
+
+import (
+	"container/list"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ayushgupta5/GoLLD/pkg/periodic"
+)
+
+// ============================================================
+// PUB-SUB / MESSAGE QUEUE SYSTEM - Low Level Design
+// ============================================================
+//
+// What is Pub-Sub?
+// - Publishers send messages to topics
+// - Subscribers listen to topics and receive all messages
+// - One message can be delivered to MULTIPLE subscribers (fan-out)
+//
+// What is a Message Queue?
+// - Producers add messages to a queue
+// - Consumers take messages from the queue
+// - Each message is delivered to only ONE consumer (point-to-point)
+//
+// Design Patterns Used:
+// - Observer Pattern: Subscribers observe topics for new messages
+// - Strategy Pattern: Different subscriber types handle messages differently
+// - Producer-Consumer: Queue-based message processing
+// - Access Control List: Per-topic publish/subscribe permissions per client
+// - Weighted Round Robin: Priority-aware delivery queues per subscriber
+// - Memento Pattern: Broker snapshots to JSON for restore and replay
+// - Instrumentation Hooks: publish/deliver/ack callbacks with trace propagation
+// - Retained Messages: last value (per topic or per key) sent to new subscribers
+// - Schema Registry: versioned per-topic schemas validated on publish
+// - Bridge: pluggable connectors forward topics to other brokers/processes
+// - Idempotent Consumer: producer-supplied IDs, bounded LRU dedup per subscriber
+// - Bulkhead: subscriber panics recovered, reported with the message, repeat offenders quarantined
+//
+// ============================================================
+
+// ========== MESSAGE ==========
+// Message represents a single unit of data in the pub-sub system.
+// It contains the content (payload) and metadata (id, topic, timestamp, headers).
+
+type Message struct {
+	ID        string            // Unique identifier for the message
+	Topic     string            // The topic this message belongs to
+	Payload   interface{}       // The actual content (can be any type)
+	Timestamp time.Time         // When the message was created
+	ExpiresAt time.Time         // When the message stops being useful (zero = never)
+	Priority  MessagePriority   // Delivery priority (PriorityDefault = inherit from topic)
+	Headers   map[string]string // Optional key-value metadata
+}
+
+// MessagePriority orders messages for delivery when subscribers fall behind.
+type MessagePriority int
+
+const (
+	PriorityDefault MessagePriority = iota // Inherit the topic's default priority
+	PriorityLow                            // Bulk work: analytics, digests
+	PriorityNormal                         // Regular traffic
+	PriorityHigh                           // Urgent: alerts, payments
+)
+
+// priorityLevels is the number of concrete priorities (Low, Normal, High).
+const priorityLevels = 3
+
+// String returns the priority name.
+func (p MessagePriority) String() string {
+	names := []string{"default", "low", "normal", "high"}
+	if p >= 0 && int(p) < len(names) {
+		return names[p]
+	}
+	return "unknown"
+}
+
+// isConcrete reports whether the priority is Low, Normal or High.
+func (p MessagePriority) isConcrete() bool {
+	return p >= PriorityLow && p <= PriorityHigh
+}
+
+// level maps a concrete priority to a queue index (0 = Low).
+func (p MessagePriority) level() int {
+	return int(p - PriorityLow)
+}
+
+// messageCounter is used to generate unique message IDs.
+// We use atomic.Int64 for thread-safety when multiple goroutines create messages.
+var messageCounter atomic.Int64
+
+// NewMessage creates a new message with a unique ID and the current timestamp.
+// Parameters:
+//   - topic: The topic name this message is for
+//   - payload: The actual message content
+func NewMessage(topic string, payload interface{}) *Message {
+	// Atomically increment counter to ensure unique IDs even with concurrent access
+	newID := messageCounter.Add(1)
+
+	return &Message{
+		ID:        fmt.Sprintf("MSG-%d", newID),
+		Topic:     topic,
+		Payload:   payload,
+		Timestamp: time.Now(),
+		Headers:   make(map[string]string),
+	}
+}
+
+// NewMessageWithTTL creates a message that expires ttl after creation.
+// Expired messages are not delivered and are purged from topic history.
+func NewMessageWithTTL(topic string, payload interface{}, ttl time.Duration) *Message {
+	message := NewMessage(topic, payload)
+	message.ExpiresAt = message.Timestamp.Add(ttl)
+	return message
+}
+
+// IsExpired checks whether the message's TTL has passed at the given time.
+// Messages without a TTL never expire.
+func (m *Message) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+// SetHeader adds a custom header to the message.
+// Headers are useful for passing metadata like priority, source, etc.
+func (m *Message) SetHeader(key, value string) {
+	m.Headers[key] = value
+}
+
+// GetHeader retrieves a header value by key.
+// Returns empty string if the header doesn't exist.
+func (m *Message) GetHeader(key string) string {
+	return m.Headers[key]
+}
+
+// String returns a human-readable representation of the message.
+func (m *Message) String() string {
+	return fmt.Sprintf("[%s] %s: %v", m.ID, m.Topic, m.Payload)
+}
+
+// ========== SUBSCRIBER INTERFACE ==========
+// Subscriber defines what any message receiver must implement.
+// This allows us to create different types of subscribers (logger, email, etc.)
+
+type Subscriber interface {
+	// GetID returns the unique identifier of this subscriber
+	GetID() string
+
+	// OnMessage is called when a new message arrives
+	// Each subscriber decides what to do with the message
+	OnMessage(msg *Message)
+}
+
+// ========== BASE SUBSCRIBER ==========
+// BaseSubscriber is a simple implementation of the Subscriber interface.
+// It uses a handler function to process messages, making it flexible.
+
+type BaseSubscriber struct {
+	id      string         // Unique identifier for this subscriber
+	handler func(*Message) // Custom function to handle incoming messages
+}
+
+// NewSubscriber creates a new subscriber with a custom message handler.
+// Parameters:
+//   - id: A unique identifier for this subscriber
+//   - handler: A function that will be called for each received message
+func NewSubscriber(id string, handler func(*Message)) *BaseSubscriber {
+	return &BaseSubscriber{
+		id:      id,
+		handler: handler,
+	}
+}
+
+// GetID returns the subscriber's unique identifier.
+func (s *BaseSubscriber) GetID() string {
+	return s.id
+}
+
+// OnMessage processes an incoming message using the handler function.
+func (s *BaseSubscriber) OnMessage(msg *Message) {
+	if s.handler != nil {
+		s.handler(msg)
+	}
+}
+
+// ========== TOPIC ==========
+// Topic is a channel that publishers write to and subscribers read from.
+// All subscribers to a topic receive ALL messages published to it.
+
+type Topic struct {
+	name        string                // Name of the topic (e.g., "orders", "payments")
+	subscribers map[string]Subscriber // Map of subscriber ID to subscriber
+	messages    []*Message            // History of all messages (for persistence)
+	mutex       sync.RWMutex          // Protects concurrent access to subscribers and messages
+
+	// Expiry counters for observability
+	skippedDeliveries atomic.Int64 // Deliveries dropped because the message had expired
+	purgedMessages    atomic.Int64 // Expired messages removed from history
+	failedDeliveries  atomic.Int64 // Deliveries that panicked or returned an error
+
+	// Priority scheduling (see PRIORITY SCHEDULING below)
+	defaultPriority     MessagePriority              // Applied to messages with PriorityDefault
+	weights             *PriorityWeights             // nil = deliver immediately via goroutines
+	deliveryQueues      map[string]*weightedQueue    // Subscriber ID -> queue (scheduled mode only)
+	deliveredByPriority [priorityLevels]atomic.Int64 // Successful deliveries per priority
+
+	// Retained messages (see RETAINED MESSAGES below)
+	retainPolicy RetainPolicy        // What to keep for new subscribers
+	retained     map[string]*Message // Retain key -> latest message ("" under RetainLast)
+
+	telemetry  *telemetry  // Broker-wide instrumentation (nil for standalone topics)
+	supervisor *supervisor // Broker-wide failure handling (nil for standalone topics)
+}
+
+// NewTopic creates a new topic with the given name.
+func NewTopic(name string) *Topic {
+	return &Topic{
+		name:            name,
+		subscribers:     make(map[string]Subscriber),
+		messages:        make([]*Message, 0),
+		defaultPriority: PriorityNormal,
+		deliveryQueues:  make(map[string]*weightedQueue),
+		retained:        make(map[string]*Message),
+	}
+}
+
+// GetName returns the topic's name.
+func (t *Topic) GetName() string {
+	return t.name
+}
+
+// Subscribe adds a subscriber to this topic.
+// The subscriber will receive all future messages published to this topic,
+// preceded by the topic's retained messages (if it retains any).
+func (t *Topic) Subscribe(subscriber Subscriber) {
+	t.mutex.Lock()
+	subscriberID := subscriber.GetID()
+	t.subscribers[subscriberID] = subscriber
+	retained := t.retainedLocked(time.Now())
+
+	// Scheduled mode: retained messages go first in the new queue, ahead
+	// of anything published after this point
+	if t.weights != nil {
+		t.startQueueLocked(subscriber)
+		for _, message := range retained {
+			t.deliveryQueues[subscriberID].push(retainedCopy(message))
+		}
+		t.mutex.Unlock()
+		return
+	}
+	t.mutex.Unlock()
+
+	// Immediate mode: hand them over synchronously, oldest first, to the
+	// new subscriber only
+	for _, message := range retained {
+		t.deliver(subscriber, retainedCopy(message))
+	}
+}
+
+// Unsubscribe removes a subscriber from this topic.
+// The subscriber will no longer receive messages from this topic.
+func (t *Topic) Unsubscribe(subscriberID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.subscribers, subscriberID)
+	if queue, exists := t.deliveryQueues[subscriberID]; exists {
+		queue.close()
+		delete(t.deliveryQueues, subscriberID)
+	}
+}
+
+// Publish sends a message to all subscribers of this topic.
+// Messages are delivered asynchronously using goroutines.
+func (t *Topic) Publish(msg *Message) {
+	// Trace headers must be written before any delivery goroutine sees msg
+	t.telemetry.publish(msg)
+
+	// Lock to safely read subscribers and store message
+	t.mutex.Lock()
+	if !msg.Priority.isConcrete() {
+		msg.Priority = t.defaultPriority
+	}
+	t.messages = append(t.messages, msg)
+	t.retainLocked(msg)
+
+	// Scheduled mode: queue per subscriber, drained by weighted round robin
+	if t.weights != nil {
+		for _, queue := range t.deliveryQueues {
+			queue.push(msg)
+		}
+		t.mutex.Unlock()
+		return
+	}
+
+	// Copy subscribers to a slice to avoid holding the lock during delivery
+	// This prevents deadlocks if a subscriber tries to unsubscribe during delivery
+	subscriberList := make([]Subscriber, 0, len(t.subscribers))
+	for _, subscriber := range t.subscribers {
+		subscriberList = append(subscriberList, subscriber)
+	}
+	t.mutex.Unlock()
+
+	// Deliver message to each subscriber asynchronously
+	// Using goroutines ensures fast publishers aren't blocked by slow subscribers
+	for _, subscriber := range subscriberList {
+		go t.deliver(subscriber, msg)
+	}
+}
+
+// deliver hands a message to one subscriber unless it expired on the way.
+// The TTL is checked at delivery time, not publish time, because delivery
+// goroutines may be delayed under load.
+func (t *Topic) deliver(subscriber Subscriber, msg *Message) {
+	if msg.IsExpired(time.Now()) {
+		t.skippedDeliveries.Add(1)
+		return
+	}
+	if !t.invoke(subscriber, msg) {
+		return // Recovered panic or returned error (see SUBSCRIBER FAILURE ISOLATION)
+	}
+	if msg.Priority.isConcrete() {
+		t.deliveredByPriority[msg.Priority.level()].Add(1)
+	}
+}
+
+// PurgeExpired removes expired messages from the topic history.
+// Returns the number of messages removed.
+func (t *Topic) PurgeExpired(now time.Time) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	liveMessages := make([]*Message, 0, len(t.messages))
+	for _, message := range t.messages {
+		if !message.IsExpired(now) {
+			liveMessages = append(liveMessages, message)
+		}
+	}
+
+	purgedCount := len(t.messages) - len(liveMessages)
+	t.messages = liveMessages
+	for key, message := range t.retained {
+		if message.IsExpired(now) {
+			delete(t.retained, key)
+		}
+	}
+	t.purgedMessages.Add(int64(purgedCount))
+	return purgedCount
+}
+
+// GetSkippedDeliveryCount returns how many deliveries were dropped due to expiry.
+func (t *Topic) GetSkippedDeliveryCount() int64 {
+	return t.skippedDeliveries.Load()
+}
+
+// GetPurgedCount returns how many expired messages were purged from history.
+func (t *Topic) GetPurgedCount() int64 {
+	return t.purgedMessages.Load()
+}
+
+// GetSubscriberCount returns the number of active subscribers.
+func (t *Topic) GetSubscriberCount() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return len(t.subscribers)
+}
+
+// GetMessageCount returns the total number of messages published to this topic.
+func (t *Topic) GetMessageCount() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return len(t.messages)
+}
+
+// ========== PRIORITY SCHEDULING ==========
+// By default a topic hands every message to a new goroutine, so a slow
+// subscriber sees messages in whatever order the goroutines run.
+//
+// With priority scheduling enabled, each subscriber gets its own queue
+// with one FIFO per priority, drained by a single worker. The worker uses
+// weighted round robin: per round it takes up to Weight(High) high, then
+// Weight(Normal) normal, then Weight(Low) low messages. High priority
+// drains first under backlog, but low priority still gets a guaranteed
+// share of every round, so it never starves.
+
+// PriorityWeights sets how many messages of each priority one round may deliver.
+type PriorityWeights struct {
+	High   int
+	Normal int
+	Low    int
+}
+
+// DefaultPriorityWeights delivers up to 4 high, 2 normal and 1 low per round.
+var DefaultPriorityWeights = PriorityWeights{High: 4, Normal: 2, Low: 1}
+
+// byLevel returns the weights indexed by priority level (0 = Low).
+func (w PriorityWeights) byLevel() [priorityLevels]int {
+	return [priorityLevels]int{w.Low, w.Normal, w.High}
+}
+
+// validate checks that every priority gets a positive share.
+func (w PriorityWeights) validate() error {
+	if w.High <= 0 || w.Normal <= 0 || w.Low <= 0 {
+		return fmt.Errorf("priority weights must be positive, got %+v", w)
+	}
+	return nil
+}
+
+// weightedQueue holds one subscriber's pending messages, one FIFO per priority.
+type weightedQueue struct {
+	queues  [priorityLevels][]*Message // Pending messages by level
+	weights [priorityLevels]int        // Messages per round by level
+	credits [priorityLevels]int        // Messages left in the current round
+	closed  bool                       // Set when the subscriber goes away
+	mutex   sync.Mutex
+	ready   *sync.Cond // Signalled on push and close
+}
+
+// newWeightedQueue creates an empty queue with the given weights.
+func newWeightedQueue(weights PriorityWeights) *weightedQueue {
+	queue := &weightedQueue{weights: weights.byLevel()}
+	queue.credits = queue.weights
+	queue.ready = sync.NewCond(&queue.mutex)
+	return queue
+}
+
+// push adds a message behind others of the same priority.
+func (q *weightedQueue) push(msg *Message) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.closed {
+		return
+	}
+	level := msg.Priority.level()
+	q.queues[level] = append(q.queues[level], msg)
+	q.ready.Signal()
+}
+
+// pop blocks until a message is available and returns it.
+// Returns false once the queue is closed.
+func (q *weightedQueue) pop() (*Message, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for {
+		if q.closed {
+			return nil, false
+		}
+		if msg := q.nextLocked(); msg != nil {
+			return msg, true
+		}
+		q.ready.Wait()
+	}
+}
+
+// nextLocked picks the next message by weighted round robin.
+// A priority with no pending messages forfeits the rest of its share, and a
+// new round starts once no waiting priority has credit left.
+// Caller must hold q.mutex.
+func (q *weightedQueue) nextLocked() *Message {
+	for attempt := 0; attempt < 2; attempt++ {
+		for level := priorityLevels - 1; level >= 0; level-- {
+			if q.credits[level] > 0 && len(q.queues[level]) > 0 {
+				msg := q.queues[level][0]
+				q.queues[level][0] = nil
+				q.queues[level] = q.queues[level][1:]
+				q.credits[level]--
+				return msg
+			}
+		}
+		q.credits = q.weights // Start a new round
+	}
+	return nil
+}
+
+// pending returns how many messages are waiting.
+func (q *weightedQueue) pending() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	total := 0
+	for _, queue := range q.queues {
+		total += len(queue)
+	}
+	return total
+}
+
+// close stops the worker and drops pending messages.
+func (q *weightedQueue) close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.closed = true
+	q.queues = [priorityLevels][]*Message{}
+	q.ready.Broadcast()
+}
+
+// SetDefaultPriority sets the priority given to messages published
+// without one. Topics start at PriorityNormal.
+func (t *Topic) SetDefaultPriority(priority MessagePriority) error {
+	if !priority.isConcrete() {
+		return fmt.Errorf("invalid default priority: %s", priority)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.defaultPriority = priority
+	return nil
+}
+
+// GetDefaultPriority returns the priority given to messages published without one.
+func (t *Topic) GetDefaultPriority() MessagePriority {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.defaultPriority
+}
+
+// EnablePriorityScheduling switches the topic to per-subscriber weighted
+// queues. Existing and future subscribers each get a delivery worker.
+func (t *Topic) EnablePriorityScheduling(weights PriorityWeights) error {
+	if err := weights.validate(); err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.weights != nil {
+		return fmt.Errorf("priority scheduling already enabled on topic %s", t.name)
+	}
+	t.weights = &weights
+	for _, subscriber := range t.subscribers {
+		t.startQueueLocked(subscriber)
+	}
+	return nil
+}
+
+// startQueueLocked creates the subscriber's queue and its delivery worker,
+// replacing any previous queue for the same subscriber ID.
+// Caller must hold t.mutex.
+func (t *Topic) startQueueLocked(subscriber Subscriber) {
+	if previous, exists := t.deliveryQueues[subscriber.GetID()]; exists {
+		previous.close()
+	}
+	queue := newWeightedQueue(*t.weights)
+	t.deliveryQueues[subscriber.GetID()] = queue
+
+	go func() {
+		for {
+			msg, ok := queue.pop()
+			if !ok {
+				return
+			}
+			t.deliver(subscriber, msg)
+		}
+	}()
+}
+
+// GetPendingDeliveries returns how many scheduled deliveries are queued
+// across all subscribers (always 0 when scheduling is disabled).
+func (t *Topic) GetPendingDeliveries() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	total := 0
+	for _, queue := range t.deliveryQueues {
+		total += queue.pending()
+	}
+	return total
+}
+
+// GetDeliveredCount returns how many deliveries of the given priority succeeded.
+func (t *Topic) GetDeliveredCount(priority MessagePriority) int64 {
+	if !priority.isConcrete() {
+		return 0
+	}
+	return t.deliveredByPriority[priority.level()].Load()
+}
+
+// ========== RETAINED MESSAGES ==========
+// A state topic ("current config", "latest price") is useless to a late
+// subscriber if it has to wait for the next change. Like MQTT's retain flag,
+// a topic can keep the most recent message and hand it to every new
+// subscriber the moment it subscribes:
+//   - RetainLast keeps one message per topic
+//   - RetainPerKey keeps the last message per HeaderRetainKey value, e.g.
+//     the latest price of every ticker on a "prices" topic
+//
+// Publishing a nil payload clears the retained message for its key (it is
+// still delivered to current subscribers). Retained copies carry
+// HeaderRetained so subscribers can tell them from live traffic, and expired
+// messages are never handed out.
+
+// RetainPolicy controls which messages a topic keeps for new subscribers.
+type RetainPolicy int
+
+const (
+	RetainNone   RetainPolicy = iota // New subscribers only see future messages
+	RetainLast                       // Keep the most recent message
+	RetainPerKey                     // Keep the most recent message per retain key
+)
+
+// String returns the policy name.
+func (p RetainPolicy) String() string {
+	names := []string{"none", "last", "per-key"}
+	if p >= 0 && int(p) < len(names) {
+		return names[p]
+	}
+	return "unknown"
+}
+
+const (
+	// HeaderRetainKey names the retain slot under RetainPerKey
+	HeaderRetainKey = "retain-key"
+	// HeaderRetained is set to "true" on retained messages sent on subscribe
+	HeaderRetained = "retained"
+)
+
+// parseRetainPolicy is the inverse of RetainPolicy.String.
+func parseRetainPolicy(name string) (RetainPolicy, error) {
+	for p := RetainNone; p <= RetainPerKey; p++ {
+		if p.String() == name {
+			return p, nil
+		}
+	}
+	return RetainNone, fmt.Errorf("unknown retain policy: %q", name)
+}
+
+// SetRetainPolicy changes what the topic retains. Switching policy drops
+// whatever was retained under the old one.
+func (t *Topic) SetRetainPolicy(policy RetainPolicy) error {
+	if policy < RetainNone || policy > RetainPerKey {
+		return fmt.Errorf("invalid retain policy: %d", policy)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if policy != t.retainPolicy {
+		t.retained = make(map[string]*Message)
+	}
+	t.retainPolicy = policy
+	return nil
+}
+
+// GetRetainPolicy returns the topic's retain policy.
+func (t *Topic) GetRetainPolicy() RetainPolicy {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.retainPolicy
+}
+
+// retainKey returns the slot a message is retained under.
+// Caller must hold t.mutex.
+func (t *Topic) retainKey(msg *Message) string {
+	if t.retainPolicy == RetainPerKey {
+		return msg.GetHeader(HeaderRetainKey)
+	}
+	return ""
+}
+
+// retainLocked stores msg as the latest value of its slot, or clears the
+// slot if the payload is nil.
+// Caller must hold t.mutex.
+func (t *Topic) retainLocked(msg *Message) {
+	if t.retainPolicy == RetainNone {
+		return
+	}
+	key := t.retainKey(msg)
+	if msg.Payload == nil {
+		delete(t.retained, key)
+		return
+	}
+	t.retained[key] = msg
+}
+
+// retainedLocked returns the unexpired retained messages, oldest first.
+// Caller must hold t.mutex (read lock is enough).
+func (t *Topic) retainedLocked(now time.Time) []*Message {
+	messages := make([]*Message, 0, len(t.retained))
+	for _, message := range t.retained {
+		if !message.IsExpired(now) {
+			messages = append(messages, message)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+	return messages
+}
+
+// GetRetained returns the topic's current retained messages, oldest first.
+func (t *Topic) GetRetained() []*Message {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.retainedLocked(time.Now())
+}
+
+// ClearRetained forgets the retained message for a key ("" under RetainLast).
+func (t *Topic) ClearRetained(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.retained, key)
+}
+
+// retainedCopy returns a copy of msg flagged with HeaderRetained, so the
+// flag never leaks into the original delivered to live subscribers.
+func retainedCopy(msg *Message) *Message {
+	copied := *msg
+	copied.Headers = make(map[string]string, len(msg.Headers)+1)
+	for key, value := range msg.Headers {
+		copied.Headers[key] = value
+	}
+	copied.Headers[HeaderRetained] = "true"
+	return &copied
+}
+
+// ========== ACCESS CONTROL ==========
+// In a multi-tenant broker, one team must not be able to read another
+// team's topics or inject messages into them. Every producer/consumer
+// registers as a Client and receives a secret token; each topic keeps an
+// ACL mapping client IDs to the operations they are allowed to perform.
+
+// Permission is a bit flag describing what a client may do on a topic.
+type Permission int
+
+const (
+	PermissionPublish   Permission = 1 << iota // Client may publish to the topic
+	PermissionSubscribe                        // Client may subscribe to the topic
+)
+
+// PermissionAll grants both publish and subscribe.
+const PermissionAll = PermissionPublish | PermissionSubscribe
+
+// String returns a readable form such as "publish|subscribe".
+func (p Permission) String() string {
+	switch p {
+	case PermissionPublish:
+		return "publish"
+	case PermissionSubscribe:
+		return "subscribe"
+	case PermissionAll:
+		return "publish|subscribe"
+	default:
+		return "none"
+	}
+}
+
+// Errors returned by authenticated broker operations.
+// Callers can distinguish them with errors.Is.
+var (
+	ErrUnauthenticated = errors.New("invalid or unknown client token")
+	ErrForbidden       = errors.New("client is not permitted on topic")
+)
+
+// Client is a registered producer or consumer.
+type Client struct {
+	ID    string // Unique identifier (e.g., "billing-team")
+	token string // Secret presented on every authenticated call
+}
+
+// AccessControl stores registered clients and per-topic permissions.
+type AccessControl struct {
+	clientsByToken map[string]*Client               // token -> client (authentication)
+	clientsByID    map[string]*Client               // client ID -> client
+	topicACLs      map[string]map[string]Permission // topic -> client ID -> permissions
+	mutex          sync.RWMutex                     // Protects all maps above
+}
+
+// NewAccessControl creates an empty access controller.
+func NewAccessControl() *AccessControl {
+	return &AccessControl{
+		clientsByToken: make(map[string]*Client),
+		clientsByID:    make(map[string]*Client),
+		topicACLs:      make(map[string]map[string]Permission),
+	}
+}
+
+// generateToken returns a random 128-bit hex token.
+func generateToken() (string, error) {
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+// RegisterClient registers a new client and returns its secret token.
+// Returns an error if the client ID is already registered.
+func (ac *AccessControl) RegisterClient(clientID string) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+
+	if _, exists := ac.clientsByID[clientID]; exists {
+		return "", fmt.Errorf("client already registered: %s", clientID)
+	}
+
+	client := &Client{ID: clientID, token: token}
+	ac.clientsByID[clientID] = client
+	ac.clientsByToken[token] = client
+	return token, nil
+}
+
+// Authenticate resolves a token to its client.
+func (ac *AccessControl) Authenticate(token string) (*Client, error) {
+	ac.mutex.RLock()
+	defer ac.mutex.RUnlock()
+
+	client, exists := ac.clientsByToken[token]
+	if !exists {
+		return nil, ErrUnauthenticated
+	}
+	return client, nil
+}
+
+// Grant adds the given permissions for a client on a topic.
+func (ac *AccessControl) Grant(topicName, clientID string, permission Permission) error {
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+
+	if _, exists := ac.clientsByID[clientID]; !exists {
+		return fmt.Errorf("client not registered: %s", clientID)
+	}
+
+	acl, exists := ac.topicACLs[topicName]
+	if !exists {
+		acl = make(map[string]Permission)
+		ac.topicACLs[topicName] = acl
+	}
+	acl[clientID] |= permission
+	return nil
+}
+
+// Revoke removes the given permissions for a client on a topic.
+func (ac *AccessControl) Revoke(topicName, clientID string, permission Permission) {
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+
+	acl, exists := ac.topicACLs[topicName]
+	if !exists {
+		return
+	}
+	acl[clientID] &^= permission
+	if acl[clientID] == 0 {
+		delete(acl, clientID)
+	}
+}
+
+// IsProtected reports whether a topic has ever been granted to a client.
+// A protected topic stays protected after its grants are revoked.
+func (ac *AccessControl) IsProtected(topicName string) bool {
+	ac.mutex.RLock()
+	defer ac.mutex.RUnlock()
+
+	_, protected := ac.topicACLs[topicName]
+	return protected
+}
+
+// IsAllowed reports whether a client holds a permission on a topic.
+func (ac *AccessControl) IsAllowed(topicName, clientID string, permission Permission) bool {
+	ac.mutex.RLock()
+	defer ac.mutex.RUnlock()
+
+	return ac.topicACLs[topicName][clientID]&permission == permission
+}
+
+// GetPermissions returns a client's permissions on a topic.
+func (ac *AccessControl) GetPermissions(topicName, clientID string) Permission {
+	ac.mutex.RLock()
+	defer ac.mutex.RUnlock()
+
+	return ac.topicACLs[topicName][clientID]
+}
+
+// authorize authenticates the token and checks the permission in one step.
+func (ac *AccessControl) authorize(token, topicName string, permission Permission) (*Client, error) {
+	client, err := ac.Authenticate(token)
+	if err != nil {
+		return nil, err
+	}
+	if !ac.IsAllowed(topicName, client.ID, permission) {
+		return nil, fmt.Errorf("%w: %s needs %s on %s", ErrForbidden, client.ID, permission, topicName)
+	}
+	return client, nil
+}
+
+// ========== MESSAGE BROKER ==========
+// MessageBroker is the central hub that manages topics and routes messages.
+// Publishers and subscribers interact with the broker instead of topics directly.
+
+type MessageBroker struct {
+	topics map[string]*Topic // Map of topic name to topic
+	mutex  sync.RWMutex      // Protects concurrent access to topics map
+
+	// Purges expired messages in the background
+	janitor periodic.Job
+
+	// Access control for the authenticated APIs (PublishAs/SubscribeAs)
+	acl *AccessControl
+	// topic -> client ID -> subscriber IDs created via SubscribeAs,
+	// so revoking subscribe access can also drop live subscriptions
+	clientSubscriptions map[string]map[string][]string
+
+	// Instrumentation shared with every topic (see INSTRUMENTATION & TRACING)
+	telemetry *telemetry
+
+	// Optional per-topic payload schemas (see SCHEMA REGISTRY)
+	schemas *SchemaRegistry
+
+	// Panic recovery, failure callbacks and quarantine (see SUBSCRIBER FAILURE ISOLATION)
+	supervisor *supervisor
+}
+
+// NewMessageBroker creates a new message broker.
+func NewMessageBroker() *MessageBroker {
+	return &MessageBroker{
+		topics:              make(map[string]*Topic),
+		acl:                 NewAccessControl(),
+		clientSubscriptions: make(map[string]map[string][]string),
+		telemetry:           &telemetry{},
+		schemas:             NewSchemaRegistry(),
+		supervisor:          newSupervisor(),
+	}
+}
+
+// CreateTopic creates a new topic with the given name.
+// If the topic already exists, it returns the existing topic.
+func (b *MessageBroker) CreateTopic(name string) *Topic {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// Return existing topic if it already exists
+	if existingTopic, exists := b.topics[name]; exists {
+		return existingTopic
+	}
+
+	// Create and store new topic
+	newTopic := NewTopic(name)
+	newTopic.telemetry = b.telemetry
+	newTopic.supervisor = b.supervisor
+	b.topics[name] = newTopic
+
+	return newTopic
+}
+
+// GetTopic returns a topic by name, or nil if it doesn't exist.
+func (b *MessageBroker) GetTopic(name string) *Topic {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.topics[name]
+}
+
+// DeleteTopic removes a topic from the broker.
+// Warning: This will disconnect all subscribers from the topic.
+func (b *MessageBroker) DeleteTopic(name string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.topics, name)
+}
+
+// Publish sends a message to all subscribers of the specified topic.
+// Returns the created message and an error if the topic doesn't exist, is
+// protected by an ACL (use PublishAs) or the payload doesn't match the
+// topic's schema.
+func (b *MessageBroker) Publish(topicName string, payload interface{}) (*Message, error) {
+	topic, err := b.openTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create message and publish to topic
+	message := NewMessage(topicName, payload)
+	if err := b.schemas.validate(message, 0); err != nil {
+		return nil, err
+	}
+	topic.Publish(message)
+
+	return message, nil
+}
+
+// PublishWithTTL sends a message that expires after ttl.
+// Returns the created message and an error if the topic doesn't exist.
+func (b *MessageBroker) PublishWithTTL(topicName string, payload interface{}, ttl time.Duration) (*Message, error) {
+	topic, err := b.openTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("ttl must be positive")
+	}
+
+	message := NewMessageWithTTL(topicName, payload, ttl)
+	if err := b.schemas.validate(message, 0); err != nil {
+		return nil, err
+	}
+	topic.Publish(message)
+
+	return message, nil
+}
+
+// PublishWithPriority sends a message with an explicit delivery priority.
+// Returns the created message and an error if the topic doesn't exist.
+func (b *MessageBroker) PublishWithPriority(topicName string, payload interface{}, priority MessagePriority) (*Message, error) {
+	topic, err := b.openTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+	if !priority.isConcrete() {
+		return nil, fmt.Errorf("invalid priority: %s", priority)
+	}
+
+	message := NewMessage(topicName, payload)
+	message.Priority = priority
+	if err := b.schemas.validate(message, 0); err != nil {
+		return nil, err
+	}
+	topic.Publish(message)
+
+	return message, nil
+}
+
+// SetRetainPolicy changes what a topic retains for new subscribers.
+// Returns an error if the topic doesn't exist.
+func (b *MessageBroker) SetRetainPolicy(topicName string, policy RetainPolicy) error {
+	topic := b.GetTopic(topicName)
+	if topic == nil {
+		return fmt.Errorf("topic not found: %s", topicName)
+	}
+	return topic.SetRetainPolicy(policy)
+}
+
+// PublishKeyed sends a message tagged with a retain key, so a RetainPerKey
+// topic keeps the latest message per key. A nil payload clears the key.
+// Returns the created message and an error if the topic doesn't exist.
+func (b *MessageBroker) PublishKeyed(topicName, key string, payload interface{}) (*Message, error) {
+	topic, err := b.openTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, fmt.Errorf("retain key must not be empty")
+	}
+
+	message := NewMessage(topicName, payload)
+	message.SetHeader(HeaderRetainKey, key)
+	if err := b.schemas.validate(message, 0); err != nil {
+		return nil, err
+	}
+	topic.Publish(message)
+
+	return message, nil
+}
+
+// PurgeExpired removes expired messages from every topic's history.
+// Returns the total number of messages removed.
+func (b *MessageBroker) PurgeExpired(now time.Time) int {
+	b.mutex.RLock()
+	topics := make([]*Topic, 0, len(b.topics))
+	for _, topic := range b.topics {
+		topics = append(topics, topic)
+	}
+	b.mutex.RUnlock()
+
+	totalPurged := 0
+	for _, topic := range topics {
+		totalPurged += topic.PurgeExpired(now)
+	}
+	return totalPurged
+}
+
+// StartJanitor purges expired messages from all topics every interval.
+// Calling it while the janitor is already running has no effect.
+func (b *MessageBroker) StartJanitor(interval time.Duration) {
+	b.janitor.Start(interval, func(now time.Time) {
+		b.PurgeExpired(now)
+	})
+}
+
+// StopJanitor stops the background expiry janitor and waits for a purge in
+// progress to finish.
+func (b *MessageBroker) StopJanitor() {
+	b.janitor.Stop()
+}
+
+// Subscribe adds a subscriber to the specified topic.
+// Returns an error if the topic doesn't exist or is protected by an ACL;
+// protected topics only accept SubscribeAs.
+func (b *MessageBroker) Subscribe(topicName string, subscriber Subscriber) error {
+	topic, err := b.openTopic(topicName)
+	if err != nil {
+		return err
+	}
+
+	topic.Subscribe(subscriber)
+	return nil
+}
+
+// Unsubscribe removes a subscriber from the specified topic.
+// Returns an error if the topic doesn't exist.
+func (b *MessageBroker) Unsubscribe(topicName string, subscriberID string) error {
+	topic := b.GetTopic(topicName)
+	if topic == nil {
+		return fmt.Errorf("topic not found: %s", topicName)
+	}
+
+	topic.Unsubscribe(subscriberID)
+	return nil
+}
+
+// ListTopics returns the names of all topics in the broker.
+func (b *MessageBroker) ListTopics() []string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	topicNames := make([]string, 0, len(b.topics))
+	for name := range b.topics {
+		topicNames = append(topicNames, name)
+	}
+
+	return topicNames
+}
+
+// ========== AUTHENTICATED BROKER APIS ==========
+// These wrap Publish/Subscribe with token authentication and topic ACLs.
+// Once a topic has been granted to any client it is protected, and the
+// unauthenticated entry points refuse it.
+
+// HeaderPublisher carries the authenticated client that published a message.
+// Only PublishAs sets it.
+const HeaderPublisher = "publisher"
+
+// openTopic returns a topic for an unauthenticated publish or subscribe.
+// Returns an error if the topic doesn't exist or is protected.
+func (b *MessageBroker) openTopic(topicName string) (*Topic, error) {
+	topic := b.GetTopic(topicName)
+	if topic == nil {
+		return nil, fmt.Errorf("topic not found: %s", topicName)
+	}
+	if b.acl.IsProtected(topicName) {
+		return nil, fmt.Errorf("%w: %s requires an authenticated client", ErrForbidden, topicName)
+	}
+	return topic, nil
+}
+
+// RegisterClient registers a producer/consumer and returns its token.
+func (b *MessageBroker) RegisterClient(clientID string) (string, error) {
+	return b.acl.RegisterClient(clientID)
+}
+
+// GrantAccess gives a client permissions on a topic.
+func (b *MessageBroker) GrantAccess(topicName, clientID string, permission Permission) error {
+	if b.GetTopic(topicName) == nil {
+		return fmt.Errorf("topic not found: %s", topicName)
+	}
+	return b.acl.Grant(topicName, clientID, permission)
+}
+
+// RevokeAccess removes a client's permissions on a topic.
+// Revoking subscribe access also removes the client's active subscriptions,
+// so it stops receiving messages immediately.
+func (b *MessageBroker) RevokeAccess(topicName, clientID string, permission Permission) {
+	b.acl.Revoke(topicName, clientID, permission)
+
+	if permission&PermissionSubscribe == 0 {
+		return
+	}
+
+	b.mutex.Lock()
+	subscriberIDs := b.clientSubscriptions[topicName][clientID]
+	delete(b.clientSubscriptions[topicName], clientID)
+	b.mutex.Unlock()
+
+	if topic := b.GetTopic(topicName); topic != nil {
+		for _, subscriberID := range subscriberIDs {
+			topic.Unsubscribe(subscriberID)
+		}
+	}
+}
+
+// GetPermissions returns a client's permissions on a topic.
+func (b *MessageBroker) GetPermissions(topicName, clientID string) Permission {
+	return b.acl.GetPermissions(topicName, clientID)
+}
+
+// PublishAs publishes on behalf of the client owning the token.
+// The message carries a "publisher" header so consumers know its origin.
+func (b *MessageBroker) PublishAs(token, topicName string, payload interface{}) (*Message, error) {
+	client, err := b.acl.authorize(token, topicName, PermissionPublish)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := b.GetTopic(topicName)
+	if topic == nil {
+		return nil, fmt.Errorf("topic not found: %s", topicName)
+	}
+
+	message := NewMessage(topicName, payload)
+	message.SetHeader(HeaderPublisher, client.ID)
+	if err := b.schemas.validate(message, 0); err != nil {
+		return nil, err
+	}
+	topic.Publish(message)
+
+	return message, nil
+}
+
+// SubscribeAs subscribes on behalf of the client owning the token.
+func (b *MessageBroker) SubscribeAs(token, topicName string, subscriber Subscriber) error {
+	client, err := b.acl.authorize(token, topicName, PermissionSubscribe)
+	if err != nil {
+		return err
+	}
+
+	topic := b.GetTopic(topicName)
+	if topic == nil {
+		return fmt.Errorf("topic not found: %s", topicName)
+	}
+	topic.Subscribe(subscriber)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	owners, exists := b.clientSubscriptions[topicName]
+	if !exists {
+		owners = make(map[string][]string)
+		b.clientSubscriptions[topicName] = owners
+	}
+	owners[client.ID] = append(owners[client.ID], subscriber.GetID())
+	return nil
+}
+
+// ========== MESSAGE QUEUE (Point-to-Point) ==========
+// Unlike Pub-Sub where messages go to ALL subscribers,
+// a Queue delivers each message to only ONE consumer.
+// This is useful for distributing work among multiple workers.
+
+type MessageQueue struct {
+	name     string        // Name of the queue
+	messages chan *Message // Buffered channel for storing messages
+	capacity int           // Maximum number of messages the queue can hold
+}
+
+// NewMessageQueue creates a new message queue with the specified capacity.
+// Parameters:
+//   - name: A name to identify this queue
+//   - capacity: Maximum number of messages to buffer
+func NewMessageQueue(name string, capacity int) *MessageQueue {
+	return &MessageQueue{
+		name:     name,
+		messages: make(chan *Message, capacity),
+		capacity: capacity,
+	}
+}
+
+// Enqueue adds a message to the queue.
+// Returns the created message.
+// Note: This will block if the queue is full!
+func (q *MessageQueue) Enqueue(payload interface{}) *Message {
+	message := NewMessage(q.name, payload)
+	q.messages <- message
+	return message
+}
+
+// Dequeue removes and returns a message from the queue.
+// Returns nil immediately if the queue is empty (non-blocking).
+func (q *MessageQueue) Dequeue() *Message {
+	select {
+	case message := <-q.messages:
+		return message
+	default:
+		// Queue is empty, return nil without blocking
+		return nil
+	}
+}
+
+// DequeueBlocking removes and returns a message from the queue.
+// This will BLOCK until a message is available.
+// Use this when you want consumers to wait for work.
+func (q *MessageQueue) DequeueBlocking() *Message {
+	return <-q.messages
+}
+
+// Size returns the current number of messages in the queue.
+func (q *MessageQueue) Size() int {
+	return len(q.messages)
+}
+
+// GetCapacity returns the maximum capacity of the queue.
+func (q *MessageQueue) GetCapacity() int {
+	return q.capacity
+}
+
+// ========== CONSUMER GROUP ==========
+// ConsumerGroup allows multiple consumers to share work from a topic.
+// Messages are distributed among consumers (like a queue) instead of
+// being broadcast to all (like pub-sub).
+
+type ConsumerGroup struct {
+	id        string      // Unique identifier for this consumer group
+	consumers []*Consumer // List of consumers in this group
+	topic     *Topic      // The topic this group consumes from
+	mutex     sync.Mutex  // Protects concurrent access to consumers list
+}
+
+// Consumer represents a single consumer within a consumer group.
+type Consumer struct {
+	ID      string         // Unique identifier for this consumer
+	Handler func(*Message) // Function to process messages
+}
+
+// NewConsumerGroup creates a new consumer group for the specified topic.
+func NewConsumerGroup(id string, topic *Topic) *ConsumerGroup {
+	return &ConsumerGroup{
+		id:        id,
+		consumers: make([]*Consumer, 0),
+		topic:     topic,
+	}
+}
+
+// AddConsumer adds a new consumer to the group.
+// Parameters:
+//   - id: Unique identifier for this consumer
+//   - handler: Function to process messages assigned to this consumer
+func (cg *ConsumerGroup) AddConsumer(id string, handler func(*Message)) {
+	cg.mutex.Lock()
+	defer cg.mutex.Unlock()
+
+	newConsumer := &Consumer{
+		ID:      id,
+		Handler: handler,
+	}
+	cg.consumers = append(cg.consumers, newConsumer)
+}
+
+// GetConsumerCount returns the number of consumers in the group.
+func (cg *ConsumerGroup) GetConsumerCount() int {
+	cg.mutex.Lock()
+	defer cg.mutex.Unlock()
+
+	return len(cg.consumers)
+}
+
+// ========== SPECIALIZED SUBSCRIBERS ==========
+// These are concrete implementations of the Subscriber interface
+// for common use cases.
+
+// LoggingSubscriber logs all received messages to the console.
+// Useful for debugging and monitoring.
+type LoggingSubscriber struct {
+	id string
+}
+
+// NewLoggingSubscriber creates a new logging subscriber.
+func NewLoggingSubscriber(id string) *LoggingSubscriber {
+	return &LoggingSubscriber{id: id}
+}
+
+// GetID returns the subscriber's unique identifier.
+func (s *LoggingSubscriber) GetID() string {
+	return s.id
+}
+
+// OnMessage logs the received message to the console.
+func (s *LoggingSubscriber) OnMessage(msg *Message) {
+	fmt.Printf("  📥 [%s] Received: %s\n", s.id, msg)
+}
+
+// EmailSubscriber simulates sending emails when messages are received.
+// In a real system, this would integrate with an email service.
+type EmailSubscriber struct {
+	id    string
+	email string
+}
+
+// NewEmailSubscriber creates a new email subscriber.
+// Parameters:
+//   - id: Unique identifier for this subscriber
+//   - email: Email address to send notifications to
+func NewEmailSubscriber(id string, email string) *EmailSubscriber {
+	return &EmailSubscriber{
+		id:    id,
+		email: email,
+	}
+}
+
+// GetID returns the subscriber's unique identifier.
+func (s *EmailSubscriber) GetID() string {
+	return s.id
+}
+
+// OnMessage simulates sending an email notification.
+func (s *EmailSubscriber) OnMessage(msg *Message) {
+	fmt.Printf("  📧 [%s] Sending email to %s about: %v\n", s.id, s.email, msg.Payload)
+}
+
+// ========== SNAPSHOT & RESTORE ==========
+// A snapshot captures the broker's topics, subscriber registrations and
+// message history as JSON, so a demo scenario or a bug report can be
+// reproduced on a fresh broker. Subscribers are code, not data: only their
+// IDs are recorded, and Restore asks a SubscriberFactory to rebuild them.
+// Restore never redelivers history; Replay does that explicitly, one
+// message at a time and subscribers in ID order, so runs are deterministic.
+
+// SnapshotVersion is bumped whenever the snapshot format changes.
+const SnapshotVersion = 1
+
+// BrokerSnapshot is the serialized state of a broker.
+type BrokerSnapshot struct {
+	Version int             `json:"version"`
+	TakenAt time.Time       `json:"taken_at"`
+	Topics  []TopicSnapshot `json:"topics"` // Sorted by name
+}
+
+// TopicSnapshot is the serialized state of one topic.
+type TopicSnapshot struct {
+	Name            string            `json:"name"`
+	DefaultPriority string            `json:"default_priority"`
+	PriorityWeights *PriorityWeights  `json:"priority_weights,omitempty"` // nil = scheduling disabled
+	Subscribers     []string          `json:"subscribers"`                // Subscriber IDs, sorted
+	Messages        []MessageSnapshot `json:"messages"`                   // History, in publish order
+	RetainPolicy    string            `json:"retain_policy,omitempty"`    // "" = none; retained set is rebuilt from history
+}
+
+// MessageSnapshot is the serialized form of a message in topic history.
+type MessageSnapshot struct {
+	ID        string            `json:"id"`
+	Payload   json.RawMessage   `json:"payload"`
+	Timestamp time.Time         `json:"timestamp"`
+	ExpiresAt time.Time         `json:"expires_at"` // Zero = never expires
+	Priority  string            `json:"priority"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// SubscriberFactory rebuilds a subscriber recorded in a snapshot.
+// Returning nil leaves that registration unresolved.
+type SubscriberFactory func(topicName, subscriberID string) Subscriber
+
+// RestoreReport summarizes what Restore recreated.
+type RestoreReport struct {
+	Topics      int
+	Messages    int
+	Subscribers int
+	Unresolved  []string // "topic/subscriber" registrations the factory could not rebuild
+}
+
+// parsePriority is the inverse of MessagePriority.String.
+func parsePriority(name string) (MessagePriority, error) {
+	for p := PriorityDefault; p <= PriorityHigh; p++ {
+		if p.String() == name {
+			return p, nil
+		}
+	}
+	return PriorityDefault, fmt.Errorf("unknown priority: %q", name)
+}
+
+// snapshot serializes the topic's current state.
+func (t *Topic) snapshot() (TopicSnapshot, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	snap := TopicSnapshot{
+		Name:            t.name,
+		DefaultPriority: t.defaultPriority.String(),
+		Subscribers:     make([]string, 0, len(t.subscribers)),
+		Messages:        make([]MessageSnapshot, 0, len(t.messages)),
+	}
+	if t.weights != nil {
+		weights := *t.weights
+		snap.PriorityWeights = &weights
+	}
+	if t.retainPolicy != RetainNone {
+		snap.RetainPolicy = t.retainPolicy.String()
+	}
+	for subscriberID := range t.subscribers {
+		snap.Subscribers = append(snap.Subscribers, subscriberID)
+	}
+	sort.Strings(snap.Subscribers)
+
+	for _, message := range t.messages {
+		payload, err := json.Marshal(message.Payload)
+		if err != nil {
+			return TopicSnapshot{}, fmt.Errorf("message %s on topic %s: payload not serializable: %w",
+				message.ID, t.name, err)
+		}
+		snap.Messages = append(snap.Messages, MessageSnapshot{
+			ID:        message.ID,
+			Payload:   payload,
+			Timestamp: message.Timestamp,
+			ExpiresAt: message.ExpiresAt,
+			Priority:  message.Priority.String(),
+			Headers:   message.Headers,
+		})
+	}
+	return snap, nil
+}
+
+// Snapshot dumps all topics, subscriber registrations and message
+// history to indented JSON. Payloads must be JSON-serializable.
+func (b *MessageBroker) Snapshot() ([]byte, error) {
+	b.mutex.RLock()
+	topics := make([]*Topic, 0, len(b.topics))
+	for _, topic := range b.topics {
+		topics = append(topics, topic)
+	}
+	b.mutex.RUnlock()
+
+	sort.Slice(topics, func(i, j int) bool { return topics[i].name < topics[j].name })
+
+	snap := BrokerSnapshot{
+		Version: SnapshotVersion,
+		TakenAt: time.Now(),
+		Topics:  make([]TopicSnapshot, 0, len(topics)),
+	}
+	for _, topic := range topics {
+		topicSnap, err := topic.snapshot()
+		if err != nil {
+			return nil, err
+		}
+		snap.Topics = append(snap.Topics, topicSnap)
+	}
+	return json.MarshalIndent(snap, "", "  ")
+}
+
+// Restore recreates the topics in a snapshot on this broker. Topics must
+// not already exist, so a snapshot is never merged into live state.
+// History is restored without being delivered; use Replay.
+func (b *MessageBroker) Restore(data []byte, factory SubscriberFactory) (*RestoreReport, error) {
+	var snap BrokerSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if snap.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (want %d)", snap.Version, SnapshotVersion)
+	}
+
+	// Build every topic before touching the broker so a bad snapshot
+	// leaves it unchanged
+	report := &RestoreReport{Unresolved: make([]string, 0)}
+	restored := make([]*Topic, 0, len(snap.Topics))
+	retainPolicies := make([]RetainPolicy, 0, len(snap.Topics))
+	highestID := int64(0)
+	for _, topicSnap := range snap.Topics {
+		defaultPriority, err := parsePriority(topicSnap.DefaultPriority)
+		if err != nil || !defaultPriority.isConcrete() {
+			return nil, fmt.Errorf("topic %s: invalid default priority %q", topicSnap.Name, topicSnap.DefaultPriority)
+		}
+
+		retainPolicy := RetainNone
+		if topicSnap.RetainPolicy != "" {
+			if retainPolicy, err = parseRetainPolicy(topicSnap.RetainPolicy); err != nil {
+				return nil, fmt.Errorf("topic %s: %w", topicSnap.Name, err)
+			}
+		}
+
+		topic := NewTopic(topicSnap.Name)
+		topic.defaultPriority = defaultPriority
+		topic.telemetry = b.telemetry
+		topic.supervisor = b.supervisor
+		for _, messageSnap := range topicSnap.Messages {
+			priority, err := parsePriority(messageSnap.Priority)
+			if err != nil {
+				return nil, fmt.Errorf("message %s: %w", messageSnap.ID, err)
+			}
+			var payload interface{}
+			if err := json.Unmarshal(messageSnap.Payload, &payload); err != nil {
+				return nil, fmt.Errorf("message %s: invalid payload: %w", messageSnap.ID, err)
+			}
+			headers := messageSnap.Headers
+			if headers == nil {
+				headers = make(map[string]string)
+			}
+			topic.messages = append(topic.messages, &Message{
+				ID:        messageSnap.ID,
+				Topic:     topicSnap.Name,
+				Payload:   payload,
+				Timestamp: messageSnap.Timestamp,
+				ExpiresAt: messageSnap.ExpiresAt,
+				Priority:  priority,
+				Headers:   headers,
+			})
+
+			var sequence int64
+			if _, err := fmt.Sscanf(messageSnap.ID, "MSG-%d", &sequence); err == nil && sequence > highestID {
+				highestID = sequence
+			}
+		}
+		report.Messages += len(topicSnap.Messages)
+		restored = append(restored, topic)
+		retainPolicies = append(retainPolicies, retainPolicy)
+	}
+
+	b.mutex.Lock()
+	for _, topic := range restored {
+		if _, exists := b.topics[topic.name]; exists {
+			b.mutex.Unlock()
+			return nil, fmt.Errorf("topic already exists: %s", topic.name)
+		}
+	}
+	for _, topic := range restored {
+		b.topics[topic.name] = topic
+	}
+	b.mutex.Unlock()
+
+	// Subscribers are attached after the topics are live, exactly as
+	// they would have been registered originally
+	for i, topicSnap := range snap.Topics {
+		topic := restored[i]
+		if topicSnap.PriorityWeights != nil {
+			if err := topic.EnablePriorityScheduling(*topicSnap.PriorityWeights); err != nil {
+				return nil, fmt.Errorf("topic %s: %w", topic.name, err)
+			}
+		}
+		for _, subscriberID := range topicSnap.Subscribers {
+			var subscriber Subscriber
+			if factory != nil {
+				subscriber = factory(topic.name, subscriberID)
+			}
+			if subscriber == nil {
+				report.Unresolved = append(report.Unresolved, topic.name+"/"+subscriberID)
+				continue
+			}
+			topic.Subscribe(subscriber)
+			report.Subscribers++
+		}
+
+		// Rebuild retained messages only now, so restoring subscribers
+		// doesn't hand them out
+		topic.mutex.Lock()
+		topic.retainPolicy = retainPolicies[i]
+		for _, message := range topic.messages {
+			topic.retainLocked(message)
+		}
+		topic.mutex.Unlock()
+	}
+	report.Topics = len(restored)
+
+	// New messages must not reuse restored IDs
+	for {
+		current := messageCounter.Load()
+		if highestID <= current || messageCounter.CompareAndSwap(current, highestID) {
+			break
+		}
+	}
+	return report, nil
+}
+
+// Replay redelivers a topic's message history synchronously, in publish
+// order, to each current subscriber in ID order. Expired messages are
+// skipped as usual. Returns the number of successful deliveries.
+func (b *MessageBroker) Replay(topicName string) (int, error) {
+	topic := b.GetTopic(topicName)
+	if topic == nil {
+		return 0, fmt.Errorf("topic not found: %s", topicName)
+	}
+
+	topic.mutex.RLock()
+	messages := make([]*Message, len(topic.messages))
+	copy(messages, topic.messages)
+	subscribers := make([]Subscriber, 0, len(topic.subscribers))
+	for _, subscriber := range topic.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	topic.mutex.RUnlock()
+
+	sort.Slice(subscribers, func(i, j int) bool { return subscribers[i].GetID() < subscribers[j].GetID() })
+
+	delivered := 0
+	now := time.Now()
+	for _, message := range messages {
+		if message.IsExpired(now) {
+			topic.skippedDeliveries.Add(int64(len(subscribers)))
+			continue
+		}
+		for _, subscriber := range subscribers {
+			topic.deliver(subscriber, message)
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// ========== INSTRUMENTATION & TRACING ==========
+// Instrumentation hooks let metrics and tracing observe the broker without
+// touching publishers or subscribers. Each hook fires at one point:
+// - OnPublish: a topic accepts a message
+// - OnDeliver: a message is handed to a subscriber
+// - OnAck:     the subscriber's OnMessage returns (the implicit ack)
+//
+// Tracing follows the OpenTelemetry model with W3C Trace Context headers.
+// Publishing starts a "publish" span, a child of the producer's span if the
+// message already carries a "traceparent" header, and injects it into
+// Message.Headers. Every delivery extracts it and starts a "deliver" span
+// per subscriber, so one trace links a producer to all of its consumers.
+// Replayed messages keep their original trace.
+
+// HeaderTraceParent is the W3C Trace Context header: 00-<trace-id>-<span-id>-<flags>
+const HeaderTraceParent = "traceparent"
+
+// SpanContext identifies one span within a trace.
+type SpanContext struct {
+	TraceID      string // 32 hex chars, shared by every span of the trace
+	SpanID       string // 16 hex chars, unique per span
+	ParentSpanID string // Empty for a root span
+	Sampled      bool   // Whether the trace is recorded
+}
+
+// randomHex returns n random bytes as a hex string.
+func randomHex(n int) string {
+	buffer := make([]byte, n)
+	if _, err := rand.Read(buffer); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return hex.EncodeToString(buffer)
+}
+
+// NewRootSpan starts a new sampled trace.
+func NewRootSpan() SpanContext {
+	return SpanContext{TraceID: randomHex(16), SpanID: randomHex(8), Sampled: true}
+}
+
+// Child starts a span in the same trace whose parent is s.
+func (s SpanContext) Child() SpanContext {
+	return SpanContext{TraceID: s.TraceID, SpanID: randomHex(8), ParentSpanID: s.SpanID, Sampled: s.Sampled}
+}
+
+// IsValid reports whether the span has trace and span IDs.
+func (s SpanContext) IsValid() bool {
+	return s.TraceID != "" && s.SpanID != ""
+}
+
+// TraceParent renders the span as a W3C traceparent header value.
+func (s SpanContext) TraceParent() string {
+	flags := "00"
+	if s.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", s.TraceID, s.SpanID, flags)
+}
+
+// ParseTraceParent parses a W3C traceparent header value.
+// The parsed span is the remote parent, so ParentSpanID is empty.
+func ParseTraceParent(value string) (SpanContext, error) {
+	var traceID, spanID, flags string
+	if len(value) != 55 || value[:3] != "00-" || value[35] != '-' || value[52] != '-' {
+		return SpanContext{}, fmt.Errorf("malformed traceparent %q", value)
+	}
+	traceID, spanID, flags = value[3:35], value[36:52], value[53:]
+	for _, part := range []string{traceID, spanID, flags} {
+		if _, err := hex.DecodeString(part); err != nil {
+			return SpanContext{}, fmt.Errorf("malformed traceparent %q", value)
+		}
+	}
+	if traceID == "00000000000000000000000000000000" || spanID == "0000000000000000" {
+		return SpanContext{}, fmt.Errorf("traceparent %q has an all-zero ID", value)
+	}
+	return SpanContext{TraceID: traceID, SpanID: spanID, Sampled: flags == "01"}, nil
+}
+
+// InjectTrace writes a span into the message headers (producers call this
+// to attach their own span before publishing).
+func InjectTrace(msg *Message, span SpanContext) {
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]string)
+	}
+	msg.SetHeader(HeaderTraceParent, span.TraceParent())
+}
+
+// ExtractTrace reads the span carried in the message headers, if any.
+// Consumers call this to continue the trace in their own work.
+func ExtractTrace(msg *Message) (SpanContext, bool) {
+	span, err := ParseTraceParent(msg.GetHeader(HeaderTraceParent))
+	if err != nil {
+		return SpanContext{}, false
+	}
+	return span, true
+}
+
+// PublishEvent describes a message accepted by a topic.
+type PublishEvent struct {
+	Message *Message
+	Span    SpanContext // The publish span injected into the headers
+	At      time.Time
+}
+
+// DeliveryEvent describes one message handed to one subscriber.
+// AckedAt is zero in OnDeliver and set in OnAck.
+type DeliveryEvent struct {
+	Message      *Message
+	SubscriberID string
+	Span         SpanContext // The deliver span (child of the publish span)
+	DeliveredAt  time.Time
+	AckedAt      time.Time
+}
+
+// QueueLatency is the time from publish to delivery.
+func (e DeliveryEvent) QueueLatency() time.Duration {
+	return e.DeliveredAt.Sub(e.Message.Timestamp)
+}
+
+// ProcessingTime is how long the subscriber took to ack (0 before the ack).
+func (e DeliveryEvent) ProcessingTime() time.Duration {
+	if e.AckedAt.IsZero() {
+		return 0
+	}
+	return e.AckedAt.Sub(e.DeliveredAt)
+}
+
+// Instrumentation receives broker events. Hooks run on the publishing or
+// delivering goroutine, so they must be fast and thread-safe.
+type Instrumentation interface {
+	OnPublish(event PublishEvent)
+	OnDeliver(event DeliveryEvent)
+	OnAck(event DeliveryEvent)
+}
+
+// InstrumentationHooks adapts plain functions to Instrumentation.
+// Nil functions are skipped.
+type InstrumentationHooks struct {
+	Publish func(PublishEvent)
+	Deliver func(DeliveryEvent)
+	Ack     func(DeliveryEvent)
+}
+
+func (h InstrumentationHooks) OnPublish(event PublishEvent) {
+	if h.Publish != nil {
+		h.Publish(event)
+	}
+}
+
+func (h InstrumentationHooks) OnDeliver(event DeliveryEvent) {
+	if h.Deliver != nil {
+		h.Deliver(event)
+	}
+}
+
+func (h InstrumentationHooks) OnAck(event DeliveryEvent) {
+	if h.Ack != nil {
+		h.Ack(event)
+	}
+}
+
+// telemetry is shared by a broker and all of its topics, so instrumentation
+// added later also applies to existing topics.
+type telemetry struct {
+	instruments []Instrumentation
+	mutex       sync.RWMutex
+}
+
+// snapshot returns the registered instruments (nil if none).
+func (tel *telemetry) snapshot() []Instrumentation {
+	if tel == nil {
+		return nil
+	}
+	tel.mutex.RLock()
+	defer tel.mutex.RUnlock()
+	return tel.instruments
+}
+
+// publish starts the publish span, injects it and fires OnPublish.
+// Called before the message is shared with any delivery goroutine.
+func (tel *telemetry) publish(msg *Message) {
+	instruments := tel.snapshot()
+	if len(instruments) == 0 {
+		return
+	}
+
+	span := NewRootSpan()
+	if parent, ok := ExtractTrace(msg); ok {
+		span = parent.Child()
+	}
+	InjectTrace(msg, span)
+
+	event := PublishEvent{Message: msg, Span: span, At: time.Now()}
+	for _, instrument := range instruments {
+		instrument.OnPublish(event)
+	}
+}
+
+// deliver wraps one subscriber call with OnDeliver and OnAck and returns
+// the subscriber's error (OnAck fires only for handled messages).
+func (tel *telemetry) deliver(subscriber Subscriber, msg *Message) error {
+	instruments := tel.snapshot()
+	if len(instruments) == 0 {
+		return callSubscriber(subscriber, msg)
+	}
+
+	span := NewRootSpan()
+	if parent, ok := ExtractTrace(msg); ok {
+		span = parent.Child()
+	}
+	event := DeliveryEvent{Message: msg, SubscriberID: subscriber.GetID(), Span: span, DeliveredAt: time.Now()}
+	for _, instrument := range instruments {
+		instrument.OnDeliver(event)
+	}
+
+	if err := callSubscriber(subscriber, msg); err != nil {
+		return err
+	}
+
+	event.AckedAt = time.Now()
+	for _, instrument := range instruments {
+		instrument.OnAck(event)
+	}
+	return nil
+}
+
+// AddInstrumentation registers hooks for every topic of the broker.
+func (b *MessageBroker) AddInstrumentation(instrument Instrumentation) {
+	b.telemetry.mutex.Lock()
+	defer b.telemetry.mutex.Unlock()
+
+	// Copy-on-write: hooks already running keep iterating the old slice
+	instruments := make([]Instrumentation, 0, len(b.telemetry.instruments)+1)
+	instruments = append(instruments, b.telemetry.instruments...)
+	b.telemetry.instruments = append(instruments, instrument)
+}
+
+// TopicMetrics aggregates the events seen for one topic.
+type TopicMetrics struct {
+	Published       int64
+	Delivered       int64
+	Acked           int64
+	TotalQueue      time.Duration // Sum of publish -> delivery latencies
+	MaxQueue        time.Duration
+	TotalProcessing time.Duration // Sum of delivery -> ack durations
+}
+
+// AverageQueueLatency returns the mean publish -> delivery latency.
+func (m TopicMetrics) AverageQueueLatency() time.Duration {
+	if m.Delivered == 0 {
+		return 0
+	}
+	return m.TotalQueue / time.Duration(m.Delivered)
+}
+
+// AverageProcessingTime returns the mean delivery -> ack duration.
+func (m TopicMetrics) AverageProcessingTime() time.Duration {
+	if m.Acked == 0 {
+		return 0
+	}
+	return m.TotalProcessing / time.Duration(m.Acked)
+}
+
+// MetricsRecorder is an Instrumentation that keeps per-topic counters and
+// latency totals, like a metrics exporter would.
+type MetricsRecorder struct {
+	topics map[string]*TopicMetrics
+	mutex  sync.Mutex
+}
+
+// NewMetricsRecorder creates an empty recorder.
+func NewMetricsRecorder() *MetricsRecorder {
+	return &MetricsRecorder{topics: make(map[string]*TopicMetrics)}
+}
+
+// topicLocked returns the topic's metrics, creating them on first use.
+// Caller must hold r.mutex.
+func (r *MetricsRecorder) topicLocked(name string) *TopicMetrics {
+	metrics, exists := r.topics[name]
+	if !exists {
+		metrics = &TopicMetrics{}
+		r.topics[name] = metrics
+	}
+	return metrics
+}
+
+func (r *MetricsRecorder) OnPublish(event PublishEvent) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.topicLocked(event.Message.Topic).Published++
+}
+
+func (r *MetricsRecorder) OnDeliver(event DeliveryEvent) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	metrics := r.topicLocked(event.Message.Topic)
+	metrics.Delivered++
+	latency := event.QueueLatency()
+	metrics.TotalQueue += latency
+	if latency > metrics.MaxQueue {
+		metrics.MaxQueue = latency
+	}
+}
+
+func (r *MetricsRecorder) OnAck(event DeliveryEvent) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	metrics := r.topicLocked(event.Message.Topic)
+	metrics.Acked++
+	metrics.TotalProcessing += event.ProcessingTime()
+}
+
+// GetTopicMetrics returns a copy of the metrics recorded for a topic.
+func (r *MetricsRecorder) GetTopicMetrics(topicName string) TopicMetrics {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if metrics, exists := r.topics[topicName]; exists {
+		return *metrics
+	}
+	return TopicMetrics{}
+}
+
+// ========== SCHEMA REGISTRY ==========
+// Topics may register a schema describing their payloads. Once a topic has
+// one, every publish is validated and payloads that don't conform are
+// rejected before any subscriber sees them. Topics without a schema accept
+// anything, as before.
+//
+// A schema is anything implementing SchemaValidator:
+//   - JSONSchema: a subset of JSON Schema (type, properties, required,
+//     items, enum, additionalProperties). Payloads are validated by their
+//     JSON encoding, so Go structs and maps both work.
+//   - StructValidator: a Go type plus an optional check function
+//
+// Schemas are versioned per topic. Publishing uses the latest version unless
+// the producer pins an older one with PublishWithSchema, and every validated
+// message carries HeaderSchemaVersion so consumers know which format they
+// got. A compatibility mode guards registration of new JSON schema versions:
+//   - CompatibilityBackward: consumers on the new version can read old data
+//     (no new required fields, no type changes)
+//   - CompatibilityForward: consumers on the old version can read new data
+//     (no dropped required fields, no type changes)
+//   - CompatibilityFull: both
+// Struct validators can't be compared, so they need CompatibilityNone.
+// A nil tombstone sent with PublishKeyed is never validated.
+
+// HeaderSchemaVersion records the schema version a message was validated against
+const HeaderSchemaVersion = "schema-version"
+
+// ErrSchemaViolation is wrapped by errors for payloads that don't match the topic's schema
+var ErrSchemaViolation = errors.New("payload does not match schema")
+
+// ErrIncompatibleSchema is wrapped by errors for schema versions that break the compatibility mode
+var ErrIncompatibleSchema = errors.New("schema is incompatible with the previous version")
+
+// SchemaValidator checks that a payload conforms to a schema.
+type SchemaValidator interface {
+	Validate(payload interface{}) error
+	Describe() string // Short human-readable summary, e.g. "json object{id,amount}"
+}
+
+// ---------- JSON Schema ----------
+
+// JSONSchema is the supported subset of JSON Schema.
+type JSONSchema struct {
+	Type                 string                 `json:"type,omitempty"` // object, array, string, number, integer, boolean, null
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"` // nil = allowed
+}
+
+// ParseJSONSchema parses a JSON Schema document.
+func ParseJSONSchema(document string) (*JSONSchema, error) {
+	var schema JSONSchema
+	if err := json.Unmarshal([]byte(document), &schema); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	if err := schema.check("$"); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// check rejects unknown types and required fields missing from properties.
+func (s *JSONSchema) check(path string) error {
+	switch s.Type {
+	case "", "object", "array", "string", "number", "integer", "boolean", "null":
+	default:
+		return fmt.Errorf("invalid JSON schema: unknown type %q at %s", s.Type, path)
+	}
+	for _, name := range s.Required {
+		if s.Properties != nil && s.Properties[name] == nil {
+			return fmt.Errorf("invalid JSON schema: required field %q at %s has no property", name, path)
+		}
+	}
+	for name, property := range s.Properties {
+		if err := property.check(path + "." + name); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.check(path + "[]")
+	}
+	return nil
+}
+
+// Validate checks the JSON encoding of the payload against the schema.
+func (s *JSONSchema) Validate(payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%w: payload is not JSON-encodable: %v", ErrSchemaViolation, err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return fmt.Errorf("%w: %v", ErrSchemaViolation, err)
+	}
+	return s.validateValue("$", value)
+}
+
+// validateValue checks one decoded JSON value.
+func (s *JSONSchema) validateValue(path string, value interface{}) error {
+	if s.Type != "" && jsonType(value) != s.Type && !(s.Type == "number" && jsonType(value) == "integer") {
+		return fmt.Errorf("%w: %s must be %s, got %s", ErrSchemaViolation, path, s.Type, jsonType(value))
+	}
+	if len(s.Enum) > 0 {
+		allowed := false
+		for _, option := range s.Enum {
+			if reflect.DeepEqual(option, value) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %s must be one of %v, got %v", ErrSchemaViolation, path, s.Enum, value)
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, present := typed[name]; !present {
+				return fmt.Errorf("%w: %s is missing required field %q", ErrSchemaViolation, path, name)
+			}
+		}
+		names := make([]string, 0, len(typed))
+		for name := range typed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, known := s.Properties[name]
+			if !known {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fmt.Errorf("%w: %s has unexpected field %q", ErrSchemaViolation, path, name)
+				}
+				continue
+			}
+			if err := property.validateValue(path+"."+name, typed[name]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for index, item := range typed {
+				if err := s.Items.validateValue(fmt.Sprintf("%s[%d]", path, index), item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// jsonType names the JSON type of a decoded value.
+func jsonType(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if typed == float64(int64(typed)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+// Describe summarizes the schema, e.g. "json object{amount,id}".
+func (s *JSONSchema) Describe() string {
+	if s.Type != "object" || len(s.Required) == 0 {
+		return "json " + s.Type
+	}
+	required := append([]string(nil), s.Required...)
+	sort.Strings(required)
+	return fmt.Sprintf("json object{%s}", strings.Join(required, ","))
+}
+
+// requiredSet returns the required field names.
+func (s *JSONSchema) requiredSet() map[string]bool {
+	set := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		set[name] = true
+	}
+	return set
+}
+
+// checkCompatible reports why next can't replace s under mode (nil if it can).
+func (s *JSONSchema) checkCompatible(next *JSONSchema, mode SchemaCompatibility) error {
+	if s.Type != next.Type {
+		return fmt.Errorf("%w: type changed from %q to %q", ErrIncompatibleSchema, s.Type, next.Type)
+	}
+	for name, property := range s.Properties {
+		if nextProperty, kept := next.Properties[name]; kept && nextProperty.Type != property.Type {
+			return fmt.Errorf("%w: field %q changed type from %q to %q", ErrIncompatibleSchema, name, property.Type, nextProperty.Type)
+		}
+	}
+	oldRequired, newRequired := s.requiredSet(), next.requiredSet()
+	if mode == CompatibilityBackward || mode == CompatibilityFull {
+		for _, name := range next.Required {
+			if !oldRequired[name] {
+				return fmt.Errorf("%w: new required field %q is missing from old messages", ErrIncompatibleSchema, name)
+			}
+		}
+	}
+	if mode == CompatibilityForward || mode == CompatibilityFull {
+		for _, name := range s.Required {
+			if !newRequired[name] {
+				return fmt.Errorf("%w: field %q is no longer required but old consumers need it", ErrIncompatibleSchema, name)
+			}
+		}
+	}
+	return nil
+}
+
+// ---------- Go struct validator ----------
+
+// StructValidator accepts payloads of one Go type (or a pointer to it),
+// optionally running a check function on them.
+type StructValidator struct {
+	expected reflect.Type
+	check    func(payload interface{}) error
+}
+
+// NewStructValidator accepts payloads of prototype's type; check may be nil.
+func NewStructValidator(prototype interface{}, check func(payload interface{}) error) *StructValidator {
+	return &StructValidator{expected: reflect.TypeOf(prototype), check: check}
+}
+
+// Validate checks the payload's type, then runs the check function.
+func (v *StructValidator) Validate(payload interface{}) error {
+	actual := reflect.TypeOf(payload)
+	if actual == nil {
+		return fmt.Errorf("%w: payload is nil, want %s", ErrSchemaViolation, v.expected)
+	}
+	if actual != v.expected && !(actual.Kind() == reflect.Ptr && actual.Elem() == v.expected) {
+		return fmt.Errorf("%w: payload is %s, want %s", ErrSchemaViolation, actual, v.expected)
+	}
+	if v.check != nil {
+		if err := v.check(payload); err != nil {
+			return fmt.Errorf("%w: %v", ErrSchemaViolation, err)
+		}
+	}
+	return nil
+}
+
+// Describe names the accepted Go type.
+func (v *StructValidator) Describe() string {
+	return "go " + v.expected.String()
+}
+
+// ---------- Registry ----------
+
+// SchemaCompatibility controls which new schema versions a topic accepts.
+type SchemaCompatibility int
+
+const (
+	CompatibilityNone     SchemaCompatibility = iota // Any new version is accepted
+	CompatibilityBackward                            // New consumers can read old messages
+	CompatibilityForward                             // Old consumers can read new messages
+	CompatibilityFull                                // Both backward and forward
+)
+
+// String returns the compatibility mode name.
+func (c SchemaCompatibility) String() string {
+	names := []string{"none", "backward", "forward", "full"}
+	if int(c) < len(names) {
+		return names[c]
+	}
+	return "unknown"
+}
+
+// SchemaVersion is one registered schema of a topic.
+type SchemaVersion struct {
+	Topic        string
+	Version      int // Starts at 1
+	Validator    SchemaValidator
+	RegisteredAt time.Time
+}
+
+// topicSchemas holds the schema history of one topic.
+type topicSchemas struct {
+	versions      []*SchemaVersion // Index = version - 1
+	compatibility SchemaCompatibility
+}
+
+// SchemaRegistry stores versioned schemas per topic.
+type SchemaRegistry struct {
+	topics map[string]*topicSchemas
+	mutex  sync.RWMutex
+}
+
+// NewSchemaRegistry creates an empty registry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{topics: make(map[string]*topicSchemas)}
+}
+
+// topicLocked returns the topic's schema history, creating it if needed.
+// Caller must hold r.mutex for writing.
+func (r *SchemaRegistry) topicLocked(topicName string) *topicSchemas {
+	schemas, exists := r.topics[topicName]
+	if !exists {
+		schemas = &topicSchemas{}
+		r.topics[topicName] = schemas
+	}
+	return schemas
+}
+
+// SetCompatibility sets the mode checked when new versions are registered.
+func (r *SchemaRegistry) SetCompatibility(topicName string, mode SchemaCompatibility) error {
+	if mode < CompatibilityNone || mode > CompatibilityFull {
+		return fmt.Errorf("invalid compatibility mode: %d", mode)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.topicLocked(topicName).compatibility = mode
+	return nil
+}
+
+// Register adds a new schema version for the topic and returns it.
+// The new version must be compatible with the latest one under the topic's
+// compatibility mode.
+func (r *SchemaRegistry) Register(topicName string, validator SchemaValidator) (*SchemaVersion, error) {
+	if validator == nil {
+		return nil, fmt.Errorf("schema validator must not be nil")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	schemas := r.topicLocked(topicName)
+	if count := len(schemas.versions); count > 0 && schemas.compatibility != CompatibilityNone {
+		previous := schemas.versions[count-1]
+		oldSchema, oldIsJSON := previous.Validator.(*JSONSchema)
+		newSchema, newIsJSON := validator.(*JSONSchema)
+		if !oldIsJSON || !newIsJSON {
+			return nil, fmt.Errorf("%w: %s compatibility can only be checked between JSON schemas", ErrIncompatibleSchema, schemas.compatibility)
+		}
+		if err := oldSchema.checkCompatible(newSchema, schemas.compatibility); err != nil {
+			return nil, fmt.Errorf("topic %s v%d: %w", topicName, count+1, err)
+		}
+	}
+
+	version := &SchemaVersion{
+		Topic:        topicName,
+		Version:      len(schemas.versions) + 1,
+		Validator:    validator,
+		RegisteredAt: time.Now(),
+	}
+	schemas.versions = append(schemas.versions, version)
+	return version, nil
+}
+
+// GetSchema returns a version of the topic's schema (0 = latest), or nil.
+func (r *SchemaRegistry) GetSchema(topicName string, version int) *SchemaVersion {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	schemas, exists := r.topics[topicName]
+	if !exists || len(schemas.versions) == 0 {
+		return nil
+	}
+	if version == 0 {
+		return schemas.versions[len(schemas.versions)-1]
+	}
+	if version < 0 || version > len(schemas.versions) {
+		return nil
+	}
+	return schemas.versions[version-1]
+}
+
+// GetVersions returns every registered version of the topic's schema, oldest first.
+func (r *SchemaRegistry) GetVersions(topicName string) []*SchemaVersion {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	schemas, exists := r.topics[topicName]
+	if !exists {
+		return nil
+	}
+	return append([]*SchemaVersion(nil), schemas.versions...)
+}
+
+// validate checks a message against a version of its topic's schema
+// (0 = latest) and stamps HeaderSchemaVersion. Topics without a schema and
+// keyed tombstones pass unchecked.
+func (r *SchemaRegistry) validate(msg *Message, version int) error {
+	if msg.Payload == nil && msg.GetHeader(HeaderRetainKey) != "" {
+		return nil
+	}
+	schema := r.GetSchema(msg.Topic, version)
+	if schema == nil {
+		if version != 0 {
+			return fmt.Errorf("topic %s has no schema version %d", msg.Topic, version)
+		}
+		return nil
+	}
+	if err := schema.Validator.Validate(msg.Payload); err != nil {
+		return fmt.Errorf("topic %s v%d: %w", msg.Topic, schema.Version, err)
+	}
+	msg.SetHeader(HeaderSchemaVersion, strconv.Itoa(schema.Version))
+	return nil
+}
+
+// SchemaVersionOf returns the schema version a message was validated against.
+func SchemaVersionOf(msg *Message) (int, bool) {
+	version, err := strconv.Atoi(msg.GetHeader(HeaderSchemaVersion))
+	return version, err == nil
+}
+
+// GetSchemaRegistry returns the broker's schema registry.
+func (b *MessageBroker) GetSchemaRegistry() *SchemaRegistry {
+	return b.schemas
+}
+
+// RegisterSchema registers a new schema version for an existing topic.
+func (b *MessageBroker) RegisterSchema(topicName string, validator SchemaValidator) (*SchemaVersion, error) {
+	if b.GetTopic(topicName) == nil {
+		return nil, fmt.Errorf("topic not found: %s", topicName)
+	}
+	return b.schemas.Register(topicName, validator)
+}
+
+// PublishWithSchema publishes a payload validated against a specific schema
+// version, for producers that haven't moved to the latest format yet.
+// Returns the created message and an error if the topic doesn't exist, the
+// version is unknown or the payload doesn't conform.
+func (b *MessageBroker) PublishWithSchema(topicName string, version int, payload interface{}) (*Message, error) {
+	topic, err := b.openTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+	if version <= 0 {
+		return nil, fmt.Errorf("schema version must be positive: %d", version)
+	}
+
+	message := NewMessage(topicName, payload)
+	if err := b.schemas.validate(message, version); err != nil {
+		return nil, err
+	}
+	topic.Publish(message)
+
+	return message, nil
+}
+
+// ========== BRIDGE ==========
+// A Bridge connects this in-process broker to an external system, so
+// messages fan out across processes:
+// - ForwardOut subscribes to local topics and sends each message through a
+//   Connector
+// - ForwardIn listens on the connector and republishes what arrives on the
+//   local topic of the same name
+// - Mirror does both
+//
+// Connectors are pluggable (Strategy Pattern). Two reference connectors are
+// provided: BrokerConnector talks to another MessageBroker in the same
+// process, and NetworkConnector simulates a wire transport by serializing
+// messages to JSON (payloads arrive decoded as JSON values, e.g. maps and
+// float64s, exactly as they would from a real socket).
+//
+// Every bridge a message crosses appends its ID to the bridge-path header.
+// A bridge never forwards a message that already carries its own ID, which
+// stops two mirrored brokers from echoing a message back and forth, and
+// MaxBridgeHops bounds longer cycles (A → B → C → A). Retained messages
+// replayed to the bridge's subscription are not forwarded again.
+
+const (
+	// HeaderBridgePath lists the IDs of the bridges a message crossed
+	HeaderBridgePath = "bridge-path"
+	// HeaderBridgeSourceID is the message's ID on the broker that first published it
+	HeaderBridgeSourceID = "bridge-source-id"
+)
+
+// MaxBridgeHops is the most bridges a message may cross
+const MaxBridgeHops = 8
+
+// ErrConnectorClosed is returned by connectors after Close.
+var ErrConnectorClosed = errors.New("connector closed")
+
+// ErrLinkDown is returned by NetworkConnector while its link is down.
+var ErrLinkDown = errors.New("network link is down")
+
+// Connector carries messages between a Bridge and an external system.
+type Connector interface {
+	// Name identifies the external system in logs and errors
+	Name() string
+
+	// Send delivers a local message to the external system
+	Send(msg *Message) error
+
+	// Listen calls deliver for every message the external system
+	// publishes on topicName
+	Listen(topicName string, deliver func(*Message)) error
+
+	// Close stops listening and releases the connection
+	Close() error
+}
+
+// BridgeStats counts what a bridge has moved.
+type BridgeStats struct {
+	Forwarded    int64 // Local messages sent through the connector
+	Received     int64 // Remote messages republished locally
+	Failed       int64 // Sends or local publishes that returned an error
+	LoopsDropped int64 // Messages dropped because they already crossed this bridge
+}
+
+// Bridge forwards messages between a broker and a connector.
+type Bridge struct {
+	id        string
+	broker    *MessageBroker
+	connector Connector
+
+	mutex    sync.Mutex
+	outbound []string // Local topics subscribed for forwarding
+	closed   bool
+
+	forwarded    atomic.Int64
+	received     atomic.Int64
+	failed       atomic.Int64
+	loopsDropped atomic.Int64
+}
+
+// NewBridge creates a bridge; nothing is forwarded until ForwardOut,
+// ForwardIn or Mirror is called. The ID must be unique across all bridges
+// messages can cross.
+func NewBridge(id string, broker *MessageBroker, connector Connector) *Bridge {
+	return &Bridge{id: id, broker: broker, connector: connector}
+}
+
+// GetID returns the bridge's ID.
+func (br *Bridge) GetID() string {
+	return br.id
+}
+
+// subscriberID is the ID of the bridge's subscription on local topics.
+func (br *Bridge) subscriberID() string {
+	return "bridge:" + br.id
+}
+
+// ForwardOut sends every message published on the local topics through the connector.
+// Returns an error if a topic doesn't exist or the bridge is closed.
+func (br *Bridge) ForwardOut(topicNames ...string) error {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+	if br.closed {
+		return fmt.Errorf("bridge %s is closed", br.id)
+	}
+
+	for _, topicName := range topicNames {
+		subscriber := NewSubscriber(br.subscriberID(), br.send)
+		if err := br.broker.Subscribe(topicName, subscriber); err != nil {
+			return err
+		}
+		br.outbound = append(br.outbound, topicName)
+	}
+	return nil
+}
+
+// ForwardIn republishes messages arriving from the connector on the local
+// topics of the same name. Returns an error if a topic doesn't exist locally,
+// the connector refuses to listen or the bridge is closed.
+func (br *Bridge) ForwardIn(topicNames ...string) error {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+	if br.closed {
+		return fmt.Errorf("bridge %s is closed", br.id)
+	}
+
+	for _, topicName := range topicNames {
+		if br.broker.GetTopic(topicName) == nil {
+			return fmt.Errorf("topic not found: %s", topicName)
+		}
+		if err := br.connector.Listen(topicName, br.receive); err != nil {
+			return fmt.Errorf("bridge %s: %s: %w", br.id, br.connector.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Mirror forwards the topics in both directions.
+func (br *Bridge) Mirror(topicNames ...string) error {
+	if err := br.ForwardOut(topicNames...); err != nil {
+		return err
+	}
+	return br.ForwardIn(topicNames...)
+}
+
+// send forwards one local message through the connector.
+func (br *Bridge) send(msg *Message) {
+	if msg.GetHeader(HeaderRetained) != "" {
+		return // Already forwarded when it was first published
+	}
+	if !br.admit(msg) {
+		return
+	}
+	if err := br.connector.Send(br.forwardedCopy(msg)); err != nil {
+		br.failed.Add(1)
+		return
+	}
+	br.forwarded.Add(1)
+}
+
+// receive republishes one remote message on the local broker.
+func (br *Bridge) receive(msg *Message) {
+	if !br.admit(msg) {
+		return
+	}
+	if err := br.broker.PublishMessage(br.forwardedCopy(msg)); err != nil {
+		br.failed.Add(1)
+		return
+	}
+	br.received.Add(1)
+}
+
+// admit reports whether the message may cross this bridge, counting the
+// ones dropped to prevent loops.
+func (br *Bridge) admit(msg *Message) bool {
+	path := bridgePath(msg)
+	for _, bridgeID := range path {
+		if bridgeID == br.id {
+			br.loopsDropped.Add(1)
+			return false
+		}
+	}
+	if len(path) >= MaxBridgeHops {
+		br.loopsDropped.Add(1)
+		return false
+	}
+	return true
+}
+
+// bridgePath returns the IDs of the bridges a message crossed, in order.
+func bridgePath(msg *Message) []string {
+	path := msg.GetHeader(HeaderBridgePath)
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ",")
+}
+
+// forwardedCopy copies a message for the other side, stamped with this
+// bridge. Subscribers on both sides may hold the original, so it is never
+// modified.
+func (br *Bridge) forwardedCopy(msg *Message) *Message {
+	forwarded := NewMessage(msg.Topic, msg.Payload)
+	forwarded.Timestamp = msg.Timestamp
+	forwarded.ExpiresAt = msg.ExpiresAt
+	forwarded.Priority = msg.Priority
+	for key, value := range msg.Headers {
+		forwarded.Headers[key] = value
+	}
+	if forwarded.GetHeader(HeaderBridgeSourceID) == "" {
+		forwarded.SetHeader(HeaderBridgeSourceID, msg.ID)
+	}
+	forwarded.SetHeader(HeaderBridgePath, strings.Join(append(bridgePath(msg), br.id), ","))
+	return forwarded
+}
+
+// GetStats returns the bridge's counters.
+func (br *Bridge) GetStats() BridgeStats {
+	return BridgeStats{
+		Forwarded:    br.forwarded.Load(),
+		Received:     br.received.Load(),
+		Failed:       br.failed.Load(),
+		LoopsDropped: br.loopsDropped.Load(),
+	}
+}
+
+// Close unsubscribes from the local topics and closes the connector.
+func (br *Bridge) Close() error {
+	br.mutex.Lock()
+	if br.closed {
+		br.mutex.Unlock()
+		return nil
+	}
+	br.closed = true
+	outbound := br.outbound
+	br.outbound = nil
+	br.mutex.Unlock()
+
+	for _, topicName := range outbound {
+		_ = br.broker.Unsubscribe(topicName, br.subscriberID())
+	}
+	return br.connector.Close()
+}
+
+// PublishMessage publishes a message built elsewhere (e.g. received by a
+// Bridge), keeping its headers, priority and expiry. A "publisher" header
+// is dropped, since the caller isn't authenticated. Returns an error if the
+// topic doesn't exist, is protected by an ACL or the payload doesn't match
+// the topic's schema.
+func (b *MessageBroker) PublishMessage(msg *Message) error {
+	topic, err := b.openTopic(msg.Topic)
+	if err != nil {
+		return err
+	}
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]string)
+	}
+	// Only PublishAs may vouch for a publisher
+	delete(msg.Headers, HeaderPublisher)
+	if err := b.schemas.validate(msg, 0); err != nil {
+		return err
+	}
+	topic.Publish(msg)
+	return nil
+}
+
+// ========== BROKER CONNECTOR ==========
+// BrokerConnector bridges to another MessageBroker in the same process,
+// e.g. a per-tenant or per-module broker that should see some topics.
+
+type BrokerConnector struct {
+	name   string
+	remote *MessageBroker
+
+	mutex     sync.Mutex
+	listening []string // Remote topics subscribed by Listen
+	closed    bool
+}
+
+// NewBrokerConnector creates a connector to the remote broker.
+func NewBrokerConnector(name string, remote *MessageBroker) *BrokerConnector {
+	return &BrokerConnector{name: name, remote: remote}
+}
+
+// Name returns the connector's name.
+func (c *BrokerConnector) Name() string {
+	return c.name
+}
+
+// subscriberID is the ID of the connector's subscription on remote topics.
+func (c *BrokerConnector) subscriberID() string {
+	return "connector:" + c.name
+}
+
+// Send publishes the message on the remote broker.
+func (c *BrokerConnector) Send(msg *Message) error {
+	c.mutex.Lock()
+	closed := c.closed
+	c.mutex.Unlock()
+	if closed {
+		return ErrConnectorClosed
+	}
+	return c.remote.PublishMessage(msg)
+}
+
+// Listen subscribes to the remote topic.
+func (c *BrokerConnector) Listen(topicName string, deliver func(*Message)) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return ErrConnectorClosed
+	}
+	if err := c.remote.Subscribe(topicName, NewSubscriber(c.subscriberID(), deliver)); err != nil {
+		return err
+	}
+	c.listening = append(c.listening, topicName)
+	return nil
+}
+
+// Close unsubscribes from every remote topic.
+func (c *BrokerConnector) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	for _, topicName := range c.listening {
+		_ = c.remote.Unsubscribe(topicName, c.subscriberID())
+	}
+	c.listening = nil
+	return nil
+}
+
+// ========== NETWORK CONNECTOR (MOCK TRANSPORT) ==========
+// NewNetworkLink returns the two ends of a simulated network connection.
+// Each end is a Connector: what one end sends, the other end's listeners
+// receive after the link latency. Messages cross the link as JSON, so only
+// JSON-encodable payloads can be sent. SetDown simulates a partition: sends
+// fail with ErrLinkDown until the link comes back.
+
+// wireMessage is the JSON form of a message on the link.
+type wireMessage struct {
+	ID        string            `json:"id"`
+	Topic     string            `json:"topic"`
+	Payload   json.RawMessage   `json:"payload"`
+	Timestamp time.Time         `json:"timestamp"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Priority  string            `json:"priority"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// networkInboxSize is how many frames an end buffers before sends fail.
+const networkInboxSize = 256
+
+// NetworkLink is the simulated connection shared by two NetworkConnectors.
+type NetworkLink struct {
+	latency time.Duration
+	down    atomic.Bool
+}
+
+// NetworkConnector is one end of a NetworkLink.
+type NetworkConnector struct {
+	name string
+	link *NetworkLink
+	peer *NetworkConnector
+
+	inbox chan []byte   // Frames sent by the peer
+	done  chan struct{} // Closed to stop the receive loop
+
+	mutex     sync.RWMutex
+	listeners map[string][]func(*Message) // Topic -> callbacks
+	closed    bool
+}
+
+// NewNetworkLink connects two named ends with the given one-way latency.
+func NewNetworkLink(nameA, nameB string, latency time.Duration) (*NetworkConnector, *NetworkConnector) {
+	link := &NetworkLink{latency: latency}
+	endA := newNetworkConnector(nameA, link)
+	endB := newNetworkConnector(nameB, link)
+	endA.peer, endB.peer = endB, endA
+	go endA.receiveLoop()
+	go endB.receiveLoop()
+	return endA, endB
+}
+
+// newNetworkConnector creates one end of a link.
+func newNetworkConnector(name string, link *NetworkLink) *NetworkConnector {
+	return &NetworkConnector{
+		name:      name,
+		link:      link,
+		inbox:     make(chan []byte, networkInboxSize),
+		done:      make(chan struct{}),
+		listeners: make(map[string][]func(*Message)),
+	}
+}
+
+// Name returns the name of this end.
+func (c *NetworkConnector) Name() string {
+	return c.name
+}
+
+// SetDown takes the link down (or brings it back up) for both ends.
+func (c *NetworkConnector) SetDown(down bool) {
+	c.link.down.Store(down)
+}
+
+// Send serializes the message and queues it for the other end.
+func (c *NetworkConnector) Send(msg *Message) error {
+	c.mutex.RLock()
+	closed := c.closed
+	c.mutex.RUnlock()
+	if closed {
+		return ErrConnectorClosed
+	}
+	if c.link.down.Load() {
+		return ErrLinkDown
+	}
+
+	payload, err := json.Marshal(msg.Payload)
+	if err != nil {
+		return fmt.Errorf("encode payload for %s: %w", c.name, err)
+	}
+	frame, err := json.Marshal(wireMessage{
+		ID:        msg.ID,
+		Topic:     msg.Topic,
+		Payload:   payload,
+		Timestamp: msg.Timestamp,
+		ExpiresAt: msg.ExpiresAt,
+		Priority:  msg.Priority.String(),
+		Headers:   msg.Headers,
+	})
+	if err != nil {
+		return fmt.Errorf("encode message for %s: %w", c.name, err)
+	}
+
+	select {
+	case c.peer.inbox <- frame:
+		return nil
+	case <-c.peer.done:
+		return fmt.Errorf("%s: peer %s: %w", c.name, c.peer.name, ErrConnectorClosed)
+	default:
+		return fmt.Errorf("%s: peer %s is not keeping up", c.name, c.peer.name)
+	}
+}
+
+// Listen registers a callback for frames on topicName.
+func (c *NetworkConnector) Listen(topicName string, deliver func(*Message)) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return ErrConnectorClosed
+	}
+	c.listeners[topicName] = append(c.listeners[topicName], deliver)
+	return nil
+}
+
+// receiveLoop decodes frames from the peer and hands them to listeners.
+func (c *NetworkConnector) receiveLoop() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case frame := <-c.inbox:
+			if c.link.latency > 0 {
+				time.Sleep(c.link.latency)
+			}
+			msg, err := decodeWireMessage(frame)
+			if err != nil {
+				continue // A real transport would log and skip the corrupt frame
+			}
+			c.mutex.RLock()
+			listeners := c.listeners[msg.Topic]
+			c.mutex.RUnlock()
+			for _, deliver := range listeners {
+				deliver(msg)
+			}
+		}
+	}
+}
+
+// decodeWireMessage rebuilds a message from a frame.
+func decodeWireMessage(frame []byte) (*Message, error) {
+	var wire wireMessage
+	if err := json.Unmarshal(frame, &wire); err != nil {
+		return nil, err
+	}
+	var payload interface{}
+	if err := json.Unmarshal(wire.Payload, &payload); err != nil {
+		return nil, err
+	}
+	priority, err := parsePriority(wire.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := wire.Headers
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	return &Message{
+		ID:        wire.ID,
+		Topic:     wire.Topic,
+		Payload:   payload,
+		Timestamp: wire.Timestamp,
+		ExpiresAt: wire.ExpiresAt,
+		Priority:  priority,
+		Headers:   headers,
+	}, nil
+}
+
+// Close stops the receive loop; frames still queued are dropped.
+func (c *NetworkConnector) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	return nil
+}
+
+// ========== CONSUMER DEDUPLICATION ==========
+// Delivery here is at-least-once: a producer that times out waiting for an
+// acknowledgement publishes again, a bridge re-sends after a partition, a
+// snapshot is replayed. The usual answer is the idempotent-consumer pattern:
+//
+//  1. The producer gives every logical message a stable ID
+//     (PublishWithID), reused on every retry of that message.
+//  2. Each consumer remembers the IDs it has processed and skips repeats
+//     (DedupSubscriber).
+//
+// The memory is a bounded LRU per subscriber, so this is "exactly-once-ish":
+// a duplicate arriving after its ID was evicted is processed again. Size the
+// window to cover the longest redelivery delay, and keep side effects that
+// must never repeat (charging a card) idempotent in the handler's own store.
+//
+// An ID is recorded when processing starts, so a concurrent duplicate is
+// skipped while the first copy is in flight. If the handler panics the ID is
+// forgotten again, and a redelivery after the failure is processed.
+
+// DefaultDedupWindow is how many message IDs a DedupSubscriber remembers.
+const DefaultDedupWindow = 1024
+
+// DedupKey returns the ID duplicates are detected by: the ID on the broker
+// that first published the message, so copies arriving over a bridge match
+// the original.
+func DedupKey(msg *Message) string {
+	if sourceID := msg.GetHeader(HeaderBridgeSourceID); sourceID != "" {
+		return sourceID
+	}
+	return msg.ID
+}
+
+// NewMessageWithID creates a message with a producer-supplied ID.
+func NewMessageWithID(topic, id string, payload interface{}) *Message {
+	message := NewMessage(topic, payload)
+	message.ID = id
+	return message
+}
+
+// PublishWithID sends a message whose ID is chosen by the producer. Retrying
+// a publish with the same ID lets deduplicating subscribers drop the repeat.
+// Returns the created message and an error if the topic doesn't exist.
+func (b *MessageBroker) PublishWithID(topicName, messageID string, payload interface{}) (*Message, error) {
+	topic, err := b.openTopic(topicName)
+	if err != nil {
+		return nil, err
+	}
+	if messageID == "" {
+		return nil, fmt.Errorf("message ID must not be empty")
+	}
+
+	message := NewMessageWithID(topicName, messageID, payload)
+	if err := b.schemas.validate(message, 0); err != nil {
+		return nil, err
+	}
+	topic.Publish(message)
+
+	return message, nil
+}
+
+// Deduplicator remembers the most recently seen message IDs (LRU order).
+type Deduplicator struct {
+	capacity int
+	order    *list.List               // Front = most recently seen ID
+	entries  map[string]*list.Element // ID -> its element in order
+	evicted  int64
+	mutex    sync.Mutex
+}
+
+// NewDeduplicator creates a deduplicator remembering up to capacity IDs.
+func NewDeduplicator(capacity int) (*Deduplicator, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("dedup capacity must be positive, got %d", capacity)
+	}
+	return &Deduplicator{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}, nil
+}
+
+// FirstSeen records id and reports whether it was new. A repeat refreshes
+// the ID's position, so IDs that keep being redelivered are not evicted.
+func (d *Deduplicator) FirstSeen(id string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if element, seen := d.entries[id]; seen {
+		d.order.MoveToFront(element)
+		return false
+	}
+	d.entries[id] = d.order.PushFront(id)
+	if d.order.Len() > d.capacity {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.entries, oldest.Value.(string))
+		d.evicted++
+	}
+	return true
+}
+
+// Forget removes id, so its next delivery is processed.
+func (d *Deduplicator) Forget(id string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if element, seen := d.entries[id]; seen {
+		d.order.Remove(element)
+		delete(d.entries, id)
+	}
+}
+
+// Contains reports whether id is remembered.
+func (d *Deduplicator) Contains(id string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	_, seen := d.entries[id]
+	return seen
+}
+
+// Len returns how many IDs are remembered.
+func (d *Deduplicator) Len() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.order.Len()
+}
+
+// Evicted returns how many IDs were dropped to stay within capacity.
+func (d *Deduplicator) Evicted() int64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.evicted
+}
+
+// DedupStats counts what a DedupSubscriber did with its deliveries.
+type DedupStats struct {
+	Processed  int64 // Deliveries handed to the wrapped subscriber
+	Duplicates int64 // Deliveries skipped because the ID was remembered
+	Evicted    int64 // IDs dropped from the window to make room
+}
+
+// DedupSubscriber wraps a subscriber and drops messages it already processed.
+type DedupSubscriber struct {
+	inner      Subscriber
+	seen       *Deduplicator
+	processed  atomic.Int64
+	duplicates atomic.Int64
+}
+
+// NewDedupSubscriber wraps inner with a window of the last window message IDs.
+// The wrapper keeps inner's ID, so it subscribes in its place.
+func NewDedupSubscriber(inner Subscriber, window int) (*DedupSubscriber, error) {
+	seen, err := NewDeduplicator(window)
+	if err != nil {
+		return nil, err
+	}
+	return &DedupSubscriber{inner: inner, seen: seen}, nil
+}
+
+// GetID returns the wrapped subscriber's ID.
+func (s *DedupSubscriber) GetID() string {
+	return s.inner.GetID()
+}
+
+// OnMessage forwards the first delivery of each message ID and skips repeats.
+func (s *DedupSubscriber) OnMessage(msg *Message) {
+	_ = s.HandleMessage(msg)
+}
+
+// HandleMessage is OnMessage that passes the wrapped subscriber's error
+// through, so topics see failures of a FallibleSubscriber behind the dedup.
+// A failed attempt (error or panic) forgets the ID, so a redelivery is
+// processed instead of being dropped as a duplicate.
+func (s *DedupSubscriber) HandleMessage(msg *Message) error {
+	key := DedupKey(msg)
+	if !s.seen.FirstSeen(key) {
+		s.duplicates.Add(1)
+		return nil
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.seen.Forget(key)
+			panic(recovered)
+		}
+	}()
+	if err := callSubscriber(s.inner, msg); err != nil {
+		s.seen.Forget(key)
+		return err
+	}
+	s.processed.Add(1)
+	return nil
+}
+
+// GetStats returns the subscriber's dedup counters.
+func (s *DedupSubscriber) GetStats() DedupStats {
+	return DedupStats{
+		Processed:  s.processed.Load(),
+		Duplicates: s.duplicates.Load(),
+		Evicted:    s.seen.Evicted(),
+	}
+}
+
+// ========== SUBSCRIBER FAILURE ISOLATION ==========
+// Every delivery runs in its own goroutine (or a per-subscriber worker), so
+// a panicking subscriber used to take the whole process down with it. Now
+// each delivery is a bulkhead:
+//
+//	deliver ──► recover() ──► panic?  ──► FailureHandler.OnPanic + count
+//	                     └──► error?  ──► FailureHandler.OnError
+//	                     └──► success ──► reset the panic count
+//
+// Subscribers that want to report failures without panicking implement
+// FallibleSubscriber (NewFallibleSubscriber wraps a func returning error).
+//
+// A subscriber that panics QuarantineThreshold times in a row on the same
+// topic is quarantined: unsubscribed from that topic and parked until
+// ReleaseQuarantined re-subscribes it (after a fix or a restart of its
+// dependency). Errors never quarantine: an error is a handled failure.
+
+// DefaultQuarantineThreshold is how many consecutive panics quarantine a subscriber.
+const DefaultQuarantineThreshold = 3
+
+// FallibleSubscriber is a Subscriber whose handler reports failures as errors.
+// Topics call HandleMessage instead of OnMessage when it is implemented.
+type FallibleSubscriber interface {
+	Subscriber
+	HandleMessage(msg *Message) error
+}
+
+// FuncSubscriber is a FallibleSubscriber backed by a handler function.
+type FuncSubscriber struct {
+	id      string
+	handler func(*Message) error
+}
+
+// NewFallibleSubscriber creates a subscriber whose handler can return an error.
+func NewFallibleSubscriber(id string, handler func(*Message) error) *FuncSubscriber {
+	return &FuncSubscriber{id: id, handler: handler}
+}
+
+// GetID returns the subscriber's unique identifier.
+func (s *FuncSubscriber) GetID() string {
+	return s.id
+}
+
+// OnMessage runs the handler and drops its error (callers outside a topic).
+func (s *FuncSubscriber) OnMessage(msg *Message) {
+	_ = s.HandleMessage(msg)
+}
+
+// HandleMessage runs the handler and returns its error.
+func (s *FuncSubscriber) HandleMessage(msg *Message) error {
+	if s.handler == nil {
+		return nil
+	}
+	return s.handler(msg)
+}
+
+// callSubscriber hands a message to a subscriber, returning its error if it
+// can report one.
+func callSubscriber(subscriber Subscriber, msg *Message) error {
+	if fallible, ok := subscriber.(FallibleSubscriber); ok {
+		return fallible.HandleMessage(msg)
+	}
+	subscriber.OnMessage(msg)
+	return nil
+}
+
+// DeliveryFailure describes one failed delivery, with the offending message.
+type DeliveryFailure struct {
+	Topic        string
+	SubscriberID string
+	Message      *Message
+	Err          error       // Returned error, or the panic wrapped as an error
+	PanicValue   interface{} // Value passed to panic (nil for returned errors)
+	Stack        []byte      // Goroutine stack at the panic (nil for returned errors)
+	Consecutive  int         // Panics in a row by this subscriber on this topic
+	Quarantined  bool        // This failure put the subscriber in quarantine
+	At           time.Time
+}
+
+// FailureHandler is notified of failed deliveries. Callbacks run on the
+// delivering goroutine, so they must be fast and thread-safe.
+// A panicking callback is recovered and logged.
+type FailureHandler interface {
+	OnError(failure DeliveryFailure)
+	OnPanic(failure DeliveryFailure)
+}
+
+// FailureHooks adapts plain functions to FailureHandler.
+// Nil functions are skipped.
+type FailureHooks struct {
+	Error func(DeliveryFailure)
+	Panic func(DeliveryFailure)
+}
+
+func (h FailureHooks) OnError(failure DeliveryFailure) {
+	if h.Error != nil {
+		h.Error(failure)
+	}
+}
+
+func (h FailureHooks) OnPanic(failure DeliveryFailure) {
+	if h.Panic != nil {
+		h.Panic(failure)
+	}
+}
+
+// QuarantinedSubscriber is a subscriber removed from a topic for panicking.
+type QuarantinedSubscriber struct {
+	Topic         string
+	Subscriber    Subscriber
+	Panics        int       // Consecutive panics that triggered the quarantine
+	LastPanic     string    // fmt of the last panic value
+	QuarantinedAt time.Time // When it was removed
+}
+
+// subscriptionKey identifies one subscriber on one topic.
+type subscriptionKey struct {
+	topic        string
+	subscriberID string
+}
+
+// supervisor is shared by a broker and all of its topics. It tracks panics
+// per subscription, notifies failure handlers and quarantines repeat offenders.
+type supervisor struct {
+	handlers    []FailureHandler
+	threshold   int // Consecutive panics before quarantine (0 = never)
+	consecutive map[subscriptionKey]int
+	quarantined map[subscriptionKey]*QuarantinedSubscriber
+	mutex       sync.Mutex
+}
+
+// newSupervisor creates a supervisor with the default quarantine threshold.
+func newSupervisor() *supervisor {
+	return &supervisor{
+		threshold:   DefaultQuarantineThreshold,
+		consecutive: make(map[subscriptionKey]int),
+		quarantined: make(map[subscriptionKey]*QuarantinedSubscriber),
+	}
+}
+
+// invoke runs one delivery, recovering a panicking subscriber so it cannot
+// crash the process. Reports whether the subscriber handled the message.
+func (t *Topic) invoke(subscriber Subscriber, msg *Message) (handled bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			handled = false
+			t.failedDeliveries.Add(1)
+			t.supervisor.panicked(t, subscriber, msg, recovered, debug.Stack())
+		}
+	}()
+
+	if err := t.telemetry.deliver(subscriber, msg); err != nil {
+		t.failedDeliveries.Add(1)
+		t.supervisor.failed(t, subscriber, msg, err)
+		return false
+	}
+	t.supervisor.succeeded(t, subscriber)
+	return true
+}
+
+// GetFailedDeliveryCount returns how many deliveries panicked or returned an error.
+func (t *Topic) GetFailedDeliveryCount() int64 {
+	return t.failedDeliveries.Load()
+}
+
+// succeeded resets the subscription's panic streak.
+func (sv *supervisor) succeeded(t *Topic, subscriber Subscriber) {
+	if sv == nil {
+		return
+	}
+	key := subscriptionKey{t.name, subscriber.GetID()}
+	sv.mutex.Lock()
+	delete(sv.consecutive, key)
+	sv.mutex.Unlock()
+}
+
+// failed reports a returned error. Errors end a panic streak but never
+// quarantine the subscriber.
+func (sv *supervisor) failed(t *Topic, subscriber Subscriber, msg *Message, err error) {
+	if sv == nil {
+		return
+	}
+	key := subscriptionKey{t.name, subscriber.GetID()}
+	sv.mutex.Lock()
+	delete(sv.consecutive, key)
+	handlers := sv.handlers
+	sv.mutex.Unlock()
+
+	failure := DeliveryFailure{
+		Topic: t.name, SubscriberID: key.subscriberID, Message: msg, Err: err, At: time.Now(),
+	}
+	for _, handler := range handlers {
+		notifyHandler(handler.OnError, failure)
+	}
+}
+
+// panicked records a recovered panic, quarantining the subscriber once its
+// streak reaches the threshold, then notifies the failure handlers.
+func (sv *supervisor) panicked(t *Topic, subscriber Subscriber, msg *Message, value interface{}, stack []byte) {
+	if sv == nil {
+		return // Standalone topic: the panic is recovered and counted only
+	}
+	key := subscriptionKey{t.name, subscriber.GetID()}
+	failure := DeliveryFailure{
+		Topic:        t.name,
+		SubscriberID: key.subscriberID,
+		Message:      msg,
+		Err:          fmt.Errorf("subscriber %s panicked: %v", key.subscriberID, value),
+		PanicValue:   value,
+		Stack:        stack,
+		At:           time.Now(),
+	}
+
+	sv.mutex.Lock()
+	if _, parked := sv.quarantined[key]; parked {
+		// A delivery already in flight when the quarantine started
+		sv.mutex.Unlock()
+		return
+	}
+	sv.consecutive[key]++
+	failure.Consecutive = sv.consecutive[key]
+	if sv.threshold > 0 && failure.Consecutive >= sv.threshold {
+		failure.Quarantined = true
+		delete(sv.consecutive, key)
+		sv.quarantined[key] = &QuarantinedSubscriber{
+			Topic:         t.name,
+			Subscriber:    subscriber,
+			Panics:        failure.Consecutive,
+			LastPanic:     fmt.Sprint(value),
+			QuarantinedAt: failure.At,
+		}
+	}
+	handlers := sv.handlers
+	sv.mutex.Unlock()
+
+	if failure.Quarantined {
+		t.Unsubscribe(key.subscriberID)
+	}
+	for _, handler := range handlers {
+		notifyHandler(handler.OnPanic, failure)
+	}
+}
+
+// notifyHandler runs one failure callback under its own recover. Callbacks
+// run inside Topic.invoke (OnPanic from its deferred recover), so a panic
+// here would otherwise crash the process or be blamed on the subscriber.
+func notifyHandler(callback func(DeliveryFailure), failure DeliveryFailure) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fmt.Printf("⚠️  Failure handler panicked on %s/%s: %v\n",
+				failure.Topic, failure.SubscriberID, recovered)
+		}
+	}()
+	callback(failure)
+}
+
+// AddFailureHandler registers callbacks for failed deliveries on every topic.
+func (b *MessageBroker) AddFailureHandler(handler FailureHandler) {
+	b.supervisor.mutex.Lock()
+	defer b.supervisor.mutex.Unlock()
+
+	// Copy-on-write: callbacks already running keep iterating the old slice
+	handlers := make([]FailureHandler, 0, len(b.supervisor.handlers)+1)
+	handlers = append(handlers, b.supervisor.handlers...)
+	b.supervisor.handlers = append(handlers, handler)
+}
+
+// SetQuarantineThreshold sets how many consecutive panics quarantine a
+// subscriber (0 disables quarantine; panics are still recovered).
+func (b *MessageBroker) SetQuarantineThreshold(panics int) error {
+	if panics < 0 {
+		return fmt.Errorf("quarantine threshold cannot be negative: %d", panics)
+	}
+	b.supervisor.mutex.Lock()
+	defer b.supervisor.mutex.Unlock()
+	b.supervisor.threshold = panics
+	return nil
+}
+
+// GetQuarantined returns the quarantined subscribers, by topic then subscriber ID.
+func (b *MessageBroker) GetQuarantined() []QuarantinedSubscriber {
+	b.supervisor.mutex.Lock()
+	defer b.supervisor.mutex.Unlock()
+
+	parked := make([]QuarantinedSubscriber, 0, len(b.supervisor.quarantined))
+	for _, entry := range b.supervisor.quarantined {
+		parked = append(parked, *entry)
+	}
+	sort.Slice(parked, func(i, j int) bool {
+		if parked[i].Topic != parked[j].Topic {
+			return parked[i].Topic < parked[j].Topic
+		}
+		return parked[i].Subscriber.GetID() < parked[j].Subscriber.GetID()
+	})
+	return parked
+}
+
+// ReleaseQuarantined re-subscribes a quarantined subscriber to its topic
+// with a clean panic streak.
+func (b *MessageBroker) ReleaseQuarantined(topicName, subscriberID string) error {
+	key := subscriptionKey{topicName, subscriberID}
+	b.supervisor.mutex.Lock()
+	entry, parked := b.supervisor.quarantined[key]
+	delete(b.supervisor.quarantined, key)
+	b.supervisor.mutex.Unlock()
+
+	if !parked {
+		return fmt.Errorf("subscriber %s is not quarantined on topic %s", subscriberID, topicName)
+	}
+	if !b.acl.IsProtected(topicName) {
+		return b.Subscribe(topicName, entry.Subscriber)
+	}
+
+	// A protected subscription comes back only while its owner still holds
+	// subscribe access; RevokeAccess drops the ownership record
+	clientID, owned := b.subscriptionOwner(topicName, subscriberID)
+	if !owned || !b.acl.IsAllowed(topicName, clientID, PermissionSubscribe) {
+		return fmt.Errorf("%w: subscriber %s no longer has subscribe access on %s", ErrForbidden, subscriberID, topicName)
+	}
+	topic := b.GetTopic(topicName)
+	if topic == nil {
+		return fmt.Errorf("topic not found: %s", topicName)
+	}
+	topic.Subscribe(entry.Subscriber)
+	return nil
+}
+
+// subscriptionOwner returns the client that subscribed subscriberID to a
+// topic through SubscribeAs.
+func (b *MessageBroker) subscriptionOwner(topicName, subscriberID string) (string, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for clientID, subscriberIDs := range b.clientSubscriptions[topicName] {
+		for _, id := range subscriberIDs {
+			if id == subscriberID {
+				return clientID, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,8 @@
+// Command demo runs the urlshortener walkthrough: go run ./20_url_shortener/cmd/demo
+package main
+
+import urlshortener "github.com/ayushgupta5/GoLLD/20_url_shortener"
+
+func main() {
+	urlshortener.RunDemo()
+}
@@ -0,0 +1,402 @@
+package urlshortener
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	ratelimiter "github.com/ayushgupta5/GoLLD/09_rate_limiter"
+)
+
+// ========== MAIN ==========
+
+// RunDemo prints a walkthrough of every feature; cmd/demo calls it.
+func RunDemo() {
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("        🔗 URL SHORTENER SERVICE")
+	fmt.Println("═══════════════════════════════════════════")
+
+	shortener := NewURLShortener("https://short.ly")
+
+	// Create short URLs
+	fmt.Println("\n📝 Creating Short URLs...")
+	fmt.Println("─────────────────────────────────────────")
+
+	url1, _ := shortener.Shorten("https://www.example.com/very/long/path/to/page?with=query&params=123", "user1", 0)
+	fmt.Printf("✅ %s\n   → Original: https://www.example.com/very/long/...\n", url1)
+
+	url2, _ := shortener.Shorten("https://github.com/golang/go/wiki/CodeReviewComments", "user1", 30)
+	fmt.Printf("✅ %s (expires in 30 days)\n   → Original: https://github.com/golang/...\n", url2)
+
+	url3, _ := shortener.Shorten("https://docs.google.com/document/d/abc123/edit", "user2", 0)
+	fmt.Printf("✅ %s\n   → Original: https://docs.google.com/...\n", url3)
+
+	// Custom short URL
+	url4, err := shortener.ShortenCustom("https://myportfolio.com/projects", "mywork", "user1")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	} else {
+		fmt.Printf("✅ %s (custom)\n   → Original: https://myportfolio.com/projects\n", url4)
+	}
+
+	// Try duplicate custom code
+	_, err = shortener.ShortenCustom("https://other.com", "mywork", "user2")
+	if err != nil {
+		fmt.Printf("❌ Custom code error: %v\n", err)
+	}
+
+	// Resolve URLs
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔍 Resolving Short URLs...")
+
+	codes := []string{"0000001", "0000002", "mywork", "invalid"}
+	for _, code := range codes {
+		original, err := shortener.Resolve(code)
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", code, err)
+		} else {
+			displayURL := original
+			if len(original) > 50 {
+				displayURL = original[:50] + "..."
+			}
+			fmt.Printf("  ✅ %s → %s\n", code, displayURL)
+		}
+	}
+
+	// Simulate multiple clicks
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("👆 Simulating clicks...")
+
+	// Simulate 5 additional clicks on first URL (we already resolved once above)
+	for i := 0; i < 5; i++ {
+		_, _ = shortener.Resolve("0000001") // Ignoring errors for simulation
+	}
+	// Simulate 12 additional clicks on custom URL
+	for i := 0; i < 12; i++ {
+		_, _ = shortener.Resolve("mywork") // Ignoring errors for simulation
+	}
+	// Simulate 2 additional clicks on second URL
+	_, _ = shortener.Resolve("0000002")
+	_, _ = shortener.Resolve("0000002")
+
+	// Show statistics
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📊 URL Statistics...")
+
+	shortener.PrintStats("0000001")
+	shortener.PrintStats("mywork")
+
+	// List all URLs
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("📋 All Short URLs:")
+	for _, entry := range shortener.ListAll() {
+		status := "🟢"
+		if !entry.IsActive || entry.IsExpired() {
+			status = "🔴"
+		}
+		// Truncate long URLs for display
+		displayURL := entry.GetDestination()
+		if len(displayURL) > 40 {
+			displayURL = displayURL[:40] + "..."
+		}
+		fmt.Printf("  %s %s → %s (clicks: %d)\n",
+			status, entry.ShortCode, displayURL, entry.GetClickCount())
+	}
+
+	// Delete a URL
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🗑️  Deleting short URL...")
+	err = shortener.Delete("0000001")
+	if err != nil {
+		fmt.Printf("  Error deleting: %v\n", err)
+	} else {
+		fmt.Println("  Successfully deleted 0000001")
+	}
+
+	_, err = shortener.Resolve("0000001")
+	fmt.Printf("  Resolve deleted URL: %v\n", err)
+
+	// Redirect rules
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🔀 Redirect Rules...")
+
+	_, _ = shortener.ShortenCustom("https://myapp.com/download", "getapp", "user1")
+
+	// Device targets first, so mobile visitors go straight to their store
+	_ = shortener.AddRedirectRule("getapp", &DeviceRule{Device: DeviceIOS, Destination: "https://apps.apple.com/app/myapp"})
+	_ = shortener.AddRedirectRule("getapp", &DeviceRule{Device: DeviceAndroid, Destination: "https://play.google.com/store/apps/myapp"})
+
+	// Weekend promo page, live for the next two days only
+	promoRule, _ := NewTimeWindowRule(time.Now().Add(-time.Hour), time.Now().Add(48*time.Hour), "https://myapp.com/weekend-promo")
+	_ = shortener.AddRedirectRule("getapp", promoRule)
+
+	visitors := []RedirectRequest{
+		{IPAddress: "10.0.0.1", UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0)"},
+		{IPAddress: "10.0.0.2", UserAgent: "Mozilla/5.0 (Linux; Android 14)"},
+		{IPAddress: "10.0.0.3", UserAgent: "Mozilla/5.0 (Windows NT 10.0)"},
+		{IPAddress: "10.0.0.3", UserAgent: "Mozilla/5.0 (Windows NT 10.0)", Time: time.Now().Add(72 * time.Hour)},
+	}
+	for _, visitor := range visitors {
+		destination, _ := shortener.ResolveRequest("getapp", visitor)
+		fmt.Printf("  %-8s → %s\n", DetectDevice(visitor.UserAgent), destination)
+	}
+
+	// A/B test two landing pages with a 70/30 split
+	_, _ = shortener.ShortenCustom("https://shop.com/landing", "spring", "user2")
+	splitRule, err := NewSplitRule(
+		WeightedDestination{URL: "https://shop.com/landing-a", Weight: 70},
+		WeightedDestination{URL: "https://shop.com/landing-b", Weight: 30},
+	)
+	if err != nil {
+		fmt.Printf("  Error: %v\n", err)
+	} else {
+		_ = shortener.AddRedirectRule("spring", splitRule)
+	}
+
+	for i := 0; i < 100; i++ {
+		visitor := RedirectRequest{IPAddress: fmt.Sprintf("192.168.1.%d", i)}
+		_, _ = shortener.ResolveRequest("spring", visitor)
+	}
+	// Same visitor always lands on the same variant
+	first, _ := shortener.ResolveRequest("spring", RedirectRequest{IPAddress: "192.168.1.7"})
+	second, _ := shortener.ResolveRequest("spring", RedirectRequest{IPAddress: "192.168.1.7"})
+	fmt.Printf("  Sticky assignment for 192.168.1.7: %v\n", first == second)
+
+	breakdown := shortener.GetDestinationBreakdown("spring")
+	fmt.Printf("  A/B clicks: landing-a=%d, landing-b=%d\n",
+		breakdown["https://shop.com/landing-a"], breakdown["https://shop.com/landing-b"])
+
+	// Link editing with versioned destination history
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("✏️  Editing a Link's Destination...")
+
+	_, _ = shortener.ShortenCustom("https://conf.example.com/agenda-draft", "agenda", "user1")
+	for i := 0; i < 3; i++ {
+		shortener.ResolveRequest("agenda", RedirectRequest{IPAddress: fmt.Sprintf("10.1.0.%d", i)})
+	}
+
+	var permissionErr *PermissionError
+	if _, err := shortener.UpdateDestination("agenda", "https://conf.example.com/agenda-final", "user2"); errors.As(err, &permissionErr) {
+		fmt.Printf("  ⛔ HTTP %d: %v\n", permissionErr.StatusCode(), permissionErr)
+	}
+	_ = shortener.GrantEditAccess("agenda", "user1", "user2")
+	if version, err := shortener.UpdateDestination("agenda", "https://conf.example.com/agenda-final", "user2"); err == nil {
+		fmt.Printf("  ✅ user2 published version %d → %s\n", version.Version, version.URL)
+	}
+	for i := 0; i < 5; i++ {
+		shortener.ResolveRequest("agenda", RedirectRequest{IPAddress: fmt.Sprintf("10.2.0.%d", i)})
+	}
+	_ = shortener.RevokeEditAccess("agenda", "user1", "user2")
+	if _, err := shortener.UpdateDestination("agenda", "https://conf.example.com/agenda-v3", "user2"); err != nil {
+		fmt.Printf("  ❌ After revoke: %v\n", err)
+	}
+
+	versionStats, _ := shortener.GetVersionStats("agenda")
+	for _, stats := range versionStats {
+		fmt.Printf("  v%d by %-5s %s → %-38s %d clicks\n",
+			stats.Version, stats.EditorID, stats.EditedAt.Format("15:04:05"), stats.URL, stats.Clicks)
+	}
+
+	// Abuse protection
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🛡️  Rate Limiting & Block List...")
+
+	codeLimiter := ratelimiter.NewFixedWindowRateLimiter(50, time.Minute)
+	shortener.SetRateLimiters(
+		ratelimiter.NewFixedWindowRateLimiter(5, time.Minute), // Per client IP
+		codeLimiter, // Per short code
+	)
+
+	scraper := RedirectRequest{IPAddress: "203.0.113.9"}
+	allowed := 0
+	for i := 0; i < 7; i++ {
+		_, err := shortener.ResolveRequest("mywork", scraper)
+		var throttleErr *ThrottleError
+		if errors.As(err, &throttleErr) {
+			fmt.Printf("  ❌ HTTP %d: %v\n", throttleErr.StatusCode(), throttleErr)
+			continue
+		}
+		allowed++
+	}
+	fmt.Printf("  203.0.113.9 resolved %d/7 before being throttled\n", allowed)
+
+	// Unknown codes are not tracked per code, so scanning costs no memory
+	for _, guess := range []string{"aaaaaa", "aaaaab", "aaaaac"} {
+		shortener.ResolveRequest(guess, RedirectRequest{IPAddress: "192.0.2.50"})
+	}
+	fmt.Printf("  After 3 guessed codes, per-code limiter tracks %d code(s)\n", codeLimiter.GetTrackedUsers())
+
+	shortener.GetBlockList().BlockIP("198.51.100.66", "credential-stuffing scanner")
+	shortener.GetBlockList().BlockCode("getapp", "phishing report")
+	blockedCalls := []struct {
+		code    string
+		request RedirectRequest
+	}{
+		{"mywork", RedirectRequest{IPAddress: "198.51.100.66"}},
+		{"getapp", RedirectRequest{IPAddress: "10.0.0.8"}},
+	}
+	for _, call := range blockedCalls {
+		_, err := shortener.ResolveRequest(call.code, call.request)
+		var blockedErr *BlockedError
+		if errors.As(err, &blockedErr) {
+			fmt.Printf("  ⛔ HTTP %d: %v\n", blockedErr.StatusCode(), blockedErr)
+		}
+	}
+
+	// Trash: restore and retention
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("♻️  Trash & Restore...")
+
+	_, _ = shortener.ShortenCustom("https://myportfolio.com/resume", "resume", "user1")
+	_ = shortener.Delete("resume")
+	for _, entry := range shortener.ListTrash("user1") {
+		displayURL := entry.URL
+		if len(displayURL) > 30 {
+			displayURL = displayURL[:30] + "..."
+		}
+		fmt.Printf("  🗑️  user1 trash: %-8s → %-33s purge on %s\n", entry.ShortCode, displayURL, entry.PurgeAt.Format("Jan 02"))
+	}
+
+	// The deleted alias stays reserved while it is in the trash
+	if _, err := shortener.ShortenCustom("https://impostor.example.com", "resume", "user2"); err != nil {
+		fmt.Printf("  ❌ user2 claiming the alias: %v\n", err)
+	}
+	var restoreErr *PermissionError
+	if err := shortener.Restore("resume", "user2"); errors.As(err, &restoreErr) {
+		fmt.Printf("  ⛔ HTTP %d: %v\n", restoreErr.StatusCode(), restoreErr)
+	}
+	if err := shortener.Restore("resume", "user1"); err == nil {
+		destination, _ := shortener.Resolve("resume")
+		fmt.Printf("  ✅ user1 restored resume → %s (%d left in trash)\n", destination, len(shortener.ListTrash("user1")))
+	}
+	shortener.AddAdmin("support")
+	if err := shortener.Restore("0000001", "support"); err == nil {
+		restored, _ := shortener.GetStats("0000001")
+		fmt.Printf("  ✅ support (admin) restored 0000001 for user1 with its %d clicks\n", restored.GetClickCount())
+	}
+
+	// After the retention period the janitor frees the code for good
+	_ = shortener.SetTrashRetention(7 * 24 * time.Hour)
+	_ = shortener.Delete("0000003")
+	fmt.Printf("  Janitor today: %d purged\n", shortener.PurgeTrash(time.Now()))
+	fmt.Printf("  Janitor in 8 days: %d purged\n", shortener.PurgeTrash(time.Now().Add(8*24*time.Hour)))
+	if err := shortener.Restore("0000003", "user2"); err != nil {
+		fmt.Printf("  ❌ Restore after purge: %v\n", err)
+	}
+	shortener.StartJanitor(time.Hour) // Production setup: purge hourly
+	shortener.StopJanitor()
+
+	// Admin API: paginated queries and bulk operations
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🛠️  Admin Query API...")
+
+	for index := 1; index <= 3; index++ {
+		_, _ = shortener.Shorten(fmt.Sprintf("https://free-prizes.example.net/claim/%d", index), "user3", 7)
+	}
+	if _, err := shortener.QueryLinks("user1", LinkQuery{}); err != nil {
+		fmt.Printf("  ⛔ %v\n", err)
+	}
+	if _, err := shortener.QueryLinks("support", LinkQuery{PageSize: 500}); err != nil {
+		fmt.Printf("  ❌ %v\n", err)
+	}
+
+	topQuery := LinkQuery{Status: LinkStatusActive, SortBy: SortByClicks, Descending: true, PageSize: 3}
+	for {
+		page, err := shortener.QueryLinks("support", topQuery)
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			break
+		}
+		fmt.Printf("  Page %d/%d (%d active links):", page.Page, page.TotalPages, page.Total)
+		for _, entry := range page.Entries {
+			fmt.Printf(" %s[%s,%d]", entry.ShortCode, entry.CreatedBy, entry.GetClickCount())
+		}
+		fmt.Println()
+		if !page.HasNext() {
+			break
+		}
+		topQuery.Page = page.Page + 1
+	}
+	popular, _ := shortener.QueryLinks("support", LinkQuery{CreatedBy: "user1", MinClicks: 3})
+	fmt.Printf("  user1 links with 3+ clicks: %d\n", popular.Total)
+
+	// Suspend a spammer's links; only an admin can lift it
+	disabledCount, _ := shortener.DisableUserLinks("support", "user3", "spam campaign")
+	spamPage, _ := shortener.QueryLinks("support", LinkQuery{CreatedBy: "user3", Status: LinkStatusDisabled})
+	if _, err := shortener.Resolve(spamPage.Entries[0].ShortCode); err != nil {
+		fmt.Printf("  🚫 Disabled %d user3 link(s); resolving %s: %v\n", disabledCount, spamPage.Entries[0].ShortCode, err)
+	}
+
+	// Push back the expiry of user1's expiring links by 90 days
+	githubLink, _ := shortener.GetStats("0000002")
+	beforeExtension := githubLink.ExpiresAt
+	extendedCount, _ := shortener.ExtendExpiry("support", LinkQuery{CreatedBy: "user1", Status: LinkStatusActive}, 90*24*time.Hour)
+	fmt.Printf("  Extended %d link(s): 0000002 now expires %s (was %s)\n",
+		extendedCount, formatExpiry(githubLink.ExpiresAt), formatExpiry(beforeExtension))
+
+	// Bot filtering: bots are redirected but reported separately
+	fmt.Println("\n─────────────────────────────────────────")
+	fmt.Println("🤖 Bot Filtering...")
+
+	shortener.SetRateLimiters(nil, nil) // Keep the demo traffic below from being throttled
+	shortener.SetBotFilter(NewDefaultBotFilter())
+	_, _ = shortener.ShortenCustom("https://shop.com/black-friday", "deals", "user2")
+
+	launchTime := time.Now()
+	launchClicks := []RedirectRequest{
+		{IPAddress: "10.3.0.1", UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0)"},
+		{IPAddress: "10.3.0.2", UserAgent: "Mozilla/5.0 (Windows NT 10.0)"},
+		{IPAddress: "66.249.66.1", UserAgent: "Mozilla/5.0 (compatible; Googlebot/2.1)"},
+		{IPAddress: "3.4.5.6", UserAgent: "Slackbot-LinkExpanding 1.0"},
+		{IPAddress: "34.1.2.3", UserAgent: "python-requests/2.31"},
+		{IPAddress: "34.1.2.4", UserAgent: ""},
+	}
+	for _, click := range launchClicks {
+		destination, err := shortener.ResolveRequest("deals", click)
+		agent := click.UserAgent
+		if agent == "" {
+			agent = "(no user agent)"
+		}
+		if len(agent) > 30 {
+			agent = agent[:30] + "..."
+		}
+		fmt.Printf("  %-33s → %s (err: %v)\n", agent, destination, err)
+	}
+	// A click farm behind one IP with a real browser agent: 30 clicks in 30s
+	for i := 0; i < 30; i++ {
+		_, _ = shortener.ResolveRequest("deals", RedirectRequest{
+			IPAddress: "185.220.101.4",
+			UserAgent: "Mozilla/5.0 (Windows NT 10.0)",
+			Time:      launchTime.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	clickReport := shortener.GetClickReport("deals")
+	fmt.Printf("  deals: %d clicks = %d human + %d bot (%.0f%% bot)\n",
+		clickReport.Total, clickReport.Human, clickReport.Bot, clickReport.GetBotShare()*100)
+	botReasons := make([]string, 0, len(clickReport.BotReasons))
+	for reason := range clickReport.BotReasons {
+		botReasons = append(botReasons, reason)
+	}
+	sort.Strings(botReasons)
+	for _, reason := range botReasons {
+		fmt.Printf("    %-28s %d\n", reason, clickReport.BotReasons[reason])
+	}
+
+	fmt.Println("\n═══════════════════════════════════════════")
+	fmt.Println("  KEY DESIGN DECISIONS:")
+	fmt.Println("═══════════════════════════════════════════")
+	fmt.Println("  1. Counter-based ID (guaranteed unique)")
+	fmt.Println("  2. Base62 encoding for short codes")
+	fmt.Println("  3. Custom aliases supported")
+	fmt.Println("  4. Click tracking & analytics")
+	fmt.Println("  5. TTL/expiration support")
+	fmt.Println("  6. Redirect rules as strategies (A/B, device, time window)")
+	fmt.Println("  7. Per-IP/per-code throttling + block list with typed 429/403 errors")
+	fmt.Println("  8. Editable destinations with version history; clicks tagged by version")
+	fmt.Println("  9. Soft delete to a per-user trash; owner/admin restore; janitor purges")
+	fmt.Println("  10. Admin queries: filters, sorting, pagination; bulk disable/extend")
+	fmt.Println("  11. Pluggable bot detectors; clicks reported as human vs bot")
+	fmt.Println("═══════════════════════════════════════════")
+}
@@ -0,0 +1,1799 @@
+// Package urlshortener turns long URLs into Base62 short codes and tracks
+// their clicks.
+package urlshortener
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ratelimiter "github.com/ayushgupta5/GoLLD/09_rate_limiter"
+	"github.com/ayushgupta5/GoLLD/pkg/periodic"
+)
+
+// ============================================================
+// URL SHORTENER - Low Level Design
+// ============================================================
+//
+// What is a URL Shortener?
+// - A service that converts long URLs into short, easy-to-share links
+// - Example: "https://example.com/very/long/path" → "https://short.ly/abc123"
+//
+// Key Concepts Covered:
+// 1. Base62 Encoding - Convert numbers to short alphanumeric strings
+// 2. Counter-based ID Generation - Ensures unique short codes
+// 3. Analytics - Track how many times each link is clicked
+// 4. URL Expiration - Links can have a time-to-live (TTL)
+// 5. Thread Safety - Using mutexes for concurrent access
+// 6. Redirect Rules - A/B splits, device targets and time windows
+// 7. Abuse Protection - Per-IP/per-code rate limits and a block list
+// 8. Link Editing - Owners/editors change the destination; clicks are
+//    attributed to the destination version live at click time
+// 9. Trash - Deleted links can be restored by their owner or an admin
+//    until a retention period ends and the janitor hard-deletes them
+// 10. Admin API - Paginated, filtered, sorted queries plus bulk disable
+//     and bulk expiry extension
+// 11. Bot Filtering - Crawler list, user-agent and click-rate detectors
+//     separate human from bot clicks in analytics (bots still redirect)
+//
+// ============================================================
+
+// ========== CONSTANTS ==========
+
+const (
+	// Base62Chars contains all characters used for encoding short URLs
+	// Using 62 characters (0-9, A-Z, a-z) gives us many possible combinations
+	// With 7 characters: 62^7 = 3.5 trillion unique codes!
+	Base62Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// ShortCodeLength is the number of characters in generated short codes
+	ShortCodeLength = 7
+
+	// DefaultBaseDomain is used when no custom domain is provided
+	DefaultBaseDomain = "https://short.url"
+
+	// Constraints for custom short codes
+	MinCustomCodeLength = 3
+	MaxCustomCodeLength = 20
+)
+
+// ========== URL ENTRY ==========
+// URLEntry represents a single shortened URL with all its metadata.
+// Think of it as a "record" that stores everything about one short link.
+
+type URLEntry struct {
+	ShortCode      string     // The short code (e.g., "abc123")
+	OriginalURL    string     // The URL this code currently points to (changed by UpdateDestination)
+	CreatedAt      time.Time  // When this short URL was created
+	ExpiresAt      time.Time  // When this short URL will expire (zero means never)
+	CreatedBy      string     // ID of the user who created this short URL
+	IsCustom       bool       // True if user chose their own custom code
+	ClickCount     int64      // How many times this short URL has been accessed
+	LastAccess     time.Time  // When was this URL last accessed
+	IsActive       bool       // False if the URL has been deleted/deactivated
+	DeletedAt      time.Time  // When it was moved to the trash (zero while active)
+	Disabled       bool       // Suspended by an admin; does not resolve (see ADMIN QUERY API)
+	DisabledReason string     // Why an admin disabled the link
+	mutex          sync.Mutex // Protects concurrent access to mutable fields
+
+	// Rules evaluated in order on each click; first match wins,
+	// OriginalURL is the fallback when no rule matches
+	redirectRules []RedirectRule
+
+	// Link editing (see LINK EDITING & VERSION HISTORY)
+	versions []DestinationVersion // Destination history; the last one is live
+	editors  map[string]bool      // Users besides CreatedBy who may edit
+}
+
+// IsExpired checks if this short URL has passed its expiration time.
+// Returns false if no expiration was set (ExpiresAt is zero).
+func (entry *URLEntry) IsExpired() bool {
+	// If expiration time was never set, the URL never expires
+	if entry.ExpiresAt.IsZero() {
+		return false
+	}
+	// Check if current time is after the expiration time
+	return time.Now().After(entry.ExpiresAt)
+}
+
+// IncrementClicks safely increases the click count by 1.
+// Uses atomic operation to be thread-safe without heavy locking.
+func (entry *URLEntry) IncrementClicks() {
+	// atomic.AddInt64 is thread-safe - multiple goroutines can call this safely
+	atomic.AddInt64(&entry.ClickCount, 1)
+
+	// Update last access time (requires mutex since time.Time isn't atomic)
+	entry.mutex.Lock()
+	entry.LastAccess = time.Now()
+	entry.mutex.Unlock()
+}
+
+// GetClickCount safely retrieves the current click count.
+// Uses atomic load to ensure we get a consistent value.
+func (entry *URLEntry) GetClickCount() int64 {
+	return atomic.LoadInt64(&entry.ClickCount)
+}
+
+// addRedirectRule appends a rule to the end of the evaluation order.
+func (entry *URLEntry) addRedirectRule(rule RedirectRule) {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	entry.redirectRules = append(entry.redirectRules, rule)
+}
+
+// chooseDestination runs the redirect rules against the request.
+// Returns the destination URL, the name of the rule that picked it
+// ("default" when falling back to OriginalURL) and the live version.
+func (entry *URLEntry) chooseDestination(request RedirectRequest) (string, string, int) {
+	entry.mutex.Lock()
+	rules := append([]RedirectRule(nil), entry.redirectRules...)
+	fallback, version := entry.OriginalURL, len(entry.versions)
+	entry.mutex.Unlock()
+
+	for _, rule := range rules {
+		if destination, matched := rule.Evaluate(request); matched {
+			return destination, rule.Name(), version
+		}
+	}
+	return fallback, "default", version
+}
+
+// firstVersion is the destination history of a newly created entry.
+func firstVersion(originalURL, userID string, createdAt time.Time) []DestinationVersion {
+	return []DestinationVersion{{Version: 1, URL: originalURL, EditedAt: createdAt, EditorID: userID}}
+}
+
+// ========== REDIRECT RULES ==========
+// Redirect rules let one short code route visitors to different places.
+// Each rule is a strategy: it either picks a destination or passes.
+
+// RedirectRequest carries the visitor details rules can route on.
+type RedirectRequest struct {
+	IPAddress string    // Visitor IP (also used for sticky A/B assignment)
+	UserAgent string    // Browser/device info
+	Referer   string    // Where the click came from
+	Time      time.Time // When the click happened
+}
+
+// DeviceType is the visitor platform derived from the user agent.
+type DeviceType string
+
+const (
+	DeviceIOS     DeviceType = "ios"
+	DeviceAndroid DeviceType = "android"
+	DeviceDesktop DeviceType = "desktop"
+)
+
+// DetectDevice classifies a user agent string into a device type.
+func DetectDevice(userAgent string) DeviceType {
+	agent := strings.ToLower(userAgent)
+	switch {
+	case strings.Contains(agent, "iphone"), strings.Contains(agent, "ipad"):
+		return DeviceIOS
+	case strings.Contains(agent, "android"):
+		return DeviceAndroid
+	default:
+		return DeviceDesktop
+	}
+}
+
+// RedirectRule decides where a click should go.
+// Evaluate returns false when the rule does not apply to the request.
+type RedirectRule interface {
+	Name() string
+	Evaluate(request RedirectRequest) (string, bool)
+}
+
+// DeviceRule sends visitors on a given platform to a specific URL
+// (e.g., App Store link for iOS, Play Store link for Android).
+type DeviceRule struct {
+	Device      DeviceType
+	Destination string
+}
+
+func (rule *DeviceRule) Name() string {
+	return "device:" + string(rule.Device)
+}
+
+func (rule *DeviceRule) Evaluate(request RedirectRequest) (string, bool) {
+	if DetectDevice(request.UserAgent) != rule.Device {
+		return "", false
+	}
+	return rule.Destination, true
+}
+
+// TimeWindowRule sends visitors to a URL only between Start and End
+// (e.g., a flash-sale page that is live for one weekend).
+type TimeWindowRule struct {
+	Start       time.Time
+	End         time.Time
+	Destination string
+}
+
+// NewTimeWindowRule creates a time-windowed rule; End must be after Start.
+func NewTimeWindowRule(start, end time.Time, destination string) (*TimeWindowRule, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("time window end must be after start")
+	}
+	return &TimeWindowRule{Start: start, End: end, Destination: destination}, nil
+}
+
+func (rule *TimeWindowRule) Name() string {
+	return "time-window"
+}
+
+func (rule *TimeWindowRule) Evaluate(request RedirectRequest) (string, bool) {
+	if request.Time.Before(rule.Start) || !request.Time.Before(rule.End) {
+		return "", false
+	}
+	return rule.Destination, true
+}
+
+// WeightedDestination is one arm of an A/B split.
+type WeightedDestination struct {
+	URL    string
+	Weight int
+}
+
+// SplitRule distributes visitors across destinations by weight (A/B testing).
+// Visitors with a known IP are assigned by hash so they always see the same
+// variant; anonymous visitors are assigned randomly.
+type SplitRule struct {
+	destinations []WeightedDestination
+	totalWeight  int
+}
+
+// NewSplitRule creates an A/B split. Every weight must be positive.
+func NewSplitRule(destinations ...WeightedDestination) (*SplitRule, error) {
+	if len(destinations) < 2 {
+		return nil, fmt.Errorf("split needs at least 2 destinations")
+	}
+
+	totalWeight := 0
+	for _, destination := range destinations {
+		if destination.URL == "" || destination.Weight <= 0 {
+			return nil, fmt.Errorf("split destinations need a URL and a positive weight")
+		}
+		totalWeight += destination.Weight
+	}
+
+	return &SplitRule{destinations: destinations, totalWeight: totalWeight}, nil
+}
+
+func (rule *SplitRule) Name() string {
+	return "split"
+}
+
+func (rule *SplitRule) Evaluate(request RedirectRequest) (string, bool) {
+	// Pick a point in [0, totalWeight) and walk the cumulative weights
+	var point int
+	if request.IPAddress != "" {
+		hasher := fnv.New32a()
+		hasher.Write([]byte(request.IPAddress))
+		point = int(hasher.Sum32() % uint32(rule.totalWeight))
+	} else {
+		point = rand.Intn(rule.totalWeight)
+	}
+
+	for _, destination := range rule.destinations {
+		if point < destination.Weight {
+			return destination.URL, true
+		}
+		point -= destination.Weight
+	}
+	return rule.destinations[len(rule.destinations)-1].URL, true
+}
+
+// ========== CLICK ANALYTICS ==========
+// ClickEvent records details about each time a short URL is accessed.
+// This helps track usage patterns and provides insights.
+
+type ClickEvent struct {
+	ShortCode   string    // Which short URL was clicked
+	Timestamp   time.Time // When the click happened
+	IPAddress   string    // IP address of the visitor (for geo-location)
+	UserAgent   string    // Browser/device info
+	Referer     string    // Where the click came from (e.g., Twitter, email)
+	Destination string    // Where the visitor was actually sent
+	RuleName    string    // Which redirect rule chose the destination
+	Version     int       // Destination version live at click time
+	IsBot       bool      // Classified as a bot by the bot filter
+	BotReason   string    // Why the click counts as a bot (e.g. "crawler:slack")
+}
+
+// Analytics stores and manages all click events.
+// This is a simple in-memory implementation (production would use a database).
+type Analytics struct {
+	clickEvents []ClickEvent // List of all click events
+	mutex       sync.Mutex   // Protects concurrent access
+}
+
+// NewAnalytics creates a new Analytics tracker.
+func NewAnalytics() *Analytics {
+	return &Analytics{
+		clickEvents: make([]ClickEvent, 0), // Start with empty slice
+	}
+}
+
+// RecordClick adds a new click event to the analytics.
+func (analytics *Analytics) RecordClick(shortCode string, request RedirectRequest, destination, ruleName string, version int, verdict BotVerdict) {
+	analytics.mutex.Lock()
+	defer analytics.mutex.Unlock()
+
+	newClick := ClickEvent{
+		ShortCode:   shortCode,
+		Timestamp:   request.Time,
+		IPAddress:   request.IPAddress,
+		UserAgent:   request.UserAgent,
+		Referer:     request.Referer,
+		Destination: destination,
+		RuleName:    ruleName,
+		Version:     version,
+		IsBot:       verdict.IsBot,
+		BotReason:   verdict.Reason,
+	}
+	analytics.clickEvents = append(analytics.clickEvents, newClick)
+}
+
+// GetClickCountByCode returns how many times a specific short URL was clicked.
+func (analytics *Analytics) GetClickCountByCode(shortCode string) int {
+	analytics.mutex.Lock()
+	defer analytics.mutex.Unlock()
+
+	count := 0
+	for _, clickEvent := range analytics.clickEvents {
+		if clickEvent.ShortCode == shortCode {
+			count++
+		}
+	}
+	return count
+}
+
+// GetDestinationBreakdown returns clicks per destination for a short code.
+// Useful for comparing A/B variants.
+func (analytics *Analytics) GetDestinationBreakdown(shortCode string) map[string]int {
+	analytics.mutex.Lock()
+	defer analytics.mutex.Unlock()
+
+	breakdown := make(map[string]int)
+	for _, clickEvent := range analytics.clickEvents {
+		if clickEvent.ShortCode == shortCode {
+			breakdown[clickEvent.Destination]++
+		}
+	}
+	return breakdown
+}
+
+// GetVersionBreakdown returns clicks per destination version for a short code.
+func (analytics *Analytics) GetVersionBreakdown(shortCode string) map[int]int {
+	analytics.mutex.Lock()
+	defer analytics.mutex.Unlock()
+
+	breakdown := make(map[int]int)
+	for _, clickEvent := range analytics.clickEvents {
+		if clickEvent.ShortCode == shortCode {
+			breakdown[clickEvent.Version]++
+		}
+	}
+	return breakdown
+}
+
+// ========== RATE LIMITING & ABUSE PROTECTION ==========
+// Resolution is the public, unauthenticated hot path, so it is throttled
+// twice: per client IP (one scraper cannot hammer the service) and per
+// short code (one viral or abused link cannot starve everything else).
+// A block list rejects known-bad IPs and codes (phishing reports, scanners)
+// before any limiter or lookup runs.
+//
+// Any limiter from 09_rate_limiter can be plugged in; the fixed window
+// limiter gives an exact Retry-After. Limiters that can Compact are
+// compacted by the janitor, so keys seen once do not pile up forever.
+
+// compactable is implemented by limiters that can drop the state of idle keys.
+type compactable interface {
+	Compact() int
+}
+
+// Throttle and block scopes.
+const (
+	ScopeIP   = "ip"
+	ScopeCode = "code"
+)
+
+// ThrottleError is returned when a client IP or short code has exhausted
+// its quota, so the HTTP layer can emit a 429 with Retry-After.
+type ThrottleError struct {
+	Scope      string        // ScopeIP or ScopeCode
+	Key        string        // The throttled IP address or short code
+	Limit      int           // Requests allowed per window
+	RetryAfter time.Duration // How long until the window resets
+}
+
+func (err *ThrottleError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s %s (limit %d), retry after %s",
+		err.Scope, err.Key, err.Limit, err.RetryAfter.Round(time.Second))
+}
+
+// StatusCode returns the HTTP status for this error.
+func (err *ThrottleError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+// BlockedError is returned when the client IP or short code is on the block list.
+type BlockedError struct {
+	Scope  string // ScopeIP or ScopeCode
+	Key    string // The blocked IP address or short code
+	Reason string // Why it was blocked (e.g., "phishing report")
+}
+
+func (err *BlockedError) Error() string {
+	return fmt.Sprintf("%s %s is blocked: %s", err.Scope, err.Key, err.Reason)
+}
+
+// StatusCode returns the HTTP status for this error.
+func (err *BlockedError) StatusCode() int {
+	return http.StatusForbidden
+}
+
+// BlockList holds IPs and short codes that may not resolve, with the reason for each.
+type BlockList struct {
+	ips   map[string]string // IP -> reason
+	codes map[string]string // short code -> reason
+	mutex sync.RWMutex      // Protects both maps
+}
+
+// NewBlockList creates an empty block list.
+func NewBlockList() *BlockList {
+	return &BlockList{
+		ips:   make(map[string]string),
+		codes: make(map[string]string),
+	}
+}
+
+// BlockIP rejects every resolution from the given IP.
+func (blockList *BlockList) BlockIP(ipAddress, reason string) {
+	blockList.mutex.Lock()
+	defer blockList.mutex.Unlock()
+	blockList.ips[ipAddress] = reason
+}
+
+// UnblockIP removes an IP from the block list.
+func (blockList *BlockList) UnblockIP(ipAddress string) {
+	blockList.mutex.Lock()
+	defer blockList.mutex.Unlock()
+	delete(blockList.ips, ipAddress)
+}
+
+// BlockCode rejects every resolution of the given short code.
+func (blockList *BlockList) BlockCode(shortCode, reason string) {
+	blockList.mutex.Lock()
+	defer blockList.mutex.Unlock()
+	blockList.codes[shortCode] = reason
+}
+
+// UnblockCode removes a short code from the block list.
+func (blockList *BlockList) UnblockCode(shortCode string) {
+	blockList.mutex.Lock()
+	defer blockList.mutex.Unlock()
+	delete(blockList.codes, shortCode)
+}
+
+// check returns a BlockedError if the IP or the code is blocked.
+func (blockList *BlockList) check(ipAddress, shortCode string) error {
+	blockList.mutex.RLock()
+	defer blockList.mutex.RUnlock()
+
+	if reason, blocked := blockList.ips[ipAddress]; blocked && ipAddress != "" {
+		return &BlockedError{Scope: ScopeIP, Key: ipAddress, Reason: reason}
+	}
+	if reason, blocked := blockList.codes[shortCode]; blocked {
+		return &BlockedError{Scope: ScopeCode, Key: shortCode, Reason: reason}
+	}
+	return nil
+}
+
+// throttle consumes one request from limiter for key, returning a
+// ThrottleError when the quota is exhausted. A nil limiter never throttles.
+func throttle(limiter ratelimiter.RateLimiter, scope, key string) error {
+	if limiter == nil || key == "" || limiter.Allow(key) {
+		return nil
+	}
+	quota := limiter.Check(key)
+	retryAt := quota.RetryAt
+	if retryAt.IsZero() {
+		retryAt = quota.ResetAt
+	}
+	return &ThrottleError{
+		Scope:      scope,
+		Key:        key,
+		Limit:      quota.Limit,
+		RetryAfter: max(0, time.Until(retryAt)),
+	}
+}
+
+// ========== URL SHORTENER SERVICE ==========
+// URLShortener is the main service that handles all URL shortening operations.
+// It manages creating, resolving, and tracking short URLs.
+
+type URLShortener struct {
+	baseDomain       string                  // Base domain for short URLs (e.g., "https://short.ly")
+	urlDatabase      map[string]*URLEntry    // Maps: shortCode -> URLEntry
+	reverseLookup    map[string]string       // Maps: originalURL -> shortCode (for deduplication)
+	idCounter        uint64                  // Auto-incrementing counter for unique ID generation
+	analyticsTracker *Analytics              // Tracks click events
+	ipLimiter        ratelimiter.RateLimiter // Throttles Resolve per client IP (nil = unlimited)
+	codeLimiter      ratelimiter.RateLimiter // Throttles Resolve per existing short code (nil = unlimited)
+	blockList        *BlockList              // IPs and codes that may not resolve
+	botFilter        *BotFilter              // Classifies clicks as human or bot (nil = all human)
+	admins           map[string]bool         // Users who may restore anyone's links
+	trashRetention   time.Duration           // How long deleted links stay restorable
+	janitor          periodic.Job            // Purges the trash in the background
+	mutex            sync.RWMutex            // Read-Write mutex for thread-safe access
+}
+
+// NewURLShortener creates a new URL shortener service with the given domain.
+// If no domain is provided, it uses the default domain.
+func NewURLShortener(domain string) *URLShortener {
+	if domain == "" {
+		domain = DefaultBaseDomain
+	}
+	return &URLShortener{
+		baseDomain:       domain,
+		urlDatabase:      make(map[string]*URLEntry),
+		reverseLookup:    make(map[string]string),
+		analyticsTracker: NewAnalytics(),
+		blockList:        NewBlockList(),
+		admins:           make(map[string]bool),
+		trashRetention:   DefaultTrashRetention,
+	}
+}
+
+// encodeBase62 converts a number to a Base62 string.
+// Base62 uses 0-9, A-Z, a-z (62 characters) to create short, URL-safe strings.
+//
+// Why Base62?
+// - URLs are case-sensitive, so we can use both uppercase and lowercase letters
+// - 62 chars means 62^7 = 3.5 trillion combinations with just 7 characters!
+// - No special characters that need URL encoding
+func (shortener *URLShortener) encodeBase62(number uint64) string {
+	// Handle zero case
+	if number == 0 {
+		return string(Base62Chars[0])
+	}
+
+	// Build the encoded string by repeatedly dividing by 62
+	// Similar to converting decimal to any other base
+	result := ""
+	for number > 0 {
+		remainder := number % 62                         // Get the current digit (0-61)
+		result = string(Base62Chars[remainder]) + result // Prepend the character
+		number /= 62                                     // Move to next digit
+	}
+
+	// Pad with leading zeros to ensure minimum length for consistency
+	for len(result) < ShortCodeLength {
+		result = string(Base62Chars[0]) + result
+	}
+
+	return result
+}
+
+// generateUniqueShortCode creates a new unique short code using counter-based generation.
+// This approach guarantees uniqueness because the counter always increases.
+func (shortener *URLShortener) generateUniqueShortCode() string {
+	// Atomically increment and get the new counter value
+	// atomic.AddUint64 is thread-safe, so multiple goroutines won't get same ID
+	newID := atomic.AddUint64(&shortener.idCounter, 1)
+	return shortener.encodeBase62(newID)
+}
+
+// Shorten creates a short URL from a long URL.
+// Parameters:
+//   - originalURL: The long URL to shorten
+//   - userID: ID of the user creating the short URL
+//   - ttlDays: Time-to-live in days (0 means never expires)
+//
+// Returns:
+//   - The complete short URL (e.g., "https://short.ly/abc123")
+//   - An error if the URL is empty
+func (shortener *URLShortener) Shorten(originalURL string, userID string, ttlDays int) (string, error) {
+	// Validate input
+	if originalURL == "" {
+		return "", fmt.Errorf("URL cannot be empty")
+	}
+
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	// Check if this URL was already shortened (deduplication)
+	// This prevents creating multiple short codes for the same URL
+	if existingCode, alreadyExists := shortener.reverseLookup[originalURL]; alreadyExists {
+		existingEntry := shortener.urlDatabase[existingCode]
+		// Only return existing code if it's still active and not expired
+		if existingEntry.statusLocked() == LinkStatusActive {
+			return shortener.baseDomain + "/" + existingCode, nil
+		}
+	}
+
+	// Generate a new unique short code
+	shortCode := shortener.generateUniqueShortCode()
+
+	// Handle collision (very unlikely with counter-based generation, but good practice)
+	for _, codeExists := shortener.urlDatabase[shortCode]; codeExists; {
+		shortCode = shortener.generateUniqueShortCode()
+	}
+
+	// Create the URL entry with all metadata
+	createdAt := time.Now()
+	newEntry := &URLEntry{
+		ShortCode:   shortCode,
+		OriginalURL: originalURL,
+		CreatedAt:   createdAt,
+		CreatedBy:   userID,
+		IsActive:    true,
+		versions:    firstVersion(originalURL, userID, createdAt),
+	}
+
+	// Set expiration if TTL was specified
+	if ttlDays > 0 {
+		newEntry.ExpiresAt = time.Now().AddDate(0, 0, ttlDays)
+	}
+
+	// Store in both maps
+	shortener.urlDatabase[shortCode] = newEntry
+	shortener.reverseLookup[originalURL] = shortCode
+
+	return shortener.baseDomain + "/" + shortCode, nil
+}
+
+// ShortenCustom creates a short URL with a user-chosen custom code.
+// This allows users to create memorable/branded short links.
+// Parameters:
+//   - originalURL: The long URL to shorten
+//   - customCode: User's desired custom code (e.g., "mylink")
+//   - userID: ID of the user creating the short URL
+//
+// Returns:
+//   - The complete short URL (e.g., "https://short.ly/mylink")
+//   - An error if validation fails or code is already taken
+func (shortener *URLShortener) ShortenCustom(originalURL, customCode, userID string) (string, error) {
+	// Validate inputs
+	if originalURL == "" || customCode == "" {
+		return "", fmt.Errorf("URL and custom code cannot be empty")
+	}
+
+	// Validate custom code length
+	if len(customCode) < MinCustomCodeLength || len(customCode) > MaxCustomCodeLength {
+		return "", fmt.Errorf("custom code must be %d-%d characters", MinCustomCodeLength, MaxCustomCodeLength)
+	}
+
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	// Check if custom code is already taken
+	if _, codeExists := shortener.urlDatabase[customCode]; codeExists {
+		return "", fmt.Errorf("custom code '%s' already taken", customCode)
+	}
+
+	// Create the URL entry with custom code
+	createdAt := time.Now()
+	newEntry := &URLEntry{
+		ShortCode:   customCode,
+		OriginalURL: originalURL,
+		CreatedAt:   createdAt,
+		CreatedBy:   userID,
+		IsCustom:    true, // Mark as custom code
+		IsActive:    true,
+		versions:    firstVersion(originalURL, userID, createdAt),
+	}
+
+	// Store in both maps
+	shortener.urlDatabase[customCode] = newEntry
+	shortener.reverseLookup[originalURL] = customCode
+
+	return shortener.baseDomain + "/" + customCode, nil
+}
+
+// Resolve converts a short code back to the original URL.
+// This is called when someone clicks on a short link.
+// Also records analytics for tracking click counts.
+func (shortener *URLShortener) Resolve(shortCode string) (string, error) {
+	return shortener.ResolveRequest(shortCode, RedirectRequest{})
+}
+
+// ResolveRequest resolves a short code for a specific visitor, applying the
+// entry's redirect rules. The chosen destination is recorded in analytics.
+// Blocked callers get a *BlockedError and throttled ones a *ThrottleError.
+func (shortener *URLShortener) ResolveRequest(shortCode string, request RedirectRequest) (string, error) {
+	if request.Time.IsZero() {
+		request.Time = time.Now()
+	}
+
+	// Use read lock for better concurrency (multiple readers allowed)
+	shortener.mutex.RLock()
+	urlEntry, exists := shortener.urlDatabase[shortCode]
+	ipLimiter, codeLimiter := shortener.ipLimiter, shortener.codeLimiter
+	botFilter := shortener.botFilter
+	shortener.mutex.RUnlock()
+
+	// Block and IP checks run before the lookup result is revealed, so
+	// scanning for valid codes is throttled like any other traffic
+	if err := shortener.blockList.check(request.IPAddress, shortCode); err != nil {
+		return "", err
+	}
+	if err := throttle(ipLimiter, ScopeIP, request.IPAddress); err != nil {
+		return "", err
+	}
+
+	// Check if the short code exists
+	if !exists {
+		return "", fmt.Errorf("short URL not found")
+	}
+
+	// Only real codes are throttled per code; otherwise every random code
+	// a scanner tries would leave a limiter record behind
+	if err := throttle(codeLimiter, ScopeCode, shortCode); err != nil {
+		return "", err
+	}
+
+	// Check if the URL is still active (not deleted)
+	if !urlEntry.IsActive {
+		return "", fmt.Errorf("short URL is inactive")
+	}
+
+	// Check if an admin has suspended the URL
+	if urlEntry.Disabled {
+		return "", fmt.Errorf("short URL has been disabled")
+	}
+
+	// Check if the URL has expired
+	if urlEntry.IsExpired() {
+		return "", fmt.Errorf("short URL has expired")
+	}
+
+	// Pick the destination for this visitor
+	destination, ruleName, version := urlEntry.chooseDestination(request)
+
+	// Record this click for analytics; bots are redirected too, but tagged
+	urlEntry.IncrementClicks()
+	shortener.analyticsTracker.RecordClick(shortCode, request, destination, ruleName, version, botFilter.Classify(request))
+
+	return destination, nil
+}
+
+// SetRateLimiters configures resolution throttling per client IP and per
+// short code. Pass nil to disable either limit.
+func (shortener *URLShortener) SetRateLimiters(ipLimiter, codeLimiter ratelimiter.RateLimiter) {
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	shortener.ipLimiter = ipLimiter
+	shortener.codeLimiter = codeLimiter
+}
+
+// GetBlockList returns the block list consulted on every resolution.
+func (shortener *URLShortener) GetBlockList() *BlockList {
+	return shortener.blockList
+}
+
+// AddRedirectRule attaches a routing rule to a short code.
+// Rules are evaluated in the order they were added.
+func (shortener *URLShortener) AddRedirectRule(shortCode string, rule RedirectRule) error {
+	if rule == nil {
+		return fmt.Errorf("rule cannot be nil")
+	}
+
+	shortener.mutex.RLock()
+	urlEntry, exists := shortener.urlDatabase[shortCode]
+	shortener.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("short URL not found")
+	}
+
+	urlEntry.addRedirectRule(rule)
+	return nil
+}
+
+// GetDestinationBreakdown returns clicks per destination for a short code.
+func (shortener *URLShortener) GetDestinationBreakdown(shortCode string) map[string]int {
+	return shortener.analyticsTracker.GetDestinationBreakdown(shortCode)
+}
+
+// Delete deactivates a short URL (soft delete).
+// The entry still exists in the database but is marked as inactive.
+// This allows us to keep analytics data while preventing future access.
+// It can be restored until the trash retention period ends.
+func (shortener *URLShortener) Delete(shortCode string) error {
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	urlEntry, exists := shortener.urlDatabase[shortCode]
+	if !exists {
+		return fmt.Errorf("short URL not found")
+	}
+
+	// Soft delete - mark as inactive instead of removing
+	if urlEntry.IsActive {
+		urlEntry.IsActive = false
+		urlEntry.DeletedAt = time.Now()
+	}
+	return nil
+}
+
+// GetStats returns the URLEntry for a given short code.
+// This provides access to all metadata including click count, creation time, etc.
+func (shortener *URLShortener) GetStats(shortCode string) (*URLEntry, error) {
+	shortener.mutex.RLock()
+	defer shortener.mutex.RUnlock()
+
+	urlEntry, exists := shortener.urlDatabase[shortCode]
+	if !exists {
+		return nil, fmt.Errorf("short URL not found")
+	}
+
+	return urlEntry, nil
+}
+
+// ListAll returns all URL entries stored in the service.
+// Useful for debugging; admin dashboards should page through QueryLinks.
+func (shortener *URLShortener) ListAll() []*URLEntry {
+	shortener.mutex.RLock()
+	defer shortener.mutex.RUnlock()
+
+	// Create a slice with capacity equal to the number of entries
+	allEntries := make([]*URLEntry, 0, len(shortener.urlDatabase))
+	for _, urlEntry := range shortener.urlDatabase {
+		allEntries = append(allEntries, urlEntry)
+	}
+	return allEntries
+}
+
+// PrintStats prints detailed statistics for a URL entry in a formatted display.
+func (shortener *URLShortener) PrintStats(shortCode string) {
+	entry, err := shortener.GetStats(shortCode)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf(`
+╔════════════════════════════════════════════════╗
+║              📊 URL STATISTICS                 ║
+╠════════════════════════════════════════════════╣
+  Short Code: %s
+  Full URL:   %s/%s
+  Original:   %s
+  
+  Created:    %s
+  Expires:    %s
+  Custom:     %v
+  Active:     %v
+  
+  Total Clicks: %d
+  Last Access:  %s
+╚════════════════════════════════════════════════╝
+`,
+		entry.ShortCode,
+		shortener.baseDomain, entry.ShortCode,
+		entry.GetDestination(),
+		entry.CreatedAt.Format("Jan 02, 2006 15:04"),
+		formatExpiry(entry.ExpiresAt),
+		entry.IsCustom,
+		entry.IsActive,
+		entry.GetClickCount(),
+		formatLastAccess(entry.LastAccess),
+	)
+}
+
+// formatExpiry formats the expiration time for display.
+// Returns "Never" if no expiration was set.
+func formatExpiry(expirationTime time.Time) string {
+	if expirationTime.IsZero() {
+		return "Never"
+	}
+	return expirationTime.Format("Jan 02, 2006")
+}
+
+// formatLastAccess formats the last access time for display.
+// Returns "Never" if the URL was never accessed.
+func formatLastAccess(lastAccessTime time.Time) string {
+	if lastAccessTime.IsZero() {
+		return "Never"
+	}
+	return lastAccessTime.Format("Jan 02, 2006 15:04")
+}
+
+// ========== LINK EDITING & VERSION HISTORY ==========
+// A printed QR code or a tweet can't be changed, but where it points can.
+// The owner of a short code (or an editor they granted) may move it to a
+// new destination. Every change is kept as a numbered DestinationVersion
+// (who, when, where), and each click records the version that was live, so
+// stats before and after an edit can be compared.
+
+// DestinationVersion is one destination a short code has pointed to.
+type DestinationVersion struct {
+	Version  int       // 1 for the destination given at creation
+	URL      string    // Destination URL of this version
+	EditedAt time.Time // When this version went live
+	EditorID string    // Who set it (the creator for version 1)
+}
+
+// VersionStats is a destination version with the clicks it received.
+type VersionStats struct {
+	DestinationVersion
+	Clicks int
+}
+
+// PermissionError is returned when a user may not modify a short code.
+type PermissionError struct {
+	UserID    string // Who attempted the change
+	ShortCode string // The code they tried to change
+	Action    string // e.g. "edit", "grant editors on"
+}
+
+func (err *PermissionError) Error() string {
+	return fmt.Sprintf("user %s may not %s %s", err.UserID, err.Action, err.ShortCode)
+}
+
+// StatusCode returns the HTTP status for this error.
+func (err *PermissionError) StatusCode() int {
+	return http.StatusForbidden
+}
+
+// canEditLocked reports whether the user owns or was granted edit access.
+// Caller must hold entry.mutex.
+func (entry *URLEntry) canEditLocked(userID string) bool {
+	return userID != "" && (userID == entry.CreatedBy || entry.editors[userID])
+}
+
+// GetDestination returns the current destination URL.
+func (entry *URLEntry) GetDestination() string {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	return entry.OriginalURL
+}
+
+// GetCurrentVersion returns the number of the live destination version.
+func (entry *URLEntry) GetCurrentVersion() int {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	return len(entry.versions)
+}
+
+// GrantEditAccess lets another user change the destination of a short code.
+// Only the creator may grant access.
+func (shortener *URLShortener) GrantEditAccess(shortCode, ownerID, editorID string) error {
+	urlEntry, err := shortener.GetStats(shortCode)
+	if err != nil {
+		return err
+	}
+
+	urlEntry.mutex.Lock()
+	defer urlEntry.mutex.Unlock()
+
+	if ownerID != urlEntry.CreatedBy {
+		return &PermissionError{UserID: ownerID, ShortCode: shortCode, Action: "grant editors on"}
+	}
+	if urlEntry.editors == nil {
+		urlEntry.editors = make(map[string]bool)
+	}
+	urlEntry.editors[editorID] = true
+	return nil
+}
+
+// RevokeEditAccess removes a user's edit access. Only the creator may revoke.
+func (shortener *URLShortener) RevokeEditAccess(shortCode, ownerID, editorID string) error {
+	urlEntry, err := shortener.GetStats(shortCode)
+	if err != nil {
+		return err
+	}
+
+	urlEntry.mutex.Lock()
+	defer urlEntry.mutex.Unlock()
+
+	if ownerID != urlEntry.CreatedBy {
+		return &PermissionError{UserID: ownerID, ShortCode: shortCode, Action: "revoke editors on"}
+	}
+	delete(urlEntry.editors, editorID)
+	return nil
+}
+
+// UpdateDestination points a short code at a new URL and records the
+// change as a new version. Redirect rules keep their own destinations;
+// only the default (fallback) destination changes.
+func (shortener *URLShortener) UpdateDestination(shortCode, newURL, editorID string) (DestinationVersion, error) {
+	if newURL == "" {
+		return DestinationVersion{}, fmt.Errorf("URL cannot be empty")
+	}
+
+	// The service lock keeps reverseLookup consistent with the edit
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	urlEntry, exists := shortener.urlDatabase[shortCode]
+	if !exists {
+		return DestinationVersion{}, fmt.Errorf("short URL not found")
+	}
+
+	urlEntry.mutex.Lock()
+	defer urlEntry.mutex.Unlock()
+
+	if !urlEntry.canEditLocked(editorID) {
+		return DestinationVersion{}, &PermissionError{UserID: editorID, ShortCode: shortCode, Action: "edit"}
+	}
+	if !urlEntry.IsActive {
+		return DestinationVersion{}, fmt.Errorf("short URL is inactive")
+	}
+	if urlEntry.OriginalURL == newURL {
+		return DestinationVersion{}, fmt.Errorf("short URL already points to %s", newURL)
+	}
+
+	// Deduplication should no longer hand out this code for the old URL
+	if shortener.reverseLookup[urlEntry.OriginalURL] == shortCode {
+		delete(shortener.reverseLookup, urlEntry.OriginalURL)
+	}
+	if _, taken := shortener.reverseLookup[newURL]; !taken {
+		shortener.reverseLookup[newURL] = shortCode
+	}
+
+	version := DestinationVersion{
+		Version:  len(urlEntry.versions) + 1,
+		URL:      newURL,
+		EditedAt: time.Now(),
+		EditorID: editorID,
+	}
+	urlEntry.versions = append(urlEntry.versions, version)
+	urlEntry.OriginalURL = newURL
+	return version, nil
+}
+
+// GetDestinationHistory returns every destination version, oldest first.
+func (shortener *URLShortener) GetDestinationHistory(shortCode string) ([]DestinationVersion, error) {
+	urlEntry, err := shortener.GetStats(shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	urlEntry.mutex.Lock()
+	defer urlEntry.mutex.Unlock()
+	return append([]DestinationVersion(nil), urlEntry.versions...), nil
+}
+
+// GetVersionStats returns each destination version with the clicks it
+// received while it was live, oldest first.
+func (shortener *URLShortener) GetVersionStats(shortCode string) ([]VersionStats, error) {
+	history, err := shortener.GetDestinationHistory(shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	clicks := shortener.analyticsTracker.GetVersionBreakdown(shortCode)
+	stats := make([]VersionStats, 0, len(history))
+	for _, version := range history {
+		stats = append(stats, VersionStats{DestinationVersion: version, Clicks: clicks[version.Version]})
+	}
+	return stats, nil
+}
+
+// ========== TRASH & RESTORE ==========
+// Delete is a soft delete: the entry moves to its creator's trash, stops
+// resolving, and keeps its short code reserved (nobody can claim a deleted
+// custom alias). Within the retention period the creator or an admin can
+// Restore it, clicks and history intact. Once the period has passed the
+// janitor hard-deletes it and the code becomes free again.
+
+// DefaultTrashRetention is how long deleted links can be restored.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// TrashEntry is a deleted link as shown in a user's trash.
+type TrashEntry struct {
+	ShortCode string
+	URL       string    // Destination when it was deleted
+	DeletedAt time.Time // When it was deleted
+	PurgeAt   time.Time // When the janitor hard-deletes it
+}
+
+// AddAdmin lets a user restore any link, not just their own.
+func (shortener *URLShortener) AddAdmin(userID string) {
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+	shortener.admins[userID] = true
+}
+
+// SetTrashRetention changes how long deleted links stay restorable.
+func (shortener *URLShortener) SetTrashRetention(retention time.Duration) error {
+	if retention <= 0 {
+		return fmt.Errorf("trash retention must be positive")
+	}
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+	shortener.trashRetention = retention
+	return nil
+}
+
+// Restore brings a deleted link back. Only its creator or an admin may
+// restore it, and only before the retention period ends.
+func (shortener *URLShortener) Restore(shortCode, userID string) error {
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	urlEntry, exists := shortener.urlDatabase[shortCode]
+	if !exists {
+		return fmt.Errorf("short URL not found")
+	}
+	if userID == "" || (userID != urlEntry.CreatedBy && !shortener.admins[userID]) {
+		return &PermissionError{UserID: userID, ShortCode: shortCode, Action: "restore"}
+	}
+	if urlEntry.IsActive {
+		return fmt.Errorf("short URL is not deleted")
+	}
+	if purgeAt := urlEntry.DeletedAt.Add(shortener.trashRetention); !time.Now().Before(purgeAt) {
+		return fmt.Errorf("short URL left the trash on %s", purgeAt.Format("Jan 02, 2006"))
+	}
+
+	urlEntry.IsActive = true
+	urlEntry.DeletedAt = time.Time{}
+
+	// Deduplication may hand this code out again, unless the URL was
+	// shortened anew while this one was in the trash
+	if _, taken := shortener.reverseLookup[urlEntry.GetDestination()]; !taken {
+		shortener.reverseLookup[urlEntry.GetDestination()] = shortCode
+	}
+	return nil
+}
+
+// ListTrash returns a user's deleted links, most recently deleted first.
+func (shortener *URLShortener) ListTrash(userID string) []TrashEntry {
+	shortener.mutex.RLock()
+	defer shortener.mutex.RUnlock()
+
+	trash := make([]TrashEntry, 0)
+	for _, urlEntry := range shortener.urlDatabase {
+		if urlEntry.IsActive || urlEntry.CreatedBy != userID {
+			continue
+		}
+		trash = append(trash, TrashEntry{
+			ShortCode: urlEntry.ShortCode,
+			URL:       urlEntry.GetDestination(),
+			DeletedAt: urlEntry.DeletedAt,
+			PurgeAt:   urlEntry.DeletedAt.Add(shortener.trashRetention),
+		})
+	}
+	sort.Slice(trash, func(i, j int) bool {
+		if !trash[i].DeletedAt.Equal(trash[j].DeletedAt) {
+			return trash[i].DeletedAt.After(trash[j].DeletedAt)
+		}
+		return trash[i].ShortCode < trash[j].ShortCode
+	})
+	return trash
+}
+
+// PurgeTrash hard-deletes links whose retention period ended before now,
+// freeing their short codes. Click analytics are kept.
+// Returns the number of links removed.
+func (shortener *URLShortener) PurgeTrash(now time.Time) int {
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	purged := 0
+	for shortCode, urlEntry := range shortener.urlDatabase {
+		if urlEntry.IsActive || now.Before(urlEntry.DeletedAt.Add(shortener.trashRetention)) {
+			continue
+		}
+		delete(shortener.urlDatabase, shortCode)
+		if destination := urlEntry.GetDestination(); shortener.reverseLookup[destination] == shortCode {
+			delete(shortener.reverseLookup, destination)
+		}
+		purged++
+	}
+	return purged
+}
+
+// CompactRateLimiters drops the idle per-IP and per-code limiter state and
+// returns how many keys were dropped.
+func (shortener *URLShortener) CompactRateLimiters() int {
+	shortener.mutex.RLock()
+	limiters := []ratelimiter.RateLimiter{shortener.ipLimiter, shortener.codeLimiter}
+	shortener.mutex.RUnlock()
+
+	dropped := 0
+	for _, limiter := range limiters {
+		if compacting, ok := limiter.(compactable); ok {
+			dropped += compacting.Compact()
+		}
+	}
+	return dropped
+}
+
+// StartJanitor purges the trash and compacts the rate limiters every
+// interval in a background goroutine. Calling it while a janitor is
+// running does nothing.
+func (shortener *URLShortener) StartJanitor(interval time.Duration) {
+	shortener.janitor.Start(interval, func(now time.Time) {
+		shortener.PurgeTrash(now)
+		shortener.CompactRateLimiters()
+	})
+}
+
+// StopJanitor stops the background janitor, if running, and waits for a
+// purge in progress to finish.
+func (shortener *URLShortener) StopJanitor() {
+	shortener.janitor.Stop()
+}
+
+// ========== ADMIN QUERY API ==========
+// ListAll returns every entry at once, which does not scale to an admin
+// dashboard. QueryLinks filters (creator, creation date range, status,
+// minimum clicks), sorts and paginates instead. Bulk operations apply to
+// every link a query matches, across all pages:
+// - DisableLinks suspends links (abuse, unpaid account). Unlike Delete they
+//   do not go to the creator's trash, so only an admin can EnableLinks again
+// - ExtendExpiry pushes back the expiry of links that have one
+// Every call requires an admin (see AddAdmin).
+
+const (
+	DefaultPageSize = 20  // Page size when a query does not set one
+	MaxPageSize     = 100 // Largest page a query may request
+)
+
+// LinkStatus is the lifecycle state of a short link, for filtering.
+type LinkStatus int
+
+const (
+	LinkStatusAny      LinkStatus = iota // No status filter
+	LinkStatusActive                     // Resolves
+	LinkStatusExpired                    // Past its ExpiresAt
+	LinkStatusDisabled                   // Suspended by an admin
+	LinkStatusDeleted                    // In the creator's trash
+)
+
+func (status LinkStatus) String() string {
+	names := []string{"any", "active", "expired", "disabled", "deleted"}
+	if status >= 0 && int(status) < len(names) {
+		return names[status]
+	}
+	return "unknown"
+}
+
+// LinkSortField selects the order of query results.
+type LinkSortField int
+
+const (
+	SortByCreatedAt  LinkSortField = iota // Creation time (default)
+	SortByClicks                          // Total clicks
+	SortByLastAccess                      // Most recent click
+	SortByShortCode                       // Alphabetical
+)
+
+// LinkQuery selects short links for the admin API. Zero values mean "no filter".
+type LinkQuery struct {
+	CreatedBy     string     // Only links created by this user
+	CreatedAfter  time.Time  // Only links created at or after this time
+	CreatedBefore time.Time  // Only links created before this time
+	Status        LinkStatus // Only links in this state
+	MinClicks     int64      // Only links with at least this many clicks
+	SortBy        LinkSortField
+	Descending    bool
+	Page          int // 1-based page number (default 1)
+	PageSize      int // Entries per page (default DefaultPageSize, at most MaxPageSize)
+}
+
+// LinkPage is one page of query results.
+type LinkPage struct {
+	Entries    []*URLEntry
+	Page       int
+	PageSize   int
+	Total      int // Links matching the query across all pages
+	TotalPages int
+}
+
+// HasNext reports whether a later page exists.
+func (page *LinkPage) HasNext() bool {
+	return page.Page < page.TotalPages
+}
+
+// statusLocked returns the link's current lifecycle state.
+// Caller must hold shortener.mutex (IsActive and Disabled change under it).
+func (entry *URLEntry) statusLocked() LinkStatus {
+	switch {
+	case !entry.IsActive:
+		return LinkStatusDeleted
+	case entry.Disabled:
+		return LinkStatusDisabled
+	case entry.IsExpired():
+		return LinkStatusExpired
+	default:
+		return LinkStatusActive
+	}
+}
+
+// validate checks the filters and fills in pagination defaults.
+func (query *LinkQuery) validate() error {
+	if query.MinClicks < 0 {
+		return fmt.Errorf("minimum clicks cannot be negative")
+	}
+	if !query.CreatedAfter.IsZero() && !query.CreatedBefore.IsZero() && !query.CreatedAfter.Before(query.CreatedBefore) {
+		return fmt.Errorf("created-after must be before created-before")
+	}
+	if query.Status < LinkStatusAny || query.Status > LinkStatusDeleted {
+		return fmt.Errorf("unknown link status %d", query.Status)
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = DefaultPageSize
+	}
+	if query.PageSize > MaxPageSize {
+		return fmt.Errorf("page size %d exceeds the maximum of %d", query.PageSize, MaxPageSize)
+	}
+	return nil
+}
+
+// matchesLocked reports whether the entry passes every filter of the query.
+// Caller must hold shortener.mutex.
+func (query *LinkQuery) matchesLocked(entry *URLEntry) bool {
+	if query.CreatedBy != "" && entry.CreatedBy != query.CreatedBy {
+		return false
+	}
+	if !query.CreatedAfter.IsZero() && entry.CreatedAt.Before(query.CreatedAfter) {
+		return false
+	}
+	if !query.CreatedBefore.IsZero() && !entry.CreatedAt.Before(query.CreatedBefore) {
+		return false
+	}
+	if query.Status != LinkStatusAny && entry.statusLocked() != query.Status {
+		return false
+	}
+	return entry.GetClickCount() >= query.MinClicks
+}
+
+// lessThan orders two entries by the query's sort field, breaking ties by
+// short code so pages are stable.
+func (query *LinkQuery) lessThan(first, second *URLEntry) bool {
+	var compare int
+	switch query.SortBy {
+	case SortByClicks:
+		compare = int(first.GetClickCount() - second.GetClickCount())
+	case SortByLastAccess:
+		compare = first.getLastAccess().Compare(second.getLastAccess())
+	case SortByShortCode:
+		compare = strings.Compare(first.ShortCode, second.ShortCode)
+	default:
+		compare = first.CreatedAt.Compare(second.CreatedAt)
+	}
+	if compare == 0 {
+		compare = strings.Compare(first.ShortCode, second.ShortCode)
+	}
+	if query.Descending {
+		return compare > 0
+	}
+	return compare < 0
+}
+
+// getLastAccess reads LastAccess, which clicks update under entry.mutex.
+func (entry *URLEntry) getLastAccess() time.Time {
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	return entry.LastAccess
+}
+
+// requireAdminLocked returns a *PermissionError unless userID is an admin.
+// Caller must hold shortener.mutex.
+func (shortener *URLShortener) requireAdminLocked(userID, action string) error {
+	if !shortener.admins[userID] {
+		return &PermissionError{UserID: userID, ShortCode: "links", Action: action}
+	}
+	return nil
+}
+
+// matchingLocked returns every entry the query matches, sorted.
+// Caller must hold shortener.mutex.
+func (shortener *URLShortener) matchingLocked(query *LinkQuery) []*URLEntry {
+	matches := make([]*URLEntry, 0)
+	for _, urlEntry := range shortener.urlDatabase {
+		if query.matchesLocked(urlEntry) {
+			matches = append(matches, urlEntry)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return query.lessThan(matches[i], matches[j])
+	})
+	return matches
+}
+
+// QueryLinks returns one page of the links matching the query.
+func (shortener *URLShortener) QueryLinks(adminID string, query LinkQuery) (*LinkPage, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+
+	shortener.mutex.RLock()
+	defer shortener.mutex.RUnlock()
+
+	if err := shortener.requireAdminLocked(adminID, "query"); err != nil {
+		return nil, err
+	}
+	matches := shortener.matchingLocked(&query)
+
+	page := &LinkPage{
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+		Total:      len(matches),
+		TotalPages: (len(matches) + query.PageSize - 1) / query.PageSize,
+	}
+	start := (query.Page - 1) * query.PageSize
+	if start < len(matches) {
+		end := start + query.PageSize
+		if end > len(matches) {
+			end = len(matches)
+		}
+		page.Entries = matches[start:end]
+	}
+	return page, nil
+}
+
+// DisableLinks suspends every link the query matches (pagination is
+// ignored). Disabled links stop resolving until EnableLinks.
+// Returns how many links were newly disabled.
+func (shortener *URLShortener) DisableLinks(adminID string, query LinkQuery, reason string) (int, error) {
+	query.Page, query.PageSize = 1, MaxPageSize
+	if err := query.validate(); err != nil {
+		return 0, err
+	}
+
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	if err := shortener.requireAdminLocked(adminID, "disable"); err != nil {
+		return 0, err
+	}
+	disabled := 0
+	for _, urlEntry := range shortener.matchingLocked(&query) {
+		if !urlEntry.Disabled {
+			urlEntry.Disabled = true
+			urlEntry.DisabledReason = reason
+			disabled++
+		}
+	}
+	return disabled, nil
+}
+
+// DisableUserLinks suspends every link created by userID.
+func (shortener *URLShortener) DisableUserLinks(adminID, userID, reason string) (int, error) {
+	if userID == "" {
+		return 0, fmt.Errorf("user ID cannot be empty")
+	}
+	return shortener.DisableLinks(adminID, LinkQuery{CreatedBy: userID}, reason)
+}
+
+// EnableLinks lifts the suspension of every disabled link the query matches.
+// Returns how many links were re-enabled.
+func (shortener *URLShortener) EnableLinks(adminID string, query LinkQuery) (int, error) {
+	query.Page, query.PageSize = 1, MaxPageSize
+	if err := query.validate(); err != nil {
+		return 0, err
+	}
+
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	if err := shortener.requireAdminLocked(adminID, "enable"); err != nil {
+		return 0, err
+	}
+	enabled := 0
+	for _, urlEntry := range shortener.matchingLocked(&query) {
+		if urlEntry.Disabled {
+			urlEntry.Disabled = false
+			urlEntry.DisabledReason = ""
+			enabled++
+		}
+	}
+	return enabled, nil
+}
+
+// ExtendExpiry pushes back the expiry of every matching link that has one
+// by extension. Links that never expire are left alone. An expired link
+// resolves again once its new expiry is in the future.
+// Returns how many links were extended.
+func (shortener *URLShortener) ExtendExpiry(adminID string, query LinkQuery, extension time.Duration) (int, error) {
+	if extension <= 0 {
+		return 0, fmt.Errorf("extension must be positive")
+	}
+	query.Page, query.PageSize = 1, MaxPageSize
+	if err := query.validate(); err != nil {
+		return 0, err
+	}
+
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+
+	if err := shortener.requireAdminLocked(adminID, "extend"); err != nil {
+		return 0, err
+	}
+	extended := 0
+	for _, urlEntry := range shortener.matchingLocked(&query) {
+		if urlEntry.ExpiresAt.IsZero() {
+			continue
+		}
+		urlEntry.ExpiresAt = urlEntry.ExpiresAt.Add(extension)
+		extended++
+	}
+	return extended, nil
+}
+
+// ========== BOT FILTERING ==========
+// Crawlers, link-preview fetchers and click farms inflate click counts.
+// Bots are still redirected (blocking them breaks link previews and SEO),
+// but every click is classified so analytics can report humans and bots
+// separately. Detection is a chain of pluggable strategies; the first one
+// that flags a click names the reason:
+// - KnownCrawlerDetector: named crawlers that identify themselves
+//   (Googlebot, Slackbot, facebookexternalhit, ...)
+// - UserAgentDetector: generic heuristics (empty agent, "bot"/"spider",
+//   HTTP libraries and headless browsers)
+// - ClickRateDetector: too many clicks from one IP in a sliding window,
+//   the signature of click fraud with a spoofed browser agent
+
+const (
+	DefaultBotClickLimit  = 20          // Clicks per IP per window before a client counts as a bot
+	DefaultBotClickWindow = time.Minute // Sliding window for DefaultBotClickLimit
+
+	// botSweepInterval is how many observations pass between sweeps that
+	// forget IPs with no clicks inside the window
+	botSweepInterval = 1000
+)
+
+// BotDetector decides whether a click came from a bot.
+// Detect returns the reason (e.g. "crawler:googlebot") when it did.
+type BotDetector interface {
+	Name() string
+	Detect(request RedirectRequest) (string, bool)
+}
+
+// KnownCrawlerDetector matches user agents of crawlers that identify
+// themselves. Tokens are matched case-insensitively.
+type KnownCrawlerDetector struct {
+	crawlers map[string]string // lowercase user-agent token -> crawler name
+	mutex    sync.RWMutex
+}
+
+// NewKnownCrawlerDetector creates a detector preloaded with common search
+// engine, social preview and chat unfurl crawlers.
+func NewKnownCrawlerDetector() *KnownCrawlerDetector {
+	detector := &KnownCrawlerDetector{crawlers: make(map[string]string)}
+	for token, name := range map[string]string{
+		"googlebot":           "googlebot",
+		"bingbot":             "bingbot",
+		"yandexbot":           "yandexbot",
+		"baiduspider":         "baiduspider",
+		"duckduckbot":         "duckduckbot",
+		"applebot":            "applebot",
+		"facebookexternalhit": "facebook",
+		"twitterbot":          "twitter",
+		"linkedinbot":         "linkedin",
+		"slackbot":            "slack",
+		"discordbot":          "discord",
+		"telegrambot":         "telegram",
+		"whatsapp":            "whatsapp",
+	} {
+		detector.crawlers[token] = name
+	}
+	return detector
+}
+
+// AddCrawler registers another crawler by a token of its user agent.
+func (detector *KnownCrawlerDetector) AddCrawler(userAgentToken, name string) {
+	detector.mutex.Lock()
+	defer detector.mutex.Unlock()
+	detector.crawlers[strings.ToLower(userAgentToken)] = name
+}
+
+func (detector *KnownCrawlerDetector) Name() string {
+	return "known-crawler"
+}
+
+func (detector *KnownCrawlerDetector) Detect(request RedirectRequest) (string, bool) {
+	agent := strings.ToLower(request.UserAgent)
+	if agent == "" {
+		return "", false
+	}
+
+	detector.mutex.RLock()
+	defer detector.mutex.RUnlock()
+	for token, name := range detector.crawlers {
+		if strings.Contains(agent, token) {
+			return "crawler:" + name, true
+		}
+	}
+	return "", false
+}
+
+// botUserAgentMarkers are user-agent fragments no human browser sends.
+var botUserAgentMarkers = []string{
+	"bot", "spider", "crawl", "scrape", "headless", "phantomjs", "puppeteer",
+	"curl/", "wget/", "python-requests", "python-urllib", "go-http-client",
+	"java/", "okhttp", "libwww-perl", "httpclient",
+}
+
+// UserAgentDetector flags user agents that are empty or carry a bot marker.
+type UserAgentDetector struct{}
+
+func (detector *UserAgentDetector) Name() string {
+	return "user-agent"
+}
+
+func (detector *UserAgentDetector) Detect(request RedirectRequest) (string, bool) {
+	agent := strings.ToLower(strings.TrimSpace(request.UserAgent))
+	if agent == "" {
+		return "user-agent:empty", true
+	}
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(agent, marker) {
+			return "user-agent:" + strings.TrimSuffix(marker, "/"), true
+		}
+	}
+	return "", false
+}
+
+// ClickRateDetector flags an IP once it has clicked more than maxClicks
+// times within the sliding window. The clicks up to the limit stay human:
+// a burst is only recognisable once it has happened.
+type ClickRateDetector struct {
+	maxClicks    int
+	window       time.Duration
+	clicksByIP   map[string][]time.Time // IP -> click times inside the window, oldest first
+	observations int                    // Clicks seen since the last idle-IP sweep
+	mutex        sync.Mutex
+}
+
+// NewClickRateDetector creates a detector allowing maxClicks per IP per window.
+func NewClickRateDetector(maxClicks int, window time.Duration) (*ClickRateDetector, error) {
+	if maxClicks <= 0 || window <= 0 {
+		return nil, fmt.Errorf("click limit and window must be positive")
+	}
+	return &ClickRateDetector{
+		maxClicks:  maxClicks,
+		window:     window,
+		clicksByIP: make(map[string][]time.Time),
+	}, nil
+}
+
+func (detector *ClickRateDetector) Name() string {
+	return "click-rate"
+}
+
+// Detect records the click and reports whether the IP is over the limit.
+// Clicks without an IP cannot be attributed and are never flagged.
+func (detector *ClickRateDetector) Detect(request RedirectRequest) (string, bool) {
+	if request.IPAddress == "" {
+		return "", false
+	}
+
+	detector.mutex.Lock()
+	defer detector.mutex.Unlock()
+
+	windowStart := request.Time.Add(-detector.window)
+	clicks := pruneClicksBefore(detector.clicksByIP[request.IPAddress], windowStart)
+	clicks = append(clicks, request.Time)
+	detector.clicksByIP[request.IPAddress] = clicks
+
+	detector.observations++
+	if detector.observations >= botSweepInterval {
+		detector.observations = 0
+		for ipAddress, ipClicks := range detector.clicksByIP {
+			if remaining := pruneClicksBefore(ipClicks, windowStart); len(remaining) == 0 {
+				delete(detector.clicksByIP, ipAddress)
+			} else {
+				detector.clicksByIP[ipAddress] = remaining
+			}
+		}
+	}
+
+	if len(clicks) > detector.maxClicks {
+		return fmt.Sprintf("click-rate:>%d/%v", detector.maxClicks, detector.window), true
+	}
+	return "", false
+}
+
+// pruneClicksBefore drops click times before cutoff from a sorted slice.
+func pruneClicksBefore(clicks []time.Time, cutoff time.Time) []time.Time {
+	firstKept := sort.Search(len(clicks), func(index int) bool {
+		return !clicks[index].Before(cutoff)
+	})
+	return clicks[firstKept:]
+}
+
+// BotVerdict is the classification of one click.
+type BotVerdict struct {
+	IsBot  bool
+	Reason string // Detector reason when IsBot (e.g. "crawler:slack")
+}
+
+// BotFilter runs detectors in order; the first match classifies the click.
+type BotFilter struct {
+	detectors []BotDetector
+}
+
+// NewBotFilter creates a filter from the given detectors, in order.
+func NewBotFilter(detectors ...BotDetector) *BotFilter {
+	return &BotFilter{detectors: detectors}
+}
+
+// NewDefaultBotFilter checks known crawlers, then user-agent heuristics,
+// then the per-IP click rate (DefaultBotClickLimit per DefaultBotClickWindow).
+func NewDefaultBotFilter() *BotFilter {
+	rateDetector, _ := NewClickRateDetector(DefaultBotClickLimit, DefaultBotClickWindow)
+	return NewBotFilter(NewKnownCrawlerDetector(), &UserAgentDetector{}, rateDetector)
+}
+
+// Classify returns the verdict for a click.
+func (filter *BotFilter) Classify(request RedirectRequest) BotVerdict {
+	if filter == nil {
+		return BotVerdict{}
+	}
+	for _, detector := range filter.detectors {
+		if reason, isBot := detector.Detect(request); isBot {
+			return BotVerdict{IsBot: true, Reason: reason}
+		}
+	}
+	return BotVerdict{}
+}
+
+// ClickReport splits a short code's clicks into humans and bots.
+type ClickReport struct {
+	ShortCode  string
+	Total      int
+	Human      int
+	Bot        int
+	BotReasons map[string]int // Bot clicks per detector reason
+}
+
+// GetBotShare returns the fraction of clicks that came from bots.
+func (report ClickReport) GetBotShare() float64 {
+	if report.Total == 0 {
+		return 0
+	}
+	return float64(report.Bot) / float64(report.Total)
+}
+
+// GetClickReport returns the human/bot split for a short code.
+func (analytics *Analytics) GetClickReport(shortCode string) ClickReport {
+	analytics.mutex.Lock()
+	defer analytics.mutex.Unlock()
+
+	report := ClickReport{ShortCode: shortCode, BotReasons: make(map[string]int)}
+	for _, clickEvent := range analytics.clickEvents {
+		if clickEvent.ShortCode != shortCode {
+			continue
+		}
+		report.Total++
+		if clickEvent.IsBot {
+			report.Bot++
+			report.BotReasons[clickEvent.BotReason]++
+		} else {
+			report.Human++
+		}
+	}
+	return report
+}
+
+// SetBotFilter configures how clicks are classified. Pass nil to count
+// every click as human. Bots are redirected either way.
+func (shortener *URLShortener) SetBotFilter(filter *BotFilter) {
+	shortener.mutex.Lock()
+	defer shortener.mutex.Unlock()
+	shortener.botFilter = filter
+}
+
+// GetClickReport returns the human/bot click split for a short code.
+func (shortener *URLShortener) GetClickReport(shortCode string) ClickReport {
+	return shortener.analyticsTracker.GetClickReport(shortCode)
+}
@@ -0,0 +1,3 @@
+module github.com/ayushgupta5/GoLLD
+
+go 1.22
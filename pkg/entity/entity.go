@@ -0,0 +1,23 @@
+// Package entity holds the people the booking and checkout systems share.
+// A hotel Guest, a rental Customer and a store Customer all embed Person and
+// add only the details their own domain needs.
+package entity
+
+// Person is someone the business can identify and contact.
+type Person struct {
+	id    string // Unique identifier (e.g., "G001")
+	name  string // Full name
+	email string // Contact email
+	phone string // Contact phone number ("" if not collected)
+}
+
+// NewPerson creates a Person with the given contact details.
+func NewPerson(id, name, email, phone string) Person {
+	return Person{id: id, name: name, email: email, phone: phone}
+}
+
+// Getter methods for Person fields
+func (person *Person) GetID() string    { return person.id }
+func (person *Person) GetName() string  { return person.name }
+func (person *Person) GetEmail() string { return person.email }
+func (person *Person) GetPhone() string { return person.phone }
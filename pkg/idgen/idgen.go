@@ -0,0 +1,29 @@
+// Package idgen hands out the human-readable, prefixed IDs ("BK-1", "ORD-2")
+// that the booking and checkout systems print in their receipts and logs.
+package idgen
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Sequence generates IDs of the form "<prefix>-<n>" with n counting up from 1.
+// It is safe for concurrent use. In production, use a database sequence.
+type Sequence struct {
+	prefix  string
+	counter int
+	mutex   sync.Mutex
+}
+
+// NewSequence creates a sequence whose first ID is "<prefix>-1".
+func NewSequence(prefix string) *Sequence {
+	return &Sequence{prefix: prefix}
+}
+
+// NextID returns the next unique ID in the sequence.
+func (seq *Sequence) NextID() string {
+	seq.mutex.Lock()
+	defer seq.mutex.Unlock()
+	seq.counter++
+	return fmt.Sprintf("%s-%d", seq.prefix, seq.counter)
+}
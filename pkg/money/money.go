@@ -0,0 +1,67 @@
+// Package money holds the currency types and amount helpers the booking and
+// checkout systems share. Amounts are float64 in major units (dollars, not
+// cents), rounded to cents wherever a value is charged or shown.
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Currency is an ISO 4217 currency code, e.g. "USD".
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	INR Currency = "INR"
+)
+
+// symbols holds display symbols for the currencies the systems know.
+var symbols = map[Currency]string{
+	USD: "$",
+	EUR: "€",
+	GBP: "£",
+	INR: "₹",
+}
+
+// Symbol returns the display symbol, or the code itself for unknown currencies.
+func (currency Currency) Symbol() string {
+	if symbol, known := symbols[currency]; known {
+		return symbol
+	}
+	return string(currency) + " "
+}
+
+// Format renders an amount in this currency, e.g. "€12.50".
+func (currency Currency) Format(amount float64) string {
+	return fmt.Sprintf("%s%.2f", currency.Symbol(), amount)
+}
+
+// Money is an amount together with the currency it is expressed in.
+type Money struct {
+	Amount   float64
+	Currency Currency
+}
+
+// String formats the amount, e.g. "£8.00".
+func (value Money) String() string {
+	return value.Currency.Format(value.Amount)
+}
+
+// Round rounds an amount to whole cents.
+func Round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// Format renders a US dollar amount, e.g. "$12.50".
+func Format(amount float64) string {
+	return USD.Format(amount)
+}
+
+// FormatPlain renders an amount with two decimals and no symbol, e.g. for CSV.
+func FormatPlain(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,68 @@
+// Package periodic runs the background jobs (hold expiry, janitors,
+// schedulers) that the systems start and stop around their demos.
+package periodic
+
+import (
+	"sync"
+	"time"
+)
+
+// Job runs a task every interval on its own goroutine until stopped.
+// The zero value is a stopped job, ready to start. It is safe for
+// concurrent use.
+type Job struct {
+	stop    chan struct{}  // Closed to stop the goroutine (nil when not running)
+	running sync.WaitGroup // Counts the goroutine until it has returned
+	mutex   sync.Mutex     // Serializes Start and Stop
+}
+
+// Start runs task every interval, passing the tick time.
+// Returns false and does nothing if the job is already running.
+func (job *Job) Start(interval time.Duration, task func(now time.Time)) bool {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.stop != nil {
+		return false
+	}
+
+	stop := make(chan struct{})
+	job.stop = stop
+	job.running.Add(1)
+	go func() {
+		defer job.running.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				task(now)
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return true
+}
+
+// Stop stops the job and waits for a task that is running to finish, so
+// nothing runs in the background once it returns. Does nothing if the job
+// isn't running. Must not be called from the task itself.
+func (job *Job) Stop() {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.stop == nil {
+		return
+	}
+
+	close(job.stop)
+	job.stop = nil
+	job.running.Wait()
+}
+
+// IsRunning reports whether the job has been started and not stopped.
+func (job *Job) IsRunning() bool {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.stop != nil
+}